@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"panda-pocket/internal/infrastructure/anonymize"
+	"panda-pocket/internal/infrastructure/config"
+	"panda-pocket/internal/infrastructure/database"
+)
+
+const usage = `Usage: anonymize --confirm [--password <password>] [--seed <n>]
+
+Scrubs personal data in the configured database, in place, so a copy of
+production can be used for staging. Run it against the copy, never against
+production itself.
+
+  --confirm              required; the scrub cannot be undone
+  --password <password>  password given to every user (default "staging")
+  --seed <n>             seeds the amount scaling, for repeatable scrubs
+
+Emails become user<id>@example.invalid, descriptions and other free text are
+replaced by labels, amounts are scaled per user with a little noise per row,
+locations are dropped, and notifications, webhooks, queued emails, undo history
+and export schedules are deleted.`
+
+func main() {
+	opts, err := parseArgs(os.Args[1:])
+	if err != nil {
+		fmt.Println(err)
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Failed to load configuration:", err)
+	}
+
+	db, err := database.Connect(cfg.Database)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatal("Failed to get underlying sql.DB:", err)
+	}
+	defer sqlDB.Close()
+
+	report, err := anonymize.NewAnonymizer(db).Run(context.Background(), opts)
+	if err != nil {
+		log.Fatal("Failed to anonymize the database:", err)
+	}
+
+	printCounts("Scrubbed", report.Scrubbed)
+	printCounts("Deleted", report.Deleted)
+}
+
+// parseArgs reads the command line, refusing to run without --confirm
+func parseArgs(args []string) (anonymize.Options, error) {
+	opts := anonymize.Options{Password: "staging", Seed: time.Now().UnixNano()}
+	confirmed := false
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--confirm":
+			confirmed = true
+		case "--password":
+			if i+1 >= len(args) || args[i+1] == "" {
+				return opts, fmt.Errorf("--password requires a value")
+			}
+			i++
+			opts.Password = args[i]
+		case "--seed":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("--seed requires a value")
+			}
+			i++
+			seed, err := strconv.ParseInt(args[i], 10, 64)
+			if err != nil {
+				return opts, fmt.Errorf("invalid seed %q", args[i])
+			}
+			opts.Seed = seed
+		default:
+			return opts, fmt.Errorf("unknown argument %q", args[i])
+		}
+	}
+
+	if !confirmed {
+		return opts, fmt.Errorf("refusing to scrub without --confirm")
+	}
+	return opts, nil
+}
+
+// printCounts prints the row count per table, in table order
+func printCounts(verb string, counts map[string]int64) {
+	tables := make([]string, 0, len(counts))
+	for table := range counts {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	for _, table := range tables {
+		fmt.Printf("%s %d rows in %s\n", verb, counts[table], table)
+	}
+}
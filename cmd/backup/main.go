@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"panda-pocket/internal/infrastructure/backup"
+	"panda-pocket/internal/infrastructure/config"
+	"panda-pocket/internal/infrastructure/database"
+)
+
+const usage = `Usage: backup <command>
+
+Commands:
+  create [user-id]          back up the whole database, or one user's data
+  list                      list stored backups, oldest first
+  restore <name> [--force]  restore a backup; --force replaces a non-empty database
+  prune <n>                 delete all but the newest n full backups
+
+Backups are written to BACKUP_DIR, or to BACKUP_S3_BUCKET when BACKUP_STORAGE=s3.`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Failed to load configuration:", err)
+	}
+
+	db, err := database.Connect(cfg.Database)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatal("Failed to get underlying sql.DB:", err)
+	}
+	defer sqlDB.Close()
+
+	ctx := context.Background()
+	store, err := backup.NewStore(ctx, cfg.Backup)
+	if err != nil {
+		log.Fatal("Failed to open backup storage:", err)
+	}
+
+	if err := run(ctx, backup.NewService(db, store), os.Args[1], os.Args[2:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run executes a single backup command
+func run(ctx context.Context, service *backup.Service, command string, args []string) error {
+	switch command {
+	case "create":
+		var userID *uint
+		if len(args) > 0 {
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil || id == 0 {
+				return fmt.Errorf("invalid user ID %q", args[0])
+			}
+			uid := uint(id)
+			userID = &uid
+		}
+		name, err := service.Create(ctx, userID)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Created backup %s\n", name)
+
+	case "list":
+		names, err := service.List(ctx)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			fmt.Println(name)
+		}
+
+	case "restore":
+		if len(args) == 0 {
+			return fmt.Errorf("restore requires a backup name")
+		}
+		force := len(args) > 1 && args[1] == "--force"
+		if err := service.Restore(ctx, args[0], force); err != nil {
+			return err
+		}
+		fmt.Printf("Restored backup %s\n", args[0])
+
+	case "prune":
+		if len(args) == 0 {
+			return fmt.Errorf("prune requires the number of backups to keep")
+		}
+		retain, err := strconv.Atoi(args[0])
+		if err != nil || retain < 0 {
+			return fmt.Errorf("invalid number of backups %q", args[0])
+		}
+		deleted, err := service.Prune(ctx, retain)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Deleted %d backups\n", deleted)
+
+	default:
+		return fmt.Errorf("unknown command %q\n%s", command, usage)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"panda-pocket/internal/infrastructure/config"
+	"panda-pocket/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+const usage = `Usage: migrate <command>
+
+Commands:
+  up            apply all pending migrations
+  down [n]      roll back the last n migrations (default 1)
+  status        list migrations and whether they are applied
+  check         verify the schema matches the GORM models
+  force <v>     set the schema version without running migrations`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Failed to load configuration:", err)
+	}
+
+	db, err := database.Connect(cfg.Database)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatal("Failed to get underlying sql.DB:", err)
+	}
+	defer sqlDB.Close()
+
+	migrator, err := database.NewMigrator(sqlDB, db.Dialector.Name())
+	if err != nil {
+		log.Fatal("Failed to load migrations:", err)
+	}
+
+	if err := run(context.Background(), db, migrator, os.Args[1], os.Args[2:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run executes a single migrate command
+func run(ctx context.Context, db *gorm.DB, migrator *database.Migrator, command string, args []string) error {
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Applied %d migrations\n", applied)
+
+	case "down":
+		steps := 1
+		if len(args) > 0 {
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid number of steps %q", args[0])
+			}
+			steps = n
+		}
+		rolledBack, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Rolled back %d migrations\n", rolledBack)
+
+	case "status":
+		version, dirty, err := migrator.Version(ctx)
+		if err != nil {
+			return err
+		}
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Current version: %d (dirty: %t)\n", version, dirty)
+		for _, status := range statuses {
+			state := "pending"
+			if status.Applied {
+				state = "applied"
+			}
+			fmt.Printf("  %06d_%s\t%s\n", status.Version, status.Name, state)
+		}
+
+	case "check":
+		if err := database.VerifySchema(db); err != nil {
+			return err
+		}
+		fmt.Println("Schema matches the models")
+
+	case "force":
+		if len(args) == 0 {
+			return fmt.Errorf("force requires a version")
+		}
+		version, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[0])
+		}
+		if err := migrator.Force(ctx, uint(version)); err != nil {
+			return err
+		}
+		fmt.Printf("Forced schema version to %d\n", version)
+
+	default:
+		return fmt.Errorf("unknown command %q\n%s", command, usage)
+	}
+	return nil
+}
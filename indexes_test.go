@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"panda-pocket/internal/domain/finance"
+	"panda-pocket/internal/infrastructure/config"
+	"panda-pocket/internal/infrastructure/database"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// queryRecorder captures the SQL GORM executes
+type queryRecorder struct {
+	logger.Interface
+	queries []string
+}
+
+func (r *queryRecorder) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	query, _ := fc()
+	r.queries = append(r.queries, query)
+}
+
+func TestTransactionFilterIndexes(t *testing.T) {
+	// Migrated in-memory SQLite database
+	cfg := config.Default().Database
+	cfg.Type = "sqlite"
+	cfg.Path = ":memory:"
+	db, err := database.Connect(cfg)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("Failed to get sql.DB: %v", err)
+	}
+	migrator, err := database.NewMigrator(sqlDB, db.Dialector.Name())
+	if err != nil {
+		t.Fatalf("Failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	// Record the queries issued by the filtered transaction list
+	recorder := &queryRecorder{Interface: logger.Discard}
+	repo := database.NewGormTransactionRepository(db.Session(&gorm.Session{Logger: recorder}))
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	filters := []finance.TransactionFilters{
+		{StartDate: &start, EndDate: &end, Limit: 20},
+		{StartDate: &start, EndDate: &end, CategoryIDs: []finance.CategoryID{finance.NewCategoryID(1)}, Limit: 20},
+	}
+	for _, f := range filters {
+		_, _, err := repo.FindByUserIDWithFilters(context.Background(), finance.NewUserID(1), f)
+		assert.NoError(t, err)
+	}
+
+	// Every expense/income query must be served by one of the composite indexes
+	checked := 0
+	for _, query := range recorder.queries {
+		if !strings.Contains(query, "FROM `expenses`") && !strings.Contains(query, "FROM `incomes`") {
+			continue
+		}
+		checked++
+
+		var plan []struct {
+			ID      int
+			Parent  int
+			Notused int
+			Detail  string
+		}
+		assert.NoError(t, db.Raw("EXPLAIN QUERY PLAN "+query).Scan(&plan).Error)
+
+		usesComposite := false
+		for _, step := range plan {
+			if strings.Contains(step.Detail, "_user_date") || strings.Contains(step.Detail, "_user_category_date") {
+				usesComposite = true
+			}
+		}
+		assert.True(t, usesComposite, "query does not use a composite index: %s\nplan: %+v", query, plan)
+	}
+	assert.NotZero(t, checked, "no transaction queries recorded")
+}
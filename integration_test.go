@@ -0,0 +1,5346 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"panda-pocket/internal/application"
+	appFinance "panda-pocket/internal/application/finance"
+	appIdentity "panda-pocket/internal/application/identity"
+	appNotification "panda-pocket/internal/application/notification"
+	"panda-pocket/internal/domain/finance"
+	"panda-pocket/internal/domain/identity"
+	"panda-pocket/internal/infrastructure/anonymize"
+	"panda-pocket/internal/infrastructure/captcha"
+	"panda-pocket/internal/infrastructure/config"
+	"panda-pocket/internal/infrastructure/database"
+	"panda-pocket/internal/infrastructure/encryption"
+	"panda-pocket/internal/infrastructure/events"
+	"panda-pocket/internal/infrastructure/mail"
+	"panda-pocket/internal/infrastructure/metrics"
+	"panda-pocket/internal/infrastructure/ratelimit"
+	"panda-pocket/internal/infrastructure/realtime"
+	"panda-pocket/internal/infrastructure/webhook"
+	"panda-pocket/internal/interfaces/http/handlers"
+	"panda-pocket/internal/interfaces/http/middleware"
+	"panda-pocket/internal/testsupport"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+	"gorm.io/gorm"
+)
+
+// Integration tests run against an in-memory SQLite database by default;
+// set TEST_DB=postgres to run them against a Postgres container instead.
+
+func TestTransactionRepositoryIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	repo := database.NewGormTransactionRepository(db)
+	ctx := context.Background()
+	userID := finance.NewUserID(int(fixtures.User.ID))
+
+	jan := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)
+	january := fixtures.AddExpense(t, db, 10, jan)
+	fixtures.AddExpense(t, db, 20, feb)
+	fixtures.AddIncome(t, db, 100, feb)
+	require.NoError(t, db.Model(&database.Expense{}).Where("id = ?", january.ID).
+		Updates(map[string]interface{}{"client_id": "0d6c7f5e-4a39-4c3e-9f4b-2f1f7d3b8a10", "version": 3}).Error)
+
+	t.Run("save assigns an ID", func(t *testing.T) {
+		amount, err := finance.NewMoney(5, finance.NewCurrencyID(int(fixtures.Currency.ID)))
+		require.NoError(t, err)
+		transaction := finance.NewTransaction(
+			finance.TransactionID{},
+			finance.NewUserID(int(fixtures.Admin.ID)),
+			finance.NewCategoryID(int(fixtures.ExpenseCategory.ID)),
+			finance.NewCurrencyID(int(fixtures.Currency.ID)),
+			amount,
+			"coffee",
+			jan,
+			finance.TransactionTypeExpense,
+		)
+		require.NoError(t, repo.Save(ctx, transaction))
+		assert.NotZero(t, transaction.ID().Value())
+	})
+
+	t.Run("filters by type and date", func(t *testing.T) {
+		expense := finance.TransactionTypeExpense
+		start := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+		transactions, total, err := repo.FindByUserIDWithFilters(ctx, userID, finance.TransactionFilters{
+			TransactionType: &expense,
+			StartDate:       &start,
+			Limit:           10,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		require.Len(t, transactions, 1)
+		assert.Equal(t, 20.0, transactions[0].Amount().Amount())
+	})
+
+	t.Run("paginates across expenses and incomes", func(t *testing.T) {
+		transactions, total, err := repo.FindByUserIDWithFilters(ctx, userID, finance.TransactionFilters{Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		assert.Len(t, transactions, 2)
+	})
+
+	t.Run("archived transactions are hidden unless requested", func(t *testing.T) {
+		archived, err := repo.ArchiveBefore(ctx, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, 2, archived) // the fixture user's and the admin's January expenses
+
+		_, total, err := repo.FindByUserIDWithFilters(ctx, userID, finance.TransactionFilters{Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+
+		_, total, err = repo.FindByUserIDWithFilters(ctx, userID, finance.TransactionFilters{Limit: 10, IncludeArchived: true})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+	})
+
+	t.Run("archived transactions keep their client ID and version", func(t *testing.T) {
+		var archived database.ArchivedExpense
+		require.NoError(t, db.First(&archived, january.ID).Error)
+		require.NotNil(t, archived.ClientID)
+		assert.Equal(t, "0d6c7f5e-4a39-4c3e-9f4b-2f1f7d3b8a10", *archived.ClientID)
+		assert.Equal(t, 3, archived.Version)
+	})
+}
+
+func TestFinanceHandlersIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	t.Run("login returns a token", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, "/api/v120/auth/login", "", map[string]string{
+			"email":    fixtures.User.Email,
+			"password": testsupport.FixturePassword,
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+
+	t.Run("requires authentication", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v120/transactions", "", nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("created expenses are listed", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, "/api/v100/expenses", token, appFinance.CreateTransactionRequest{
+			CategoryID:  int(fixtures.ExpenseCategory.ID),
+			Amount:      12.5,
+			Description: "lunch",
+			Date:        "2024-03-01",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodGet, "/api/v100/transactions?type=expense", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var list appFinance.GetAllTransactionsResponse
+		testsupport.DecodeData(t, w, &list)
+		assert.Equal(t, int64(1), list.Total)
+		require.Len(t, list.Transactions, 1)
+		assert.Equal(t, "lunch", list.Transactions[0].Description)
+	})
+
+	t.Run("lists webhook event types", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v100/webhooks/events", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var catalog struct {
+			Events []struct {
+				Name   string                 `json:"name"`
+				Sample map[string]interface{} `json:"sample_payload"`
+			} `json:"events"`
+		}
+		testsupport.DecodeData(t, w, &catalog)
+		require.NotEmpty(t, catalog.Events)
+		assert.Equal(t, finance.EventTransactionCreated, catalog.Events[0].Name)
+		assert.Contains(t, catalog.Events[0].Sample, "transaction_id")
+	})
+
+	t.Run("search ranks results of every type", func(t *testing.T) {
+		for _, description := range []string{"Starbucks", "starbucks", "Coffee at Starbucks"} {
+			w := server.Do(t, http.MethodPost, "/api/v100/expenses", token, appFinance.CreateTransactionRequest{
+				CategoryID:  int(fixtures.ExpenseCategory.ID),
+				Amount:      4.5,
+				Description: description,
+				Date:        "2024-03-02",
+			})
+			require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		}
+
+		w := server.Do(t, http.MethodGet, "/api/v100/search?q=starbucks", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appFinance.SearchResponse
+		testsupport.DecodeData(t, w, &response)
+		require.Len(t, response.Results, 5) // two merchants and three transactions
+		assert.Equal(t, appFinance.SearchResultMerchant, response.Results[0].Type)
+		assert.Equal(t, "2 transactions", response.Results[0].Subtitle)
+		assert.Equal(t, appFinance.SearchResultTransaction, response.Results[4].Type)
+		assert.Equal(t, "Coffee at Starbucks", response.Results[4].Title)
+
+		w = server.Do(t, http.MethodGet, "/api/v100/search?q="+url.QueryEscape(fixtures.ExpenseCategory.Name), token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		testsupport.DecodeData(t, w, &response)
+		require.NotEmpty(t, response.Results)
+		assert.Equal(t, appFinance.SearchResultCategory, response.Results[0].Type)
+		assert.Equal(t, int(fixtures.ExpenseCategory.ID), response.Results[0].ID)
+	})
+
+	t.Run("search needs at least two characters", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v100/search?q=s", token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("admin routes require the admin role", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/admin/version-usage", token, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = server.Do(t, http.MethodGet, "/api/admin/version-usage", server.Token(t, fixtures.Admin), nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestAdminAPIIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	adminToken := server.Token(t, fixtures.Admin)
+	userToken := server.Token(t, fixtures.User)
+	fixtures.AddExpense(t, db, 10, time.Now())
+
+	t.Run("lists and searches users", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/admin/users?limit=1", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var page struct {
+			Users      []appIdentity.AdminUserResponse `json:"users"`
+			Pagination struct {
+				Total      int64 `json:"total"`
+				TotalPages int   `json:"total_pages"`
+			} `json:"pagination"`
+		}
+		testsupport.DecodeData(t, w, &page)
+		assert.Len(t, page.Users, 1)
+		assert.Equal(t, int64(2), page.Pagination.Total)
+		assert.Equal(t, 2, page.Pagination.TotalPages)
+
+		w = server.Do(t, http.MethodGet, "/api/admin/users?search="+fixtures.User.Email[:4], adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		testsupport.DecodeData(t, w, &page)
+		require.Len(t, page.Users, 1)
+		assert.Equal(t, fixtures.User.Email, page.Users[0].Email)
+	})
+
+	t.Run("reports per-user usage", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, fmt.Sprintf("/api/admin/users/%d/usage", fixtures.User.ID), adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var usage appIdentity.UserUsageResponse
+		testsupport.DecodeData(t, w, &usage)
+		assert.Equal(t, 1, usage.TransactionCount)
+		assert.True(t, usage.User.Active)
+	})
+
+	t.Run("deactivated users are locked out", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, fmt.Sprintf("/api/admin/users/%d/deactivate", fixtures.User.ID), adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodGet, "/api/transactions", userToken, nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		w = server.Do(t, http.MethodPost, "/api/auth/login", "", map[string]string{
+			"email":    fixtures.User.Email,
+			"password": testsupport.FixturePassword,
+		})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = server.Do(t, http.MethodPost, fmt.Sprintf("/api/admin/users/%d/reactivate", fixtures.User.ID), adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodGet, "/api/transactions", userToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("admins cannot deactivate themselves", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, fmt.Sprintf("/api/admin/users/%d/deactivate", fixtures.Admin.ID), adminToken, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("requires the admin role", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/admin/users", userToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("is served once under /api/admin rather than per version", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/admin/dashboard/stats", adminToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodGet, "/api/v120/admin/users", adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestFeatureFlagsIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	adminToken := server.Token(t, fixtures.Admin)
+	userToken := server.Token(t, fixtures.User)
+
+	features := func(t *testing.T) []string {
+		w := server.Do(t, http.MethodGet, "/api/features", userToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var body struct {
+			Features []string `json:"features"`
+		}
+		testsupport.DecodeData(t, w, &body)
+		return body.Features
+	}
+
+	t.Run("flags are off until set", func(t *testing.T) {
+		assert.Empty(t, features(t))
+	})
+
+	t.Run("flags can target listed users", func(t *testing.T) {
+		w := server.Do(t, http.MethodPut, "/api/admin/feature-flags/bank_sync_beta", adminToken, map[string]interface{}{
+			"user_ids": []uint{fixtures.User.ID},
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, []string{"bank_sync_beta"}, features(t))
+		assert.False(t, server.App.FeatureFlags.IsEnabled(context.Background(), "bank_sync_beta", int(fixtures.Admin.ID)))
+	})
+
+	t.Run("full rollout turns a flag on for everyone", func(t *testing.T) {
+		w := server.Do(t, http.MethodPut, "/api/admin/feature-flags/bank_sync_beta", adminToken, map[string]interface{}{
+			"rollout_percentage": 100,
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.True(t, server.App.FeatureFlags.IsEnabled(context.Background(), "bank_sync_beta", int(fixtures.Admin.ID)))
+	})
+
+	t.Run("invalid flags are rejected", func(t *testing.T) {
+		w := server.Do(t, http.MethodPut, "/api/admin/feature-flags/Bad%20Key", adminToken, map[string]interface{}{})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = server.Do(t, http.MethodPut, "/api/admin/feature-flags/analytics_v2", adminToken, map[string]interface{}{
+			"rollout_percentage": 150,
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("deleted flags are off", func(t *testing.T) {
+		w := server.Do(t, http.MethodDelete, "/api/admin/feature-flags/bank_sync_beta", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Empty(t, features(t))
+
+		w = server.Do(t, http.MethodDelete, "/api/admin/feature-flags/bank_sync_beta", adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("requires the admin role", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/admin/feature-flags", userToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestAnnouncementsIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	adminToken := server.Token(t, fixtures.Admin)
+	userToken := server.Token(t, fixtures.User)
+
+	notifications := func(t *testing.T, token string) appNotification.GetNotificationsResponse {
+		w := server.Do(t, http.MethodGet, "/api/notifications", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appNotification.GetNotificationsResponse
+		testsupport.DecodeData(t, w, &response)
+		return response
+	}
+
+	t.Run("announcements reach the selected segment", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, "/api/admin/announcements", adminToken, appNotification.BroadcastAnnouncementRequest{
+			Title:     "Planned maintenance",
+			Message:   "PandaPocket will be unavailable on Sunday from 02:00 to 03:00 UTC.",
+			Role:      "user",
+			SendEmail: true,
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var response appNotification.BroadcastAnnouncementResponse
+		testsupport.DecodeData(t, w, &response)
+		assert.Equal(t, 1, response.Recipients)
+		assert.Equal(t, 1, response.EmailRecipients)
+
+		assert.Equal(t, 1, notifications(t, userToken).UnreadCount)
+		assert.Empty(t, notifications(t, adminToken).Notifications)
+	})
+
+	t.Run("notifications can be marked as read", func(t *testing.T) {
+		list := notifications(t, userToken)
+		require.Len(t, list.Notifications, 1)
+		id := list.Notifications[0].ID
+
+		w := server.Do(t, http.MethodPut, fmt.Sprintf("/api/notifications/%d/read", id), adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = server.Do(t, http.MethodPut, fmt.Sprintf("/api/notifications/%d/read", id), userToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, 0, notifications(t, userToken).UnreadCount)
+	})
+
+	t.Run("announcements need a title and message", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, "/api/admin/announcements", adminToken, map[string]string{"title": "Empty"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("requires the admin role", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, "/api/admin/announcements", userToken, appNotification.BroadcastAnnouncementRequest{
+			Title:   "Hi",
+			Message: "Hello",
+		})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestNotificationChannelsIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	adminToken := server.Token(t, fixtures.Admin)
+	userToken := server.Token(t, fixtures.User)
+
+	var created appNotification.ChannelResponse
+	t.Run("creates a channel", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, "/api/notification-channels", userToken, appNotification.CreateChannelRequest{
+			Kind:       "discord",
+			Name:       "Family budget",
+			WebhookURL: "https://discord.com/api/webhooks/123/abc",
+			Types:      []string{"budget_exceeded"},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		testsupport.DecodeData(t, w, &created)
+		assert.NotZero(t, created.ID)
+		assert.Equal(t, []string{"budget_exceeded"}, created.Types)
+
+		w = server.Do(t, http.MethodGet, "/api/notification-channels", userToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response struct {
+			Channels []appNotification.ChannelResponse `json:"channels"`
+		}
+		testsupport.DecodeData(t, w, &response)
+		require.Len(t, response.Channels, 1)
+		assert.Equal(t, "Family budget", response.Channels[0].Name)
+	})
+
+	t.Run("rejects webhooks on other hosts", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, "/api/notification-channels", userToken, appNotification.CreateChannelRequest{
+			Kind:       "slack",
+			Name:       "Elsewhere",
+			WebhookURL: "https://example.com/hook",
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("rejects unknown notification types", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, "/api/notification-channels", userToken, appNotification.CreateChannelRequest{
+			Kind:       "slack",
+			Name:       "Personal",
+			WebhookURL: "https://hooks.slack.com/services/T0/B0/x",
+			Types:      []string{"weather"},
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("only the owner can delete a channel", func(t *testing.T) {
+		path := fmt.Sprintf("/api/notification-channels/%d", created.ID)
+		w := server.Do(t, http.MethodDelete, path, adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = server.Do(t, http.MethodDelete, path, userToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+}
+
+func TestUndoIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	userToken := server.Token(t, fixtures.User)
+	adminToken := server.Token(t, fixtures.Admin)
+
+	expense := fixtures.AddExpense(t, db, 12.5, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	expensePath := fmt.Sprintf("/api/v100/expenses/%d", expense.ID)
+
+	actions := func(t *testing.T) []appFinance.ActionResponse {
+		w := server.Do(t, http.MethodGet, "/api/v100/actions", userToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response struct {
+			Actions []appFinance.ActionResponse `json:"actions"`
+		}
+		testsupport.DecodeData(t, w, &response)
+		return response.Actions
+	}
+	undo := func(t *testing.T, token string, id int) int {
+		return server.Do(t, http.MethodPost, fmt.Sprintf("/api/v100/actions/%d/undo", id), token, nil).Code
+	}
+	update := func(t *testing.T, amount float64) {
+		w := server.Do(t, http.MethodPut, expensePath, userToken, map[string]interface{}{
+			"category_id": fixtures.ExpenseCategory.ID,
+			"amount":      amount,
+			"description": "groceries",
+			"date":        "2024-03-01",
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	}
+	amount := func(t *testing.T) float64 {
+		var model database.Expense
+		require.NoError(t, db.First(&model, expense.ID).Error)
+		return model.Amount
+	}
+
+	t.Run("updates are undone newest first", func(t *testing.T) {
+		update(t, 20)
+		update(t, 30)
+
+		recent := actions(t)
+		require.Len(t, recent, 2)
+		assert.Equal(t, "update", recent[0].Kind)
+		assert.Equal(t, int(expense.ID), recent[0].TargetID)
+
+		assert.Equal(t, http.StatusConflict, undo(t, userToken, recent[1].ID))
+		assert.Equal(t, http.StatusNotFound, undo(t, adminToken, recent[0].ID))
+
+		require.Equal(t, http.StatusOK, undo(t, userToken, recent[0].ID))
+		assert.Equal(t, 20.0, amount(t))
+		require.Equal(t, http.StatusOK, undo(t, userToken, recent[1].ID))
+		assert.Equal(t, 12.5, amount(t))
+
+		assert.Equal(t, http.StatusConflict, undo(t, userToken, recent[0].ID))
+		assert.Empty(t, actions(t))
+	})
+
+	t.Run("a deleted transaction is restored with its ID", func(t *testing.T) {
+		w := server.Do(t, http.MethodDelete, expensePath, userToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		recent := actions(t)
+		require.Len(t, recent, 1)
+		assert.Equal(t, "delete", recent[0].Kind)
+		require.Equal(t, http.StatusOK, undo(t, userToken, recent[0].ID))
+		assert.Equal(t, 12.5, amount(t))
+	})
+
+	t.Run("a deleted budget is restored", func(t *testing.T) {
+		budget := database.Budget{
+			UserID:     fixtures.User.ID,
+			CategoryID: fixtures.ExpenseCategory.ID,
+			Amount:     300,
+			Period:     "monthly",
+			StartDate:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:    time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		}
+		require.NoError(t, db.Omit("User", "Category").Create(&budget).Error)
+
+		w := server.Do(t, http.MethodDelete, fmt.Sprintf("/api/v100/budgets/%d", budget.ID), userToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		recent := actions(t)
+		require.Len(t, recent, 1)
+		assert.Equal(t, "budget", recent[0].Target)
+		require.Equal(t, http.StatusOK, undo(t, userToken, recent[0].ID))
+
+		var restored database.Budget
+		require.NoError(t, db.First(&restored, budget.ID).Error)
+		assert.Equal(t, 300.0, restored.Amount)
+	})
+}
+
+// TestReconciliationIntegration checks accounts, transaction statuses and
+// reconciling a statement period
+func TestReconciliationIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	userToken := server.Token(t, fixtures.User)
+	adminToken := server.Token(t, fixtures.Admin)
+
+	w := server.Do(t, http.MethodPost, "/api/v100/accounts", userToken, map[string]interface{}{
+		"name": "Checking",
+		"type": "checking",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		Account appFinance.AccountResponse `json:"account"`
+	}
+	testsupport.DecodeData(t, w, &created)
+	accountID := created.Account.ID
+	assert.Equal(t, int(fixtures.Currency.ID), created.Account.CurrencyID)
+
+	w = server.Do(t, http.MethodPost, "/api/v100/accounts", userToken, map[string]interface{}{
+		"name": "Wallet",
+		"type": "purse",
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+	record := func(t *testing.T, path string, categoryID uint, amount float64, date string) int {
+		w := server.Do(t, http.MethodPost, path, userToken, map[string]interface{}{
+			"category_id": categoryID,
+			"amount":      amount,
+			"description": "statement line",
+			"date":        date,
+			"account_id":  accountID,
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var response map[string]appFinance.CreateTransactionResponse
+		testsupport.DecodeData(t, w, &response)
+		for _, transaction := range response {
+			assert.Equal(t, accountID, transaction.AccountID)
+			assert.Equal(t, "uncleared", transaction.Status)
+			return transaction.ID
+		}
+		return 0
+	}
+	expenseID := record(t, "/api/v100/expenses", fixtures.ExpenseCategory.ID, 40.25, "2024-02-10")
+	record(t, "/api/v100/expenses", fixtures.ExpenseCategory.ID, 9.75, "2024-02-20")
+	record(t, "/api/v100/incomes", fixtures.IncomeCategory.ID, 1000, "2024-02-01")
+	record(t, "/api/v100/expenses", fixtures.ExpenseCategory.ID, 5, "2024-03-02")
+
+	statement := map[string]interface{}{
+		"start_date":            "2024-02-01",
+		"end_date":              "2024-02-29",
+		"statement_deposits":    1000,
+		"statement_withdrawals": 60,
+	}
+	status := func(t *testing.T, id int) string {
+		var model database.Expense
+		require.NoError(t, db.First(&model, id).Error)
+		return model.Status
+	}
+
+	t.Run("another user's account is not found", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, fmt.Sprintf("/api/v100/accounts/%d/reconcile", accountID), adminToken, statement)
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodPost, "/api/v100/expenses", adminToken, map[string]interface{}{
+			"category_id": fixtures.ExpenseCategory.ID,
+			"amount":      1,
+			"date":        "2024-02-01",
+			"account_id":  accountID,
+		})
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+
+	t.Run("the report compares recorded and statement totals", func(t *testing.T) {
+		path := fmt.Sprintf("/api/v100/accounts/%d/reconciliation?start_date=2024-02-01&end_date=2024-02-29&statement_deposits=1000&statement_withdrawals=60", accountID)
+		w := server.Do(t, http.MethodGet, path, userToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var report appFinance.ReconciliationResponse
+		testsupport.DecodeData(t, w, &report)
+		assert.Equal(t, 1000.0, report.RecordedDeposits)
+		assert.Equal(t, 50.0, report.RecordedWithdrawals)
+		assert.Equal(t, -10.0, report.WithdrawalsDifference)
+		assert.Equal(t, 3, report.TransactionCount)
+		assert.False(t, report.Balanced)
+	})
+
+	t.Run("an unbalanced statement marks nothing", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, fmt.Sprintf("/api/v100/accounts/%d/reconcile", accountID), userToken, statement)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var report appFinance.ReconciliationResponse
+		testsupport.DecodeData(t, w, &report)
+		assert.False(t, report.Reconciled)
+		assert.Equal(t, "uncleared", status(t, expenseID))
+	})
+
+	t.Run("a single transaction can be cleared", func(t *testing.T) {
+		path := fmt.Sprintf("/api/v100/expenses/%d/status", expenseID)
+		w := server.Do(t, http.MethodPut, path, userToken, map[string]string{"status": "cleared"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "cleared", status(t, expenseID))
+
+		w = server.Do(t, http.MethodPut, path, userToken, map[string]string{"status": "bounced"})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("a balanced statement reconciles the period", func(t *testing.T) {
+		statement["statement_withdrawals"] = 50
+		w := server.Do(t, http.MethodPost, fmt.Sprintf("/api/v100/accounts/%d/reconcile", accountID), userToken, statement)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var report appFinance.ReconciliationResponse
+		testsupport.DecodeData(t, w, &report)
+		assert.True(t, report.Balanced)
+		assert.True(t, report.Reconciled)
+		assert.Equal(t, "reconciled", status(t, expenseID))
+
+		var outside int64
+		require.NoError(t, db.Model(&database.Expense{}).Where("date > ? AND status = ?", "2024-03-01", "uncleared").Count(&outside).Error)
+		assert.Equal(t, int64(1), outside)
+	})
+
+	t.Run("an inverted period is rejected", func(t *testing.T) {
+		statement["start_date"] = "2024-03-01"
+		w := server.Do(t, http.MethodPost, fmt.Sprintf("/api/v100/accounts/%d/reconcile", accountID), userToken, statement)
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+}
+
+// TestBalanceAssertionsIntegration checks that asserted balances are compared with
+// the balance derived from an account's transactions
+func TestBalanceAssertionsIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	userToken := server.Token(t, fixtures.User)
+	adminToken := server.Token(t, fixtures.Admin)
+
+	w := server.Do(t, http.MethodPost, "/api/v100/accounts", userToken, map[string]interface{}{
+		"name": "Savings",
+		"type": "savings",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		Account appFinance.AccountResponse `json:"account"`
+	}
+	testsupport.DecodeData(t, w, &created)
+	assertionsPath := fmt.Sprintf("/api/v100/accounts/%d/balance-assertions", created.Account.ID)
+
+	record := func(t *testing.T, path string, categoryID uint, amount float64, date string) {
+		w := server.Do(t, http.MethodPost, path, userToken, map[string]interface{}{
+			"category_id": categoryID,
+			"amount":      amount,
+			"date":        date,
+			"account_id":  created.Account.ID,
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+	record(t, "/api/v100/incomes", fixtures.IncomeCategory.ID, 500, "2024-01-05")
+	record(t, "/api/v100/expenses", fixtures.ExpenseCategory.ID, 120, "2024-01-20")
+	record(t, "/api/v100/expenses", fixtures.ExpenseCategory.ID, 30, "2024-02-10")
+
+	assertBalance := func(t *testing.T, date string, balance float64) appFinance.BalanceAssertionResponse {
+		w := server.Do(t, http.MethodPost, assertionsPath, userToken, map[string]interface{}{
+			"date":    date,
+			"balance": balance,
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var response struct {
+			Assertion appFinance.BalanceAssertionResponse `json:"balance_assertion"`
+		}
+		testsupport.DecodeData(t, w, &response)
+		return response.Assertion
+	}
+
+	t.Run("a matching balance holds", func(t *testing.T) {
+		january := assertBalance(t, "2024-01-31", 380)
+		require.True(t, january.Holds, "%+v", january)
+		require.Equal(t, 380.0, january.DerivedBalance)
+	})
+
+	t.Run("a discrepancy is reported against the previous assertion", func(t *testing.T) {
+		february := assertBalance(t, "2024-02-29", 360)
+		require.False(t, february.Holds)
+		require.Equal(t, -10.0, february.Difference)
+		require.Equal(t, -10.0, february.NewDiscrepancy)
+	})
+
+	t.Run("the list reflects later corrections", func(t *testing.T) {
+		record(t, "/api/v100/incomes", fixtures.IncomeCategory.ID, 10, "2024-02-15")
+
+		w := server.Do(t, http.MethodGet, assertionsPath, userToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response struct {
+			Assertions []appFinance.BalanceAssertionResponse `json:"balance_assertions"`
+		}
+		testsupport.DecodeData(t, w, &response)
+		require.Len(t, response.Assertions, 2)
+		require.Equal(t, "2024-01-31", response.Assertions[0].Date)
+		require.True(t, response.Assertions[1].Holds, "%+v", response.Assertions[1])
+
+		w = server.Do(t, http.MethodGet, assertionsPath, adminToken, nil)
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+		path := fmt.Sprintf("%s/%d", assertionsPath, response.Assertions[0].ID)
+		w = server.Do(t, http.MethodDelete, path, userToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		w = server.Do(t, http.MethodDelete, path, userToken, nil)
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+}
+
+// recordingUploader stands in for the cloud storage providers, keeping uploads in memory
+type recordingUploader struct {
+	files map[string][]byte
+	err   error
+}
+
+func (u *recordingUploader) Upload(ctx context.Context, schedule *finance.ExportSchedule, name, contentType string, data []byte) error {
+	if u.err != nil {
+		return u.err
+	}
+	u.files[name] = data
+	return nil
+}
+
+// TestScheduledExportsIntegration checks export schedules, the export job and the export history
+func TestScheduledExportsIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	userToken := server.Token(t, fixtures.User)
+	adminToken := server.Token(t, fixtures.Admin)
+	ctx := context.Background()
+
+	fixtures.AddExpense(t, db, 12.5, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	fixtures.AddIncome(t, db, 1000, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC))
+
+	uploader := &recordingUploader{files: map[string][]byte{}}
+	scheduleRepo := database.NewGormExportScheduleRepository(db)
+	job := appFinance.NewRunScheduledExportsUseCase(
+		scheduleRepo,
+		database.NewGormExportRunRepository(db),
+		database.NewGormTransactionRepository(db),
+		database.NewGormCategoryRepository(db),
+		uploader,
+	)
+
+	t.Run("invalid schedules are rejected", func(t *testing.T) {
+		for _, body := range []map[string]string{
+			{"format": "xml", "provider": "s3", "target": "bucket", "frequency": "daily"},
+			{"format": "csv", "provider": "ftp", "target": "bucket", "frequency": "daily"},
+			{"format": "csv", "provider": "dropbox", "target": "/PandaPocket", "frequency": "daily"},
+			{"format": "csv", "provider": "s3", "target": "bucket", "frequency": "hourly"},
+		} {
+			w := server.Do(t, http.MethodPost, "/api/v100/exports/schedules", userToken, body)
+			assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		}
+	})
+
+	var schedule appFinance.ExportScheduleResponse
+	t.Run("a schedule is created without exposing its token", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, "/api/v100/exports/schedules", userToken, map[string]string{
+			"format":       "csv",
+			"provider":     "dropbox",
+			"target":       "/PandaPocket",
+			"access_token": "secret-token",
+			"frequency":    "weekly",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		assert.NotContains(t, w.Body.String(), "secret-token")
+
+		var response struct {
+			Schedule appFinance.ExportScheduleResponse `json:"schedule"`
+		}
+		testsupport.DecodeData(t, w, &response)
+		schedule = response.Schedule
+	})
+
+	t.Run("due schedules are exported once per period", func(t *testing.T) {
+		now := time.Now().Add(time.Minute)
+		ran, err := job.Execute(ctx, now)
+		require.NoError(t, err)
+		require.Equal(t, 1, ran)
+		require.Len(t, uploader.files, 1)
+		for _, data := range uploader.files {
+			assert.Contains(t, string(data), "id,date,type,category,amount,currency_id,description")
+			assert.Contains(t, string(data), "2024-03-01,expense,Food,12.50")
+		}
+
+		ran, err = job.Execute(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 0, ran)
+
+		stored, err := scheduleRepo.FindByID(ctx, finance.NewExportScheduleID(schedule.ID))
+		require.NoError(t, err)
+		assert.True(t, stored.NextRunAt().After(now.AddDate(0, 0, 6)))
+	})
+
+	t.Run("failed uploads are recorded in the history", func(t *testing.T) {
+		uploader.err = fmt.Errorf("dropbox upload returned status 401")
+		ran, err := job.Execute(ctx, time.Now().AddDate(0, 0, 8))
+		require.NoError(t, err)
+		require.Equal(t, 1, ran)
+
+		w := server.Do(t, http.MethodGet, "/api/v100/exports", userToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response struct {
+			Exports []appFinance.ExportRunResponse `json:"exports"`
+		}
+		testsupport.DecodeData(t, w, &response)
+		require.Len(t, response.Exports, 2)
+		assert.Equal(t, "failed", response.Exports[0].Status)
+		assert.Contains(t, response.Exports[0].Error, "401")
+		assert.Equal(t, "succeeded", response.Exports[1].Status)
+		assert.Equal(t, 2, response.Exports[1].TransactionCount)
+	})
+
+	t.Run("only the owner can delete a schedule", func(t *testing.T) {
+		path := fmt.Sprintf("/api/v100/exports/schedules/%d", schedule.ID)
+		w := server.Do(t, http.MethodDelete, path, adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodDelete, path, userToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodGet, "/api/v100/exports/schedules", userToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), `"schedules":[]`)
+	})
+}
+
+func TestTaxReportIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	userToken := server.Token(t, fixtures.User)
+	adminToken := server.Token(t, fixtures.Admin)
+
+	w := server.Do(t, http.MethodPost, "/api/v100/categories", userToken, map[string]interface{}{
+		"name": "Office Supplies",
+		"type": "expense",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	// The create response does not carry the new ID, so look the category up
+	var office database.Category
+	require.NoError(t, db.Where("name = ?", "Office Supplies").First(&office).Error)
+	officeID := int(office.ID)
+
+	record := func(t *testing.T, categoryID int, amount float64, date, description string) int {
+		w := server.Do(t, http.MethodPost, "/api/v100/expenses", userToken, map[string]interface{}{
+			"category_id": categoryID,
+			"amount":      amount,
+			"date":        date,
+			"description": description,
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var response struct {
+			Expense appFinance.CreateTransactionResponse `json:"expense"`
+		}
+		testsupport.DecodeData(t, w, &response)
+		return response.Expense.ID
+	}
+	chair := record(t, officeID, 299.5, "2024-02-10", "Office chair")
+	record(t, officeID, 50, "2024-05-02", "Printer paper")
+	record(t, officeID, 80, "2023-12-30", "Desk lamp")
+	donation := record(t, int(fixtures.ExpenseCategory.ID), 100, "2024-06-01", "Charity donation")
+	record(t, int(fixtures.ExpenseCategory.ID), 12, "2024-06-02", "Lunch")
+
+	t.Run("categories and expenses are marked deductible", func(t *testing.T) {
+		w := server.Do(t, http.MethodPut, fmt.Sprintf("/api/v100/categories/%d/tax-deductible", officeID), userToken, map[string]interface{}{
+			"tax_deductible": true,
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodPut, fmt.Sprintf("/api/v100/categories/%d/tax-deductible", fixtures.IncomeCategory.ID), userToken, map[string]interface{}{
+			"tax_deductible": true,
+		})
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "INCOME_NOT_DEDUCTIBLE")
+
+		w = server.Do(t, http.MethodPut, fmt.Sprintf("/api/v100/expenses/%d/tax", donation), userToken, map[string]interface{}{
+			"tax_deductible":    true,
+			"receipt_reference": "receipts/2024/donation.pdf",
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodPut, fmt.Sprintf("/api/v100/expenses/%d/tax", chair), userToken, map[string]interface{}{
+			"tax_deductible":    false,
+			"receipt_reference": "receipts/2024/office-chair.pdf",
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodPut, fmt.Sprintf("/api/v100/expenses/%d/tax", donation), adminToken, map[string]interface{}{
+			"tax_deductible": false,
+		})
+		require.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodGet, "/api/v100/categories?type=expense", userToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var categories []appFinance.CategoryResponse
+		testsupport.DecodeData(t, w, &categories)
+		for _, category := range categories {
+			assert.Equal(t, category.ID == officeID, category.TaxDeductible, category.Name)
+		}
+	})
+
+	t.Run("the report totals the year's deductible expenses, archived ones included", func(t *testing.T) {
+		_, err := database.NewGormTransactionRepository(db).ArchiveBefore(context.Background(), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+
+		w := server.Do(t, http.MethodGet, "/api/v100/reports/tax/2024", userToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var report appFinance.TaxReportResponse
+		testsupport.DecodeData(t, w, &report)
+
+		require.Len(t, report.Categories, 2, "%+v", report)
+		require.Equal(t, 3, report.TransactionCount)
+		require.Equal(t, 1, report.MissingReceipts)
+		require.Len(t, report.Totals, 1)
+		require.Equal(t, 449.5, report.Totals[0].Total)
+
+		for _, category := range report.Categories {
+			switch category.CategoryID {
+			case officeID:
+				require.Equal(t, "Office Supplies", category.CategoryName)
+				require.Equal(t, 349.5, category.Total)
+				require.Len(t, category.Transactions, 2)
+				require.Equal(t, chair, category.Transactions[0].ID)
+				require.Equal(t, "receipts/2024/office-chair.pdf", category.Transactions[0].ReceiptReference)
+			case int(fixtures.ExpenseCategory.ID):
+				require.Equal(t, 100.0, category.Total)
+				require.Len(t, category.Transactions, 1)
+				require.Equal(t, donation, category.Transactions[0].ID)
+			default:
+				t.Fatalf("unexpected category in report: %+v", category)
+			}
+		}
+	})
+
+	t.Run("invalid years are rejected", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v100/reports/tax/abc", userToken, nil)
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		w = server.Do(t, http.MethodGet, "/api/v100/reports/tax/12", userToken, nil)
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+}
+
+func TestEncryptedFieldsIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	ctx := context.Background()
+	userID := finance.NewUserID(int(fixtures.User.ID))
+
+	key := make([]byte, encryption.KeySize)
+	fields, err := encryption.NewFieldCipher(key)
+	require.NoError(t, err)
+	repo := database.NewGormTransactionRepository(db).WithFieldCipher(fields)
+
+	date := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	// Written in plaintext before encryption was switched on
+	legacy := fixtures.AddExpense(t, db, 15, date)
+	require.NoError(t, db.Model(&legacy).Update("description", "Pharmacy").Error)
+
+	amount, err := finance.NewMoney(42, finance.NewCurrencyID(int(fixtures.Currency.ID)))
+	require.NoError(t, err)
+	transaction := finance.NewTransaction(
+		finance.TransactionID{},
+		userID,
+		finance.NewCategoryID(int(fixtures.ExpenseCategory.ID)),
+		finance.NewCurrencyID(int(fixtures.Currency.ID)),
+		amount,
+		"Doctor visit",
+		date,
+		finance.TransactionTypeExpense,
+	)
+	require.NoError(t, transaction.UpdateTaxDetails(true, "receipts/doctor.pdf"))
+	require.NoError(t, repo.Save(ctx, transaction))
+
+	t.Run("values are encrypted in the database", func(t *testing.T) {
+		var stored database.Expense
+		require.NoError(t, db.First(&stored, transaction.ID().Value()).Error)
+		assert.True(t, strings.HasPrefix(stored.Description, "enc:v1:"))
+		assert.True(t, strings.HasPrefix(stored.ReceiptReference, "enc:v1:"))
+		assert.NotContains(t, stored.Description, "Doctor")
+	})
+
+	t.Run("reads decrypt transparently", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, transaction.ID())
+		require.NoError(t, err)
+		assert.Equal(t, "Doctor visit", found.Description())
+		assert.Equal(t, "receipts/doctor.pdf", found.ReceiptReference())
+	})
+
+	t.Run("plaintext rows are still readable", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, finance.NewTransactionID(int(legacy.ID)))
+		require.NoError(t, err)
+		assert.Equal(t, "Pharmacy", found.Description())
+	})
+
+	t.Run("search matches decrypted descriptions", func(t *testing.T) {
+		transactions, total, err := repo.FindByUserIDWithFilters(ctx, userID, finance.TransactionFilters{Search: "doctor", Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		require.Len(t, transactions, 1)
+		assert.Equal(t, transaction.ID(), transactions[0].ID())
+	})
+
+	t.Run("values encrypted with a retired key still decrypt", func(t *testing.T) {
+		newKey := make([]byte, encryption.KeySize)
+		newKey[0] = 1
+		rotated, err := encryption.NewFieldCipher(newKey, key)
+		require.NoError(t, err)
+
+		found, err := database.NewGormTransactionRepository(db).WithFieldCipher(rotated).FindByID(ctx, transaction.ID())
+		require.NoError(t, err)
+		assert.Equal(t, "Doctor visit", found.Description())
+	})
+}
+
+func TestBodyLimitsIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+	handler := server.App.Handler()
+
+	send := func(h http.Handler, contentType string, body io.Reader) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v100/categories", body)
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("JSON bodies within the limit are accepted", func(t *testing.T) {
+		w := send(handler, "application/json", strings.NewReader(`{"name":"Hobbies","type":"expense"}`))
+		assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	})
+
+	t.Run("other content types are rejected", func(t *testing.T) {
+		w := send(handler, "text/plain", strings.NewReader(`{"name":"Hobbies","type":"expense"}`))
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+		assert.Contains(t, w.Body.String(), "UNSUPPORTED_MEDIA_TYPE")
+	})
+
+	t.Run("oversized bodies are rejected", func(t *testing.T) {
+		name := strings.Repeat("a", server.App.Config.Server.MaxBodyBytes)
+		w := send(handler, "application/json", strings.NewReader(`{"name":"`+name+`","type":"expense"}`))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Contains(t, w.Body.String(), "REQUEST_TOO_LARGE")
+	})
+}
+
+// stubCaptchaVerifier accepts one token and rejects the rest
+type stubCaptchaVerifier struct {
+	valid string
+}
+
+func (v stubCaptchaVerifier) Verify(_ context.Context, token, _ string) error {
+	if token != v.valid {
+		return captcha.ErrRejected
+	}
+	return nil
+}
+
+func TestCaptchaIntegration(t *testing.T) {
+	register := func(m *middleware.CaptchaMiddleware, token string) *httptest.ResponseRecorder {
+		router := gin.New()
+		router.POST("/auth/register", m.Require(), func(c *gin.Context) {
+			c.Status(http.StatusCreated)
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/auth/register", nil)
+		if token != "" {
+			req.Header.Set(middleware.CaptchaTokenHeader, token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	required := middleware.NewCaptchaMiddleware(stubCaptchaVerifier{valid: "solved"}, slog.Default())
+
+	t.Run("no CAPTCHA is required when none is configured", func(t *testing.T) {
+		w := register(middleware.NewCaptchaMiddleware(nil, slog.Default()), "")
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("a missing token is rejected", func(t *testing.T) {
+		w := register(required, "")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "CAPTCHA_REQUIRED")
+	})
+
+	t.Run("an invalid token is rejected", func(t *testing.T) {
+		w := register(required, "guessed")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "CAPTCHA_FAILED")
+	})
+
+	t.Run("a solved CAPTCHA passes", func(t *testing.T) {
+		w := register(required, "solved")
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+}
+
+// recordingMailer keeps the plain-text bodies of sent emails instead of delivering them
+type recordingMailer struct {
+	bodies []string
+}
+
+func (m *recordingMailer) SendHTML(_ context.Context, _, _, text, _ string) error {
+	m.bodies = append(m.bodies, text)
+	return nil
+}
+
+func TestPasswordResetIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	ctx := context.Background()
+
+	userService := identity.NewUserService(database.NewGormUserRepository(db))
+	resetRepo := database.NewGormPasswordResetRepository(db)
+	mailer := &recordingMailer{}
+	templates, err := mail.NewTemplateRenderer("")
+	require.NoError(t, err)
+	forgot := appIdentity.NewForgotPasswordUseCase(userService, resetRepo, mailer, templates, "https://app.example.com/reset", time.Hour, 2)
+	reset := appIdentity.NewResetPasswordUseCase(userService, resetRepo)
+
+	tokenFrom := func(body string) string {
+		_, rest, found := strings.Cut(body, "token=")
+		require.True(t, found, body)
+		return strings.Fields(rest)[0]
+	}
+
+	t.Run("the response does not reveal whether the account exists", func(t *testing.T) {
+		known := server.Do(t, http.MethodPost, "/api/v100/auth/forgot-password", "", gin.H{"email": fixtures.Admin.Email})
+		unknown := server.Do(t, http.MethodPost, "/api/v100/auth/forgot-password", "", gin.H{"email": "nobody@example.com"})
+		assert.Equal(t, http.StatusOK, known.Code, known.Body.String())
+		assert.Equal(t, known.Code, unknown.Code)
+		assert.Equal(t, known.Body.String(), unknown.Body.String())
+	})
+
+	require.NoError(t, forgot.Execute(ctx, appIdentity.ForgotPasswordRequest{Email: fixtures.User.Email}))
+	require.NoError(t, forgot.Execute(ctx, appIdentity.ForgotPasswordRequest{Email: fixtures.User.Email}))
+	require.Len(t, mailer.bodies, 2)
+	first, second := tokenFrom(mailer.bodies[0]), tokenFrom(mailer.bodies[1])
+
+	t.Run("emails are limited per address per hour", func(t *testing.T) {
+		require.NoError(t, forgot.Execute(ctx, appIdentity.ForgotPasswordRequest{Email: fixtures.User.Email}))
+		assert.Len(t, mailer.bodies, 2)
+	})
+
+	t.Run("a new link invalidates earlier ones", func(t *testing.T) {
+		err := reset.Execute(ctx, appIdentity.ResetPasswordRequest{Token: first, Password: "new-password"})
+		assert.ErrorIs(t, err, identity.ErrInvalidResetToken)
+	})
+
+	t.Run("the newest link resets the password once", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, "/api/v100/auth/reset-password", "", gin.H{"token": second, "password": "new-password"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodPost, "/api/v100/auth/login", "", gin.H{"email": fixtures.User.Email, "password": "new-password"})
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodPost, "/api/v100/auth/reset-password", "", gin.H{"token": second, "password": "another-password"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "INVALID_RESET_TOKEN")
+	})
+}
+
+func TestEmailTemplatesIntegration(t *testing.T) {
+	data := struct{ Link, ExpiresIn string }{Link: "https://app.example.com/reset?token=abc&x=1", ExpiresIn: "1 hour"}
+
+	t.Run("built-in templates render", func(t *testing.T) {
+		renderer, err := mail.NewTemplateRenderer("")
+		require.NoError(t, err)
+
+		subject, text, html, err := renderer.Render("password_reset", data)
+		require.NoError(t, err)
+		assert.Equal(t, "Reset your PandaPocket password", subject)
+		assert.Contains(t, text, data.Link)
+		assert.Contains(t, html, "https://app.example.com/reset?token=abc&amp;x=1")
+	})
+
+	t.Run("files in the override directory replace built-in templates", func(t *testing.T) {
+		dir := t.TempDir()
+		custom := `{{define "subject"}}Password help{{end}}Reset it at {{.Link}}`
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "password_reset.txt"), []byte(custom), 0o644))
+
+		renderer, err := mail.NewTemplateRenderer(dir)
+		require.NoError(t, err)
+
+		subject, text, html, err := renderer.Render("password_reset", data)
+		require.NoError(t, err)
+		assert.Equal(t, "Password help", subject)
+		assert.Equal(t, "Reset it at "+data.Link, text)
+		assert.Contains(t, html, "Choose a new password") // the HTML template was not overridden
+	})
+
+	t.Run("an override without a subject is rejected", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "password_reset.txt"), []byte("Reset it at {{.Link}}"), 0o644))
+
+		_, err := mail.NewTemplateRenderer(dir)
+		assert.Error(t, err)
+	})
+}
+
+// failingMailer fails every delivery, like an unreachable SMTP server
+type failingMailer struct{}
+
+func (failingMailer) Send(context.Context, string, string, string) error {
+	return errors.New("connection refused")
+}
+
+func (failingMailer) SendHTML(context.Context, string, string, string, string) error {
+	return errors.New("connection refused")
+}
+
+func TestEmailQueueIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	adminToken := server.Token(t, fixtures.Admin)
+	ctx := context.Background()
+
+	queue := mail.NewQueue(database.NewGormEmailQueueRepository(db), failingMailer{}, 2)
+	require.NoError(t, queue.SendHTML(ctx, fixtures.User.Email, "Hello", "text body", "<p>html body</p>"))
+
+	var stored database.QueuedEmail
+	t.Run("failed deliveries are retried later", func(t *testing.T) {
+		require.NoError(t, queue.Deliver(ctx))
+
+		require.NoError(t, db.First(&stored).Error)
+		assert.Equal(t, "pending", stored.Status)
+		assert.Equal(t, 1, stored.Attempts)
+		assert.Equal(t, "connection refused", stored.LastError)
+		assert.True(t, stored.NextAttemptAt.After(time.Now()))
+	})
+
+	t.Run("emails are dead-lettered after the last attempt", func(t *testing.T) {
+		require.NoError(t, db.Model(&stored).Update("next_attempt_at", time.Now().Add(-time.Minute)).Error)
+		require.NoError(t, queue.Deliver(ctx))
+
+		require.NoError(t, db.First(&stored, stored.ID).Error)
+		assert.Equal(t, "dead", stored.Status)
+		assert.Equal(t, 2, stored.Attempts)
+	})
+
+	t.Run("admins see dead-lettered emails", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/admin/emails/dead-letters", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var page struct {
+			Emails []mail.QueuedEmail `json:"emails"`
+		}
+		testsupport.DecodeData(t, w, &page)
+		require.Len(t, page.Emails, 1)
+		assert.Equal(t, fixtures.User.Email, page.Emails[0].To)
+		assert.NotContains(t, w.Body.String(), "text body")
+	})
+
+	t.Run("retried emails are delivered and their bodies dropped", func(t *testing.T) {
+		path := fmt.Sprintf("/api/admin/emails/%d/retry", stored.ID)
+		w := server.Do(t, http.MethodPost, path, adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		require.NoError(t, server.App.EmailQueue.Deliver(ctx))
+		require.NoError(t, db.First(&stored, stored.ID).Error)
+		assert.Equal(t, "sent", stored.Status)
+		assert.NotNil(t, stored.SentAt)
+		assert.Empty(t, stored.TextBody)
+		assert.Empty(t, stored.HTMLBody)
+
+		w = server.Do(t, http.MethodPost, path, adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestCategoryTranslationsIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	var food database.Category
+	require.NoError(t, db.Where("translation_key = ?", "expense.food").First(&food).Error)
+
+	categoryNamed := func(t *testing.T, acceptLanguage string) appFinance.CategoryResponse {
+		req := httptest.NewRequest(http.MethodGet, "/api/v100/categories?type=expense", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		if acceptLanguage != "" {
+			req.Header.Set("Accept-Language", acceptLanguage)
+		}
+		w := httptest.NewRecorder()
+		server.App.Handler().ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var categories []appFinance.CategoryResponse
+		testsupport.DecodeData(t, w, &categories)
+		for _, category := range categories {
+			if category.ID == int(food.ID) {
+				return category
+			}
+		}
+		t.Fatalf("default category %d missing from %s", food.ID, w.Body.String())
+		return appFinance.CategoryResponse{}
+	}
+
+	t.Run("default categories are named in English without a preference", func(t *testing.T) {
+		category := categoryNamed(t, "")
+		assert.Equal(t, "Food", category.Name)
+		assert.Equal(t, "expense.food", category.TranslationKey)
+	})
+
+	t.Run("regional tags fall back to their base language", func(t *testing.T) {
+		category := categoryNamed(t, "id-ID,en;q=0.8")
+		assert.Equal(t, "Makanan", category.Name)
+		assert.Equal(t, "expense.food", category.TranslationKey)
+	})
+
+	t.Run("the most preferred language with a translation wins", func(t *testing.T) {
+		assert.Equal(t, "Makanan", categoryNamed(t, "fr, id;q=0.5").Name)
+		assert.Equal(t, "Food", categoryNamed(t, "fr").Name)
+	})
+
+	t.Run("transactions show localized category names", func(t *testing.T) {
+		fixtures.AddExpense(t, db, 12, time.Now())
+		require.NoError(t, db.Model(&database.Expense{}).Where("user_id = ?", fixtures.User.ID).Update("category_id", food.ID).Error)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v100/expenses", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Accept-Language", "id")
+		w := httptest.NewRecorder()
+		server.App.Handler().ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "Makanan")
+		assert.Contains(t, w.Header().Values("Vary"), "Accept-Language")
+	})
+}
+
+func TestMoneyPrecisionIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	createExpense := func(t *testing.T, amount float64) *httptest.ResponseRecorder {
+		return server.Do(t, http.MethodPost, "/api/v100/expenses", token, map[string]interface{}{
+			"category_id": fixtures.ExpenseCategory.ID,
+			"amount":      amount,
+			"description": "Groceries",
+			"date":        "2024-03-01",
+		})
+	}
+
+	t.Run("amounts are rounded to cents", func(t *testing.T) {
+		w := createExpense(t, 12.345)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var created struct {
+			Expense appFinance.CreateTransactionResponse `json:"expense"`
+		}
+		testsupport.DecodeData(t, w, &created)
+		assert.Equal(t, 12.35, created.Expense.Amount)
+	})
+
+	t.Run("amounts over the maximum return INVALID_AMOUNT", func(t *testing.T) {
+		w := createExpense(t, 100_000_000)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "INVALID_AMOUNT")
+	})
+}
+
+func TestProratedBudgetsIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	fixtures.AddExpense(t, db, 100, time.Date(2024, 1, 25, 0, 0, 0, 0, time.UTC))
+
+	createBudget := func(t *testing.T, prorate bool) {
+		w := server.Do(t, http.MethodPost, "/api/v100/budgets", token, map[string]interface{}{
+			"category_id": fixtures.ExpenseCategory.ID,
+			"amount":      310,
+			"period":      "monthly",
+			"start_date":  "2024-01-21",
+			"prorate":     prorate,
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+	budgets := func(t *testing.T) []appFinance.BudgetResponse {
+		w := server.Do(t, http.MethodGet, "/api/v100/budgets", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var budgets []appFinance.BudgetResponse
+		testsupport.DecodeData(t, w, &budgets)
+		return budgets
+	}
+
+	t.Run("a prorated budget covers the rest of its month", func(t *testing.T) {
+		createBudget(t, true)
+
+		list := budgets(t)
+		require.Len(t, list, 1)
+		budget := list[0]
+		assert.True(t, budget.Prorated)
+		assert.Equal(t, "2024-02-01", budget.EndDate)
+		require.NotNil(t, budget.Report)
+		assert.Equal(t, 110.0, budget.Report.Allowance)
+		assert.Equal(t, 10.0, budget.Report.Remaining)
+		assert.True(t, budget.Report.IsOnTrack)
+	})
+
+	t.Run("budgets without proration allow the full amount", func(t *testing.T) {
+		require.NoError(t, db.Where("user_id = ?", fixtures.User.ID).Delete(&database.Budget{}).Error)
+		createBudget(t, false)
+
+		list := budgets(t)
+		require.Len(t, list, 1)
+		assert.False(t, list[0].Prorated)
+		assert.Equal(t, "2024-02-21", list[0].EndDate)
+		assert.Equal(t, 310.0, list[0].Report.Allowance)
+	})
+}
+
+func TestBudgetExceededWebhooksIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	adminToken := server.Token(t, fixtures.Admin)
+	userToken := server.Token(t, fixtures.User)
+
+	var created appNotification.WebhookResponse
+	t.Run("registers a webhook and returns its secret once", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, "/api/webhooks", userToken, appNotification.CreateWebhookRequest{
+			URL:    "https://hooks.example.com/panda",
+			Events: []string{finance.EventBudgetExceeded},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		testsupport.DecodeData(t, w, &created)
+		assert.Len(t, created.Secret, 64)
+		assert.Equal(t, []string{finance.EventBudgetExceeded}, created.Events)
+
+		w = server.Do(t, http.MethodGet, "/api/webhooks", userToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response struct {
+			Webhooks []appNotification.WebhookResponse `json:"webhooks"`
+		}
+		testsupport.DecodeData(t, w, &response)
+		require.Len(t, response.Webhooks, 1)
+		assert.Empty(t, response.Webhooks[0].Secret)
+	})
+
+	t.Run("rejects private endpoints and unknown events", func(t *testing.T) {
+		for _, req := range []appNotification.CreateWebhookRequest{
+			{URL: "http://hooks.example.com/panda"},
+			{URL: "https://127.0.0.1/hook"},
+			{URL: "https://10.0.0.8/hook"},
+			{URL: "https://localhost/hook"},
+			{URL: "https://hooks.example.com/panda", Events: []string{"weather.changed"}},
+		} {
+			w := server.Do(t, http.MethodPost, "/api/webhooks", userToken, req)
+			assert.Equal(t, http.StatusBadRequest, w.Code, req.URL)
+		}
+	})
+
+	t.Run("the event carries the triggering transaction", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, "/api/v100/budgets", userToken, map[string]interface{}{
+			"category_id": fixtures.ExpenseCategory.ID,
+			"amount":      50,
+			"period":      "monthly",
+			"start_date":  "2024-03-01",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodPost, "/api/v100/expenses", userToken, appFinance.CreateTransactionRequest{
+			CategoryID:  int(fixtures.ExpenseCategory.ID),
+			Amount:      60,
+			Description: "new shoes",
+			Date:        "2024-03-05",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var record database.OutboxEvent
+		require.NoError(t, db.Where("event_name = ?", finance.EventBudgetExceeded).First(&record).Error)
+		var event finance.BudgetExceeded
+		require.NoError(t, json.Unmarshal([]byte(record.Payload), &event))
+		assert.NotZero(t, event.TransactionID)
+		assert.Equal(t, 60.0, event.TransactionAmount)
+		assert.Equal(t, 10.0, event.Overspend)
+	})
+
+	t.Run("deliveries are signed with the webhook secret", func(t *testing.T) {
+		received := make(chan *http.Request, 1)
+		bodies := make(chan []byte, 1)
+		endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			received <- r
+			bodies <- body
+		}))
+		defer endpoint.Close()
+
+		// Private addresses cannot be registered, so the local endpoint is stored directly
+		model := database.Webhook{UserID: fixtures.User.ID, URL: endpoint.URL, Secret: "s3cret"}
+		require.NoError(t, db.Create(&model).Error)
+
+		deliverer := appNotification.NewWebhookDeliverer(
+			database.NewGormWebhookRepository(db),
+			webhook.NewUnrestrictedSender(endpoint.Client()),
+		)
+		event := finance.BudgetExceeded{BudgetID: 1, UserID: int(fixtures.User.ID), TransactionID: 9, Overspend: 10, At: time.Now()}
+		require.NoError(t, deliverer.HandleEvent(context.Background(), event))
+
+		req := <-received
+		body := <-bodies
+		assert.Equal(t, finance.EventBudgetExceeded, req.Header.Get(webhook.EventHeader))
+		expected := "sha256=" + webhook.Sign("s3cret", req.Header.Get(webhook.TimestampHeader), body)
+		assert.Equal(t, expected, req.Header.Get(webhook.SignatureHeader))
+
+		var payload struct {
+			Event string                 `json:"event"`
+			Data  finance.BudgetExceeded `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, finance.EventBudgetExceeded, payload.Event)
+		assert.Equal(t, 9, payload.Data.TransactionID)
+	})
+
+	t.Run("only the owner can delete a webhook", func(t *testing.T) {
+		path := fmt.Sprintf("/api/webhooks/%d", created.ID)
+		w := server.Do(t, http.MethodDelete, path, adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = server.Do(t, http.MethodDelete, path, userToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+}
+
+func TestDeprecatedVersionClientsIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	adminToken := server.Token(t, fixtures.Admin)
+	userToken := server.Token(t, fixtures.User)
+
+	server.App.VersionManager.AddDeprecatedVersion("v100", time.Date(2099, 6, 1, 0, 0, 0, 0, time.UTC), "API version v100 is deprecated")
+
+	for i := 0; i < 2; i++ {
+		w := server.Do(t, http.MethodGet, "/api/v100/categories", userToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	}
+	w := server.Do(t, http.MethodGet, "/api/v120/categories", adminToken, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = server.Do(t, http.MethodGet, "/api/admin/version-usage/clients", adminToken, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response struct {
+		Versions []handlers.DeprecatedVersionClients `json:"versions"`
+	}
+	testsupport.DecodeData(t, w, &response)
+
+	require.Len(t, response.Versions, 1)
+	assert.Equal(t, "v100", response.Versions[0].Version)
+	assert.Equal(t, "2099-06-01", response.Versions[0].SunsetDate)
+	require.Len(t, response.Versions[0].Clients, 1)
+	client := response.Versions[0].Clients[0]
+	assert.Equal(t, int(fixtures.User.ID), client.UserID)
+	assert.Equal(t, fixtures.User.Email, client.Email)
+	assert.Equal(t, int64(2), client.RequestCount)
+
+	w = server.Do(t, http.MethodGet, "/api/admin/version-usage/clients", userToken, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestDefaultsCacheIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	var queries int
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:count_queries", func(*gorm.DB) {
+		queries++
+	}))
+	t.Cleanup(func() { _ = db.Callback().Query().Remove("test:count_queries") })
+
+	countQueries := func(t *testing.T, path string) int {
+		before := queries
+		w := server.Do(t, http.MethodGet, path, token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		return queries - before
+	}
+
+	t.Run("repeat requests read defaults from memory", func(t *testing.T) {
+		for _, path := range []string{"/api/v100/categories", "/api/v100/currencies"} {
+			first := countQueries(t, path)
+			second := countQueries(t, path)
+			assert.Less(t, second, first, path)
+		}
+	})
+
+	t.Run("user categories are never cached", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, "/api/v100/categories", token, map[string]interface{}{
+			"name":  "Pets",
+			"color": "#AA5500",
+			"type":  "expense",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodGet, "/api/v100/categories", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "Pets")
+	})
+
+	t.Run("saving a default currency invalidates the cache", func(t *testing.T) {
+		repo := database.NewGormCurrencyRepository(db).WithDefaultsCache()
+		ctx := context.Background()
+		id := finance.NewCurrencyID(int(fixtures.Currency.ID))
+
+		currency, err := repo.FindByID(ctx, id)
+		require.NoError(t, err)
+
+		// Changes made behind the repository's back are only seen after the TTL
+		require.NoError(t, db.Model(&database.Currency{}).Where("id = ?", fixtures.Currency.ID).Update("name", "Dollar").Error)
+		cached, err := repo.FindByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, currency.Name(), cached.Name())
+
+		require.NoError(t, repo.Save(ctx, cached))
+		require.NoError(t, db.Model(&database.Currency{}).Where("id = ?", fixtures.Currency.ID).Update("name", "Greenback").Error)
+		reloaded, err := repo.FindByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "Greenback", reloaded.Name())
+	})
+}
+
+func TestRecurringOccurrencesIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	nextDue := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 3)
+	recurring := database.RecurringTransaction{
+		UserID:      fixtures.User.ID,
+		CategoryID:  fixtures.ExpenseCategory.ID,
+		CurrencyID:  fixtures.Currency.ID,
+		Amount:      15.99,
+		Description: "streaming",
+		Frequency:   "monthly",
+		NextDueDate: nextDue,
+		IsActive:    true,
+	}
+	require.NoError(t, db.Create(&recurring).Error)
+	path := fmt.Sprintf("/api/v100/recurring-transactions/%d/occurrences", recurring.ID)
+
+	t.Run("projects monthly postings up to until", func(t *testing.T) {
+		until := nextDue.AddDate(0, 2, 0).Format("2006-01-02")
+		w := server.Do(t, http.MethodGet, path+"?until="+until, token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response appFinance.RecurringOccurrencesResponse
+		testsupport.DecodeData(t, w, &response)
+		require.Len(t, response.Occurrences, 3)
+		assert.Equal(t, nextDue.Format("2006-01-02"), response.Occurrences[0].Date)
+		assert.Equal(t, until, response.Occurrences[2].Date)
+		assert.Equal(t, 15.99, response.Occurrences[0].Amount)
+		assert.False(t, response.Truncated)
+	})
+
+	t.Run("defaults to a year ahead", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, path, token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response appFinance.RecurringOccurrencesResponse
+		testsupport.DecodeData(t, w, &response)
+		assert.Len(t, response.Occurrences, 12)
+	})
+
+	t.Run("rejects a malformed or distant until", func(t *testing.T) {
+		for _, until := range []string{"soon", time.Now().AddDate(6, 0, 0).Format("2006-01-02")} {
+			w := server.Do(t, http.MethodGet, path+"?until="+until, token, nil)
+			assert.Equal(t, http.StatusBadRequest, w.Code, until)
+		}
+	})
+
+	t.Run("hides other users' recurring transactions", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, path, server.Token(t, fixtures.Admin), nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestRecurringOccurrenceOverridesIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	nextDue := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 10)
+	recurring := database.RecurringTransaction{
+		UserID:      fixtures.User.ID,
+		CategoryID:  fixtures.ExpenseCategory.ID,
+		CurrencyID:  fixtures.Currency.ID,
+		Amount:      15.99,
+		Description: "streaming",
+		Frequency:   "monthly",
+		NextDueDate: nextDue,
+		IsActive:    true,
+	}
+	require.NoError(t, db.Create(&recurring).Error)
+	base := fmt.Sprintf("/api/v100/recurring-transactions/%d", recurring.ID)
+	until := "?until=" + nextDue.AddDate(0, 2, 0).Format("2006-01-02")
+
+	occurrences := func(t *testing.T) []appFinance.OccurrenceResponse {
+		w := server.Do(t, http.MethodGet, base+"/occurrences"+until, token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appFinance.RecurringOccurrencesResponse
+		testsupport.DecodeData(t, w, &response)
+		return response.Occurrences
+	}
+
+	t.Run("overrides only the next occurrence", func(t *testing.T) {
+		earlier := nextDue.AddDate(0, 0, -5).Format("2006-01-02")
+		w := server.Do(t, http.MethodPut, base+"/next-occurrence", token, map[string]interface{}{
+			"amount": 17.99,
+			"date":   earlier,
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appFinance.RecurringTransactionResponse
+		testsupport.DecodeData(t, w, &response)
+		assert.Equal(t, 15.99, response.Amount)
+		assert.Equal(t, nextDue.Format("2006-01-02"), response.NextDueDate)
+
+		projected := occurrences(t)
+		require.Len(t, projected, 3)
+		assert.Equal(t, appFinance.OccurrenceResponse{Date: earlier, Amount: 17.99, CurrencyID: int(fixtures.Currency.ID), Overridden: true}, projected[0])
+		assert.Equal(t, nextDue.AddDate(0, 1, 0).Format("2006-01-02"), projected[1].Date)
+		assert.Equal(t, 15.99, projected[1].Amount)
+	})
+
+	t.Run("rejects empty overrides and dates past the following occurrence", func(t *testing.T) {
+		for _, body := range []map[string]interface{}{
+			{},
+			{"date": nextDue.AddDate(0, 1, 0).Format("2006-01-02")},
+			{"date": "next week"},
+		} {
+			w := server.Do(t, http.MethodPut, base+"/next-occurrence", token, body)
+			assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		}
+	})
+
+	t.Run("clears an override", func(t *testing.T) {
+		w := server.Do(t, http.MethodDelete, base+"/next-occurrence", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		projected := occurrences(t)
+		assert.Equal(t, nextDue.Format("2006-01-02"), projected[0].Date)
+		assert.False(t, projected[0].Overridden)
+	})
+
+	t.Run("skips the next occurrence", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, base+"/skip", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		projected := occurrences(t)
+		require.Len(t, projected, 2)
+		assert.Equal(t, nextDue.AddDate(0, 1, 0).Format("2006-01-02"), projected[0].Date)
+	})
+
+	t.Run("refuses to change a paused recurring transaction", func(t *testing.T) {
+		require.NoError(t, db.Model(&recurring).Update("is_active", false).Error)
+		w := server.Do(t, http.MethodPost, base+"/skip", token, nil)
+		assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	})
+}
+
+func TestRecurringEndConditionsIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	nextDue := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 10)
+	recurring := database.RecurringTransaction{
+		UserID:      fixtures.User.ID,
+		CategoryID:  fixtures.ExpenseCategory.ID,
+		CurrencyID:  fixtures.Currency.ID,
+		Amount:      120,
+		Description: "loan",
+		Frequency:   "monthly",
+		NextDueDate: nextDue,
+		IsActive:    true,
+	}
+	require.NoError(t, db.Create(&recurring).Error)
+	base := fmt.Sprintf("/api/v100/recurring-transactions/%d", recurring.ID)
+
+	occurrences := func(t *testing.T) []appFinance.OccurrenceResponse {
+		w := server.Do(t, http.MethodGet, base+"/occurrences", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appFinance.RecurringOccurrencesResponse
+		testsupport.DecodeData(t, w, &response)
+		return response.Occurrences
+	}
+
+	t.Run("previews stop after the remaining occurrences", func(t *testing.T) {
+		w := server.Do(t, http.MethodPut, base+"/end-conditions", token, map[string]interface{}{"occurrences_remaining": 3})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appFinance.RecurringTransactionResponse
+		testsupport.DecodeData(t, w, &response)
+		require.NotNil(t, response.OccurrencesRemaining)
+		assert.Equal(t, 3, *response.OccurrencesRemaining)
+		assert.Nil(t, response.EndDate)
+
+		assert.Len(t, occurrences(t), 3)
+	})
+
+	t.Run("previews stop at the end date", func(t *testing.T) {
+		endDate := nextDue.AddDate(0, 1, 0).Format("2006-01-02")
+		w := server.Do(t, http.MethodPut, base+"/end-conditions", token, map[string]interface{}{"end_date": endDate})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		projected := occurrences(t)
+		require.Len(t, projected, 2)
+		assert.Equal(t, endDate, projected[1].Date)
+	})
+
+	t.Run("rejects invalid end conditions", func(t *testing.T) {
+		for _, body := range []map[string]interface{}{
+			{"end_date": nextDue.AddDate(0, 0, -1).Format("2006-01-02")},
+			{"end_date": "someday"},
+			{"occurrences_remaining": 0},
+		} {
+			w := server.Do(t, http.MethodPut, base+"/end-conditions", token, body)
+			assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		}
+	})
+
+	t.Run("deactivates once the last occurrence posts", func(t *testing.T) {
+		repo := database.NewGormRecurringTransactionRepository(db)
+		ctx := context.Background()
+
+		loaded, err := repo.FindByID(ctx, finance.NewRecurringTransactionID(int(recurring.ID)))
+		require.NoError(t, err)
+		remaining := 2
+		require.NoError(t, loaded.UpdateEndConditions(nil, &remaining))
+
+		loaded.RecordOccurrencePosted()
+		assert.True(t, loaded.IsActive())
+		loaded.RecordOccurrencePosted()
+		assert.False(t, loaded.IsActive())
+		require.NoError(t, repo.Save(ctx, loaded))
+
+		assert.Empty(t, occurrences(t))
+		w := server.Do(t, http.MethodPost, base+"/skip", token, nil)
+		assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	})
+}
+
+func TestRecurringPostingIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	ctx := context.Background()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	addRecurring := func(t *testing.T, description string, categoryID uint, remaining *int) database.RecurringTransaction {
+		recurring := database.RecurringTransaction{
+			UserID:               fixtures.User.ID,
+			CategoryID:           categoryID,
+			CurrencyID:           fixtures.Currency.ID,
+			Amount:               80,
+			Description:          description,
+			Frequency:            "weekly",
+			NextDueDate:          today.AddDate(0, 0, -10),
+			IsActive:             true,
+			OccurrencesRemaining: remaining,
+		}
+		require.NoError(t, db.Create(&recurring).Error)
+		return recurring
+	}
+	postedDates := func(t *testing.T, model interface{}, description string) []string {
+		var dates []time.Time
+		require.NoError(t, db.Model(model).Where("user_id = ? AND description = ?", fixtures.User.ID, description).Order("date").Pluck("date", &dates).Error)
+		formatted := make([]string, len(dates))
+		for i, date := range dates {
+			formatted[i] = date.Format("2006-01-02")
+		}
+		return formatted
+	}
+
+	gym := addRecurring(t, "Gym", fixtures.ExpenseCategory.ID, nil)
+	override := 95.0
+	require.NoError(t, db.Model(&gym).Update("next_amount_override", override).Error)
+	remaining := 1
+	salary := addRecurring(t, "Side job", fixtures.IncomeCategory.ID, &remaining)
+
+	posted, err := server.App.PostRecurring.Execute(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 3, posted)
+
+	t.Run("missed occurrences post on their own dates", func(t *testing.T) {
+		assert.Equal(t, []string{
+			today.AddDate(0, 0, -10).Format("2006-01-02"),
+			today.AddDate(0, 0, -3).Format("2006-01-02"),
+		}, postedDates(t, &database.Expense{}, "Gym"))
+
+		var amounts []float64
+		require.NoError(t, db.Model(&database.Expense{}).Where("description = ?", "Gym").Order("date").Pluck("amount", &amounts).Error)
+		assert.Equal(t, []float64{95, 80}, amounts)
+
+		require.NoError(t, db.First(&gym, gym.ID).Error)
+		assert.True(t, gym.NextDueDate.Equal(today.AddDate(0, 0, 4)))
+		assert.Nil(t, gym.NextAmountOverride)
+	})
+
+	t.Run("income categories post incomes and end conditions are kept", func(t *testing.T) {
+		assert.Equal(t, []string{today.AddDate(0, 0, -10).Format("2006-01-02")}, postedDates(t, &database.Income{}, "Side job"))
+
+		require.NoError(t, db.First(&salary, salary.ID).Error)
+		assert.False(t, salary.IsActive)
+		require.NotNil(t, salary.OccurrencesRemaining)
+		assert.Zero(t, *salary.OccurrencesRemaining)
+	})
+
+	t.Run("occurrences post once", func(t *testing.T) {
+		posted, err := server.App.PostRecurring.Execute(ctx, time.Now())
+		require.NoError(t, err)
+		assert.Zero(t, posted)
+	})
+}
+
+func TestMakeRecurringIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	expense := fixtures.AddExpense(t, db, 15.99, today.AddDate(0, -2, -3))
+	path := fmt.Sprintf("/api/v100/transactions/%d/make-recurring", expense.ID)
+
+	t.Run("creates a recurring transaction from the expense", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, path, token, map[string]interface{}{
+			"type":                  "expense",
+			"frequency":             "monthly",
+			"occurrences_remaining": 6,
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var response appFinance.RecurringTransactionResponse
+		testsupport.DecodeData(t, w, &response)
+		assert.NotZero(t, response.ID)
+		assert.Equal(t, 15.99, response.Amount)
+		assert.Equal(t, int(fixtures.ExpenseCategory.ID), response.CategoryID)
+		assert.Equal(t, int(fixtures.Currency.ID), response.CurrencyID)
+		assert.Equal(t, expense.Description, response.Description)
+		assert.Equal(t, "monthly", response.Frequency)
+		assert.Equal(t, today.AddDate(0, 1, -3).Format("2006-01-02"), response.NextDueDate)
+		require.NotNil(t, response.OccurrencesRemaining)
+		assert.Equal(t, 6, *response.OccurrencesRemaining)
+
+		w = server.Do(t, http.MethodGet, fmt.Sprintf("/api/v100/recurring-transactions/%d/occurrences", response.ID), token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var preview appFinance.RecurringOccurrencesResponse
+		testsupport.DecodeData(t, w, &preview)
+		assert.Len(t, preview.Occurrences, 6)
+	})
+
+	t.Run("accepts a chosen next due date", func(t *testing.T) {
+		nextDue := today.AddDate(0, 0, 7).Format("2006-01-02")
+		w := server.Do(t, http.MethodPost, path, token, map[string]interface{}{
+			"frequency":     "weekly",
+			"next_due_date": nextDue,
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var response appFinance.RecurringTransactionResponse
+		testsupport.DecodeData(t, w, &response)
+		assert.Equal(t, nextDue, response.NextDueDate)
+	})
+
+	t.Run("rejects invalid requests", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, path, token, map[string]interface{}{"frequency": "hourly"})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodPost, path, token, map[string]interface{}{"frequency": "monthly", "next_due_date": "soon"})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodPost, path, server.Token(t, fixtures.Admin), map[string]interface{}{"frequency": "monthly"})
+		assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodPost, "/api/v100/transactions/999999/make-recurring", token, map[string]interface{}{"frequency": "monthly"})
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+}
+
+// sentEmails keeps the recipients and bodies of emails instead of delivering them
+type sentEmails struct {
+	to     []string
+	bodies []string
+}
+
+func (m *sentEmails) Send(_ context.Context, to, _, body string) error {
+	m.to = append(m.to, to)
+	m.bodies = append(m.bodies, body)
+	return nil
+}
+
+func TestRecurringRemindersIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	today := now.Truncate(24 * time.Hour)
+	addRecurring := func(userID uint, description string, nextDue time.Time) database.RecurringTransaction {
+		recurring := database.RecurringTransaction{
+			UserID:      userID,
+			CategoryID:  fixtures.ExpenseCategory.ID,
+			CurrencyID:  fixtures.Currency.ID,
+			Amount:      15.99,
+			Description: description,
+			Frequency:   "monthly",
+			NextDueDate: nextDue,
+			IsActive:    true,
+		}
+		require.NoError(t, db.Create(&recurring).Error)
+		return recurring
+	}
+	soon := addRecurring(fixtures.User.ID, "Streaming", today.AddDate(0, 0, 2))
+	addRecurring(fixtures.User.ID, "Gym", today.AddDate(0, 0, 10))
+	addRecurring(fixtures.Admin.ID, "Hosting", today.AddDate(0, 0, 1))
+
+	// The admin has turned recurring reminders off
+	preferences := database.UserPreferences{UserID: fixtures.Admin.ID, PrimaryCurrencyID: fixtures.Currency.ID}
+	require.NoError(t, db.Create(&preferences).Error)
+	require.NoError(t, db.Model(&preferences).Update("recurring_reminders", false).Error)
+
+	recurringRepo := database.NewGormRecurringTransactionRepository(db)
+	notificationRepo := database.NewGormNotificationRepository(db)
+	dispatcher := appNotification.NewDispatcher(notificationRepo, database.NewGormNotificationChannelRepository(db), nil)
+	mailer := &sentEmails{}
+	reminders := appNotification.NewSendRecurringRemindersUseCase(
+		recurringRepo,
+		database.NewGormCurrencyRepository(db),
+		notificationRepo,
+		identity.NewUserService(database.NewGormUserRepository(db)),
+		dispatcher,
+		mailer,
+		3,
+	)
+
+	t.Run("reminds of charges due within the window", func(t *testing.T) {
+		sent, err := reminders.Execute(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+
+		var notifications []database.Notification
+		require.NoError(t, db.Where("type = ?", "recurring_reminder").Find(&notifications).Error)
+		require.Len(t, notifications, 1)
+		assert.Equal(t, fixtures.User.ID, notifications[0].UserID)
+		assert.Contains(t, notifications[0].Message, "Streaming of 15.99 "+fixtures.Currency.Code)
+
+		assert.Equal(t, []string{fixtures.User.Email}, mailer.to)
+	})
+
+	t.Run("reminds of each occurrence once", func(t *testing.T) {
+		sent, err := reminders.Execute(ctx, now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+	})
+
+	t.Run("reminds again when the occurrence moves", func(t *testing.T) {
+		loaded, err := recurringRepo.FindByID(ctx, finance.NewRecurringTransactionID(int(soon.ID)))
+		require.NoError(t, err)
+		earlier := today.AddDate(0, 0, 1)
+		require.NoError(t, loaded.OverrideNextOccurrence(nil, &earlier))
+		require.NoError(t, recurringRepo.Save(ctx, loaded))
+
+		sent, err := reminders.Execute(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+	})
+}
+
+func TestBudgetSuggestionsIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	now := time.Now().UTC()
+	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	fixtures.AddExpense(t, db, 100, currentMonth.AddDate(0, -1, 4))
+	fixtures.AddExpense(t, db, 50, currentMonth.AddDate(0, -2, 9))
+	fixtures.AddExpense(t, db, 30.5, currentMonth.AddDate(0, -3, 0))
+	fixtures.AddExpense(t, db, 999, currentMonth.AddDate(0, -4, 0)) // outside the default window
+	fixtures.AddExpense(t, db, 999, currentMonth)                   // the current month is never averaged
+
+	suggestions := func(t *testing.T, query string) appFinance.BudgetSuggestionsResponse {
+		w := server.Do(t, http.MethodGet, "/api/v100/budgets/suggestions"+query, token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appFinance.BudgetSuggestionsResponse
+		testsupport.DecodeData(t, w, &response)
+		return response
+	}
+
+	t.Run("averages the trailing complete months", func(t *testing.T) {
+		response := suggestions(t, "")
+		assert.Equal(t, 3, response.Months)
+		assert.Equal(t, currentMonth.AddDate(0, -3, 0).Format("2006-01-02"), response.StartDate)
+		assert.Equal(t, currentMonth.AddDate(0, 0, -1).Format("2006-01-02"), response.EndDate)
+
+		require.Len(t, response.Suggestions, 1)
+		suggestion := response.Suggestions[0]
+		assert.Equal(t, int(fixtures.ExpenseCategory.ID), suggestion.CategoryID)
+		assert.Equal(t, 60.17, suggestion.Average)
+		assert.Equal(t, 61.0, suggestion.Amount)
+		assert.Equal(t, 3, suggestion.MonthsWithSpending)
+		assert.False(t, suggestion.HasBudget)
+	})
+
+	t.Run("accepts a longer history", func(t *testing.T) {
+		response := suggestions(t, "?months=4")
+		require.Len(t, response.Suggestions, 1)
+		assert.Equal(t, 294.88, response.Suggestions[0].Average)
+
+		w := server.Do(t, http.MethodGet, "/api/v100/budgets/suggestions?months=12", token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("creates no budgets when one fails", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, "/api/v100/budgets/suggestions/accept", token, appFinance.AcceptBudgetSuggestionsRequest{
+			Budgets: []appFinance.AcceptedBudgetSuggestion{
+				{CategoryID: int(fixtures.ExpenseCategory.ID), Amount: 61},
+				{CategoryID: 999999, Amount: 20},
+			},
+		})
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+		var count int64
+		require.NoError(t, db.Model(&database.Budget{}).Count(&count).Error)
+		assert.Zero(t, count)
+	})
+
+	t.Run("a dry run lists the budgets without creating them", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, "/api/v100/budgets/suggestions/accept?dry_run=true", token, appFinance.AcceptBudgetSuggestionsRequest{
+			Budgets: []appFinance.AcceptedBudgetSuggestion{{CategoryID: int(fixtures.ExpenseCategory.ID), Amount: 61}},
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response struct {
+			Budgets []appFinance.CreateBudgetResponse `json:"budgets"`
+			DryRun  bool                              `json:"dry_run"`
+		}
+		testsupport.DecodeData(t, w, &response)
+		require.Len(t, response.Budgets, 1)
+		assert.Equal(t, 61.0, response.Budgets[0].Amount)
+		assert.True(t, response.DryRun)
+
+		var count int64
+		require.NoError(t, db.Model(&database.Budget{}).Count(&count).Error)
+		assert.Zero(t, count)
+
+		w = server.Do(t, http.MethodPost, "/api/v100/budgets/suggestions/accept?dry_run=true", token, appFinance.AcceptBudgetSuggestionsRequest{
+			Budgets: []appFinance.AcceptedBudgetSuggestion{{CategoryID: 999999, Amount: 20}},
+		})
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+
+	t.Run("creates the accepted budgets in one call", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, "/api/v100/budgets/suggestions/accept", token, appFinance.AcceptBudgetSuggestionsRequest{
+			Budgets: []appFinance.AcceptedBudgetSuggestion{{CategoryID: int(fixtures.ExpenseCategory.ID), Amount: 61}},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var response struct {
+			Budgets []appFinance.CreateBudgetResponse `json:"budgets"`
+		}
+		testsupport.DecodeData(t, w, &response)
+		require.Len(t, response.Budgets, 1)
+		assert.Equal(t, 61.0, response.Budgets[0].Amount)
+		assert.Equal(t, "monthly", response.Budgets[0].Period)
+		assert.Equal(t, currentMonth.Format("2006-01-02"), response.Budgets[0].StartDate)
+
+		assert.True(t, suggestions(t, "").Suggestions[0].HasBudget)
+	})
+}
+
+func TestAnomalyDetectionIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	today := now.Truncate(24 * time.Hour)
+	for i, amount := range []float64{20, 22, 18, 21, 19, 20} {
+		fixtures.AddExpense(t, db, amount, today.AddDate(0, 0, -10-7*i))
+	}
+	large := fixtures.AddExpense(t, db, 200, today.AddDate(0, 0, -1))
+
+	detector := server.App.AnomalyDetection
+
+	t.Run("preferences default to medium sensitivity", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v100/preferences", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var preferences appIdentity.PreferencesResponse
+		testsupport.DecodeData(t, w, &preferences)
+		assert.Equal(t, "medium", preferences.AnomalySensitivity)
+		assert.True(t, preferences.EmailNotifications)
+	})
+
+	t.Run("rejects an unknown sensitivity", func(t *testing.T) {
+		w := server.Do(t, http.MethodPut, "/api/v100/preferences", token, map[string]interface{}{"anomaly_sensitivity": "extreme"})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("flags a large expense and the category spike it causes", func(t *testing.T) {
+		flagged, err := detector.Execute(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 2, flagged)
+
+		var anomalies []database.Anomaly
+		require.NoError(t, db.Order("kind").Find(&anomalies).Error)
+		require.Len(t, anomalies, 2)
+		assert.Equal(t, "category_spike", anomalies[0].Kind)
+		assert.Equal(t, "large_transaction", anomalies[1].Kind)
+		require.NotNil(t, anomalies[1].TransactionID)
+		assert.Equal(t, large.ID, *anomalies[1].TransactionID)
+		assert.InDelta(t, 20, anomalies[1].Baseline, 0.01)
+
+		var notifications []database.Notification
+		require.NoError(t, db.Where("type = ?", "spending_anomaly").Find(&notifications).Error)
+		require.Len(t, notifications, 2)
+		assert.Equal(t, fixtures.User.ID, notifications[0].UserID)
+	})
+
+	t.Run("flags the same spending once", func(t *testing.T) {
+		flagged, err := detector.Execute(ctx, now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, flagged)
+	})
+
+	t.Run("flags nothing once the user turns detection off", func(t *testing.T) {
+		w := server.Do(t, http.MethodPut, "/api/v100/preferences", token, map[string]interface{}{
+			"anomaly_sensitivity": "off",
+			"email_notifications": false,
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var preferences appIdentity.PreferencesResponse
+		testsupport.DecodeData(t, w, &preferences)
+		assert.Equal(t, "off", preferences.AnomalySensitivity)
+		assert.False(t, preferences.EmailNotifications)
+		assert.True(t, preferences.BudgetAlerts)
+
+		fixtures.AddExpense(t, db, 500, today)
+		flagged, err := detector.Execute(ctx, now)
+		require.NoError(t, err)
+		assert.Zero(t, flagged)
+	})
+}
+
+func TestClosedMonthsIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	expense := fixtures.AddExpense(t, db, 40, time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC))
+	expensePath := fmt.Sprintf("/api/v100/expenses/%d", expense.ID)
+	update := func(t *testing.T, date string) *httptest.ResponseRecorder {
+		return server.Do(t, http.MethodPut, expensePath, token, map[string]interface{}{
+			"category_id": fixtures.ExpenseCategory.ID,
+			"amount":      45,
+			"description": "groceries",
+			"date":        date,
+		})
+	}
+
+	t.Run("only months that have ended can be closed", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, "/api/v100/closed-months", token, map[string]interface{}{"month": time.Now().UTC().Format("2006-01")})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "INVALID_MONTH")
+
+		w = server.Do(t, http.MethodPost, "/api/v100/closed-months", token, map[string]interface{}{"month": "March 2024"})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("closes a month once", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, "/api/v100/closed-months", token, map[string]interface{}{"month": "2024-03"})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodPost, "/api/v100/closed-months", token, map[string]interface{}{"month": "2024-03"})
+		assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "MONTH_ALREADY_CLOSED")
+
+		w = server.Do(t, http.MethodGet, "/api/v100/closed-months", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response struct {
+			ClosedMonths []appFinance.ClosedMonthResponse `json:"closed_months"`
+		}
+		testsupport.DecodeData(t, w, &response)
+		require.Len(t, response.ClosedMonths, 1)
+		assert.Equal(t, "2024-03", response.ClosedMonths[0].Month)
+	})
+
+	t.Run("transactions in a closed month cannot change", func(t *testing.T) {
+		w := update(t, "2024-04-02")
+		assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "MONTH_CLOSED")
+
+		w = server.Do(t, http.MethodDelete, expensePath, token, nil)
+		assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodPost, "/api/v100/expenses", token, map[string]interface{}{
+			"category_id": fixtures.ExpenseCategory.ID,
+			"currency_id": fixtures.Currency.ID,
+			"amount":      10,
+			"description": "late receipt",
+			"date":        "2024-03-30",
+		})
+		assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+
+		var model database.Expense
+		require.NoError(t, db.First(&model, expense.ID).Error)
+		assert.Equal(t, 40.0, model.Amount)
+	})
+
+	t.Run("transactions cannot move into a closed month", func(t *testing.T) {
+		other := fixtures.AddExpense(t, db, 15, time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC))
+		w := server.Do(t, http.MethodPut, fmt.Sprintf("/api/v100/expenses/%d", other.ID), token, map[string]interface{}{
+			"category_id": fixtures.ExpenseCategory.ID,
+			"amount":      15,
+			"description": "moved",
+			"date":        "2024-03-20",
+		})
+		assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	})
+
+	t.Run("a reopened month can change again", func(t *testing.T) {
+		w := server.Do(t, http.MethodDelete, "/api/v100/closed-months/2024-03", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodDelete, "/api/v100/closed-months/2024-03", token, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+		w = update(t, "2024-03-15")
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+}
+
+func TestAnalyticsByCurrencyIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	now := time.Now()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	fixtures.AddExpense(t, db, 30, thisMonth)
+	fixtures.AddIncome(t, db, 100, thisMonth)
+
+	euro := database.Currency{UserID: &fixtures.User.ID, Code: "ZZE", Name: "Test euro", Symbol: "€"}
+	require.NoError(t, db.Create(&euro).Error)
+	expense := database.Expense{
+		UserID:     fixtures.User.ID,
+		CategoryID: fixtures.ExpenseCategory.ID,
+		CurrencyID: euro.ID,
+		Amount:     12.5,
+		Date:       thisMonth,
+	}
+	require.NoError(t, db.Omit("User", "Category", "Currency").Create(&expense).Error)
+
+	w := server.Do(t, http.MethodGet, "/api/v100/analytics?period=monthly", token, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var analytics appFinance.GetAnalyticsResponse
+	testsupport.DecodeData(t, w, &analytics)
+	assert.Equal(t, 42.5, analytics.TotalSpent)
+	require.Len(t, analytics.ByCurrency, 2)
+
+	byCode := make(map[string]appFinance.CurrencyAnalytics)
+	for _, totals := range analytics.ByCurrency {
+		byCode[totals.CurrencyCode] = totals
+	}
+	assert.Equal(t, 30.0, byCode[fixtures.Currency.Code].TotalSpent)
+	assert.Equal(t, 100.0, byCode[fixtures.Currency.Code].TotalIncome)
+	assert.Equal(t, 70.0, byCode[fixtures.Currency.Code].NetAmount)
+	assert.Equal(t, 2, byCode[fixtures.Currency.Code].TransactionCount)
+	assert.Equal(t, 12.5, byCode["ZZE"].TotalSpent)
+	assert.Equal(t, -12.5, byCode["ZZE"].NetAmount)
+}
+
+func TestFXGainLossIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+	adminToken := server.Token(t, fixtures.Admin)
+
+	addTransaction := func(model interface{}) {
+		require.NoError(t, db.Omit("User", "Category", "Currency").Create(model).Error)
+	}
+	euro := database.Currency{UserID: &fixtures.User.ID, Code: "ZZE", Name: "Test euro", Symbol: "€"}
+	require.NoError(t, db.Create(&euro).Error)
+	addTransaction(&database.Income{UserID: fixtures.User.ID, CategoryID: fixtures.IncomeCategory.ID, CurrencyID: euro.ID,
+		Amount: 100, Date: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)})
+	addTransaction(&database.Expense{UserID: fixtures.User.ID, CategoryID: fixtures.ExpenseCategory.ID, CurrencyID: euro.ID,
+		Amount: 20, Date: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)})
+	yen := database.Currency{UserID: &fixtures.User.ID, Code: "ZZY", Name: "Test yen", Symbol: "¥"}
+	require.NoError(t, db.Create(&yen).Error)
+	addTransaction(&database.Income{UserID: fixtures.User.ID, CategoryID: fixtures.IncomeCategory.ID, CurrencyID: yen.ID,
+		Amount: 5000, Date: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)})
+	fixtures.AddIncome(t, db, 300, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+
+	setRate := func(t *testing.T, token, base, quote string, rate float64, date string) *httptest.ResponseRecorder {
+		return server.Do(t, http.MethodPost, "/api/admin/exchange-rates", token, map[string]interface{}{
+			"base": base, "quote": quote, "rate": rate, "date": date,
+		})
+	}
+
+	t.Run("only admins record rates", func(t *testing.T) {
+		w := setRate(t, token, "ZZE", fixtures.Currency.Code, 1.1, "2024-01-01")
+		assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	})
+
+	t.Run("rejects invalid rates", func(t *testing.T) {
+		w := setRate(t, adminToken, "ZZE", fixtures.Currency.Code, -1, "2024-01-01")
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "INVALID_EXCHANGE_RATE")
+
+		w = setRate(t, adminToken, "ZZE", "zze", 1.1, "2024-01-01")
+		assert.Contains(t, w.Body.String(), "INVALID_EXCHANGE_RATE")
+
+		w = setRate(t, adminToken, "ZZE", fixtures.Currency.Code, 1.1, "January 2024")
+		assert.Contains(t, w.Body.String(), "INVALID_EXCHANGE_RATE")
+	})
+
+	t.Run("a rate for the same day replaces the earlier one", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, setRate(t, adminToken, "ZZE", fixtures.Currency.Code, 1.05, "2024-01-01").Code)
+		require.Equal(t, http.StatusOK, setRate(t, adminToken, "zze", fixtures.Currency.Code, 1.10, "2024-01-01").Code)
+		require.Equal(t, http.StatusOK, setRate(t, adminToken, "ZZE", fixtures.Currency.Code, 1.20, "2024-03-01").Code)
+		// Recorded the other way round, so read as 1 / 0.8 = 1.25
+		require.Equal(t, http.StatusOK, setRate(t, adminToken, fixtures.Currency.Code, "ZZE", 0.8, "2024-06-01").Code)
+
+		w := server.Do(t, http.MethodGet, "/api/v100/exchange-rates?base=ZZE&quote="+fixtures.Currency.Code, token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response struct {
+			ExchangeRates []appFinance.ExchangeRateResponse `json:"exchange_rates"`
+		}
+		testsupport.DecodeData(t, w, &response)
+		require.Len(t, response.ExchangeRates, 2)
+		assert.Equal(t, "2024-01-01", response.ExchangeRates[0].Date)
+		assert.Equal(t, 1.10, response.ExchangeRates[0].Rate)
+	})
+
+	t.Run("values foreign holdings at historical and current rates", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v100/reports/fx-gain-loss", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var report appFinance.FXGainLossResponse
+		testsupport.DecodeData(t, w, &report)
+		assert.Equal(t, fixtures.Currency.Code, report.DefaultCurrency)
+		assert.Equal(t, []string{"ZZY"}, report.MissingRates)
+		require.Len(t, report.Currencies, 1)
+
+		position := report.Currencies[0]
+		assert.Equal(t, "ZZE", position.CurrencyCode)
+		assert.Equal(t, 80.0, position.NetPosition)
+		assert.Equal(t, 86.0, position.CostBasis) // 100 × 1.10 − 20 × 1.20
+		assert.Equal(t, 1.25, position.CurrentRate)
+		assert.Equal(t, "2024-06-01", position.RateDate)
+		assert.Equal(t, 100.0, position.CurrentValue)
+		assert.Equal(t, 14.0, position.UnrealizedGain)
+		assert.Equal(t, 14.0, report.TotalUnrealizedGain)
+	})
+}
+
+func TestAccountTransactionsIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	userToken := server.Token(t, fixtures.User)
+	adminToken := server.Token(t, fixtures.Admin)
+
+	w := server.Do(t, http.MethodPost, "/api/v100/accounts", userToken, map[string]interface{}{
+		"name": "Checking",
+		"type": "checking",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		Account appFinance.AccountResponse `json:"account"`
+	}
+	testsupport.DecodeData(t, w, &created)
+	transactionsPath := fmt.Sprintf("/api/v100/accounts/%d/transactions", created.Account.ID)
+
+	record := func(t *testing.T, path string, categoryID uint, amount float64, date string, accountID interface{}) {
+		w := server.Do(t, http.MethodPost, path, userToken, map[string]interface{}{
+			"category_id": categoryID,
+			"amount":      amount,
+			"date":        date,
+			"account_id":  accountID,
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+	record(t, "/api/v100/incomes", fixtures.IncomeCategory.ID, 500, "2024-01-05", created.Account.ID)
+	record(t, "/api/v100/expenses", fixtures.ExpenseCategory.ID, 120, "2024-01-20", created.Account.ID)
+	record(t, "/api/v100/expenses", fixtures.ExpenseCategory.ID, 30, "2024-02-10", created.Account.ID)
+	record(t, "/api/v100/incomes", fixtures.IncomeCategory.ID, 75.5, "2024-02-10", created.Account.ID)
+	record(t, "/api/v100/expenses", fixtures.ExpenseCategory.ID, 999, "2024-02-11", nil)
+
+	get := func(t *testing.T, token, query string) appFinance.AccountTransactionsResponse {
+		w := server.Do(t, http.MethodGet, transactionsPath+query, token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appFinance.AccountTransactionsResponse
+		testsupport.DecodeData(t, w, &response)
+		return response
+	}
+
+	t.Run("runs the balance oldest first", func(t *testing.T) {
+		response := get(t, userToken, "")
+		assert.Equal(t, 0.0, response.OpeningBalance)
+		require.Len(t, response.Transactions, 4)
+
+		var balances []float64
+		for _, transaction := range response.Transactions {
+			balances = append(balances, transaction.RunningBalance)
+		}
+		assert.Equal(t, []float64{500, 380, 350, 425.5}, balances)
+		assert.Equal(t, "2024-01-05", response.Transactions[0].Date)
+		assert.Equal(t, 425.5, response.ClosingBalance)
+	})
+
+	t.Run("a period opens with the balance before it", func(t *testing.T) {
+		response := get(t, userToken, "?start_date=2024-02-01&end_date=2024-02-10")
+		assert.Equal(t, 380.0, response.OpeningBalance)
+		require.Len(t, response.Transactions, 2)
+		assert.Equal(t, 350.0, response.Transactions[0].RunningBalance)
+		assert.Equal(t, 425.5, response.ClosingBalance)
+	})
+
+	t.Run("rejects an invalid period", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, transactionsPath+"?start_date=2024-03-01&end_date=2024-02-01", userToken, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodGet, transactionsPath+"?start_date=February", userToken, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("other users' accounts are not found", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, transactionsPath, adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+}
+
+func TestCardStatementsIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	createAccount := func(t *testing.T, accountType string) appFinance.AccountResponse {
+		w := server.Do(t, http.MethodPost, "/api/v100/accounts", token, map[string]interface{}{
+			"name": "Visa",
+			"type": accountType,
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var created struct {
+			Account appFinance.AccountResponse `json:"account"`
+		}
+		testsupport.DecodeData(t, w, &created)
+		return created.Account
+	}
+	card := createAccount(t, "credit_card")
+	checking := createAccount(t, "checking")
+	cyclePath := fmt.Sprintf("/api/v100/accounts/%d/billing-cycle", card.ID)
+	statementsPath := fmt.Sprintf("/api/v100/accounts/%d/statements", card.ID)
+
+	cycle, err := finance.NewBillingCycle(15, 5)
+	require.NoError(t, err)
+	now := time.Now().UTC()
+	lastClosing := cycle.PreviousClosingDate(cycle.ClosingDateFor(now))
+	closingBefore := cycle.PreviousClosingDate(lastClosing)
+
+	addTransaction := func(model interface{}) {
+		require.NoError(t, db.Omit("User", "Category", "Currency").Create(model).Error)
+	}
+	accountID := uint(card.ID)
+	charge := func(amount float64, date time.Time) {
+		addTransaction(&database.Expense{UserID: fixtures.User.ID, CategoryID: fixtures.ExpenseCategory.ID,
+			CurrencyID: fixtures.Currency.ID, AccountID: &accountID, Amount: amount, Date: date})
+	}
+	charge(50, closingBefore)
+	charge(100, closingBefore.AddDate(0, 0, 1))
+	charge(200, lastClosing)
+	charge(40, lastClosing.AddDate(0, 0, 1))
+	addTransaction(&database.Income{UserID: fixtures.User.ID, CategoryID: fixtures.IncomeCategory.ID,
+		CurrencyID: fixtures.Currency.ID, AccountID: &accountID, Amount: 10, Date: lastClosing.AddDate(0, 0, 1)})
+
+	t.Run("statements need a billing cycle", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, statementsPath, token, nil)
+		assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "NO_BILLING_CYCLE")
+	})
+
+	t.Run("only credit cards take valid billing cycles", func(t *testing.T) {
+		w := server.Do(t, http.MethodPut, fmt.Sprintf("/api/v100/accounts/%d/billing-cycle", checking.ID), token,
+			map[string]interface{}{"closing_day": 15, "due_day": 5})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "INVALID_BILLING_CYCLE")
+
+		w = server.Do(t, http.MethodPut, cyclePath, token, map[string]interface{}{"closing_day": 32, "due_day": 5})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "INVALID_BILLING_CYCLE")
+	})
+
+	t.Run("summarizes spending per cycle", func(t *testing.T) {
+		w := server.Do(t, http.MethodPut, cyclePath, token, map[string]interface{}{"closing_day": 15, "due_day": 5})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var updated struct {
+			Account appFinance.AccountResponse `json:"account"`
+		}
+		testsupport.DecodeData(t, w, &updated)
+		require.NotNil(t, updated.Account.BillingCycle)
+		assert.Equal(t, 15, updated.Account.BillingCycle.ClosingDay)
+
+		w = server.Do(t, http.MethodGet, statementsPath+"?count=2", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appFinance.CardStatementsResponse
+		testsupport.DecodeData(t, w, &response)
+		require.Len(t, response.Statements, 2)
+
+		open, closed := response.Statements[0], response.Statements[1]
+		assert.True(t, open.Open)
+		assert.Equal(t, 350.0, open.PreviousBalance)
+		assert.Equal(t, 40.0, open.Charges)
+		assert.Equal(t, 10.0, open.Payments)
+		assert.Equal(t, 380.0, open.Balance)
+
+		assert.False(t, closed.Open)
+		assert.Equal(t, lastClosing.Format("2006-01-02"), closed.ClosingDate)
+		assert.Equal(t, cycle.DueDate(lastClosing).Format("2006-01-02"), closed.DueDate)
+		assert.Equal(t, 50.0, closed.PreviousBalance)
+		assert.Equal(t, 300.0, closed.Charges)
+		assert.Equal(t, 350.0, closed.Balance)
+		assert.Equal(t, 17.5, closed.MinimumPayment)
+		assert.Equal(t, 2, closed.TransactionCount)
+	})
+
+	t.Run("reminds of the minimum payment shortly before it is due", func(t *testing.T) {
+		ctx := context.Background()
+		due := cycle.DueDate(lastClosing)
+
+		sent, err := server.App.CardPaymentReminders.Execute(ctx, due.AddDate(0, 0, -10))
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+
+		sent, err = server.App.CardPaymentReminders.Execute(ctx, due.AddDate(0, 0, -1))
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+
+		var notifications []database.Notification
+		require.NoError(t, db.Where("type = ?", "card_payment_reminder").Find(&notifications).Error)
+		require.Len(t, notifications, 1)
+		assert.Contains(t, notifications[0].Message, "Pay at least 17.50 "+fixtures.Currency.Code)
+
+		sent, err = server.App.CardPaymentReminders.Execute(ctx, due.AddDate(0, 0, -1))
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+	})
+
+	t.Run("removing the cycle stops statements", func(t *testing.T) {
+		w := server.Do(t, http.MethodDelete, cyclePath, token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.NotContains(t, w.Body.String(), "billing_cycle")
+	})
+}
+
+func TestAccountArchivingIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	w := server.Do(t, http.MethodPost, "/api/v100/accounts", token, map[string]interface{}{
+		"name":            "Savings",
+		"type":            "savings",
+		"opening_balance": 100,
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		Account appFinance.AccountResponse `json:"account"`
+	}
+	testsupport.DecodeData(t, w, &created)
+	assert.Equal(t, 100.0, created.Account.OpeningBalance)
+	accountPath := fmt.Sprintf("/api/v100/accounts/%d", created.Account.ID)
+
+	record := func(t *testing.T, date string) *httptest.ResponseRecorder {
+		return server.Do(t, http.MethodPost, "/api/v100/incomes", token, map[string]interface{}{
+			"category_id": fixtures.IncomeCategory.ID,
+			"amount":      50,
+			"date":        date,
+			"account_id":  created.Account.ID,
+		})
+	}
+	require.Equal(t, http.StatusCreated, record(t, "2024-01-05").Code)
+
+	ledger := func(t *testing.T) appFinance.AccountTransactionsResponse {
+		w := server.Do(t, http.MethodGet, accountPath+"/transactions", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appFinance.AccountTransactionsResponse
+		testsupport.DecodeData(t, w, &response)
+		return response
+	}
+	listAccounts := func(t *testing.T, query string) []appFinance.AccountResponse {
+		w := server.Do(t, http.MethodGet, "/api/v100/accounts"+query, token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response struct {
+			Accounts []appFinance.AccountResponse `json:"accounts"`
+		}
+		testsupport.DecodeData(t, w, &response)
+		return response.Accounts
+	}
+
+	var adjustmentID int
+	t.Run("adjusts the derived balance to a known one", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, accountPath+"/adjustments", token, map[string]interface{}{
+			"date":        "2024-01-31",
+			"balance":     170,
+			"description": "Interest",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var response struct {
+			Adjustment appFinance.BalanceAdjustmentResponse `json:"balance_adjustment"`
+		}
+		testsupport.DecodeData(t, w, &response)
+		assert.Equal(t, 20.0, response.Adjustment.Amount)
+		adjustmentID = response.Adjustment.ID
+
+		w = server.Do(t, http.MethodPost, accountPath+"/adjustments", token, map[string]interface{}{
+			"date":    "2024-01-31",
+			"balance": 170,
+		})
+		assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "BALANCE_ALREADY_MATCHES")
+	})
+
+	t.Run("the ledger runs from the opening balance through adjustments", func(t *testing.T) {
+		response := ledger(t)
+		assert.Equal(t, 100.0, response.OpeningBalance)
+		require.Len(t, response.Transactions, 2)
+		assert.Equal(t, 150.0, response.Transactions[0].RunningBalance)
+		assert.Equal(t, "adjustment", response.Transactions[1].Type)
+		assert.Equal(t, 170.0, response.ClosingBalance)
+
+		w := server.Do(t, http.MethodPost, accountPath+"/balance-assertions", token, map[string]interface{}{
+			"date":    "2024-01-31",
+			"balance": 170,
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), `"holds":true`)
+	})
+
+	t.Run("archived accounts are hidden and take no new transactions", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, accountPath+"/archive", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "archived_at")
+
+		assert.Empty(t, listAccounts(t, ""))
+		assert.Len(t, listAccounts(t, "?include_archived=true"), 1)
+
+		w = record(t, "2024-02-05")
+		assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "ACCOUNT_ARCHIVED")
+		assert.Equal(t, 170.0, ledger(t).ClosingBalance)
+	})
+
+	t.Run("unarchived accounts take transactions again", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, accountPath+"/unarchive", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Len(t, listAccounts(t, ""), 1)
+		assert.Equal(t, http.StatusCreated, record(t, "2024-02-05").Code)
+	})
+
+	t.Run("deleting an adjustment restores the derived balance", func(t *testing.T) {
+		w := server.Do(t, http.MethodDelete, fmt.Sprintf("%s/adjustments/%d", accountPath, adjustmentID), token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, 200.0, ledger(t).ClosingBalance)
+
+		w = server.Do(t, http.MethodDelete, fmt.Sprintf("%s/adjustments/%d", accountPath, adjustmentID), token, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+}
+
+func TestInvestmentsIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	w := server.Do(t, http.MethodPost, "/api/v100/accounts", token, map[string]interface{}{
+		"name":            "Checking",
+		"type":            "checking",
+		"opening_balance": 500,
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = server.Do(t, http.MethodPost, "/api/v100/investments/holdings", token, map[string]interface{}{
+		"ticker":     "vwrl",
+		"quantity":   10,
+		"cost_basis": 1000,
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		Holding appFinance.HoldingResponse `json:"holding"`
+	}
+	testsupport.DecodeData(t, w, &created)
+	assert.Equal(t, "VWRL", created.Holding.Ticker)
+	assert.Nil(t, created.Holding.Value)
+	holdingPath := fmt.Sprintf("/api/v100/investments/holdings/%d", created.Holding.ID)
+
+	netWorth := func(t *testing.T) appFinance.NetWorthResponse {
+		w := server.Do(t, http.MethodGet, "/api/v100/reports/net-worth", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appFinance.NetWorthResponse
+		testsupport.DecodeData(t, w, &response)
+		return response
+	}
+
+	t.Run("rejects invalid holdings", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, "/api/v100/investments/holdings", token, map[string]interface{}{
+			"ticker":     "VWRL",
+			"quantity":   -1,
+			"cost_basis": 1000,
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "INVALID_HOLDING")
+	})
+
+	t.Run("unpriced holdings count at cost basis", func(t *testing.T) {
+		response := netWorth(t)
+		require.Len(t, response.Holdings, 1)
+		assert.False(t, response.Holdings[0].Priced)
+		assert.Equal(t, 1000.0, response.Holdings[0].MarketValue)
+		assert.Equal(t, 1500.0, response.NetWorth)
+	})
+
+	t.Run("recorded prices value holdings", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, holdingPath+"/prices", token, map[string]interface{}{"price": 120})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodGet, "/api/v100/investments/holdings", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var listed struct {
+			Holdings []appFinance.HoldingResponse `json:"holdings"`
+		}
+		testsupport.DecodeData(t, w, &listed)
+		require.Len(t, listed.Holdings, 1)
+		require.NotNil(t, listed.Holdings[0].Value)
+		assert.Equal(t, 1200.0, *listed.Holdings[0].Value)
+		assert.Equal(t, 200.0, *listed.Holdings[0].UnrealizedGain)
+
+		response := netWorth(t)
+		assert.True(t, response.Holdings[0].Priced)
+		assert.Equal(t, 1700.0, response.NetWorth)
+	})
+
+	t.Run("changing the quantity revalues the holding", func(t *testing.T) {
+		w := server.Do(t, http.MethodPut, holdingPath, token, map[string]interface{}{
+			"ticker":     "VWRL",
+			"quantity":   5,
+			"cost_basis": 500,
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, 1100.0, netWorth(t).NetWorth)
+	})
+
+	t.Run("other users' holdings are not found", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, holdingPath+"/prices", server.Token(t, fixtures.Admin), nil)
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "HOLDING_NOT_FOUND")
+	})
+
+	t.Run("deletes holdings with their prices", func(t *testing.T) {
+		w := server.Do(t, http.MethodDelete, holdingPath, token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var count int64
+		require.NoError(t, db.Model(&database.HoldingSnapshot{}).Count(&count).Error)
+		assert.Zero(t, count)
+		assert.Equal(t, 500.0, netWorth(t).NetWorth)
+	})
+}
+
+func TestEmergencyFundIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	w := server.Do(t, http.MethodPost, "/api/v100/accounts", token, map[string]interface{}{
+		"name":            "Rainy day",
+		"type":            "savings",
+		"opening_balance": 6000,
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		Account appFinance.AccountResponse `json:"account"`
+	}
+	testsupport.DecodeData(t, w, &created)
+	assert.False(t, created.Account.EmergencyFund)
+
+	lastMonth := finance.MonthStart(time.Now()).AddDate(0, -1, 0)
+	for _, day := range []int{3, 17} {
+		w := server.Do(t, http.MethodPost, "/api/v100/expenses", token, appFinance.CreateTransactionRequest{
+			CategoryID: int(fixtures.ExpenseCategory.ID),
+			Amount:     1500,
+			Date:       lastMonth.AddDate(0, 0, day-1).Format("2006-01-02"),
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	emergencyFund := func(t *testing.T, query string) appFinance.EmergencyFundResponse {
+		w := server.Do(t, http.MethodGet, "/api/v100/dashboard/emergency-fund"+query, token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appFinance.EmergencyFundResponse
+		testsupport.DecodeData(t, w, &response)
+		return response
+	}
+
+	t.Run("is empty until an account is designated", func(t *testing.T) {
+		response := emergencyFund(t, "?months=1")
+		assert.Empty(t, response.Accounts)
+		assert.Equal(t, 3000.0, response.AverageMonthlySpend)
+		require.NotNil(t, response.MonthsCovered)
+		assert.Zero(t, *response.MonthsCovered)
+	})
+
+	t.Run("covers months of average spending", func(t *testing.T) {
+		w := server.Do(t, http.MethodPut, fmt.Sprintf("/api/v100/accounts/%d/emergency-fund", created.Account.ID), token, map[string]interface{}{
+			"emergency_fund": true,
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		response := emergencyFund(t, "?months=1")
+		require.Len(t, response.Accounts, 1)
+		assert.Equal(t, 6000.0, response.FundBalance)
+		require.NotNil(t, response.MonthsCovered)
+		assert.Equal(t, 2.0, *response.MonthsCovered)
+
+		response = emergencyFund(t, "?months=3")
+		assert.Equal(t, 1000.0, response.AverageMonthlySpend)
+		assert.Equal(t, 6.0, *response.MonthsCovered)
+	})
+
+	t.Run("rejects lookbacks out of range", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v100/dashboard/emergency-fund?months=30", token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+}
+
+func TestSpendingPatternsIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	for date, amount := range map[string]float64{"2024-06-01": 60, "2024-06-03": 20, "2024-06-10": 500} {
+		w := server.Do(t, http.MethodPost, "/api/v100/expenses", token, appFinance.CreateTransactionRequest{
+			CategoryID: int(fixtures.ExpenseCategory.ID),
+			Amount:     amount,
+			Date:       date,
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	t.Run("groups expenses by weekday and hour", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v100/analytics/spending-patterns?start_date=2024-06-01&end_date=2024-06-07&timezone=Asia/Jakarta", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appFinance.SpendingPatternsResponse
+		testsupport.DecodeData(t, w, &response)
+
+		require.Len(t, response.ByWeekday, 7)
+		assert.Equal(t, "monday", response.ByWeekday[0].Weekday)
+		assert.Equal(t, 20.0, response.ByWeekday[0].TotalSpent)
+		assert.Equal(t, "saturday", response.ByWeekday[5].Weekday)
+		assert.Equal(t, 60.0, response.ByWeekday[5].DailyAverage)
+		assert.Equal(t, 80.0, response.TotalSpent)
+		assert.Equal(t, 4.0, response.WeekdayDailyAverage)
+		assert.Equal(t, 30.0, response.WeekendDailyAverage)
+
+		require.Len(t, response.ByHour, 24)
+		count := 0
+		for _, hour := range response.ByHour {
+			count += hour.TransactionCount
+		}
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("rejects invalid periods and time zones", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v100/analytics/spending-patterns?start_date=2024-06-07&end_date=2024-06-01", token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "INVALID_DATE_RANGE")
+
+		w = server.Do(t, http.MethodGet, "/api/v100/analytics/spending-patterns?timezone=Mars/Olympus", token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "INVALID_TIMEZONE")
+	})
+}
+
+func TestSpendingBenchmarksIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	var otherCategory database.Category
+	require.NoError(t, db.Where("is_default = ? AND category_type = ? AND id <> ?", true, "expense", fixtures.ExpenseCategory.ID).First(&otherCategory).Error)
+
+	lastMonth := finance.MonthStart(time.Now()).AddDate(0, -1, 0)
+	spend := func(t *testing.T, userID, categoryID uint, amount float64) {
+		expense := database.Expense{
+			UserID:     userID,
+			CategoryID: categoryID,
+			CurrencyID: fixtures.Currency.ID,
+			Amount:     amount,
+			Date:       lastMonth.AddDate(0, 0, 9),
+		}
+		require.NoError(t, db.Omit("User", "Category", "Currency").Create(&expense).Error)
+	}
+	addPeer := func(t *testing.T, email string, categoryID uint, amount float64) {
+		peer := database.User{Email: email, PasswordHash: "x", Role: "user"}
+		require.NoError(t, db.Create(&peer).Error)
+		preferences := database.UserPreferences{UserID: peer.ID, PrimaryCurrencyID: fixtures.Currency.ID}
+		require.NoError(t, db.Create(&preferences).Error)
+		require.NoError(t, db.Model(&preferences).Update("benchmarking", true).Error)
+		spend(t, peer.ID, categoryID, amount)
+	}
+
+	spend(t, fixtures.User.ID, fixtures.ExpenseCategory.ID, 300)
+	for i, amount := range []float64{100, 200, 400, 500} {
+		addPeer(t, fmt.Sprintf("peer%d@example.com", i), fixtures.ExpenseCategory.ID, amount)
+	}
+	// Users who did not opt in are never compared
+	spend(t, fixtures.Admin.ID, fixtures.ExpenseCategory.ID, 1000)
+
+	benchmarks := func(t *testing.T) appFinance.SpendingBenchmarksResponse {
+		w := server.Do(t, http.MethodGet, "/api/v100/analytics/benchmarks?months=1", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appFinance.SpendingBenchmarksResponse
+		testsupport.DecodeData(t, w, &response)
+		return response
+	}
+
+	t.Run("requires opting in", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v100/analytics/benchmarks", token, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "BENCHMARKING_DISABLED")
+
+		w = server.Do(t, http.MethodPut, "/api/v100/preferences", token, map[string]interface{}{"benchmarking": true})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+
+	t.Run("hides comparisons with too few peers", func(t *testing.T) {
+		response := benchmarks(t)
+		assert.Nil(t, response.PeerCount)
+		assert.Empty(t, response.Categories)
+	})
+
+	t.Run("ranks spending among peers", func(t *testing.T) {
+		addPeer(t, "peer-elsewhere@example.com", otherCategory.ID, 50)
+
+		response := benchmarks(t)
+		require.NotNil(t, response.PeerCount)
+		assert.Equal(t, 5, *response.PeerCount)
+		require.Len(t, response.Categories, 1)
+		assert.Equal(t, int(fixtures.ExpenseCategory.ID), response.Categories[0].CategoryID)
+		assert.Equal(t, 300.0, response.Categories[0].MonthlySpend)
+		assert.Equal(t, 200.0, response.Categories[0].PeerMonthlyMedian)
+		assert.Equal(t, 60, response.Categories[0].Percentile)
+	})
+}
+
+func TestSpendingByPeriodIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	month := finance.MonthStart(time.Now())
+	fixtures.AddExpense(t, db, 300, month.AddDate(0, -5, 3))
+	fixtures.AddExpense(t, db, 100, month.AddDate(0, -3, 3))
+	fixtures.AddExpense(t, db, 200, month.AddDate(0, -2, 3))
+	fixtures.AddExpense(t, db, 300, month.AddDate(0, -1, 3))
+	fixtures.AddExpense(t, db, 400, month)
+	fixtures.AddIncome(t, db, 5000, month)
+
+	t.Run("smooths monthly spending", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v100/analytics/spending-by-period?periods=4", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appFinance.SpendingByPeriodResponse
+		testsupport.DecodeData(t, w, &response)
+
+		assert.Equal(t, "monthly", response.Period)
+		require.Len(t, response.Periods, 4)
+		assert.Equal(t, month.AddDate(0, -3, 0).Format("2006-01-02"), response.Periods[0].StartDate)
+		assert.Equal(t, month.AddDate(0, -2, -1).Format("2006-01-02"), response.Periods[0].EndDate)
+		assert.Equal(t, month.Format("2006-01-02"), response.Periods[3].StartDate)
+
+		// The first rolling average reaches back before the listed periods
+		assert.Equal(t, 133.33, response.Periods[0].RollingAverage)
+		assert.Equal(t, 300.0, response.Periods[3].RollingAverage)
+		assert.Equal(t, 400.0, response.Periods[3].TotalSpent)
+		assert.Equal(t, 1, response.Periods[3].TransactionCount)
+
+		assert.Equal(t, 100.0, response.TrendSlope)
+		for i, period := range response.Periods {
+			assert.Equal(t, float64(100*(i+1)), period.Trend)
+		}
+	})
+
+	t.Run("groups by week", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v100/analytics/spending-by-period?period=weekly&periods=2", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appFinance.SpendingByPeriodResponse
+		testsupport.DecodeData(t, w, &response)
+
+		require.Len(t, response.Periods, 2)
+		start, err := time.Parse("2006-01-02", response.Periods[1].StartDate)
+		require.NoError(t, err)
+		assert.Equal(t, time.Monday, start.Weekday())
+	})
+
+	t.Run("rejects unknown periods", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v100/analytics/spending-by-period?period=daily", token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+}
+
+func TestBudgetCalendarIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	w := server.Do(t, http.MethodPost, "/api/v100/budgets", token, map[string]interface{}{
+		"category_id": fixtures.ExpenseCategory.ID,
+		"amount":      310,
+		"period":      "monthly",
+		"start_date":  "2024-05-01",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	fixtures.AddExpense(t, db, 25, time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC))
+	var otherCategory database.Category
+	require.NoError(t, db.Where("is_default = ? AND category_type = ? AND id <> ?", true, "expense", fixtures.ExpenseCategory.ID).First(&otherCategory).Error)
+	unbudgeted := database.Expense{
+		UserID:     fixtures.User.ID,
+		CategoryID: otherCategory.ID,
+		CurrencyID: fixtures.Currency.ID,
+		Amount:     40,
+		Date:       time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, db.Omit("User", "Category", "Currency").Create(&unbudgeted).Error)
+
+	t.Run("spreads budgets over the month", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v100/budgets/calendar?month=2024-05", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appFinance.BudgetCalendarResponse
+		testsupport.DecodeData(t, w, &response)
+
+		assert.Equal(t, "2024-05", response.Month)
+		require.Len(t, response.Days, 31)
+		assert.Equal(t, appFinance.BudgetCalendarDay{Date: "2024-05-01", Planned: 10, Actual: 0, Remaining: 10}, response.Days[0])
+		assert.Equal(t, appFinance.BudgetCalendarDay{Date: "2024-05-02", Planned: 10, Actual: 25, Remaining: -5}, response.Days[1])
+		assert.Equal(t, 285.0, response.Days[30].Remaining)
+		assert.Equal(t, 310.0, response.TotalPlanned)
+		assert.Equal(t, 25.0, response.TotalActual)
+		assert.Nil(t, response.SafeToSpendToday)
+	})
+
+	t.Run("reports safe to spend for the current month", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v100/budgets/calendar", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appFinance.BudgetCalendarResponse
+		testsupport.DecodeData(t, w, &response)
+
+		assert.Equal(t, time.Now().UTC().Format("2006-01"), response.Month)
+		require.NotNil(t, response.SafeToSpendToday)
+		assert.Equal(t, 0.0, *response.SafeToSpendToday)
+	})
+
+	t.Run("rejects invalid months", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v100/budgets/calendar?month=2024-13", token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "INVALID_MONTH")
+	})
+}
+
+func TestSafeToSpendIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	month := finance.MonthStart(time.Now())
+	lastDay := month.AddDate(0, 1, -1).Format("2006-01-02")
+	var otherCategory database.Category
+	require.NoError(t, db.Where("is_default = ? AND category_type = ? AND id <> ?", true, "expense", fixtures.ExpenseCategory.ID).First(&otherCategory).Error)
+
+	w := server.Do(t, http.MethodPost, "/api/v100/budgets", token, map[string]interface{}{
+		"category_id": fixtures.ExpenseCategory.ID,
+		"amount":      310,
+		"period":      "monthly",
+		"start_date":  month.Format("2006-01-02"),
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	fixtures.AddIncome(t, db, 3000, month)
+	budgeted := fixtures.AddExpense(t, db, 50, month)
+	unbudgeted := database.Expense{
+		UserID:      fixtures.User.ID,
+		CategoryID:  otherCategory.ID,
+		CurrencyID:  fixtures.Currency.ID,
+		Amount:      40,
+		Description: "Phone",
+		Date:        month,
+	}
+	require.NoError(t, db.Omit("User", "Category", "Currency").Create(&unbudgeted).Error)
+
+	// The phone bill is due again this month; the budgeted bill is left to its budget
+	for _, id := range []uint{unbudgeted.ID, budgeted.ID} {
+		w := server.Do(t, http.MethodPost, fmt.Sprintf("/api/v100/transactions/%d/make-recurring", id), token, map[string]interface{}{
+			"type":          "expense",
+			"frequency":     "monthly",
+			"next_due_date": lastDay,
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	w = server.Do(t, http.MethodGet, "/api/v100/dashboard/safe-to-spend", token, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response appFinance.SafeToSpendResponse
+	testsupport.DecodeData(t, w, &response)
+
+	assert.Equal(t, month.Format("2006-01-02"), response.StartDate)
+	assert.Equal(t, lastDay, response.EndDate)
+	assert.Equal(t, 3000.0, response.IncomeReceived)
+	assert.Equal(t, 310.0, response.BudgetAllocations)
+	assert.Equal(t, 40.0, response.SpentOutsideBudgets)
+	assert.Equal(t, 40.0, response.UpcomingBills)
+	require.Len(t, response.Bills, 1)
+	assert.Equal(t, "Phone", response.Bills[0].Description)
+	assert.Equal(t, lastDay, response.Bills[0].Date)
+	assert.Equal(t, 2610.0, response.SafeToSpend)
+}
+
+func TestTransactionLocationIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	create := func(description string, latitude, longitude *float64) *httptest.ResponseRecorder {
+		return server.Do(t, http.MethodPost, "/api/v100/expenses", token, appFinance.CreateTransactionRequest{
+			CategoryID:  int(fixtures.ExpenseCategory.ID),
+			Amount:      10,
+			Description: description,
+			Date:        "2024-03-01",
+			Latitude:    latitude,
+			Longitude:   longitude,
+		})
+	}
+	coordinate := func(value float64) *float64 { return &value }
+	list := func(bbox string) []appFinance.TransactionResponse {
+		w := server.Do(t, http.MethodGet, "/api/v100/transactions?bbox="+bbox, token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appFinance.GetAllTransactionsResponse
+		testsupport.DecodeData(t, w, &response)
+		return response.Transactions
+	}
+
+	for _, w := range []*httptest.ResponseRecorder{
+		create("Jakarta", coordinate(-6.2088), coordinate(106.8456)),
+		create("Fiji", coordinate(-17.7134), coordinate(178.065)),
+		create("Nowhere", nil, nil),
+	} {
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	t.Run("filters by map bounds", func(t *testing.T) {
+		transactions := list("106.7,-6.4,107.0,-6.1")
+		require.Len(t, transactions, 1)
+		assert.Equal(t, "Jakarta", transactions[0].Description)
+		require.NotNil(t, transactions[0].Latitude)
+		require.NotNil(t, transactions[0].Longitude)
+		assert.Equal(t, -6.2088, *transactions[0].Latitude)
+		assert.Equal(t, 106.8456, *transactions[0].Longitude)
+	})
+
+	t.Run("wraps boxes across the antimeridian", func(t *testing.T) {
+		transactions := list("170,-20,-170,-10")
+		require.Len(t, transactions, 1)
+		assert.Equal(t, "Fiji", transactions[0].Description)
+	})
+
+	t.Run("requires both coordinates", func(t *testing.T) {
+		w := create("Half", coordinate(-6.2), nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "INVALID_LOCATION")
+
+		w = create("Off the map", coordinate(91), coordinate(0))
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "INVALID_LOCATION")
+	})
+
+	t.Run("rejects malformed boxes", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v100/transactions?bbox=1,2,3", token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "INVALID_BBOX")
+	})
+}
+
+func TestReceiptLineItemsIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	expense := fixtures.AddExpense(t, db, 23.5, time.Now())
+	path := fmt.Sprintf("/api/v100/expenses/%d/line-items", expense.ID)
+	var otherCategory database.Category
+	require.NoError(t, db.Where("is_default = ? AND category_type = ? AND id <> ?", true, "expense", fixtures.ExpenseCategory.ID).First(&otherCategory).Error)
+
+	t.Run("starts without line items", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, path, token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appFinance.LineItemsResponse
+		testsupport.DecodeData(t, w, &response)
+
+		assert.Empty(t, response.Items)
+		assert.Equal(t, 23.5, response.Unitemized)
+	})
+
+	t.Run("replaces line items", func(t *testing.T) {
+		w := server.Do(t, http.MethodPut, path, token, map[string]interface{}{
+			"items": []map[string]interface{}{
+				{"name": "Milk 1L", "quantity": 2, "unit_price": 1.75},
+				{"name": "Dish soap", "quantity": 1, "unit_price": 18, "category_id": otherCategory.ID},
+			},
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodGet, path, token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appFinance.LineItemsResponse
+		testsupport.DecodeData(t, w, &response)
+
+		require.Len(t, response.Items, 2)
+		assert.Equal(t, "Milk 1L", response.Items[0].Name)
+		assert.Equal(t, 3.5, response.Items[0].Total)
+		assert.Nil(t, response.Items[0].CategoryID)
+		require.NotNil(t, response.Items[1].CategoryID)
+		assert.Equal(t, int(otherCategory.ID), *response.Items[1].CategoryID)
+		assert.Equal(t, 21.5, response.ItemsTotal)
+		assert.Equal(t, 2.0, response.Unitemized)
+	})
+
+	t.Run("rejects income categories", func(t *testing.T) {
+		w := server.Do(t, http.MethodPut, path, token, map[string]interface{}{
+			"items": []map[string]interface{}{
+				{"name": "Refund", "quantity": 1, "unit_price": 5, "category_id": fixtures.IncomeCategory.ID},
+			},
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "CATEGORY_TYPE_MISMATCH")
+	})
+
+	t.Run("removes line items", func(t *testing.T) {
+		w := server.Do(t, http.MethodPut, path, token, map[string]interface{}{"items": []interface{}{}})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var count int64
+		require.NoError(t, db.Model(&database.ReceiptLineItem{}).Where("expense_id = ?", expense.ID).Count(&count).Error)
+		assert.Zero(t, count)
+	})
+
+	t.Run("hides other users' expenses", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, path, server.Token(t, fixtures.Admin), nil)
+		assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	})
+}
+
+func TestWebhookTestDeliveryIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	// The endpoint answers 401 unless the signature verifies against its secret
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		expected := "sha256=" + webhook.Sign("s3cret", r.Header.Get(webhook.TimestampHeader), body)
+		if r.Header.Get(webhook.SignatureHeader) != expected {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer endpoint.Close()
+
+	// Private addresses cannot be registered, so the local endpoints are stored directly
+	verified := database.Webhook{UserID: fixtures.User.ID, URL: endpoint.URL, Secret: "s3cret", Events: finance.EventBudgetExceeded}
+	misconfigured := database.Webhook{UserID: fixtures.User.ID, URL: endpoint.URL, Secret: "wrong"}
+	require.NoError(t, db.Create(&verified).Error)
+	require.NoError(t, db.Create(&misconfigured).Error)
+
+	useCase := appNotification.NewTestWebhookUseCase(
+		database.NewGormWebhookRepository(db),
+		webhook.NewUnrestrictedSender(endpoint.Client()),
+		events.Samples(),
+	)
+
+	t.Run("delivers a signed sample of a subscribed event", func(t *testing.T) {
+		response, err := useCase.Execute(context.Background(), int(fixtures.User.ID), int(verified.ID), appNotification.TestWebhookRequest{})
+		require.NoError(t, err)
+
+		assert.True(t, response.Delivered, response.Error)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, finance.EventBudgetExceeded, response.Event)
+		var payload struct {
+			Event string `json:"event"`
+			Test  bool   `json:"test"`
+		}
+		require.NoError(t, json.Unmarshal(response.Payload, &payload))
+		assert.Equal(t, finance.EventBudgetExceeded, payload.Event)
+		assert.True(t, payload.Test)
+	})
+
+	t.Run("reports rejected signatures", func(t *testing.T) {
+		response, err := useCase.Execute(context.Background(), int(fixtures.User.ID), int(misconfigured.ID), appNotification.TestWebhookRequest{
+			Event: finance.EventCurrencyDeleted,
+		})
+		require.NoError(t, err)
+
+		assert.False(t, response.Delivered)
+		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+		assert.Equal(t, finance.EventCurrencyDeleted, response.Event)
+		assert.NotEmpty(t, response.Error)
+	})
+
+	t.Run("reports refused endpoints over the API", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, fmt.Sprintf("/api/v100/webhooks/%d/test", verified.ID), token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appNotification.TestWebhookResponse
+		testsupport.DecodeData(t, w, &response)
+
+		assert.False(t, response.Delivered)
+		assert.Zero(t, response.StatusCode)
+		assert.Contains(t, response.Error, "private address")
+	})
+
+	t.Run("rejects unknown events", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, fmt.Sprintf("/api/v100/webhooks/%d/test", verified.ID), token, map[string]string{"event": "nope"})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "INVALID_EVENT_NAME")
+	})
+
+	t.Run("hides other users' webhooks", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, fmt.Sprintf("/api/v100/webhooks/%d/test", verified.ID), server.Token(t, fixtures.Admin), nil)
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+}
+
+func TestTransactionPaginationIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	for day := 1; day <= 3; day++ {
+		fixtures.AddExpense(t, db, 10, time.Date(2024, 3, day, 0, 0, 0, 0, time.UTC))
+	}
+
+	type page struct {
+		Transactions []appFinance.TransactionResponse `json:"transactions"`
+		Meta         handlers.PaginationMeta          `json:"meta"`
+	}
+
+	t.Run("follows the next cursor", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v120/transactions?type=expense&limit=2", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var first page
+		testsupport.DecodeData(t, w, &first)
+
+		assert.Len(t, first.Transactions, 2)
+		assert.Equal(t, int64(3), first.Meta.Total)
+		assert.Equal(t, 2, first.Meta.TotalPages)
+		require.NotNil(t, first.Meta.NextCursor)
+		link := w.Header().Get("Link")
+		assert.Contains(t, link, `</api/v120/transactions?limit=2&page=2&type=expense>; rel="next"`)
+		assert.NotContains(t, link, `rel="prev"`)
+
+		w = server.Do(t, http.MethodGet, "/api/v120/transactions?type=expense&limit=2&cursor="+*first.Meta.NextCursor, token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var second page
+		testsupport.DecodeData(t, w, &second)
+
+		assert.Len(t, second.Transactions, 1)
+		assert.Equal(t, 2, second.Meta.Page)
+		assert.Nil(t, second.Meta.NextCursor)
+		link = w.Header().Get("Link")
+		assert.Contains(t, link, `</api/v120/transactions?limit=2&page=1&type=expense>; rel="prev"`)
+		assert.NotContains(t, link, `rel="next"`)
+	})
+
+	t.Run("keeps the v100 fields alongside meta", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v100/transactions?limit=2", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response struct {
+			appFinance.GetAllTransactionsResponse
+			Meta handlers.PaginationMeta `json:"meta"`
+		}
+		testsupport.DecodeData(t, w, &response)
+
+		assert.Equal(t, int64(3), response.Total)
+		assert.Equal(t, response.Total, response.Meta.Total)
+		assert.Equal(t, response.TotalPages, response.Meta.TotalPages)
+	})
+
+	t.Run("rejects invalid cursors", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v120/transactions?cursor=bm9wZQ", token, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "INVALID_CURSOR")
+	})
+}
+
+func TestFieldSelectionIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	fixtures.AddExpense(t, db, 12.5, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	w := server.Do(t, http.MethodPost, "/api/v100/budgets", token, map[string]interface{}{
+		"category_id": fixtures.ExpenseCategory.ID,
+		"amount":      300,
+		"period":      "monthly",
+		"start_date":  "2024-03-01",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	t.Run("reduces transactions to the requested fields", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v120/transactions?fields=id,amount,category.name,nope", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response struct {
+			Transactions []map[string]interface{} `json:"transactions"`
+			Meta         handlers.PaginationMeta  `json:"meta"`
+		}
+		testsupport.DecodeData(t, w, &response)
+
+		require.Len(t, response.Transactions, 1)
+		transaction := response.Transactions[0]
+		assert.Len(t, transaction, 3)
+		assert.Equal(t, 12.5, transaction["amount"])
+		assert.Equal(t, map[string]interface{}{"name": fixtures.ExpenseCategory.Name}, transaction["category"])
+		assert.Equal(t, int64(1), response.Meta.Total)
+	})
+
+	t.Run("reduces budgets to the requested fields", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v100/budgets?fields=amount,period", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var budgets []map[string]interface{}
+		testsupport.DecodeData(t, w, &budgets)
+
+		require.Len(t, budgets, 1)
+		assert.Equal(t, map[string]interface{}{"amount": 300.0, "period": "monthly"}, budgets[0])
+	})
+
+	t.Run("returns whole items without fields", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v100/expenses", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var expenses []map[string]interface{}
+		testsupport.DecodeData(t, w, &expenses)
+
+		require.Len(t, expenses, 1)
+		assert.Contains(t, expenses[0], "description")
+	})
+}
+
+func TestAccountUsageIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	fixtures.AddExpense(t, db, 12.5, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	fixtures.AddIncome(t, db, 900, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	w := server.Do(t, http.MethodPost, "/api/v100/categories", token, map[string]interface{}{
+		"name":  "Pets",
+		"color": "#AA5500",
+		"type":  "expense",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	w = server.Do(t, http.MethodGet, "/api/v120/categories", token, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = server.Do(t, http.MethodGet, "/api/v120/categories", server.Token(t, fixtures.Admin), nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = server.Do(t, http.MethodGet, "/api/v100/account/usage", token, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var usage appIdentity.AccountUsageResponse
+	testsupport.DecodeData(t, w, &usage)
+
+	assert.Equal(t, 2, usage.TransactionCount)
+	assert.Equal(t, 1, usage.CategoryCount)
+	assert.Equal(t, 0, usage.BudgetCount)
+	assert.Equal(t, int64(2), usage.APICallsThisMonth)
+}
+
+func TestDailyQuotaIntegration(t *testing.T) {
+	quota := middleware.NewRateLimitMiddleware(ratelimit.NewMemoryStore(), slog.Default()).DailyQuota(2)
+	get := func(userID int) *httptest.ResponseRecorder {
+		router := gin.New()
+		router.GET("/categories", func(c *gin.Context) {
+			if userID != 0 {
+				c.Set("user_id", userID)
+			}
+		}, quota, func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/categories", nil))
+		return w
+	}
+
+	for remaining := 1; remaining >= 0; remaining-- {
+		w := get(1)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "2", w.Header().Get("X-Daily-Quota-Limit"))
+		assert.Equal(t, fmt.Sprint(remaining), w.Header().Get("X-Daily-Quota-Remaining"))
+	}
+
+	w := get(1)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Contains(t, w.Body.String(), "DAILY_QUOTA_EXCEEDED")
+	retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+	require.NoError(t, err)
+	assert.True(t, retryAfter > 0 && retryAfter <= 24*60*60)
+
+	// Other users have their own budget, and unauthenticated requests are not counted
+	assert.Equal(t, http.StatusOK, get(2).Code)
+	w = get(0)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("X-Daily-Quota-Limit"))
+}
+
+func TestAnonymizeIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	w := server.Do(t, http.MethodPost, "/api/v100/expenses", token, map[string]interface{}{
+		"category_id": fixtures.ExpenseCategory.ID,
+		"currency_id": fixtures.Currency.ID,
+		"amount":      100,
+		"date":        "2024-03-01",
+		"description": "Dinner with Alice at 12 Baker Street",
+		"latitude":    51.5237,
+		"longitude":   -0.1585,
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	fixtures.AddExpense(t, db, 200, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, db.Create(&database.Notification{UserID: fixtures.User.ID, Title: "Budget alert", Message: "Dinner with Alice", Type: "budget_alert"}).Error)
+
+	report, err := anonymize.NewAnonymizer(db).Run(context.Background(), anonymize.Options{Password: "staging-password", Seed: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), report.Scrubbed["users"])
+	assert.Equal(t, int64(1), report.Deleted["notifications"])
+
+	var user database.User
+	require.NoError(t, db.First(&user, fixtures.User.ID).Error)
+	assert.Equal(t, fmt.Sprintf("user%d@example.invalid", fixtures.User.ID), user.Email)
+
+	var expenses []database.Expense
+	require.NoError(t, db.Order("id").Find(&expenses).Error)
+	require.Len(t, expenses, 2)
+	assert.Equal(t, fmt.Sprintf("Expense %d", expenses[0].ID), expenses[0].Description)
+	assert.Nil(t, expenses[0].Latitude)
+	assert.Nil(t, expenses[0].Longitude)
+	assert.Empty(t, expenses[1].Description)
+	for i, original := range []float64{100, 200} {
+		assert.NotEqual(t, original, expenses[i].Amount)
+		assert.InDelta(t, original, expenses[i].Amount, original*0.4)
+	}
+
+	// The scrubbed accounts can log in with the staging password
+	w = server.Do(t, http.MethodPost, "/api/v100/auth/login", "", gin.H{"email": user.Email, "password": "staging-password"})
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
+
+func TestVersionConflictIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	t.Run("a stale expense update is refused", func(t *testing.T) {
+		expense := fixtures.AddExpense(t, db, 12.5, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+		path := fmt.Sprintf("/api/v100/expenses/%d", expense.ID)
+		update := func(amount float64, version interface{}) *httptest.ResponseRecorder {
+			return server.Do(t, http.MethodPut, path, token, map[string]interface{}{
+				"category_id": fixtures.ExpenseCategory.ID,
+				"amount":      amount,
+				"description": "groceries",
+				"date":        "2024-03-01",
+				"version":     version,
+			})
+		}
+
+		// Both devices read version 1; the first to save wins
+		w := update(20, 1)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response struct {
+			Expense struct {
+				Version int `json:"version"`
+			} `json:"expense"`
+		}
+		testsupport.DecodeData(t, w, &response)
+		assert.Equal(t, 2, response.Expense.Version)
+
+		w = update(30, 1)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "VERSION_CONFLICT")
+
+		var model database.Expense
+		require.NoError(t, db.First(&model, expense.ID).Error)
+		assert.Equal(t, 20.0, model.Amount)
+		assert.Equal(t, 2, model.Version)
+
+		// Updates without a version are not checked
+		w = update(40, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, db.First(&model, expense.ID).Error)
+		assert.Equal(t, 3, model.Version)
+	})
+
+	t.Run("a stale budget update is refused", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, "/api/v100/budgets", token, map[string]interface{}{
+			"category_id": fixtures.ExpenseCategory.ID,
+			"amount":      300,
+			"period":      "monthly",
+			"start_date":  "2024-03-01",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodGet, "/api/v100/budgets", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var budgets []appFinance.BudgetResponse
+		testsupport.DecodeData(t, w, &budgets)
+		require.Len(t, budgets, 1)
+		assert.Equal(t, 1, budgets[0].Version)
+
+		update := func(amount float64) *httptest.ResponseRecorder {
+			return server.Do(t, http.MethodPut, fmt.Sprintf("/api/v100/budgets/%d", budgets[0].ID), token, map[string]interface{}{
+				"category_id": fixtures.ExpenseCategory.ID,
+				"amount":      amount,
+				"period":      "monthly",
+				"start_date":  "2024-03-01",
+				"end_date":    "2024-03-31",
+				"version":     budgets[0].Version,
+			})
+		}
+		w = update(350)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var updated appFinance.UpdateBudgetResponse
+		testsupport.DecodeData(t, w, &updated)
+		assert.Equal(t, 2, updated.Version)
+
+		w = update(400)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "VERSION_CONFLICT")
+	})
+}
+
+func TestSyncIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	kept := fixtures.AddExpense(t, db, 12.5, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	deleted := fixtures.AddExpense(t, db, 20, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC))
+	fixtures.AddIncome(t, db, 900, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	// Changed well before the first sync, so later syncs leave them out
+	earlier := time.Now().Add(-2 * time.Hour)
+	for _, model := range []interface{}{&database.Expense{}, &database.Income{}, &database.Category{}} {
+		require.NoError(t, db.Model(model).Where("1 = 1").UpdateColumn("updated_at", earlier).Error)
+	}
+
+	sync := func(t *testing.T, cursor string) appFinance.SyncResponse {
+		w := server.Do(t, http.MethodGet, "/api/v100/sync?since="+cursor, token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appFinance.SyncResponse
+		testsupport.DecodeData(t, w, &response)
+		require.NotEmpty(t, response.NextCursor)
+		return response
+	}
+
+	first := sync(t, "")
+	assert.Len(t, first.Transactions, 3)
+	assert.NotEmpty(t, first.Categories)
+	assert.Empty(t, first.Deleted)
+
+	second := sync(t, first.NextCursor)
+	assert.Empty(t, second.Transactions)
+	assert.Empty(t, second.Categories)
+	assert.Empty(t, second.Deleted)
+
+	w := server.Do(t, http.MethodPut, fmt.Sprintf("/api/v100/expenses/%d", kept.ID), token, map[string]interface{}{
+		"category_id": fixtures.ExpenseCategory.ID,
+		"amount":      15,
+		"description": "groceries",
+		"date":        "2024-03-01",
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = server.Do(t, http.MethodDelete, fmt.Sprintf("/api/v100/expenses/%d", deleted.ID), token, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	third := sync(t, first.NextCursor)
+	require.Len(t, third.Transactions, 1)
+	assert.Equal(t, int(kept.ID), third.Transactions[0].ID)
+	assert.Equal(t, 15.0, third.Transactions[0].Amount)
+	require.Len(t, third.Deleted, 1)
+	assert.Equal(t, appFinance.DeletedRecordResponse{Type: "expense", ID: int(deleted.ID), DeletedAt: third.Deleted[0].DeletedAt}, third.Deleted[0])
+
+	// Other users' deletions are not theirs to sync
+	adminSync := server.Do(t, http.MethodGet, "/api/v100/sync?since="+first.NextCursor, server.Token(t, fixtures.Admin), nil)
+	require.Equal(t, http.StatusOK, adminSync.Code, adminSync.Body.String())
+	assert.Contains(t, adminSync.Body.String(), `"deleted":[]`)
+
+	w = server.Do(t, http.MethodGet, "/api/v100/sync?since=not-a-cursor", token, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_CURSOR")
+}
+
+func TestClientIDIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	create := func(t *testing.T, token, path, clientID string, categoryID uint) *httptest.ResponseRecorder {
+		return server.Do(t, http.MethodPost, path, token, map[string]interface{}{
+			"category_id": categoryID,
+			"amount":      12.5,
+			"description": "made offline",
+			"date":        "2024-03-01",
+			"client_id":   clientID,
+		})
+	}
+	createdID := func(t *testing.T, w *httptest.ResponseRecorder) int {
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var response struct {
+			Expense appFinance.CreateTransactionResponse `json:"expense"`
+		}
+		testsupport.DecodeData(t, w, &response)
+		return response.Expense.ID
+	}
+	const clientID = "5b2c3e1a-8f0d-4c6b-9a7e-2d4f6a8b0c1e"
+
+	// A retried create returns the first transaction rather than a second one
+	first := createdID(t, create(t, token, "/api/v100/expenses", clientID, fixtures.ExpenseCategory.ID))
+	retried := createdID(t, create(t, token, "/api/v100/expenses", strings.ToUpper(clientID), fixtures.ExpenseCategory.ID))
+	assert.Equal(t, first, retried)
+	var count int64
+	require.NoError(t, db.Model(&database.Expense{}).Where("user_id = ?", fixtures.User.ID).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	w := create(t, token, "/api/v100/incomes", clientID, fixtures.IncomeCategory.ID)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "CLIENT_ID_IN_USE")
+
+	// Client IDs are unique per user, not across users
+	other := createdID(t, create(t, server.Token(t, fixtures.Admin), "/api/v100/expenses", clientID, fixtures.ExpenseCategory.ID))
+	assert.NotEqual(t, first, other)
+
+	// A retry arriving after the transaction was archived still finds it
+	_, err := database.NewGormTransactionRepository(db).ArchiveBefore(context.Background(), time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	retried = createdID(t, create(t, token, "/api/v100/expenses", clientID, fixtures.ExpenseCategory.ID))
+	assert.Equal(t, first, retried)
+
+	w = create(t, token, "/api/v100/expenses", "not-a-uuid", fixtures.ExpenseCategory.ID)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestLiveUpdatesIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go server.App.EventBus.Run(ctx, 100*time.Millisecond)
+
+	listener := httptest.NewServer(server.App.Handler())
+	defer listener.Close()
+	dial := func(t *testing.T, token string) *websocket.Conn {
+		cfg, err := websocket.NewConfig("ws"+strings.TrimPrefix(listener.URL, "http")+"/api/v100/ws", listener.URL)
+		require.NoError(t, err)
+		cfg.Header.Set("Authorization", "Bearer "+token)
+		ws, err := websocket.DialConfig(cfg)
+		require.NoError(t, err)
+		t.Cleanup(func() { ws.Close() })
+		return ws
+	}
+	exchange := func(t *testing.T, ws *websocket.Conn, message string) map[string]interface{} {
+		require.NoError(t, websocket.Message.Send(ws, message))
+		var reply map[string]interface{}
+		require.NoError(t, ws.SetReadDeadline(time.Now().Add(5*time.Second)))
+		require.NoError(t, websocket.JSON.Receive(ws, &reply))
+		return reply
+	}
+
+	ws := dial(t, server.Token(t, fixtures.User))
+	assert.Equal(t, "pong", exchange(t, ws, `{"type":"ping"}`)["type"])
+	assert.Equal(t, "INVALID_MESSAGE", exchange(t, ws, `not json`)["code"])
+	assert.Equal(t, "UNKNOWN_TOPIC", exchange(t, ws, `{"type":"subscribe","topics":["transactions","salaries"]}`)["code"])
+	reply := exchange(t, ws, `{"type":"subscribe","topics":["transactions","notifications"]}`)
+	assert.Equal(t, map[string]interface{}{"type": "subscribed", "topics": []interface{}{"notifications", "transactions"}}, reply)
+
+	// Another user's connection receives nothing of this user's
+	other := dial(t, server.Token(t, fixtures.Admin))
+	exchange(t, other, `{"type":"subscribe","topics":["transactions"]}`)
+
+	w := server.Do(t, http.MethodPost, "/api/v100/expenses", server.Token(t, fixtures.User), map[string]interface{}{
+		"category_id": fixtures.ExpenseCategory.ID,
+		"amount":      12.5,
+		"description": "lunch",
+		"date":        "2024-03-01",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var push realtime.Push
+	require.NoError(t, ws.SetReadDeadline(time.Now().Add(5*time.Second)))
+	require.NoError(t, websocket.JSON.Receive(ws, &push))
+	assert.Equal(t, "transactions", push.Topic)
+	assert.Equal(t, finance.EventTransactionCreated, push.Event)
+	assert.Equal(t, 12.5, push.Data.(map[string]interface{})["amount"])
+
+	require.NoError(t, other.SetReadDeadline(time.Now().Add(200*time.Millisecond)))
+	assert.Error(t, websocket.JSON.Receive(other, &push))
+
+	// Without an Authorization header the upgrade is refused
+	_, err := websocket.Dial("ws"+strings.TrimPrefix(listener.URL, "http")+"/api/v100/ws", "", listener.URL)
+	assert.Error(t, err)
+}
+
+func TestGrowthMetricsIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	adminToken := server.Token(t, fixtures.Admin)
+
+	// The user is active today; the admin's own requests are not counted
+	w := server.Do(t, http.MethodGet, "/api/v100/preferences", server.Token(t, fixtures.User), nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	fixtures.AddExpense(t, db, 12.5, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	fixtures.AddIncome(t, db, 900, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, server.App.VersionUsageTracker.Flush(context.Background()))
+
+	growth := func(t *testing.T) appIdentity.GrowthMetricsResponse {
+		w := server.Do(t, http.MethodGet, "/api/admin/metrics/growth", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appIdentity.GrowthMetricsResponse
+		testsupport.DecodeData(t, w, &response)
+		return response
+	}
+
+	response := growth(t)
+	require.Len(t, response.Days, 30)
+	today := response.Days[29]
+	assert.Equal(t, time.Now().UTC().Format("2006-01-02"), today.Date)
+	assert.Equal(t, appIdentity.GrowthDayResponse{Date: today.Date, Signups: 1, ActiveUsers: 1, Transactions: 2}, today)
+	assert.Equal(t, 1, response.MonthlyActiveUsers)
+	assert.InDelta(t, 1.0/30, response.Stickiness, 0.0001)
+
+	// Reports are served from the cache until they expire
+	fixtures.AddExpense(t, db, 7, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, 2, growth(t).Days[29].Transactions)
+
+	w = server.Do(t, http.MethodGet, "/api/admin/metrics/retention?months=2", adminToken, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var retention appIdentity.RetentionResponse
+	testsupport.DecodeData(t, w, &retention)
+	require.Len(t, retention.Cohorts, 2)
+	current := retention.Cohorts[1]
+	assert.Equal(t, time.Now().UTC().Format("2006-01"), current.Month)
+	assert.Equal(t, 1, current.Users)
+	assert.Equal(t, []appIdentity.RetentionPointResponse{{MonthsAfter: 0, ActiveUsers: 1, Rate: 1}}, current.Retention)
+	assert.Len(t, retention.Cohorts[0].Retention, 2)
+
+	w = server.Do(t, http.MethodGet, "/api/admin/metrics/growth?from=2024-03-10&to=2024-03-01", adminToken, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_DATE_RANGE")
+	w = server.Do(t, http.MethodGet, "/api/admin/metrics/retention?months=36", adminToken, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = server.Do(t, http.MethodGet, "/api/admin/metrics/growth", server.Token(t, fixtures.User), nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestDefaultBudgetsIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	adminToken := server.Token(t, fixtures.Admin)
+
+	w := server.Do(t, http.MethodPut, "/api/admin/default-budgets", adminToken, map[string]interface{}{
+		"budgets": []map[string]interface{}{
+			{"category_id": fixtures.ExpenseCategory.ID, "amount": 400, "period": "monthly"},
+		},
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var template struct {
+		DefaultBudgets []appFinance.DefaultBudgetResponse `json:"default_budgets"`
+	}
+	testsupport.DecodeData(t, w, &template)
+	require.Len(t, template.DefaultBudgets, 1)
+	require.NotNil(t, template.DefaultBudgets[0].Category)
+	assert.Equal(t, fixtures.ExpenseCategory.Name, template.DefaultBudgets[0].Category.Name)
+
+	register := func(t *testing.T, email string, skip bool) []appFinance.BudgetResponse {
+		w := server.Do(t, http.MethodPost, "/api/v100/auth/register", "", map[string]interface{}{
+			"email":                email,
+			"password":             "password123",
+			"skip_default_budgets": skip,
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var registered struct {
+			Token string `json:"token"`
+		}
+		testsupport.DecodeData(t, w, &registered)
+
+		w = server.Do(t, http.MethodGet, "/api/v100/budgets", registered.Token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var budgets []appFinance.BudgetResponse
+		testsupport.DecodeData(t, w, &budgets)
+		return budgets
+	}
+
+	t.Run("new users start with the template's budgets", func(t *testing.T) {
+		budgets := register(t, "onboarded@example.com", false)
+		require.Len(t, budgets, 1)
+		assert.Equal(t, 400.0, budgets[0].Amount)
+		assert.Equal(t, "monthly", budgets[0].Period)
+		assert.True(t, budgets[0].Prorated)
+		assert.Equal(t, time.Now().UTC().Format("2006-01-02"), budgets[0].StartDate)
+	})
+
+	t.Run("onboarding can skip them", func(t *testing.T) {
+		assert.Empty(t, register(t, "skipped@example.com", true))
+	})
+
+	t.Run("entries must use distinct default expense categories", func(t *testing.T) {
+		for _, categoryID := range []uint{fixtures.IncomeCategory.ID, 99999} {
+			w := server.Do(t, http.MethodPut, "/api/admin/default-budgets", adminToken, map[string]interface{}{
+				"budgets": []map[string]interface{}{{"category_id": categoryID, "amount": 50, "period": "weekly"}},
+			})
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), "INVALID_DEFAULT_BUDGET")
+		}
+
+		entry := map[string]interface{}{"category_id": fixtures.ExpenseCategory.ID, "amount": 50, "period": "weekly"}
+		w := server.Do(t, http.MethodPut, "/api/admin/default-budgets", adminToken, map[string]interface{}{
+			"budgets": []map[string]interface{}{entry, entry},
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = server.Do(t, http.MethodGet, "/api/admin/default-budgets", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		testsupport.DecodeData(t, w, &template)
+		assert.Len(t, template.DefaultBudgets, 1)
+	})
+
+	w = server.Do(t, http.MethodGet, "/api/admin/default-budgets", server.Token(t, fixtures.User), nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestOnboardingIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	onboard := func(t *testing.T, body map[string]interface{}) *httptest.ResponseRecorder {
+		return server.Do(t, http.MethodPost, "/api/v100/onboarding", token, body)
+	}
+
+	t.Run("a failing step applies nothing", func(t *testing.T) {
+		w := onboard(t, map[string]interface{}{
+			"currency_id": fixtures.Currency.ID,
+			"categories":  []map[string]interface{}{{"name": "Coffee", "type": "expense"}},
+			"budgets":     []map[string]interface{}{{"category_id": 99999, "amount": 50, "period": "monthly"}},
+		})
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+		var count int64
+		require.NoError(t, db.Model(&database.Category{}).Where("user_id = ?", fixtures.User.ID).Count(&count).Error)
+		assert.Zero(t, count)
+	})
+
+	t.Run("everything is set up in one request", func(t *testing.T) {
+		w := onboard(t, map[string]interface{}{
+			"currency_id": fixtures.Currency.ID,
+			"categories": []map[string]interface{}{
+				{"name": "Coffee", "color": "#6F4E37", "type": "expense", "budget": map[string]interface{}{"amount": 60, "period": "monthly"}},
+				{"name": "Freelance", "type": "income"},
+			},
+			"budgets": []map[string]interface{}{{"category_id": fixtures.ExpenseCategory.ID, "amount": 400, "period": "weekly"}},
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appFinance.OnboardingResponse
+		testsupport.DecodeData(t, w, &response)
+		require.Len(t, response.Categories, 2)
+		assert.NotZero(t, response.Categories[0].ID)
+		require.Len(t, response.Budgets, 2)
+		assert.Equal(t, "Coffee", response.Budgets[0].Category.Name)
+		assert.Equal(t, "weekly", response.Budgets[1].Period)
+
+		w = server.Do(t, http.MethodGet, "/api/v100/budgets", token, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var budgets []appFinance.BudgetResponse
+		testsupport.DecodeData(t, w, &budgets)
+		assert.Len(t, budgets, 2)
+
+		w = server.Do(t, http.MethodGet, "/api/v100/preferences", token, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var preferences appIdentity.PreferencesResponse
+		testsupport.DecodeData(t, w, &preferences)
+		assert.NotNil(t, preferences.OnboardingCompletedAt)
+	})
+
+	t.Run("onboarding happens once", func(t *testing.T) {
+		w := onboard(t, map[string]interface{}{"currency_id": fixtures.Currency.ID})
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "ONBOARDING_ALREADY_COMPLETED")
+	})
+}
+
+func TestDefaultCategoryCopiesIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+
+	w := server.Do(t, http.MethodPost, "/api/v100/auth/register", "", map[string]interface{}{
+		"email":    "copies@example.com",
+		"password": "password123",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var registered struct {
+		Token string `json:"token"`
+		User  struct {
+			ID int `json:"id"`
+		} `json:"user"`
+	}
+	testsupport.DecodeData(t, w, &registered)
+
+	listCategories := func(t *testing.T, token string) []appFinance.CategoryResponse {
+		w := server.Do(t, http.MethodGet, "/api/v100/categories", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var categories []appFinance.CategoryResponse
+		testsupport.DecodeData(t, w, &categories)
+		return categories
+	}
+	copyOf := func(t *testing.T, categories []appFinance.CategoryResponse, sourceID uint) appFinance.CategoryResponse {
+		for _, category := range categories {
+			if category.SourceCategoryID != nil && *category.SourceCategoryID == int(sourceID) {
+				return category
+			}
+		}
+		t.Fatalf("no copy of category %d", sourceID)
+		return appFinance.CategoryResponse{}
+	}
+
+	t.Run("new users list their own copies instead of the defaults", func(t *testing.T) {
+		categories := listCategories(t, registered.Token)
+		require.NotEmpty(t, categories)
+		for _, category := range categories {
+			assert.False(t, category.IsDefault, category.Name)
+			assert.NotNil(t, category.SourceCategoryID, category.Name)
+		}
+		assert.Equal(t, fixtures.ExpenseCategory.Name, copyOf(t, categories, fixtures.ExpenseCategory.ID).Name)
+	})
+
+	t.Run("copies can be renamed without touching the default", func(t *testing.T) {
+		copied := copyOf(t, listCategories(t, registered.Token), fixtures.ExpenseCategory.ID)
+		w := server.Do(t, http.MethodPut, fmt.Sprintf("/api/v100/categories/%d", copied.ID), registered.Token, map[string]interface{}{
+			"name":  "Groceries",
+			"color": copied.Color,
+			"type":  copied.Type,
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var model database.Category
+		require.NoError(t, db.First(&model, fixtures.ExpenseCategory.ID).Error)
+		assert.Equal(t, fixtures.ExpenseCategory.Name, model.Name)
+	})
+
+	t.Run("transactions in a default category land in the user's copy", func(t *testing.T) {
+		w := server.Do(t, http.MethodPost, "/api/v100/expenses", registered.Token, map[string]interface{}{
+			"category_id": fixtures.ExpenseCategory.ID,
+			"currency_id": fixtures.Currency.ID,
+			"amount":      12,
+			"description": "Market",
+			"date":        "2024-03-01",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var model database.Expense
+		require.NoError(t, db.Where("user_id = ?", registered.User.ID).First(&model).Error)
+		assert.Equal(t, copyOf(t, listCategories(t, registered.Token), fixtures.ExpenseCategory.ID).ID, int(model.CategoryID))
+	})
+
+	t.Run("defaults cannot be changed through another user's copy", func(t *testing.T) {
+		copied := copyOf(t, listCategories(t, registered.Token), fixtures.ExpenseCategory.ID)
+		w := server.Do(t, http.MethodDelete, fmt.Sprintf("/api/v100/categories/%d", copied.ID), server.Token(t, fixtures.User), nil)
+		assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	})
+
+	t.Run("users without copies still see the defaults", func(t *testing.T) {
+		categories := listCategories(t, server.Token(t, fixtures.User))
+		found := false
+		for _, category := range categories {
+			found = found || category.ID == int(fixtures.ExpenseCategory.ID)
+		}
+		assert.True(t, found)
+	})
+}
+
+func TestDefaultCurrencyIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	var euro database.Currency
+	require.NoError(t, db.Where("code = ? AND user_id IS NULL", "EUR").First(&euro).Error)
+
+	getDefault := func(t *testing.T) int {
+		w := server.Do(t, http.MethodGet, "/api/v100/currencies/default", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var currency struct {
+			ID int `json:"id"`
+		}
+		testsupport.DecodeData(t, w, &currency)
+		return currency.ID
+	}
+
+	t.Run("users without preferences get the first default currency", func(t *testing.T) {
+		require.NoError(t, db.Where("user_id = ?", fixtures.User.ID).Delete(&database.UserPreferences{}).Error)
+		assert.NotEqual(t, int(euro.ID), getDefault(t))
+	})
+
+	t.Run("the first choice creates the user's preferences", func(t *testing.T) {
+		w := server.Do(t, http.MethodPut, fmt.Sprintf("/api/v100/currencies/%d/set-default", euro.ID), token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, int(euro.ID), getDefault(t))
+
+		var preferences database.UserPreferences
+		require.NoError(t, db.Where("user_id = ?", fixtures.User.ID).First(&preferences).Error)
+		assert.True(t, preferences.BudgetAlerts)
+	})
+
+	t.Run("later choices update the same preferences", func(t *testing.T) {
+		w := server.Do(t, http.MethodPut, fmt.Sprintf("/api/v100/currencies/%d/set-default", fixtures.Currency.ID), token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, int(fixtures.Currency.ID), getDefault(t))
+
+		var count int64
+		require.NoError(t, db.Model(&database.UserPreferences{}).Where("user_id = ?", fixtures.User.ID).Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("unknown currencies are rejected", func(t *testing.T) {
+		w := server.Do(t, http.MethodPut, "/api/v100/currencies/99999/set-default", token, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+}
+
+func TestCategoryStatsIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	now := time.Now().UTC()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 12, 0, 0, 0, time.UTC)
+	fixtures.AddExpense(t, db, 12.5, thisMonth)
+	fixtures.AddExpense(t, db, 7.25, thisMonth)
+	fixtures.AddExpense(t, db, 100, thisMonth.AddDate(0, -1, 0))
+
+	listCategories := func(t *testing.T, path string) []appFinance.CategoryResponse {
+		w := server.Do(t, http.MethodGet, path, token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var categories []appFinance.CategoryResponse
+		testsupport.DecodeData(t, w, &categories)
+		return categories
+	}
+
+	t.Run("stats are only included on request", func(t *testing.T) {
+		for _, category := range listCategories(t, "/api/v100/categories") {
+			assert.Nil(t, category.Stats)
+		}
+	})
+
+	t.Run("each category reports its usage", func(t *testing.T) {
+		categories := listCategories(t, "/api/v100/categories?type=expense&include=stats")
+		require.NotEmpty(t, categories)
+		for _, category := range categories {
+			require.NotNil(t, category.Stats, category.Name)
+			if category.ID == int(fixtures.ExpenseCategory.ID) {
+				assert.Equal(t, 3, category.Stats.TransactionCount)
+				assert.Equal(t, 19.75, category.Stats.CurrentMonthTotal)
+			} else {
+				assert.Zero(t, category.Stats.TransactionCount, category.Name)
+			}
+		}
+	})
+}
+
+func TestCategoryColorsIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	createCategory := func(t *testing.T, color string) *httptest.ResponseRecorder {
+		return server.Do(t, http.MethodPost, "/api/v100/categories", token, map[string]interface{}{
+			"name":  "Pets",
+			"color": color,
+			"type":  "expense",
+		})
+	}
+
+	t.Run("colors must be hex", func(t *testing.T) {
+		for _, color := range []string{"red", "#12345", "#GGGGGG", "3B82F6"} {
+			w := createCategory(t, color)
+			assert.Equal(t, http.StatusBadRequest, w.Code, color)
+			assert.Contains(t, w.Body.String(), "INVALID_COLOR", color)
+		}
+	})
+
+	t.Run("colors are stored as uppercase #RRGGBB", func(t *testing.T) {
+		w := createCategory(t, "#a1b")
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var model database.Category
+		require.NoError(t, db.Where("user_id = ? AND name = ?", fixtures.User.ID, "Pets").First(&model).Error)
+		assert.Equal(t, "#AA11BB", model.Color)
+
+		w = server.Do(t, http.MethodPut, fmt.Sprintf("/api/v100/categories/%d", model.ID), token, map[string]interface{}{
+			"name":  "Pets",
+			"color": "blue",
+			"type":  "expense",
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("categories saved with other colors still load", func(t *testing.T) {
+		require.NoError(t, db.Create(&database.Category{UserID: &fixtures.User.ID, Name: "Legacy", Color: "teal", CategoryType: "expense"}).Error)
+		w := server.Do(t, http.MethodGet, "/api/v100/categories", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+
+	t.Run("the palette lists the recommended colors", func(t *testing.T) {
+		w := server.Do(t, http.MethodGet, "/api/v100/palette", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var palette appFinance.PaletteResponse
+		testsupport.DecodeData(t, w, &palette)
+		require.NotEmpty(t, palette.Colors)
+		assert.Equal(t, "#3B82F6", palette.DefaultColor)
+		for _, color := range palette.Colors {
+			assert.Regexp(t, `^#[0-9A-F]{6}$`, color.Hex)
+		}
+	})
+}
+
+func TestTransactionDatesIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+
+	createExpense := func(t *testing.T, server *testsupport.Server, date string) *httptest.ResponseRecorder {
+		return server.Do(t, http.MethodPost, "/api/v100/expenses", token, map[string]interface{}{
+			"category_id": fixtures.ExpenseCategory.ID,
+			"amount":      12.5,
+			"description": "Dated",
+			"date":        date,
+		})
+	}
+
+	t.Run("dates must be ISO 8601", func(t *testing.T) {
+		for _, date := range []string{"15/01/2024", "2024-13-01", "yesterday", ""} {
+			w := createExpense(t, server, date)
+			assert.Equal(t, http.StatusBadRequest, w.Code, date)
+			assert.Contains(t, w.Body.String(), "VALIDATION_ERROR", date)
+		}
+	})
+
+	t.Run("timestamps keep their calendar date", func(t *testing.T) {
+		w := createExpense(t, server, "2024-01-15T23:30:00+07:00")
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var expense database.Expense
+		require.NoError(t, db.Where("user_id = ? AND description = ?", fixtures.User.ID, "Dated").First(&expense).Error)
+		assert.Equal(t, "2024-01-15", expense.Date.Format("2006-01-02"))
+	})
+
+	t.Run("malformed filter dates are rejected", func(t *testing.T) {
+		for _, query := range []string{"start_date=2024-1-1", "end_date=soon", "start_date=2024-02-01&end_date=2024-01-01"} {
+			w := server.Do(t, http.MethodGet, "/api/v100/transactions?"+query, token, nil)
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+			assert.Contains(t, w.Body.String(), "INVALID_DATE_RANGE", query)
+		}
+	})
+
+	t.Run("future dates are allowed by default", func(t *testing.T) {
+		w := createExpense(t, server, time.Now().AddDate(0, 1, 0).Format("2006-01-02"))
+		assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	})
+
+	t.Run("the reject policy refuses dates after tomorrow", func(t *testing.T) {
+		strict := testsupport.NewServerWithConfig(t, db, func(cfg *config.Config) {
+			cfg.Transactions.FutureDates = "reject"
+		})
+
+		w := createExpense(t, strict, time.Now().UTC().AddDate(0, 0, 2).Format("2006-01-02"))
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "FUTURE_DATE_NOT_ALLOWED")
+
+		w = createExpense(t, strict, time.Now().UTC().AddDate(0, 0, 1).Format("2006-01-02"))
+		assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	})
+}
+
+func TestScheduledTransactionsIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+	nextWeek := time.Now().UTC().AddDate(0, 0, 7).Format("2006-01-02")
+
+	schedule := func(t *testing.T, description, date string) *httptest.ResponseRecorder {
+		return server.Do(t, http.MethodPost, "/api/v100/scheduled-transactions", token, map[string]interface{}{
+			"type":        "expense",
+			"category_id": fixtures.ExpenseCategory.ID,
+			"amount":      120,
+			"description": description,
+			"date":        date,
+		})
+	}
+	countExpenses := func(t *testing.T, description string) int64 {
+		var count int64
+		require.NoError(t, db.Model(&database.Expense{}).Where("user_id = ? AND description = ?", fixtures.User.ID, description).Count(&count).Error)
+		return count
+	}
+
+	t.Run("a scheduled transaction is not an expense until it posts", func(t *testing.T) {
+		w := schedule(t, "Electricity", nextWeek)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var scheduled appFinance.ScheduledTransactionResponse
+		testsupport.DecodeData(t, w, &scheduled)
+		assert.Equal(t, "scheduled", scheduled.Status)
+		assert.Equal(t, nextWeek, scheduled.Date)
+		assert.Nil(t, scheduled.TransactionID)
+		assert.Zero(t, countExpenses(t, "Electricity"))
+	})
+
+	t.Run("only dates after today can be scheduled", func(t *testing.T) {
+		w := schedule(t, "Late", time.Now().UTC().Format("2006-01-02"))
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "INVALID_SCHEDULED_DATE")
+	})
+
+	t.Run("cancelled transactions never post", func(t *testing.T) {
+		w := schedule(t, "Gym", nextWeek)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var scheduled appFinance.ScheduledTransactionResponse
+		testsupport.DecodeData(t, w, &scheduled)
+
+		path := fmt.Sprintf("/api/v100/scheduled-transactions/%d/cancel", scheduled.ID)
+		w = server.Do(t, http.MethodPost, path, token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		testsupport.DecodeData(t, w, &scheduled)
+		assert.Equal(t, "cancelled", scheduled.Status)
+
+		w = server.Do(t, http.MethodPost, path, token, nil)
+		assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+
+		other := server.Token(t, fixtures.Admin)
+		w = server.Do(t, http.MethodPost, path, other, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+
+	t.Run("the scheduler posts transactions on their date", func(t *testing.T) {
+		yesterday := time.Now().UTC().AddDate(0, 0, -1).Truncate(24 * time.Hour)
+		model := database.ScheduledTransaction{
+			UserID:          fixtures.User.ID,
+			CategoryID:      fixtures.ExpenseCategory.ID,
+			CurrencyID:      fixtures.Currency.ID,
+			Amount:          45,
+			Description:     "Internet",
+			Date:            yesterday,
+			TransactionType: "expense",
+			Status:          "scheduled",
+		}
+		require.NoError(t, db.Create(&model).Error)
+
+		posted, err := server.App.PostScheduled.Execute(context.Background(), time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 1, posted)
+		assert.Equal(t, int64(1), countExpenses(t, "Internet"))
+		assert.Zero(t, countExpenses(t, "Electricity"))
+
+		require.NoError(t, db.First(&model, model.ID).Error)
+		assert.Equal(t, "posted", model.Status)
+		require.NotNil(t, model.TransactionID)
+
+		posted, err = server.App.PostScheduled.Execute(context.Background(), time.Now())
+		require.NoError(t, err)
+		assert.Zero(t, posted)
+	})
+
+	t.Run("the schedule policy schedules future-dated expenses", func(t *testing.T) {
+		scheduling := testsupport.NewServerWithConfig(t, db, func(cfg *config.Config) {
+			cfg.Transactions.FutureDates = "schedule"
+		})
+		w := scheduling.Do(t, http.MethodPost, "/api/v100/expenses", token, map[string]interface{}{
+			"category_id": fixtures.ExpenseCategory.ID,
+			"amount":      30,
+			"description": "Concert",
+			"date":        nextWeek,
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), `"status":"scheduled"`)
+		assert.Zero(t, countExpenses(t, "Concert"))
+
+		w = scheduling.Do(t, http.MethodGet, "/api/v100/scheduled-transactions", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "Concert")
+	})
+}
+
+func TestTrashRetentionIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	now := time.Now()
+
+	logChange := func(t *testing.T, user database.User, age time.Duration) uint {
+		action := database.Action{
+			UserID:    user.ID,
+			Kind:      "delete",
+			Target:    "transaction",
+			TargetID:  1,
+			Snapshot:  "{}",
+			CreatedAt: now.Add(-age),
+		}
+		require.NoError(t, db.Create(&action).Error)
+		return action.ID
+	}
+	kept := func(t *testing.T, id uint) bool {
+		var count int64
+		require.NoError(t, db.Model(&database.Action{}).Where("id = ?", id).Count(&count).Error)
+		return count == 1
+	}
+	setRetention := func(t *testing.T, user database.User, days int) *httptest.ResponseRecorder {
+		return server.Do(t, http.MethodPut, "/api/v100/preferences", server.Token(t, user), map[string]interface{}{
+			"trash_retention_days": days,
+		})
+	}
+
+	t.Run("the server's retention applies to everyone", func(t *testing.T) {
+		old := logChange(t, fixtures.User, 40*24*time.Hour)
+		recent := logChange(t, fixtures.User, 20*24*time.Hour)
+
+		_, err := server.App.PurgeTrash.Execute(context.Background(), now)
+		require.NoError(t, err)
+		assert.False(t, kept(t, old))
+		assert.True(t, kept(t, recent))
+	})
+
+	t.Run("users can shorten their own retention", func(t *testing.T) {
+		w := setRetention(t, fixtures.User, 7)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var preferences appIdentity.PreferencesResponse
+		testsupport.DecodeData(t, w, &preferences)
+		require.NotNil(t, preferences.TrashRetentionDays)
+		assert.Equal(t, 7, *preferences.TrashRetentionDays)
+
+		mine := logChange(t, fixtures.User, 10*24*time.Hour)
+		theirs := logChange(t, fixtures.Admin, 10*24*time.Hour)
+
+		_, err := server.App.PurgeTrash.Execute(context.Background(), now)
+		require.NoError(t, err)
+		assert.False(t, kept(t, mine))
+		assert.True(t, kept(t, theirs))
+	})
+
+	t.Run("no retention still keeps changes that can be undone", func(t *testing.T) {
+		w := setRetention(t, fixtures.Admin, 0)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		undoable := logChange(t, fixtures.Admin, time.Minute)
+		expired := logChange(t, fixtures.Admin, time.Hour)
+
+		_, err := server.App.PurgeTrash.Execute(context.Background(), now)
+		require.NoError(t, err)
+		assert.True(t, kept(t, undoable))
+		assert.False(t, kept(t, expired))
+	})
+
+	t.Run("retention cannot be negative", func(t *testing.T) {
+		w := setRetention(t, fixtures.User, -1)
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "VALIDATION_ERROR")
+	})
+}
+
+func TestEventOutboxIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	ctx := context.Background()
+
+	var received []finance.Event
+	var failWith error
+	server.App.EventBus.Subscribe("test", finance.EventTransactionCreated, func(ctx context.Context, event finance.Event) error {
+		received = append(received, event)
+		return failWith
+	})
+
+	createExpense := func(t *testing.T, description string) database.OutboxEvent {
+		w := server.Do(t, http.MethodPost, "/api/v100/expenses", server.Token(t, fixtures.User), map[string]interface{}{
+			"category_id": fixtures.ExpenseCategory.ID,
+			"amount":      12.5,
+			"description": description,
+			"date":        "2024-03-01",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var record database.OutboxEvent
+		require.NoError(t, db.Where("event_name = ?", finance.EventTransactionCreated).Order("id DESC").First(&record).Error)
+		return record
+	}
+	reload := func(t *testing.T, id uint) database.OutboxEvent {
+		var record database.OutboxEvent
+		require.NoError(t, db.First(&record, id).Error)
+		return record
+	}
+
+	t.Run("undelivered events are delivered from the outbox", func(t *testing.T) {
+		record := createExpense(t, "lunch")
+		assert.Nil(t, record.PublishedAt)
+		require.NotNil(t, record.NextAttemptAt)
+
+		require.NoError(t, server.App.EventBus.Deliver(ctx))
+		require.Len(t, received, 1)
+		event, ok := received[0].(finance.TransactionCreated)
+		require.True(t, ok)
+		assert.Equal(t, 12.5, event.Amount)
+		assert.Equal(t, int(fixtures.User.ID), event.UserID)
+
+		record = reload(t, record.ID)
+		assert.NotNil(t, record.PublishedAt)
+		assert.Nil(t, record.NextAttemptAt)
+		assert.Equal(t, 1, record.Attempts)
+
+		require.NoError(t, server.App.EventBus.Deliver(ctx))
+		assert.Len(t, received, 1)
+	})
+
+	t.Run("failed deliveries are retried later", func(t *testing.T) {
+		received = nil
+		failWith = errors.New("push service unavailable")
+		record := createExpense(t, "dinner")
+
+		require.NoError(t, server.App.EventBus.Deliver(ctx))
+		record = reload(t, record.ID)
+		assert.Nil(t, record.PublishedAt)
+		require.NotNil(t, record.NextAttemptAt)
+		assert.True(t, record.NextAttemptAt.After(time.Now()))
+		assert.Equal(t, "push service unavailable", record.LastError)
+
+		// Not due yet
+		require.NoError(t, server.App.EventBus.Deliver(ctx))
+		assert.Len(t, received, 1)
+
+		failWith = nil
+		require.NoError(t, db.Model(&record).Update("next_attempt_at", time.Now().Add(-time.Second)).Error)
+		require.NoError(t, server.App.EventBus.Deliver(ctx))
+		assert.Len(t, received, 2)
+		record = reload(t, record.ID)
+		assert.NotNil(t, record.PublishedAt)
+		assert.Equal(t, 2, record.Attempts)
+	})
+
+	t.Run("retries only call the subscribers that failed", func(t *testing.T) {
+		received = nil
+		var other int
+		server.App.EventBus.Subscribe("other", finance.EventTransactionCreated, func(ctx context.Context, event finance.Event) error {
+			other++
+			return nil
+		})
+		failWith = errors.New("push service unavailable")
+		record := createExpense(t, "breakfast")
+
+		require.NoError(t, server.App.EventBus.Deliver(ctx))
+		record = reload(t, record.ID)
+		assert.Nil(t, record.PublishedAt)
+		assert.Contains(t, record.DeliveredTo, "other")
+		assert.NotContains(t, record.DeliveredTo, "test")
+
+		failWith = nil
+		require.NoError(t, db.Model(&record).Update("next_attempt_at", time.Now().Add(-time.Second)).Error)
+		require.NoError(t, server.App.EventBus.Deliver(ctx))
+		assert.Len(t, received, 2)
+		assert.Equal(t, 1, other)
+		assert.NotNil(t, reload(t, record.ID).PublishedAt)
+	})
+
+	t.Run("delivery is given up after too many attempts", func(t *testing.T) {
+		failWith = errors.New("push service unavailable")
+		defer func() { failWith = nil }()
+		record := createExpense(t, "snack")
+		require.NoError(t, db.Model(&record).Update("attempts", events.MaxAttempts-1).Error)
+
+		require.NoError(t, server.App.EventBus.Deliver(ctx))
+		record = reload(t, record.ID)
+		assert.Nil(t, record.PublishedAt)
+		assert.Nil(t, record.NextAttemptAt)
+		assert.Equal(t, events.MaxAttempts, record.Attempts)
+	})
+
+	t.Run("unknown events are given up on", func(t *testing.T) {
+		now := time.Now()
+		record := database.OutboxEvent{EventName: "salary.paid", Payload: "{}", OccurredAt: now, NextAttemptAt: &now}
+		require.NoError(t, db.Create(&record).Error)
+
+		require.NoError(t, server.App.EventBus.Deliver(ctx))
+		record = reload(t, record.ID)
+		assert.Nil(t, record.NextAttemptAt)
+		assert.Contains(t, record.LastError, "unknown event")
+	})
+}
+
+func TestEventBrokerIntegration(t *testing.T) {
+	// listen serves one broker connection, calling handle with the connection's reader
+	listen := func(t *testing.T, handle func(conn net.Conn, reader *bufio.Reader)) string {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		t.Cleanup(func() { listener.Close() })
+		go func() {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			handle(conn, bufio.NewReader(conn))
+		}()
+		return listener.Addr().String()
+	}
+	publishExpense := func(t *testing.T, brokerType, brokerURL string) *gorm.DB {
+		db := testsupport.NewDatabase(t)
+		fixtures := testsupport.Seed(t, db)
+		server := testsupport.NewServerWithConfig(t, db, func(cfg *config.Config) {
+			cfg.Broker.Type = brokerType
+			cfg.Broker.URL = brokerURL
+		})
+
+		w := server.Do(t, http.MethodPost, "/api/v100/expenses", server.Token(t, fixtures.User), map[string]interface{}{
+			"category_id": fixtures.ExpenseCategory.ID,
+			"amount":      12.5,
+			"description": "lunch",
+			"date":        "2024-03-01",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		require.NoError(t, server.App.EventBus.Deliver(context.Background()))
+		return db
+	}
+	received := func(t *testing.T, messages <-chan [2]string) (string, map[string]interface{}) {
+		select {
+		case message := <-messages:
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(message[1]), &body))
+			return message[0], body
+		case <-time.After(5 * time.Second):
+			t.Fatal("no message published")
+			return "", nil
+		}
+	}
+
+	t.Run("events are published to NATS", func(t *testing.T) {
+		messages := make(chan [2]string, 4)
+		address := listen(t, func(conn net.Conn, reader *bufio.Reader) {
+			conn.Write([]byte("INFO {\"server_id\":\"test\"}\r\n"))
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				switch fields := strings.Fields(line); fields[0] {
+				case "PING":
+					conn.Write([]byte("PONG\r\n"))
+				case "PUB":
+					payload, _ := reader.ReadString('\n')
+					messages <- [2]string{fields[1], strings.TrimSpace(payload)}
+				}
+			}
+		})
+
+		publishExpense(t, "nats", "nats://"+address)
+		subject, body := received(t, messages)
+		assert.Equal(t, "pandapocket.transaction.created", subject)
+		assert.Equal(t, finance.EventTransactionCreated, body["event"])
+		assert.Equal(t, 12.5, body["data"].(map[string]interface{})["amount"])
+	})
+
+	t.Run("events are published to RabbitMQ over STOMP", func(t *testing.T) {
+		messages := make(chan [2]string, 4)
+		address := listen(t, func(conn net.Conn, reader *bufio.Reader) {
+			for {
+				frame, err := reader.ReadString(0)
+				if err != nil {
+					return
+				}
+				head, body, _ := strings.Cut(strings.TrimLeft(frame, "\n"), "\n\n")
+				lines := strings.Split(head, "\n")
+				headers := map[string]string{}
+				for _, line := range lines[1:] {
+					name, value, _ := strings.Cut(line, ":")
+					headers[name] = value
+				}
+				switch lines[0] {
+				case "CONNECT":
+					conn.Write([]byte("CONNECTED\nversion:1.2\n\n\x00"))
+				case "SEND":
+					messages <- [2]string{headers["destination"], strings.TrimSuffix(body, "\x00")}
+					conn.Write([]byte("RECEIPT\nreceipt-id:" + headers["receipt"] + "\n\n\x00"))
+				}
+			}
+		})
+
+		publishExpense(t, "rabbitmq", "stomp://guest:guest@"+address+"/")
+		destination, body := received(t, messages)
+		assert.Equal(t, "/topic/pandapocket.transaction.created", destination)
+		assert.Equal(t, finance.EventTransactionCreated, body["event"])
+	})
+
+	t.Run("events the broker does not accept are retried", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		address := listener.Addr().String()
+		listener.Close()
+
+		db := publishExpense(t, "nats", "nats://"+address)
+		var record database.OutboxEvent
+		require.NoError(t, db.Where("event_name = ?", finance.EventTransactionCreated).First(&record).Error)
+		assert.Nil(t, record.PublishedAt)
+		assert.NotNil(t, record.NextAttemptAt)
+		assert.NotEmpty(t, record.LastError)
+		assert.Contains(t, record.DeliveredTo, "webhooks")
+		assert.NotContains(t, record.DeliveredTo, "broker")
+	})
+}
+
+func TestWarehouseExportIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	dir := t.TempDir()
+	server := testsupport.NewServerWithConfig(t, db, func(cfg *config.Config) {
+		cfg.Warehouse.Storage = "local"
+		cfg.Warehouse.Dir = dir
+	})
+	token := server.Token(t, fixtures.User)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	yesterday := today.AddDate(0, 0, -1)
+
+	create := func(t *testing.T, transactionType string, categoryID uint, amount float64) uint {
+		w := server.Do(t, http.MethodPost, "/api/v100/"+transactionType+"s", token, map[string]interface{}{
+			"category_id": categoryID,
+			"amount":      amount,
+			"description": "private note",
+			"date":        "2024-03-01",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var created map[string]appFinance.CreateTransactionResponse
+		testsupport.DecodeData(t, w, &created)
+		return uint(created[transactionType].ID)
+	}
+	// read returns the rows of an exported file, without its header
+	read := func(t *testing.T, key string) [][]string {
+		file, err := os.Open(filepath.Join(dir, filepath.FromSlash(key)))
+		require.NoError(t, err)
+		defer file.Close()
+		gz, err := gzip.NewReader(file)
+		require.NoError(t, err)
+		records, err := csv.NewReader(gz).ReadAll()
+		require.NoError(t, err)
+		return records[1:]
+	}
+
+	lunch := create(t, "expense", fixtures.ExpenseCategory.ID, 12.5)
+	rent := create(t, "expense", fixtures.ExpenseCategory.ID, 900)
+	salary := create(t, "income", fixtures.IncomeCategory.ID, 3000)
+	longAgo := today.AddDate(0, 0, -3)
+	require.NoError(t, db.Model(&database.Expense{}).Where("id IN ?", []uint{lunch, rent}).UpdateColumns(map[string]interface{}{"created_at": longAgo, "updated_at": longAgo}).Error)
+	require.NoError(t, db.Model(&database.Income{}).Where("id = ?", salary).UpdateColumns(map[string]interface{}{"created_at": longAgo, "updated_at": longAgo}).Error)
+
+	t.Run("the first export holds every transaction", func(t *testing.T) {
+		rows, err := server.App.WarehouseExport.Export(context.Background(), yesterday)
+		require.NoError(t, err)
+		assert.Equal(t, 3, rows)
+
+		partition := "transactions/exported=" + yesterday.Format("2006-01-02") + "/"
+		transactions := read(t, partition+"transactions.csv.gz")
+		require.Len(t, transactions, 3)
+		assert.Equal(t, []string{"expense", strconv.Itoa(int(lunch)), strconv.Itoa(int(fixtures.User.ID))}, transactions[0][:3])
+		assert.Equal(t, "12.50", transactions[0][6])
+		assert.Equal(t, "2024-03-01", transactions[0][7])
+		assert.Equal(t, "income", transactions[2][0])
+		assert.NotContains(t, strings.Join(transactions[0], ","), "private note")
+		assert.Empty(t, read(t, partition+"deletions.csv.gz"))
+	})
+
+	t.Run("later exports hold only what changed since", func(t *testing.T) {
+		w := server.Do(t, http.MethodPut, fmt.Sprintf("/api/v100/expenses/%d", rent), token, map[string]interface{}{
+			"category_id": fixtures.ExpenseCategory.ID,
+			"amount":      950,
+			"description": "private note",
+			"date":        "2024-03-01",
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		w = server.Do(t, http.MethodDelete, fmt.Sprintf("/api/v100/expenses/%d", lunch), token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		changedAt := yesterday.Add(time.Hour)
+		require.NoError(t, db.Model(&database.Expense{}).Where("id = ?", rent).UpdateColumn("updated_at", changedAt).Error)
+		require.NoError(t, db.Model(&database.Tombstone{}).Where("record_id = ?", lunch).UpdateColumn("deleted_at", changedAt).Error)
+
+		rows, err := server.App.WarehouseExport.Export(context.Background(), today)
+		require.NoError(t, err)
+		assert.Equal(t, 2, rows)
+
+		partition := "transactions/exported=" + today.Format("2006-01-02") + "/"
+		transactions := read(t, partition+"transactions.csv.gz")
+		require.Len(t, transactions, 1)
+		assert.Equal(t, strconv.Itoa(int(rent)), transactions[0][1])
+		assert.Equal(t, "950.00", transactions[0][6])
+		deletions := read(t, partition+"deletions.csv.gz")
+		require.Len(t, deletions, 1)
+		assert.Equal(t, []string{"expense", strconv.Itoa(int(lunch))}, deletions[0][:2])
+
+		rows, err = server.App.WarehouseExport.Export(context.Background(), today)
+		require.NoError(t, err)
+		assert.Zero(t, rows)
+	})
+}
+
+func TestAnalyticsProjectionIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServer(t, db)
+	token := server.Token(t, fixtures.User)
+	ctx := context.Background()
+
+	now := time.Now()
+	today := now.Format("2006-01-02")
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	analytics := func(t *testing.T) appFinance.GetAnalyticsResponse {
+		w := server.Do(t, http.MethodGet, "/api/v100/analytics?period=monthly", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response appFinance.GetAnalyticsResponse
+		testsupport.DecodeData(t, w, &response)
+		return response
+	}
+
+	w := server.Do(t, http.MethodPost, "/api/v100/budgets", token, map[string]interface{}{
+		"category_id": fixtures.ExpenseCategory.ID,
+		"amount":      200,
+		"period":      "monthly",
+		"start_date":  monthStart.Format("2006-01-02"),
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = server.Do(t, http.MethodPost, "/api/v100/expenses", token, map[string]interface{}{
+		"category_id": fixtures.ExpenseCategory.ID,
+		"amount":      30,
+		"description": "Groceries",
+		"date":        today,
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created map[string]appFinance.CreateTransactionResponse
+	testsupport.DecodeData(t, w, &created)
+	expenseID := created["expense"].ID
+
+	t.Run("projects new users on their first read", func(t *testing.T) {
+		response := analytics(t)
+		assert.Equal(t, 30.0, response.TotalSpent)
+		assert.Equal(t, 1, response.TransactionCount)
+		require.Len(t, response.ByCategory, 1)
+		assert.Equal(t, int(fixtures.ExpenseCategory.ID), response.ByCategory[0].CategoryID)
+		assert.Equal(t, "expense", response.ByCategory[0].Type)
+		assert.Equal(t, fixtures.Currency.Code, response.ByCategory[0].CurrencyCode)
+		require.Len(t, response.Budgets, 1)
+		assert.Equal(t, 30.0, response.Budgets[0].Spent)
+		assert.Equal(t, 15.0, response.Budgets[0].PercentageUsed)
+	})
+
+	t.Run("follows edits once their events are delivered", func(t *testing.T) {
+		w := server.Do(t, http.MethodPut, fmt.Sprintf("/api/v100/expenses/%d", expenseID), token, map[string]interface{}{
+			"category_id": fixtures.ExpenseCategory.ID,
+			"amount":      50,
+			"description": "Groceries",
+			"date":        today,
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, 30.0, analytics(t).TotalSpent, "the read model lags until the event is delivered")
+
+		require.NoError(t, server.App.EventBus.Deliver(ctx))
+		response := analytics(t)
+		assert.Equal(t, 50.0, response.TotalSpent)
+		require.Len(t, response.Budgets, 1)
+		assert.Equal(t, 150.0, response.Budgets[0].Remaining)
+
+		w = server.Do(t, http.MethodGet, "/api/v100/analytics/spending-by-period?periods=2", token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var byPeriod appFinance.SpendingByPeriodResponse
+		testsupport.DecodeData(t, w, &byPeriod)
+		assert.Equal(t, 50.0, byPeriod.Periods[1].TotalSpent)
+	})
+
+	t.Run("follows deletes", func(t *testing.T) {
+		w := server.Do(t, http.MethodDelete, fmt.Sprintf("/api/v100/expenses/%d", expenseID), token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, server.App.EventBus.Deliver(ctx))
+
+		response := analytics(t)
+		assert.Zero(t, response.TotalSpent)
+		assert.Zero(t, response.TransactionCount)
+		assert.Empty(t, response.ByCategory)
+	})
+
+	t.Run("catches up with writes that raise no events", func(t *testing.T) {
+		fixtures.AddExpense(t, db, 80, monthStart)
+		assert.Zero(t, analytics(t).TotalSpent)
+
+		projected, err := server.App.AnalyticsProjector.Execute(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, projected)
+		response := analytics(t)
+		assert.Equal(t, 80.0, response.TotalSpent)
+		require.Len(t, response.Budgets, 1)
+		assert.Equal(t, 80.0, response.Budgets[0].Spent)
+	})
+}
+
+func TestSlowQueryLoggingIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+
+	// Every query counts as slow, and logs are captured to check they hold no values
+	var logs bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+	db.Logger = database.NewQueryLogger(config.QueryLogConfig{Level: "warn", SlowThreshold: time.Nanosecond}, metrics.NewSlowQueries())
+
+	server := testsupport.NewServer(t, db)
+	adminToken := server.Token(t, fixtures.Admin)
+
+	w := server.Do(t, http.MethodPost, "/api/v100/expenses", server.Token(t, fixtures.User), map[string]interface{}{
+		"category_id": fixtures.ExpenseCategory.ID,
+		"currency_id": fixtures.Currency.ID,
+		"amount":      4321.5,
+		"description": "secret-lunch",
+		"date":        "2024-03-01",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	assert.Contains(t, logs.String(), `"msg":"slow query"`)
+	assert.Contains(t, logs.String(), `"request_id"`)
+	assert.NotContains(t, logs.String(), "secret-lunch")
+	assert.NotContains(t, logs.String(), "4321.5")
+
+	w = server.Do(t, http.MethodGet, "/api/admin/metrics/slow-queries", adminToken, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var report struct {
+		Queries []handlers.SlowQueryResponse `json:"queries"`
+	}
+	testsupport.DecodeData(t, w, &report)
+	require.NotEmpty(t, report.Queries)
+	inserts := 0
+	for _, query := range report.Queries {
+		assert.NotContains(t, query.SQL, "secret-lunch")
+		assert.Positive(t, query.Count)
+		if strings.HasPrefix(query.SQL, "INSERT INTO `expenses`") {
+			inserts++
+			assert.Contains(t, query.SQL, "?")
+		}
+	}
+	assert.Equal(t, 1, inserts)
+
+	w = server.Do(t, http.MethodGet, "/api/admin/metrics/slow-queries", server.Token(t, fixtures.User), nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestRequestTimeoutIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	server := testsupport.NewServerWithConfig(t, db, func(cfg *config.Config) {
+		cfg.Server.RequestTimeout = 100 * time.Millisecond
+	})
+	token := server.Token(t, fixtures.User)
+
+	// Queries on expenses get stuck until their context is cancelled
+	stuck := true
+	require.NoError(t, db.Callback().Query().Before("gorm:query").Register("test:stuck", func(tx *gorm.DB) {
+		if stuck && tx.Statement.Table == "expenses" {
+			<-tx.Statement.Context.Done()
+		}
+	}))
+
+	started := time.Now()
+	w := server.Do(t, http.MethodGet, "/api/v100/expenses", token, nil)
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), "REQUEST_TIMEOUT")
+	assert.Less(t, time.Since(started), 5*time.Second)
+
+	// Requests that touch no stuck query are unaffected
+	w = server.Do(t, http.MethodGet, "/api/v100/categories", token, nil)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	stuck = false
+	w = server.Do(t, http.MethodGet, "/api/v100/expenses", token, nil)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
+
+func TestMaintenanceModeIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+
+	t.Run("config", func(t *testing.T) {
+		server := testsupport.NewServerWithConfig(t, db, func(cfg *config.Config) {
+			cfg.Maintenance.Enabled = true
+		})
+
+		w := server.Do(t, http.MethodGet, "/api/v100/preferences", server.Token(t, fixtures.User), nil)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code, w.Body.String())
+		assert.Equal(t, "300", w.Header().Get("Retry-After"))
+		assert.Contains(t, w.Body.String(), `"error_code":"MAINTENANCE"`)
+		assert.Contains(t, w.Body.String(), "will be back shortly")
+
+		w = server.Do(t, http.MethodPost, "/api/v100/auth/register", "", map[string]interface{}{
+			"email":    "new@example.com",
+			"password": "password123",
+		})
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code, w.Body.String())
+
+		// Admins can still sign in and use the API
+		w = server.Do(t, http.MethodPost, "/api/v100/auth/login", "", gin.H{"email": fixtures.Admin.Email, "password": testsupport.FixturePassword})
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		adminToken := server.Token(t, fixtures.Admin)
+		w = server.Do(t, http.MethodGet, "/api/v100/preferences", adminToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		w = server.Do(t, http.MethodGet, "/api/admin/users", adminToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodGet, "/health", "", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("flag", func(t *testing.T) {
+		server := testsupport.NewServer(t, db)
+		adminToken := server.Token(t, fixtures.Admin)
+		userToken := server.Token(t, fixtures.User)
+
+		w := server.Do(t, http.MethodGet, "/api/v100/preferences", userToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = server.Do(t, http.MethodPut, "/api/admin/feature-flags/maintenance", adminToken, map[string]interface{}{"enabled": true})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		w = server.Do(t, http.MethodGet, "/api/v100/preferences", userToken, nil)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code, w.Body.String())
+		assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+		w = server.Do(t, http.MethodDelete, "/api/admin/feature-flags/maintenance", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		w = server.Do(t, http.MethodGet, "/api/v100/preferences", userToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+}
+
+func TestTrustedProxiesIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	testsupport.Seed(t, db)
+
+	newHandler := func(trustedProxies []string) http.Handler {
+		server := testsupport.NewServerWithConfig(t, db, func(cfg *config.Config) {
+			cfg.RateLimit.Enabled = true
+			cfg.RateLimit.Global = config.RateLimitRule{Requests: 2, Window: time.Minute}
+			cfg.Server.TrustedProxies = trustedProxies
+		})
+		return server.App.Handler()
+	}
+	// httptest requests come from 192.0.2.1
+	get := func(handler http.Handler, forwardedFor string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	// Without trusted proxies a spoofed X-Forwarded-For does not reset the limit
+	handler := newHandler(nil)
+	for i := 0; i < 2; i++ {
+		require.Equal(t, http.StatusOK, get(handler, fmt.Sprintf("203.0.113.%d", i)).Code)
+	}
+	w := get(handler, "203.0.113.99")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Contains(t, w.Body.String(), "RATE_LIMIT_EXCEEDED")
+
+	// Behind a trusted proxy every forwarded client has its own bucket
+	handler = newHandler([]string{"192.0.2.0/24"})
+	for i := 0; i < 2; i++ {
+		require.Equal(t, http.StatusOK, get(handler, "203.0.113.1").Code)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, get(handler, "203.0.113.1").Code)
+	assert.Equal(t, http.StatusOK, get(handler, "203.0.113.2").Code)
+}
+
+func TestNewAppInvalidEncryptionKeyIntegration(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+
+	cfg := config.Default()
+	cfg.Encryption.Key = "not-a-key"
+	app, err := application.NewApp(db, cfg)
+	assert.Nil(t, app)
+	assert.ErrorContains(t, err, "field encryption key")
+}
@@ -1,78 +1,267 @@
 package application
 
 import (
+	"context"
+	"fmt"
+	"log/slog"
 	"net/http"
 	appFinance "panda-pocket/internal/application/finance"
 	appIdentity "panda-pocket/internal/application/identity"
+	appNotification "panda-pocket/internal/application/notification"
 	domainFinance "panda-pocket/internal/domain/finance"
 	domainIdentity "panda-pocket/internal/domain/identity"
+	"panda-pocket/internal/infrastructure/backup"
+	"panda-pocket/internal/infrastructure/broker"
+	"panda-pocket/internal/infrastructure/captcha"
+	"panda-pocket/internal/infrastructure/chat"
+	"panda-pocket/internal/infrastructure/config"
 	"panda-pocket/internal/infrastructure/database"
+	"panda-pocket/internal/infrastructure/encryption"
+	"panda-pocket/internal/infrastructure/events"
+	"panda-pocket/internal/infrastructure/export"
+	"panda-pocket/internal/infrastructure/featureflags"
+	"panda-pocket/internal/infrastructure/mail"
+	"panda-pocket/internal/infrastructure/metrics"
+	"panda-pocket/internal/infrastructure/prices"
+	"panda-pocket/internal/infrastructure/ratelimit"
+	"panda-pocket/internal/infrastructure/realtime"
+	"panda-pocket/internal/infrastructure/warehouse"
+	"panda-pocket/internal/infrastructure/webhook"
 	"panda-pocket/internal/interfaces/http/handlers"
 	"panda-pocket/internal/interfaces/http/middleware"
 	"panda-pocket/internal/interfaces/http/versioning"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
 // App represents the application with all its dependencies
 type App struct {
-	DB                 *gorm.DB
-	IdentityHandlers   *handlers.IdentityHandlers
-	FinanceHandlers    *handlers.FinanceHandlers
-	DashboardHandlers  *handlers.DashboardHandlers
-	DeprecationHandler *handlers.DeprecationHandler
-	AuthMiddleware     *middleware.AuthMiddleware
-	VersionMiddleware  *middleware.VersionMiddleware
-	VersionManager     *versioning.VersionManager
-}
-
-// NewApp creates a new application instance with all dependencies wired up
-func NewApp(db *gorm.DB) *App {
+	Config               *config.Config
+	DB                   *gorm.DB
+	IdentityHandlers     *handlers.IdentityHandlers
+	FinanceHandlers      *handlers.FinanceHandlers
+	FinanceHandlersV110  *handlers.FinanceHandlersV110
+	FinanceHandlersV120  *handlers.FinanceHandlersV120
+	DashboardHandlers    *handlers.DashboardHandlers
+	AdminHandlers        *handlers.AdminHandlers
+	GrowthMetrics        *handlers.GrowthMetricsHandler
+	NotificationHandlers *handlers.NotificationHandlers
+	DeprecationHandler   *handlers.DeprecationHandler
+	HealthHandlers       *handlers.HealthHandlers
+	AuthMiddleware       *middleware.AuthMiddleware
+	LoggingMiddleware    *middleware.LoggingMiddleware
+	RateLimitMiddleware  *middleware.RateLimitMiddleware
+	BodyLimitMiddleware  *middleware.BodyLimitMiddleware
+	TimeoutMiddleware    *middleware.TimeoutMiddleware
+	Maintenance          *middleware.MaintenanceMiddleware
+	CaptchaMiddleware    *middleware.CaptchaMiddleware
+	LocaleMiddleware     *middleware.LocaleMiddleware
+	VersionMiddleware    *middleware.VersionMiddleware
+	VersionManager       *versioning.VersionManager
+	VersionUsageTracker  *metrics.VersionUsageTracker
+	VersionUsageHandler  *handlers.VersionUsageHandler
+	SlowQueryHandler     *handlers.SlowQueryHandler
+	ArchiveTransactions  *appFinance.ArchiveTransactionsUseCase
+	ScheduledExports     *appFinance.RunScheduledExportsUseCase
+	PostScheduled        *appFinance.PostScheduledTransactionsUseCase
+	PostRecurring        *appFinance.PostRecurringTransactionsUseCase
+	PurgeTrash           *appFinance.PurgeTrashUseCase
+	AnalyticsProjector   *appFinance.AnalyticsProjector
+	RecurringReminders   *appNotification.SendRecurringRemindersUseCase
+	CardPaymentReminders *appNotification.SendCardPaymentRemindersUseCase
+	AnomalyDetection     *appNotification.DetectAnomaliesUseCase
+	EventBus             *events.Bus
+	EmailQueue           *mail.Queue
+	BackupService        *backup.Service
+	WarehouseExport      *warehouse.Exporter
+	BackupHandler        *handlers.BackupHandler
+	EmailQueueHandler    *handlers.EmailQueueHandler
+	FeatureFlags         *featureflags.FlagService
+	FeatureFlagHandler   *handlers.FeatureFlagHandler
+	WebhookHandler       *handlers.WebhookHandler
+	SearchHandler        *handlers.SearchHandler
+	ActionHandler        *handlers.ActionHandler
+	AccountHandler       *handlers.AccountHandler
+	ExportHandler        *handlers.ExportHandler
+	TaxHandler           *handlers.TaxHandler
+	ReceiptHandler       *handlers.ReceiptHandler
+	RecurringHandler     *handlers.RecurringHandler
+	ScheduledHandler     *handlers.ScheduledTransactionHandler
+	BudgetSuggestions    *handlers.BudgetSuggestionHandler
+	BudgetCalendar       *handlers.BudgetCalendarHandler
+	PreferencesHandler   *handlers.PreferencesHandler
+	UsageHandler         *handlers.UsageHandler
+	SyncHandler          *handlers.SyncHandler
+	LiveHandler          *handlers.LiveHandler
+	ClosedMonthHandler   *handlers.ClosedMonthHandler
+	ExchangeRateHandler  *handlers.ExchangeRateHandler
+	InvestmentHandler    *handlers.InvestmentHandler
+	HoldingPrices        *appFinance.RefreshHoldingPricesUseCase
+	EmergencyFundHandler *handlers.EmergencyFundHandler
+	SafeToSpendHandler   *handlers.SafeToSpendHandler
+	AnalyticsHandler     *handlers.AnalyticsHandler
+	DefaultBudgets       *handlers.DefaultBudgetHandler
+	OnboardingHandler    *handlers.OnboardingHandler
+	PaletteHandler       *handlers.PaletteHandler
+}
+
+// NewApp creates a new application instance with all dependencies wired up. It
+// fails when a dependency the application cannot run without cannot be set up.
+func NewApp(db *gorm.DB, cfg *config.Config) (*App, error) {
 	// Infrastructure layer - repositories (GORM)
 	userRepo := database.NewGormUserRepository(db)
-	categoryRepo := database.NewGormCategoryRepository(db)
-	currencyRepo := database.NewGormCurrencyRepository(db)
+	passwordResetRepo := database.NewGormPasswordResetRepository(db)
+	categoryRepo := database.NewGormCategoryRepository(db).WithDefaultsCache()
+	currencyRepo := database.NewGormCurrencyRepository(db).WithDefaultsCache()
 	transactionRepo := database.NewGormTransactionRepository(db)
 	budgetRepo := database.NewGormBudgetRepository(db)
+	actionRepo := database.NewGormActionRepository(db)
+	lineItemRepo := database.NewGormReceiptLineItemRepository(db)
+	// There is no safe fallback when the field encryption key cannot be loaded,
+	// because writing plaintext or failing to read encrypted values would both lose data
+	fields, err := encryption.NewFromConfig(context.Background(), cfg.Encryption)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the field encryption key: %w", err)
+	}
+	if fields != nil {
+		transactionRepo = transactionRepo.WithFieldCipher(fields)
+		actionRepo = actionRepo.WithFieldCipher(fields)
+		lineItemRepo = lineItemRepo.WithFieldCipher(fields)
+	}
+	accountRepo := database.NewGormAccountRepository(db)
+	balanceAssertionRepo := database.NewGormBalanceAssertionRepository(db)
+	balanceAdjustmentRepo := database.NewGormBalanceAdjustmentRepository(db)
+	exportScheduleRepo := database.NewGormExportScheduleRepository(db)
+	exportRunRepo := database.NewGormExportRunRepository(db)
+	recurringRepo := database.NewGormRecurringTransactionRepository(db)
+	scheduledRepo := database.NewGormScheduledTransactionRepository(db)
+	taxCategoryRepo := database.NewGormTaxCategoryRepository(db)
+	notificationRepo := database.NewGormNotificationRepository(db)
+	notificationChannelRepo := database.NewGormNotificationChannelRepository(db)
+	webhookRepo := database.NewGormWebhookRepository(db)
+	preferencesRepo := database.NewGormPreferencesRepository(db)
+	anomalyRepo := database.NewGormAnomalyRepository(db)
+	closedMonthRepo := database.NewGormClosedMonthRepository(db)
+	exchangeRateRepo := database.NewGormExchangeRateRepository(db)
+	holdingRepo := database.NewGormHoldingRepository(db)
+	holdingSnapshotRepo := database.NewGormHoldingSnapshotRepository(db)
+	spendingBenchmarkRepo := database.NewGormSpendingBenchmarkRepository(db)
+	unitOfWork := database.NewGormUnitOfWork(db)
+
+	// Domain events
+	eventBus := events.NewBus(database.NewGormOutboxRepository(db))
+	eventBus.Subscribe("log", events.AllEvents, events.LogHandler(slog.Default()))
+	liveHub := realtime.NewHub()
+	eventBus.Subscribe("live", events.AllEvents, liveHub.HandleEvent)
+	dispatcher := appNotification.NewDispatcher(notificationRepo, notificationChannelRepo, chat.NewPoster()).WithPusher(liveHub)
+	eventBus.Subscribe("notifications", domainFinance.EventBudgetExceeded, dispatcher.HandleBudgetExceeded)
+	webhookSender := webhook.NewSender()
+	webhookDeliverer := appNotification.NewWebhookDeliverer(webhookRepo, webhookSender)
+	eventBus.Subscribe("webhooks", events.AllEvents, webhookDeliverer.HandleEvent)
+	if publisher := broker.NewPublisher(cfg.Broker); publisher != nil {
+		eventBus.Subscribe("broker", events.AllEvents, publisher.HandleEvent)
+	}
+
+	// The analytics read model is projected again for each event about a user
+	analyticsProjector := appFinance.NewAnalyticsProjector(transactionRepo, budgetRepo, database.NewGormAnalyticsRepository(db))
+	eventBus.Subscribe("analytics", events.AllEvents, analyticsProjector.HandleEvent)
 
 	// Domain layer - services
 	userService := domainIdentity.NewUserService(userRepo)
-	transactionService := domainFinance.NewTransactionService(transactionRepo, categoryRepo, currencyRepo)
+	transactionService := domainFinance.NewTransactionService(transactionRepo, categoryRepo, currencyRepo, budgetRepo, accountRepo, actionRepo, closedMonthRepo, eventBus).
+		WithFutureDatePolicy(domainFinance.FutureDatePolicy(cfg.Transactions.FutureDates)).
+		WithScheduledTransactions(scheduledRepo).
+		WithRecurringTransactions(recurringRepo)
 	categoryService := domainFinance.NewCategoryService(categoryRepo)
-	currencyService := domainFinance.NewCurrencyService(currencyRepo)
-	budgetService := domainFinance.NewBudgetService(budgetRepo, categoryRepo)
+	currencyService := domainFinance.NewCurrencyService(currencyRepo, eventBus)
+	budgetService := domainFinance.NewBudgetService(budgetRepo, categoryRepo, actionRepo)
+	actionService := domainFinance.NewActionService(actionRepo, transactionRepo, budgetRepo, closedMonthRepo)
+	accountService := domainFinance.NewAccountService(accountRepo, currencyRepo, transactionRepo, balanceAssertionRepo, balanceAdjustmentRepo)
+	taxService := domainFinance.NewTaxService(taxCategoryRepo, categoryRepo, transactionRepo)
+	receiptService := domainFinance.NewReceiptService(lineItemRepo, transactionRepo, categoryRepo)
+	investmentService := domainFinance.NewInvestmentService(holdingRepo, holdingSnapshotRepo, currencyRepo)
 
 	// Application layer - use cases
-	tokenService := appIdentity.NewTokenService()
-	registerUserUseCase := appIdentity.NewRegisterUserUseCase(userService, tokenService)
+	tokenService := appIdentity.NewTokenService(cfg.Auth.JWTSecret, cfg.Auth.JWTExpiry)
+	manageDefaultBudgetsUseCase := appFinance.NewManageDefaultBudgetsUseCase(
+		database.NewGormDefaultBudgetRepository(db),
+		budgetService,
+		categoryService,
+		currencyService,
+		unitOfWork,
+	)
+	registerUserUseCase := appIdentity.NewRegisterUserUseCase(userService, tokenService).
+		WithDefaultCategories(appFinance.NewCopyDefaultCategoriesUseCase(categoryService, unitOfWork)).
+		WithDefaultBudgets(manageDefaultBudgetsUseCase)
 	loginUserUseCase := appIdentity.NewLoginUserUseCase(userService, tokenService)
 	getUsersUseCase := appIdentity.NewGetUsersUseCase(userService)
+	emailQueue := mail.NewQueue(database.NewGormEmailQueueRepository(db), newMailer(cfg.Mail), cfg.Mail.MaxAttempts)
+	forgotPasswordUseCase := appIdentity.NewForgotPasswordUseCase(
+		userService,
+		passwordResetRepo,
+		emailQueue,
+		newTemplateRenderer(cfg.Mail),
+		cfg.Auth.PasswordResetURL,
+		cfg.Auth.PasswordResetTTL,
+		cfg.Auth.PasswordResetLimit,
+	)
+	resetPasswordUseCase := appIdentity.NewResetPasswordUseCase(userService, passwordResetRepo)
 	getDashboardStatsUseCase := appIdentity.NewGetDashboardStatsUseCase(userRepo, budgetRepo, transactionRepo)
+	listUsersUseCase := appIdentity.NewListUsersUseCase(userService)
+	getUserUsageUseCase := appIdentity.NewGetUserUsageUseCase(userService, budgetRepo, transactionRepo)
+	deactivateUserUseCase := appIdentity.NewDeactivateUserUseCase(userService)
+	getNotificationsUseCase := appNotification.NewGetNotificationsUseCase(notificationRepo)
+	markNotificationReadUseCase := appNotification.NewMarkNotificationReadUseCase(notificationRepo)
+	broadcastAnnouncementUseCase := appNotification.NewBroadcastAnnouncementUseCase(notificationRepo, userService, emailQueue)
+	manageChannelsUseCase := appNotification.NewManageChannelsUseCase(notificationChannelRepo)
+	manageWebhooksUseCase := appNotification.NewManageWebhooksUseCase(webhookRepo, events.Names())
 	createTransactionUseCase := appFinance.NewCreateTransactionUseCase(transactionService, currencyService)
+	searchUseCase := appFinance.NewSearchUseCase(transactionService, categoryService, budgetService)
 	getTransactionsUseCase := appFinance.NewGetTransactionsUseCase(transactionService, categoryService)
 	getAllTransactionsUseCase := appFinance.NewGetAllTransactionsUseCase(transactionService, categoryService)
-	updateTransactionUseCase := appFinance.NewUpdateTransactionUseCase(transactionService)
-	deleteTransactionUseCase := appFinance.NewDeleteTransactionUseCase(transactionService)
+	updateTransactionUseCase := appFinance.NewUpdateTransactionUseCase(transactionService, unitOfWork)
+	deleteTransactionUseCase := appFinance.NewDeleteTransactionUseCase(transactionService, unitOfWork)
 	createCategoryUseCase := appFinance.NewCreateCategoryUseCase(categoryService)
 	updateCategoryUseCase := appFinance.NewUpdateCategoryUseCase(categoryService)
 	deleteCategoryUseCase := appFinance.NewDeleteCategoryUseCase(categoryService)
-	getCategoriesUseCase := appFinance.NewGetCategoriesUseCase(categoryService)
-	getAnalyticsUseCase := appFinance.NewGetAnalyticsUseCase(transactionService)
+	getCategoriesUseCase := appFinance.NewGetCategoriesUseCase(categoryService, taxService, transactionService)
+	getAnalyticsUseCase := appFinance.NewGetAnalyticsUseCase(analyticsProjector, categoryService, currencyService)
 	createBudgetUseCase := appFinance.NewCreateBudgetUseCase(budgetService, currencyService, categoryService)
 	getBudgetsUseCase := appFinance.NewGetBudgetsUseCase(budgetService, categoryService, transactionService)
-	updateBudgetUseCase := appFinance.NewUpdateBudgetUseCase(budgetService, categoryService)
-	deleteBudgetUseCase := appFinance.NewDeleteBudgetUseCase(budgetService)
+	updateBudgetUseCase := appFinance.NewUpdateBudgetUseCase(budgetService, categoryService, unitOfWork)
+	deleteBudgetUseCase := appFinance.NewDeleteBudgetUseCase(budgetService, unitOfWork)
+	getActionsUseCase := appFinance.NewGetActionsUseCase(actionService)
+	undoActionUseCase := appFinance.NewUndoActionUseCase(actionService, unitOfWork)
+	manageAccountsUseCase := appFinance.NewManageAccountsUseCase(accountService, currencyService)
+	reconcileAccountUseCase := appFinance.NewReconcileAccountUseCase(accountService, unitOfWork)
+	updateTransactionStatusUseCase := appFinance.NewUpdateTransactionStatusUseCase(transactionService)
+	balanceAssertionsUseCase := appFinance.NewBalanceAssertionsUseCase(accountService)
+	getAccountTransactionsUseCase := appFinance.NewGetAccountTransactionsUseCase(accountService, categoryService)
+	cardStatementsUseCase := appFinance.NewCardStatementsUseCase(accountService)
+	balanceAdjustmentsUseCase := appFinance.NewBalanceAdjustmentsUseCase(accountService)
+	manageExportsUseCase := appFinance.NewManageExportsUseCase(exportScheduleRepo, exportRunRepo)
+	taxDeductionsUseCase := appFinance.NewTaxDeductionsUseCase(taxService, categoryService)
+	manageRecurringUseCase := appFinance.NewManageRecurringTransactionsUseCase(transactionService, recurringRepo)
+	budgetSuggestionsUseCase := appFinance.NewBudgetSuggestionsUseCase(transactionService, budgetService, categoryService, currencyService, unitOfWork)
 	createCurrencyUseCase := appFinance.NewCreateCurrencyUseCase(currencyService)
 	getCurrenciesUseCase := appFinance.NewGetCurrenciesUseCase(currencyService)
 	updateCurrencyUseCase := appFinance.NewUpdateCurrencyUseCase(currencyService)
-	deleteCurrencyUseCase := appFinance.NewDeleteCurrencyUseCase(currencyService)
+	deleteCurrencyUseCase := appFinance.NewDeleteCurrencyUseCase(currencyService, unitOfWork)
 	setDefaultCurrencyUseCase := appFinance.NewSetDefaultCurrencyUseCase(currencyService)
 	getDefaultCurrencyUseCase := appFinance.NewGetDefaultCurrencyUseCase(currencyService)
 
 	// Interface layer - handlers and middleware
-	identityHandlers := handlers.NewIdentityHandlers(registerUserUseCase, loginUserUseCase, getUsersUseCase)
+	identityHandlers := handlers.NewIdentityHandlers(
+		registerUserUseCase,
+		loginUserUseCase,
+		getUsersUseCase,
+		forgotPasswordUseCase,
+		resetPasswordUseCase,
+	)
 	financeHandlers := handlers.NewFinanceHandlers(
 		createTransactionUseCase,
 		getTransactionsUseCase,
@@ -96,127 +285,614 @@ func NewApp(db *gorm.DB) *App {
 		getDefaultCurrencyUseCase,
 	)
 	dashboardHandlers := handlers.NewDashboardHandlers(getDashboardStatsUseCase)
+	adminHandlers := handlers.NewAdminHandlers(listUsersUseCase, getUserUsageUseCase, deactivateUserUseCase)
+	growthMetricsHandler := handlers.NewGrowthMetricsHandler(appIdentity.NewGetGrowthMetricsUseCase(database.NewGormGrowthMetricsRepository(db)))
+	notificationHandlers := handlers.NewNotificationHandlers(getNotificationsUseCase, markNotificationReadUseCase, broadcastAnnouncementUseCase, manageChannelsUseCase)
+	healthHandlers := handlers.NewHealthHandlers(database.NewHealthCheck(db))
 
 	// Version management
-	versionManager := versioning.NewVersionManager()
-	versionMiddleware := middleware.NewVersionMiddleware()
+	financeHandlersV110 := handlers.NewFinanceHandlersV110(financeHandlers)
+	financeHandlersV120 := handlers.NewFinanceHandlersV120(financeHandlersV110)
+	versionManager := newVersionManager(cfg.Versions)
+	versionMiddleware := middleware.NewVersionMiddleware(versionManager)
+	versionUsageTracker := metrics.NewVersionUsageTracker(database.NewGormVersionUsageRepository(db))
+	versionUsageHandler := handlers.NewVersionUsageHandler(versionUsageTracker, versionManager)
 	deprecationHandler := handlers.NewDeprecationHandler(versionManager)
-	authMiddleware := middleware.NewAuthMiddleware(tokenService)
+
+	// Backups
+	backupService := backup.NewService(db, newBackupStore(cfg.Backup))
+	backupHandler := handlers.NewBackupHandler(backupService)
+	emailQueueHandler := handlers.NewEmailQueueHandler(emailQueue)
+
+	// Feature flags
+	featureFlags := featureflags.NewFlagService(database.NewGormFeatureFlagRepository(db))
+	featureFlagHandler := handlers.NewFeatureFlagHandler(featureFlags)
+
+	authMiddleware := middleware.NewAuthMiddleware(tokenService, deactivateUserUseCase)
+	loggingMiddleware := middleware.NewLoggingMiddleware(slog.Default())
+	rateLimitMiddleware := middleware.NewRateLimitMiddleware(newRateLimitStore(cfg.RateLimit), slog.Default())
+	bodyLimitMiddleware := middleware.NewBodyLimitMiddleware(cfg.Server.MaxBodyBytes, cfg.Server.MaxUploadBytes)
+	maintenanceMiddleware := middleware.NewMaintenanceMiddleware(tokenService, featureFlags, cfg.Maintenance.Enabled, cfg.Maintenance.RetryAfter, cfg.Maintenance.Message)
+	var captchaVerifier middleware.CaptchaVerifier
+	if verifier := captcha.NewVerifier(cfg.Captcha); verifier != nil {
+		captchaVerifier = verifier
+	}
+	captchaMiddleware := middleware.NewCaptchaMiddleware(captchaVerifier, slog.Default())
+	var priceProvider appFinance.PriceProvider
+	if provider := prices.NewProvider(cfg.Prices); provider != nil {
+		priceProvider = provider
+	}
+	localeMiddleware := middleware.NewLocaleMiddleware()
 
 	return &App{
-		DB:                 db,
-		IdentityHandlers:   identityHandlers,
-		FinanceHandlers:    financeHandlers,
-		DashboardHandlers:  dashboardHandlers,
-		DeprecationHandler: deprecationHandler,
-		AuthMiddleware:     authMiddleware,
-		VersionMiddleware:  versionMiddleware,
-		VersionManager:     versionManager,
+		Config:               cfg,
+		DB:                   db,
+		IdentityHandlers:     identityHandlers,
+		FinanceHandlers:      financeHandlers,
+		FinanceHandlersV110:  financeHandlersV110,
+		FinanceHandlersV120:  financeHandlersV120,
+		DashboardHandlers:    dashboardHandlers,
+		AdminHandlers:        adminHandlers,
+		GrowthMetrics:        growthMetricsHandler,
+		NotificationHandlers: notificationHandlers,
+		DeprecationHandler:   deprecationHandler,
+		HealthHandlers:       healthHandlers,
+		AuthMiddleware:       authMiddleware,
+		LoggingMiddleware:    loggingMiddleware,
+		RateLimitMiddleware:  rateLimitMiddleware,
+		BodyLimitMiddleware:  bodyLimitMiddleware,
+		TimeoutMiddleware:    middleware.NewTimeoutMiddleware(cfg.Server.RequestTimeout),
+		Maintenance:          maintenanceMiddleware,
+		CaptchaMiddleware:    captchaMiddleware,
+		LocaleMiddleware:     localeMiddleware,
+		VersionMiddleware:    versionMiddleware,
+		VersionManager:       versionManager,
+		VersionUsageTracker:  versionUsageTracker,
+		VersionUsageHandler:  versionUsageHandler,
+		SlowQueryHandler:     handlers.NewSlowQueryHandler(database.SlowQueries(db), cfg.Database.Log.SlowThreshold),
+		ArchiveTransactions:  appFinance.NewArchiveTransactionsUseCase(transactionRepo, cfg.Archive.AfterYears),
+		ScheduledExports:     appFinance.NewRunScheduledExportsUseCase(exportScheduleRepo, exportRunRepo, transactionRepo, categoryRepo, export.NewRegistry()),
+		PostScheduled:        appFinance.NewPostScheduledTransactionsUseCase(transactionService, unitOfWork),
+		PostRecurring:        appFinance.NewPostRecurringTransactionsUseCase(transactionService, unitOfWork),
+		PurgeTrash:           appFinance.NewPurgeTrashUseCase(actionRepo, preferencesRepo, cfg.Trash.RetentionDays),
+		AnalyticsProjector:   analyticsProjector,
+		RecurringReminders:   appNotification.NewSendRecurringRemindersUseCase(recurringRepo, currencyRepo, notificationRepo, userService, dispatcher, emailQueue, cfg.Reminders.DaysAhead),
+		CardPaymentReminders: appNotification.NewSendCardPaymentRemindersUseCase(accountService, currencyRepo, dispatcher, cfg.Reminders.DaysAhead),
+		AnomalyDetection:     appNotification.NewDetectAnomaliesUseCase(userService, preferencesRepo, transactionService, categoryService, currencyRepo, anomalyRepo, dispatcher),
+		EventBus:             eventBus,
+		BackupService:        backupService,
+		WarehouseExport:      newWarehouseExporter(db, cfg.Warehouse),
+		BackupHandler:        backupHandler,
+		EmailQueue:           emailQueue,
+		EmailQueueHandler:    emailQueueHandler,
+		FeatureFlags:         featureFlags,
+		FeatureFlagHandler:   featureFlagHandler,
+		SearchHandler:        handlers.NewSearchHandler(searchUseCase),
+		ActionHandler:        handlers.NewActionHandler(getActionsUseCase, undoActionUseCase),
+		AccountHandler:       handlers.NewAccountHandler(manageAccountsUseCase, reconcileAccountUseCase, updateTransactionStatusUseCase, balanceAssertionsUseCase, getAccountTransactionsUseCase, cardStatementsUseCase, balanceAdjustmentsUseCase),
+		ExportHandler:        handlers.NewExportHandler(manageExportsUseCase),
+		TaxHandler:           handlers.NewTaxHandler(taxDeductionsUseCase),
+		ReceiptHandler:       handlers.NewReceiptHandler(appFinance.NewReceiptLineItemsUseCase(receiptService)),
+		RecurringHandler:     handlers.NewRecurringHandler(manageRecurringUseCase),
+		ScheduledHandler:     handlers.NewScheduledTransactionHandler(appFinance.NewManageScheduledTransactionsUseCase(transactionService, currencyService)),
+		BudgetSuggestions:    handlers.NewBudgetSuggestionHandler(budgetSuggestionsUseCase),
+		BudgetCalendar:       handlers.NewBudgetCalendarHandler(appFinance.NewBudgetCalendarUseCase(budgetService, transactionService, currencyService)),
+		PreferencesHandler:   handlers.NewPreferencesHandler(appIdentity.NewManagePreferencesUseCase(preferencesRepo)),
+		UsageHandler:         handlers.NewUsageHandler(appIdentity.NewGetAccountUsageUseCase(transactionRepo, categoryRepo, budgetRepo, versionUsageTracker)),
+		SyncHandler:          handlers.NewSyncHandler(appFinance.NewSyncUseCase(transactionRepo, budgetRepo, categoryRepo, database.NewGormTombstoneRepository(db))),
+		LiveHandler:          handlers.NewLiveHandler(liveHub),
+		ClosedMonthHandler:   handlers.NewClosedMonthHandler(appFinance.NewManageClosedMonthsUseCase(closedMonthRepo)),
+		ExchangeRateHandler: handlers.NewExchangeRateHandler(
+			appFinance.NewManageExchangeRatesUseCase(exchangeRateRepo),
+			appFinance.NewFXGainLossUseCase(transactionService, currencyService, exchangeRateRepo),
+		),
+		InvestmentHandler: handlers.NewInvestmentHandler(
+			appFinance.NewManageHoldingsUseCase(investmentService, currencyService),
+			appFinance.NewNetWorthUseCase(accountService, investmentService, currencyService, exchangeRateRepo),
+		),
+		HoldingPrices: appFinance.NewRefreshHoldingPricesUseCase(investmentService, priceProvider),
+		EmergencyFundHandler: handlers.NewEmergencyFundHandler(
+			appFinance.NewEmergencyFundUseCase(accountService, transactionService, currencyService, exchangeRateRepo),
+		),
+		SafeToSpendHandler: handlers.NewSafeToSpendHandler(
+			appFinance.NewSafeToSpendUseCase(transactionService, budgetService, categoryService, currencyService, recurringRepo),
+		),
+		AnalyticsHandler: handlers.NewAnalyticsHandler(
+			appFinance.NewSpendingPatternsUseCase(transactionService, currencyService),
+			appFinance.NewSpendingBenchmarksUseCase(spendingBenchmarkRepo, categoryService, currencyService, preferencesRepo),
+			appFinance.NewSpendingByPeriodUseCase(analyticsProjector, currencyService),
+		),
+		WebhookHandler: handlers.NewWebhookHandler(
+			manageWebhooksUseCase,
+			appNotification.NewTestWebhookUseCase(webhookRepo, webhookSender, events.Samples()),
+		),
+		DefaultBudgets: handlers.NewDefaultBudgetHandler(manageDefaultBudgetsUseCase),
+		OnboardingHandler: handlers.NewOnboardingHandler(
+			appFinance.NewOnboardingUseCase(currencyService, categoryService, budgetService, preferencesRepo, unitOfWork),
+		),
+		PaletteHandler: handlers.NewPaletteHandler(appFinance.NewGetPaletteUseCase()),
+	}, nil
+}
+
+// newVersionManager creates the version manager, persisting runtime changes when a state file is configured
+func newVersionManager(cfg config.VersionsConfig) *versioning.VersionManager {
+	matrix := newVersionMatrix(cfg)
+	if cfg.StateFile == "" {
+		return versioning.NewVersionManagerFromMatrix(matrix)
+	}
+
+	versionManager, err := versioning.NewPersistentVersionManager(matrix, versioning.NewFileVersionStore(cfg.StateFile))
+	if err != nil {
+		slog.Error("failed to load version state, using configured versions", "error", err.Error())
+		return versioning.NewVersionManagerFromMatrix(matrix)
+	}
+	return versionManager
+}
+
+// newVersionMatrix converts the configured version matrix for the version manager
+func newVersionMatrix(cfg config.VersionsConfig) versioning.VersionMatrix {
+	matrix := versioning.VersionMatrix{
+		Current:   cfg.Current,
+		Supported: cfg.Supported,
+	}
+
+	for _, deprecated := range cfg.Deprecated {
+		// Sunset dates are checked by config validation
+		sunsetDate, _ := time.Parse("2006-01-02", deprecated.SunsetDate)
+
+		message := deprecated.Message
+		if message == "" {
+			message = fmt.Sprintf("API version %s is deprecated and will be removed on %s", deprecated.Version, deprecated.SunsetDate)
+		}
+
+		matrix.Deprecated = append(matrix.Deprecated, versioning.DeprecationInfo{
+			Version:        deprecated.Version,
+			SunsetDate:     sunsetDate,
+			WarningMessage: message,
+		})
+	}
+
+	return matrix
+}
+
+// newRateLimitStore creates the rate limit store for the configured backend
+func newRateLimitStore(cfg config.RateLimitConfig) ratelimit.Store {
+	if cfg.Backend != "redis" {
+		return ratelimit.NewMemoryStore()
+	}
+
+	opts, err := redis.ParseURL(cfg.RedisURL)
+	if err != nil {
+		slog.Error("invalid REDIS_URL, falling back to in-memory rate limiting", "error", err.Error())
+		return ratelimit.NewMemoryStore()
+	}
+	return ratelimit.NewRedisStore(redis.NewClient(opts))
+}
+
+// newTemplateRenderer loads the email templates, falling back to the built-in ones when
+// the deployment's overrides cannot be loaded
+func newTemplateRenderer(cfg config.MailConfig) *mail.TemplateRenderer {
+	renderer, err := mail.NewTemplateRenderer(cfg.TemplateDir)
+	if err == nil {
+		return renderer
+	}
+
+	slog.Error("failed to load email templates, using the built-in templates", "dir", cfg.TemplateDir, "error", err.Error())
+	renderer, err = mail.NewTemplateRenderer("")
+	if err != nil {
+		// The built-in templates are checked by the tests, so this is a programming error
+		panic(err)
+	}
+	return renderer
+}
+
+// newBackupStore creates the backup store for the configured storage backend
+func newBackupStore(cfg config.BackupConfig) backup.Store {
+	store, err := backup.NewStore(context.Background(), cfg)
+	if err != nil {
+		slog.Error("failed to open backup storage, falling back to local disk", "error", err.Error())
+		return backup.NewLocalStore(cfg.Dir)
+	}
+	return store
+}
+
+// newWarehouseExporter creates the warehouse exporter for the configured storage
+// backend, or returns nil when none is configured or it cannot be opened
+func newWarehouseExporter(db *gorm.DB, cfg config.WarehouseConfig) *warehouse.Exporter {
+	if cfg.Storage == "" {
+		return nil
 	}
+	bucket, err := warehouse.NewBucket(context.Background(), cfg)
+	if err != nil {
+		slog.Error("failed to open warehouse storage, exports are disabled", "error", err.Error())
+		return nil
+	}
+	return warehouse.NewExporter(db, bucket)
+}
+
+// newMailer creates the SMTP mailer, or a mailer that only logs when no SMTP server is configured
+func newMailer(cfg config.MailConfig) mail.Mailer {
+	if cfg.Host == "" {
+		return mail.NewLogMailer(slog.Default())
+	}
+
+	mailer, err := mail.NewSMTPMailer(cfg)
+	if err != nil {
+		slog.Error("failed to configure SMTP, emails will only be logged", "error", err.Error())
+		return mail.NewLogMailer(slog.Default())
+	}
+	return mailer
+}
+
+// rateLimit returns the limiter for a route group, or a no-op when rate limiting is disabled
+func (app *App) rateLimit(name string, rule config.RateLimitRule) gin.HandlerFunc {
+	if !app.Config.RateLimit.Enabled {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return app.RateLimitMiddleware.Limit(name, ratelimit.Limit{Requests: rule.Requests, Window: rule.Window})
+}
+
+// dailyQuota returns the per-user daily API call budget, or a no-op when rate
+// limiting or the budget is disabled
+func (app *App) dailyQuota() gin.HandlerFunc {
+	if !app.Config.RateLimit.Enabled || app.Config.RateLimit.DailyAPICalls <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return app.RateLimitMiddleware.DailyQuota(app.Config.RateLimit.DailyAPICalls)
+}
+
+// Handler returns the HTTP handler for the server, with version negotiation
+// applied before routing
+func (app *App) Handler() http.Handler {
+	return app.VersionMiddleware.Negotiate(app.SetupRoutes())
 }
 
 // SetupRoutes sets up all the HTTP routes
 func (app *App) SetupRoutes() *gin.Engine {
-	r := gin.Default()
+	r := gin.New()
+	r.Use(gin.Recovery())
+
+	// Only take the client IP from X-Forwarded-For when set by a trusted proxy, so
+	// clients cannot spoof it to get a fresh rate limit bucket
+	if err := r.SetTrustedProxies(app.Config.Server.TrustedProxies); err != nil {
+		slog.Error("invalid trusted proxies, trusting none", "error", err.Error())
+		_ = r.SetTrustedProxies(nil)
+	}
+
+	// Request ID and structured access logging
+	r.Use(app.LoggingMiddleware.RequestID())
+	r.Use(app.LoggingMiddleware.LogRequests())
+
+	// Request deadline, cancelling the queries of requests that take too long
+	r.Use(app.TimeoutMiddleware.Deadline())
 
 	// CORS configuration
-	config := cors.DefaultConfig()
-	config.AllowOrigins = []string{
-		"http://localhost:3000",
-		"http://localhost:3001",
-		"http://localhost:3002",
-		"http://localhost:3003",
-		"http://localhost:3004",     // Back office port
-		"https://berbudget.com",     // Production frontend
-		"https://www.berbudget.com", // Production frontend with www
-	}
-	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", "X-API-Version"}
-	config.AllowCredentials = false
-	r.Use(cors.New(config))
+	corsConfig := cors.DefaultConfig()
+	corsConfig.AllowOrigins = app.Config.CORS.AllowedOrigins
+	corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", "X-API-Version", "X-Request-ID", "If-None-Match", middleware.CaptchaTokenHeader}
+	corsConfig.ExposeHeaders = []string{"X-Request-ID", "X-API-Version", "X-API-Version-Source", "ETag", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "Retry-After"}
+	corsConfig.AllowCredentials = false
+	r.Use(cors.New(corsConfig))
+
+	// Request body size and content type limits
+	r.Use(app.BodyLimitMiddleware.LimitBody())
+
+	// Preferred languages for localized names
+	r.Use(app.LocaleMiddleware.DetectLocale())
 
 	// Version middleware
 	r.Use(app.VersionMiddleware.ExtractVersion())
 	r.Use(app.VersionMiddleware.ValidateVersion())
 	r.Use(app.VersionMiddleware.AddDeprecationWarning())
+	r.Use(app.VersionMiddleware.RecordUsage(app.VersionUsageTracker))
 
-	// Rate limiting middleware - commented out as it doesn't exist yet
-	// r.Use(middleware.RateLimitMiddleware(15*time.Minute, 100)) // 100 requests per 15 minutes
+	// Global rate limit per client IP
+	r.Use(app.rateLimit("global", app.Config.RateLimit.Global))
 
 	// Versioned routes
 	versioned := r.Group("/api")
 	{
-		// v100 routes (current version)
+		// v100 routes
 		v100 := versioned.Group("/v100")
+		app.registerVersionRoutes(v100, app.FinanceHandlers)
+
+		// v110 routes - unified transaction endpoints and transaction analytics
+		v110 := versioned.Group("/v110")
+		v110Protected := app.registerVersionRoutes(v110, app.FinanceHandlersV110)
+		v110Protected.POST("/transactions", app.FinanceHandlersV110.CreateTransaction)
+		v110Protected.GET("/transactions/analytics", app.FinanceHandlersV110.GetTransactionAnalytics)
+
+		// v120 routes (current version) - v110 plus version metadata on list responses
+		v120 := versioned.Group("/v120")
+		v120Protected := app.registerVersionRoutes(v120, app.FinanceHandlersV120)
+		v120Protected.POST("/transactions", app.FinanceHandlersV120.CreateTransaction)
+		v120Protected.GET("/transactions/analytics", app.FinanceHandlersV120.GetTransactionAnalytics)
+
+		// Back office, shared by every API version
+		app.registerAdminRoutes(versioned.Group("/admin"))
+
+		// Version management
+		version := versioned.Group("/version")
 		{
-			// Auth routes
-			auth := v100.Group("/auth")
-			{
-				auth.POST("/register", app.IdentityHandlers.Register)
-				auth.POST("/login", app.IdentityHandlers.Login)
-				auth.POST("/logout", app.IdentityHandlers.Logout)
-			}
-
-			// Protected routes
-			protected := v100.Group("")
-			protected.Use(app.AuthMiddleware.RequireAuth())
-			{
-				// Users (basic)
-				protected.GET("/users", app.IdentityHandlers.GetUsers)
-
-				// User Management (admin only)
-				adminOnly := protected.Group("")
-				adminOnly.Use(app.AuthMiddleware.RequireRole("admin"))
-				{
-					// Dashboard stats (admin only)
-					adminOnly.GET("/dashboard/stats", app.DashboardHandlers.GetDashboardStats)
-				}
-
-				// Categories
-				protected.GET("/categories", app.FinanceHandlers.GetCategories)
-				protected.POST("/categories", app.FinanceHandlers.CreateCategory)
-				protected.PUT("/categories/:id", app.FinanceHandlers.UpdateCategory)
-				protected.DELETE("/categories/:id", app.FinanceHandlers.DeleteCategory)
-
-				// Expenses
-				protected.GET("/expenses", app.FinanceHandlers.GetExpenses)
-				protected.POST("/expenses", app.FinanceHandlers.CreateExpense)
-				protected.PUT("/expenses/:id", app.FinanceHandlers.UpdateExpense)
-				protected.DELETE("/expenses/:id", app.FinanceHandlers.DeleteExpense)
-
-				// Incomes
-				protected.GET("/incomes", app.FinanceHandlers.GetIncomes)
-				protected.POST("/incomes", app.FinanceHandlers.CreateIncome)
-				protected.PUT("/incomes/:id", app.FinanceHandlers.UpdateIncome)
-				protected.DELETE("/incomes/:id", app.FinanceHandlers.DeleteIncome)
-
-				// All Transactions (with filters)
-				protected.GET("/transactions", app.FinanceHandlers.GetAllTransactions)
-
-				// Budgets
-				protected.GET("/budgets", app.FinanceHandlers.GetBudgets)
-				protected.POST("/budgets", app.FinanceHandlers.CreateBudget)
-				protected.PUT("/budgets/:id", app.FinanceHandlers.UpdateBudget)
-				protected.DELETE("/budgets/:id", app.FinanceHandlers.DeleteBudget)
-
-				// Currencies
-				protected.GET("/currencies", app.FinanceHandlers.GetCurrencies)
-				protected.POST("/currencies", app.FinanceHandlers.CreateCurrency)
-				protected.GET("/currencies/default", app.FinanceHandlers.GetDefaultCurrency)
-				protected.PUT("/currencies/:id/set-default", app.FinanceHandlers.SetDefaultCurrency)
-				protected.PUT("/currencies/:id", app.FinanceHandlers.UpdateCurrency)
-				protected.DELETE("/currencies/:id", app.FinanceHandlers.DeleteCurrency)
-
-				// Analytics
-				protected.GET("/analytics", app.FinanceHandlers.GetAnalytics)
-			}
+			version.GET("/info/:version", app.DeprecationHandler.GetVersionStatus)
+			version.GET("/features/:version", app.DeprecationHandler.GetVersionFeatures)
+			version.GET("/migration/:version", app.DeprecationHandler.GetMigrationPath)
+			version.GET("/upgrade/:version", app.DeprecationHandler.GetUpgradeRecommendations)
+			version.GET("/deprecation/:version", app.DeprecationHandler.GetDeprecationInfo)
+			version.GET("/matrix", app.DeprecationHandler.GetVersionMatrix)
+			version.GET("/compare", app.DeprecationHandler.CompareVersions)
+			version.GET("/validate", app.DeprecationHandler.ValidateVersionTransition)
+			version.GET("/timeline", app.DeprecationHandler.GetDeprecationTimeline)
 		}
 	}
 
-	// Health check
-	r.GET("/health", func(c *gin.Context) {
-		handlers.SuccessResponse(c, http.StatusOK, gin.H{"status": "ok"})
-	})
+	// Health checks
+	r.GET("/health", app.HealthHandlers.Live)
+	r.GET("/health/live", app.HealthHandlers.Live)
+	r.GET("/health/ready", app.HealthHandlers.Ready)
 
 	return r
 }
+
+// financeRouteHandlers is the set of finance handlers every API version exposes.
+// Later versions embed earlier ones and override individual methods.
+type financeRouteHandlers interface {
+	GetCategories(c *gin.Context)
+	CreateCategory(c *gin.Context)
+	UpdateCategory(c *gin.Context)
+	DeleteCategory(c *gin.Context)
+	GetExpenses(c *gin.Context)
+	CreateExpense(c *gin.Context)
+	UpdateExpense(c *gin.Context)
+	DeleteExpense(c *gin.Context)
+	GetIncomes(c *gin.Context)
+	CreateIncome(c *gin.Context)
+	UpdateIncome(c *gin.Context)
+	DeleteIncome(c *gin.Context)
+	GetAllTransactions(c *gin.Context)
+	GetBudgets(c *gin.Context)
+	CreateBudget(c *gin.Context)
+	UpdateBudget(c *gin.Context)
+	DeleteBudget(c *gin.Context)
+	GetCurrencies(c *gin.Context)
+	CreateCurrency(c *gin.Context)
+	GetDefaultCurrency(c *gin.Context)
+	SetDefaultCurrency(c *gin.Context)
+	UpdateCurrency(c *gin.Context)
+	DeleteCurrency(c *gin.Context)
+	GetAnalytics(c *gin.Context)
+}
+
+// registerVersionRoutes registers the routes shared by every API version and
+// returns the protected group so versions can add their own endpoints
+func (app *App) registerVersionRoutes(v *gin.RouterGroup, finance financeRouteHandlers) *gin.RouterGroup {
+	// Auth routes
+	auth := v.Group("/auth")
+	auth.Use(app.rateLimit("auth", app.Config.RateLimit.Auth))
+	{
+		// Login stays open during maintenance so admins can sign in
+		auth.POST("/register", app.Maintenance.AdminsOnly(), app.CaptchaMiddleware.Require(), app.IdentityHandlers.Register)
+		auth.POST("/login", app.IdentityHandlers.Login)
+		auth.POST("/logout", app.IdentityHandlers.Logout)
+		auth.POST("/forgot-password", app.Maintenance.AdminsOnly(), app.CaptchaMiddleware.Require(), app.IdentityHandlers.ForgotPassword)
+		auth.POST("/reset-password", app.Maintenance.AdminsOnly(), app.IdentityHandlers.ResetPassword)
+	}
+
+	// Protected routes
+	protected := v.Group("")
+	protected.Use(app.Maintenance.AdminsOnly())
+	protected.Use(app.AuthMiddleware.RequireAuth())
+	protected.Use(app.rateLimit("api", app.Config.RateLimit.API))
+	protected.Use(app.dailyQuota())
+	{
+		// Users (basic)
+		protected.GET("/users", app.IdentityHandlers.GetUsers)
+
+		// Preferences
+		protected.GET("/preferences", app.PreferencesHandler.GetPreferences)
+		protected.PUT("/preferences", app.PreferencesHandler.UpdatePreferences)
+		protected.POST("/onboarding", app.OnboardingHandler.CompleteOnboarding)
+		protected.GET("/palette", app.PaletteHandler.GetPalette)
+		protected.GET("/account/usage", app.UsageHandler.GetAccountUsage)
+
+		// Offline sync
+		protected.GET("/sync", app.SyncHandler.Sync)
+
+		// Live pushes to dashboards over WebSocket
+		protected.GET("/ws", app.TimeoutMiddleware.NoDeadline(), app.LiveHandler.Connect)
+
+		// Notifications
+		protected.GET("/notifications", app.NotificationHandlers.GetNotifications)
+		protected.PUT("/notifications/read", app.NotificationHandlers.MarkAllNotificationsRead)
+		protected.PUT("/notifications/:id/read", app.NotificationHandlers.MarkNotificationRead)
+		protected.GET("/notification-channels", app.NotificationHandlers.GetChannels)
+		protected.POST("/notification-channels", app.NotificationHandlers.CreateChannel)
+		protected.DELETE("/notification-channels/:id", app.NotificationHandlers.DeleteChannel)
+
+		// Webhook event catalog for integration platforms
+		protected.GET("/webhooks/events", app.WebhookHandler.ListEventTypes)
+		protected.GET("/webhooks", app.WebhookHandler.GetWebhooks)
+		protected.POST("/webhooks", app.WebhookHandler.CreateWebhook)
+		protected.DELETE("/webhooks/:id", app.WebhookHandler.DeleteWebhook)
+		protected.POST("/webhooks/:id/test", app.WebhookHandler.TestWebhook)
+		protected.GET("/search", app.SearchHandler.Search)
+		protected.GET("/actions", app.ActionHandler.GetActions)
+		protected.POST("/actions/:id/undo", app.ActionHandler.UndoAction)
+
+		// Features switched on for the current user
+		protected.GET("/features", app.FeatureFlagHandler.GetFeatures)
+
+		// Dashboard stats (admin only); predates the /api/admin group
+		protected.GET("/dashboard/stats", app.AuthMiddleware.RequireRole("admin"), app.DashboardHandlers.GetDashboardStats)
+
+		// Categories
+		protected.GET("/categories", finance.GetCategories)
+		protected.POST("/categories", finance.CreateCategory)
+		protected.PUT("/categories/:id", finance.UpdateCategory)
+		protected.DELETE("/categories/:id", finance.DeleteCategory)
+		protected.PUT("/categories/:id/tax-deductible", app.TaxHandler.SetCategoryTaxDeductible)
+
+		// Expenses
+		protected.GET("/expenses", finance.GetExpenses)
+		protected.POST("/expenses", finance.CreateExpense)
+		protected.PUT("/expenses/:id", finance.UpdateExpense)
+		protected.DELETE("/expenses/:id", finance.DeleteExpense)
+		protected.PUT("/expenses/:id/status", app.AccountHandler.UpdateExpenseStatus)
+		protected.PUT("/expenses/:id/tax", app.TaxHandler.UpdateExpenseTaxDetails)
+		protected.GET("/expenses/:id/line-items", app.ReceiptHandler.GetLineItems)
+		protected.PUT("/expenses/:id/line-items", app.ReceiptHandler.ReplaceLineItems)
+
+		// Incomes
+		protected.GET("/incomes", finance.GetIncomes)
+		protected.POST("/incomes", finance.CreateIncome)
+		protected.PUT("/incomes/:id", finance.UpdateIncome)
+		protected.DELETE("/incomes/:id", finance.DeleteIncome)
+		protected.PUT("/incomes/:id/status", app.AccountHandler.UpdateIncomeStatus)
+
+		// All Transactions (with filters)
+		protected.GET("/transactions", finance.GetAllTransactions)
+
+		// Closed months, whose transactions cannot change until reopened
+		protected.GET("/closed-months", app.ClosedMonthHandler.GetClosedMonths)
+		protected.POST("/closed-months", app.ClosedMonthHandler.CloseMonth)
+		protected.DELETE("/closed-months/:month", app.ClosedMonthHandler.ReopenMonth)
+
+		// Accounts and bank statement reconciliation
+		protected.GET("/accounts", app.AccountHandler.GetAccounts)
+		protected.POST("/accounts", app.AccountHandler.CreateAccount)
+		protected.POST("/accounts/:id/archive", app.AccountHandler.ArchiveAccount)
+		protected.POST("/accounts/:id/unarchive", app.AccountHandler.UnarchiveAccount)
+		protected.PUT("/accounts/:id/emergency-fund", app.AccountHandler.SetEmergencyFund)
+		protected.GET("/accounts/:id/transactions", app.AccountHandler.GetAccountTransactions)
+		protected.PUT("/accounts/:id/billing-cycle", app.AccountHandler.SetBillingCycle)
+		protected.DELETE("/accounts/:id/billing-cycle", app.AccountHandler.RemoveBillingCycle)
+		protected.GET("/accounts/:id/statements", app.AccountHandler.GetStatements)
+		protected.GET("/accounts/:id/reconciliation", app.AccountHandler.GetReconciliation)
+		protected.POST("/accounts/:id/reconcile", app.AccountHandler.Reconcile)
+		protected.GET("/accounts/:id/balance-assertions", app.AccountHandler.GetBalanceAssertions)
+		protected.POST("/accounts/:id/balance-assertions", app.AccountHandler.CreateBalanceAssertion)
+		protected.DELETE("/accounts/:id/balance-assertions/:assertion_id", app.AccountHandler.DeleteBalanceAssertion)
+		protected.GET("/accounts/:id/adjustments", app.AccountHandler.GetBalanceAdjustments)
+		protected.POST("/accounts/:id/adjustments", app.AccountHandler.CreateBalanceAdjustment)
+		protected.DELETE("/accounts/:id/adjustments/:adjustment_id", app.AccountHandler.DeleteBalanceAdjustment)
+
+		// Scheduled exports to cloud storage
+		protected.GET("/exports", app.ExportHandler.GetExports)
+		protected.GET("/exports/schedules", app.ExportHandler.GetSchedules)
+		protected.POST("/exports/schedules", app.ExportHandler.CreateSchedule)
+		protected.DELETE("/exports/schedules/:id", app.ExportHandler.DeleteSchedule)
+
+		// Recurring transactions
+		protected.POST("/transactions/:id/make-recurring", app.RecurringHandler.MakeRecurring)
+		protected.GET("/recurring-transactions/:id/occurrences", app.RecurringHandler.GetOccurrences)
+		protected.POST("/recurring-transactions/:id/skip", app.RecurringHandler.SkipNextOccurrence)
+		protected.PUT("/recurring-transactions/:id/next-occurrence", app.RecurringHandler.OverrideNextOccurrence)
+		protected.DELETE("/recurring-transactions/:id/next-occurrence", app.RecurringHandler.ClearNextOccurrenceOverride)
+		protected.PUT("/recurring-transactions/:id/end-conditions", app.RecurringHandler.UpdateEndConditions)
+
+		// Scheduled transactions
+		protected.POST("/scheduled-transactions", app.ScheduledHandler.Schedule)
+		protected.GET("/scheduled-transactions", app.ScheduledHandler.List)
+		protected.POST("/scheduled-transactions/:id/cancel", app.ScheduledHandler.Cancel)
+
+		// Budgets
+		protected.GET("/budgets", finance.GetBudgets)
+		protected.POST("/budgets", finance.CreateBudget)
+		protected.PUT("/budgets/:id", finance.UpdateBudget)
+		protected.DELETE("/budgets/:id", finance.DeleteBudget)
+		protected.GET("/budgets/suggestions", app.BudgetSuggestions.GetSuggestions)
+		protected.POST("/budgets/suggestions/accept", app.BudgetSuggestions.AcceptSuggestions)
+		protected.GET("/budgets/calendar", app.BudgetCalendar.GetCalendar)
+
+		// Currencies
+		protected.GET("/currencies", finance.GetCurrencies)
+		protected.POST("/currencies", finance.CreateCurrency)
+		protected.GET("/currencies/default", finance.GetDefaultCurrency)
+		protected.PUT("/currencies/:id/set-default", finance.SetDefaultCurrency)
+		protected.PUT("/currencies/:id", finance.UpdateCurrency)
+		protected.DELETE("/currencies/:id", finance.DeleteCurrency)
+
+		// Analytics
+		protected.GET("/analytics", finance.GetAnalytics)
+		protected.GET("/analytics/spending-patterns", app.AnalyticsHandler.GetSpendingPatterns)
+		protected.GET("/analytics/benchmarks", app.AnalyticsHandler.GetSpendingBenchmarks)
+		protected.GET("/analytics/spending-by-period", app.AnalyticsHandler.GetSpendingByPeriod)
+
+		// Annual report of tax-deductible expenses
+		protected.GET("/reports/tax/:year", app.TaxHandler.GetTaxReport)
+
+		// Unrealized exchange gain/loss on foreign currency holdings
+		protected.GET("/exchange-rates", app.ExchangeRateHandler.GetExchangeRates)
+		protected.GET("/reports/fx-gain-loss", app.ExchangeRateHandler.GetFXGainLossReport)
+
+		// Investment holdings and the net worth they add up to
+		protected.GET("/investments/holdings", app.InvestmentHandler.GetHoldings)
+		protected.POST("/investments/holdings", app.InvestmentHandler.CreateHolding)
+		protected.PUT("/investments/holdings/:id", app.InvestmentHandler.UpdateHolding)
+		protected.DELETE("/investments/holdings/:id", app.InvestmentHandler.DeleteHolding)
+		protected.GET("/investments/holdings/:id/prices", app.InvestmentHandler.GetHoldingPrices)
+		protected.POST("/investments/holdings/:id/prices", app.InvestmentHandler.RecordHoldingPrice)
+		protected.GET("/reports/net-worth", app.InvestmentHandler.GetNetWorthReport)
+
+		// Months of spending the emergency fund accounts cover
+		protected.GET("/dashboard/emergency-fund", app.EmergencyFundHandler.GetEmergencyFund)
+
+		// This month's income left once bills and budgets are provided for
+		protected.GET("/dashboard/safe-to-spend", app.SafeToSpendHandler.GetSafeToSpend)
+	}
+
+	return protected
+}
+
+// registerAdminRoutes registers the back office routes, which are mounted once
+// under /api/admin rather than under every API version
+func (app *App) registerAdminRoutes(admin *gin.RouterGroup) {
+	admin.Use(app.Maintenance.AdminsOnly())
+	admin.Use(app.AuthMiddleware.RequireAuth())
+	admin.Use(app.AuthMiddleware.RequireRole("admin"))
+	admin.Use(app.rateLimit("api", app.Config.RateLimit.API))
+
+	// Dashboard stats
+	admin.GET("/dashboard/stats", app.DashboardHandlers.GetDashboardStats)
+
+	// User management
+	admin.GET("/users", app.AdminHandlers.ListUsers)
+	admin.GET("/users/:id/usage", app.AdminHandlers.GetUserUsage)
+	admin.POST("/users/:id/deactivate", app.AdminHandlers.DeactivateUser)
+	admin.POST("/users/:id/reactivate", app.AdminHandlers.ReactivateUser)
+
+	// Growth metrics for the back office
+	admin.GET("/metrics/growth", app.GrowthMetrics.GetGrowth)
+	admin.GET("/metrics/retention", app.GrowthMetrics.GetRetention)
+
+	// Queries slower than the slow query threshold
+	admin.GET("/metrics/slow-queries", app.SlowQueryHandler.GetSlowQueries)
+
+	// Announcements
+	admin.POST("/announcements", app.NotificationHandlers.BroadcastAnnouncement)
+
+	// API version adoption
+	admin.GET("/version-usage", app.VersionUsageHandler.GetVersionUsage)
+	admin.GET("/version-usage/clients", app.VersionUsageHandler.GetDeprecatedVersionClients)
+
+	// Database backups
+	admin.GET("/backups", app.BackupHandler.ListBackups)
+	admin.POST("/backups", app.TimeoutMiddleware.NoDeadline(), app.BackupHandler.CreateBackup)
+
+	// Emails that could not be delivered
+	admin.GET("/emails/dead-letters", app.EmailQueueHandler.ListDeadLetters)
+	admin.POST("/emails/:id/retry", app.EmailQueueHandler.RetryEmail)
+
+	// Feature flags
+	admin.GET("/feature-flags", app.FeatureFlagHandler.ListFeatureFlags)
+	admin.PUT("/feature-flags/:key", app.FeatureFlagHandler.SetFeatureFlag)
+	admin.DELETE("/feature-flags/:key", app.FeatureFlagHandler.DeleteFeatureFlag)
+
+	// Budgets new users start with
+	admin.GET("/default-budgets", app.DefaultBudgets.GetDefaultBudgets)
+	admin.PUT("/default-budgets", app.DefaultBudgets.ReplaceDefaultBudgets)
+
+	// Exchange rates for the FX gain/loss report
+	admin.POST("/exchange-rates", app.ExchangeRateHandler.SetExchangeRate)
+}
@@ -0,0 +1,127 @@
+package finance
+
+import (
+	"context"
+	"log/slog"
+	"panda-pocket/internal/domain/finance"
+	"time"
+)
+
+// AnalyticsProjector maintains the analytics read model: daily totals, category
+// totals and budget progress per user. Domain events about a user project their
+// read model again, and the analytics use cases read it instead of the
+// transactions. Writes that raise no events, such as imports and undos, are
+// picked up by Run.
+type AnalyticsProjector struct {
+	transactionRepo finance.TransactionRepository
+	budgetRepo      finance.BudgetRepository
+	analyticsRepo   finance.AnalyticsRepository
+}
+
+// NewAnalyticsProjector creates a new analytics projector
+func NewAnalyticsProjector(
+	transactionRepo finance.TransactionRepository,
+	budgetRepo finance.BudgetRepository,
+	analyticsRepo finance.AnalyticsRepository,
+) *AnalyticsProjector {
+	return &AnalyticsProjector{
+		transactionRepo: transactionRepo,
+		budgetRepo:      budgetRepo,
+		analyticsRepo:   analyticsRepo,
+	}
+}
+
+// Project builds the user's read model from their transactions and budgets
+func (p *AnalyticsProjector) Project(ctx context.Context, userID finance.UserID) error {
+	transactions, err := p.transactionRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	budgets, err := p.budgetRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return p.analyticsRepo.Replace(ctx, finance.ProjectAnalytics(userID, transactions, budgets, time.Now()))
+}
+
+// HandleEvent projects the read model of the user an event belongs to. Failures
+// are logged rather than returned, as Run catches the user up anyway.
+func (p *AnalyticsProjector) HandleEvent(ctx context.Context, event finance.Event) error {
+	userEvent, ok := event.(finance.UserEvent)
+	if !ok {
+		return nil
+	}
+	if err := p.Project(ctx, finance.NewUserID(userEvent.EventUserID())); err != nil {
+		slog.Error("failed to project analytics", "event", event.EventName(), "user_id", userEvent.EventUserID(), "error", err.Error())
+	}
+	return nil
+}
+
+// Execute projects the read model of every user who has one again, and returns
+// how many were projected
+func (p *AnalyticsProjector) Execute(ctx context.Context) (int, error) {
+	userIDs, err := p.analyticsRepo.FindProjectedUserIDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for i, userID := range userIDs {
+		if err := p.Project(ctx, userID); err != nil {
+			return i, err
+		}
+	}
+	return len(userIDs), nil
+}
+
+// Run executes the projector every interval until ctx is cancelled
+func (p *AnalyticsProjector) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			projected, err := p.Execute(ctx)
+			if err != nil {
+				slog.Error("analytics projection failed", "projected", projected, "error", err.Error())
+				continue
+			}
+			slog.Info("projected analytics", "users", projected)
+		}
+	}
+}
+
+// DailyTotals returns the user's daily totals dated within the range
+func (p *AnalyticsProjector) DailyTotals(ctx context.Context, userID finance.UserID, startDate, endDate time.Time) ([]finance.DailyTotal, error) {
+	if err := p.ensureProjected(ctx, userID); err != nil {
+		return nil, err
+	}
+	return p.analyticsRepo.FindDailyTotals(ctx, userID, startDate, endDate)
+}
+
+// CategoryTotals returns the user's category totals dated within the range
+func (p *AnalyticsProjector) CategoryTotals(ctx context.Context, userID finance.UserID, startDate, endDate time.Time) ([]finance.CategoryTotal, error) {
+	if err := p.ensureProjected(ctx, userID); err != nil {
+		return nil, err
+	}
+	return p.analyticsRepo.FindCategoryTotals(ctx, userID, startDate, endDate)
+}
+
+// BudgetProgress returns the progress of the user's budgets overlapping the range
+func (p *AnalyticsProjector) BudgetProgress(ctx context.Context, userID finance.UserID, startDate, endDate time.Time) ([]finance.BudgetProgress, error) {
+	if err := p.ensureProjected(ctx, userID); err != nil {
+		return nil, err
+	}
+	return p.analyticsRepo.FindBudgetProgress(ctx, userID, startDate, endDate)
+}
+
+// ensureProjected projects the read model of a user who has none yet, such as
+// one who has not recorded anything since it was introduced or restored
+func (p *AnalyticsProjector) ensureProjected(ctx context.Context, userID finance.UserID) error {
+	projectedAt, err := p.analyticsRepo.FindProjectedAt(ctx, userID)
+	if err != nil || projectedAt != nil {
+		return err
+	}
+	return p.Project(ctx, userID)
+}
@@ -0,0 +1,51 @@
+package finance
+
+import (
+	"context"
+	"log/slog"
+	"panda-pocket/internal/domain/finance"
+	"time"
+)
+
+// ArchiveTransactionsUseCase moves old transactions out of the hot tables
+type ArchiveTransactionsUseCase struct {
+	transactionRepo finance.TransactionRepository
+	afterYears      int
+}
+
+// NewArchiveTransactionsUseCase creates a use case archiving transactions older than afterYears
+func NewArchiveTransactionsUseCase(transactionRepo finance.TransactionRepository, afterYears int) *ArchiveTransactionsUseCase {
+	return &ArchiveTransactionsUseCase{
+		transactionRepo: transactionRepo,
+		afterYears:      afterYears,
+	}
+}
+
+// Execute archives every transaction dated before the start of the day afterYears ago
+func (uc *ArchiveTransactionsUseCase) Execute(ctx context.Context) (int, error) {
+	now := time.Now()
+	cutoff := time.Date(now.Year()-uc.afterYears, now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return uc.transactionRepo.ArchiveBefore(ctx, cutoff)
+}
+
+// Run executes the use case every interval until ctx is cancelled
+func (uc *ArchiveTransactionsUseCase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			archived, err := uc.Execute(ctx)
+			if err != nil {
+				slog.Error("transaction archival failed", "error", err.Error())
+				continue
+			}
+			if archived > 0 {
+				slog.Info("archived old transactions", "count", archived, "after_years", uc.afterYears)
+			}
+		}
+	}
+}
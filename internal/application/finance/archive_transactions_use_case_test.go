@@ -0,0 +1,36 @@
+package finance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"panda-pocket/internal/domain/finance"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// archivingRepository records the cutoff it is asked to archive before
+type archivingRepository struct {
+	finance.TransactionRepository
+	cutoff time.Time
+}
+
+func (r *archivingRepository) ArchiveBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	r.cutoff = cutoff
+	return 3, nil
+}
+
+func TestArchiveTransactionsUseCase(t *testing.T) {
+	repo := &archivingRepository{}
+	before := time.Now()
+
+	archived, err := NewArchiveTransactionsUseCase(repo, 2).Execute(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, archived)
+
+	// The cutoff is the start of today's date two years back
+	want := time.Date(before.Year()-2, before.Month(), before.Day(), 0, 0, 0, 0, before.Location())
+	assert.Equal(t, want, repo.cutoff)
+}
@@ -0,0 +1,98 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"panda-pocket/internal/domain/finance"
+	"time"
+)
+
+// CreateBalanceAdjustmentRequest represents the balance an account should have at
+// the end of a day; the difference from the derived balance is recorded as an adjustment
+type CreateBalanceAdjustmentRequest struct {
+	Date        string   `json:"date" binding:"required"`
+	Balance     *float64 `json:"balance" binding:"required"`
+	Description string   `json:"description"`
+}
+
+// BalanceAdjustmentResponse represents a balance adjustment in the response
+type BalanceAdjustmentResponse struct {
+	ID          int     `json:"id"`
+	AccountID   int     `json:"account_id"`
+	Amount      float64 `json:"amount"`
+	Date        string  `json:"date"`
+	Description string  `json:"description"`
+	CreatedAt   string  `json:"created_at"`
+}
+
+// BalanceAdjustmentsUseCase handles reconciling account balances with adjustments
+type BalanceAdjustmentsUseCase struct {
+	accountService *finance.AccountService
+}
+
+// NewBalanceAdjustmentsUseCase creates a new balance adjustments use case
+func NewBalanceAdjustmentsUseCase(accountService *finance.AccountService) *BalanceAdjustmentsUseCase {
+	return &BalanceAdjustmentsUseCase{
+		accountService: accountService,
+	}
+}
+
+// Create records the adjustment that brings the account's derived balance to the
+// requested one. Adjustments change account balances but not income or spending.
+func (uc *BalanceAdjustmentsUseCase) Create(ctx context.Context, userID, accountID int, req CreateBalanceAdjustmentRequest) (*BalanceAdjustmentResponse, error) {
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, errors.New("invalid date format. Expected YYYY-MM-DD")
+	}
+
+	adjustment, err := uc.accountService.AdjustBalance(
+		ctx,
+		finance.NewAccountID(accountID),
+		finance.NewUserID(userID),
+		date,
+		*req.Balance,
+		req.Description,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response := newBalanceAdjustmentResponse(adjustment)
+	return &response, nil
+}
+
+// List returns the account's adjustments, oldest first
+func (uc *BalanceAdjustmentsUseCase) List(ctx context.Context, userID, accountID int) ([]BalanceAdjustmentResponse, error) {
+	adjustments, err := uc.accountService.GetBalanceAdjustments(ctx, finance.NewAccountID(accountID), finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]BalanceAdjustmentResponse, len(adjustments))
+	for i, adjustment := range adjustments {
+		responses[i] = newBalanceAdjustmentResponse(adjustment)
+	}
+	return responses, nil
+}
+
+// Delete removes one of the account's adjustments
+func (uc *BalanceAdjustmentsUseCase) Delete(ctx context.Context, userID, accountID, adjustmentID int) error {
+	return uc.accountService.DeleteBalanceAdjustment(
+		ctx,
+		finance.NewAccountID(accountID),
+		finance.NewBalanceAdjustmentID(adjustmentID),
+		finance.NewUserID(userID),
+	)
+}
+
+// newBalanceAdjustmentResponse converts a domain balance adjustment for the API
+func newBalanceAdjustmentResponse(adjustment *finance.BalanceAdjustment) BalanceAdjustmentResponse {
+	return BalanceAdjustmentResponse{
+		ID:          adjustment.ID().Value(),
+		AccountID:   adjustment.AccountID().Value(),
+		Amount:      adjustment.Amount(),
+		Date:        adjustment.Date().Format("2006-01-02"),
+		Description: adjustment.Description(),
+		CreatedAt:   adjustment.CreatedAt().Format(time.RFC3339),
+	}
+}
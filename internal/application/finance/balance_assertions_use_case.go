@@ -0,0 +1,100 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"panda-pocket/internal/domain/finance"
+	"time"
+)
+
+// CreateBalanceAssertionRequest represents the balance an account showed at the end of a day
+type CreateBalanceAssertionRequest struct {
+	Date    string   `json:"date" binding:"required"`
+	Balance *float64 `json:"balance" binding:"required"`
+}
+
+// BalanceAssertionResponse represents an asserted balance checked against the recorded transactions
+type BalanceAssertionResponse struct {
+	ID              int     `json:"id"`
+	AccountID       int     `json:"account_id"`
+	Date            string  `json:"date"`
+	AssertedBalance float64 `json:"asserted_balance"`
+	DerivedBalance  float64 `json:"derived_balance"`
+	Difference      float64 `json:"difference"`
+	NewDiscrepancy  float64 `json:"new_discrepancy"`
+	Holds           bool    `json:"holds"`
+	CreatedAt       string  `json:"created_at"`
+}
+
+// BalanceAssertionsUseCase handles asserting account balances and reporting discrepancies
+type BalanceAssertionsUseCase struct {
+	accountService *finance.AccountService
+}
+
+// NewBalanceAssertionsUseCase creates a new balance assertions use case
+func NewBalanceAssertionsUseCase(accountService *finance.AccountService) *BalanceAssertionsUseCase {
+	return &BalanceAssertionsUseCase{
+		accountService: accountService,
+	}
+}
+
+// Create records a balance assertion and returns how it compares with the derived balance
+func (uc *BalanceAssertionsUseCase) Create(ctx context.Context, userID, accountID int, req CreateBalanceAssertionRequest) (*BalanceAssertionResponse, error) {
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, errors.New("invalid date format. Expected YYYY-MM-DD")
+	}
+
+	check, err := uc.accountService.AssertBalance(
+		ctx,
+		finance.NewAccountID(accountID),
+		finance.NewUserID(userID),
+		date,
+		*req.Balance,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response := newBalanceAssertionResponse(check)
+	return &response, nil
+}
+
+// List returns the account's assertions, oldest first, checked against the current transactions
+func (uc *BalanceAssertionsUseCase) List(ctx context.Context, userID, accountID int) ([]BalanceAssertionResponse, error) {
+	checks, err := uc.accountService.GetBalanceChecks(ctx, finance.NewAccountID(accountID), finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]BalanceAssertionResponse, len(checks))
+	for i, check := range checks {
+		responses[i] = newBalanceAssertionResponse(check)
+	}
+	return responses, nil
+}
+
+// Delete removes one of the account's assertions
+func (uc *BalanceAssertionsUseCase) Delete(ctx context.Context, userID, accountID, assertionID int) error {
+	return uc.accountService.DeleteBalanceAssertion(
+		ctx,
+		finance.NewAccountID(accountID),
+		finance.NewBalanceAssertionID(assertionID),
+		finance.NewUserID(userID),
+	)
+}
+
+// newBalanceAssertionResponse converts a domain balance check for the API
+func newBalanceAssertionResponse(check *finance.BalanceCheck) BalanceAssertionResponse {
+	return BalanceAssertionResponse{
+		ID:              check.Assertion.ID().Value(),
+		AccountID:       check.Assertion.AccountID().Value(),
+		Date:            check.Assertion.Date().Format("2006-01-02"),
+		AssertedBalance: check.Assertion.Balance(),
+		DerivedBalance:  check.DerivedBalance,
+		Difference:      check.Difference(),
+		NewDiscrepancy:  check.NewDiscrepancy(),
+		Holds:           check.Holds(),
+		CreatedAt:       check.Assertion.CreatedAt().Format(time.RFC3339),
+	}
+}
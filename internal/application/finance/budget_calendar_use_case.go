@@ -0,0 +1,138 @@
+package finance
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"time"
+)
+
+// BudgetCalendarRequest represents the month and currency of a budget calendar
+type BudgetCalendarRequest struct {
+	Month      string `form:"month"`       // YYYY-MM; defaults to the current month
+	CurrencyID int    `form:"currency_id"` // defaults to the user's default currency
+}
+
+// BudgetCalendarResponse represents how much the user's budgets allow them to
+// spend on each day of a month against what they spent
+type BudgetCalendarResponse struct {
+	Month        string              `json:"month"`
+	CurrencyID   int                 `json:"currency_id"`
+	CurrencyCode string              `json:"currency_code"`
+	Days         []BudgetCalendarDay `json:"days"`
+	TotalPlanned float64             `json:"total_planned"`
+	TotalActual  float64             `json:"total_actual"`
+	// SafeToSpendToday is what is left of everything planned up to and including
+	// today; it is only set for the current month
+	SafeToSpendToday *float64 `json:"safe_to_spend_today"`
+}
+
+// BudgetCalendarDay represents one day of a budget calendar. Remaining is the
+// month's planned spend up to and including the day less the actual spend.
+type BudgetCalendarDay struct {
+	Date      string  `json:"date"`
+	Planned   float64 `json:"planned"`
+	Actual    float64 `json:"actual"`
+	Remaining float64 `json:"remaining"`
+}
+
+// BudgetCalendarUseCase handles spreading budgets over the days of a month
+type BudgetCalendarUseCase struct {
+	budgetService      *finance.BudgetService
+	transactionService *finance.TransactionService
+	currencyService    *finance.CurrencyService
+}
+
+// NewBudgetCalendarUseCase creates a new budget calendar use case
+func NewBudgetCalendarUseCase(
+	budgetService *finance.BudgetService,
+	transactionService *finance.TransactionService,
+	currencyService *finance.CurrencyService,
+) *BudgetCalendarUseCase {
+	return &BudgetCalendarUseCase{
+		budgetService:      budgetService,
+		transactionService: transactionService,
+		currencyService:    currencyService,
+	}
+}
+
+// Execute spreads each of the user's budgets in the currency evenly over the
+// days it covers and compares every day of the month with the expenses dated on
+// it in the budgeted categories. Spending in categories without a budget that
+// day is left out.
+func (uc *BudgetCalendarUseCase) Execute(ctx context.Context, userID int, req BudgetCalendarRequest) (*BudgetCalendarResponse, error) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	month := finance.MonthStart(now)
+	if req.Month != "" {
+		parsed, err := finance.ParseMonth(req.Month)
+		if err != nil {
+			return nil, err
+		}
+		month = parsed
+	}
+	nextMonth := month.AddDate(0, 1, 0)
+
+	currency, err := userCurrency(ctx, uc.currencyService, userID, req.CurrencyID)
+	if err != nil {
+		return nil, err
+	}
+
+	allBudgets, err := uc.budgetService.GetBudgetsByUser(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	var budgets []*finance.Budget
+	for _, budget := range allBudgets {
+		if budget.Amount().Currency() == currency.ID() {
+			budgets = append(budgets, budget)
+		}
+	}
+
+	transactions, err := uc.transactionService.GetTransactionsByUserAndDateRange(ctx, finance.NewUserID(userID), month, nextMonth.Add(-time.Nanosecond))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &BudgetCalendarResponse{
+		Month:        month.Format("2006-01"),
+		CurrencyID:   currency.ID().Value(),
+		CurrencyCode: currency.Code(),
+		Days:         []BudgetCalendarDay{},
+	}
+	var planned, actual float64
+	for day := month; day.Before(nextMonth); day = day.AddDate(0, 0, 1) {
+		budgeted := make(map[finance.CategoryID]bool)
+		var dayPlanned, dayActual float64
+		for _, budget := range budgets {
+			if budget.Covers(day) {
+				budgeted[budget.CategoryID()] = true
+				dayPlanned += budget.DailyAllowance()
+			}
+		}
+		for _, transaction := range transactions {
+			date := transaction.Date().UTC()
+			if transaction.Type() != finance.TransactionTypeExpense || transaction.CurrencyID() != currency.ID() ||
+				!budgeted[transaction.CategoryID()] || date.Before(day) || !date.Before(day.AddDate(0, 0, 1)) {
+				continue
+			}
+			dayActual += transaction.Amount().Amount()
+		}
+
+		planned += dayPlanned
+		actual += dayActual
+		response.Days = append(response.Days, BudgetCalendarDay{
+			Date:      day.Format("2006-01-02"),
+			Planned:   roundAmount(dayPlanned),
+			Actual:    roundAmount(dayActual),
+			Remaining: roundAmount(planned - actual),
+		})
+		if day.Equal(today) {
+			safeToSpend := roundAmount(max(planned-actual, 0))
+			response.SafeToSpendToday = &safeToSpend
+		}
+	}
+
+	response.TotalPlanned = roundAmount(planned)
+	response.TotalActual = roundAmount(actual)
+	return response, nil
+}
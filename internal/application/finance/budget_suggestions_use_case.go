@@ -0,0 +1,244 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"math"
+	"panda-pocket/internal/domain/finance"
+	"sort"
+	"time"
+)
+
+// minSuggestionMonths is the spending history suggestions are based on by default
+const minSuggestionMonths = 3
+
+// BudgetSuggestionsRequest represents the query for budget suggestions
+type BudgetSuggestionsRequest struct {
+	// Months is how many complete months before the current one are averaged; defaults to 3
+	Months int `form:"months" binding:"omitempty,min=3,max=6"`
+}
+
+// BudgetSuggestion represents a proposed monthly budget for one expense category
+type BudgetSuggestion struct {
+	CategoryID int               `json:"category_id"`
+	Category   *CategoryResponse `json:"category"`
+	// Average is the mean monthly spend; Amount rounds it up to a whole unit
+	Average            float64 `json:"average"`
+	Amount             float64 `json:"amount"`
+	MonthsWithSpending int     `json:"months_with_spending"`
+	// HasBudget is set when the category already has an active budget
+	HasBudget bool `json:"has_budget"`
+}
+
+// BudgetSuggestionsResponse represents the budget suggestions and the history they are based on
+type BudgetSuggestionsResponse struct {
+	Months      int                `json:"months"`
+	StartDate   string             `json:"start_date"`
+	EndDate     string             `json:"end_date"`
+	Suggestions []BudgetSuggestion `json:"suggestions"`
+}
+
+// AcceptBudgetSuggestionsRequest represents the suggestions to turn into monthly budgets
+type AcceptBudgetSuggestionsRequest struct {
+	Budgets []AcceptedBudgetSuggestion `json:"budgets" binding:"required,min=1,max=100,dive"`
+	// StartDate defaults to the first day of the current month
+	StartDate string `json:"start_date"`
+}
+
+// AcceptedBudgetSuggestion represents one suggestion to accept, possibly with a changed amount
+type AcceptedBudgetSuggestion struct {
+	CategoryID int     `json:"category_id" binding:"required"`
+	Amount     float64 `json:"amount" binding:"required,gt=0"`
+}
+
+// BudgetSuggestionsUseCase handles proposing budgets from the user's spending
+// history and creating the ones they accept
+type BudgetSuggestionsUseCase struct {
+	transactionService *finance.TransactionService
+	budgetService      *finance.BudgetService
+	categoryService    *finance.CategoryService
+	currencyService    *finance.CurrencyService
+	unitOfWork         finance.UnitOfWork
+}
+
+// NewBudgetSuggestionsUseCase creates a new budget suggestions use case
+func NewBudgetSuggestionsUseCase(
+	transactionService *finance.TransactionService,
+	budgetService *finance.BudgetService,
+	categoryService *finance.CategoryService,
+	currencyService *finance.CurrencyService,
+	unitOfWork finance.UnitOfWork,
+) *BudgetSuggestionsUseCase {
+	return &BudgetSuggestionsUseCase{
+		transactionService: transactionService,
+		budgetService:      budgetService,
+		categoryService:    categoryService,
+		currencyService:    currencyService,
+		unitOfWork:         unitOfWork,
+	}
+}
+
+// Suggest proposes a monthly budget for every expense category the user spent in
+// over the complete months before the current one, largest first
+func (uc *BudgetSuggestionsUseCase) Suggest(ctx context.Context, userID int, req BudgetSuggestionsRequest) (*BudgetSuggestionsResponse, error) {
+	months := req.Months
+	if months == 0 {
+		months = minSuggestionMonths
+	}
+
+	now := time.Now().UTC()
+	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	startDate := currentMonth.AddDate(0, -months, 0)
+	endDate := currentMonth.AddDate(0, 0, -1)
+
+	transactions, err := uc.transactionService.GetTransactionsByUserAndDateRange(ctx, finance.NewUserID(userID), startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[int]float64)
+	spendingMonths := make(map[int]map[time.Month]bool)
+	for _, transaction := range transactions {
+		if transaction.Type() != finance.TransactionTypeExpense {
+			continue
+		}
+		categoryID := transaction.CategoryID().Value()
+		totals[categoryID] += transaction.Amount().Amount()
+		if spendingMonths[categoryID] == nil {
+			spendingMonths[categoryID] = make(map[time.Month]bool)
+		}
+		spendingMonths[categoryID][transaction.Date().Month()] = true
+	}
+
+	budgeted, err := uc.budgetedCategories(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := []BudgetSuggestion{}
+	for categoryID, total := range totals {
+		average := math.Round(total/float64(months)*100) / 100
+		suggestions = append(suggestions, BudgetSuggestion{
+			CategoryID:         categoryID,
+			Category:           uc.categoryResponse(ctx, finance.NewCategoryID(categoryID)),
+			Average:            average,
+			Amount:             math.Ceil(average),
+			MonthsWithSpending: len(spendingMonths[categoryID]),
+			HasBudget:          budgeted[categoryID],
+		})
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Average != suggestions[j].Average {
+			return suggestions[i].Average > suggestions[j].Average
+		}
+		return suggestions[i].CategoryID < suggestions[j].CategoryID
+	})
+
+	return &BudgetSuggestionsResponse{
+		Months:      months,
+		StartDate:   startDate.Format("2006-01-02"),
+		EndDate:     endDate.Format("2006-01-02"),
+		Suggestions: suggestions,
+	}, nil
+}
+
+// errDryRun rolls back a dry run's transaction once its changes are known
+var errDryRun = errors.New("dry run")
+
+// Accept creates a monthly budget for each accepted suggestion. Either all of
+// them are created or, when one fails, none are. A dry run creates them inside
+// a transaction that is rolled back, so it fails the same way a real run would
+// and returns the budgets that would be created.
+func (uc *BudgetSuggestionsUseCase) Accept(ctx context.Context, userID int, req AcceptBudgetSuggestionsRequest, dryRun bool) ([]CreateBudgetResponse, error) {
+	now := time.Now().UTC()
+	startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if req.StartDate != "" {
+		parsed, err := time.Parse("2006-01-02", req.StartDate)
+		if err != nil {
+			return nil, err
+		}
+		startDate = parsed
+	}
+
+	currency, err := uc.currencyService.GetPrimaryCurrency(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	var budgets []*finance.Budget
+	err = uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		for _, accepted := range req.Budgets {
+			money, err := finance.NewMoney(accepted.Amount, currency.ID())
+			if err != nil {
+				return err
+			}
+			money, err = money.In(currency)
+			if err != nil {
+				return err
+			}
+
+			budget, err := uc.budgetService.CreateBudget(
+				ctx,
+				finance.NewUserID(userID),
+				finance.NewCategoryID(accepted.CategoryID),
+				money,
+				finance.BudgetPeriodMonthly,
+				startDate,
+				false,
+			)
+			if err != nil {
+				return err
+			}
+			budgets = append(budgets, budget)
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	responses := make([]CreateBudgetResponse, len(budgets))
+	for i, budget := range budgets {
+		responses[i] = CreateBudgetResponse{
+			Amount:    budget.Amount().Amount(),
+			Period:    string(budget.Period()),
+			StartDate: budget.StartDate().Format("2006-01-02"),
+			EndDate:   budget.EndDate().Format("2006-01-02"),
+			Prorated:  budget.Prorated(),
+			Allowance: budget.Allowance(),
+			Category:  uc.categoryResponse(ctx, budget.CategoryID()),
+		}
+	}
+	return responses, nil
+}
+
+// budgetedCategories returns the IDs of the categories the user has an active budget for
+func (uc *BudgetSuggestionsUseCase) budgetedCategories(ctx context.Context, userID int) (map[int]bool, error) {
+	budgets, err := uc.budgetService.GetActiveBudgetsByUser(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	budgeted := make(map[int]bool, len(budgets))
+	for _, budget := range budgets {
+		budgeted[budget.CategoryID().Value()] = true
+	}
+	return budgeted, nil
+}
+
+// categoryResponse looks up a category for the response, or nil when it is gone
+func (uc *BudgetSuggestionsUseCase) categoryResponse(ctx context.Context, categoryID finance.CategoryID) *CategoryResponse {
+	category, err := uc.categoryService.GetCategoryByID(ctx, categoryID)
+	if err != nil {
+		return nil
+	}
+	return &CategoryResponse{
+		ID:    category.ID().Value(),
+		Name:  category.Name(),
+		Color: category.Color(),
+		Type:  string(category.Type()),
+	}
+}
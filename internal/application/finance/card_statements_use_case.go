@@ -0,0 +1,141 @@
+package finance
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"time"
+)
+
+// SetBillingCycleRequest represents the days of the month a credit card's
+// statement closes and its payment is due
+type SetBillingCycleRequest struct {
+	ClosingDay int `json:"closing_day" binding:"required"`
+	DueDay     int `json:"due_day" binding:"required"`
+}
+
+// BillingCycleResponse represents a credit card's billing cycle
+type BillingCycleResponse struct {
+	ClosingDay int `json:"closing_day"`
+	DueDay     int `json:"due_day"`
+}
+
+// GetCardStatementsRequest represents how many billing cycles to summarize
+type GetCardStatementsRequest struct {
+	Count int `form:"count"` // defaults to 6, at most 24
+}
+
+// CardStatementResponse represents a credit card's spending over one billing cycle
+type CardStatementResponse struct {
+	PeriodStart      string  `json:"period_start"`
+	ClosingDate      string  `json:"closing_date"`
+	DueDate          string  `json:"due_date"`
+	Open             bool    `json:"open"`
+	PreviousBalance  float64 `json:"previous_balance"`
+	Charges          float64 `json:"charges"`
+	Payments         float64 `json:"payments"`
+	Adjustments      float64 `json:"adjustments"`
+	Balance          float64 `json:"balance"`
+	MinimumPayment   float64 `json:"minimum_payment"`
+	TransactionCount int     `json:"transaction_count"`
+}
+
+// CardStatementsResponse represents the statements of a credit card, newest first
+type CardStatementsResponse struct {
+	AccountID    int                     `json:"account_id"`
+	CurrencyID   int                     `json:"currency_id"`
+	BillingCycle BillingCycleResponse    `json:"billing_cycle"`
+	Statements   []CardStatementResponse `json:"statements"`
+}
+
+// CardStatementsUseCase handles credit card billing cycles and their statements
+type CardStatementsUseCase struct {
+	accountService *finance.AccountService
+}
+
+// NewCardStatementsUseCase creates a new card statements use case
+func NewCardStatementsUseCase(accountService *finance.AccountService) *CardStatementsUseCase {
+	return &CardStatementsUseCase{
+		accountService: accountService,
+	}
+}
+
+// SetBillingCycle sets when one of the user's credit cards closes its statements and is due
+func (uc *CardStatementsUseCase) SetBillingCycle(ctx context.Context, userID, accountID int, req SetBillingCycleRequest) (*AccountResponse, error) {
+	cycle, err := finance.NewBillingCycle(req.ClosingDay, req.DueDay)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := uc.accountService.SetBillingCycle(ctx, finance.NewAccountID(accountID), finance.NewUserID(userID), &cycle)
+	if err != nil {
+		return nil, err
+	}
+
+	response := newAccountResponse(account)
+	return &response, nil
+}
+
+// RemoveBillingCycle stops summarizing a credit card by statement
+func (uc *CardStatementsUseCase) RemoveBillingCycle(ctx context.Context, userID, accountID int) (*AccountResponse, error) {
+	account, err := uc.accountService.SetBillingCycle(ctx, finance.NewAccountID(accountID), finance.NewUserID(userID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := newAccountResponse(account)
+	return &response, nil
+}
+
+// List summarizes the card's latest billing cycles, starting with the open one
+func (uc *CardStatementsUseCase) List(ctx context.Context, userID, accountID int, req GetCardStatementsRequest) (*CardStatementsResponse, error) {
+	count := req.Count
+	if count <= 0 {
+		count = 6
+	}
+	if count > 24 {
+		count = 24
+	}
+
+	account, err := uc.accountService.GetAccount(ctx, finance.NewAccountID(accountID), finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	statements, err := uc.accountService.GetCardStatements(ctx, account, count, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CardStatementsResponse{
+		AccountID:    accountID,
+		CurrencyID:   account.CurrencyID().Value(),
+		BillingCycle: *newBillingCycleResponse(account.BillingCycle()),
+		Statements:   make([]CardStatementResponse, len(statements)),
+	}
+	for i, statement := range statements {
+		response.Statements[i] = CardStatementResponse{
+			PeriodStart:      statement.PeriodStart.Format("2006-01-02"),
+			ClosingDate:      statement.ClosingDate.Format("2006-01-02"),
+			DueDate:          statement.DueDate.Format("2006-01-02"),
+			Open:             statement.Open,
+			PreviousBalance:  statement.PreviousBalance,
+			Charges:          statement.Charges,
+			Payments:         statement.Payments,
+			Adjustments:      statement.Adjustments,
+			Balance:          statement.Balance(),
+			MinimumPayment:   statement.MinimumPayment(),
+			TransactionCount: statement.TransactionCount,
+		}
+	}
+	return response, nil
+}
+
+// newBillingCycleResponse converts a domain billing cycle for the API; nil stays nil
+func newBillingCycleResponse(cycle *finance.BillingCycle) *BillingCycleResponse {
+	if cycle == nil {
+		return nil
+	}
+	return &BillingCycleResponse{
+		ClosingDay: cycle.ClosingDay(),
+		DueDay:     cycle.DueDay(),
+	}
+}
@@ -0,0 +1,30 @@
+package finance
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+)
+
+// CopyDefaultCategoriesUseCase handles giving a user their own copies of the
+// default categories, which they can rename, recolor and delete like any other
+type CopyDefaultCategoriesUseCase struct {
+	categoryService *finance.CategoryService
+	unitOfWork      finance.UnitOfWork
+}
+
+// NewCopyDefaultCategoriesUseCase creates a new copy default categories use case
+func NewCopyDefaultCategoriesUseCase(categoryService *finance.CategoryService, unitOfWork finance.UnitOfWork) *CopyDefaultCategoriesUseCase {
+	return &CopyDefaultCategoriesUseCase{
+		categoryService: categoryService,
+		unitOfWork:      unitOfWork,
+	}
+}
+
+// Execute copies every default category the user has no copy of yet. Either all
+// of them are copied or none.
+func (uc *CopyDefaultCategoriesUseCase) Execute(ctx context.Context, userID int) error {
+	return uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		_, err := uc.categoryService.CopyDefaultCategories(ctx, finance.NewUserID(userID))
+		return err
+	})
+}
@@ -12,6 +12,8 @@ type CreateBudgetRequest struct {
 	Amount     float64 `json:"amount" binding:"required,gt=0"`
 	Period     string  `json:"period" binding:"required,oneof=weekly monthly yearly"`
 	StartDate  string  `json:"start_date" binding:"required"`
+	// Prorate ends the budget with the calendar period it starts in and scales its amount to the days left
+	Prorate bool `json:"prorate"`
 }
 
 // CreateBudgetResponse represents the response for creating a budget
@@ -20,6 +22,8 @@ type CreateBudgetResponse struct {
 	Period    string            `json:"period"`
 	StartDate string            `json:"start_date"`
 	EndDate   string            `json:"end_date"`
+	Prorated  bool              `json:"prorated"`
+	Allowance float64           `json:"allowance"`
 	Category  *CategoryResponse `json:"category"`
 }
 
@@ -53,11 +57,15 @@ func (uc *CreateBudgetUseCase) Execute(ctx context.Context, userID int, req Crea
 		return nil, err
 	}
 
-	// Create money object
+	// Create money object, kept to the currency's minor units
 	money, err := finance.NewMoney(req.Amount, currency.ID())
 	if err != nil {
 		return nil, err
 	}
+	money, err = money.In(currency)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create budget
 	budget, err := uc.budgetService.CreateBudget(
@@ -67,6 +75,7 @@ func (uc *CreateBudgetUseCase) Execute(ctx context.Context, userID int, req Crea
 		money,
 		finance.BudgetPeriod(req.Period),
 		startDate,
+		req.Prorate,
 	)
 	if err != nil {
 		return nil, err
@@ -90,6 +99,8 @@ func (uc *CreateBudgetUseCase) Execute(ctx context.Context, userID int, req Crea
 		Period:    string(budget.Period()),
 		StartDate: budget.StartDate().Format("2006-01-02"),
 		EndDate:   budget.EndDate().Format("2006-01-02"),
+		Prorated:  budget.Prorated(),
+		Allowance: budget.Allowance(),
 		Category:  categoryResponse,
 	}, nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"panda-pocket/internal/domain/finance"
+	"strings"
 	"time"
 )
 
@@ -14,6 +15,13 @@ type CreateTransactionRequest struct {
 	Description string  `json:"description"`
 	Date        string  `json:"date" binding:"required"`
 	Type        string  `json:"type"`
+	AccountID   int     `json:"account_id,omitempty"`
+	// Where the transaction was made, usually from a mobile client; both or neither
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+	// ClientID is a UUID the client made the transaction under, so a create
+	// retried after going offline returns the transaction instead of a duplicate
+	ClientID string `json:"client_id" binding:"omitempty,uuid_rfc4122"`
 }
 
 // CreateTransactionResponse represents the response after creating a transaction
@@ -22,11 +30,18 @@ type CreateTransactionResponse struct {
 	UserID      int     `json:"user_id"`
 	CategoryID  int     `json:"category_id"`
 	CurrencyID  int     `json:"currency_id"`
+	AccountID   int     `json:"account_id,omitempty"`
 	Amount      float64 `json:"amount"`
 	Description string  `json:"description"`
 	Date        string  `json:"date"`
 	Type        string  `json:"type"`
+	Status      string  `json:"status"`
+	Version     int     `json:"version"`
+	ClientID    string  `json:"client_id,omitempty"`
 	CreatedAt   string  `json:"created_at"`
+	// Unset when the client did not say where the transaction was made
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
 }
 
 // CreateTransactionUseCase handles transaction creation
@@ -46,12 +61,26 @@ func NewCreateTransactionUseCase(
 	}
 }
 
-// Execute executes the create transaction use case
+// Execute executes the create transaction use case. A request with the client ID
+// of one of the user's transactions returns that transaction unchanged. When the
+// future date policy schedules the date, the response is the scheduled
+// transaction, with status scheduled.
 func (uc *CreateTransactionUseCase) Execute(ctx context.Context, userID int, req CreateTransactionRequest) (*CreateTransactionResponse, error) {
-	// Parse date
-	date, err := time.Parse("2006-01-02", req.Date)
+	date, err := parseTransactionDate(req.Date)
 	if err != nil {
-		return nil, errors.New("invalid date format. Expected YYYY-MM-DD")
+		return nil, err
+	}
+
+	var location *finance.Location
+	if req.Latitude != nil || req.Longitude != nil {
+		if req.Latitude == nil || req.Longitude == nil {
+			return nil, finance.ErrInvalidLocation
+		}
+		parsed, err := finance.NewLocation(*req.Latitude, *req.Longitude)
+		if err != nil {
+			return nil, err
+		}
+		location = &parsed
 	}
 
 	// Get user's primary currency
@@ -66,6 +95,35 @@ func (uc *CreateTransactionUseCase) Execute(ctx context.Context, userID int, req
 		return nil, err
 	}
 
+	// Under the schedule policy a future-dated transaction posts on its date instead
+	if uc.transactionService.SchedulesDate(date) {
+		scheduled, err := uc.transactionService.ScheduleTransaction(
+			ctx,
+			finance.NewUserID(userID),
+			finance.NewCategoryID(req.CategoryID),
+			primaryCurrency.ID(),
+			money,
+			req.Description,
+			date,
+			finance.TransactionType(req.Type),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return &CreateTransactionResponse{
+			ID:          scheduled.ID().Value(),
+			UserID:      scheduled.UserID().Value(),
+			CategoryID:  scheduled.CategoryID().Value(),
+			CurrencyID:  scheduled.CurrencyID().Value(),
+			Amount:      scheduled.Amount().Amount(),
+			Description: scheduled.Description(),
+			Date:        scheduled.Date().Format("2006-01-02"),
+			Type:        string(scheduled.Type()),
+			Status:      string(scheduled.Status()),
+			CreatedAt:   scheduled.CreatedAt().Format(time.RFC3339),
+		}, nil
+	}
+
 	// Create transaction
 	transaction, err := uc.transactionService.CreateTransaction(
 		ctx,
@@ -76,6 +134,9 @@ func (uc *CreateTransactionUseCase) Execute(ctx context.Context, userID int, req
 		req.Description,
 		date,
 		finance.TransactionType(req.Type),
+		finance.NewAccountID(req.AccountID),
+		location,
+		strings.ToLower(req.ClientID),
 	)
 	if err != nil {
 		return nil, err
@@ -86,10 +147,30 @@ func (uc *CreateTransactionUseCase) Execute(ctx context.Context, userID int, req
 		UserID:      transaction.UserID().Value(),
 		CategoryID:  transaction.CategoryID().Value(),
 		CurrencyID:  transaction.CurrencyID().Value(),
+		AccountID:   transaction.AccountID().Value(),
 		Amount:      transaction.Amount().Amount(),
 		Description: transaction.Description(),
 		Date:        transaction.Date().Format("2006-01-02"),
 		Type:        string(transaction.Type()),
+		Status:      string(transaction.Status()),
+		Version:     transaction.Version(),
+		ClientID:    transaction.ClientID(),
 		CreatedAt:   transaction.CreatedAt().Format(time.RFC3339),
+		Latitude:    latitudeOf(transaction),
+		Longitude:   longitudeOf(transaction),
 	}, nil
 }
+
+// parseTransactionDate accepts an ISO 8601 date (YYYY-MM-DD) or date and time
+// (RFC 3339). Transactions are dated by day, so a timestamp keeps the calendar
+// date in its own offset.
+func parseTransactionDate(value string) (time.Time, error) {
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, nil
+	}
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, finance.ErrInvalidTransactionDate
+	}
+	return time.Date(timestamp.Year(), timestamp.Month(), timestamp.Day(), 0, 0, 0, 0, time.UTC), nil
+}
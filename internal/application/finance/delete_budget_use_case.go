@@ -9,12 +9,14 @@ import (
 // DeleteBudgetUseCase handles budget deletion
 type DeleteBudgetUseCase struct {
 	budgetService *finance.BudgetService
+	unitOfWork    finance.UnitOfWork
 }
 
 // NewDeleteBudgetUseCase creates a new delete budget use case
-func NewDeleteBudgetUseCase(budgetService *finance.BudgetService) *DeleteBudgetUseCase {
+func NewDeleteBudgetUseCase(budgetService *finance.BudgetService, unitOfWork finance.UnitOfWork) *DeleteBudgetUseCase {
 	return &DeleteBudgetUseCase{
 		budgetService: budgetService,
+		unitOfWork:    unitOfWork,
 	}
 }
 
@@ -30,6 +32,8 @@ func (uc *DeleteBudgetUseCase) Execute(ctx context.Context, budgetIDStr string,
 	budgetID := finance.NewBudgetID(budgetIDInt)
 	userIDDomain := finance.NewUserID(userID)
 
-	// Delete budget
-	return uc.budgetService.DeleteBudget(ctx, budgetID, userIDDomain)
+	// Delete budget; the delete and its undo record share one transaction
+	return uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		return uc.budgetService.DeleteBudget(ctx, budgetID, userIDDomain)
+	})
 }
@@ -8,12 +8,14 @@ import (
 // DeleteCurrencyUseCase handles currency deletion
 type DeleteCurrencyUseCase struct {
 	currencyService *finance.CurrencyService
+	unitOfWork      finance.UnitOfWork
 }
 
 // NewDeleteCurrencyUseCase creates a new delete currency use case
-func NewDeleteCurrencyUseCase(currencyService *finance.CurrencyService) *DeleteCurrencyUseCase {
+func NewDeleteCurrencyUseCase(currencyService *finance.CurrencyService, unitOfWork finance.UnitOfWork) *DeleteCurrencyUseCase {
 	return &DeleteCurrencyUseCase{
 		currencyService: currencyService,
+		unitOfWork:      unitOfWork,
 	}
 }
 
@@ -24,8 +26,10 @@ type DeleteCurrencyResponse struct {
 
 // Execute executes the delete currency use case
 func (uc *DeleteCurrencyUseCase) Execute(ctx context.Context, userID finance.UserID, currencyID finance.CurrencyID) (*DeleteCurrencyResponse, error) {
-	// Delete currency using domain service
-	err := uc.currencyService.DeleteCurrency(ctx, currencyID, userID)
+	// Delete currency using domain service; the in-use check and the delete share one transaction
+	err := uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		return uc.currencyService.DeleteCurrency(ctx, currencyID, userID)
+	})
 	if err != nil {
 		return nil, err
 	}
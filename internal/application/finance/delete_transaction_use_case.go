@@ -9,12 +9,14 @@ import (
 // DeleteTransactionUseCase handles transaction deletion
 type DeleteTransactionUseCase struct {
 	transactionService *finance.TransactionService
+	unitOfWork         finance.UnitOfWork
 }
 
 // NewDeleteTransactionUseCase creates a new delete transaction use case
-func NewDeleteTransactionUseCase(transactionService *finance.TransactionService) *DeleteTransactionUseCase {
+func NewDeleteTransactionUseCase(transactionService *finance.TransactionService, unitOfWork finance.UnitOfWork) *DeleteTransactionUseCase {
 	return &DeleteTransactionUseCase{
 		transactionService: transactionService,
+		unitOfWork:         unitOfWork,
 	}
 }
 
@@ -30,6 +32,8 @@ func (uc *DeleteTransactionUseCase) Execute(ctx context.Context, transactionIDSt
 	transactionID := finance.NewTransactionID(transactionIDInt)
 	userIDDomain := finance.NewUserID(userID)
 
-	// Delete transaction
-	return uc.transactionService.DeleteTransaction(ctx, transactionID, userIDDomain)
+	// Delete transaction; the delete and its undo record share one transaction
+	return uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		return uc.transactionService.DeleteTransaction(ctx, transactionID, userIDDomain)
+	})
 }
@@ -0,0 +1,183 @@
+package finance
+
+import (
+	"context"
+	"math"
+	"panda-pocket/internal/domain/finance"
+	"sort"
+	"time"
+)
+
+// defaultEmergencyFundMonths is the spending history the fund is measured against by default
+const defaultEmergencyFundMonths = 6
+
+// EmergencyFundRequest represents the query for the emergency fund metric
+type EmergencyFundRequest struct {
+	// Months is how many complete months before the current one are averaged; defaults to 6
+	Months int `form:"months" binding:"omitempty,min=1,max=24"`
+}
+
+// EmergencyFundResponse represents how many months of spending the user's
+// emergency fund would cover, in their default currency
+type EmergencyFundResponse struct {
+	DefaultCurrency string              `json:"default_currency"`
+	Accounts        []EmergencyFundItem `json:"accounts"`
+	FundBalance     float64             `json:"fund_balance"`
+	Months          int                 `json:"months"`
+	StartDate       string              `json:"start_date"`
+	EndDate         string              `json:"end_date"`
+	// AverageMonthlySpend is the mean of the expenses over the complete months averaged
+	AverageMonthlySpend float64 `json:"average_monthly_spend"`
+	// MonthsCovered is unset when there was no spending to measure the fund against
+	MonthsCovered *float64 `json:"months_covered"`
+	// MissingRates lists the currency codes without any rate to the default
+	// currency; amounts in them are left out
+	MissingRates []string `json:"missing_rates"`
+}
+
+// EmergencyFundItem represents one of the accounts making up the emergency fund
+type EmergencyFundItem struct {
+	ID           int      `json:"id"`
+	Name         string   `json:"name"`
+	CurrencyCode string   `json:"currency_code"`
+	Balance      float64  `json:"balance"`
+	Value        *float64 `json:"value"`
+}
+
+// EmergencyFundUseCase handles the emergency fund dashboard metric
+type EmergencyFundUseCase struct {
+	accountService     *finance.AccountService
+	transactionService *finance.TransactionService
+	currencyService    *finance.CurrencyService
+	exchangeRateRepo   finance.ExchangeRateRepository
+}
+
+// NewEmergencyFundUseCase creates a new emergency fund use case
+func NewEmergencyFundUseCase(
+	accountService *finance.AccountService,
+	transactionService *finance.TransactionService,
+	currencyService *finance.CurrencyService,
+	exchangeRateRepo finance.ExchangeRateRepository,
+) *EmergencyFundUseCase {
+	return &EmergencyFundUseCase{
+		accountService:     accountService,
+		transactionService: transactionService,
+		currencyService:    currencyService,
+		exchangeRateRepo:   exchangeRateRepo,
+	}
+}
+
+// Execute divides the current balance of the user's open emergency fund accounts
+// by their average monthly spend over the complete months before the current one.
+// Amounts in other currencies are converted at the latest rate.
+func (uc *EmergencyFundUseCase) Execute(ctx context.Context, userID int, req EmergencyFundRequest) (*EmergencyFundResponse, error) {
+	months := req.Months
+	if months == 0 {
+		months = defaultEmergencyFundMonths
+	}
+
+	now := time.Now().UTC()
+	currentMonth := finance.MonthStart(now)
+	startDate := currentMonth.AddDate(0, -months, 0)
+	endDate := currentMonth.AddDate(0, 0, -1)
+
+	defaultCurrency, err := uc.currencyService.GetDefaultCurrency(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	currencies, err := uc.currencyService.GetCurrenciesByUser(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	codes := make(map[finance.CurrencyID]string)
+	for _, currency := range currencies {
+		codes[currency.ID()] = currency.Code()
+	}
+
+	response := &EmergencyFundResponse{
+		DefaultCurrency: defaultCurrency.Code(),
+		Accounts:        []EmergencyFundItem{},
+		Months:          months,
+		StartDate:       startDate.Format("2006-01-02"),
+		EndDate:         endDate.Format("2006-01-02"),
+		MissingRates:    []string{},
+	}
+	rates := make(map[string]float64)
+	convert := func(amount float64, currencyID finance.CurrencyID) (*float64, error) {
+		code := codes[currencyID]
+		if code == defaultCurrency.Code() {
+			return &amount, nil
+		}
+		rate, found := rates[code]
+		if !found {
+			history, err := ratesBetween(ctx, uc.exchangeRateRepo, code, defaultCurrency.Code())
+			if err != nil {
+				return nil, err
+			}
+			if rate, found = history.On(now); !found {
+				response.MissingRates = append(response.MissingRates, code)
+			}
+			rates[code] = rate
+		}
+		if rate == 0 {
+			return nil, nil
+		}
+		value := roundAmount(amount * rate)
+		return &value, nil
+	}
+
+	accounts, err := uc.accountService.GetAccountsByUser(ctx, finance.NewUserID(userID), false)
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range accounts {
+		if !account.EmergencyFund() {
+			continue
+		}
+		balance, err := uc.accountService.GetBalance(ctx, account, now)
+		if err != nil {
+			return nil, err
+		}
+		value, err := convert(balance, account.CurrencyID())
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			response.FundBalance += *value
+		}
+		response.Accounts = append(response.Accounts, EmergencyFundItem{
+			ID:           account.ID().Value(),
+			Name:         account.Name(),
+			CurrencyCode: codes[account.CurrencyID()],
+			Balance:      balance,
+			Value:        value,
+		})
+	}
+
+	transactions, err := uc.transactionService.GetTransactionsByUserAndDateRange(ctx, finance.NewUserID(userID), startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	var spent float64
+	for _, transaction := range transactions {
+		if transaction.Type() != finance.TransactionTypeExpense {
+			continue
+		}
+		value, err := convert(transaction.Amount().Amount(), transaction.CurrencyID())
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			spent += *value
+		}
+	}
+
+	sort.Strings(response.MissingRates)
+	response.FundBalance = roundAmount(response.FundBalance)
+	response.AverageMonthlySpend = roundAmount(spent / float64(months))
+	if response.AverageMonthlySpend > 0 {
+		covered := math.Round(response.FundBalance/response.AverageMonthlySpend*10) / 10
+		response.MonthsCovered = &covered
+	}
+	return response, nil
+}
@@ -0,0 +1,144 @@
+package finance
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"sort"
+	"time"
+)
+
+// FXGainLossResponse represents the unrealized exchange gain or loss on each
+// foreign currency the user holds, valued in their default currency
+type FXGainLossResponse struct {
+	DefaultCurrency     string               `json:"default_currency"`
+	AsOf                time.Time            `json:"as_of"`
+	Currencies          []FXPositionResponse `json:"currencies"`
+	TotalUnrealizedGain float64              `json:"total_unrealized_gain"`
+	// MissingRates lists the foreign currency codes held without any rate to the default currency
+	MissingRates []string `json:"missing_rates"`
+}
+
+// FXPositionResponse represents the position in one foreign currency. Amounts
+// other than net_position are in the default currency.
+type FXPositionResponse struct {
+	CurrencyID     int     `json:"currency_id"`
+	CurrencyCode   string  `json:"currency_code"`
+	NetPosition    float64 `json:"net_position"`
+	CostBasis      float64 `json:"cost_basis"`
+	CurrentRate    float64 `json:"current_rate"`
+	RateDate       string  `json:"rate_date"`
+	CurrentValue   float64 `json:"current_value"`
+	UnrealizedGain float64 `json:"unrealized_gain"`
+}
+
+// FXGainLossUseCase handles the report of unrealized exchange gains and losses
+type FXGainLossUseCase struct {
+	transactionService *finance.TransactionService
+	currencyService    *finance.CurrencyService
+	exchangeRateRepo   finance.ExchangeRateRepository
+}
+
+// NewFXGainLossUseCase creates a new FX gain/loss use case
+func NewFXGainLossUseCase(
+	transactionService *finance.TransactionService,
+	currencyService *finance.CurrencyService,
+	exchangeRateRepo finance.ExchangeRateRepository,
+) *FXGainLossUseCase {
+	return &FXGainLossUseCase{
+		transactionService: transactionService,
+		currencyService:    currencyService,
+		exchangeRateRepo:   exchangeRateRepo,
+	}
+}
+
+// Execute values the user's net position in every currency other than their
+// default one. The cost basis converts each transaction at the rate of its date;
+// the current value converts the whole position at the latest rate.
+func (uc *FXGainLossUseCase) Execute(ctx context.Context, userID int) (*FXGainLossResponse, error) {
+	defaultCurrency, err := uc.currencyService.GetDefaultCurrency(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	currencies, err := uc.currencyService.GetCurrenciesByUser(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	transactions, err := uc.transactionService.GetTransactionsByUser(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make(map[finance.CurrencyID]string)
+	for _, currency := range currencies {
+		codes[currency.ID()] = currency.Code()
+	}
+
+	byCurrency := make(map[finance.CurrencyID][]*finance.Transaction)
+	for _, transaction := range transactions {
+		code, found := codes[transaction.CurrencyID()]
+		if !found || code == defaultCurrency.Code() {
+			continue
+		}
+		byCurrency[transaction.CurrencyID()] = append(byCurrency[transaction.CurrencyID()], transaction)
+	}
+
+	response := &FXGainLossResponse{
+		DefaultCurrency: defaultCurrency.Code(),
+		AsOf:            time.Now().UTC(),
+		Currencies:      []FXPositionResponse{},
+		MissingRates:    []string{},
+	}
+	missing := make(map[string]bool)
+	for currencyID, held := range byCurrency {
+		code := codes[currencyID]
+		rates, err := ratesBetween(ctx, uc.exchangeRateRepo, code, defaultCurrency.Code())
+		if err != nil {
+			return nil, err
+		}
+
+		position, found := finance.NewFXPosition(currencyID, held, rates)
+		if !found {
+			if !missing[code] {
+				missing[code] = true
+				response.MissingRates = append(response.MissingRates, code)
+			}
+			continue
+		}
+
+		response.Currencies = append(response.Currencies, FXPositionResponse{
+			CurrencyID:     currencyID.Value(),
+			CurrencyCode:   code,
+			NetPosition:    position.NetPosition,
+			CostBasis:      position.CostBasis,
+			CurrentRate:    position.CurrentRate,
+			RateDate:       position.RateDate.Format("2006-01-02"),
+			CurrentValue:   position.CurrentValue,
+			UnrealizedGain: position.UnrealizedGain,
+		})
+		response.TotalUnrealizedGain += position.UnrealizedGain
+	}
+
+	sort.Slice(response.Currencies, func(i, j int) bool {
+		if response.Currencies[i].CurrencyCode != response.Currencies[j].CurrencyCode {
+			return response.Currencies[i].CurrencyCode < response.Currencies[j].CurrencyCode
+		}
+		return response.Currencies[i].CurrencyID < response.Currencies[j].CurrencyID
+	})
+	sort.Strings(response.MissingRates)
+	response.TotalUnrealizedGain = roundAmount(response.TotalUnrealizedGain)
+	return response, nil
+}
+
+// ratesBetween returns the rates converting from into to, using the inverse of
+// rates recorded the other way round where needed
+func ratesBetween(ctx context.Context, exchangeRateRepo finance.ExchangeRateRepository, from, to string) (finance.RateHistory, error) {
+	direct, err := exchangeRateRepo.FindByPair(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	inverse, err := exchangeRateRepo.FindByPair(ctx, to, from)
+	if err != nil {
+		return nil, err
+	}
+	return finance.NewRateHistory(direct, inverse), nil
+}
@@ -0,0 +1,142 @@
+package finance
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"time"
+)
+
+// GetAccountTransactionsRequest represents the optional period of an account statement
+type GetAccountTransactionsRequest struct {
+	StartDate string `form:"start_date"`
+	EndDate   string `form:"end_date"`
+}
+
+// adjustmentEntryType is the type of ledger entries that are balance adjustments
+// rather than transactions. Their amount is signed and they have no category.
+const adjustmentEntryType = "adjustment"
+
+// AccountTransactionResponse represents a transaction or balance adjustment with the account balance after it
+type AccountTransactionResponse struct {
+	TransactionResponse
+	RunningBalance float64 `json:"running_balance"`
+}
+
+// AccountTransactionsResponse represents an account's transactions with running balances
+type AccountTransactionsResponse struct {
+	AccountID      int                          `json:"account_id"`
+	CurrencyID     int                          `json:"currency_id"`
+	OpeningBalance float64                      `json:"opening_balance"`
+	ClosingBalance float64                      `json:"closing_balance"`
+	Transactions   []AccountTransactionResponse `json:"transactions"`
+}
+
+// GetAccountTransactionsUseCase handles listing an account's transactions like a statement
+type GetAccountTransactionsUseCase struct {
+	accountService  *finance.AccountService
+	categoryService *finance.CategoryService
+}
+
+// NewGetAccountTransactionsUseCase creates a new get account transactions use case
+func NewGetAccountTransactionsUseCase(accountService *finance.AccountService, categoryService *finance.CategoryService) *GetAccountTransactionsUseCase {
+	return &GetAccountTransactionsUseCase{
+		accountService:  accountService,
+		categoryService: categoryService,
+	}
+}
+
+// Execute returns the account's transactions and balance adjustments in the
+// period, oldest first, each with the running balance after it
+func (uc *GetAccountTransactionsUseCase) Execute(ctx context.Context, userID, accountID int, req GetAccountTransactionsRequest) (*AccountTransactionsResponse, error) {
+	var startDate, endDate *time.Time
+	if req.StartDate != "" {
+		date, err := time.Parse("2006-01-02", req.StartDate)
+		if err != nil {
+			return nil, finance.ErrInvalidStatementPeriod
+		}
+		startDate = &date
+	}
+	if req.EndDate != "" {
+		date, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			return nil, finance.ErrInvalidStatementPeriod
+		}
+		// Include the whole end day
+		date = date.Add(24*time.Hour - time.Nanosecond)
+		endDate = &date
+	}
+
+	account, err := uc.accountService.GetAccount(ctx, finance.NewAccountID(accountID), finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	ledger, err := uc.accountService.GetLedger(ctx, account.ID(), finance.NewUserID(userID), startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AccountTransactionsResponse{
+		AccountID:      accountID,
+		CurrencyID:     account.CurrencyID().Value(),
+		OpeningBalance: ledger.OpeningBalance,
+		ClosingBalance: ledger.ClosingBalance,
+		Transactions:   make([]AccountTransactionResponse, len(ledger.Entries)),
+	}
+	for i, entry := range ledger.Entries {
+		if adjustment := entry.Adjustment; adjustment != nil {
+			response.Transactions[i] = AccountTransactionResponse{
+				TransactionResponse: TransactionResponse{
+					ID:          adjustment.ID().Value(),
+					UserID:      adjustment.UserID().Value(),
+					CurrencyID:  account.CurrencyID().Value(),
+					AccountID:   accountID,
+					Amount:      adjustment.Amount(),
+					Description: adjustment.Description(),
+					Date:        adjustment.Date().Format("2006-01-02"),
+					Type:        adjustmentEntryType,
+					CreatedAt:   adjustment.CreatedAt().Format(time.RFC3339),
+				},
+				RunningBalance: entry.RunningBalance,
+			}
+			continue
+		}
+
+		transaction := entry.Transaction
+		category, err := uc.categoryService.GetCategoryByID(ctx, transaction.CategoryID())
+		if err != nil {
+			// If category not found, create a default response
+			category = &finance.Category{}
+		}
+
+		response.Transactions[i] = AccountTransactionResponse{
+			TransactionResponse: TransactionResponse{
+				ID:     transaction.ID().Value(),
+				UserID: transaction.UserID().Value(),
+				Category: CategoryResponse{
+					ID:        category.ID().Value(),
+					Name:      category.Name(),
+					Color:     category.Color(),
+					Type:      string(category.Type()),
+					IsDefault: category.IsDefault(),
+				},
+				CurrencyID:  transaction.CurrencyID().Value(),
+				AccountID:   transaction.AccountID().Value(),
+				Amount:      transaction.Amount().Amount(),
+				Description: transaction.Description(),
+				Date:        transaction.Date().Format("2006-01-02"),
+				Type:        string(transaction.Type()),
+				Status:      string(transaction.Status()),
+				Version:     transaction.Version(),
+				ClientID:    transaction.ClientID(),
+				CreatedAt:   transaction.CreatedAt().Format(time.RFC3339),
+
+				TaxDeductible:    transaction.TaxDeductible(),
+				ReceiptReference: transaction.ReceiptReference(),
+				Latitude:         latitudeOf(transaction),
+				Longitude:        longitudeOf(transaction),
+			},
+			RunningBalance: entry.RunningBalance,
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,61 @@
+package finance
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"time"
+)
+
+// ActionResponse represents an undoable change in the response
+type ActionResponse struct {
+	ID        int     `json:"id"`
+	Kind      string  `json:"kind"`
+	Target    string  `json:"target"`
+	TargetID  int     `json:"target_id"`
+	CreatedAt string  `json:"created_at"`
+	ExpiresAt string  `json:"expires_at"`
+	UndoneAt  *string `json:"undone_at,omitempty"`
+}
+
+// GetActionsUseCase handles listing the current user's recent changes that can still be undone
+type GetActionsUseCase struct {
+	actionService *finance.ActionService
+}
+
+// NewGetActionsUseCase creates a new get actions use case
+func NewGetActionsUseCase(actionService *finance.ActionService) *GetActionsUseCase {
+	return &GetActionsUseCase{
+		actionService: actionService,
+	}
+}
+
+// Execute returns the undoable actions, newest first
+func (uc *GetActionsUseCase) Execute(ctx context.Context, userID int) ([]ActionResponse, error) {
+	actions, err := uc.actionService.GetUndoableActions(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]ActionResponse, len(actions))
+	for i, action := range actions {
+		responses[i] = newActionResponse(action)
+	}
+	return responses, nil
+}
+
+// newActionResponse converts a domain action for the API
+func newActionResponse(action *finance.Action) ActionResponse {
+	response := ActionResponse{
+		ID:        action.ID().Value(),
+		Kind:      string(action.Kind()),
+		Target:    string(action.Target()),
+		TargetID:  action.TargetID(),
+		CreatedAt: action.CreatedAt().Format(time.RFC3339),
+		ExpiresAt: action.ExpiresAt().Format(time.RFC3339),
+	}
+	if action.UndoneAt() != nil {
+		undoneAt := action.UndoneAt().Format(time.RFC3339)
+		response.UndoneAt = &undoneAt
+	}
+	return response
+}
@@ -16,6 +16,10 @@ type GetAllTransactionsRequest struct {
 	EndDate     string   `json:"end_date,omitempty"`     // Date in YYYY-MM-DD format
 	Page        int      `json:"page,omitempty"`         // Page number (1-based)
 	Limit       int      `json:"limit,omitempty"`        // Number of items per page
+	// IncludeArchived also returns transactions moved to the archive
+	IncludeArchived bool `json:"include_archived,omitempty"`
+	// BBox keeps the transactions made inside a map area: min_lng,min_lat,max_lng,max_lat
+	BBox string `json:"bbox,omitempty"`
 }
 
 // GetAllTransactionsResponse represents the response for getting all transactions
@@ -81,16 +85,34 @@ func (uc *GetAllTransactionsUseCase) Execute(ctx context.Context, userID int, re
 		filters.CategoryIDs = categoryIDs
 	}
 
-	// Parse date range
+	// Parse date range; a malformed range is rejected rather than ignored, as
+	// ignoring it would quietly return transactions outside the period asked for
 	if req.StartDate != "" {
-		if startDate, err := time.Parse("2006-01-02", req.StartDate); err == nil {
-			filters.StartDate = &startDate
+		startDate, err := time.Parse("2006-01-02", req.StartDate)
+		if err != nil {
+			return nil, finance.ErrInvalidDateRange
 		}
+		filters.StartDate = &startDate
 	}
 	if req.EndDate != "" {
-		if endDate, err := time.Parse("2006-01-02", req.EndDate); err == nil {
-			filters.EndDate = &endDate
+		endDate, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			return nil, finance.ErrInvalidDateRange
+		}
+		filters.EndDate = &endDate
+	}
+	if filters.StartDate != nil && filters.EndDate != nil && filters.StartDate.After(*filters.EndDate) {
+		return nil, finance.ErrInvalidDateRange
+	}
+
+	// Parse map bounds; a malformed box is rejected too, as ignoring it would put
+	// every transaction on the map
+	if req.BBox != "" {
+		bounds, err := parseBoundingBox(req.BBox)
+		if err != nil {
+			return nil, err
 		}
+		filters.Bounds = &bounds
 	}
 
 	// Parse pagination parameters
@@ -111,6 +133,7 @@ func (uc *GetAllTransactionsUseCase) Execute(ctx context.Context, userID int, re
 	offset := (page - 1) * limit
 	filters.Limit = limit
 	filters.Offset = offset
+	filters.IncludeArchived = req.IncludeArchived
 
 	// Get transactions with filters
 	transactions, totalCount, err := uc.transactionService.GetTransactionsByUserWithFilters(ctx, finance.NewUserID(userID), filters)
@@ -139,11 +162,20 @@ func (uc *GetAllTransactionsUseCase) Execute(ctx context.Context, userID int, re
 				IsDefault: category.IsDefault(),
 			},
 			CurrencyID:  transaction.CurrencyID().Value(),
+			AccountID:   transaction.AccountID().Value(),
 			Amount:      transaction.Amount().Amount(),
 			Description: transaction.Description(),
 			Date:        transaction.Date().Format("2006-01-02"),
 			Type:        string(transaction.Type()),
+			Status:      string(transaction.Status()),
+			Version:     transaction.Version(),
+			ClientID:    transaction.ClientID(),
 			CreatedAt:   transaction.CreatedAt().Format(time.RFC3339),
+
+			TaxDeductible:    transaction.TaxDeductible(),
+			ReceiptReference: transaction.ReceiptReference(),
+			Latitude:         latitudeOf(transaction),
+			Longitude:        longitudeOf(transaction),
 		}
 	}
 
@@ -162,3 +194,20 @@ func (uc *GetAllTransactionsUseCase) Execute(ctx context.Context, userID int, re
 		Filters:      req,
 	}, nil
 }
+
+// parseBoundingBox reads a bounding box written as min_lng,min_lat,max_lng,max_lat
+func parseBoundingBox(value string) (finance.BoundingBox, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 4 {
+		return finance.BoundingBox{}, finance.ErrInvalidBoundingBox
+	}
+	var corners [4]float64
+	for i, part := range parts {
+		corner, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return finance.BoundingBox{}, finance.ErrInvalidBoundingBox
+		}
+		corners[i] = corner
+	}
+	return finance.NewBoundingBox(corners[0], corners[1], corners[2], corners[3])
+}
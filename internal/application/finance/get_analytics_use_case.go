@@ -3,6 +3,7 @@ package finance
 import (
 	"context"
 	"panda-pocket/internal/domain/finance"
+	"sort"
 	"time"
 )
 
@@ -18,17 +19,62 @@ type GetAnalyticsResponse struct {
 	NetAmount        float64 `json:"net_amount"`
 	Period           string  `json:"period"`
 	TransactionCount int     `json:"transaction_count"`
+	// ByCurrency totals the period in each currency used, without converting amounts
+	ByCurrency []CurrencyAnalytics `json:"by_currency"`
+	// ByCategory totals the period in each category and currency, largest first
+	ByCategory []CategoryAnalytics `json:"by_category"`
+	// Budgets shows the progress of the budgets overlapping the period
+	Budgets []BudgetAnalytics `json:"budgets"`
 }
 
-// GetAnalyticsUseCase handles getting analytics data
+// CurrencyAnalytics represents the totals of the transactions in one currency
+type CurrencyAnalytics struct {
+	CurrencyID       int     `json:"currency_id"`
+	CurrencyCode     string  `json:"currency_code"`
+	TotalIncome      float64 `json:"total_income"`
+	TotalSpent       float64 `json:"total_spent"`
+	NetAmount        float64 `json:"net_amount"`
+	TransactionCount int     `json:"transaction_count"`
+}
+
+// CategoryAnalytics represents the total of the transactions in one category and currency
+type CategoryAnalytics struct {
+	CategoryID       int     `json:"category_id"`
+	CategoryName     string  `json:"category_name"`
+	Type             string  `json:"type"`
+	CurrencyID       int     `json:"currency_id"`
+	CurrencyCode     string  `json:"currency_code"`
+	Total            float64 `json:"total"`
+	TransactionCount int     `json:"transaction_count"`
+}
+
+// BudgetAnalytics represents how much of a budget's allowance has been spent
+type BudgetAnalytics struct {
+	BudgetID       int     `json:"budget_id"`
+	CategoryID     int     `json:"category_id"`
+	CategoryName   string  `json:"category_name"`
+	StartDate      string  `json:"start_date"`
+	EndDate        string  `json:"end_date"`
+	Allowance      float64 `json:"allowance"`
+	Spent          float64 `json:"spent"`
+	Remaining      float64 `json:"remaining"`
+	PercentageUsed float64 `json:"percentage_used"`
+}
+
+// GetAnalyticsUseCase handles getting analytics data. It reads the analytics read
+// model, so transactions show up once the projector has caught up with them.
 type GetAnalyticsUseCase struct {
-	transactionService *finance.TransactionService
+	projector       *AnalyticsProjector
+	categoryService *finance.CategoryService
+	currencyService *finance.CurrencyService
 }
 
 // NewGetAnalyticsUseCase creates a new get analytics use case
-func NewGetAnalyticsUseCase(transactionService *finance.TransactionService) *GetAnalyticsUseCase {
+func NewGetAnalyticsUseCase(projector *AnalyticsProjector, categoryService *finance.CategoryService, currencyService *finance.CurrencyService) *GetAnalyticsUseCase {
 	return &GetAnalyticsUseCase{
-		transactionService: transactionService,
+		projector:       projector,
+		categoryService: categoryService,
+		currencyService: currencyService,
 	}
 }
 
@@ -57,31 +103,181 @@ func (uc *GetAnalyticsUseCase) Execute(ctx context.Context, userID int, req GetA
 		endDate = startDate.AddDate(0, 1, -1).Add(23*time.Hour + 59*time.Minute + 59*time.Second)
 	}
 
-	// Get transactions for the period
-	transactions, err := uc.transactionService.GetTransactionsByUserAndDateRange(ctx, finance.NewUserID(userID), startDate, endDate)
+	user := finance.NewUserID(userID)
+	dailyTotals, err := uc.projector.DailyTotals(ctx, user, startDate, endDate)
 	if err != nil {
 		return nil, err
 	}
 
 	// Calculate analytics
 	var totalIncome, totalSpent float64
-	transactionCount := len(transactions)
+	var transactionCount int
+	byCurrency := make(map[int]*CurrencyAnalytics)
+
+	for _, daily := range dailyTotals {
+		currencyID := daily.CurrencyID.Value()
+		totals := byCurrency[currencyID]
+		if totals == nil {
+			totals = &CurrencyAnalytics{CurrencyID: currencyID}
+			byCurrency[currencyID] = totals
+		}
+		totals.TransactionCount += daily.Count
+		transactionCount += daily.Count
 
-	for _, transaction := range transactions {
-		if transaction.Type() == finance.TransactionTypeIncome {
-			totalIncome += transaction.Amount().Amount()
-		} else if transaction.Type() == finance.TransactionTypeExpense {
-			totalSpent += transaction.Amount().Amount()
+		if daily.Type == finance.TransactionTypeIncome {
+			totalIncome += daily.Amount
+			totals.TotalIncome += daily.Amount
+		} else if daily.Type == finance.TransactionTypeExpense {
+			totalSpent += daily.Amount
+			totals.TotalSpent += daily.Amount
 		}
 	}
 
 	netAmount := totalIncome - totalSpent
 
+	codes, err := uc.currencyCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	names, err := uc.categoryNames(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	byCategory, err := uc.categoryTotals(ctx, user, startDate, endDate, names, codes)
+	if err != nil {
+		return nil, err
+	}
+	budgets, err := uc.budgetProgress(ctx, user, startDate, endDate, names)
+	if err != nil {
+		return nil, err
+	}
+
 	return &GetAnalyticsResponse{
-		TotalIncome:      totalIncome,
-		TotalSpent:       totalSpent,
-		NetAmount:        netAmount,
+		TotalIncome:      roundAmount(totalIncome),
+		TotalSpent:       roundAmount(totalSpent),
+		NetAmount:        roundAmount(netAmount),
 		Period:           req.Period,
 		TransactionCount: transactionCount,
+		ByCurrency:       currencyTotals(byCurrency, codes),
+		ByCategory:       byCategory,
+		Budgets:          budgets,
 	}, nil
 }
+
+// currencyCodes returns the code of each of the user's currencies by ID
+func (uc *GetAnalyticsUseCase) currencyCodes(ctx context.Context, userID int) (map[int]string, error) {
+	currencies, err := uc.currencyService.GetCurrenciesByUser(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	codes := make(map[int]string, len(currencies))
+	for _, currency := range currencies {
+		codes[currency.ID().Value()] = currency.Code()
+	}
+	return codes, nil
+}
+
+// categoryNames returns the name of each of the user's categories by ID
+func (uc *GetAnalyticsUseCase) categoryNames(ctx context.Context, userID int) (map[int]string, error) {
+	categories, err := uc.categoryService.GetCategoriesByUser(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int]string, len(categories))
+	for _, category := range categories {
+		names[category.ID().Value()] = category.Name()
+	}
+	return names, nil
+}
+
+// currencyTotals names the currencies of the per-currency totals and orders them by code
+func currencyTotals(byCurrency map[int]*CurrencyAnalytics, codes map[int]string) []CurrencyAnalytics {
+	totals := make([]CurrencyAnalytics, 0, len(byCurrency))
+	for currencyID, currencyTotal := range byCurrency {
+		currencyTotal.CurrencyCode = codes[currencyID]
+		currencyTotal.TotalIncome = roundAmount(currencyTotal.TotalIncome)
+		currencyTotal.TotalSpent = roundAmount(currencyTotal.TotalSpent)
+		currencyTotal.NetAmount = roundAmount(currencyTotal.TotalIncome - currencyTotal.TotalSpent)
+		totals = append(totals, *currencyTotal)
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].CurrencyCode != totals[j].CurrencyCode {
+			return totals[i].CurrencyCode < totals[j].CurrencyCode
+		}
+		return totals[i].CurrencyID < totals[j].CurrencyID
+	})
+	return totals
+}
+
+// categoryTotals adds up the daily category totals of the period per category,
+// type and currency, largest first
+func (uc *GetAnalyticsUseCase) categoryTotals(ctx context.Context, userID finance.UserID, startDate, endDate time.Time, names, codes map[int]string) ([]CategoryAnalytics, error) {
+	dailyTotals, err := uc.projector.CategoryTotals(ctx, userID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		categoryID int
+		currencyID int
+		kind       finance.TransactionType
+	}
+	byCategory := make(map[key]*CategoryAnalytics)
+	for _, daily := range dailyTotals {
+		k := key{daily.CategoryID.Value(), daily.CurrencyID.Value(), daily.Type}
+		totals := byCategory[k]
+		if totals == nil {
+			totals = &CategoryAnalytics{
+				CategoryID:   k.categoryID,
+				CategoryName: names[k.categoryID],
+				Type:         string(k.kind),
+				CurrencyID:   k.currencyID,
+				CurrencyCode: codes[k.currencyID],
+			}
+			byCategory[k] = totals
+		}
+		totals.Total += daily.Amount
+		totals.TransactionCount += daily.Count
+	}
+
+	totals := make([]CategoryAnalytics, 0, len(byCategory))
+	for _, categoryTotal := range byCategory {
+		categoryTotal.Total = roundAmount(categoryTotal.Total)
+		totals = append(totals, *categoryTotal)
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].Total != totals[j].Total {
+			return totals[i].Total > totals[j].Total
+		}
+		return totals[i].CategoryID < totals[j].CategoryID
+	})
+	return totals, nil
+}
+
+// budgetProgress reports on the budgets overlapping the period
+func (uc *GetAnalyticsUseCase) budgetProgress(ctx context.Context, userID finance.UserID, startDate, endDate time.Time, names map[int]string) ([]BudgetAnalytics, error) {
+	progress, err := uc.projector.BudgetProgress(ctx, userID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	budgets := make([]BudgetAnalytics, len(progress))
+	for i, budget := range progress {
+		var percentageUsed float64
+		if budget.Allowance > 0 {
+			percentageUsed = roundAmount(budget.Spent / budget.Allowance * 100)
+		}
+		budgets[i] = BudgetAnalytics{
+			BudgetID:       budget.BudgetID.Value(),
+			CategoryID:     budget.CategoryID.Value(),
+			CategoryName:   names[budget.CategoryID.Value()],
+			StartDate:      budget.StartDate.Format("2006-01-02"),
+			EndDate:        budget.EndDate.Format("2006-01-02"),
+			Allowance:      budget.Allowance,
+			Spent:          budget.Spent,
+			Remaining:      roundAmount(budget.Allowance - budget.Spent),
+			PercentageUsed: percentageUsed,
+		}
+	}
+	return budgets, nil
+}
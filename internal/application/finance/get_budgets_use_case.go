@@ -19,6 +19,8 @@ type BudgetResponse struct {
 	Period    string            `json:"period"`
 	StartDate string            `json:"start_date"`
 	EndDate   string            `json:"end_date"`
+	Prorated  bool              `json:"prorated"`
+	Version   int               `json:"version"` // sent back on update to detect conflicting edits
 	CreatedAt string            `json:"created_at"`
 	Category  *CategoryResponse `json:"category,omitempty"`
 	Report    *BudgetReport     `json:"report,omitempty"`
@@ -26,6 +28,8 @@ type BudgetResponse struct {
 
 // BudgetReport represents budget tracking information
 type BudgetReport struct {
+	// Allowance is the amount, prorated for budgets starting mid-period
+	Allowance      float64 `json:"allowance"`
 	IsOnTrack      bool    `json:"is_on_track"`
 	TotalSpent     float64 `json:"total_spent"`
 	Remaining      float64 `json:"remaining"`
@@ -85,6 +89,8 @@ func (uc *GetBudgetsUseCase) Execute(ctx context.Context, userID int) (*GetBudge
 			Period:    string(budget.Period()),
 			StartDate: budget.StartDate().Format("2006-01-02"),
 			EndDate:   budget.EndDate().Format("2006-01-02"),
+			Prorated:  budget.Prorated(),
+			Version:   budget.Version(),
 			CreatedAt: budget.CreatedAt().Format(time.RFC3339),
 			Category:  categoryResponse,
 			Report:    report,
@@ -118,13 +124,14 @@ func (uc *GetBudgetsUseCase) calculateBudgetReport(ctx context.Context, budget *
 		}
 	}
 
-	// Calculate report metrics
-	budgetAmount := budget.Amount().Amount()
-	remaining := budgetAmount - totalSpent
-	percentageUsed := (totalSpent / budgetAmount) * 100
-	isOnTrack := totalSpent <= budgetAmount
+	// Calculate report metrics against the allowance, which is prorated for partial periods
+	allowance := budget.Allowance()
+	remaining := allowance - totalSpent
+	percentageUsed := (totalSpent / allowance) * 100
+	isOnTrack := totalSpent <= allowance
 
 	return &BudgetReport{
+		Allowance:      allowance,
 		IsOnTrack:      isOnTrack,
 		TotalSpent:     totalSpent,
 		Remaining:      remaining,
@@ -3,7 +3,9 @@ package finance
 import (
 	"context"
 	"errors"
+	"math"
 	"panda-pocket/internal/domain/finance"
+	"time"
 )
 
 // GetCategoriesResponse represents the response for getting categories
@@ -18,22 +20,47 @@ type CategoryResponse struct {
 	Color     string `json:"color"`
 	Type      string `json:"type"`
 	IsDefault bool   `json:"is_default"`
+	// TranslationKey identifies default categories regardless of the language of Name
+	TranslationKey string `json:"translation_key,omitempty"`
+	// SourceCategoryID is the default category a user's category was copied from
+	SourceCategoryID *int `json:"source_category_id,omitempty"`
+	// TaxDeductible is only reported when listing categories
+	TaxDeductible bool `json:"tax_deductible,omitempty"`
+	// Stats is only reported when listing categories with include=stats
+	Stats *CategoryStatsResponse `json:"stats,omitempty"`
+}
+
+// CategoryStatsResponse represents how much a category is used. CurrentMonthTotal
+// sums the amounts of the transactions dated in the current month (UTC) as
+// recorded, without converting between currencies.
+type CategoryStatsResponse struct {
+	TransactionCount  int     `json:"transaction_count"`
+	CurrentMonthTotal float64 `json:"current_month_total"`
 }
 
 // GetCategoriesUseCase handles getting categories for a user
 type GetCategoriesUseCase struct {
-	categoryService *finance.CategoryService
+	categoryService    *finance.CategoryService
+	taxService         *finance.TaxService
+	transactionService *finance.TransactionService
 }
 
 // NewGetCategoriesUseCase creates a new get categories use case
-func NewGetCategoriesUseCase(categoryService *finance.CategoryService) *GetCategoriesUseCase {
+func NewGetCategoriesUseCase(
+	categoryService *finance.CategoryService,
+	taxService *finance.TaxService,
+	transactionService *finance.TransactionService,
+) *GetCategoriesUseCase {
 	return &GetCategoriesUseCase{
-		categoryService: categoryService,
+		categoryService:    categoryService,
+		taxService:         taxService,
+		transactionService: transactionService,
 	}
 }
 
-// Execute executes the get categories use case
-func (uc *GetCategoriesUseCase) Execute(ctx context.Context, userID int, categoryType string) (*GetCategoriesResponse, error) {
+// Execute executes the get categories use case. With includeStats every category
+// also reports its transaction count and current month total.
+func (uc *GetCategoriesUseCase) Execute(ctx context.Context, userID int, categoryType string, includeStats bool) (*GetCategoriesResponse, error) {
 	var categories []*finance.Category
 	var err error
 
@@ -59,6 +86,19 @@ func (uc *GetCategoriesUseCase) Execute(ctx context.Context, userID int, categor
 		return nil, err
 	}
 
+	deductible, err := uc.taxService.GetDeductibleCategoryIDs(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	var stats map[finance.CategoryID]finance.CategoryStats
+	if includeStats {
+		stats, err = uc.transactionService.GetCategoryStats(ctx, finance.NewUserID(userID), time.Now().UTC())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Convert to response format
 	categoryResponses := make([]CategoryResponse, len(categories))
 	for i, category := range categories {
@@ -68,6 +108,20 @@ func (uc *GetCategoriesUseCase) Execute(ctx context.Context, userID int, categor
 			Color:     category.Color(),
 			Type:      string(category.Type()),
 			IsDefault: category.IsDefault(),
+
+			TranslationKey: category.TranslationKey(),
+			TaxDeductible:  deductible[category.ID().Value()],
+		}
+		if includeStats {
+			categoryStats := stats[category.ID()]
+			categoryResponses[i].Stats = &CategoryStatsResponse{
+				TransactionCount:  categoryStats.TransactionCount,
+				CurrentMonthTotal: math.Round(categoryStats.MonthTotal*100) / 100,
+			}
+		}
+		if source := category.SourceID(); source != nil {
+			sourceID := source.Value()
+			categoryResponses[i].SourceCategoryID = &sourceID
 		}
 	}
 
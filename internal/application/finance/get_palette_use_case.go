@@ -0,0 +1,36 @@
+package finance
+
+import "panda-pocket/internal/domain/finance"
+
+// PaletteColorResponse represents a recommended category color
+type PaletteColorResponse struct {
+	Name string `json:"name"`
+	Hex  string `json:"hex"`
+}
+
+// PaletteResponse represents the recommended category colors
+type PaletteResponse struct {
+	Colors       []PaletteColorResponse `json:"colors"`
+	DefaultColor string                 `json:"default_color"`
+}
+
+// GetPaletteUseCase handles getting the colors recommended for categories
+type GetPaletteUseCase struct{}
+
+// NewGetPaletteUseCase creates a new get palette use case
+func NewGetPaletteUseCase() *GetPaletteUseCase {
+	return &GetPaletteUseCase{}
+}
+
+// Execute returns the recommended colors, in the order clients should offer them
+func (uc *GetPaletteUseCase) Execute() *PaletteResponse {
+	palette := finance.Palette()
+	response := &PaletteResponse{
+		Colors:       make([]PaletteColorResponse, len(palette)),
+		DefaultColor: finance.DefaultCategoryColor,
+	}
+	for i, color := range palette {
+		response.Colors[i] = PaletteColorResponse{Name: color.Name, Hex: color.Hex}
+	}
+	return response
+}
@@ -54,11 +54,20 @@ func (uc *GetTransactionsUseCase) Execute(ctx context.Context, userID int) (*Get
 				IsDefault: category.IsDefault(),
 			},
 			CurrencyID:  transaction.CurrencyID().Value(),
+			AccountID:   transaction.AccountID().Value(),
 			Amount:      transaction.Amount().Amount(),
 			Description: transaction.Description(),
 			Date:        transaction.Date().Format("2006-01-02"),
 			Type:        string(transaction.Type()),
+			Status:      string(transaction.Status()),
+			Version:     transaction.Version(),
+			ClientID:    transaction.ClientID(),
 			CreatedAt:   transaction.CreatedAt().Format(time.RFC3339),
+
+			TaxDeductible:    transaction.TaxDeductible(),
+			ReceiptReference: transaction.ReceiptReference(),
+			Latitude:         latitudeOf(transaction),
+			Longitude:        longitudeOf(transaction),
 		}
 	}
 
@@ -0,0 +1,151 @@
+package finance
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"time"
+)
+
+// CreateAccountRequest represents the request to create an account
+type CreateAccountRequest struct {
+	Name       string `json:"name" binding:"required"`
+	Type       string `json:"type" binding:"required"`
+	CurrencyID int    `json:"currency_id"` // defaults to the user's primary currency
+	// OpeningBalance is the balance before the first transaction recorded on the account
+	OpeningBalance float64 `json:"opening_balance"`
+}
+
+// ListAccountsRequest represents the filters for listing accounts
+type ListAccountsRequest struct {
+	IncludeArchived bool `form:"include_archived"`
+}
+
+// SetEmergencyFundRequest represents whether an account makes up the user's emergency fund
+type SetEmergencyFundRequest struct {
+	EmergencyFund *bool `json:"emergency_fund" binding:"required"`
+}
+
+// AccountResponse represents an account in the response
+type AccountResponse struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	CurrencyID int    `json:"currency_id"`
+	CreatedAt  string `json:"created_at"`
+	// BillingCycle is set for credit cards with statement cycles
+	BillingCycle *BillingCycleResponse `json:"billing_cycle,omitempty"`
+	// OpeningBalance is the balance before the account's first transaction
+	OpeningBalance float64 `json:"opening_balance"`
+	// ArchivedAt is set for archived accounts
+	ArchivedAt *string `json:"archived_at,omitempty"`
+	// EmergencyFund is set on accounts that make up the user's emergency fund
+	EmergencyFund bool `json:"emergency_fund"`
+}
+
+// ManageAccountsUseCase handles creating, listing and archiving the accounts transactions are recorded against
+type ManageAccountsUseCase struct {
+	accountService  *finance.AccountService
+	currencyService *finance.CurrencyService
+}
+
+// NewManageAccountsUseCase creates a new manage accounts use case
+func NewManageAccountsUseCase(accountService *finance.AccountService, currencyService *finance.CurrencyService) *ManageAccountsUseCase {
+	return &ManageAccountsUseCase{
+		accountService:  accountService,
+		currencyService: currencyService,
+	}
+}
+
+// Create creates an account for the user
+func (uc *ManageAccountsUseCase) Create(ctx context.Context, userID int, req CreateAccountRequest) (*AccountResponse, error) {
+	currencyID := finance.NewCurrencyID(req.CurrencyID)
+	if req.CurrencyID == 0 {
+		primaryCurrency, err := uc.currencyService.GetPrimaryCurrency(ctx, finance.NewUserID(userID))
+		if err != nil {
+			return nil, err
+		}
+		currencyID = primaryCurrency.ID()
+	}
+
+	account, err := uc.accountService.CreateAccount(
+		ctx,
+		finance.NewUserID(userID),
+		req.Name,
+		finance.AccountType(req.Type),
+		currencyID,
+		req.OpeningBalance,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response := newAccountResponse(account)
+	return &response, nil
+}
+
+// List returns the user's accounts. Archived accounts are left out, as account
+// pickers should not offer them, unless the request includes them.
+func (uc *ManageAccountsUseCase) List(ctx context.Context, userID int, req ListAccountsRequest) ([]AccountResponse, error) {
+	accounts, err := uc.accountService.GetAccountsByUser(ctx, finance.NewUserID(userID), req.IncludeArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]AccountResponse, len(accounts))
+	for i, account := range accounts {
+		responses[i] = newAccountResponse(account)
+	}
+	return responses, nil
+}
+
+// Archive closes one of the user's accounts. Its transactions are kept and still
+// count in analytics and reports; it just takes no new ones.
+func (uc *ManageAccountsUseCase) Archive(ctx context.Context, userID, accountID int) (*AccountResponse, error) {
+	return uc.setArchived(ctx, userID, accountID, true)
+}
+
+// Unarchive reopens one of the user's archived accounts
+func (uc *ManageAccountsUseCase) Unarchive(ctx context.Context, userID, accountID int) (*AccountResponse, error) {
+	return uc.setArchived(ctx, userID, accountID, false)
+}
+
+// SetEmergencyFund adds one of the user's accounts to or removes it from their emergency fund
+func (uc *ManageAccountsUseCase) SetEmergencyFund(ctx context.Context, userID, accountID int, req SetEmergencyFundRequest) (*AccountResponse, error) {
+	account, err := uc.accountService.SetEmergencyFund(ctx, finance.NewAccountID(accountID), finance.NewUserID(userID), *req.EmergencyFund)
+	if err != nil {
+		return nil, err
+	}
+
+	response := newAccountResponse(account)
+	return &response, nil
+}
+
+func (uc *ManageAccountsUseCase) setArchived(ctx context.Context, userID, accountID int, archived bool) (*AccountResponse, error) {
+	account, err := uc.accountService.SetArchived(ctx, finance.NewAccountID(accountID), finance.NewUserID(userID), archived)
+	if err != nil {
+		return nil, err
+	}
+
+	response := newAccountResponse(account)
+	return &response, nil
+}
+
+// newAccountResponse converts a domain account for the API
+func newAccountResponse(account *finance.Account) AccountResponse {
+	response := AccountResponse{
+		ID:         account.ID().Value(),
+		Name:       account.Name(),
+		Type:       string(account.Type()),
+		CurrencyID: account.CurrencyID().Value(),
+		CreatedAt:  account.CreatedAt().Format(time.RFC3339),
+
+		BillingCycle:   newBillingCycleResponse(account.BillingCycle()),
+		OpeningBalance: account.OpeningBalance(),
+		EmergencyFund:  account.EmergencyFund(),
+	}
+	if archivedAt := account.ArchivedAt(); archivedAt != nil {
+		formatted := archivedAt.Format(time.RFC3339)
+		response.ArchivedAt = &formatted
+	}
+	return response
+}
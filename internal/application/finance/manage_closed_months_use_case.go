@@ -0,0 +1,81 @@
+package finance
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"time"
+)
+
+// CloseMonthRequest represents the month to close, written as YYYY-MM
+type CloseMonthRequest struct {
+	Month string `json:"month" binding:"required"`
+}
+
+// ClosedMonthResponse represents a closed month
+type ClosedMonthResponse struct {
+	Month    string    `json:"month"`
+	ClosedAt time.Time `json:"closed_at"`
+}
+
+// ManageClosedMonthsUseCase handles closing months so their transactions stay
+// as they are, and reopening them
+type ManageClosedMonthsUseCase struct {
+	closedMonthRepo finance.ClosedMonthRepository
+}
+
+// NewManageClosedMonthsUseCase creates a new manage closed months use case
+func NewManageClosedMonthsUseCase(closedMonthRepo finance.ClosedMonthRepository) *ManageClosedMonthsUseCase {
+	return &ManageClosedMonthsUseCase{
+		closedMonthRepo: closedMonthRepo,
+	}
+}
+
+// List returns the user's closed months, latest first
+func (uc *ManageClosedMonthsUseCase) List(ctx context.Context, userID int) ([]ClosedMonthResponse, error) {
+	months, err := uc.closedMonthRepo.FindByUserID(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]ClosedMonthResponse, len(months))
+	for i, month := range months {
+		responses[i] = toClosedMonthResponse(month)
+	}
+	return responses, nil
+}
+
+// Close closes a month that has ended
+func (uc *ManageClosedMonthsUseCase) Close(ctx context.Context, userID int, req CloseMonthRequest) (*ClosedMonthResponse, error) {
+	date, err := finance.ParseMonth(req.Month)
+	if err != nil {
+		return nil, err
+	}
+
+	month, err := finance.NewClosedMonth(finance.NewUserID(userID), date, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.closedMonthRepo.Save(ctx, month); err != nil {
+		return nil, err
+	}
+
+	response := toClosedMonthResponse(month)
+	return &response, nil
+}
+
+// Reopen lets the transactions of a closed month change again
+func (uc *ManageClosedMonthsUseCase) Reopen(ctx context.Context, userID int, month string) error {
+	date, err := finance.ParseMonth(month)
+	if err != nil {
+		return err
+	}
+	return uc.closedMonthRepo.Delete(ctx, finance.NewUserID(userID), date)
+}
+
+// toClosedMonthResponse converts a domain closed month to a response
+func toClosedMonthResponse(month *finance.ClosedMonth) ClosedMonthResponse {
+	return ClosedMonthResponse{
+		Month:    month.Month().Format("2006-01"),
+		ClosedAt: month.ClosedAt(),
+	}
+}
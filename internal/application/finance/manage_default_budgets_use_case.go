@@ -0,0 +1,154 @@
+package finance
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"time"
+)
+
+// DefaultBudgetRequest represents one entry of the default budget template
+type DefaultBudgetRequest struct {
+	CategoryID int     `json:"category_id" binding:"required"`
+	Amount     float64 `json:"amount" binding:"required,gt=0"`
+	Period     string  `json:"period" binding:"required,oneof=weekly monthly yearly"`
+}
+
+// ReplaceDefaultBudgetsRequest represents the whole default budget template; an
+// empty list clears it
+type ReplaceDefaultBudgetsRequest struct {
+	Budgets []DefaultBudgetRequest `json:"budgets" binding:"max=50,dive"`
+}
+
+// DefaultBudgetResponse represents one entry of the default budget template
+type DefaultBudgetResponse struct {
+	CategoryID int               `json:"category_id"`
+	Category   *CategoryResponse `json:"category"`
+	Amount     float64           `json:"amount"`
+	Period     string            `json:"period"`
+}
+
+// ManageDefaultBudgetsUseCase handles the template of budgets admins define for
+// new users, and creating those budgets when a user registers
+type ManageDefaultBudgetsUseCase struct {
+	defaultBudgetRepo finance.DefaultBudgetRepository
+	budgetService     *finance.BudgetService
+	categoryService   *finance.CategoryService
+	currencyService   *finance.CurrencyService
+	unitOfWork        finance.UnitOfWork
+}
+
+// NewManageDefaultBudgetsUseCase creates a new manage default budgets use case
+func NewManageDefaultBudgetsUseCase(
+	defaultBudgetRepo finance.DefaultBudgetRepository,
+	budgetService *finance.BudgetService,
+	categoryService *finance.CategoryService,
+	currencyService *finance.CurrencyService,
+	unitOfWork finance.UnitOfWork,
+) *ManageDefaultBudgetsUseCase {
+	return &ManageDefaultBudgetsUseCase{
+		defaultBudgetRepo: defaultBudgetRepo,
+		budgetService:     budgetService,
+		categoryService:   categoryService,
+		currencyService:   currencyService,
+		unitOfWork:        unitOfWork,
+	}
+}
+
+// List returns the template, in the order it was saved
+func (uc *ManageDefaultBudgetsUseCase) List(ctx context.Context) ([]DefaultBudgetResponse, error) {
+	budgets, err := uc.defaultBudgetRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return uc.toResponses(ctx, budgets), nil
+}
+
+// Replace replaces the whole template. Every entry must use a different default
+// expense category.
+func (uc *ManageDefaultBudgetsUseCase) Replace(ctx context.Context, req ReplaceDefaultBudgetsRequest) ([]DefaultBudgetResponse, error) {
+	budgets := make([]*finance.DefaultBudget, 0, len(req.Budgets))
+	seen := make(map[int]bool, len(req.Budgets))
+	for _, entry := range req.Budgets {
+		category, err := uc.categoryService.GetCategoryByID(ctx, finance.NewCategoryID(entry.CategoryID))
+		if err != nil || !category.IsDefault() || category.Type() != finance.CategoryTypeExpense || seen[entry.CategoryID] {
+			return nil, finance.ErrInvalidDefaultBudget
+		}
+		seen[entry.CategoryID] = true
+
+		budget, err := finance.NewDefaultBudget(category.ID(), entry.Amount, finance.BudgetPeriod(entry.Period))
+		if err != nil {
+			return nil, err
+		}
+		budgets = append(budgets, budget)
+	}
+
+	if err := uc.defaultBudgetRepo.ReplaceAll(ctx, budgets); err != nil {
+		return nil, err
+	}
+	return uc.toResponses(ctx, budgets), nil
+}
+
+// Apply creates the template's budgets for a user, in their primary currency and
+// prorated from today. Either all of them are created or none.
+func (uc *ManageDefaultBudgetsUseCase) Apply(ctx context.Context, userID int) error {
+	budgets, err := uc.defaultBudgetRepo.FindAll(ctx)
+	if err != nil || len(budgets) == 0 {
+		return err
+	}
+
+	currency, err := uc.currencyService.GetPrimaryCurrency(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	startDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		for _, entry := range budgets {
+			money, err := finance.NewMoney(entry.Amount(), currency.ID())
+			if err != nil {
+				return err
+			}
+			money, err = money.In(currency)
+			if err != nil {
+				return err
+			}
+
+			_, err = uc.budgetService.CreateBudget(
+				ctx,
+				finance.NewUserID(userID),
+				entry.CategoryID(),
+				money,
+				entry.Period(),
+				startDate,
+				true,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// toResponses converts template entries to responses
+func (uc *ManageDefaultBudgetsUseCase) toResponses(ctx context.Context, budgets []*finance.DefaultBudget) []DefaultBudgetResponse {
+	responses := make([]DefaultBudgetResponse, len(budgets))
+	for i, budget := range budgets {
+		responses[i] = DefaultBudgetResponse{
+			CategoryID: budget.CategoryID().Value(),
+			Amount:     budget.Amount(),
+			Period:     string(budget.Period()),
+		}
+		if category, err := uc.categoryService.GetCategoryByID(ctx, budget.CategoryID()); err == nil {
+			responses[i].Category = &CategoryResponse{
+				ID:        category.ID().Value(),
+				Name:      category.Name(),
+				Color:     category.Color(),
+				Type:      string(category.Type()),
+				IsDefault: category.IsDefault(),
+			}
+		}
+	}
+	return responses
+}
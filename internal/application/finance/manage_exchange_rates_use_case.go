@@ -0,0 +1,87 @@
+package finance
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"time"
+)
+
+// SetExchangeRateRequest represents a rate to record. Rate is how many units of
+// Quote one unit of Base bought on Date, written as YYYY-MM-DD.
+type SetExchangeRateRequest struct {
+	Base  string  `json:"base" binding:"required"`
+	Quote string  `json:"quote" binding:"required"`
+	Rate  float64 `json:"rate" binding:"required"`
+	Date  string  `json:"date" binding:"required"`
+}
+
+// ExchangeRateResponse represents a recorded exchange rate
+type ExchangeRateResponse struct {
+	ID    int     `json:"id"`
+	Base  string  `json:"base"`
+	Quote string  `json:"quote"`
+	Rate  float64 `json:"rate"`
+	Date  string  `json:"date"`
+}
+
+// ManageExchangeRatesUseCase handles recording and listing the exchange rates
+// the FX gain/loss report values foreign currencies with
+type ManageExchangeRatesUseCase struct {
+	exchangeRateRepo finance.ExchangeRateRepository
+}
+
+// NewManageExchangeRatesUseCase creates a new manage exchange rates use case
+func NewManageExchangeRatesUseCase(exchangeRateRepo finance.ExchangeRateRepository) *ManageExchangeRatesUseCase {
+	return &ManageExchangeRatesUseCase{
+		exchangeRateRepo: exchangeRateRepo,
+	}
+}
+
+// Set records the rate of a currency pair on a date, replacing any rate already recorded for it
+func (uc *ManageExchangeRatesUseCase) Set(ctx context.Context, req SetExchangeRateRequest) (*ExchangeRateResponse, error) {
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, finance.ErrInvalidRateDate
+	}
+
+	rate, err := finance.NewExchangeRate(req.Base, req.Quote, req.Rate, date)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.exchangeRateRepo.Save(ctx, rate); err != nil {
+		return nil, err
+	}
+
+	response := toExchangeRateResponse(rate)
+	return &response, nil
+}
+
+// List returns the rates recorded for a currency pair, oldest first
+func (uc *ManageExchangeRatesUseCase) List(ctx context.Context, base, quote string) ([]ExchangeRateResponse, error) {
+	pair, err := finance.NewExchangeRate(base, quote, 1, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := uc.exchangeRateRepo.FindByPair(ctx, pair.Base(), pair.Quote())
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]ExchangeRateResponse, len(rates))
+	for i, rate := range rates {
+		responses[i] = toExchangeRateResponse(rate)
+	}
+	return responses, nil
+}
+
+// toExchangeRateResponse converts a domain exchange rate to a response
+func toExchangeRateResponse(rate *finance.ExchangeRate) ExchangeRateResponse {
+	return ExchangeRateResponse{
+		ID:    rate.ID().Value(),
+		Base:  rate.Base(),
+		Quote: rate.Quote(),
+		Rate:  rate.Rate(),
+		Date:  rate.Date().Format("2006-01-02"),
+	}
+}
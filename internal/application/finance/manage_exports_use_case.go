@@ -0,0 +1,140 @@
+package finance
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"time"
+)
+
+// exportHistoryLimit is the number of export runs the history endpoint returns
+const exportHistoryLimit = 50
+
+// CreateExportScheduleRequest represents the request to schedule a transaction export
+type CreateExportScheduleRequest struct {
+	Format      string `json:"format" binding:"required"`
+	Provider    string `json:"provider" binding:"required"`
+	Target      string `json:"target" binding:"required"`
+	AccessToken string `json:"access_token"`
+	Frequency   string `json:"frequency" binding:"required"`
+}
+
+// ExportScheduleResponse represents an export schedule in the response; the
+// access token is never returned
+type ExportScheduleResponse struct {
+	ID        int    `json:"id"`
+	Format    string `json:"format"`
+	Provider  string `json:"provider"`
+	Target    string `json:"target"`
+	Frequency string `json:"frequency"`
+	NextRunAt string `json:"next_run_at"`
+	CreatedAt string `json:"created_at"`
+}
+
+// ExportRunResponse represents one export in the history
+type ExportRunResponse struct {
+	ID               int    `json:"id"`
+	ScheduleID       int    `json:"schedule_id"`
+	Provider         string `json:"provider"`
+	FileName         string `json:"file_name"`
+	TransactionCount int    `json:"transaction_count"`
+	Status           string `json:"status"`
+	Error            string `json:"error,omitempty"`
+	CreatedAt        string `json:"created_at"`
+}
+
+// ManageExportsUseCase handles the current user's export schedules and export history
+type ManageExportsUseCase struct {
+	scheduleRepo finance.ExportScheduleRepository
+	runRepo      finance.ExportRunRepository
+}
+
+// NewManageExportsUseCase creates a new manage exports use case
+func NewManageExportsUseCase(scheduleRepo finance.ExportScheduleRepository, runRepo finance.ExportRunRepository) *ManageExportsUseCase {
+	return &ManageExportsUseCase{
+		scheduleRepo: scheduleRepo,
+		runRepo:      runRepo,
+	}
+}
+
+// CreateSchedule schedules an export; the first one runs on the next job tick
+func (uc *ManageExportsUseCase) CreateSchedule(ctx context.Context, userID int, req CreateExportScheduleRequest) (*ExportScheduleResponse, error) {
+	schedule, err := finance.NewExportSchedule(
+		finance.NewUserID(userID),
+		finance.ExportFormat(req.Format),
+		finance.StorageProvider(req.Provider),
+		req.Target,
+		req.AccessToken,
+		finance.Frequency(req.Frequency),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.scheduleRepo.Save(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	response := newExportScheduleResponse(schedule)
+	return &response, nil
+}
+
+// ListSchedules returns the user's export schedules
+func (uc *ManageExportsUseCase) ListSchedules(ctx context.Context, userID int) ([]ExportScheduleResponse, error) {
+	schedules, err := uc.scheduleRepo.FindByUserID(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]ExportScheduleResponse, len(schedules))
+	for i, schedule := range schedules {
+		responses[i] = newExportScheduleResponse(schedule)
+	}
+	return responses, nil
+}
+
+// DeleteSchedule deletes one of the user's export schedules; its history is kept
+func (uc *ManageExportsUseCase) DeleteSchedule(ctx context.Context, userID, scheduleID int) error {
+	schedule, err := uc.scheduleRepo.FindByID(ctx, finance.NewExportScheduleID(scheduleID))
+	if err != nil {
+		return err
+	}
+	if schedule.UserID().Value() != userID {
+		return finance.ErrExportScheduleNotFound
+	}
+	return uc.scheduleRepo.Delete(ctx, schedule.ID())
+}
+
+// ListRuns returns the user's most recent exports, newest first
+func (uc *ManageExportsUseCase) ListRuns(ctx context.Context, userID int) ([]ExportRunResponse, error) {
+	runs, err := uc.runRepo.FindByUserID(ctx, finance.NewUserID(userID), exportHistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]ExportRunResponse, len(runs))
+	for i, run := range runs {
+		responses[i] = ExportRunResponse{
+			ID:               run.ID().Value(),
+			ScheduleID:       run.ScheduleID().Value(),
+			Provider:         string(run.Provider()),
+			FileName:         run.FileName(),
+			TransactionCount: run.TransactionCount(),
+			Status:           string(run.Status()),
+			Error:            run.ErrorMessage(),
+			CreatedAt:        run.CreatedAt().Format(time.RFC3339),
+		}
+	}
+	return responses, nil
+}
+
+// newExportScheduleResponse converts a domain export schedule for the API
+func newExportScheduleResponse(schedule *finance.ExportSchedule) ExportScheduleResponse {
+	return ExportScheduleResponse{
+		ID:        schedule.ID().Value(),
+		Format:    string(schedule.Format()),
+		Provider:  string(schedule.Provider()),
+		Target:    schedule.Target(),
+		Frequency: string(schedule.Frequency()),
+		NextRunAt: schedule.NextRunAt().Format(time.RFC3339),
+		CreatedAt: schedule.CreatedAt().Format(time.RFC3339),
+	}
+}
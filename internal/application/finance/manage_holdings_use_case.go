@@ -0,0 +1,208 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"panda-pocket/internal/domain/finance"
+	"time"
+)
+
+// CreateHoldingRequest represents the request to record an investment holding
+type CreateHoldingRequest struct {
+	Ticker     string   `json:"ticker" binding:"required"`
+	Quantity   float64  `json:"quantity" binding:"required"`
+	CostBasis  *float64 `json:"cost_basis" binding:"required"` // what the whole position cost
+	CurrencyID int      `json:"currency_id"`                   // defaults to the user's primary currency
+}
+
+// UpdateHoldingRequest represents the request to change a holding
+type UpdateHoldingRequest struct {
+	Ticker    string   `json:"ticker" binding:"required"`
+	Quantity  float64  `json:"quantity" binding:"required"`
+	CostBasis *float64 `json:"cost_basis" binding:"required"`
+}
+
+// RecordHoldingPriceRequest represents the price of a holding's security on a day
+type RecordHoldingPriceRequest struct {
+	Price float64 `json:"price" binding:"required"`
+	Date  string  `json:"date"` // YYYY-MM-DD; defaults to today
+}
+
+// HoldingResponse represents a holding with its latest value
+type HoldingResponse struct {
+	ID         int     `json:"id"`
+	Ticker     string  `json:"ticker"`
+	Quantity   float64 `json:"quantity"`
+	CostBasis  float64 `json:"cost_basis"`
+	CurrencyID int     `json:"currency_id"`
+	CreatedAt  string  `json:"created_at"`
+	// The latest price and value; unset until a price is recorded
+	Price          *float64 `json:"price,omitempty"`
+	PriceDate      *string  `json:"price_date,omitempty"`
+	Value          *float64 `json:"value,omitempty"`
+	UnrealizedGain *float64 `json:"unrealized_gain,omitempty"`
+}
+
+// HoldingSnapshotResponse represents a holding's price and value on a day
+type HoldingSnapshotResponse struct {
+	Date  string  `json:"date"`
+	Price float64 `json:"price"`
+	Value float64 `json:"value"`
+}
+
+// ManageHoldingsUseCase handles recording investment holdings and their prices
+type ManageHoldingsUseCase struct {
+	investmentService *finance.InvestmentService
+	currencyService   *finance.CurrencyService
+}
+
+// NewManageHoldingsUseCase creates a new manage holdings use case
+func NewManageHoldingsUseCase(investmentService *finance.InvestmentService, currencyService *finance.CurrencyService) *ManageHoldingsUseCase {
+	return &ManageHoldingsUseCase{
+		investmentService: investmentService,
+		currencyService:   currencyService,
+	}
+}
+
+// Create records a holding for the user
+func (uc *ManageHoldingsUseCase) Create(ctx context.Context, userID int, req CreateHoldingRequest) (*HoldingResponse, error) {
+	currencyID := finance.NewCurrencyID(req.CurrencyID)
+	if req.CurrencyID == 0 {
+		primaryCurrency, err := uc.currencyService.GetPrimaryCurrency(ctx, finance.NewUserID(userID))
+		if err != nil {
+			return nil, err
+		}
+		currencyID = primaryCurrency.ID()
+	}
+
+	holding, err := uc.investmentService.CreateHolding(
+		ctx,
+		finance.NewUserID(userID),
+		req.Ticker,
+		req.Quantity,
+		*req.CostBasis,
+		currencyID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return uc.respond(ctx, holding)
+}
+
+// List returns the user's holdings, each with its latest value
+func (uc *ManageHoldingsUseCase) List(ctx context.Context, userID int) ([]HoldingResponse, error) {
+	holdings, err := uc.investmentService.GetHoldings(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]HoldingResponse, len(holdings))
+	for i, holding := range holdings {
+		response, err := uc.respond(ctx, holding)
+		if err != nil {
+			return nil, err
+		}
+		responses[i] = *response
+	}
+	return responses, nil
+}
+
+// Update changes one of the user's holdings
+func (uc *ManageHoldingsUseCase) Update(ctx context.Context, userID, holdingID int, req UpdateHoldingRequest) (*HoldingResponse, error) {
+	holding, err := uc.investmentService.UpdateHolding(
+		ctx,
+		finance.NewHoldingID(holdingID),
+		finance.NewUserID(userID),
+		req.Ticker,
+		req.Quantity,
+		*req.CostBasis,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return uc.respond(ctx, holding)
+}
+
+// Delete removes one of the user's holdings with its price history
+func (uc *ManageHoldingsUseCase) Delete(ctx context.Context, userID, holdingID int) error {
+	return uc.investmentService.DeleteHolding(ctx, finance.NewHoldingID(holdingID), finance.NewUserID(userID))
+}
+
+// RecordPrice values one of the user's holdings at a price they looked up,
+// replacing any price already recorded for that day
+func (uc *ManageHoldingsUseCase) RecordPrice(ctx context.Context, userID, holdingID int, req RecordHoldingPriceRequest) (*HoldingSnapshotResponse, error) {
+	date := time.Now()
+	if req.Date != "" {
+		parsed, err := time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			return nil, errors.New("invalid date format. Expected YYYY-MM-DD")
+		}
+		date = parsed
+	}
+
+	holding, err := uc.investmentService.GetHolding(ctx, finance.NewHoldingID(holdingID), finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := uc.investmentService.RecordPrice(ctx, holding, req.Price, date)
+	if err != nil {
+		return nil, err
+	}
+
+	response := newHoldingSnapshotResponse(snapshot)
+	return &response, nil
+}
+
+// ListPrices returns one of the user's holdings' values over time, oldest first
+func (uc *ManageHoldingsUseCase) ListPrices(ctx context.Context, userID, holdingID int) ([]HoldingSnapshotResponse, error) {
+	holding, err := uc.investmentService.GetHolding(ctx, finance.NewHoldingID(holdingID), finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := uc.investmentService.GetSnapshots(ctx, holding)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]HoldingSnapshotResponse, len(snapshots))
+	for i, snapshot := range snapshots {
+		responses[i] = newHoldingSnapshotResponse(snapshot)
+	}
+	return responses, nil
+}
+
+// respond converts a holding for the API with its latest value
+func (uc *ManageHoldingsUseCase) respond(ctx context.Context, holding *finance.Holding) (*HoldingResponse, error) {
+	latest, err := uc.investmentService.ValueOn(ctx, holding, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &HoldingResponse{
+		ID:         holding.ID().Value(),
+		Ticker:     holding.Ticker(),
+		Quantity:   holding.Quantity(),
+		CostBasis:  holding.CostBasis(),
+		CurrencyID: holding.CurrencyID().Value(),
+		CreatedAt:  holding.CreatedAt().Format(time.RFC3339),
+	}
+	if latest != nil {
+		price, value := latest.Price(), latest.Value()
+		date := latest.Date().Format("2006-01-02")
+		gain := roundAmount(value - holding.CostBasis())
+		response.Price = &price
+		response.PriceDate = &date
+		response.Value = &value
+		response.UnrealizedGain = &gain
+	}
+	return response, nil
+}
+
+// newHoldingSnapshotResponse converts a domain holding snapshot for the API
+func newHoldingSnapshotResponse(snapshot *finance.HoldingSnapshot) HoldingSnapshotResponse {
+	return HoldingSnapshotResponse{
+		Date:  snapshot.Date().Format("2006-01-02"),
+		Price: snapshot.Price(),
+		Value: snapshot.Value(),
+	}
+}
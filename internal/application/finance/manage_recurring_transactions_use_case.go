@@ -0,0 +1,329 @@
+package finance
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"time"
+)
+
+const (
+	// occurrenceLimit caps how many occurrences one preview returns, a year of daily postings
+	occurrenceLimit = 366
+	// maxOccurrenceYears is how far ahead occurrences can be projected
+	maxOccurrenceYears = 5
+)
+
+// RecurringOccurrencesRequest represents the query of an occurrence preview
+type RecurringOccurrencesRequest struct {
+	// Until is the last date to project, YYYY-MM-DD; defaults to a year from today
+	Until string `form:"until"`
+}
+
+// MakeRecurringRequest represents the request to turn a transaction into a recurring one
+type MakeRecurringRequest struct {
+	// Type picks between an expense and an income with the same ID; expenses are checked first when omitted
+	Type      string `json:"type" binding:"omitempty,oneof=expense income"`
+	Frequency string `json:"frequency" binding:"required"`
+	// NextDueDate defaults to the first date after today on the transaction's schedule
+	NextDueDate          *string `json:"next_due_date"`
+	EndDate              *string `json:"end_date"`
+	OccurrencesRemaining *int    `json:"occurrences_remaining" binding:"omitempty,gt=0"`
+}
+
+// OverrideOccurrenceRequest represents a one-time change to the next occurrence;
+// omitted fields keep their scheduled value
+type OverrideOccurrenceRequest struct {
+	Amount *float64 `json:"amount" binding:"omitempty,gt=0"`
+	Date   *string  `json:"date"`
+}
+
+// UpdateEndConditionsRequest represents when a recurring transaction stops; an
+// omitted field removes that condition
+type UpdateEndConditionsRequest struct {
+	EndDate              *string `json:"end_date"`
+	OccurrencesRemaining *int    `json:"occurrences_remaining" binding:"omitempty,gt=0"`
+}
+
+// OccurrenceResponse represents one projected posting in the response
+type OccurrenceResponse struct {
+	Date       string  `json:"date"`
+	Amount     float64 `json:"amount"`
+	CurrencyID int     `json:"currency_id"`
+	// Overridden is set on an occurrence changed one time
+	Overridden bool `json:"overridden,omitempty"`
+}
+
+// RecurringTransactionResponse represents a recurring transaction in the response
+type RecurringTransactionResponse struct {
+	ID             int                `json:"id"`
+	CategoryID     int                `json:"category_id"`
+	CurrencyID     int                `json:"currency_id"`
+	Amount         float64            `json:"amount"`
+	Description    string             `json:"description"`
+	Frequency      string             `json:"frequency"`
+	NextDueDate    string             `json:"next_due_date"`
+	IsActive       bool               `json:"is_active"`
+	NextOccurrence OccurrenceResponse `json:"next_occurrence"`
+	// EndDate and OccurrencesRemaining are null when the transaction recurs indefinitely
+	EndDate              *string `json:"end_date"`
+	OccurrencesRemaining *int    `json:"occurrences_remaining"`
+	CreatedAt            string  `json:"created_at"`
+}
+
+// RecurringOccurrencesResponse represents the projected postings of a recurring transaction
+type RecurringOccurrencesResponse struct {
+	RecurringTransactionID int                  `json:"recurring_transaction_id"`
+	Until                  string               `json:"until"`
+	Occurrences            []OccurrenceResponse `json:"occurrences"`
+	// Truncated is set when more occurrences fall before until than were returned
+	Truncated bool `json:"truncated"`
+}
+
+// ManageRecurringTransactionsUseCase handles the current user's recurring transactions
+type ManageRecurringTransactionsUseCase struct {
+	transactionService *finance.TransactionService
+	recurringRepo      finance.RecurringTransactionRepository
+}
+
+// NewManageRecurringTransactionsUseCase creates a new manage recurring transactions use case
+func NewManageRecurringTransactionsUseCase(transactionService *finance.TransactionService, recurringRepo finance.RecurringTransactionRepository) *ManageRecurringTransactionsUseCase {
+	return &ManageRecurringTransactionsUseCase{
+		transactionService: transactionService,
+		recurringRepo:      recurringRepo,
+	}
+}
+
+// MakeRecurring creates a recurring transaction with the amount, category, currency
+// and description of one of the user's transactions
+func (uc *ManageRecurringTransactionsUseCase) MakeRecurring(ctx context.Context, userID, transactionID int, req MakeRecurringRequest) (*RecurringTransactionResponse, error) {
+	transaction, err := uc.transactionService.GetTransaction(ctx, finance.NewTransactionID(transactionID), finance.NewUserID(userID), finance.TransactionType(req.Type))
+	if err != nil {
+		return nil, err
+	}
+
+	frequency := finance.Frequency(req.Frequency)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	nextDueDate := finance.FirstDueDateAfter(transaction.Date().UTC().Truncate(24*time.Hour), frequency, today)
+	if req.NextDueDate != nil {
+		parsed, err := time.Parse("2006-01-02", *req.NextDueDate)
+		if err != nil {
+			return nil, finance.ErrInvalidDueDate
+		}
+		nextDueDate = parsed
+	}
+	var endDate *time.Time
+	if req.EndDate != nil {
+		parsed, err := time.Parse("2006-01-02", *req.EndDate)
+		if err != nil {
+			return nil, finance.ErrInvalidEndDate
+		}
+		endDate = &parsed
+	}
+
+	recurring, err := finance.NewRecurringTransaction(
+		finance.NewRecurringTransactionID(0),
+		transaction.UserID(),
+		transaction.CategoryID(),
+		transaction.CurrencyID(),
+		transaction.Amount(),
+		transaction.Description(),
+		frequency,
+		nextDueDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if req.NextDueDate == nil {
+		// Keep to the transaction's day when the first due date fell in a shorter month
+		recurring.AssignAnchorDay(transaction.Date().UTC().Day())
+	}
+	if err := recurring.UpdateEndConditions(endDate, req.OccurrencesRemaining); err != nil {
+		return nil, err
+	}
+	if err := uc.recurringRepo.Save(ctx, recurring); err != nil {
+		return nil, err
+	}
+
+	response := newRecurringTransactionResponse(recurring)
+	return &response, nil
+}
+
+// Occurrences projects the upcoming postings of one of the user's recurring
+// transactions, so clients can show them on a calendar before they post
+func (uc *ManageRecurringTransactionsUseCase) Occurrences(ctx context.Context, userID, recurringID int, req RecurringOccurrencesRequest) (*RecurringOccurrencesResponse, error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	until := today.AddDate(1, 0, 0)
+	if req.Until != "" {
+		parsed, err := time.Parse("2006-01-02", req.Until)
+		if err != nil || parsed.After(today.AddDate(maxOccurrenceYears, 0, 0)) {
+			return nil, finance.ErrInvalidOccurrenceRange
+		}
+		until = parsed
+	}
+
+	recurring, err := uc.findOwned(ctx, userID, recurringID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The end of the day is included, whatever time the due dates carry
+	projected := recurring.Occurrences(until.Add(24*time.Hour-time.Nanosecond), occurrenceLimit+1)
+	response := &RecurringOccurrencesResponse{
+		RecurringTransactionID: recurring.ID().Value(),
+		Until:                  until.Format("2006-01-02"),
+		Occurrences:            []OccurrenceResponse{},
+	}
+	if len(projected) > occurrenceLimit {
+		projected = projected[:occurrenceLimit]
+		response.Truncated = true
+	}
+	for i, occurrence := range projected {
+		response.Occurrences = append(response.Occurrences, newOccurrenceResponse(occurrence, i == 0 && isOverridden(recurring)))
+	}
+	return response, nil
+}
+
+// SkipNextOccurrence skips the next posting of one of the user's recurring transactions
+func (uc *ManageRecurringTransactionsUseCase) SkipNextOccurrence(ctx context.Context, userID, recurringID int) (*RecurringTransactionResponse, error) {
+	recurring, err := uc.findOwned(ctx, userID, recurringID)
+	if err != nil {
+		return nil, err
+	}
+	if err := recurring.SkipNextOccurrence(); err != nil {
+		return nil, err
+	}
+	if err := uc.recurringRepo.Save(ctx, recurring); err != nil {
+		return nil, err
+	}
+
+	response := newRecurringTransactionResponse(recurring)
+	return &response, nil
+}
+
+// OverrideNextOccurrence changes the amount or date of the next posting of one of
+// the user's recurring transactions, leaving the later ones as scheduled
+func (uc *ManageRecurringTransactionsUseCase) OverrideNextOccurrence(ctx context.Context, userID, recurringID int, req OverrideOccurrenceRequest) (*RecurringTransactionResponse, error) {
+	recurring, err := uc.findOwned(ctx, userID, recurringID)
+	if err != nil {
+		return nil, err
+	}
+
+	var amount *finance.Money
+	if req.Amount != nil {
+		money, err := finance.NewMoney(*req.Amount, recurring.CurrencyID())
+		if err != nil {
+			return nil, err
+		}
+		amount = &money
+	}
+	var date *time.Time
+	if req.Date != nil {
+		parsed, err := time.Parse("2006-01-02", *req.Date)
+		if err != nil {
+			return nil, finance.ErrInvalidOccurrenceOverride
+		}
+		date = &parsed
+	}
+
+	if err := recurring.OverrideNextOccurrence(amount, date); err != nil {
+		return nil, err
+	}
+	if err := uc.recurringRepo.Save(ctx, recurring); err != nil {
+		return nil, err
+	}
+
+	response := newRecurringTransactionResponse(recurring)
+	return &response, nil
+}
+
+// ClearNextOccurrenceOverride restores the scheduled amount and date of the next
+// posting of one of the user's recurring transactions
+func (uc *ManageRecurringTransactionsUseCase) ClearNextOccurrenceOverride(ctx context.Context, userID, recurringID int) (*RecurringTransactionResponse, error) {
+	recurring, err := uc.findOwned(ctx, userID, recurringID)
+	if err != nil {
+		return nil, err
+	}
+	recurring.ClearNextOccurrenceOverride()
+	if err := uc.recurringRepo.Save(ctx, recurring); err != nil {
+		return nil, err
+	}
+
+	response := newRecurringTransactionResponse(recurring)
+	return &response, nil
+}
+
+// findOwned loads a recurring transaction, reporting another user's as not found
+func (uc *ManageRecurringTransactionsUseCase) findOwned(ctx context.Context, userID, recurringID int) (*finance.RecurringTransaction, error) {
+	recurring, err := uc.recurringRepo.FindByID(ctx, finance.NewRecurringTransactionID(recurringID))
+	if err != nil {
+		return nil, err
+	}
+	if recurring.UserID().Value() != userID {
+		return nil, finance.ErrRecurringTransactionNotFound
+	}
+	return recurring, nil
+}
+
+// UpdateEndConditions sets when one of the user's recurring transactions stops posting
+func (uc *ManageRecurringTransactionsUseCase) UpdateEndConditions(ctx context.Context, userID, recurringID int, req UpdateEndConditionsRequest) (*RecurringTransactionResponse, error) {
+	recurring, err := uc.findOwned(ctx, userID, recurringID)
+	if err != nil {
+		return nil, err
+	}
+
+	var endDate *time.Time
+	if req.EndDate != nil {
+		parsed, err := time.Parse("2006-01-02", *req.EndDate)
+		if err != nil {
+			return nil, finance.ErrInvalidEndDate
+		}
+		endDate = &parsed
+	}
+
+	if err := recurring.UpdateEndConditions(endDate, req.OccurrencesRemaining); err != nil {
+		return nil, err
+	}
+	if err := uc.recurringRepo.Save(ctx, recurring); err != nil {
+		return nil, err
+	}
+
+	response := newRecurringTransactionResponse(recurring)
+	return &response, nil
+}
+
+// isOverridden reports whether the next occurrence has one-time changes
+func isOverridden(recurring *finance.RecurringTransaction) bool {
+	return recurring.NextAmountOverride() != nil || recurring.NextDateOverride() != nil
+}
+
+// newOccurrenceResponse converts a projected posting to its response
+func newOccurrenceResponse(occurrence finance.Occurrence, overridden bool) OccurrenceResponse {
+	return OccurrenceResponse{
+		Date:       occurrence.Date.Format("2006-01-02"),
+		Amount:     occurrence.Amount.Amount(),
+		CurrencyID: occurrence.Amount.Currency().Value(),
+		Overridden: overridden,
+	}
+}
+
+// newRecurringTransactionResponse converts a recurring transaction to its response
+func newRecurringTransactionResponse(recurring *finance.RecurringTransaction) RecurringTransactionResponse {
+	response := RecurringTransactionResponse{
+		ID:                   recurring.ID().Value(),
+		CategoryID:           recurring.CategoryID().Value(),
+		CurrencyID:           recurring.CurrencyID().Value(),
+		Amount:               recurring.Amount().Amount(),
+		Description:          recurring.Description(),
+		Frequency:            string(recurring.Frequency()),
+		NextDueDate:          recurring.NextDueDate().Format("2006-01-02"),
+		IsActive:             recurring.IsActive(),
+		NextOccurrence:       newOccurrenceResponse(recurring.NextOccurrence(), isOverridden(recurring)),
+		OccurrencesRemaining: recurring.OccurrencesRemaining(),
+		CreatedAt:            recurring.CreatedAt().Format(time.RFC3339),
+	}
+	if endDate := recurring.EndDate(); endDate != nil {
+		formatted := endDate.Format("2006-01-02")
+		response.EndDate = &formatted
+	}
+	return response
+}
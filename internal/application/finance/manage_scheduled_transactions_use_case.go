@@ -0,0 +1,126 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"panda-pocket/internal/domain/finance"
+	"time"
+)
+
+// ScheduleTransactionRequest represents the request to schedule a transaction for a later date
+type ScheduleTransactionRequest struct {
+	Type        string  `json:"type" binding:"required,oneof=expense income"`
+	CategoryID  int     `json:"category_id" binding:"required"`
+	Amount      float64 `json:"amount" binding:"required,gt=0"`
+	Description string  `json:"description"`
+	// Date is the day it posts, after today
+	Date string `json:"date" binding:"required"`
+}
+
+// ScheduledTransactionResponse represents a scheduled transaction in the response
+type ScheduledTransactionResponse struct {
+	ID          int     `json:"id"`
+	Type        string  `json:"type"`
+	CategoryID  int     `json:"category_id"`
+	CurrencyID  int     `json:"currency_id"`
+	Amount      float64 `json:"amount"`
+	Description string  `json:"description"`
+	Date        string  `json:"date"`
+	Status      string  `json:"status"`
+	// TransactionID is the expense or income it posted as; null until it posts
+	TransactionID *int   `json:"transaction_id"`
+	CreatedAt     string `json:"created_at"`
+}
+
+// ManageScheduledTransactionsUseCase handles the current user's scheduled transactions
+type ManageScheduledTransactionsUseCase struct {
+	transactionService *finance.TransactionService
+	currencyService    *finance.CurrencyService
+}
+
+// NewManageScheduledTransactionsUseCase creates a new manage scheduled transactions use case
+func NewManageScheduledTransactionsUseCase(transactionService *finance.TransactionService, currencyService *finance.CurrencyService) *ManageScheduledTransactionsUseCase {
+	return &ManageScheduledTransactionsUseCase{
+		transactionService: transactionService,
+		currencyService:    currencyService,
+	}
+}
+
+// Schedule records a transaction in the user's primary currency to post on a date after today
+func (uc *ManageScheduledTransactionsUseCase) Schedule(ctx context.Context, userID int, req ScheduleTransactionRequest) (*ScheduledTransactionResponse, error) {
+	date, err := parseTransactionDate(req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	primaryCurrency, err := uc.currencyService.GetPrimaryCurrency(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, errors.New("failed to get primary currency")
+	}
+	money, err := finance.NewMoney(req.Amount, primaryCurrency.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled, err := uc.transactionService.ScheduleTransaction(
+		ctx,
+		finance.NewUserID(userID),
+		finance.NewCategoryID(req.CategoryID),
+		primaryCurrency.ID(),
+		money,
+		req.Description,
+		date,
+		finance.TransactionType(req.Type),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response := newScheduledTransactionResponse(scheduled)
+	return &response, nil
+}
+
+// List returns the user's scheduled transactions, soonest first, including the
+// ones that already posted or were cancelled
+func (uc *ManageScheduledTransactionsUseCase) List(ctx context.Context, userID int) ([]ScheduledTransactionResponse, error) {
+	scheduled, err := uc.transactionService.GetScheduledTransactions(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]ScheduledTransactionResponse, len(scheduled))
+	for i, transaction := range scheduled {
+		responses[i] = newScheduledTransactionResponse(transaction)
+	}
+	return responses, nil
+}
+
+// Cancel stops one of the user's scheduled transactions from posting
+func (uc *ManageScheduledTransactionsUseCase) Cancel(ctx context.Context, userID, scheduledID int) (*ScheduledTransactionResponse, error) {
+	scheduled, err := uc.transactionService.CancelScheduledTransaction(ctx, finance.NewScheduledTransactionID(scheduledID), finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	response := newScheduledTransactionResponse(scheduled)
+	return &response, nil
+}
+
+// newScheduledTransactionResponse converts a scheduled transaction to its response
+func newScheduledTransactionResponse(scheduled *finance.ScheduledTransaction) ScheduledTransactionResponse {
+	response := ScheduledTransactionResponse{
+		ID:          scheduled.ID().Value(),
+		Type:        string(scheduled.Type()),
+		CategoryID:  scheduled.CategoryID().Value(),
+		CurrencyID:  scheduled.CurrencyID().Value(),
+		Amount:      scheduled.Amount().Amount(),
+		Description: scheduled.Description(),
+		Date:        scheduled.Date().Format("2006-01-02"),
+		Status:      string(scheduled.Status()),
+		CreatedAt:   scheduled.CreatedAt().Format(time.RFC3339),
+	}
+	if transactionID := scheduled.TransactionID().Value(); transactionID != 0 {
+		response.TransactionID = &transactionID
+	}
+	return response
+}
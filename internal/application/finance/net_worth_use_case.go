@@ -0,0 +1,210 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"panda-pocket/internal/domain/finance"
+	"sort"
+	"time"
+)
+
+// NetWorthRequest represents the optional day net worth is reported for
+type NetWorthRequest struct {
+	Date string `form:"date"` // YYYY-MM-DD; defaults to today
+}
+
+// NetWorthResponse represents everything the user owns less everything they owe,
+// valued in their default currency
+type NetWorthResponse struct {
+	DefaultCurrency  string                `json:"default_currency"`
+	Date             string                `json:"date"`
+	Accounts         []NetWorthAccountItem `json:"accounts"`
+	Holdings         []NetWorthHoldingItem `json:"holdings"`
+	TotalAssets      float64               `json:"total_assets"`
+	TotalLiabilities float64               `json:"total_liabilities"`
+	NetWorth         float64               `json:"net_worth"`
+	// MissingRates lists the currency codes held without any rate to the default
+	// currency; their items are listed but left out of the totals
+	MissingRates []string `json:"missing_rates"`
+}
+
+// NetWorthAccountItem represents an account's balance. Value is the balance in
+// the default currency; it is unset when there is no rate to convert it.
+type NetWorthAccountItem struct {
+	ID           int      `json:"id"`
+	Name         string   `json:"name"`
+	Type         string   `json:"type"`
+	CurrencyCode string   `json:"currency_code"`
+	Balance      float64  `json:"balance"`
+	Value        *float64 `json:"value"`
+}
+
+// NetWorthHoldingItem represents a holding's market value. Holdings without a
+// price by the date are valued at their cost basis and have priced unset.
+type NetWorthHoldingItem struct {
+	ID           int      `json:"id"`
+	Ticker       string   `json:"ticker"`
+	CurrencyCode string   `json:"currency_code"`
+	MarketValue  float64  `json:"market_value"`
+	PriceDate    *string  `json:"price_date,omitempty"`
+	Priced       bool     `json:"priced"`
+	Value        *float64 `json:"value"`
+}
+
+// NetWorthUseCase handles the net worth report
+type NetWorthUseCase struct {
+	accountService    *finance.AccountService
+	investmentService *finance.InvestmentService
+	currencyService   *finance.CurrencyService
+	exchangeRateRepo  finance.ExchangeRateRepository
+}
+
+// NewNetWorthUseCase creates a new net worth use case
+func NewNetWorthUseCase(
+	accountService *finance.AccountService,
+	investmentService *finance.InvestmentService,
+	currencyService *finance.CurrencyService,
+	exchangeRateRepo finance.ExchangeRateRepository,
+) *NetWorthUseCase {
+	return &NetWorthUseCase{
+		accountService:    accountService,
+		investmentService: investmentService,
+		currencyService:   currencyService,
+		exchangeRateRepo:  exchangeRateRepo,
+	}
+}
+
+// Execute values the user's accounts, archived ones included, at their balance
+// at the end of the day and their holdings at the latest price recorded by then.
+// Foreign currency amounts are converted at the rate on that day. Positive values
+// are assets and negative ones, such as credit card debt, liabilities.
+func (uc *NetWorthUseCase) Execute(ctx context.Context, userID int, req NetWorthRequest) (*NetWorthResponse, error) {
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+	if req.Date != "" {
+		parsed, err := time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			return nil, errors.New("invalid date format. Expected YYYY-MM-DD")
+		}
+		date = parsed
+	}
+	endOfDay := date.Add(24*time.Hour - time.Nanosecond)
+
+	defaultCurrency, err := uc.currencyService.GetDefaultCurrency(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	currencies, err := uc.currencyService.GetCurrenciesByUser(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	codes := make(map[finance.CurrencyID]string)
+	for _, currency := range currencies {
+		codes[currency.ID()] = currency.Code()
+	}
+
+	response := &NetWorthResponse{
+		DefaultCurrency: defaultCurrency.Code(),
+		Date:            date.Format("2006-01-02"),
+		Accounts:        []NetWorthAccountItem{},
+		Holdings:        []NetWorthHoldingItem{},
+		MissingRates:    []string{},
+	}
+	rates := make(map[string]finance.RateHistory)
+	convert := func(amount float64, currencyID finance.CurrencyID) (*float64, error) {
+		code := codes[currencyID]
+		if code == defaultCurrency.Code() {
+			value := roundAmount(amount)
+			return &value, nil
+		}
+		history, found := rates[code]
+		if !found {
+			var err error
+			if history, err = ratesBetween(ctx, uc.exchangeRateRepo, code, defaultCurrency.Code()); err != nil {
+				return nil, err
+			}
+			rates[code] = history
+			if len(history) == 0 {
+				response.MissingRates = append(response.MissingRates, code)
+			}
+		}
+		rate, found := history.On(endOfDay)
+		if !found {
+			return nil, nil
+		}
+		value := roundAmount(amount * rate)
+		return &value, nil
+	}
+	tally := func(value *float64) {
+		switch {
+		case value == nil:
+		case *value >= 0:
+			response.TotalAssets += *value
+		default:
+			response.TotalLiabilities -= *value
+		}
+	}
+
+	accounts, err := uc.accountService.GetAccountsByUser(ctx, finance.NewUserID(userID), true)
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range accounts {
+		if account.CreatedAt().After(endOfDay) {
+			continue
+		}
+		balance, err := uc.accountService.GetBalance(ctx, account, endOfDay)
+		if err != nil {
+			return nil, err
+		}
+		value, err := convert(balance, account.CurrencyID())
+		if err != nil {
+			return nil, err
+		}
+		tally(value)
+		response.Accounts = append(response.Accounts, NetWorthAccountItem{
+			ID:           account.ID().Value(),
+			Name:         account.Name(),
+			Type:         string(account.Type()),
+			CurrencyCode: codes[account.CurrencyID()],
+			Balance:      balance,
+			Value:        value,
+		})
+	}
+
+	holdings, err := uc.investmentService.GetHoldings(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	for _, holding := range holdings {
+		if holding.CreatedAt().After(endOfDay) {
+			continue
+		}
+		item := NetWorthHoldingItem{
+			ID:           holding.ID().Value(),
+			Ticker:       holding.Ticker(),
+			CurrencyCode: codes[holding.CurrencyID()],
+			MarketValue:  holding.CostBasis(),
+		}
+		snapshot, err := uc.investmentService.ValueOn(ctx, holding, endOfDay)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot != nil {
+			priceDate := snapshot.Date().Format("2006-01-02")
+			item.MarketValue = snapshot.Value()
+			item.PriceDate = &priceDate
+			item.Priced = true
+		}
+		if item.Value, err = convert(item.MarketValue, holding.CurrencyID()); err != nil {
+			return nil, err
+		}
+		tally(item.Value)
+		response.Holdings = append(response.Holdings, item)
+	}
+
+	sort.Strings(response.MissingRates)
+	response.TotalAssets = roundAmount(response.TotalAssets)
+	response.TotalLiabilities = roundAmount(response.TotalLiabilities)
+	response.NetWorth = roundAmount(response.TotalAssets - response.TotalLiabilities)
+	return response, nil
+}
@@ -0,0 +1,172 @@
+package finance
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	domainIdentity "panda-pocket/internal/domain/identity"
+	"time"
+)
+
+// OnboardingBudgetRequest represents a budget to start with
+type OnboardingBudgetRequest struct {
+	Amount float64 `json:"amount" binding:"required,gt=0"`
+	Period string  `json:"period" binding:"required,oneof=weekly monthly yearly"`
+}
+
+// OnboardingCategoryRequest represents a starter category the user picked,
+// optionally with a budget for it
+type OnboardingCategoryRequest struct {
+	Name   string                   `json:"name" binding:"required"`
+	Color  string                   `json:"color"`
+	Type   string                   `json:"type" binding:"required,oneof=expense income"`
+	Budget *OnboardingBudgetRequest `json:"budget"`
+}
+
+// OnboardingCategoryBudgetRequest represents a budget to start with for a category
+// that already exists, such as a default one
+type OnboardingCategoryBudgetRequest struct {
+	CategoryID int     `json:"category_id" binding:"required"`
+	Amount     float64 `json:"amount" binding:"required,gt=0"`
+	Period     string  `json:"period" binding:"required,oneof=weekly monthly yearly"`
+}
+
+// OnboardingRequest represents everything a new user chooses while setting up their account
+type OnboardingRequest struct {
+	CurrencyID int                               `json:"currency_id" binding:"required"`
+	Categories []OnboardingCategoryRequest       `json:"categories" binding:"max=50,dive"`
+	Budgets    []OnboardingCategoryBudgetRequest `json:"budgets" binding:"max=50,dive"`
+}
+
+// OnboardingResponse represents the account as onboarding left it
+type OnboardingResponse struct {
+	CurrencyID            int                    `json:"currency_id"`
+	Categories            []CategoryResponse     `json:"categories"`
+	Budgets               []CreateBudgetResponse `json:"budgets"`
+	OnboardingCompletedAt time.Time              `json:"onboarding_completed_at"`
+}
+
+// OnboardingUseCase handles setting up a new user's account in one step: their
+// default currency, starter categories and first budgets
+type OnboardingUseCase struct {
+	currencyService *finance.CurrencyService
+	categoryService *finance.CategoryService
+	budgetService   *finance.BudgetService
+	preferencesRepo domainIdentity.PreferencesRepository
+	unitOfWork      finance.UnitOfWork
+}
+
+// NewOnboardingUseCase creates a new onboarding use case
+func NewOnboardingUseCase(
+	currencyService *finance.CurrencyService,
+	categoryService *finance.CategoryService,
+	budgetService *finance.BudgetService,
+	preferencesRepo domainIdentity.PreferencesRepository,
+	unitOfWork finance.UnitOfWork,
+) *OnboardingUseCase {
+	return &OnboardingUseCase{
+		currencyService: currencyService,
+		categoryService: categoryService,
+		budgetService:   budgetService,
+		preferencesRepo: preferencesRepo,
+		unitOfWork:      unitOfWork,
+	}
+}
+
+// Complete applies the user's onboarding choices and marks onboarding complete
+// in their preferences. Either everything is applied or nothing is, and
+// onboarding can only be completed once.
+func (uc *OnboardingUseCase) Complete(ctx context.Context, userID int, req OnboardingRequest) (*OnboardingResponse, error) {
+	now := time.Now().UTC()
+	startDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	response := &OnboardingResponse{
+		CurrencyID:            req.CurrencyID,
+		Categories:            []CategoryResponse{},
+		Budgets:               []CreateBudgetResponse{},
+		OnboardingCompletedAt: now,
+	}
+
+	err := uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		preferences, err := uc.preferencesRepo.FindByUserID(ctx, domainIdentity.NewUserID(userID))
+		if err != nil {
+			return err
+		}
+		if err := preferences.CompleteOnboarding(now); err != nil {
+			return err
+		}
+
+		if err := uc.currencyService.SetDefaultCurrency(ctx, finance.NewUserID(userID), finance.NewCurrencyID(req.CurrencyID)); err != nil {
+			return err
+		}
+		currency, err := uc.currencyService.GetDefaultCurrency(ctx, finance.NewUserID(userID))
+		if err != nil {
+			return err
+		}
+
+		createBudget := func(category *finance.Category, amount float64, period string) error {
+			money, err := finance.NewMoney(amount, currency.ID())
+			if err != nil {
+				return err
+			}
+			money, err = money.In(currency)
+			if err != nil {
+				return err
+			}
+
+			budget, err := uc.budgetService.CreateBudget(ctx, finance.NewUserID(userID), category.ID(), money, finance.BudgetPeriod(period), startDate, true)
+			if err != nil {
+				return err
+			}
+			response.Budgets = append(response.Budgets, CreateBudgetResponse{
+				Amount:    budget.Amount().Amount(),
+				Period:    string(budget.Period()),
+				StartDate: budget.StartDate().Format("2006-01-02"),
+				EndDate:   budget.EndDate().Format("2006-01-02"),
+				Prorated:  budget.Prorated(),
+				Allowance: budget.Allowance(),
+				Category:  toOnboardingCategoryResponse(category),
+			})
+			return nil
+		}
+
+		for _, entry := range req.Categories {
+			category, err := uc.categoryService.CreateCategory(ctx, finance.NewUserID(userID), entry.Name, entry.Color, finance.CategoryType(entry.Type))
+			if err != nil {
+				return err
+			}
+			response.Categories = append(response.Categories, *toOnboardingCategoryResponse(category))
+
+			if entry.Budget != nil {
+				if err := createBudget(category, entry.Budget.Amount, entry.Budget.Period); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, entry := range req.Budgets {
+			category, err := uc.categoryService.GetCategoryByID(ctx, finance.NewCategoryID(entry.CategoryID))
+			if err != nil {
+				return finance.ErrCategoryNotFound
+			}
+			if err := createBudget(category, entry.Amount, entry.Period); err != nil {
+				return err
+			}
+		}
+
+		return uc.preferencesRepo.Save(ctx, preferences)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// toOnboardingCategoryResponse converts a domain category to a response
+func toOnboardingCategoryResponse(category *finance.Category) *CategoryResponse {
+	return &CategoryResponse{
+		ID:        category.ID().Value(),
+		Name:      category.Name(),
+		Color:     category.Color(),
+		Type:      string(category.Type()),
+		IsDefault: category.IsDefault(),
+	}
+}
@@ -0,0 +1,76 @@
+package finance
+
+import (
+	"context"
+	"log/slog"
+	"panda-pocket/internal/domain/finance"
+	"time"
+)
+
+// PostRecurringTransactionsUseCase records the occurrences of recurring
+// transactions as expenses and incomes once their date comes
+type PostRecurringTransactionsUseCase struct {
+	transactionService *finance.TransactionService
+	unitOfWork         finance.UnitOfWork
+}
+
+// NewPostRecurringTransactionsUseCase creates a new post recurring transactions use case
+func NewPostRecurringTransactionsUseCase(transactionService *finance.TransactionService, unitOfWork finance.UnitOfWork) *PostRecurringTransactionsUseCase {
+	return &PostRecurringTransactionsUseCase{
+		transactionService: transactionService,
+		unitOfWork:         unitOfWork,
+	}
+}
+
+// Execute posts the occurrences due at the given time and returns how many
+// posted. Occurrences missed while the job was not running are posted too, each
+// on its own date. A recurring transaction that cannot post, say because its
+// category was deleted or its month closed, is logged and tried again on the
+// next run.
+func (uc *PostRecurringTransactionsUseCase) Execute(ctx context.Context, at time.Time) (int, error) {
+	due, err := uc.transactionService.GetDueRecurringTransactions(ctx, at)
+	if err != nil {
+		return 0, err
+	}
+
+	today := at.UTC().Truncate(24 * time.Hour)
+	posted := 0
+	for _, recurring := range due {
+		for recurring.IsActive() && !recurring.NextOccurrence().Date.After(today) {
+			// The transaction and the moved schedule are saved together, so a
+			// failure cannot post an occurrence twice
+			err := uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+				_, err := uc.transactionService.PostRecurringOccurrence(ctx, recurring)
+				return err
+			})
+			if err != nil {
+				slog.Warn("recurring transaction could not post", "recurring_transaction_id", recurring.ID().Value(), "error", err.Error())
+				break
+			}
+			posted++
+		}
+	}
+	return posted, nil
+}
+
+// Run executes the use case every interval until ctx is cancelled
+func (uc *PostRecurringTransactionsUseCase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			posted, err := uc.Execute(ctx, time.Now())
+			if err != nil {
+				slog.Error("posting recurring transactions failed", "error", err.Error())
+				continue
+			}
+			if posted > 0 {
+				slog.Info("posted recurring transactions", "count", posted)
+			}
+		}
+	}
+}
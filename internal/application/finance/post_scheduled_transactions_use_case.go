@@ -0,0 +1,71 @@
+package finance
+
+import (
+	"context"
+	"log/slog"
+	"panda-pocket/internal/domain/finance"
+	"time"
+)
+
+// PostScheduledTransactionsUseCase records scheduled transactions as expenses and
+// incomes once their date comes
+type PostScheduledTransactionsUseCase struct {
+	transactionService *finance.TransactionService
+	unitOfWork         finance.UnitOfWork
+}
+
+// NewPostScheduledTransactionsUseCase creates a new post scheduled transactions use case
+func NewPostScheduledTransactionsUseCase(transactionService *finance.TransactionService, unitOfWork finance.UnitOfWork) *PostScheduledTransactionsUseCase {
+	return &PostScheduledTransactionsUseCase{
+		transactionService: transactionService,
+		unitOfWork:         unitOfWork,
+	}
+}
+
+// Execute posts the scheduled transactions due at the given time and returns how
+// many posted. One that cannot post, say because its category was deleted or its
+// month closed, is logged and tried again on the next run.
+func (uc *PostScheduledTransactionsUseCase) Execute(ctx context.Context, at time.Time) (int, error) {
+	due, err := uc.transactionService.GetDueScheduledTransactions(ctx, at)
+	if err != nil {
+		return 0, err
+	}
+
+	posted := 0
+	for _, scheduled := range due {
+		// The transaction and the posted status are saved together, so a failure
+		// cannot post it twice
+		err := uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+			_, err := uc.transactionService.PostScheduledTransaction(ctx, scheduled)
+			return err
+		})
+		if err != nil {
+			slog.Warn("scheduled transaction could not post", "scheduled_transaction_id", scheduled.ID().Value(), "error", err.Error())
+			continue
+		}
+		posted++
+	}
+	return posted, nil
+}
+
+// Run executes the use case every interval until ctx is cancelled
+func (uc *PostScheduledTransactionsUseCase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			posted, err := uc.Execute(ctx, time.Now())
+			if err != nil {
+				slog.Error("posting scheduled transactions failed", "error", err.Error())
+				continue
+			}
+			if posted > 0 {
+				slog.Info("posted scheduled transactions", "count", posted)
+			}
+		}
+	}
+}
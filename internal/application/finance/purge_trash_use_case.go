@@ -0,0 +1,86 @@
+package finance
+
+import (
+	"context"
+	"log/slog"
+	"panda-pocket/internal/domain/finance"
+	domainIdentity "panda-pocket/internal/domain/identity"
+	"time"
+)
+
+// PurgeTrashUseCase deletes the undo log entries, which hold what updated and
+// deleted transactions and budgets looked like, once their retention has passed
+type PurgeTrashUseCase struct {
+	actionRepo      finance.ActionRepository
+	preferencesRepo domainIdentity.PreferencesRepository
+	retentionDays   int
+}
+
+// NewPurgeTrashUseCase creates a use case purging undo log entries older than
+// retentionDays, or sooner for users who chose a shorter retention. With
+// retentionDays 0 only those users' entries are purged.
+func NewPurgeTrashUseCase(actionRepo finance.ActionRepository, preferencesRepo domainIdentity.PreferencesRepository, retentionDays int) *PurgeTrashUseCase {
+	return &PurgeTrashUseCase{
+		actionRepo:      actionRepo,
+		preferencesRepo: preferencesRepo,
+		retentionDays:   retentionDays,
+	}
+}
+
+// Execute purges the entries whose retention has passed at the given time and
+// returns how many were purged
+func (uc *PurgeTrashUseCase) Execute(ctx context.Context, now time.Time) (int, error) {
+	purged := 0
+	if uc.retentionDays > 0 {
+		deleted, err := uc.actionRepo.DeleteBefore(ctx, now.AddDate(0, 0, -uc.retentionDays))
+		if err != nil {
+			return purged, err
+		}
+		purged += deleted
+	}
+
+	preferences, err := uc.preferencesRepo.FindWithTrashRetention(ctx)
+	if err != nil {
+		return purged, err
+	}
+	for _, p := range preferences {
+		days := *p.TrashRetentionDays()
+		if uc.retentionDays > 0 && days >= uc.retentionDays {
+			continue
+		}
+
+		cutoff := now.AddDate(0, 0, -days)
+		if days == 0 {
+			// Changes are kept while they can still be undone
+			cutoff = now.Add(-finance.UndoWindow)
+		}
+		deleted, err := uc.actionRepo.DeleteByUserIDBefore(ctx, finance.NewUserID(p.UserID().Value()), cutoff)
+		if err != nil {
+			return purged, err
+		}
+		purged += deleted
+	}
+	return purged, nil
+}
+
+// Run executes the use case every interval until ctx is cancelled
+func (uc *PurgeTrashUseCase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := uc.Execute(ctx, time.Now())
+			if err != nil {
+				slog.Error("trash purge failed", "error", err.Error())
+				continue
+			}
+			if purged > 0 {
+				slog.Info("purged undo log entries", "count", purged, "retention_days", uc.retentionDays)
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package finance
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+)
+
+// ReplaceLineItemsRequest represents the lines of an expense's receipt, in receipt order
+type ReplaceLineItemsRequest struct {
+	Items []LineItemRequest `json:"items" binding:"required,dive"`
+}
+
+// LineItemRequest represents one line of a receipt
+type LineItemRequest struct {
+	Name       string  `json:"name" binding:"required,max=255"`
+	Quantity   float64 `json:"quantity" binding:"required,gt=0"`
+	UnitPrice  float64 `json:"unit_price" binding:"min=0"`
+	CategoryID *int    `json:"category_id"` // for splitting the line off the expense's category
+}
+
+// LineItemsResponse represents an expense's receipt lines. Unitemized is the
+// part of the expense amount the lines do not account for, such as taxes, tips
+// or discounts; it is negative when the lines add up to more.
+type LineItemsResponse struct {
+	ExpenseID  int                `json:"expense_id"`
+	Amount     float64            `json:"amount"`
+	Items      []LineItemResponse `json:"items"`
+	ItemsTotal float64            `json:"items_total"`
+	Unitemized float64            `json:"unitemized"`
+}
+
+// LineItemResponse represents one line of a receipt
+type LineItemResponse struct {
+	Name       string  `json:"name"`
+	Quantity   float64 `json:"quantity"`
+	UnitPrice  float64 `json:"unit_price"`
+	Total      float64 `json:"total"`
+	CategoryID *int    `json:"category_id"`
+}
+
+// ReceiptLineItemsUseCase handles reading and replacing the line items of expense receipts
+type ReceiptLineItemsUseCase struct {
+	receiptService *finance.ReceiptService
+}
+
+// NewReceiptLineItemsUseCase creates a new receipt line items use case
+func NewReceiptLineItemsUseCase(receiptService *finance.ReceiptService) *ReceiptLineItemsUseCase {
+	return &ReceiptLineItemsUseCase{
+		receiptService: receiptService,
+	}
+}
+
+// Get returns the line items of one of the user's expenses
+func (uc *ReceiptLineItemsUseCase) Get(ctx context.Context, userID, expenseID int) (*LineItemsResponse, error) {
+	expense, items, err := uc.receiptService.GetLineItems(ctx, finance.NewTransactionID(expenseID), finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	return toLineItemsResponse(expense, items), nil
+}
+
+// Replace replaces the line items of one of the user's expenses; an empty list removes them
+func (uc *ReceiptLineItemsUseCase) Replace(ctx context.Context, userID, expenseID int, req ReplaceLineItemsRequest) (*LineItemsResponse, error) {
+	items := make([]*finance.ReceiptLineItem, len(req.Items))
+	for i, line := range req.Items {
+		var categoryID *finance.CategoryID
+		if line.CategoryID != nil {
+			id := finance.NewCategoryID(*line.CategoryID)
+			categoryID = &id
+		}
+		item, err := finance.NewReceiptLineItem(line.Name, line.Quantity, line.UnitPrice, categoryID)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = item
+	}
+
+	expense, err := uc.receiptService.ReplaceLineItems(ctx, finance.NewTransactionID(expenseID), finance.NewUserID(userID), items)
+	if err != nil {
+		return nil, err
+	}
+	return toLineItemsResponse(expense, items), nil
+}
+
+// toLineItemsResponse converts an expense and its line items to a response
+func toLineItemsResponse(expense *finance.Transaction, items []*finance.ReceiptLineItem) *LineItemsResponse {
+	response := &LineItemsResponse{
+		ExpenseID: expense.ID().Value(),
+		Amount:    expense.Amount().Amount(),
+		Items:     make([]LineItemResponse, len(items)),
+	}
+	for i, item := range items {
+		response.Items[i] = LineItemResponse{
+			Name:      item.Name(),
+			Quantity:  item.Quantity(),
+			UnitPrice: item.UnitPrice(),
+			Total:     item.Total(),
+		}
+		if item.CategoryID() != nil {
+			categoryID := item.CategoryID().Value()
+			response.Items[i].CategoryID = &categoryID
+		}
+		response.ItemsTotal += item.Total()
+	}
+
+	response.ItemsTotal = roundAmount(response.ItemsTotal)
+	response.Unitemized = roundAmount(response.Amount - response.ItemsTotal)
+	return response
+}
@@ -0,0 +1,106 @@
+package finance
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"time"
+)
+
+// ReconcileAccountRequest represents a bank statement to compare an account against
+type ReconcileAccountRequest struct {
+	StartDate            string  `json:"start_date" form:"start_date" binding:"required"`
+	EndDate              string  `json:"end_date" form:"end_date" binding:"required"`
+	StatementDeposits    float64 `json:"statement_deposits" form:"statement_deposits" binding:"gte=0"`
+	StatementWithdrawals float64 `json:"statement_withdrawals" form:"statement_withdrawals" binding:"gte=0"`
+}
+
+// ReconciliationResponse represents the recorded and statement totals of an account for a period
+type ReconciliationResponse struct {
+	AccountID             int     `json:"account_id"`
+	StartDate             string  `json:"start_date"`
+	EndDate               string  `json:"end_date"`
+	RecordedDeposits      float64 `json:"recorded_deposits"`
+	RecordedWithdrawals   float64 `json:"recorded_withdrawals"`
+	StatementDeposits     float64 `json:"statement_deposits"`
+	StatementWithdrawals  float64 `json:"statement_withdrawals"`
+	DepositsDifference    float64 `json:"deposits_difference"`
+	WithdrawalsDifference float64 `json:"withdrawals_difference"`
+	TransactionCount      int     `json:"transaction_count"`
+	Balanced              bool    `json:"balanced"`
+	Reconciled            bool    `json:"reconciled"`
+}
+
+// ReconcileAccountUseCase handles comparing an account with a bank statement and
+// marking the matched period reconciled
+type ReconcileAccountUseCase struct {
+	accountService *finance.AccountService
+	unitOfWork     finance.UnitOfWork
+}
+
+// NewReconcileAccountUseCase creates a new reconcile account use case
+func NewReconcileAccountUseCase(accountService *finance.AccountService, unitOfWork finance.UnitOfWork) *ReconcileAccountUseCase {
+	return &ReconcileAccountUseCase{
+		accountService: accountService,
+		unitOfWork:     unitOfWork,
+	}
+}
+
+// Report compares the account's recorded totals with the statement without changing anything
+func (uc *ReconcileAccountUseCase) Report(ctx context.Context, userID, accountID int, req ReconcileAccountRequest) (*ReconciliationResponse, error) {
+	return uc.reconcile(ctx, userID, accountID, req, false)
+}
+
+// Execute marks the period's transactions reconciled when the totals match the statement
+func (uc *ReconcileAccountUseCase) Execute(ctx context.Context, userID, accountID int, req ReconcileAccountRequest) (*ReconciliationResponse, error) {
+	return uc.reconcile(ctx, userID, accountID, req, true)
+}
+
+// reconcile runs the reconciliation; expenses and incomes are marked in one transaction
+func (uc *ReconcileAccountUseCase) reconcile(ctx context.Context, userID, accountID int, req ReconcileAccountRequest, markReconciled bool) (*ReconciliationResponse, error) {
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, finance.ErrInvalidStatementPeriod
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return nil, finance.ErrInvalidStatementPeriod
+	}
+
+	statement := finance.Statement{
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Deposits:    req.StatementDeposits,
+		Withdrawals: req.StatementWithdrawals,
+	}
+
+	var reconciliation *finance.Reconciliation
+	err = uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		reconciliation, err = uc.accountService.Reconcile(
+			ctx,
+			finance.NewAccountID(accountID),
+			finance.NewUserID(userID),
+			statement,
+			markReconciled,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReconciliationResponse{
+		AccountID:             reconciliation.AccountID.Value(),
+		StartDate:             startDate.Format("2006-01-02"),
+		EndDate:               endDate.Format("2006-01-02"),
+		RecordedDeposits:      reconciliation.RecordedDeposits,
+		RecordedWithdrawals:   reconciliation.RecordedWithdrawals,
+		StatementDeposits:     statement.Deposits,
+		StatementWithdrawals:  statement.Withdrawals,
+		DepositsDifference:    reconciliation.DepositsDifference(),
+		WithdrawalsDifference: reconciliation.WithdrawalsDifference(),
+		TransactionCount:      reconciliation.TransactionCount,
+		Balanced:              reconciliation.Balanced(),
+		Reconciled:            reconciliation.Reconciled,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package finance
+
+import (
+	"context"
+	"log/slog"
+	"panda-pocket/internal/domain/finance"
+	"time"
+)
+
+// PriceProvider looks up the latest price of a security and the day it is from
+type PriceProvider interface {
+	Price(ctx context.Context, ticker string) (float64, time.Time, error)
+}
+
+// RefreshHoldingPricesUseCase records the latest price of every holding from a
+// price provider, so net worth follows the market without users entering prices
+type RefreshHoldingPricesUseCase struct {
+	investmentService *finance.InvestmentService
+	provider          PriceProvider
+}
+
+// NewRefreshHoldingPricesUseCase creates a new refresh holding prices use case
+func NewRefreshHoldingPricesUseCase(investmentService *finance.InvestmentService, provider PriceProvider) *RefreshHoldingPricesUseCase {
+	return &RefreshHoldingPricesUseCase{
+		investmentService: investmentService,
+		provider:          provider,
+	}
+}
+
+// Run refreshes prices at every interval until ctx is cancelled
+func (uc *RefreshHoldingPricesUseCase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			refreshed, err := uc.Execute(ctx)
+			if err != nil {
+				slog.Error("holding price refresh failed", "error", err.Error())
+				continue
+			}
+			if refreshed > 0 {
+				slog.Info("refreshed holding prices", "count", refreshed)
+			}
+		}
+	}
+}
+
+// Execute looks up each ticker held once and records its price on every holding
+// of it, returning how many holdings were valued. A ticker the provider cannot
+// price is logged and skipped; its holdings keep their last value.
+func (uc *RefreshHoldingPricesUseCase) Execute(ctx context.Context) (int, error) {
+	holdings, err := uc.investmentService.GetAllHoldings(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	type quote struct {
+		price float64
+		date  time.Time
+		err   error
+	}
+	quotes := make(map[string]quote)
+
+	refreshed := 0
+	for _, holding := range holdings {
+		q, found := quotes[holding.Ticker()]
+		if !found {
+			q.price, q.date, q.err = uc.provider.Price(ctx, holding.Ticker())
+			if q.err != nil {
+				slog.Warn("failed to look up price", "ticker", holding.Ticker(), "error", q.err.Error())
+			}
+			quotes[holding.Ticker()] = q
+		}
+		if q.err != nil {
+			continue
+		}
+
+		if _, err := uc.investmentService.RecordPrice(ctx, holding, q.price, q.date); err != nil {
+			slog.Error("failed to record price", "holding_id", holding.ID().Value(), "error", err.Error())
+			continue
+		}
+		refreshed++
+	}
+	return refreshed, nil
+}
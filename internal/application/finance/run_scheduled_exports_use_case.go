@@ -0,0 +1,171 @@
+package finance
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"panda-pocket/internal/domain/finance"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ExportUploader sends an export file to the storage provider a schedule names
+type ExportUploader interface {
+	Upload(ctx context.Context, schedule *finance.ExportSchedule, name, contentType string, data []byte) error
+}
+
+// exportedTransaction is one transaction in an export file
+type exportedTransaction struct {
+	ID          int     `json:"id"`
+	Date        string  `json:"date"`
+	Type        string  `json:"type"`
+	Category    string  `json:"category"`
+	Amount      float64 `json:"amount"`
+	CurrencyID  int     `json:"currency_id"`
+	Description string  `json:"description"`
+}
+
+// RunScheduledExportsUseCase exports the transactions of every due schedule and
+// records each attempt in the export history
+type RunScheduledExportsUseCase struct {
+	scheduleRepo    finance.ExportScheduleRepository
+	runRepo         finance.ExportRunRepository
+	transactionRepo finance.TransactionRepository
+	categoryRepo    finance.CategoryRepository
+	uploader        ExportUploader
+}
+
+// NewRunScheduledExportsUseCase creates a new run scheduled exports use case
+func NewRunScheduledExportsUseCase(
+	scheduleRepo finance.ExportScheduleRepository,
+	runRepo finance.ExportRunRepository,
+	transactionRepo finance.TransactionRepository,
+	categoryRepo finance.CategoryRepository,
+	uploader ExportUploader,
+) *RunScheduledExportsUseCase {
+	return &RunScheduledExportsUseCase{
+		scheduleRepo:    scheduleRepo,
+		runRepo:         runRepo,
+		transactionRepo: transactionRepo,
+		categoryRepo:    categoryRepo,
+		uploader:        uploader,
+	}
+}
+
+// Execute runs the schedules due at the given time and returns how many ran.
+// A failed upload is recorded in the history and retried at the next period.
+func (uc *RunScheduledExportsUseCase) Execute(ctx context.Context, at time.Time) (int, error) {
+	schedules, err := uc.scheduleRepo.FindDue(ctx, at)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, schedule := range schedules {
+		name, count, exportErr := uc.export(ctx, schedule, at)
+		if exportErr != nil {
+			slog.Warn("scheduled export failed", "schedule_id", schedule.ID().Value(), "error", exportErr.Error())
+		}
+
+		if err := uc.runRepo.Save(ctx, finance.NewExportRun(schedule, name, count, exportErr)); err != nil {
+			return 0, err
+		}
+		schedule.Advance(at)
+		if err := uc.scheduleRepo.Save(ctx, schedule); err != nil {
+			return 0, err
+		}
+	}
+	return len(schedules), nil
+}
+
+// export encodes all of the schedule owner's transactions and uploads them
+func (uc *RunScheduledExportsUseCase) export(ctx context.Context, schedule *finance.ExportSchedule, at time.Time) (string, int, error) {
+	name := fmt.Sprintf("pandapocket-transactions-%s.%s", at.UTC().Format("20060102T150405Z"), schedule.Format())
+
+	transactions, err := uc.transactionRepo.FindByUserID(ctx, schedule.UserID())
+	if err != nil {
+		return name, 0, err
+	}
+	sort.Slice(transactions, func(i, j int) bool {
+		return transactions[i].Date().Before(transactions[j].Date())
+	})
+
+	rows := make([]exportedTransaction, len(transactions))
+	categoryNames := make(map[int]string)
+	for i, transaction := range transactions {
+		categoryID := transaction.CategoryID()
+		if _, ok := categoryNames[categoryID.Value()]; !ok {
+			if category, err := uc.categoryRepo.FindByID(ctx, categoryID); err == nil {
+				categoryNames[categoryID.Value()] = category.Name()
+			}
+		}
+
+		rows[i] = exportedTransaction{
+			ID:          transaction.ID().Value(),
+			Date:        transaction.Date().Format("2006-01-02"),
+			Type:        string(transaction.Type()),
+			Category:    categoryNames[categoryID.Value()],
+			Amount:      transaction.Amount().Amount(),
+			CurrencyID:  transaction.CurrencyID().Value(),
+			Description: transaction.Description(),
+		}
+	}
+
+	data, contentType, err := encodeExport(schedule.Format(), rows)
+	if err != nil {
+		return name, len(rows), err
+	}
+	return name, len(rows), uc.uploader.Upload(ctx, schedule, name, contentType, data)
+}
+
+// encodeExport writes the rows in the export format and returns the content type
+func encodeExport(format finance.ExportFormat, rows []exportedTransaction) ([]byte, string, error) {
+	if format == finance.ExportFormatJSON {
+		data, err := json.MarshalIndent(rows, "", "  ")
+		return data, "application/json", err
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	records := [][]string{{"id", "date", "type", "category", "amount", "currency_id", "description"}}
+	for _, row := range rows {
+		records = append(records, []string{
+			strconv.Itoa(row.ID),
+			row.Date,
+			row.Type,
+			row.Category,
+			strconv.FormatFloat(row.Amount, 'f', 2, 64),
+			strconv.Itoa(row.CurrencyID),
+			row.Description,
+		})
+	}
+	if err := writer.WriteAll(records); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "text/csv", nil
+}
+
+// Run executes the use case every interval until ctx is cancelled
+func (uc *RunScheduledExportsUseCase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ran, err := uc.Execute(ctx, time.Now())
+			if err != nil {
+				slog.Error("scheduled exports failed", "error", err.Error())
+				continue
+			}
+			if ran > 0 {
+				slog.Info("ran scheduled exports", "count", ran)
+			}
+		}
+	}
+}
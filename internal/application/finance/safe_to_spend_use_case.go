@@ -0,0 +1,181 @@
+package finance
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"sort"
+	"time"
+)
+
+// SafeToSpendRequest represents the currency safe to spend is worked out in
+type SafeToSpendRequest struct {
+	CurrencyID int `form:"currency_id"` // defaults to the user's default currency
+}
+
+// SafeToSpendResponse represents what is left of this month's income once bills
+// and budgets are provided for
+type SafeToSpendResponse struct {
+	CurrencyID     int     `json:"currency_id"`
+	CurrencyCode   string  `json:"currency_code"`
+	StartDate      string  `json:"start_date"`
+	EndDate        string  `json:"end_date"`
+	IncomeReceived float64 `json:"income_received"`
+	// BudgetAllocations is each budget's share of the month, or what was spent
+	// against it when that is more
+	BudgetAllocations   float64        `json:"budget_allocations"`
+	SpentOutsideBudgets float64        `json:"spent_outside_budgets"`
+	UpcomingBills       float64        `json:"upcoming_bills"`
+	SafeToSpend         float64        `json:"safe_to_spend"`
+	Bills               []UpcomingBill `json:"bills"`
+}
+
+// UpcomingBill represents an occurrence of a recurring expense due later this month
+type UpcomingBill struct {
+	RecurringTransactionID int     `json:"recurring_transaction_id"`
+	Description            string  `json:"description"`
+	Date                   string  `json:"date"`
+	Amount                 float64 `json:"amount"`
+}
+
+// SafeToSpendUseCase handles working out the user's discretionary spending money
+type SafeToSpendUseCase struct {
+	transactionService *finance.TransactionService
+	budgetService      *finance.BudgetService
+	categoryService    *finance.CategoryService
+	currencyService    *finance.CurrencyService
+	recurringRepo      finance.RecurringTransactionRepository
+}
+
+// NewSafeToSpendUseCase creates a new safe to spend use case
+func NewSafeToSpendUseCase(
+	transactionService *finance.TransactionService,
+	budgetService *finance.BudgetService,
+	categoryService *finance.CategoryService,
+	currencyService *finance.CurrencyService,
+	recurringRepo finance.RecurringTransactionRepository,
+) *SafeToSpendUseCase {
+	return &SafeToSpendUseCase{
+		transactionService: transactionService,
+		budgetService:      budgetService,
+		categoryService:    categoryService,
+		currencyService:    currencyService,
+		recurringRepo:      recurringRepo,
+	}
+}
+
+// Execute takes the income received so far this calendar month in the currency
+// and sets aside every budget's share of the month, the spending outside budgets
+// and the recurring expenses still to post before the month ends. Bills in
+// budgeted categories are left to their budget so they are not counted twice.
+// Income still to come is not counted.
+func (uc *SafeToSpendUseCase) Execute(ctx context.Context, userID int, req SafeToSpendRequest) (*SafeToSpendResponse, error) {
+	now := time.Now().UTC()
+	month := finance.MonthStart(now)
+	nextMonth := month.AddDate(0, 1, 0)
+
+	currency, err := userCurrency(ctx, uc.currencyService, userID, req.CurrencyID)
+	if err != nil {
+		return nil, err
+	}
+
+	allBudgets, err := uc.budgetService.GetBudgetsByUser(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	var budgets []*finance.Budget
+	for _, budget := range allBudgets {
+		if budget.Amount().Currency() == currency.ID() {
+			budgets = append(budgets, budget)
+		}
+	}
+	// covering returns the first budget for the category on day, or -1
+	covering := func(categoryID finance.CategoryID, day time.Time) int {
+		for i, budget := range budgets {
+			if budget.CategoryID() == categoryID && budget.Covers(day) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	transactions, err := uc.transactionService.GetTransactionsByUserAndDateRange(ctx, finance.NewUserID(userID), month, nextMonth.Add(-time.Nanosecond))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SafeToSpendResponse{
+		CurrencyID:   currency.ID().Value(),
+		CurrencyCode: currency.Code(),
+		StartDate:    month.Format("2006-01-02"),
+		EndDate:      nextMonth.AddDate(0, 0, -1).Format("2006-01-02"),
+		Bills:        []UpcomingBill{},
+	}
+	spentAgainst := make([]float64, len(budgets))
+	for _, transaction := range transactions {
+		if transaction.CurrencyID() != currency.ID() {
+			continue
+		}
+		amount := transaction.Amount().Amount()
+		switch transaction.Type() {
+		case finance.TransactionTypeIncome:
+			response.IncomeReceived += amount
+		case finance.TransactionTypeExpense:
+			if i := covering(transaction.CategoryID(), transaction.Date().UTC()); i >= 0 {
+				spentAgainst[i] += amount
+			} else {
+				response.SpentOutsideBudgets += amount
+			}
+		}
+	}
+
+	for i, budget := range budgets {
+		var allocation float64
+		for day := month; day.Before(nextMonth); day = day.AddDate(0, 0, 1) {
+			if budget.Covers(day) {
+				allocation += budget.DailyAllowance()
+			}
+		}
+		response.BudgetAllocations += max(allocation, spentAgainst[i])
+	}
+
+	categories, err := uc.categoryService.GetCategoriesByUser(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	categoryTypes := make(map[finance.CategoryID]finance.CategoryType)
+	for _, category := range categories {
+		categoryTypes[category.ID()] = category.Type()
+	}
+	recurring, err := uc.recurringRepo.FindActiveByUserID(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	for _, bill := range recurring {
+		if bill.CurrencyID() != currency.ID() || categoryTypes[bill.CategoryID()] != finance.CategoryTypeExpense {
+			continue
+		}
+		// The next occurrence may be overdue; it has not posted yet either way
+		for _, occurrence := range bill.Occurrences(nextMonth.Add(-time.Nanosecond), 31) {
+			if covering(bill.CategoryID(), occurrence.Date) >= 0 {
+				continue
+			}
+			response.UpcomingBills += occurrence.Amount.Amount()
+			response.Bills = append(response.Bills, UpcomingBill{
+				RecurringTransactionID: bill.ID().Value(),
+				Description:            bill.Description(),
+				Date:                   occurrence.Date.Format("2006-01-02"),
+				Amount:                 occurrence.Amount.Amount(),
+			})
+		}
+	}
+	sort.SliceStable(response.Bills, func(i, j int) bool {
+		return response.Bills[i].Date < response.Bills[j].Date
+	})
+
+	response.IncomeReceived = roundAmount(response.IncomeReceived)
+	response.BudgetAllocations = roundAmount(response.BudgetAllocations)
+	response.SpentOutsideBudgets = roundAmount(response.SpentOutsideBudgets)
+	response.UpcomingBills = roundAmount(response.UpcomingBills)
+	response.SafeToSpend = roundAmount(response.IncomeReceived - response.BudgetAllocations - response.SpentOutsideBudgets - response.UpcomingBills)
+	return response, nil
+}
@@ -0,0 +1,222 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"panda-pocket/internal/domain/finance"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Search result types
+const (
+	SearchResultTransaction = "transaction"
+	SearchResultCategory    = "category"
+	SearchResultBudget      = "budget"
+	SearchResultMerchant    = "merchant"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+	// searchTransactionScan is how many of the newest matching transactions are
+	// ranked and grouped into merchants
+	searchTransactionScan = 200
+)
+
+// ErrSearchQueryTooShort is returned when the search text is under two characters
+var ErrSearchQueryTooShort = errors.New("search query must be at least 2 characters")
+
+// SearchRequest represents a search across a user's finance data
+type SearchRequest struct {
+	Query string `json:"q"`
+	Limit int    `json:"limit,omitempty"`
+}
+
+// SearchResult is one match. Results of every type share this shape so a
+// search bar can render them in one list.
+type SearchResult struct {
+	Type     string   `json:"type"`
+	ID       int      `json:"id,omitempty"` // not set for merchants
+	Title    string   `json:"title"`
+	Subtitle string   `json:"subtitle,omitempty"`
+	Amount   *float64 `json:"amount,omitempty"`
+	Date     string   `json:"date,omitempty"`
+	Score    int      `json:"score"`
+}
+
+// SearchResponse represents the ranked search results
+type SearchResponse struct {
+	Query   string         `json:"query"`
+	Results []SearchResult `json:"results"`
+}
+
+// SearchUseCase handles searching transactions, categories, budgets and
+// merchants in one call. Merchants are the distinct descriptions of matching
+// transactions.
+type SearchUseCase struct {
+	transactionService *finance.TransactionService
+	categoryService    *finance.CategoryService
+	budgetService      *finance.BudgetService
+}
+
+// NewSearchUseCase creates a new search use case
+func NewSearchUseCase(transactionService *finance.TransactionService, categoryService *finance.CategoryService, budgetService *finance.BudgetService) *SearchUseCase {
+	return &SearchUseCase{
+		transactionService: transactionService,
+		categoryService:    categoryService,
+		budgetService:      budgetService,
+	}
+}
+
+// Execute returns the best matches, highest score first
+func (uc *SearchUseCase) Execute(ctx context.Context, userID int, req SearchRequest) (*SearchResponse, error) {
+	query := strings.TrimSpace(req.Query)
+	if len([]rune(query)) < 2 {
+		return nil, ErrSearchQueryTooShort
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	categories, err := uc.categoryService.GetCategoriesByUser(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	categoryNames := make(map[int]string, len(categories))
+
+	// Results are appended in tie-break order: categories, merchants, budgets, transactions
+	var results []SearchResult
+	for _, category := range categories {
+		categoryNames[category.ID().Value()] = category.Name()
+		if score := matchScore(category.Name(), query); score > 0 {
+			results = append(results, SearchResult{
+				Type:     SearchResultCategory,
+				ID:       category.ID().Value(),
+				Title:    category.Name(),
+				Subtitle: string(category.Type()),
+				Score:    score,
+			})
+		}
+	}
+
+	transactions, _, err := uc.transactionService.GetTransactionsByUserWithFilters(ctx, finance.NewUserID(userID), finance.TransactionFilters{
+		Search: query,
+		Limit:  searchTransactionScan,
+	})
+	if err != nil {
+		return nil, err
+	}
+	results = append(results, merchantResults(transactions, query)...)
+
+	budgets, err := uc.budgetService.GetBudgetsByUser(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	for _, budget := range budgets {
+		name := categoryNames[budget.CategoryID().Value()]
+		if score := matchScore(name, query); score > 0 {
+			amount := budget.Amount().Amount()
+			results = append(results, SearchResult{
+				Type:     SearchResultBudget,
+				ID:       budget.ID().Value(),
+				Title:    name,
+				Subtitle: string(budget.Period()),
+				Amount:   &amount,
+				Date:     budget.StartDate().Format("2006-01-02"),
+				Score:    score,
+			})
+		}
+	}
+
+	for _, transaction := range transactions {
+		amount := transaction.Amount().Amount()
+		results = append(results, SearchResult{
+			Type:     SearchResultTransaction,
+			ID:       transaction.ID().Value(),
+			Title:    transaction.Description(),
+			Subtitle: categoryNames[transaction.CategoryID().Value()],
+			Amount:   &amount,
+			Date:     transaction.Date().Format("2006-01-02"),
+			Score:    matchScore(transaction.Description(), query),
+		})
+	}
+
+	// Stable, so equal scores keep the type order and transactions stay newest first
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	if results == nil {
+		results = []SearchResult{}
+	}
+
+	return &SearchResponse{Query: query, Results: results}, nil
+}
+
+// merchantResults groups matching transactions by description, ignoring case
+func merchantResults(transactions []*finance.Transaction, query string) []SearchResult {
+	type merchant struct {
+		name  string
+		count int
+		last  time.Time
+	}
+
+	var order []string
+	merchants := make(map[string]*merchant)
+	for _, transaction := range transactions {
+		name := strings.TrimSpace(transaction.Description())
+		key := strings.ToLower(name)
+		m, ok := merchants[key]
+		if !ok {
+			m = &merchant{name: name}
+			merchants[key] = m
+			order = append(order, key)
+		}
+		m.count++
+		if transaction.Date().After(m.last) {
+			m.last = transaction.Date()
+		}
+	}
+
+	results := make([]SearchResult, 0, len(order))
+	for _, key := range order {
+		m := merchants[key]
+		results = append(results, SearchResult{
+			Type:     SearchResultMerchant,
+			Title:    m.name,
+			Subtitle: fmt.Sprintf("%d transactions", m.count),
+			Date:     m.last.Format("2006-01-02"),
+			Score:    matchScore(m.name, query),
+		})
+	}
+	return results
+}
+
+// matchScore ranks how well text matches the query: an exact match scores
+// highest, then a prefix, then the start of a later word, then anywhere
+func matchScore(text, query string) int {
+	text = strings.ToLower(strings.TrimSpace(text))
+	query = strings.ToLower(query)
+
+	switch {
+	case text == query:
+		return 100
+	case strings.HasPrefix(text, query):
+		return 75
+	case strings.Contains(text, " "+query):
+		return 50
+	case strings.Contains(text, query):
+		return 25
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,141 @@
+package finance
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	domainIdentity "panda-pocket/internal/domain/identity"
+	"time"
+)
+
+// defaultBenchmarkMonths is the spending history compared by default
+const defaultBenchmarkMonths = 3
+
+// SpendingBenchmarksRequest represents the query for spending benchmarks
+type SpendingBenchmarksRequest struct {
+	// Months is how many complete months before the current one are compared; defaults to 3
+	Months     int `form:"months" binding:"omitempty,min=1,max=12"`
+	CurrencyID int `form:"currency_id"` // defaults to the user's default currency
+}
+
+// SpendingBenchmarksResponse represents how the user's spending per category
+// compares with other users spending in the same currency
+type SpendingBenchmarksResponse struct {
+	CurrencyCode string `json:"currency_code"`
+	Months       int    `json:"months"`
+	StartDate    string `json:"start_date"`
+	EndDate      string `json:"end_date"`
+	// PeerCount is how many users the user is compared with; unset, with no
+	// categories, while there are too few to keep their spending anonymous
+	PeerCount  *int                `json:"peer_count"`
+	Categories []CategoryBenchmark `json:"categories"`
+}
+
+// CategoryBenchmark represents the user's average monthly spend in a category
+// against their peers'
+type CategoryBenchmark struct {
+	CategoryID   int     `json:"category_id"`
+	CategoryName string  `json:"category_name"`
+	MonthlySpend float64 `json:"monthly_spend"`
+	// PeerMonthlyMedian counts peers who spent nothing in the category
+	PeerMonthlyMedian float64 `json:"peer_monthly_median"`
+	// Percentile is the share of peers, 0-100, who spent less than the user
+	Percentile int `json:"percentile"`
+}
+
+// SpendingBenchmarksUseCase handles comparing users' category spending with
+// other users who opted in to share theirs
+type SpendingBenchmarksUseCase struct {
+	benchmarkRepo   finance.SpendingBenchmarkRepository
+	categoryService *finance.CategoryService
+	currencyService *finance.CurrencyService
+	preferencesRepo domainIdentity.PreferencesRepository
+}
+
+// NewSpendingBenchmarksUseCase creates a new spending benchmarks use case
+func NewSpendingBenchmarksUseCase(
+	benchmarkRepo finance.SpendingBenchmarkRepository,
+	categoryService *finance.CategoryService,
+	currencyService *finance.CurrencyService,
+	preferencesRepo domainIdentity.PreferencesRepository,
+) *SpendingBenchmarksUseCase {
+	return &SpendingBenchmarksUseCase{
+		benchmarkRepo:   benchmarkRepo,
+		categoryService: categoryService,
+		currencyService: currencyService,
+		preferencesRepo: preferencesRepo,
+	}
+}
+
+// Execute compares the user's spending in each default category over the complete
+// months before the current one with the other opted-in users who spent in a
+// currency with the same code. Only users who share their own spending see the
+// comparison, and only aggregates of at least finance.MinBenchmarkPeers users are shown.
+func (uc *SpendingBenchmarksUseCase) Execute(ctx context.Context, userID int, req SpendingBenchmarksRequest) (*SpendingBenchmarksResponse, error) {
+	preferences, err := uc.preferencesRepo.FindByUserID(ctx, domainIdentity.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	if !preferences.Benchmarking() {
+		return nil, finance.ErrBenchmarkingDisabled
+	}
+
+	months := req.Months
+	if months == 0 {
+		months = defaultBenchmarkMonths
+	}
+	currentMonth := finance.MonthStart(time.Now())
+	startDate := currentMonth.AddDate(0, -months, 0)
+	endDate := currentMonth.Add(-time.Nanosecond)
+
+	currency, err := userCurrency(ctx, uc.currencyService, userID, req.CurrencyID)
+	if err != nil {
+		return nil, err
+	}
+
+	spending, err := uc.benchmarkRepo.SumByUserAndCategory(ctx, currency.Code(), startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	peers, benchmarks := finance.BenchmarkCategories(finance.NewUserID(userID), spending)
+
+	response := &SpendingBenchmarksResponse{
+		CurrencyCode: currency.Code(),
+		Months:       months,
+		StartDate:    startDate.Format("2006-01-02"),
+		EndDate:      endDate.Format("2006-01-02"),
+		Categories:   []CategoryBenchmark{},
+	}
+	if peers < finance.MinBenchmarkPeers {
+		return response, nil
+	}
+	response.PeerCount = &peers
+
+	categories, err := uc.categoryService.GetCategoriesByUser(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int]string, len(categories))
+	copies := make(map[int]int) // default category ID to the ID of the user's copy
+	for _, category := range categories {
+		names[category.ID().Value()] = category.Name()
+		if source := category.SourceID(); source != nil {
+			names[source.Value()] = category.Name()
+			copies[source.Value()] = category.ID().Value()
+		}
+	}
+
+	for _, benchmark := range benchmarks {
+		categoryID := benchmark.CategoryID.Value()
+		if copyID, ok := copies[categoryID]; ok {
+			categoryID = copyID
+		}
+		response.Categories = append(response.Categories, CategoryBenchmark{
+			CategoryID:        categoryID,
+			CategoryName:      names[benchmark.CategoryID.Value()],
+			MonthlySpend:      roundAmount(benchmark.Amount / float64(months)),
+			PeerMonthlyMedian: roundAmount(benchmark.PeerMedian / float64(months)),
+			Percentile:        benchmark.Percentile,
+		})
+	}
+	return response, nil
+}
@@ -0,0 +1,159 @@
+package finance
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"time"
+)
+
+// rollingAverageWindow is how many periods, the current one included, each
+// rolling average spans
+const rollingAverageWindow = 3
+
+// SpendingByPeriodRequest represents the periods and currency of a spending series
+type SpendingByPeriodRequest struct {
+	Period     string `form:"period" binding:"omitempty,oneof=weekly monthly"` // defaults to monthly
+	Periods    int    `form:"periods" binding:"omitempty,min=2,max=36"`        // defaults to 12
+	CurrencyID int    `form:"currency_id"`                                     // defaults to the user's default currency
+}
+
+// SpendingByPeriodResponse represents the user's spending in each of the latest
+// periods, oldest first, ending with the current one
+type SpendingByPeriodResponse struct {
+	CurrencyID   int              `json:"currency_id"`
+	CurrencyCode string           `json:"currency_code"`
+	Period       string           `json:"period"`
+	Periods      []PeriodSpending `json:"periods"`
+	// TrendSlope is how much the trend line rises (or falls, when negative) each period
+	TrendSlope float64 `json:"trend_slope"`
+}
+
+// PeriodSpending represents the expenses of one period. RollingAverage averages
+// it with the two periods before it; Trend is the value of the least squares
+// line fitted through every period's total.
+type PeriodSpending struct {
+	StartDate        string  `json:"start_date"`
+	EndDate          string  `json:"end_date"`
+	TotalSpent       float64 `json:"total_spent"`
+	TransactionCount int     `json:"transaction_count"`
+	RollingAverage   float64 `json:"rolling_average"`
+	Trend            float64 `json:"trend"`
+}
+
+// SpendingByPeriodUseCase handles the spending series with its smoothing, read
+// from the daily totals of the analytics read model
+type SpendingByPeriodUseCase struct {
+	projector       *AnalyticsProjector
+	currencyService *finance.CurrencyService
+}
+
+// NewSpendingByPeriodUseCase creates a new spending by period use case
+func NewSpendingByPeriodUseCase(projector *AnalyticsProjector, currencyService *finance.CurrencyService) *SpendingByPeriodUseCase {
+	return &SpendingByPeriodUseCase{
+		projector:       projector,
+		currencyService: currencyService,
+	}
+}
+
+// Execute totals the user's expenses in one currency per calendar month or
+// Monday-to-Sunday week. The current period is still running, so its total and
+// the trend through it tend to be low until it ends.
+func (uc *SpendingByPeriodUseCase) Execute(ctx context.Context, userID int, req SpendingByPeriodRequest) (*SpendingByPeriodResponse, error) {
+	if req.Period == "" {
+		req.Period = "monthly"
+	}
+	if req.Periods == 0 {
+		req.Periods = 12
+	}
+
+	currency, err := userCurrency(ctx, uc.currencyService, userID, req.CurrencyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The periods before the first one are only fetched to fill its rolling average
+	now := time.Now().UTC()
+	var current time.Time
+	next := func(start time.Time, n int) time.Time { return start.AddDate(0, n, 0) }
+	if req.Period == "weekly" {
+		current = time.Date(now.Year(), now.Month(), now.Day()-mondayIndex(now.Weekday()), 0, 0, 0, 0, time.UTC)
+		next = func(start time.Time, n int) time.Time { return start.AddDate(0, 0, 7*n) }
+	} else {
+		current = finance.MonthStart(now)
+	}
+	count := req.Periods + rollingAverageWindow - 1
+	starts := make([]time.Time, count+1)
+	for i := range starts {
+		starts[i] = next(current, i-count+1)
+	}
+
+	dailyTotals, err := uc.projector.DailyTotals(ctx, finance.NewUserID(userID), starts[0], starts[count].Add(-time.Nanosecond))
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make([]float64, count)
+	counts := make([]int, count)
+	for _, daily := range dailyTotals {
+		if daily.Type != finance.TransactionTypeExpense || daily.CurrencyID != currency.ID() {
+			continue
+		}
+		for i := count - 1; i >= 0; i-- {
+			if !daily.Date.Before(starts[i]) {
+				totals[i] += daily.Amount
+				counts[i] += daily.Count
+				break
+			}
+		}
+	}
+
+	offset := rollingAverageWindow - 1
+	intercept, slope := linearTrend(totals[offset:])
+	periods := make([]PeriodSpending, req.Periods)
+	for i := range periods {
+		var window float64
+		for _, total := range totals[i : i+rollingAverageWindow] {
+			window += total
+		}
+		periods[i] = PeriodSpending{
+			StartDate:        starts[i+offset].Format("2006-01-02"),
+			EndDate:          starts[i+offset+1].AddDate(0, 0, -1).Format("2006-01-02"),
+			TotalSpent:       roundAmount(totals[i+offset]),
+			TransactionCount: counts[i+offset],
+			RollingAverage:   roundAmount(window / rollingAverageWindow),
+			Trend:            roundAmount(intercept + slope*float64(i)),
+		}
+	}
+
+	return &SpendingByPeriodResponse{
+		CurrencyID:   currency.ID().Value(),
+		CurrencyCode: currency.Code(),
+		Period:       req.Period,
+		Periods:      periods,
+		TrendSlope:   roundAmount(slope),
+	}, nil
+}
+
+// linearTrend fits a least squares line through values at x = 0, 1, 2, ... and
+// returns its intercept and slope
+func linearTrend(values []float64) (float64, float64) {
+	n := float64(len(values))
+	if n == 0 {
+		return 0, 0
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	for i, value := range values {
+		x := float64(i)
+		sumX += x
+		sumY += value
+		sumXY += x * value
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return sumY / n, 0
+	}
+	slope := (n*sumXY - sumX*sumY) / denominator
+	return (sumY - slope*sumX) / n, slope
+}
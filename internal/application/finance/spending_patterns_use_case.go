@@ -0,0 +1,205 @@
+package finance
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"strings"
+	"time"
+)
+
+// defaultPatternWeeks is how many weeks up to today spending patterns cover by
+// default; whole weeks give every weekday the same number of days
+const defaultPatternWeeks = 12
+
+// SpendingPatternsRequest represents the period, currency and time zone of the
+// spending patterns
+type SpendingPatternsRequest struct {
+	StartDate  string `form:"start_date"`  // YYYY-MM-DD; defaults to 12 weeks before the end date
+	EndDate    string `form:"end_date"`    // YYYY-MM-DD; defaults to today
+	CurrencyID int    `form:"currency_id"` // defaults to the user's default currency
+	Timezone   string `form:"timezone"`    // IANA name the hours are in; defaults to UTC
+}
+
+// SpendingPatternsResponse represents the user's expenses grouped by day of the
+// week and hour of the day
+type SpendingPatternsResponse struct {
+	CurrencyID   int               `json:"currency_id"`
+	CurrencyCode string            `json:"currency_code"`
+	StartDate    string            `json:"start_date"`
+	EndDate      string            `json:"end_date"`
+	Timezone     string            `json:"timezone"`
+	ByWeekday    []WeekdaySpending `json:"by_weekday"`
+	ByHour       []HourSpending    `json:"by_hour"`
+	TotalSpent   float64           `json:"total_spent"`
+	// Average spend per calendar day on weekdays (Monday to Friday) and weekends
+	WeekdayDailyAverage float64 `json:"weekday_daily_average"`
+	WeekendDailyAverage float64 `json:"weekend_daily_average"`
+}
+
+// WeekdaySpending represents the expenses dated on one day of the week. DailyAverage
+// spreads the total over every such day in the period, including days without spending.
+type WeekdaySpending struct {
+	Weekday          string  `json:"weekday"`
+	TotalSpent       float64 `json:"total_spent"`
+	TransactionCount int     `json:"transaction_count"`
+	DailyAverage     float64 `json:"daily_average"`
+}
+
+// HourSpending represents the expenses recorded in one hour of the day
+type HourSpending struct {
+	Hour             int     `json:"hour"`
+	TotalSpent       float64 `json:"total_spent"`
+	TransactionCount int     `json:"transaction_count"`
+}
+
+// SpendingPatternsUseCase handles grouping spending by when it happens
+type SpendingPatternsUseCase struct {
+	transactionService *finance.TransactionService
+	currencyService    *finance.CurrencyService
+}
+
+// NewSpendingPatternsUseCase creates a new spending patterns use case
+func NewSpendingPatternsUseCase(transactionService *finance.TransactionService, currencyService *finance.CurrencyService) *SpendingPatternsUseCase {
+	return &SpendingPatternsUseCase{
+		transactionService: transactionService,
+		currencyService:    currencyService,
+	}
+}
+
+// Execute groups the user's expenses in one currency by weekday and by hour.
+// Weekdays come from the date of each expense. Expenses only have a date, so hours
+// come from when each one was recorded, in the requested time zone; expenses
+// entered long after they happened count in the hour they were entered.
+func (uc *SpendingPatternsUseCase) Execute(ctx context.Context, userID int, req SpendingPatternsRequest) (*SpendingPatternsResponse, error) {
+	location := time.UTC
+	if req.Timezone != "" {
+		loaded, err := time.LoadLocation(req.Timezone)
+		if err != nil {
+			return nil, finance.ErrInvalidTimezone
+		}
+		location = loaded
+	}
+
+	now := time.Now().In(location)
+	endDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if req.EndDate != "" {
+		parsed, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			return nil, finance.ErrInvalidDateRange
+		}
+		endDate = parsed
+	}
+	startDate := endDate.AddDate(0, 0, -7*defaultPatternWeeks+1)
+	if req.StartDate != "" {
+		parsed, err := time.Parse("2006-01-02", req.StartDate)
+		if err != nil {
+			return nil, finance.ErrInvalidDateRange
+		}
+		startDate = parsed
+	}
+	if startDate.After(endDate) {
+		return nil, finance.ErrInvalidDateRange
+	}
+
+	currency, err := userCurrency(ctx, uc.currencyService, userID, req.CurrencyID)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := uc.transactionService.GetTransactionsByUserAndDateRange(ctx, finance.NewUserID(userID), startDate, endDate.Add(24*time.Hour-time.Nanosecond))
+	if err != nil {
+		return nil, err
+	}
+
+	// Monday first
+	byWeekday := make([]WeekdaySpending, 7)
+	for i := range byWeekday {
+		byWeekday[i].Weekday = strings.ToLower(time.Weekday((i + 1) % 7).String())
+	}
+	byHour := make([]HourSpending, 24)
+	for hour := range byHour {
+		byHour[hour].Hour = hour
+	}
+
+	var total float64
+	for _, transaction := range transactions {
+		if transaction.Type() != finance.TransactionTypeExpense || transaction.CurrencyID() != currency.ID() {
+			continue
+		}
+		amount := transaction.Amount().Amount()
+		total += amount
+
+		weekday := &byWeekday[mondayIndex(transaction.Date().Weekday())]
+		weekday.TotalSpent += amount
+		weekday.TransactionCount++
+
+		hour := &byHour[transaction.CreatedAt().In(location).Hour()]
+		hour.TotalSpent += amount
+		hour.TransactionCount++
+	}
+
+	days := make([]int, 7)
+	for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+		days[mondayIndex(date.Weekday())]++
+	}
+	var weekdayTotal, weekendTotal float64
+	var weekdayDays, weekendDays int
+	for i := range byWeekday {
+		byWeekday[i].TotalSpent = roundAmount(byWeekday[i].TotalSpent)
+		if days[i] > 0 {
+			byWeekday[i].DailyAverage = roundAmount(byWeekday[i].TotalSpent / float64(days[i]))
+		}
+		if i < 5 {
+			weekdayTotal += byWeekday[i].TotalSpent
+			weekdayDays += days[i]
+		} else {
+			weekendTotal += byWeekday[i].TotalSpent
+			weekendDays += days[i]
+		}
+	}
+	for hour := range byHour {
+		byHour[hour].TotalSpent = roundAmount(byHour[hour].TotalSpent)
+	}
+
+	response := &SpendingPatternsResponse{
+		CurrencyID:   currency.ID().Value(),
+		CurrencyCode: currency.Code(),
+		StartDate:    startDate.Format("2006-01-02"),
+		EndDate:      endDate.Format("2006-01-02"),
+		Timezone:     location.String(),
+		ByWeekday:    byWeekday,
+		ByHour:       byHour,
+		TotalSpent:   roundAmount(total),
+	}
+	if weekdayDays > 0 {
+		response.WeekdayDailyAverage = roundAmount(weekdayTotal / float64(weekdayDays))
+	}
+	if weekendDays > 0 {
+		response.WeekendDailyAverage = roundAmount(weekendTotal / float64(weekendDays))
+	}
+	return response, nil
+}
+
+// userCurrency returns the requested currency of the user's, or their default
+// currency when none is requested
+func userCurrency(ctx context.Context, currencyService *finance.CurrencyService, userID, currencyID int) (*finance.Currency, error) {
+	if currencyID == 0 {
+		return currencyService.GetDefaultCurrency(ctx, finance.NewUserID(userID))
+	}
+
+	currencies, err := currencyService.GetCurrenciesByUser(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	for _, currency := range currencies {
+		if currency.ID().Value() == currencyID {
+			return currency, nil
+		}
+	}
+	return nil, finance.ErrCurrencyNotFound
+}
+
+// mondayIndex numbers the days of the week from Monday (0) to Sunday (6)
+func mondayIndex(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
@@ -0,0 +1,158 @@
+package finance
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"time"
+)
+
+// DeletedRecordResponse identifies a record deleted since the last sync
+type DeletedRecordResponse struct {
+	Type      string `json:"type"` // expense, income, budget or category
+	ID        int    `json:"id"`
+	DeletedAt string `json:"deleted_at"`
+}
+
+// SyncResponse represents the records created, updated and deleted since a
+// sync. Clients remove the deleted records first and then store the changed
+// ones, since a record deleted and restored by undo is in both lists.
+type SyncResponse struct {
+	Transactions []TransactionResponse   `json:"transactions"`
+	Budgets      []BudgetResponse        `json:"budgets"`
+	Categories   []CategoryResponse      `json:"categories"`
+	Deleted      []DeletedRecordResponse `json:"deleted"`
+	SyncedAt     time.Time               `json:"synced_at"`
+	// NextCursor is passed back on the next sync; it is set by the handler
+	NextCursor string `json:"next_cursor"`
+}
+
+// SyncUseCase handles the change feed offline-first clients sync from
+type SyncUseCase struct {
+	transactionRepo finance.TransactionRepository
+	budgetRepo      finance.BudgetRepository
+	categoryRepo    finance.CategoryRepository
+	tombstoneRepo   finance.TombstoneRepository
+}
+
+// NewSyncUseCase creates a new sync use case
+func NewSyncUseCase(
+	transactionRepo finance.TransactionRepository,
+	budgetRepo finance.BudgetRepository,
+	categoryRepo finance.CategoryRepository,
+	tombstoneRepo finance.TombstoneRepository,
+) *SyncUseCase {
+	return &SyncUseCase{
+		transactionRepo: transactionRepo,
+		budgetRepo:      budgetRepo,
+		categoryRepo:    categoryRepo,
+		tombstoneRepo:   tombstoneRepo,
+	}
+}
+
+// Execute returns the user's transactions, budgets and categories created or
+// updated at or after since, and the records deleted since then. A zero since
+// returns every record and no deletions, for a client's first sync. SyncedAt is
+// taken before reading, so changes made while the sync runs are sent again.
+func (uc *SyncUseCase) Execute(ctx context.Context, userID int, since time.Time) (*SyncResponse, error) {
+	userIDDomain := finance.NewUserID(userID)
+	response := &SyncResponse{
+		Transactions: []TransactionResponse{},
+		Budgets:      []BudgetResponse{},
+		Categories:   []CategoryResponse{},
+		Deleted:      []DeletedRecordResponse{},
+		SyncedAt:     time.Now(),
+	}
+
+	// Transactions and budgets name their category, which may not have changed
+	categories, err := uc.categoryRepo.FindByUserID(ctx, userIDDomain)
+	if err != nil {
+		return nil, err
+	}
+	categoryResponses := make(map[int]CategoryResponse, len(categories))
+	for _, category := range categories {
+		categoryResponses[category.ID().Value()] = CategoryResponse{
+			ID:             category.ID().Value(),
+			Name:           category.Name(),
+			Color:          category.Color(),
+			Type:           string(category.Type()),
+			IsDefault:      category.IsDefault(),
+			TranslationKey: category.TranslationKey(),
+		}
+	}
+
+	changedCategories, err := uc.categoryRepo.FindByUserIDUpdatedSince(ctx, userIDDomain, since)
+	if err != nil {
+		return nil, err
+	}
+	for _, category := range changedCategories {
+		response.Categories = append(response.Categories, categoryResponses[category.ID().Value()])
+	}
+
+	transactions, err := uc.transactionRepo.FindByUserIDUpdatedSince(ctx, userIDDomain, since)
+	if err != nil {
+		return nil, err
+	}
+	for _, transaction := range transactions {
+		response.Transactions = append(response.Transactions, TransactionResponse{
+			ID:          transaction.ID().Value(),
+			UserID:      transaction.UserID().Value(),
+			Category:    categoryResponses[transaction.CategoryID().Value()],
+			CurrencyID:  transaction.CurrencyID().Value(),
+			AccountID:   transaction.AccountID().Value(),
+			Amount:      transaction.Amount().Amount(),
+			Description: transaction.Description(),
+			Date:        transaction.Date().Format("2006-01-02"),
+			Type:        string(transaction.Type()),
+			Status:      string(transaction.Status()),
+			Version:     transaction.Version(),
+			ClientID:    transaction.ClientID(),
+			CreatedAt:   transaction.CreatedAt().Format(time.RFC3339),
+
+			TaxDeductible:    transaction.TaxDeductible(),
+			ReceiptReference: transaction.ReceiptReference(),
+			Latitude:         latitudeOf(transaction),
+			Longitude:        longitudeOf(transaction),
+		})
+	}
+
+	budgets, err := uc.budgetRepo.FindByUserIDUpdatedSince(ctx, userIDDomain, since)
+	if err != nil {
+		return nil, err
+	}
+	for _, budget := range budgets {
+		var category *CategoryResponse
+		if found, ok := categoryResponses[budget.CategoryID().Value()]; ok {
+			category = &found
+		}
+		response.Budgets = append(response.Budgets, BudgetResponse{
+			ID:        budget.ID().Value(),
+			UserID:    budget.UserID().Value(),
+			Amount:    budget.Amount().Amount(),
+			Period:    string(budget.Period()),
+			StartDate: budget.StartDate().Format("2006-01-02"),
+			EndDate:   budget.EndDate().Format("2006-01-02"),
+			Prorated:  budget.Prorated(),
+			Version:   budget.Version(),
+			CreatedAt: budget.CreatedAt().Format(time.RFC3339),
+			Category:  category,
+		})
+	}
+
+	// A first sync has nothing to delete
+	if since.IsZero() {
+		return response, nil
+	}
+	tombstones, err := uc.tombstoneRepo.FindByUserIDSince(ctx, userIDDomain, since)
+	if err != nil {
+		return nil, err
+	}
+	for _, tombstone := range tombstones {
+		response.Deleted = append(response.Deleted, DeletedRecordResponse{
+			Type:      string(tombstone.Kind()),
+			ID:        tombstone.RecordID(),
+			DeletedAt: tombstone.DeletedAt().UTC().Format(time.RFC3339),
+		})
+	}
+
+	return response, nil
+}
@@ -0,0 +1,161 @@
+package finance
+
+import (
+	"context"
+	"math"
+	"panda-pocket/internal/domain/finance"
+)
+
+// SetCategoryTaxDeductibleRequest represents the request to mark or unmark a category as tax-deductible
+type SetCategoryTaxDeductibleRequest struct {
+	TaxDeductible *bool `json:"tax_deductible" binding:"required"`
+}
+
+// UpdateExpenseTaxDetailsRequest represents the request to mark an expense as tax-deductible
+type UpdateExpenseTaxDetailsRequest struct {
+	TaxDeductible    *bool  `json:"tax_deductible" binding:"required"`
+	ReceiptReference string `json:"receipt_reference" binding:"max=255"`
+}
+
+// TaxReportResponse represents a user's deductible expenses for a calendar year
+type TaxReportResponse struct {
+	Year             int                   `json:"year"`
+	Categories       []TaxCategoryResponse `json:"categories"`
+	Totals           []TaxTotalResponse    `json:"totals"`
+	TransactionCount int                   `json:"transaction_count"`
+	MissingReceipts  int                   `json:"missing_receipts"`
+}
+
+// TaxCategoryResponse represents the deductible total of one category in one currency
+type TaxCategoryResponse struct {
+	CategoryID       int                      `json:"category_id"`
+	CategoryName     string                   `json:"category_name"`
+	CurrencyID       int                      `json:"currency_id"`
+	Total            float64                  `json:"total"`
+	TransactionCount int                      `json:"transaction_count"`
+	Transactions     []TaxTransactionResponse `json:"transactions"`
+}
+
+// TaxTransactionResponse represents a deductible expense and its receipt reference
+type TaxTransactionResponse struct {
+	ID               int     `json:"id"`
+	Date             string  `json:"date"`
+	Amount           float64 `json:"amount"`
+	Description      string  `json:"description"`
+	ReceiptReference string  `json:"receipt_reference"`
+}
+
+// TaxTotalResponse represents the deductible total in one currency
+type TaxTotalResponse struct {
+	CurrencyID int     `json:"currency_id"`
+	Total      float64 `json:"total"`
+}
+
+// TaxDeductionsUseCase handles tax-deductible tagging and the annual tax report
+type TaxDeductionsUseCase struct {
+	taxService      *finance.TaxService
+	categoryService *finance.CategoryService
+}
+
+// NewTaxDeductionsUseCase creates a new tax deductions use case
+func NewTaxDeductionsUseCase(taxService *finance.TaxService, categoryService *finance.CategoryService) *TaxDeductionsUseCase {
+	return &TaxDeductionsUseCase{
+		taxService:      taxService,
+		categoryService: categoryService,
+	}
+}
+
+// SetCategoryDeductible marks or unmarks one of the user's expense categories, or a default one, as tax-deductible
+func (uc *TaxDeductionsUseCase) SetCategoryDeductible(ctx context.Context, userID, categoryID int, req SetCategoryTaxDeductibleRequest) (*CategoryResponse, error) {
+	category, err := uc.taxService.SetCategoryDeductible(ctx, finance.NewUserID(userID), finance.NewCategoryID(categoryID), *req.TaxDeductible)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CategoryResponse{
+		ID:            category.ID().Value(),
+		Name:          category.Name(),
+		Color:         category.Color(),
+		Type:          string(category.Type()),
+		IsDefault:     category.IsDefault(),
+		TaxDeductible: *req.TaxDeductible,
+	}, nil
+}
+
+// UpdateExpenseTaxDetails marks one of the user's expenses as tax-deductible and records its receipt reference
+func (uc *TaxDeductionsUseCase) UpdateExpenseTaxDetails(ctx context.Context, userID, transactionID int, req UpdateExpenseTaxDetailsRequest) (*finance.Transaction, error) {
+	return uc.taxService.UpdateExpenseTaxDetails(
+		ctx,
+		finance.NewTransactionID(transactionID),
+		finance.NewUserID(userID),
+		*req.TaxDeductible,
+		req.ReceiptReference,
+	)
+}
+
+// Report summarizes the user's deductible expenses for the year by category
+func (uc *TaxDeductionsUseCase) Report(ctx context.Context, userID, year int) (*TaxReportResponse, error) {
+	report, err := uc.taxService.GetTaxReport(ctx, finance.NewUserID(userID), year)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := uc.categoryService.GetCategoriesByUser(ctx, finance.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	categoryNames := make(map[int]string, len(categories))
+	for _, category := range categories {
+		categoryNames[category.ID().Value()] = category.Name()
+	}
+
+	response := &TaxReportResponse{
+		Year:            report.Year,
+		Categories:      make([]TaxCategoryResponse, len(report.Entries)),
+		Totals:          []TaxTotalResponse{},
+		MissingReceipts: report.MissingReceipts(),
+	}
+	totals := make(map[int]int) // currency ID to index in Totals
+	for i, entry := range report.Entries {
+		transactions := make([]TaxTransactionResponse, len(entry.Transactions))
+		for j, transaction := range entry.Transactions {
+			transactions[j] = toTaxTransactionResponse(transaction)
+		}
+
+		response.Categories[i] = TaxCategoryResponse{
+			CategoryID:       entry.CategoryID.Value(),
+			CategoryName:     categoryNames[entry.CategoryID.Value()],
+			CurrencyID:       entry.CurrencyID.Value(),
+			Total:            entry.Total,
+			TransactionCount: len(entry.Transactions),
+			Transactions:     transactions,
+		}
+		response.TransactionCount += len(entry.Transactions)
+
+		index, ok := totals[entry.CurrencyID.Value()]
+		if !ok {
+			index = len(response.Totals)
+			totals[entry.CurrencyID.Value()] = index
+			response.Totals = append(response.Totals, TaxTotalResponse{CurrencyID: entry.CurrencyID.Value()})
+		}
+		response.Totals[index].Total = roundAmount(response.Totals[index].Total + entry.Total)
+	}
+
+	return response, nil
+}
+
+// toTaxTransactionResponse converts a deductible expense to its tax report form
+func toTaxTransactionResponse(transaction *finance.Transaction) TaxTransactionResponse {
+	return TaxTransactionResponse{
+		ID:               transaction.ID().Value(),
+		Date:             transaction.Date().Format("2006-01-02"),
+		Amount:           transaction.Amount().Amount(),
+		Description:      transaction.Description(),
+		ReceiptReference: transaction.ReceiptReference(),
+	}
+}
+
+// roundAmount rounds to two decimals, so summed report totals print cleanly
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
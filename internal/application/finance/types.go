@@ -1,14 +1,43 @@
 package finance
 
+import "panda-pocket/internal/domain/finance"
+
 // TransactionResponse represents a transaction in the response
 type TransactionResponse struct {
-	ID          int              `json:"id"`
-	UserID      int              `json:"user_id"`
-	Category    CategoryResponse `json:"category"`
-	CurrencyID  int              `json:"currency_id"`
-	Amount      float64          `json:"amount"`
-	Description string           `json:"description"`
-	Date        string           `json:"date"`
-	Type        string           `json:"type"`
-	CreatedAt   string           `json:"created_at"`
+	ID               int              `json:"id"`
+	UserID           int              `json:"user_id"`
+	Category         CategoryResponse `json:"category"`
+	CurrencyID       int              `json:"currency_id"`
+	AccountID        int              `json:"account_id,omitempty"`
+	Amount           float64          `json:"amount"`
+	Description      string           `json:"description"`
+	Date             string           `json:"date"`
+	Type             string           `json:"type"`
+	Status           string           `json:"status"`
+	TaxDeductible    bool             `json:"tax_deductible,omitempty"`
+	ReceiptReference string           `json:"receipt_reference,omitempty"`
+	Version          int              `json:"version,omitempty"` // sent back on update to detect conflicting edits
+	ClientID         string           `json:"client_id,omitempty"`
+	CreatedAt        string           `json:"created_at"`
+	// Unset when the client did not say where the transaction was made
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+}
+
+// latitudeOf returns where a transaction was made, or nil when that is unknown
+func latitudeOf(transaction *finance.Transaction) *float64 {
+	if transaction.Location() == nil {
+		return nil
+	}
+	latitude := transaction.Location().Latitude()
+	return &latitude
+}
+
+// longitudeOf returns where a transaction was made, or nil when that is unknown
+func longitudeOf(transaction *finance.Transaction) *float64 {
+	if transaction.Location() == nil {
+		return nil
+	}
+	longitude := transaction.Location().Longitude()
+	return &longitude
 }
@@ -0,0 +1,36 @@
+package finance
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+)
+
+// UndoActionUseCase handles reversing a recent update or delete of a transaction or budget
+type UndoActionUseCase struct {
+	actionService *finance.ActionService
+	unitOfWork    finance.UnitOfWork
+}
+
+// NewUndoActionUseCase creates a new undo action use case
+func NewUndoActionUseCase(actionService *finance.ActionService, unitOfWork finance.UnitOfWork) *UndoActionUseCase {
+	return &UndoActionUseCase{
+		actionService: actionService,
+		unitOfWork:    unitOfWork,
+	}
+}
+
+// Execute restores the changed record and marks the action undone in one transaction
+func (uc *UndoActionUseCase) Execute(ctx context.Context, userID, actionID int) (*ActionResponse, error) {
+	var action *finance.Action
+	err := uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		action, err = uc.actionService.Undo(ctx, finance.NewActionID(actionID), finance.NewUserID(userID))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := newActionResponse(action)
+	return &response, nil
+}
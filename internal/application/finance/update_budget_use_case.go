@@ -13,6 +13,9 @@ type UpdateBudgetResponse struct {
 	Period    string           `json:"period"`
 	StartDate string           `json:"start_date"`
 	EndDate   string           `json:"end_date"`
+	Prorated  bool             `json:"prorated"`
+	Allowance float64          `json:"allowance"`
+	Version   int              `json:"version"`
 	Category  *CategoryResponse `json:"category"`
 }
 
@@ -20,17 +23,20 @@ type UpdateBudgetResponse struct {
 type UpdateBudgetUseCase struct {
 	budgetService   *finance.BudgetService
 	categoryService *finance.CategoryService
+	unitOfWork      finance.UnitOfWork
 }
 
 // NewUpdateBudgetUseCase creates a new update budget use case
-func NewUpdateBudgetUseCase(budgetService *finance.BudgetService, categoryService *finance.CategoryService) *UpdateBudgetUseCase {
+func NewUpdateBudgetUseCase(budgetService *finance.BudgetService, categoryService *finance.CategoryService, unitOfWork finance.UnitOfWork) *UpdateBudgetUseCase {
 	return &UpdateBudgetUseCase{
 		budgetService:   budgetService,
 		categoryService: categoryService,
+		unitOfWork:      unitOfWork,
 	}
 }
 
-// Execute updates a budget
+// Execute updates a budget. A non-nil expectedVersion must match the stored
+// version, or finance.ErrVersionConflict is returned.
 func (uc *UpdateBudgetUseCase) Execute(
 	ctx context.Context,
 	budgetIDStr string,
@@ -40,6 +46,8 @@ func (uc *UpdateBudgetUseCase) Execute(
 	periodStr string,
 	startDateStr string,
 	endDateStr string,
+	prorate *bool,
+	expectedVersion *int,
 ) (*UpdateBudgetResponse, error) {
 	// Parse budget ID
 	budgetIDInt, err := strconv.Atoi(budgetIDStr)
@@ -81,17 +89,24 @@ func (uc *UpdateBudgetUseCase) Execute(
 	}
 	period := finance.BudgetPeriod(periodStr)
 
-	// Update budget
-	updatedBudget, err := uc.budgetService.UpdateBudget(
-		ctx,
-		budgetID,
-		userIDDomain,
-		finance.NewCategoryID(categoryIDInt),
-		amountDomain,
-		period,
-		startDate,
-		endDate,
-	)
+	// Update budget; the update and its undo record share one transaction
+	var updatedBudget *finance.Budget
+	err = uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		updatedBudget, err = uc.budgetService.UpdateBudget(
+			ctx,
+			budgetID,
+			userIDDomain,
+			finance.NewCategoryID(categoryIDInt),
+			amountDomain,
+			period,
+			startDate,
+			endDate,
+			prorate,
+			expectedVersion,
+		)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -114,6 +129,9 @@ func (uc *UpdateBudgetUseCase) Execute(
 		Period:    string(updatedBudget.Period()),
 		StartDate: updatedBudget.StartDate().Format("2006-01-02"),
 		EndDate:   updatedBudget.EndDate().Format("2006-01-02"),
+		Prorated:  updatedBudget.Prorated(),
+		Allowance: updatedBudget.Allowance(),
+		Version:   updatedBudget.Version(),
 		Category:  categoryResponse,
 	}, nil
 }
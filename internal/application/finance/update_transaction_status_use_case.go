@@ -0,0 +1,45 @@
+package finance
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+)
+
+// UpdateTransactionStatusRequest represents the request to change a transaction's reconciliation status
+type UpdateTransactionStatusRequest struct {
+	Status string `json:"status" binding:"required"`
+}
+
+// UpdateTransactionStatusUseCase handles marking a single transaction cleared, reconciled or uncleared
+type UpdateTransactionStatusUseCase struct {
+	transactionService *finance.TransactionService
+}
+
+// NewUpdateTransactionStatusUseCase creates a new update transaction status use case
+func NewUpdateTransactionStatusUseCase(transactionService *finance.TransactionService) *UpdateTransactionStatusUseCase {
+	return &UpdateTransactionStatusUseCase{
+		transactionService: transactionService,
+	}
+}
+
+// Execute sets the status of the user's transaction of the expected type
+func (uc *UpdateTransactionStatusUseCase) Execute(
+	ctx context.Context,
+	transactionID int,
+	userID int,
+	expectedType finance.TransactionType,
+	req UpdateTransactionStatusRequest,
+) (*finance.Transaction, error) {
+	status, err := finance.ParseReconciliationStatus(req.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.transactionService.UpdateTransactionStatus(
+		ctx,
+		finance.NewTransactionID(transactionID),
+		finance.NewUserID(userID),
+		expectedType,
+		status,
+	)
+}
@@ -4,22 +4,24 @@ import (
 	"context"
 	"panda-pocket/internal/domain/finance"
 	"strconv"
-	"time"
 )
 
 // UpdateTransactionUseCase handles transaction updates
 type UpdateTransactionUseCase struct {
 	transactionService *finance.TransactionService
+	unitOfWork         finance.UnitOfWork
 }
 
 // NewUpdateTransactionUseCase creates a new update transaction use case
-func NewUpdateTransactionUseCase(transactionService *finance.TransactionService) *UpdateTransactionUseCase {
+func NewUpdateTransactionUseCase(transactionService *finance.TransactionService, unitOfWork finance.UnitOfWork) *UpdateTransactionUseCase {
 	return &UpdateTransactionUseCase{
 		transactionService: transactionService,
+		unitOfWork:         unitOfWork,
 	}
 }
 
-// Execute updates a transaction
+// Execute updates a transaction. A non-nil expectedVersion must match the stored
+// version, or finance.ErrVersionConflict is returned.
 func (uc *UpdateTransactionUseCase) Execute(
 	ctx context.Context,
 	transactionIDStr string,
@@ -30,6 +32,7 @@ func (uc *UpdateTransactionUseCase) Execute(
 	description string,
 	dateStr string,
 	expectedType finance.TransactionType,
+	expectedVersion *int,
 ) (*finance.Transaction, error) {
 	// Parse transaction ID
 	transactionIDInt, err := strconv.Atoi(transactionIDStr)
@@ -49,8 +52,7 @@ func (uc *UpdateTransactionUseCase) Execute(
 		return nil, err
 	}
 
-	// Parse date
-	date, err := time.Parse("2006-01-02", dateStr)
+	date, err := parseTransactionDate(dateStr)
 	if err != nil {
 		return nil, err
 	}
@@ -65,16 +67,26 @@ func (uc *UpdateTransactionUseCase) Execute(
 		return nil, err
 	}
 
-	// Update transaction
-	return uc.transactionService.UpdateTransaction(
-		ctx,
-		transactionID,
-		userIDDomain,
-		categoryID,
-		currencyID,
-		amountDomain,
-		description,
-		date,
-		expectedType,
-	)
+	// Update transaction; the update and its undo record share one transaction
+	var transaction *finance.Transaction
+	err = uc.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		transaction, err = uc.transactionService.UpdateTransaction(
+			ctx,
+			transactionID,
+			userIDDomain,
+			categoryID,
+			currencyID,
+			amountDomain,
+			description,
+			date,
+			expectedType,
+			expectedVersion,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
 }
@@ -0,0 +1,46 @@
+package identity
+
+import (
+	"context"
+	"panda-pocket/internal/domain/identity"
+)
+
+// DeactivateUserUseCase handles admins deactivating and reactivating accounts
+type DeactivateUserUseCase struct {
+	userService *identity.UserService
+}
+
+// NewDeactivateUserUseCase creates a new deactivate user use case
+func NewDeactivateUserUseCase(userService *identity.UserService) *DeactivateUserUseCase {
+	return &DeactivateUserUseCase{
+		userService: userService,
+	}
+}
+
+// Execute deactivates a user on behalf of an admin. The user's data is kept,
+// but they can no longer sign in and their existing tokens stop working.
+func (uc *DeactivateUserUseCase) Execute(ctx context.Context, adminID, userID int) (*AdminUserResponse, error) {
+	user, err := uc.userService.DeactivateUser(ctx, identity.NewUserID(adminID), identity.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	response := newAdminUserResponse(user)
+	return &response, nil
+}
+
+// Reactivate lets a deactivated user sign in again
+func (uc *DeactivateUserUseCase) Reactivate(ctx context.Context, userID int) (*AdminUserResponse, error) {
+	user, err := uc.userService.ReactivateUser(ctx, identity.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	response := newAdminUserResponse(user)
+	return &response, nil
+}
+
+// IsActive reports whether a signed-in user's account may still use the API
+func (uc *DeactivateUserUseCase) IsActive(ctx context.Context, userID int) (bool, error) {
+	return uc.userService.IsUserActive(ctx, identity.NewUserID(userID))
+}
@@ -0,0 +1,163 @@
+package identity
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"panda-pocket/internal/domain/identity"
+	"time"
+)
+
+// Mailer sends email with plain-text and HTML bodies
+type Mailer interface {
+	SendHTML(ctx context.Context, to, subject, text, html string) error
+}
+
+// TemplateRenderer renders the subject and bodies of an email from its template
+type TemplateRenderer interface {
+	Render(name string, data interface{}) (subject, text, html string, err error)
+}
+
+// passwordResetEmail is the data of the password reset email template
+type passwordResetEmail struct {
+	Link      string
+	ExpiresIn string
+}
+
+// ForgotPasswordRequest represents a request for a password reset email
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ForgotPasswordUseCase emails a password reset link. The caller gets the same
+// answer whether or not the address belongs to an account, so the endpoint
+// cannot be used to find out who is registered.
+type ForgotPasswordUseCase struct {
+	userService *identity.UserService
+	resetRepo   identity.PasswordResetRepository
+	mailer      Mailer
+	templates   TemplateRenderer
+	resetURL    string
+	ttl         time.Duration
+	hourlyLimit int
+}
+
+// NewForgotPasswordUseCase creates a new forgot password use case. At most
+// hourlyLimit emails are sent to an address per hour.
+func NewForgotPasswordUseCase(
+	userService *identity.UserService,
+	resetRepo identity.PasswordResetRepository,
+	mailer Mailer,
+	templates TemplateRenderer,
+	resetURL string,
+	ttl time.Duration,
+	hourlyLimit int,
+) *ForgotPasswordUseCase {
+	return &ForgotPasswordUseCase{
+		userService: userService,
+		resetRepo:   resetRepo,
+		mailer:      mailer,
+		templates:   templates,
+		resetURL:    resetURL,
+		ttl:         ttl,
+		hourlyLimit: hourlyLimit,
+	}
+}
+
+// Execute issues a new reset token, invalidating any earlier ones, and emails it.
+// Unknown and deactivated addresses, and addresses over the hourly limit, are
+// skipped silently.
+func (uc *ForgotPasswordUseCase) Execute(ctx context.Context, req ForgotPasswordRequest) error {
+	email, err := identity.NewEmail(req.Email)
+	if err != nil {
+		return nil
+	}
+
+	user, err := uc.userService.GetUserByEmail(ctx, email)
+	if errors.Is(err, identity.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !user.IsActive() {
+		return nil
+	}
+
+	now := time.Now()
+	sent, err := uc.resetRepo.CountSince(ctx, user.ID(), now.Add(-time.Hour))
+	if err != nil {
+		return err
+	}
+	if sent >= int64(uc.hourlyLimit) {
+		slog.InfoContext(ctx, "password reset limit reached", "user_id", user.ID().Value())
+		return nil
+	}
+
+	token, tokenHash, err := newResetToken()
+	if err != nil {
+		return err
+	}
+
+	// Only the newest link works
+	if err := uc.resetRepo.InvalidateByUserID(ctx, user.ID(), now); err != nil {
+		return err
+	}
+	if err := uc.resetRepo.Save(ctx, identity.NewPasswordReset(user.ID(), tokenHash, now, uc.ttl)); err != nil {
+		return err
+	}
+
+	link, err := url.Parse(uc.resetURL)
+	if err != nil {
+		return err
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	subject, text, html, err := uc.templates.Render("password_reset", passwordResetEmail{
+		Link:      link.String(),
+		ExpiresIn: formatDuration(uc.ttl),
+	})
+	if err != nil {
+		return err
+	}
+	if err := uc.mailer.SendHTML(ctx, user.Email().Value(), subject, text, html); err != nil {
+		// Failing the request would tell the caller that the account exists
+		slog.ErrorContext(ctx, "failed to send password reset email", "user_id", user.ID().Value(), "error", err.Error())
+	}
+	return nil
+}
+
+// formatDuration describes a duration in words, such as "1 hour" or "30 minutes"
+func formatDuration(d time.Duration) string {
+	unit, count := "minute", int(d.Minutes())
+	if d >= time.Hour && d%time.Hour == 0 {
+		unit, count = "hour", int(d.Hours())
+	}
+	if count == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", count, unit)
+}
+
+// newResetToken returns a random token and the hash stored in its place
+func newResetToken() (string, string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	token := hex.EncodeToString(raw)
+	return token, hashResetToken(token), nil
+}
+
+// hashResetToken hashes a reset token for storage and lookup
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,77 @@
+package identity
+
+import "context"
+
+// CategoryRepository defines the contract for counting a user's categories
+type CategoryRepository interface {
+	GetCountByUser(ctx context.Context, userID int) (int, error)
+}
+
+// APICallCounter counts the API requests a user has made
+type APICallCounter interface {
+	MonthlyRequests(ctx context.Context, userID int) (int64, error)
+}
+
+// AccountUsageResponse represents how much of the product the current user
+// uses. APICallsThisMonth counts requests made since the start of the calendar
+// month in UTC, up to but not including the current one.
+type AccountUsageResponse struct {
+	TransactionCount  int   `json:"transaction_count"`
+	CategoryCount     int   `json:"category_count"`
+	BudgetCount       int   `json:"budget_count"`
+	APICallsThisMonth int64 `json:"api_calls_this_month"`
+}
+
+// GetAccountUsageUseCase handles getting the current user's usage metrics, for
+// account overviews
+type GetAccountUsageUseCase struct {
+	transactionRepo TransactionRepository
+	categoryRepo    CategoryRepository
+	budgetRepo      BudgetRepository
+	apiCalls        APICallCounter
+}
+
+// NewGetAccountUsageUseCase creates a new get account usage use case
+func NewGetAccountUsageUseCase(
+	transactionRepo TransactionRepository,
+	categoryRepo CategoryRepository,
+	budgetRepo BudgetRepository,
+	apiCalls APICallCounter,
+) *GetAccountUsageUseCase {
+	return &GetAccountUsageUseCase{
+		transactionRepo: transactionRepo,
+		categoryRepo:    categoryRepo,
+		budgetRepo:      budgetRepo,
+		apiCalls:        apiCalls,
+	}
+}
+
+// Execute executes the get account usage use case
+func (uc *GetAccountUsageUseCase) Execute(ctx context.Context, userID int) (*AccountUsageResponse, error) {
+	transactionCount, err := uc.transactionRepo.GetCountByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryCount, err := uc.categoryRepo.GetCountByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	budgetCount, err := uc.budgetRepo.GetCountByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	apiCalls, err := uc.apiCalls.MonthlyRequests(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountUsageResponse{
+		TransactionCount:  transactionCount,
+		CategoryCount:     categoryCount,
+		BudgetCount:       budgetCount,
+		APICallsThisMonth: apiCalls,
+	}, nil
+}
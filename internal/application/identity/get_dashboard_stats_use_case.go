@@ -29,6 +29,7 @@ type GetDashboardStatsUseCase struct {
 type BudgetRepository interface {
 	GetTotalCount(ctx context.Context) (int, error)
 	GetCountByDateRange(ctx context.Context, startDate, endDate time.Time) (int, error)
+	GetCountByUser(ctx context.Context, userID int) (int, error)
 }
 
 // TransactionRepository defines the contract for transaction persistence
@@ -36,6 +37,7 @@ type TransactionRepository interface {
 	GetTotalCount(ctx context.Context) (int, error)
 	GetTotalExpenses(ctx context.Context) (float64, error)
 	GetTotalIncome(ctx context.Context) (float64, error)
+	GetCountByUser(ctx context.Context, userID int) (int, error)
 }
 
 // NewGetDashboardStatsUseCase creates a new get dashboard stats use case
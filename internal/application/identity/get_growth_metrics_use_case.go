@@ -0,0 +1,296 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"math"
+	domainIdentity "panda-pocket/internal/domain/identity"
+	"sync"
+	"time"
+)
+
+// growthMetricsTTL is how long computed growth reports are served from memory.
+// They aggregate whole tables for back-office dashboards, so new signups and
+// activity show up within growthMetricsTTL rather than at once.
+const growthMetricsTTL = 10 * time.Minute
+
+// Limits of the growth reports
+const (
+	defaultGrowthDays      = 30
+	maxGrowthDays          = 366
+	defaultRetentionMonths = 6
+	maxRetentionMonths     = 24
+)
+
+// Layouts of the days and months in growth reports
+const (
+	growthDayLayout   = "2006-01-02"
+	growthMonthLayout = "2006-01"
+)
+
+// GrowthMetricsRequest selects the days of a growth report, as YYYY-MM-DD in
+// UTC. To defaults to today and From to 29 days before To.
+type GrowthMetricsRequest struct {
+	From string
+	To   string
+}
+
+// GrowthDayResponse represents the growth metrics of one day
+type GrowthDayResponse struct {
+	Date         string `json:"date"`
+	Signups      int    `json:"signups"`
+	ActiveUsers  int    `json:"active_users"`
+	Transactions int    `json:"transactions"`
+}
+
+// GrowthMetricsResponse represents the daily growth metrics of a range of days.
+// MonthlyActiveUsers counts the users active in the 30 days up to To, and
+// Stickiness is the average of the daily active users over those 30 days
+// divided by it (DAU/MAU).
+type GrowthMetricsResponse struct {
+	From               string              `json:"from"`
+	To                 string              `json:"to"`
+	Days               []GrowthDayResponse `json:"days"`
+	MonthlyActiveUsers int                 `json:"monthly_active_users"`
+	Stickiness         float64             `json:"stickiness"`
+	GeneratedAt        time.Time           `json:"generated_at"`
+}
+
+// RetentionPointResponse represents how many of a cohort were active a number
+// of months after they signed up
+type RetentionPointResponse struct {
+	MonthsAfter int     `json:"months_after"`
+	ActiveUsers int     `json:"active_users"`
+	Rate        float64 `json:"rate"`
+}
+
+// RetentionCohortResponse represents the users who signed up in one month and
+// their activity in each month since, up to the current one
+type RetentionCohortResponse struct {
+	Month     string                   `json:"month"`
+	Users     int                      `json:"users"`
+	Retention []RetentionPointResponse `json:"retention"`
+}
+
+// RetentionResponse represents the monthly signup cohorts, oldest first
+type RetentionResponse struct {
+	Cohorts     []RetentionCohortResponse `json:"cohorts"`
+	GeneratedAt time.Time                 `json:"generated_at"`
+}
+
+// cachedReport is a computed report and when it was computed
+type cachedReport struct {
+	report     interface{}
+	computedAt time.Time
+}
+
+// GetGrowthMetricsUseCase handles the back-office growth reports: daily signups,
+// active users and transactions, and retention cohorts. A user is active on a
+// day when they made an authenticated API request that day.
+type GetGrowthMetricsUseCase struct {
+	growthRepo domainIdentity.GrowthMetricsRepository
+	mu         sync.Mutex
+	reports    map[string]cachedReport
+}
+
+// NewGetGrowthMetricsUseCase creates a new get growth metrics use case
+func NewGetGrowthMetricsUseCase(growthRepo domainIdentity.GrowthMetricsRepository) *GetGrowthMetricsUseCase {
+	return &GetGrowthMetricsUseCase{
+		growthRepo: growthRepo,
+		reports:    make(map[string]cachedReport),
+	}
+}
+
+// Growth returns the daily growth metrics of the requested days
+func (uc *GetGrowthMetricsUseCase) Growth(ctx context.Context, req GrowthMetricsRequest) (*GrowthMetricsResponse, error) {
+	from, to, err := parseGrowthRange(req, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("growth:%s:%s", from.Format(growthDayLayout), to.Format(growthDayLayout))
+	report, err := uc.cached(key, func() (interface{}, error) {
+		return uc.computeGrowth(ctx, from, to)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report.(*GrowthMetricsResponse), nil
+}
+
+// Retention returns the signup cohorts of the last months, including the current one
+func (uc *GetGrowthMetricsUseCase) Retention(ctx context.Context, months int) (*RetentionResponse, error) {
+	if months == 0 {
+		months = defaultRetentionMonths
+	}
+	if months < 1 || months > maxRetentionMonths {
+		return nil, domainIdentity.ErrInvalidRetentionMonths
+	}
+
+	report, err := uc.cached(fmt.Sprintf("retention:%d", months), func() (interface{}, error) {
+		return uc.computeRetention(ctx, months)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report.(*RetentionResponse), nil
+}
+
+// computeGrowth aggregates the daily metrics from the first day to the last
+func (uc *GetGrowthMetricsUseCase) computeGrowth(ctx context.Context, from, to time.Time) (*GrowthMetricsResponse, error) {
+	end := to.AddDate(0, 0, 1)
+	signups, err := uc.growthRepo.DailySignups(ctx, from, end)
+	if err != nil {
+		return nil, err
+	}
+	transactions, err := uc.growthRepo.DailyTransactions(ctx, from, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// Active users are also needed for the 30 days behind the stickiness
+	monthStart := to.AddDate(0, 0, -29)
+	activeFrom := from
+	if monthStart.Before(activeFrom) {
+		activeFrom = monthStart
+	}
+	active, err := uc.growthRepo.DailyActiveUsers(ctx, activeFrom.Format(growthDayLayout), to.Format(growthDayLayout))
+	if err != nil {
+		return nil, err
+	}
+	monthlyActive, err := uc.growthRepo.ActiveUsers(ctx, monthStart.Format(growthDayLayout), to.Format(growthDayLayout))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GrowthMetricsResponse{
+		From:               from.Format(growthDayLayout),
+		To:                 to.Format(growthDayLayout),
+		Days:               []GrowthDayResponse{},
+		MonthlyActiveUsers: monthlyActive,
+		GeneratedAt:        time.Now().UTC(),
+	}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format(growthDayLayout)
+		response.Days = append(response.Days, GrowthDayResponse{
+			Date:         date,
+			Signups:      signups[date],
+			ActiveUsers:  active[date],
+			Transactions: transactions[date],
+		})
+	}
+
+	if monthlyActive > 0 {
+		total := 0
+		for day := monthStart; !day.After(to); day = day.AddDate(0, 0, 1) {
+			total += active[day.Format(growthDayLayout)]
+		}
+		response.Stickiness = roundRatio(float64(total) / 30 / float64(monthlyActive))
+	}
+	return response, nil
+}
+
+// computeRetention aggregates the cohorts of the last months
+func (uc *GetGrowthMetricsUseCase) computeRetention(ctx context.Context, months int) (*RetentionResponse, error) {
+	now := time.Now().UTC()
+	current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	since := current.AddDate(0, -(months - 1), 0)
+
+	signups, err := uc.growthRepo.MonthlySignups(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	activity, err := uc.growthRepo.CohortActivity(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	activeByCohort := make(map[string]map[string]int)
+	for _, a := range activity {
+		if activeByCohort[a.SignupMonth] == nil {
+			activeByCohort[a.SignupMonth] = make(map[string]int)
+		}
+		activeByCohort[a.SignupMonth][a.ActiveMonth] = a.Users
+	}
+
+	response := &RetentionResponse{
+		Cohorts:     make([]RetentionCohortResponse, 0, months),
+		GeneratedAt: now,
+	}
+	for cohortMonth := since; !cohortMonth.After(current); cohortMonth = cohortMonth.AddDate(0, 1, 0) {
+		month := cohortMonth.Format(growthMonthLayout)
+		cohort := RetentionCohortResponse{
+			Month:     month,
+			Users:     signups[month],
+			Retention: []RetentionPointResponse{},
+		}
+		for after, activeMonth := 0, cohortMonth; !activeMonth.After(current); after, activeMonth = after+1, activeMonth.AddDate(0, 1, 0) {
+			point := RetentionPointResponse{
+				MonthsAfter: after,
+				ActiveUsers: activeByCohort[month][activeMonth.Format(growthMonthLayout)],
+			}
+			if cohort.Users > 0 {
+				point.Rate = roundRatio(float64(point.ActiveUsers) / float64(cohort.Users))
+			}
+			cohort.Retention = append(cohort.Retention, point)
+		}
+		response.Cohorts = append(response.Cohorts, cohort)
+	}
+	return response, nil
+}
+
+// cached returns the report stored under key, computing it once it is missing
+// or older than growthMetricsTTL. Failures are not cached.
+func (uc *GetGrowthMetricsUseCase) cached(key string, compute func() (interface{}, error)) (interface{}, error) {
+	uc.mu.Lock()
+	entry, found := uc.reports[key]
+	uc.mu.Unlock()
+	if found && time.Since(entry.computedAt) < growthMetricsTTL {
+		return entry.report, nil
+	}
+
+	report, err := compute()
+	if err != nil {
+		return nil, err
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	for k, e := range uc.reports {
+		if time.Since(e.computedAt) >= growthMetricsTTL {
+			delete(uc.reports, k)
+		}
+	}
+	uc.reports[key] = cachedReport{report: report, computedAt: time.Now()}
+	return report, nil
+}
+
+// parseGrowthRange reads the requested days, defaulting to the 30 days up to today
+func parseGrowthRange(req GrowthMetricsRequest, now time.Time) (time.Time, time.Time, error) {
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if req.To != "" {
+		parsed, err := time.Parse(growthDayLayout, req.To)
+		if err != nil {
+			return time.Time{}, time.Time{}, domainIdentity.ErrInvalidGrowthRange
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -(defaultGrowthDays - 1))
+	if req.From != "" {
+		parsed, err := time.Parse(growthDayLayout, req.From)
+		if err != nil {
+			return time.Time{}, time.Time{}, domainIdentity.ErrInvalidGrowthRange
+		}
+		from = parsed
+	}
+
+	if from.After(to) || to.Sub(from) >= maxGrowthDays*24*time.Hour {
+		return time.Time{}, time.Time{}, domainIdentity.ErrInvalidGrowthRange
+	}
+	return from, to, nil
+}
+
+// roundRatio rounds a ratio to four decimal places
+func roundRatio(ratio float64) float64 {
+	return math.Round(ratio*10000) / 10000
+}
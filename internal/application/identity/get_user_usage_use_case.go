@@ -0,0 +1,57 @@
+package identity
+
+import (
+	"context"
+	"panda-pocket/internal/domain/identity"
+)
+
+// UserUsageResponse represents how much of the product one user uses
+type UserUsageResponse struct {
+	User             AdminUserResponse `json:"user"`
+	TransactionCount int               `json:"transaction_count"`
+	BudgetCount      int               `json:"budget_count"`
+}
+
+// GetUserUsageUseCase handles getting per-user usage metrics for admins
+type GetUserUsageUseCase struct {
+	userService     *identity.UserService
+	budgetRepo      BudgetRepository
+	transactionRepo TransactionRepository
+}
+
+// NewGetUserUsageUseCase creates a new get user usage use case
+func NewGetUserUsageUseCase(
+	userService *identity.UserService,
+	budgetRepo BudgetRepository,
+	transactionRepo TransactionRepository,
+) *GetUserUsageUseCase {
+	return &GetUserUsageUseCase{
+		userService:     userService,
+		budgetRepo:      budgetRepo,
+		transactionRepo: transactionRepo,
+	}
+}
+
+// Execute executes the get user usage use case
+func (uc *GetUserUsageUseCase) Execute(ctx context.Context, userID int) (*UserUsageResponse, error) {
+	user, err := uc.userService.GetUserByID(ctx, identity.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	transactionCount, err := uc.transactionRepo.GetCountByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	budgetCount, err := uc.budgetRepo.GetCountByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserUsageResponse{
+		User:             newAdminUserResponse(user),
+		TransactionCount: transactionCount,
+		BudgetCount:      budgetCount,
+	}, nil
+}
@@ -0,0 +1,111 @@
+package identity
+
+import (
+	"context"
+	"panda-pocket/internal/domain/identity"
+	"time"
+)
+
+// ListUsersRequest represents the request for the admin user listing
+type ListUsersRequest struct {
+	Search string `json:"search,omitempty"` // Matches part of the email, ignoring case
+	Page   int    `json:"page,omitempty"`   // Page number (1-based)
+	Limit  int    `json:"limit,omitempty"`  // Number of users per page
+}
+
+// AdminUserResponse represents a user as seen by admins
+type AdminUserResponse struct {
+	ID            int     `json:"id"`
+	Email         string  `json:"email"`
+	Role          string  `json:"role"`
+	Active        bool    `json:"active"`
+	CreatedAt     string  `json:"created_at"`
+	LastLoginAt   *string `json:"last_login_at"`
+	DeactivatedAt *string `json:"deactivated_at"`
+}
+
+// ListUsersResponse represents one page of the admin user listing
+type ListUsersResponse struct {
+	Users      []AdminUserResponse `json:"users"`
+	Total      int64               `json:"total"`
+	Page       int                 `json:"page"`
+	Limit      int                 `json:"limit"`
+	TotalPages int                 `json:"total_pages"`
+}
+
+// ListUsersUseCase handles the paginated admin user listing
+type ListUsersUseCase struct {
+	userService *identity.UserService
+}
+
+// NewListUsersUseCase creates a new list users use case
+func NewListUsersUseCase(userService *identity.UserService) *ListUsersUseCase {
+	return &ListUsersUseCase{
+		userService: userService,
+	}
+}
+
+// Execute executes the list users use case
+func (uc *ListUsersUseCase) Execute(ctx context.Context, req ListUsersRequest) (*ListUsersResponse, error) {
+	page := req.Page
+	if page <= 0 {
+		page = 1
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 20 // Default limit
+	}
+	if limit > 100 {
+		limit = 100 // Maximum limit
+	}
+
+	users, total, err := uc.userService.ListUsers(ctx, identity.UserFilters{
+		Search: req.Search,
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	userResponses := make([]AdminUserResponse, len(users))
+	for i, user := range users {
+		userResponses[i] = newAdminUserResponse(user)
+	}
+
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	return &ListUsersResponse{
+		Users:      userResponses,
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// newAdminUserResponse converts a domain user for the admin API
+func newAdminUserResponse(user *identity.User) AdminUserResponse {
+	return AdminUserResponse{
+		ID:            user.ID().Value(),
+		Email:         user.Email().Value(),
+		Role:          user.Role().Value(),
+		Active:        user.IsActive(),
+		CreatedAt:     user.CreatedAt().Format(time.RFC3339),
+		LastLoginAt:   formatOptionalTime(user.LastLoginAt()),
+		DeactivatedAt: formatOptionalTime(user.DeactivatedAt()),
+	}
+}
+
+// formatOptionalTime formats a timestamp that may be unset
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
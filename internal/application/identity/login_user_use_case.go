@@ -46,13 +46,22 @@ func (uc *LoginUserUseCase) Execute(ctx context.Context, req LoginUserRequest) (
 	// Get user by email
 	user, err := uc.userService.GetUserByEmail(ctx, email)
 	if err != nil {
-		return nil, errors.New("invalid credentials")
+		return nil, identity.ErrInvalidCredentials
 	}
 
 	// Verify password
 	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash().Value()), []byte(req.Password))
 	if err != nil {
-		return nil, errors.New("invalid credentials")
+		return nil, identity.ErrInvalidCredentials
+	}
+
+	// Deactivated accounts are only told so once the password has been verified
+	if !user.IsActive() {
+		return nil, identity.ErrUserDeactivated
+	}
+
+	if err := uc.userService.RecordLogin(ctx, user); err != nil {
+		return nil, err
 	}
 
 	// Generate token
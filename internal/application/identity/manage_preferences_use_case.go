@@ -0,0 +1,102 @@
+package identity
+
+import (
+	"context"
+	"panda-pocket/internal/domain/identity"
+	"time"
+)
+
+// PreferencesResponse represents a user's preferences
+type PreferencesResponse struct {
+	EmailNotifications bool   `json:"email_notifications"`
+	BudgetAlerts       bool   `json:"budget_alerts"`
+	RecurringReminders bool   `json:"recurring_reminders"`
+	AnomalySensitivity string `json:"anomaly_sensitivity"`
+	Benchmarking       bool   `json:"benchmarking"`
+	// OnboardingCompletedAt is null until the user completes onboarding
+	OnboardingCompletedAt *time.Time `json:"onboarding_completed_at"`
+	// TrashRetentionDays is null for users who follow the server's trash retention
+	TrashRetentionDays *int `json:"trash_retention_days"`
+}
+
+// UpdatePreferencesRequest represents a change to a user's preferences; omitted fields are left as they are
+type UpdatePreferencesRequest struct {
+	EmailNotifications *bool   `json:"email_notifications"`
+	BudgetAlerts       *bool   `json:"budget_alerts"`
+	RecurringReminders *bool   `json:"recurring_reminders"`
+	AnomalySensitivity *string `json:"anomaly_sensitivity"`
+	Benchmarking       *bool   `json:"benchmarking"`
+	TrashRetentionDays *int    `json:"trash_retention_days"`
+}
+
+// ManagePreferencesUseCase handles reading and changing a user's preferences
+type ManagePreferencesUseCase struct {
+	preferencesRepo identity.PreferencesRepository
+}
+
+// NewManagePreferencesUseCase creates a new manage preferences use case
+func NewManagePreferencesUseCase(preferencesRepo identity.PreferencesRepository) *ManagePreferencesUseCase {
+	return &ManagePreferencesUseCase{
+		preferencesRepo: preferencesRepo,
+	}
+}
+
+// Get returns the user's preferences
+func (uc *ManagePreferencesUseCase) Get(ctx context.Context, userID int) (*PreferencesResponse, error) {
+	preferences, err := uc.preferencesRepo.FindByUserID(ctx, identity.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	return toPreferencesResponse(preferences), nil
+}
+
+// Update changes the preferences given in the request and returns the result
+func (uc *ManagePreferencesUseCase) Update(ctx context.Context, userID int, req UpdatePreferencesRequest) (*PreferencesResponse, error) {
+	preferences, err := uc.preferencesRepo.FindByUserID(ctx, identity.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	if req.AnomalySensitivity != nil {
+		sensitivity, err := identity.NewAnomalySensitivity(*req.AnomalySensitivity)
+		if err != nil {
+			return nil, err
+		}
+		preferences.SetAnomalySensitivity(sensitivity)
+	}
+	if req.TrashRetentionDays != nil {
+		if err := preferences.SetTrashRetentionDays(req.TrashRetentionDays); err != nil {
+			return nil, err
+		}
+	}
+	if req.EmailNotifications != nil {
+		preferences.SetEmailNotifications(*req.EmailNotifications)
+	}
+	if req.BudgetAlerts != nil {
+		preferences.SetBudgetAlerts(*req.BudgetAlerts)
+	}
+	if req.RecurringReminders != nil {
+		preferences.SetRecurringReminders(*req.RecurringReminders)
+	}
+	if req.Benchmarking != nil {
+		preferences.SetBenchmarking(*req.Benchmarking)
+	}
+
+	if err := uc.preferencesRepo.Save(ctx, preferences); err != nil {
+		return nil, err
+	}
+	return toPreferencesResponse(preferences), nil
+}
+
+// toPreferencesResponse converts domain preferences to a response
+func toPreferencesResponse(preferences *identity.Preferences) *PreferencesResponse {
+	return &PreferencesResponse{
+		EmailNotifications:    preferences.EmailNotifications(),
+		BudgetAlerts:          preferences.BudgetAlerts(),
+		RecurringReminders:    preferences.RecurringReminders(),
+		AnomalySensitivity:    string(preferences.AnomalySensitivity()),
+		Benchmarking:          preferences.Benchmarking(),
+		OnboardingCompletedAt: preferences.OnboardingCompletedAt(),
+		TrashRetentionDays:    preferences.TrashRetentionDays(),
+	}
+}
@@ -3,6 +3,7 @@ package identity
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"panda-pocket/internal/domain/identity"
 
 	"golang.org/x/crypto/bcrypt"
@@ -12,6 +13,8 @@ import (
 type RegisterUserRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=6"`
+	// SkipDefaultBudgets lets onboarding start without the default budget template
+	SkipDefaultBudgets bool `json:"skip_default_budgets"`
 }
 
 // RegisterUserResponse represents the response after registering a user
@@ -23,8 +26,20 @@ type RegisterUserResponse struct {
 
 // RegisterUserUseCase handles user registration
 type RegisterUserUseCase struct {
-	userService  *identity.UserService
-	tokenService TokenService
+	userService       *identity.UserService
+	tokenService      TokenService
+	defaultCategories DefaultCategoryCopier
+	defaultBudgets    DefaultBudgetApplier
+}
+
+// DefaultCategoryCopier gives a new user their own copies of the default categories
+type DefaultCategoryCopier interface {
+	Execute(ctx context.Context, userID int) error
+}
+
+// DefaultBudgetApplier creates the budgets of the default budget template for a new user
+type DefaultBudgetApplier interface {
+	Apply(ctx context.Context, userID int) error
 }
 
 // NewRegisterUserUseCase creates a new register user use case
@@ -35,6 +50,19 @@ func NewRegisterUserUseCase(userService *identity.UserService, tokenService Toke
 	}
 }
 
+// WithDefaultCategories gives each new user their own copies of the default categories
+func (uc *RegisterUserUseCase) WithDefaultCategories(defaultCategories DefaultCategoryCopier) *RegisterUserUseCase {
+	uc.defaultCategories = defaultCategories
+	return uc
+}
+
+// WithDefaultBudgets creates the default budget template's budgets for each new
+// user who does not skip them
+func (uc *RegisterUserUseCase) WithDefaultBudgets(defaultBudgets DefaultBudgetApplier) *RegisterUserUseCase {
+	uc.defaultBudgets = defaultBudgets
+	return uc
+}
+
 // Execute executes the register user use case
 func (uc *RegisterUserUseCase) Execute(ctx context.Context, req RegisterUserRequest) (*RegisterUserResponse, error) {
 	// Create email value object
@@ -57,6 +85,21 @@ func (uc *RegisterUserUseCase) Execute(ctx context.Context, req RegisterUserRequ
 		return nil, err
 	}
 
+	// The account is usable without its copies of the default categories: the
+	// defaults themselves stay available to users who have none
+	if uc.defaultCategories != nil {
+		if err := uc.defaultCategories.Execute(ctx, user.ID().Value()); err != nil {
+			slog.ErrorContext(ctx, "failed to copy default categories", "user_id", user.ID().Value(), "error", err.Error())
+		}
+	}
+
+	// The account is usable without its default budgets, so a failure only costs them
+	if uc.defaultBudgets != nil && !req.SkipDefaultBudgets {
+		if err := uc.defaultBudgets.Apply(ctx, user.ID().Value()); err != nil {
+			slog.ErrorContext(ctx, "failed to create default budgets", "user_id", user.ID().Value(), "error", err.Error())
+		}
+	}
+
 	// Generate token
 	token, err := uc.tokenService.GenerateToken(user.ID().Value(), user.Email().Value(), user.Role().Value())
 	if err != nil {
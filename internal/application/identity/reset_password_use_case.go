@@ -0,0 +1,53 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"panda-pocket/internal/domain/identity"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ResetPasswordRequest represents a new password chosen with a reset token
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+// ResetPasswordUseCase handles users choosing a new password from a reset email
+type ResetPasswordUseCase struct {
+	userService *identity.UserService
+	resetRepo   identity.PasswordResetRepository
+}
+
+// NewResetPasswordUseCase creates a new reset password use case
+func NewResetPasswordUseCase(userService *identity.UserService, resetRepo identity.PasswordResetRepository) *ResetPasswordUseCase {
+	return &ResetPasswordUseCase{
+		userService: userService,
+		resetRepo:   resetRepo,
+	}
+}
+
+// Execute sets the new password and uses up the token
+func (uc *ResetPasswordUseCase) Execute(ctx context.Context, req ResetPasswordRequest) error {
+	reset, err := uc.resetRepo.FindByTokenHash(ctx, hashResetToken(req.Token))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := reset.Use(now); err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.New("failed to hash password")
+	}
+
+	if err := uc.userService.ChangePassword(ctx, reset.UserID(), identity.NewPasswordHash(string(hashedPassword))); err != nil {
+		return err
+	}
+	return uc.resetRepo.InvalidateByUserID(ctx, reset.UserID(), now)
+}
@@ -7,10 +7,6 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
-const (
-	JWTSecret = "panda-pocket-secret-key-change-in-production"
-)
-
 // Claims represents JWT claims
 type Claims struct {
 	UserID int    `json:"user_id"`
@@ -26,11 +22,17 @@ type TokenService interface {
 }
 
 // tokenService implements TokenService interface
-type tokenService struct{}
+type tokenService struct {
+	secret []byte
+	expiry time.Duration
+}
 
-// NewTokenService creates a new token service
-func NewTokenService() TokenService {
-	return &tokenService{}
+// NewTokenService creates a new token service signing with the given secret
+func NewTokenService(secret string, expiry time.Duration) TokenService {
+	return &tokenService{
+		secret: []byte(secret),
+		expiry: expiry,
+	}
 }
 
 // GenerateToken generates a JWT token for a user
@@ -40,19 +42,19 @@ func (s *tokenService) GenerateToken(userID int, email string, role string) (str
 		Email:  email,
 		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.expiry)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(JWTSecret))
+	return token.SignedString(s.secret)
 }
 
 // ValidateToken validates a JWT token and returns the claims
 func (s *tokenService) ValidateToken(tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(JWTSecret), nil
+		return s.secret, nil
 	})
 
 	if err != nil {
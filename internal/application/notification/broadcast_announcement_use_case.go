@@ -0,0 +1,145 @@
+package notification
+
+import (
+	"context"
+	"log/slog"
+	"panda-pocket/internal/domain/identity"
+	"panda-pocket/internal/domain/notification"
+)
+
+// Mailer sends email
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// BroadcastAnnouncementRequest represents an announcement to all users or a segment of them
+type BroadcastAnnouncementRequest struct {
+	Title   string `json:"title" binding:"required,max=255"`
+	Message string `json:"message" binding:"required"`
+	// Role limits the announcement to users with this role
+	Role string `json:"role,omitempty"`
+	// UserIDs limits the announcement to these users
+	UserIDs []int `json:"user_ids,omitempty"`
+	// SendEmail also emails recipients who accept email notifications
+	SendEmail bool `json:"send_email"`
+}
+
+// BroadcastAnnouncementResponse reports how many users the announcement reached
+type BroadcastAnnouncementResponse struct {
+	Recipients      int `json:"recipients"`
+	EmailRecipients int `json:"email_recipients"`
+}
+
+// BroadcastAnnouncementUseCase handles admins announcing something, such as
+// planned maintenance, to every active user or a segment of them
+type BroadcastAnnouncementUseCase struct {
+	notificationRepo notification.Repository
+	userService      *identity.UserService
+	mailer           Mailer
+}
+
+// NewBroadcastAnnouncementUseCase creates a new broadcast announcement use case
+func NewBroadcastAnnouncementUseCase(
+	notificationRepo notification.Repository,
+	userService *identity.UserService,
+	mailer Mailer,
+) *BroadcastAnnouncementUseCase {
+	return &BroadcastAnnouncementUseCase{
+		notificationRepo: notificationRepo,
+		userService:      userService,
+		mailer:           mailer,
+	}
+}
+
+// Execute creates a notification for every matching active user. Emails are
+// sent in the background, so the request does not wait for the mail server.
+func (uc *BroadcastAnnouncementUseCase) Execute(ctx context.Context, req BroadcastAnnouncementRequest) (*BroadcastAnnouncementResponse, error) {
+	if req.Role != "" {
+		if _, err := identity.NewRole(req.Role); err != nil {
+			return nil, err
+		}
+	}
+
+	users, err := uc.userService.GetAllUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make(map[int]bool, len(req.UserIDs))
+	for _, id := range req.UserIDs {
+		selected[id] = true
+	}
+
+	var recipients []*identity.User
+	var notifications []*notification.Notification
+	for _, user := range users {
+		if !user.IsActive() {
+			continue
+		}
+		if req.Role != "" && user.Role().Value() != req.Role {
+			continue
+		}
+		if len(selected) > 0 && !selected[user.ID().Value()] {
+			continue
+		}
+
+		n, err := notification.NewNotification(notification.NewUserID(user.ID().Value()), req.Title, req.Message, notification.TypeAnnouncement)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, user)
+		notifications = append(notifications, n)
+	}
+
+	if err := uc.notificationRepo.SaveAll(ctx, notifications); err != nil {
+		return nil, err
+	}
+
+	response := &BroadcastAnnouncementResponse{Recipients: len(notifications)}
+	if !req.SendEmail || len(recipients) == 0 {
+		return response, nil
+	}
+
+	emails, err := uc.emailAddresses(ctx, recipients)
+	if err != nil {
+		return nil, err
+	}
+	response.EmailRecipients = len(emails)
+
+	go uc.sendEmails(context.WithoutCancel(ctx), emails, req.Title, req.Message)
+	return response, nil
+}
+
+// emailAddresses returns the addresses of the recipients who accept email notifications
+func (uc *BroadcastAnnouncementUseCase) emailAddresses(ctx context.Context, recipients []*identity.User) ([]string, error) {
+	userIDs := make([]notification.UserID, len(recipients))
+	addresses := make(map[int]string, len(recipients))
+	for i, user := range recipients {
+		userIDs[i] = notification.NewUserID(user.ID().Value())
+		addresses[user.ID().Value()] = user.Email().Value()
+	}
+
+	accepted, err := uc.notificationRepo.EmailRecipients(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	emails := make([]string, len(accepted))
+	for i, userID := range accepted {
+		emails[i] = addresses[userID.Value()]
+	}
+	return emails, nil
+}
+
+// sendEmails emails the announcement, logging failures; the in-app
+// notifications have already been created
+func (uc *BroadcastAnnouncementUseCase) sendEmails(ctx context.Context, emails []string, subject, body string) {
+	failed := 0
+	for _, email := range emails {
+		if err := uc.mailer.Send(ctx, email, subject, body); err != nil {
+			failed++
+			slog.Error("failed to email announcement", "to", email, "error", err.Error())
+		}
+	}
+	slog.Info("announcement emails sent", "sent", len(emails)-failed, "failed", failed)
+}
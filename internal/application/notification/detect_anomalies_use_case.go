@@ -0,0 +1,157 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"panda-pocket/internal/domain/finance"
+	"panda-pocket/internal/domain/identity"
+	"panda-pocket/internal/domain/notification"
+	"time"
+)
+
+// DetectAnomaliesUseCase flags unusually large expenses and spikes in category
+// spending against each user's own history, and notifies them
+type DetectAnomaliesUseCase struct {
+	userService        *identity.UserService
+	preferencesRepo    identity.PreferencesRepository
+	transactionService *finance.TransactionService
+	categoryService    *finance.CategoryService
+	currencyRepo       finance.CurrencyRepository
+	anomalyRepo        finance.AnomalyRepository
+	dispatcher         *Dispatcher
+}
+
+// NewDetectAnomaliesUseCase creates a new detect anomalies use case
+func NewDetectAnomaliesUseCase(
+	userService *identity.UserService,
+	preferencesRepo identity.PreferencesRepository,
+	transactionService *finance.TransactionService,
+	categoryService *finance.CategoryService,
+	currencyRepo finance.CurrencyRepository,
+	anomalyRepo finance.AnomalyRepository,
+	dispatcher *Dispatcher,
+) *DetectAnomaliesUseCase {
+	return &DetectAnomaliesUseCase{
+		userService:        userService,
+		preferencesRepo:    preferencesRepo,
+		transactionService: transactionService,
+		categoryService:    categoryService,
+		currencyRepo:       currencyRepo,
+		anomalyRepo:        anomalyRepo,
+		dispatcher:         dispatcher,
+	}
+}
+
+// Run looks for anomalies at every interval until ctx is cancelled
+func (uc *DetectAnomaliesUseCase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			flagged, err := uc.Execute(ctx, time.Now())
+			if err != nil {
+				slog.Error("anomaly detection failed", "error", err.Error())
+				continue
+			}
+			if flagged > 0 {
+				slog.Info("flagged spending anomalies", "count", flagged)
+			}
+		}
+	}
+}
+
+// Execute checks the recent spending of every active user who has not turned
+// anomaly alerts off, at the sensitivity they chose, and returns how many
+// anomalies were flagged. The same spending is flagged once however often it runs.
+func (uc *DetectAnomaliesUseCase) Execute(ctx context.Context, at time.Time) (int, error) {
+	users, err := uc.userService.GetAllUsers(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	flagged := 0
+	for _, user := range users {
+		if !user.IsActive() {
+			continue
+		}
+		count, err := uc.detect(ctx, user.ID().Value(), at)
+		if err != nil {
+			slog.Error("failed to detect anomalies", "user_id", user.ID().Value(), "error", err.Error())
+		}
+		flagged += count
+	}
+	return flagged, nil
+}
+
+// detect flags and notifies the user of the anomalies in their recent spending
+func (uc *DetectAnomaliesUseCase) detect(ctx context.Context, userID int, at time.Time) (int, error) {
+	preferences, err := uc.preferencesRepo.FindByUserID(ctx, identity.NewUserID(userID))
+	if err != nil {
+		return 0, err
+	}
+	thresholds, enabled := finance.AnomalyThresholdsFor(string(preferences.AnomalySensitivity()))
+	if !enabled {
+		return 0, nil
+	}
+
+	windowStart, historyStart := finance.AnomalyWindow(at)
+	transactions, err := uc.transactionService.GetTransactionsByUserAndDateRange(ctx, finance.NewUserID(userID), historyStart, at)
+	if err != nil {
+		return 0, err
+	}
+	earlier, err := uc.anomalyRepo.FindByUserIDSince(ctx, finance.NewUserID(userID), windowStart)
+	if err != nil {
+		return 0, err
+	}
+
+	expenses := make(map[finance.TransactionID]*finance.Transaction)
+	for _, transaction := range transactions {
+		if transaction.Type() == finance.TransactionTypeExpense {
+			expenses[transaction.ID()] = transaction
+		}
+	}
+
+	flagged := 0
+	for _, anomaly := range finance.DetectAnomalies(finance.NewUserID(userID), transactions, earlier, at, thresholds) {
+		title, message := uc.describe(ctx, anomaly, expenses)
+		if err := uc.dispatcher.Notify(ctx, userID, notification.TypeSpendingAnomaly, title, message); err != nil {
+			return flagged, err
+		}
+		if err := uc.anomalyRepo.Save(ctx, anomaly); err != nil {
+			return flagged, err
+		}
+		flagged++
+	}
+	return flagged, nil
+}
+
+// describe writes the notification title and message for an anomaly
+func (uc *DetectAnomaliesUseCase) describe(ctx context.Context, anomaly *finance.Anomaly, expenses map[finance.TransactionID]*finance.Transaction) (string, string) {
+	category := "Uncategorized"
+	if found, err := uc.categoryService.GetCategoryByID(ctx, anomaly.CategoryID()); err == nil {
+		category = found.Name()
+	}
+
+	if anomaly.Kind() == finance.AnomalyKindCategorySpike {
+		message := fmt.Sprintf("You spent %.2f on %s in the last %d days, against %.2f in a usual week.",
+			anomaly.Amount(), category, finance.AnomalyWindowDays, anomaly.Baseline())
+		return "Spending spike in " + category, message
+	}
+
+	amount := fmt.Sprintf("%.2f", anomaly.Amount())
+	date := ""
+	if expense := expenses[*anomaly.TransactionID()]; expense != nil {
+		if currency, err := uc.currencyRepo.FindByID(ctx, expense.CurrencyID()); err == nil {
+			amount += " " + currency.Code()
+		}
+		date = " on " + expense.Date().Format("Mon, 2 Jan 2006")
+	}
+	message := fmt.Sprintf("An expense of %s in %s%s is much larger than your usual %.2f.",
+		amount, category, date, anomaly.Baseline())
+	return "Unusually large expense", message
+}
@@ -0,0 +1,103 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	domainFinance "panda-pocket/internal/domain/finance"
+	"panda-pocket/internal/domain/notification"
+)
+
+// ChannelPoster posts messages to a chat webhook
+type ChannelPoster interface {
+	Post(ctx context.Context, kind notification.ChannelKind, webhookURL, text string) error
+}
+
+// LivePusher sends a message to the live connections a user has open, on a
+// topic they may subscribe to
+type LivePusher interface {
+	Publish(userID int, topic, event string, data interface{})
+}
+
+// Live push topic and event of saved notifications
+const (
+	notificationsTopic  = "notifications"
+	notificationCreated = "notification.created"
+)
+
+// Dispatcher delivers a notification to the user in the app and to every chat
+// channel they have subscribed to its type
+type Dispatcher struct {
+	notificationRepo notification.Repository
+	channelRepo      notification.ChannelRepository
+	poster           ChannelPoster
+	pusher           LivePusher
+}
+
+// NewDispatcher creates a new notification dispatcher
+func NewDispatcher(
+	notificationRepo notification.Repository,
+	channelRepo notification.ChannelRepository,
+	poster ChannelPoster,
+) *Dispatcher {
+	return &Dispatcher{
+		notificationRepo: notificationRepo,
+		channelRepo:      channelRepo,
+		poster:           poster,
+	}
+}
+
+// WithPusher returns the dispatcher with saved notifications also pushed to the
+// user's live connections
+func (d *Dispatcher) WithPusher(pusher LivePusher) *Dispatcher {
+	d.pusher = pusher
+	return d
+}
+
+// Notify saves an in-app notification and posts it to the user's channels.
+// Channel failures are logged rather than returned; the in-app notification
+// has already been saved and retrying would duplicate it.
+func (d *Dispatcher) Notify(ctx context.Context, userID int, notificationType notification.Type, title, message string) error {
+	n, err := notification.NewNotification(notification.NewUserID(userID), title, message, notificationType)
+	if err != nil {
+		return err
+	}
+	if err := d.notificationRepo.SaveAll(ctx, []*notification.Notification{n}); err != nil {
+		return err
+	}
+	if d.pusher != nil {
+		d.pusher.Publish(userID, notificationsTopic, notificationCreated, newNotificationResponse(n))
+	}
+
+	channels, err := d.channelRepo.FindByUserID(ctx, n.UserID())
+	if err != nil {
+		return err
+	}
+
+	text := fmt.Sprintf("*%s*\n%s", title, message)
+	for _, channel := range channels {
+		if !channel.Receives(notificationType) {
+			continue
+		}
+		if err := d.poster.Post(ctx, channel.Kind(), channel.WebhookURL(), text); err != nil {
+			slog.Error("failed to post notification to channel",
+				"channel_id", channel.ID().Value(), "kind", string(channel.Kind()), "error", err.Error())
+		}
+	}
+	return nil
+}
+
+// HandleBudgetExceeded notifies the user that spending went over a budget
+func (d *Dispatcher) HandleBudgetExceeded(ctx context.Context, event domainFinance.Event) error {
+	exceeded, ok := event.(domainFinance.BudgetExceeded)
+	if !ok {
+		return nil
+	}
+
+	message := fmt.Sprintf("An expense of %.2f on %s took your spending to %.2f of your %.2f budget for %s to %s, %.2f over.",
+		exceeded.TransactionAmount, exceeded.TransactionDate.Format("2 Jan 2006"),
+		exceeded.Spent, exceeded.Budgeted,
+		exceeded.PeriodStart.Format("2 Jan 2006"), exceeded.PeriodEnd.Format("2 Jan 2006"),
+		exceeded.Overspend)
+	return d.Notify(ctx, exceeded.UserID, notification.TypeBudgetExceeded, "Budget exceeded", message)
+}
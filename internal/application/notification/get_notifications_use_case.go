@@ -0,0 +1,66 @@
+package notification
+
+import (
+	"context"
+	"panda-pocket/internal/domain/notification"
+	"time"
+)
+
+// NotificationResponse represents a notification in the response
+type NotificationResponse struct {
+	ID        int    `json:"id"`
+	Title     string `json:"title"`
+	Message   string `json:"message"`
+	Type      string `json:"type"`
+	IsRead    bool   `json:"is_read"`
+	CreatedAt string `json:"created_at"`
+}
+
+// GetNotificationsResponse represents the response for getting a user's notifications
+type GetNotificationsResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	UnreadCount   int                    `json:"unread_count"`
+}
+
+// GetNotificationsUseCase handles getting the current user's notifications
+type GetNotificationsUseCase struct {
+	notificationRepo notification.Repository
+}
+
+// NewGetNotificationsUseCase creates a new get notifications use case
+func NewGetNotificationsUseCase(notificationRepo notification.Repository) *GetNotificationsUseCase {
+	return &GetNotificationsUseCase{
+		notificationRepo: notificationRepo,
+	}
+}
+
+// Execute executes the get notifications use case
+func (uc *GetNotificationsUseCase) Execute(ctx context.Context, userID int, unreadOnly bool) (*GetNotificationsResponse, error) {
+	notifications, err := uc.notificationRepo.FindByUserID(ctx, notification.NewUserID(userID), unreadOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetNotificationsResponse{
+		Notifications: make([]NotificationResponse, len(notifications)),
+	}
+	for i, n := range notifications {
+		response.Notifications[i] = newNotificationResponse(n)
+		if !n.IsRead() {
+			response.UnreadCount++
+		}
+	}
+	return response, nil
+}
+
+// newNotificationResponse converts a domain notification for the API
+func newNotificationResponse(n *notification.Notification) NotificationResponse {
+	return NotificationResponse{
+		ID:        n.ID().Value(),
+		Title:     n.Title(),
+		Message:   n.Message(),
+		Type:      string(n.Type()),
+		IsRead:    n.IsRead(),
+		CreatedAt: n.CreatedAt().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,102 @@
+package notification
+
+import (
+	"context"
+	"panda-pocket/internal/domain/notification"
+	"time"
+)
+
+// CreateChannelRequest represents the request to add a Slack, Discord or ntfy channel
+type CreateChannelRequest struct {
+	Kind       string `json:"kind" binding:"required,oneof=slack discord ntfy"`
+	Name       string `json:"name" binding:"required,max=100"`
+	WebhookURL string `json:"webhook_url" binding:"required,url"`
+	// Types limits the channel to these notification types; empty receives all
+	Types []string `json:"types,omitempty"`
+}
+
+// ChannelResponse represents a notification channel in the response
+type ChannelResponse struct {
+	ID         int      `json:"id"`
+	Kind       string   `json:"kind"`
+	Name       string   `json:"name"`
+	WebhookURL string   `json:"webhook_url"`
+	Types      []string `json:"types"`
+	CreatedAt  string   `json:"created_at"`
+}
+
+// ManageChannelsUseCase handles users adding, listing and removing the chat
+// channels their notifications are posted to
+type ManageChannelsUseCase struct {
+	channelRepo notification.ChannelRepository
+}
+
+// NewManageChannelsUseCase creates a new manage channels use case
+func NewManageChannelsUseCase(channelRepo notification.ChannelRepository) *ManageChannelsUseCase {
+	return &ManageChannelsUseCase{
+		channelRepo: channelRepo,
+	}
+}
+
+// Create adds a channel for the user
+func (uc *ManageChannelsUseCase) Create(ctx context.Context, userID int, req CreateChannelRequest) (*ChannelResponse, error) {
+	types := make([]notification.Type, len(req.Types))
+	for i, t := range req.Types {
+		types[i] = notification.Type(t)
+	}
+
+	channel, err := notification.NewChannel(notification.NewUserID(userID), notification.ChannelKind(req.Kind), req.Name, req.WebhookURL, types)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.channelRepo.Save(ctx, channel); err != nil {
+		return nil, err
+	}
+
+	response := newChannelResponse(channel)
+	return &response, nil
+}
+
+// List returns the user's channels
+func (uc *ManageChannelsUseCase) List(ctx context.Context, userID int) ([]ChannelResponse, error) {
+	channels, err := uc.channelRepo.FindByUserID(ctx, notification.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]ChannelResponse, len(channels))
+	for i, channel := range channels {
+		responses[i] = newChannelResponse(channel)
+	}
+	return responses, nil
+}
+
+// Delete removes one of the user's channels
+func (uc *ManageChannelsUseCase) Delete(ctx context.Context, userID, channelID int) error {
+	channel, err := uc.channelRepo.FindByID(ctx, notification.NewChannelID(channelID))
+	if err != nil {
+		return err
+	}
+	if !channel.BelongsTo(notification.NewUserID(userID)) {
+		return notification.ErrChannelNotFound
+	}
+
+	return uc.channelRepo.Delete(ctx, channel.ID())
+}
+
+// newChannelResponse converts a domain channel for the API
+func newChannelResponse(channel *notification.Channel) ChannelResponse {
+	types := make([]string, len(channel.Types()))
+	for i, t := range channel.Types() {
+		types[i] = string(t)
+	}
+
+	return ChannelResponse{
+		ID:         channel.ID().Value(),
+		Kind:       string(channel.Kind()),
+		Name:       channel.Name(),
+		WebhookURL: channel.WebhookURL(),
+		Types:      types,
+		CreatedAt:  channel.CreatedAt().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,124 @@
+package notification
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"panda-pocket/internal/domain/notification"
+	"time"
+)
+
+// CreateWebhookRequest represents the request to register a webhook
+type CreateWebhookRequest struct {
+	URL string `json:"url" binding:"required,url,max=2048"`
+	// Events limits the webhook to these event names; empty receives all
+	Events []string `json:"events,omitempty"`
+}
+
+// WebhookResponse represents a registered webhook in the response
+type WebhookResponse struct {
+	ID     int      `json:"id"`
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	// Secret signs deliveries; it is only returned when the webhook is created
+	Secret    string `json:"secret,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+// ManageWebhooksUseCase handles users registering, listing and removing the
+// webhooks domain events are delivered to
+type ManageWebhooksUseCase struct {
+	webhookRepo notification.WebhookRepository
+	eventNames  map[string]bool
+}
+
+// NewManageWebhooksUseCase creates a new manage webhooks use case. eventNames are
+// the published events a webhook can subscribe to.
+func NewManageWebhooksUseCase(webhookRepo notification.WebhookRepository, eventNames []string) *ManageWebhooksUseCase {
+	names := make(map[string]bool, len(eventNames))
+	for _, name := range eventNames {
+		names[name] = true
+	}
+
+	return &ManageWebhooksUseCase{
+		webhookRepo: webhookRepo,
+		eventNames:  names,
+	}
+}
+
+// Create registers a webhook for the user with a new signing secret
+func (uc *ManageWebhooksUseCase) Create(ctx context.Context, userID int, req CreateWebhookRequest) (*WebhookResponse, error) {
+	for _, event := range req.Events {
+		if !uc.eventNames[event] {
+			return nil, notification.ErrInvalidEventName
+		}
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook, err := notification.NewWebhook(notification.NewUserID(userID), req.URL, secret, req.Events)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.webhookRepo.Save(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	response := newWebhookResponse(webhook)
+	response.Secret = webhook.Secret()
+	return &response, nil
+}
+
+// List returns the user's webhooks
+func (uc *ManageWebhooksUseCase) List(ctx context.Context, userID int) ([]WebhookResponse, error) {
+	webhooks, err := uc.webhookRepo.FindByUserID(ctx, notification.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]WebhookResponse, len(webhooks))
+	for i, webhook := range webhooks {
+		responses[i] = newWebhookResponse(webhook)
+	}
+	return responses, nil
+}
+
+// Delete removes one of the user's webhooks
+func (uc *ManageWebhooksUseCase) Delete(ctx context.Context, userID, webhookID int) error {
+	webhook, err := uc.webhookRepo.FindByID(ctx, notification.NewWebhookID(webhookID))
+	if err != nil {
+		return err
+	}
+	if !webhook.BelongsTo(notification.NewUserID(userID)) {
+		return notification.ErrWebhookNotFound
+	}
+
+	return uc.webhookRepo.Delete(ctx, webhook.ID())
+}
+
+// newWebhookSecret returns a random hex encoded signing secret
+func newWebhookSecret() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
+
+// newWebhookResponse converts a domain webhook for the API, without its secret
+func newWebhookResponse(webhook *notification.Webhook) WebhookResponse {
+	events := webhook.Events()
+	if events == nil {
+		events = []string{}
+	}
+
+	return WebhookResponse{
+		ID:        webhook.ID().Value(),
+		URL:       webhook.URL(),
+		Events:    events,
+		CreatedAt: webhook.CreatedAt().Format(time.RFC3339),
+	}
+}
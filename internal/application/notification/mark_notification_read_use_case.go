@@ -0,0 +1,38 @@
+package notification
+
+import (
+	"context"
+	"panda-pocket/internal/domain/notification"
+)
+
+// MarkNotificationReadUseCase handles marking notifications as read
+type MarkNotificationReadUseCase struct {
+	notificationRepo notification.Repository
+}
+
+// NewMarkNotificationReadUseCase creates a new mark notification read use case
+func NewMarkNotificationReadUseCase(notificationRepo notification.Repository) *MarkNotificationReadUseCase {
+	return &MarkNotificationReadUseCase{
+		notificationRepo: notificationRepo,
+	}
+}
+
+// Execute marks one of the user's notifications as read
+func (uc *MarkNotificationReadUseCase) Execute(ctx context.Context, userID, notificationID int) error {
+	n, err := uc.notificationRepo.FindByID(ctx, notification.NewNotificationID(notificationID))
+	if err != nil {
+		return err
+	}
+
+	// Other users' notifications are reported as missing rather than forbidden
+	if !n.BelongsTo(notification.NewUserID(userID)) {
+		return notification.ErrNotificationNotFound
+	}
+
+	return uc.notificationRepo.MarkRead(ctx, n.ID())
+}
+
+// MarkAll marks all of the user's notifications as read
+func (uc *MarkNotificationReadUseCase) MarkAll(ctx context.Context, userID int) error {
+	return uc.notificationRepo.MarkAllRead(ctx, notification.NewUserID(userID))
+}
@@ -0,0 +1,112 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"panda-pocket/internal/domain/finance"
+	"panda-pocket/internal/domain/notification"
+	"time"
+)
+
+// SendCardPaymentRemindersUseCase reminds users to pay their credit card
+// statements shortly before payment is due
+type SendCardPaymentRemindersUseCase struct {
+	accountService *finance.AccountService
+	currencyRepo   finance.CurrencyRepository
+	dispatcher     *Dispatcher
+	daysAhead      int
+}
+
+// NewSendCardPaymentRemindersUseCase creates a new send card payment reminders
+// use case that reminds users daysAhead days before a payment is due
+func NewSendCardPaymentRemindersUseCase(
+	accountService *finance.AccountService,
+	currencyRepo finance.CurrencyRepository,
+	dispatcher *Dispatcher,
+	daysAhead int,
+) *SendCardPaymentRemindersUseCase {
+	return &SendCardPaymentRemindersUseCase{
+		accountService: accountService,
+		currencyRepo:   currencyRepo,
+		dispatcher:     dispatcher,
+		daysAhead:      daysAhead,
+	}
+}
+
+// Run sends reminders at every interval until ctx is cancelled
+func (uc *SendCardPaymentRemindersUseCase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sent, err := uc.Execute(ctx, time.Now())
+			if err != nil {
+				slog.Error("card payment reminders failed", "error", err.Error())
+				continue
+			}
+			if sent > 0 {
+				slog.Info("sent card payment reminders", "count", sent)
+			}
+		}
+	}
+}
+
+// Execute reminds users of the minimum payment of their last closed statement
+// when it is due within daysAhead days of at and has not been paid since the
+// statement closed, and returns how many reminders were sent. Each statement is
+// reminded of once, however often Execute runs.
+func (uc *SendCardPaymentRemindersUseCase) Execute(ctx context.Context, at time.Time) (int, error) {
+	accounts, err := uc.accountService.GetAccountsWithBillingCycles(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, account := range accounts {
+		reminded, err := uc.remind(ctx, account, at)
+		if err != nil {
+			slog.Error("failed to send card payment reminder", "account_id", account.ID().Value(), "error", err.Error())
+			continue
+		}
+		if reminded {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// remind notifies the owner of the card's last closed statement if it needs paying
+func (uc *SendCardPaymentRemindersUseCase) remind(ctx context.Context, account *finance.Account, at time.Time) (bool, error) {
+	statements, err := uc.accountService.GetCardStatements(ctx, account, 2, at)
+	if err != nil {
+		return false, err
+	}
+	statement := statements[1] // the open cycle comes first
+	paid, err := uc.accountService.GetPaymentsSince(ctx, account.ID(), statement.ClosingDate, at)
+	if err != nil {
+		return false, err
+	}
+	if !account.NeedsPaymentReminder(statement, paid, at, uc.daysAhead) {
+		return false, nil
+	}
+
+	currency := ""
+	if found, err := uc.currencyRepo.FindByID(ctx, account.CurrencyID()); err == nil {
+		currency = " " + found.Code()
+	}
+	title := "Credit card payment due"
+	message := fmt.Sprintf("The %s statement balance is %.2f%s. Pay at least %.2f%s by %s.",
+		account.Name(), statement.Balance(), currency, statement.MinimumPayment(), currency,
+		statement.DueDate.Format("Mon, 2 Jan 2006"))
+
+	if err := uc.dispatcher.Notify(ctx, account.UserID().Value(), notification.TypeCardPaymentReminder, title, message); err != nil {
+		return false, err
+	}
+	account.MarkPaymentReminded(statement)
+	return true, uc.accountService.SaveAccount(ctx, account)
+}
@@ -0,0 +1,136 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"panda-pocket/internal/domain/finance"
+	"panda-pocket/internal/domain/identity"
+	"panda-pocket/internal/domain/notification"
+	"time"
+)
+
+// SendRecurringRemindersUseCase reminds users of recurring transactions shortly
+// before they are due, in the app, on their channels and by email
+type SendRecurringRemindersUseCase struct {
+	recurringRepo    finance.RecurringTransactionRepository
+	currencyRepo     finance.CurrencyRepository
+	notificationRepo notification.Repository
+	userService      *identity.UserService
+	dispatcher       *Dispatcher
+	mailer           Mailer
+	daysAhead        int
+}
+
+// NewSendRecurringRemindersUseCase creates a new send recurring reminders use
+// case that reminds users daysAhead days before a charge
+func NewSendRecurringRemindersUseCase(
+	recurringRepo finance.RecurringTransactionRepository,
+	currencyRepo finance.CurrencyRepository,
+	notificationRepo notification.Repository,
+	userService *identity.UserService,
+	dispatcher *Dispatcher,
+	mailer Mailer,
+	daysAhead int,
+) *SendRecurringRemindersUseCase {
+	return &SendRecurringRemindersUseCase{
+		recurringRepo:    recurringRepo,
+		currencyRepo:     currencyRepo,
+		notificationRepo: notificationRepo,
+		userService:      userService,
+		dispatcher:       dispatcher,
+		mailer:           mailer,
+		daysAhead:        daysAhead,
+	}
+}
+
+// Run sends reminders at every interval until ctx is cancelled
+func (uc *SendRecurringRemindersUseCase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sent, err := uc.Execute(ctx, time.Now())
+			if err != nil {
+				slog.Error("recurring reminders failed", "error", err.Error())
+				continue
+			}
+			if sent > 0 {
+				slog.Info("sent recurring reminders", "count", sent)
+			}
+		}
+	}
+}
+
+// Execute reminds users of the occurrences due within daysAhead days of at that
+// they have not been reminded of, and returns how many reminders were sent. Each
+// occurrence is reminded of once, however often Execute runs.
+func (uc *SendRecurringRemindersUseCase) Execute(ctx context.Context, at time.Time) (int, error) {
+	today := at.UTC().Truncate(24 * time.Hour)
+	upcoming, err := uc.recurringRepo.FindUpcomingForReminders(ctx, today, today.AddDate(0, 0, uc.daysAhead))
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, recurring := range upcoming {
+		if !recurring.NeedsReminder(at, uc.daysAhead) {
+			continue
+		}
+
+		if err := uc.remind(ctx, recurring); err != nil {
+			slog.Error("failed to send recurring reminder", "recurring_transaction_id", recurring.ID().Value(), "error", err.Error())
+			continue
+		}
+		recurring.MarkReminded()
+		if err := uc.recurringRepo.Save(ctx, recurring); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// remind notifies the owner of the next occurrence. Email failures are logged
+// rather than returned, as the in-app notification has already been saved.
+func (uc *SendRecurringRemindersUseCase) remind(ctx context.Context, recurring *finance.RecurringTransaction) error {
+	occurrence := recurring.NextOccurrence()
+	amount := fmt.Sprintf("%.2f", occurrence.Amount.Amount())
+	if currency, err := uc.currencyRepo.FindByID(ctx, recurring.CurrencyID()); err == nil {
+		amount += " " + currency.Code()
+	}
+
+	description := recurring.Description()
+	if description == "" {
+		description = "A recurring transaction"
+	}
+	title := "Upcoming recurring charge"
+	message := fmt.Sprintf("%s of %s is due on %s.", description, amount, occurrence.Date.Format("Mon, 2 Jan 2006"))
+
+	userID := recurring.UserID().Value()
+	if err := uc.dispatcher.Notify(ctx, userID, notification.TypeRecurringReminder, title, message); err != nil {
+		return err
+	}
+	if err := uc.email(ctx, userID, title, message); err != nil {
+		slog.Error("failed to email recurring reminder", "user_id", userID, "error", err.Error())
+	}
+	return nil
+}
+
+// email sends the reminder to the user if they accept email notifications
+func (uc *SendRecurringRemindersUseCase) email(ctx context.Context, userID int, subject, body string) error {
+	accepted, err := uc.notificationRepo.EmailRecipients(ctx, []notification.UserID{notification.NewUserID(userID)})
+	if err != nil || len(accepted) == 0 {
+		return err
+	}
+
+	user, err := uc.userService.GetUserByID(ctx, identity.NewUserID(userID))
+	if err != nil {
+		return err
+	}
+	return uc.mailer.Send(ctx, user.Email().Value(), subject, body)
+}
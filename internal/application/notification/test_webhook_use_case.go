@@ -0,0 +1,100 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	domainFinance "panda-pocket/internal/domain/finance"
+	"panda-pocket/internal/domain/notification"
+	"time"
+)
+
+// WebhookTester delivers a signed payload to a webhook like WebhookSender and
+// reports the status code the endpoint answered with, or 0 when it did not answer
+type WebhookTester interface {
+	Deliver(ctx context.Context, url, secret, event string, payload []byte) (int, error)
+}
+
+// TestWebhookRequest represents the optional event a test delivery samples
+type TestWebhookRequest struct {
+	Event string `json:"event"` // defaults to the first event the webhook receives
+}
+
+// TestWebhookResponse represents the result of a test delivery. Delivered is set
+// when the endpoint answered with a 2xx status, so an endpoint that rejects
+// payloads with a bad signature shows up as not delivered.
+type TestWebhookResponse struct {
+	WebhookID  int             `json:"webhook_id"`
+	Event      string          `json:"event"`
+	Delivered  bool            `json:"delivered"`
+	StatusCode int             `json:"status_code,omitempty"`
+	DurationMS int64           `json:"duration_ms"`
+	Error      string          `json:"error,omitempty"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// TestWebhookUseCase handles sending sample events to a user's webhook, so
+// integrators can check their signature verification before real events flow
+type TestWebhookUseCase struct {
+	webhookRepo notification.WebhookRepository
+	tester      WebhookTester
+	samples     []domainFinance.Event
+}
+
+// NewTestWebhookUseCase creates a new test webhook use case. samples are example
+// payloads of the published events, in the order they are listed.
+func NewTestWebhookUseCase(webhookRepo notification.WebhookRepository, tester WebhookTester, samples []domainFinance.Event) *TestWebhookUseCase {
+	return &TestWebhookUseCase{
+		webhookRepo: webhookRepo,
+		tester:      tester,
+		samples:     samples,
+	}
+}
+
+// Execute signs a sample of the event with the webhook's secret and delivers it
+// as a real event would be, marked as a test. A failed delivery is reported in
+// the response rather than returned as an error.
+func (uc *TestWebhookUseCase) Execute(ctx context.Context, userID, webhookID int, req TestWebhookRequest) (*TestWebhookResponse, error) {
+	webhook, err := uc.webhookRepo.FindByID(ctx, notification.NewWebhookID(webhookID))
+	if err != nil {
+		return nil, err
+	}
+	if !webhook.BelongsTo(notification.NewUserID(userID)) {
+		return nil, notification.ErrWebhookNotFound
+	}
+
+	var sample domainFinance.Event
+	for _, candidate := range uc.samples {
+		if req.Event == "" && webhook.Receives(candidate.EventName()) || candidate.EventName() == req.Event {
+			sample = candidate
+			break
+		}
+	}
+	if sample == nil {
+		return nil, notification.ErrInvalidEventName
+	}
+
+	payload, err := json.Marshal(WebhookPayload{
+		Event:      sample.EventName(),
+		OccurredAt: time.Now().UTC(),
+		Data:       sample,
+		Test:       true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	statusCode, err := uc.tester.Deliver(ctx, webhook.URL(), webhook.Secret(), sample.EventName(), payload)
+	response := &TestWebhookResponse{
+		WebhookID:  webhook.ID().Value(),
+		Event:      sample.EventName(),
+		Delivered:  err == nil,
+		StatusCode: statusCode,
+		DurationMS: time.Since(start).Milliseconds(),
+		Payload:    payload,
+	}
+	if err != nil {
+		response.Error = err.Error()
+	}
+	return response, nil
+}
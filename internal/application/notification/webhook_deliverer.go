@@ -0,0 +1,76 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	domainFinance "panda-pocket/internal/domain/finance"
+	"panda-pocket/internal/domain/notification"
+	"time"
+)
+
+// WebhookSender delivers a JSON payload to a webhook, signed with its secret
+type WebhookSender interface {
+	Send(ctx context.Context, url, secret, event string, payload []byte) error
+}
+
+// WebhookPayload is the body delivered to registered webhooks
+type WebhookPayload struct {
+	Event      string              `json:"event"`
+	OccurredAt time.Time           `json:"occurred_at"`
+	Data       domainFinance.Event `json:"data"`
+	// Test marks sample deliveries requested by the user, which receivers should not act on
+	Test bool `json:"test,omitempty"`
+}
+
+// WebhookDeliverer delivers a user's domain events to the webhooks they registered
+type WebhookDeliverer struct {
+	webhookRepo notification.WebhookRepository
+	sender      WebhookSender
+}
+
+// NewWebhookDeliverer creates a new webhook deliverer
+func NewWebhookDeliverer(webhookRepo notification.WebhookRepository, sender WebhookSender) *WebhookDeliverer {
+	return &WebhookDeliverer{
+		webhookRepo: webhookRepo,
+		sender:      sender,
+	}
+}
+
+// HandleEvent sends the event to every webhook of its user subscribed to it.
+// Delivery failures are logged rather than returned, as for chat channels; one
+// unreachable endpoint must not mark the event failed for every other handler.
+func (d *WebhookDeliverer) HandleEvent(ctx context.Context, event domainFinance.Event) error {
+	userEvent, ok := event.(domainFinance.UserEvent)
+	if !ok {
+		return nil
+	}
+
+	webhooks, err := d.webhookRepo.FindByUserID(ctx, notification.NewUserID(userEvent.EventUserID()))
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+	for _, webhook := range webhooks {
+		if !webhook.Receives(event.EventName()) {
+			continue
+		}
+		if payload == nil {
+			payload, err = json.Marshal(WebhookPayload{
+				Event:      event.EventName(),
+				OccurredAt: event.OccurredAt(),
+				Data:       event,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := d.sender.Send(ctx, webhook.URL(), webhook.Secret(), event.EventName(), payload); err != nil {
+			slog.Error("failed to deliver event to webhook",
+				"webhook_id", webhook.ID().Value(), "event", event.EventName(), "error", err.Error())
+		}
+	}
+	return nil
+}
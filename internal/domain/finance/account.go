@@ -0,0 +1,210 @@
+package finance
+
+import (
+	"strings"
+	"time"
+)
+
+// AccountType represents the kind of account money is held in
+type AccountType string
+
+const (
+	AccountTypeChecking   AccountType = "checking"
+	AccountTypeSavings    AccountType = "savings"
+	AccountTypeCash       AccountType = "cash"
+	AccountTypeCreditCard AccountType = "credit_card"
+)
+
+// AccountID is a value object representing an account identifier
+type AccountID struct {
+	value int
+}
+
+func NewAccountID(id int) AccountID {
+	return AccountID{value: id}
+}
+
+func (a AccountID) Value() int {
+	return a.value
+}
+
+// IsZero reports whether the ID is unset, as for transactions without an account
+func (a AccountID) IsZero() bool {
+	return a.value == 0
+}
+
+// Account represents a bank account, card or wallet that transactions are recorded against
+type Account struct {
+	id          AccountID
+	userID      UserID
+	name        string
+	accountType AccountType
+	currencyID  CurrencyID
+	createdAt   time.Time
+	// billingCycle is set for credit cards with statement cycles
+	billingCycle *BillingCycle
+	// paymentRemindedFor is the closing date of the last statement the user was reminded to pay
+	paymentRemindedFor *time.Time
+	// openingBalance is the balance before the first transaction recorded on the account
+	openingBalance float64
+	// archivedAt is set once the account is closed; its history is kept
+	archivedAt *time.Time
+	// emergencyFund is set on the savings the user keeps for emergencies
+	emergencyFund bool
+}
+
+// NewAccount creates a new account. The opening balance may be negative, as for
+// a card that already has spending on it.
+func NewAccount(userID UserID, name string, accountType AccountType, currencyID CurrencyID, openingBalance float64) (*Account, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrEmptyAccountName
+	}
+
+	switch accountType {
+	case AccountTypeChecking, AccountTypeSavings, AccountTypeCash, AccountTypeCreditCard:
+	default:
+		return nil, ErrInvalidAccountType
+	}
+
+	openingBalance, err := signedAmount(openingBalance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Account{
+		userID:         userID,
+		name:           name,
+		accountType:    accountType,
+		currencyID:     currencyID,
+		createdAt:      time.Now(),
+		openingBalance: openingBalance,
+	}, nil
+}
+
+// RestoreAccount rebuilds a persisted account
+func RestoreAccount(id AccountID, userID UserID, name string, accountType AccountType, currencyID CurrencyID, createdAt time.Time, billingCycle *BillingCycle, paymentRemindedFor *time.Time, openingBalance float64, archivedAt *time.Time, emergencyFund bool) *Account {
+	return &Account{
+		id:                 id,
+		userID:             userID,
+		name:               name,
+		accountType:        accountType,
+		currencyID:         currencyID,
+		createdAt:          createdAt,
+		billingCycle:       billingCycle,
+		paymentRemindedFor: paymentRemindedFor,
+		openingBalance:     openingBalance,
+		archivedAt:         archivedAt,
+		emergencyFund:      emergencyFund,
+	}
+}
+
+// Getters
+func (a *Account) ID() AccountID {
+	return a.id
+}
+
+func (a *Account) UserID() UserID {
+	return a.userID
+}
+
+func (a *Account) Name() string {
+	return a.name
+}
+
+func (a *Account) Type() AccountType {
+	return a.accountType
+}
+
+func (a *Account) CurrencyID() CurrencyID {
+	return a.currencyID
+}
+
+func (a *Account) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+func (a *Account) BillingCycle() *BillingCycle {
+	return a.billingCycle
+}
+
+func (a *Account) PaymentRemindedFor() *time.Time {
+	return a.paymentRemindedFor
+}
+
+func (a *Account) OpeningBalance() float64 {
+	return a.openingBalance
+}
+
+func (a *Account) ArchivedAt() *time.Time {
+	return a.archivedAt
+}
+
+func (a *Account) EmergencyFund() bool {
+	return a.emergencyFund
+}
+
+// IsArchived reports whether the account has been closed
+func (a *Account) IsArchived() bool {
+	return a.archivedAt != nil
+}
+
+// Archive closes the account: it is hidden from account lists and takes no new
+// transactions, but its history still counts everywhere it did before
+func (a *Account) Archive(at time.Time) {
+	if a.archivedAt == nil {
+		a.archivedAt = &at
+	}
+}
+
+// Unarchive reopens an archived account
+func (a *Account) Unarchive() {
+	a.archivedAt = nil
+}
+
+// SetEmergencyFund chooses whether the account holds the user's emergency fund.
+// Any number of accounts can make up the fund.
+func (a *Account) SetEmergencyFund(emergencyFund bool) {
+	a.emergencyFund = emergencyFund
+}
+
+// SetBillingCycle sets when the card's statements close and are due; nil removes
+// the cycle. Only credit cards have billing cycles.
+func (a *Account) SetBillingCycle(cycle *BillingCycle) error {
+	if cycle != nil && a.accountType != AccountTypeCreditCard {
+		return ErrBillingCycleNotCreditCard
+	}
+	a.billingCycle = cycle
+	return nil
+}
+
+// AssignID sets the ID given by the repository on save
+func (a *Account) AssignID(id AccountID) {
+	a.id = id
+}
+
+// BelongsTo reports whether the account is owned by the user
+func (a *Account) BelongsTo(userID UserID) bool {
+	return a.userID.Value() == userID.Value()
+}
+
+// NeedsPaymentReminder reports whether the statement's payment is due within
+// daysAhead days of at, the minimum has not been paid since it closed, and the
+// user has not been reminded of it yet
+func (a *Account) NeedsPaymentReminder(statement *CardStatement, paidSinceClosing float64, at time.Time, daysAhead int) bool {
+	if statement.Open || statement.MinimumPayment() <= 0 || paidSinceClosing >= statement.MinimumPayment() {
+		return false
+	}
+
+	today := at.UTC().Truncate(24 * time.Hour)
+	if statement.DueDate.Before(today) || statement.DueDate.After(today.AddDate(0, 0, daysAhead)) {
+		return false
+	}
+	return a.paymentRemindedFor == nil || !a.paymentRemindedFor.Equal(statement.ClosingDate)
+}
+
+// MarkPaymentReminded records that the user was reminded to pay the statement
+func (a *Account) MarkPaymentReminded(statement *CardStatement) {
+	closing := statement.ClosingDate
+	a.paymentRemindedFor = &closing
+}
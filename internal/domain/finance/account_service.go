@@ -0,0 +1,431 @@
+package finance
+
+import (
+	"context"
+	"time"
+)
+
+// AccountService handles account-related domain operations
+type AccountService struct {
+	accountRepo     AccountRepository
+	currencyRepo    CurrencyRepository
+	transactionRepo TransactionRepository
+	assertionRepo   BalanceAssertionRepository
+	adjustmentRepo  BalanceAdjustmentRepository
+}
+
+// NewAccountService creates a new account service
+func NewAccountService(
+	accountRepo AccountRepository,
+	currencyRepo CurrencyRepository,
+	transactionRepo TransactionRepository,
+	assertionRepo BalanceAssertionRepository,
+	adjustmentRepo BalanceAdjustmentRepository,
+) *AccountService {
+	return &AccountService{
+		accountRepo:     accountRepo,
+		currencyRepo:    currencyRepo,
+		transactionRepo: transactionRepo,
+		assertionRepo:   assertionRepo,
+		adjustmentRepo:  adjustmentRepo,
+	}
+}
+
+// CreateAccount creates a new account holding openingBalance before its first transaction
+func (s *AccountService) CreateAccount(ctx context.Context, userID UserID, name string, accountType AccountType, currencyID CurrencyID, openingBalance float64) (*Account, error) {
+	// Validate currency exists and user has access
+	currency, err := s.currencyRepo.FindByID(ctx, currencyID)
+	if err != nil {
+		return nil, ErrCurrencyNotFound
+	}
+	if !currency.IsDefault() && (currency.UserID() == nil || currency.UserID().Value() != userID.Value()) {
+		return nil, ErrCurrencyAccessDenied
+	}
+
+	account, err := NewAccount(userID, name, accountType, currencyID, openingBalance)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.accountRepo.Save(ctx, account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// GetAccountsByUser retrieves a user's accounts; archived accounts are left out
+// unless includeArchived is set
+func (s *AccountService) GetAccountsByUser(ctx context.Context, userID UserID, includeArchived bool) ([]*Account, error) {
+	accounts, err := s.accountRepo.FindByUserID(ctx, userID)
+	if err != nil || includeArchived {
+		return accounts, err
+	}
+
+	open := accounts[:0]
+	for _, account := range accounts {
+		if !account.IsArchived() {
+			open = append(open, account)
+		}
+	}
+	return open, nil
+}
+
+// GetAccount retrieves one of the user's accounts; other users' accounts are not found
+func (s *AccountService) GetAccount(ctx context.Context, accountID AccountID, userID UserID) (*Account, error) {
+	account, err := s.accountRepo.FindByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if !account.BelongsTo(userID) {
+		return nil, ErrAccountNotFound
+	}
+	return account, nil
+}
+
+// SetArchived archives or unarchives one of the user's accounts
+func (s *AccountService) SetArchived(ctx context.Context, accountID AccountID, userID UserID, archived bool) (*Account, error) {
+	account, err := s.GetAccount(ctx, accountID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if archived {
+		account.Archive(time.Now())
+	} else {
+		account.Unarchive()
+	}
+	if err := s.accountRepo.Save(ctx, account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// SetEmergencyFund adds one of the user's accounts to or removes it from their emergency fund
+func (s *AccountService) SetEmergencyFund(ctx context.Context, accountID AccountID, userID UserID, emergencyFund bool) (*Account, error) {
+	account, err := s.GetAccount(ctx, accountID, userID)
+	if err != nil {
+		return nil, err
+	}
+	account.SetEmergencyFund(emergencyFund)
+	if err := s.accountRepo.Save(ctx, account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// SetBillingCycle sets or, with a nil cycle, removes the statement cycle of one of the user's credit cards
+func (s *AccountService) SetBillingCycle(ctx context.Context, accountID AccountID, userID UserID, cycle *BillingCycle) (*Account, error) {
+	account, err := s.GetAccount(ctx, accountID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := account.SetBillingCycle(cycle); err != nil {
+		return nil, err
+	}
+	if err := s.accountRepo.Save(ctx, account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// GetCardStatements summarizes the last count billing cycles of a credit card up
+// to the one open at at, newest first. Each statement's previous balance carries
+// over everything recorded on the card before it.
+func (s *AccountService) GetCardStatements(ctx context.Context, account *Account, count int, at time.Time) ([]*CardStatement, error) {
+	cycle := account.BillingCycle()
+	if cycle == nil {
+		return nil, ErrNoBillingCycle
+	}
+
+	closings := make([]time.Time, count)
+	closings[0] = cycle.ClosingDateFor(at)
+	for i := 1; i < count; i++ {
+		closings[i] = cycle.PreviousClosingDate(closings[i-1])
+	}
+
+	// Totals are inclusive of their end date, so stop just before the oldest cycle starts
+	oldestStart := cycle.PreviousClosingDate(closings[count-1]).AddDate(0, 0, 1)
+	before, err := s.balanceThrough(ctx, account, oldestStart.Add(-time.Nanosecond))
+	if err != nil {
+		return nil, err
+	}
+	// What is owed on a card is its balance turned around
+	balance := -before
+
+	today := at.UTC().Truncate(24 * time.Hour)
+	statements := make([]*CardStatement, count)
+	for i := count - 1; i >= 0; i-- {
+		start := cycle.PreviousClosingDate(closings[i]).AddDate(0, 0, 1)
+		end := closings[i].Add(24*time.Hour - time.Nanosecond)
+		totals, err := s.transactionRepo.GetAccountTotals(ctx, account.ID(), start, end)
+		if err != nil {
+			return nil, err
+		}
+		adjusted, err := s.adjustmentRepo.SumByAccount(ctx, account.ID(), start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		statement := &CardStatement{
+			PeriodStart:      start,
+			ClosingDate:      closings[i],
+			DueDate:          cycle.DueDate(closings[i]),
+			Open:             !closings[i].Before(today),
+			PreviousBalance:  roundCents(balance),
+			Charges:          roundCents(totals.Withdrawals),
+			Payments:         roundCents(totals.Deposits),
+			Adjustments:      roundCents(-adjusted),
+			TransactionCount: totals.Count,
+		}
+		statements[i] = statement
+		balance = statement.Balance()
+	}
+	return statements, nil
+}
+
+// GetPaymentsSince sums the payments recorded on an account after a statement closed, up to at
+func (s *AccountService) GetPaymentsSince(ctx context.Context, accountID AccountID, closing, at time.Time) (float64, error) {
+	totals, err := s.transactionRepo.GetAccountTotals(ctx, accountID, closing.AddDate(0, 0, 1), at)
+	if err != nil {
+		return 0, err
+	}
+	return roundCents(totals.Deposits), nil
+}
+
+// GetAccountsWithBillingCycles returns every user's credit cards with statement cycles
+func (s *AccountService) GetAccountsWithBillingCycles(ctx context.Context) ([]*Account, error) {
+	return s.accountRepo.FindWithBillingCycle(ctx)
+}
+
+// GetBalance derives an account's balance as of at from its opening
+// balance, transactions and adjustments
+func (s *AccountService) GetBalance(ctx context.Context, account *Account, at time.Time) (float64, error) {
+	return s.balanceThrough(ctx, account, at)
+}
+
+// SaveAccount persists changes to an account, such as a payment reminder being sent
+func (s *AccountService) SaveAccount(ctx context.Context, account *Account) error {
+	return s.accountRepo.Save(ctx, account)
+}
+
+// Reconcile compares an account's recorded transactions with a bank statement.
+// When markReconciled is set and the totals match, the period's transactions are
+// marked reconciled; a mismatch leaves them unchanged so it can be investigated.
+func (s *AccountService) Reconcile(ctx context.Context, accountID AccountID, userID UserID, statement Statement, markReconciled bool) (*Reconciliation, error) {
+	if statement.EndDate.Before(statement.StartDate) {
+		return nil, ErrInvalidStatementPeriod
+	}
+	if _, err := s.GetAccount(ctx, accountID, userID); err != nil {
+		return nil, err
+	}
+
+	totals, err := s.transactionRepo.GetAccountTotals(ctx, accountID, statement.StartDate, statement.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	reconciliation := &Reconciliation{
+		AccountID:           accountID,
+		Statement:           statement,
+		RecordedDeposits:    roundCents(totals.Deposits),
+		RecordedWithdrawals: roundCents(totals.Withdrawals),
+		TransactionCount:    totals.Count,
+	}
+	if !markReconciled || !reconciliation.Balanced() {
+		return reconciliation, nil
+	}
+
+	if _, err := s.transactionRepo.UpdateStatusByAccount(ctx, accountID, statement.StartDate, statement.EndDate, StatusReconciled); err != nil {
+		return nil, err
+	}
+	reconciliation.Reconciled = true
+	return reconciliation, nil
+}
+
+// AssertBalance records the balance the user saw on an account at the end of a day
+// and checks it against the recorded transactions
+func (s *AccountService) AssertBalance(ctx context.Context, accountID AccountID, userID UserID, date time.Time, balance float64) (*BalanceCheck, error) {
+	if _, err := s.GetAccount(ctx, accountID, userID); err != nil {
+		return nil, err
+	}
+
+	assertion := NewBalanceAssertion(userID, accountID, date, balance)
+	if err := s.assertionRepo.Save(ctx, assertion); err != nil {
+		return nil, err
+	}
+
+	checks, err := s.checkBalances(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	for _, check := range checks {
+		if check.Assertion.ID() == assertion.ID() {
+			return check, nil
+		}
+	}
+	return nil, ErrBalanceAssertionNotFound
+}
+
+// GetBalanceChecks checks every assertion on an account against the transactions
+// recorded now, so later edits that break or fix a balance show up
+func (s *AccountService) GetBalanceChecks(ctx context.Context, accountID AccountID, userID UserID) ([]*BalanceCheck, error) {
+	if _, err := s.GetAccount(ctx, accountID, userID); err != nil {
+		return nil, err
+	}
+	return s.checkBalances(ctx, accountID)
+}
+
+// GetLedger lists the account's transactions and balance adjustments dated
+// within the period, oldest first, with the balance after each. Either end of the
+// period may be nil; the opening balance is the account's balance before the start.
+func (s *AccountService) GetLedger(ctx context.Context, accountID AccountID, userID UserID, startDate, endDate *time.Time) (*Ledger, error) {
+	if startDate != nil && endDate != nil && endDate.Before(*startDate) {
+		return nil, ErrInvalidStatementPeriod
+	}
+	account, err := s.GetAccount(ctx, accountID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	openingBalance := account.OpeningBalance()
+	if startDate != nil {
+		// Totals are inclusive of their end date, so stop just before the start
+		openingBalance, err = s.balanceThrough(ctx, account, startDate.Add(-time.Nanosecond))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	transactions, _, err := s.transactionRepo.FindByUserIDWithFilters(ctx, userID, TransactionFilters{
+		AccountID: &accountID,
+		StartDate: startDate,
+		EndDate:   endDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	allAdjustments, err := s.adjustmentRepo.FindByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	var adjustments []*BalanceAdjustment
+	for _, adjustment := range allAdjustments {
+		if startDate != nil && adjustment.Date().Before(*startDate) {
+			continue
+		}
+		if endDate != nil && adjustment.Date().After(*endDate) {
+			continue
+		}
+		adjustments = append(adjustments, adjustment)
+	}
+	return NewLedger(accountID, openingBalance, transactions, adjustments), nil
+}
+
+// AdjustBalance records an adjustment bringing the account's derived balance at
+// the end of date to balance, such as to match a bank statement the recorded
+// transactions fall short of
+func (s *AccountService) AdjustBalance(ctx context.Context, accountID AccountID, userID UserID, date time.Time, balance float64, description string) (*BalanceAdjustment, error) {
+	account, err := s.GetAccount(ctx, accountID, userID)
+	if err != nil {
+		return nil, err
+	}
+	balance, err = signedAmount(balance)
+	if err != nil {
+		return nil, err
+	}
+
+	derived, err := s.balanceThrough(ctx, account, date)
+	if err != nil {
+		return nil, err
+	}
+	adjustment, err := NewBalanceAdjustment(userID, accountID, balance-derived, date, description)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.adjustmentRepo.Save(ctx, adjustment); err != nil {
+		return nil, err
+	}
+	return adjustment, nil
+}
+
+// GetBalanceAdjustments lists one of the user's account's adjustments, oldest first
+func (s *AccountService) GetBalanceAdjustments(ctx context.Context, accountID AccountID, userID UserID) ([]*BalanceAdjustment, error) {
+	if _, err := s.GetAccount(ctx, accountID, userID); err != nil {
+		return nil, err
+	}
+	return s.adjustmentRepo.FindByAccountID(ctx, accountID)
+}
+
+// DeleteBalanceAdjustment deletes one of an account's balance adjustments
+func (s *AccountService) DeleteBalanceAdjustment(ctx context.Context, accountID AccountID, adjustmentID BalanceAdjustmentID, userID UserID) error {
+	if _, err := s.GetAccount(ctx, accountID, userID); err != nil {
+		return err
+	}
+
+	adjustment, err := s.adjustmentRepo.FindByID(ctx, adjustmentID)
+	if err != nil {
+		return err
+	}
+	if adjustment.AccountID() != accountID {
+		return ErrBalanceAdjustmentNotFound
+	}
+	return s.adjustmentRepo.Delete(ctx, adjustmentID)
+}
+
+// DeleteBalanceAssertion deletes one of an account's balance assertions
+func (s *AccountService) DeleteBalanceAssertion(ctx context.Context, accountID AccountID, assertionID BalanceAssertionID, userID UserID) error {
+	if _, err := s.GetAccount(ctx, accountID, userID); err != nil {
+		return err
+	}
+
+	assertion, err := s.assertionRepo.FindByID(ctx, assertionID)
+	if err != nil {
+		return err
+	}
+	if assertion.AccountID() != accountID {
+		return ErrBalanceAssertionNotFound
+	}
+	return s.assertionRepo.Delete(ctx, assertionID)
+}
+
+// checkBalances derives the account balance at each assertion date, oldest first
+func (s *AccountService) checkBalances(ctx context.Context, accountID AccountID) ([]*BalanceCheck, error) {
+	account, err := s.accountRepo.FindByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	assertions, err := s.assertionRepo.FindByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	checks := make([]*BalanceCheck, len(assertions))
+	for i, assertion := range assertions {
+		derived, err := s.balanceThrough(ctx, account, assertion.Date())
+		if err != nil {
+			return nil, err
+		}
+
+		checks[i] = &BalanceCheck{
+			Assertion:      assertion,
+			DerivedBalance: derived,
+		}
+		if i > 0 {
+			checks[i].PreviousDifference = checks[i-1].Difference()
+		}
+	}
+	return checks, nil
+}
+
+// balanceThrough derives the account's balance from its opening balance and
+// every transaction and adjustment dated up to end
+func (s *AccountService) balanceThrough(ctx context.Context, account *Account, end time.Time) (float64, error) {
+	totals, err := s.transactionRepo.GetAccountTotals(ctx, account.ID(), time.Time{}, end)
+	if err != nil {
+		return 0, err
+	}
+	adjusted, err := s.adjustmentRepo.SumByAccount(ctx, account.ID(), time.Time{}, end)
+	if err != nil {
+		return 0, err
+	}
+	return roundCents(account.OpeningBalance() + totals.Deposits - totals.Withdrawals + adjusted), nil
+}
@@ -0,0 +1,161 @@
+package finance
+
+import (
+	"time"
+)
+
+// UndoWindow is how long after an update or delete the change can be undone
+const UndoWindow = 10 * time.Minute
+
+// ActionKind is the kind of change an action records
+type ActionKind string
+
+const (
+	ActionKindUpdate ActionKind = "update"
+	ActionKindDelete ActionKind = "delete"
+)
+
+// ActionTarget is the kind of record an action changed
+type ActionTarget string
+
+const (
+	ActionTargetTransaction ActionTarget = "transaction"
+	ActionTargetBudget      ActionTarget = "budget"
+)
+
+// ActionID is a value object representing an action identifier
+type ActionID struct {
+	value int
+}
+
+func NewActionID(id int) ActionID {
+	return ActionID{value: id}
+}
+
+func (a ActionID) Value() int {
+	return a.value
+}
+
+// Action is an audit log entry holding the state of a transaction or budget
+// before it was updated or deleted, so the change can be undone
+type Action struct {
+	id          ActionID
+	userID      UserID
+	kind        ActionKind
+	target      ActionTarget
+	targetID    int
+	transaction *Transaction // previous state when the target is a transaction
+	budget      *Budget      // previous state when the target is a budget
+	createdAt   time.Time
+	undoneAt    *time.Time
+}
+
+// NewTransactionAction records the state of a transaction before a change
+func NewTransactionAction(kind ActionKind, previous *Transaction) *Action {
+	snapshot := *previous
+	return &Action{
+		userID:      previous.UserID(),
+		kind:        kind,
+		target:      ActionTargetTransaction,
+		targetID:    previous.ID().Value(),
+		transaction: &snapshot,
+		createdAt:   time.Now(),
+	}
+}
+
+// NewBudgetAction records the state of a budget before a change
+func NewBudgetAction(kind ActionKind, previous *Budget) *Action {
+	snapshot := *previous
+	return &Action{
+		userID:    previous.UserID(),
+		kind:      kind,
+		target:    ActionTargetBudget,
+		targetID:  previous.ID().Value(),
+		budget:    &snapshot,
+		createdAt: time.Now(),
+	}
+}
+
+// RestoreAction rebuilds a persisted action
+func RestoreAction(
+	id ActionID,
+	userID UserID,
+	kind ActionKind,
+	target ActionTarget,
+	targetID int,
+	transaction *Transaction,
+	budget *Budget,
+	createdAt time.Time,
+	undoneAt *time.Time,
+) *Action {
+	return &Action{
+		id:          id,
+		userID:      userID,
+		kind:        kind,
+		target:      target,
+		targetID:    targetID,
+		transaction: transaction,
+		budget:      budget,
+		createdAt:   createdAt,
+		undoneAt:    undoneAt,
+	}
+}
+
+// Getters
+func (a *Action) ID() ActionID {
+	return a.id
+}
+
+func (a *Action) UserID() UserID {
+	return a.userID
+}
+
+func (a *Action) Kind() ActionKind {
+	return a.kind
+}
+
+func (a *Action) Target() ActionTarget {
+	return a.target
+}
+
+func (a *Action) TargetID() int {
+	return a.targetID
+}
+
+func (a *Action) Transaction() *Transaction {
+	return a.transaction
+}
+
+func (a *Action) Budget() *Budget {
+	return a.budget
+}
+
+func (a *Action) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+func (a *Action) UndoneAt() *time.Time {
+	return a.undoneAt
+}
+
+// AssignID sets the ID given by the repository on save
+func (a *Action) AssignID(id ActionID) {
+	a.id = id
+}
+
+// ExpiresAt returns when the action can no longer be undone
+func (a *Action) ExpiresAt() time.Time {
+	return a.createdAt.Add(UndoWindow)
+}
+
+// Undo marks the action undone, if it is still within the undo window
+func (a *Action) Undo(at time.Time) error {
+	if a.undoneAt != nil {
+		return ErrActionAlreadyUndone
+	}
+	if at.After(a.ExpiresAt()) {
+		return ErrActionExpired
+	}
+	a.undoneAt = &at
+	return nil
+}
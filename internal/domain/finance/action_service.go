@@ -0,0 +1,100 @@
+package finance
+
+import (
+	"context"
+	"time"
+)
+
+// ActionService handles undoing recent updates and deletes
+type ActionService struct {
+	actionRepo      ActionRepository
+	transactionRepo TransactionRepository
+	budgetRepo      BudgetRepository
+	closedMonthRepo ClosedMonthRepository
+}
+
+// NewActionService creates a new action service
+func NewActionService(actionRepo ActionRepository, transactionRepo TransactionRepository, budgetRepo BudgetRepository, closedMonthRepo ClosedMonthRepository) *ActionService {
+	return &ActionService{
+		actionRepo:      actionRepo,
+		transactionRepo: transactionRepo,
+		budgetRepo:      budgetRepo,
+		closedMonthRepo: closedMonthRepo,
+	}
+}
+
+// GetUndoableActions returns the user's actions that can still be undone, newest first
+func (s *ActionService) GetUndoableActions(ctx context.Context, userID UserID) ([]*Action, error) {
+	actions, err := s.actionRepo.FindByUserIDSince(ctx, userID, time.Now().Add(-UndoWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	undoable := make([]*Action, 0, len(actions))
+	for _, action := range actions {
+		if action.UndoneAt() == nil {
+			undoable = append(undoable, action)
+		}
+	}
+	return undoable, nil
+}
+
+// Undo restores the record an action changed to its previous state. Only the
+// latest change to a record can be undone, so changes are reversed in order.
+func (s *ActionService) Undo(ctx context.Context, actionID ActionID, userID UserID) (*Action, error) {
+	action, err := s.actionRepo.FindByID(ctx, actionID)
+	if err != nil {
+		return nil, err
+	}
+	if action.UserID().Value() != userID.Value() {
+		return nil, ErrActionNotFound
+	}
+	if action.UndoneAt() != nil {
+		return nil, ErrActionAlreadyUndone
+	}
+
+	latest, err := s.actionRepo.FindLatestByTarget(ctx, action.Target(), action.TargetID())
+	if err != nil {
+		return nil, err
+	}
+	if latest.ID() != action.ID() {
+		return nil, ErrActionSuperseded
+	}
+
+	if action.Target() == ActionTargetTransaction {
+		if err := s.ensureTransactionMonthsOpen(ctx, action.Transaction()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := action.Undo(time.Now()); err != nil {
+		return nil, err
+	}
+
+	// Saving the previous state with its original ID updates the record, or recreates it after a delete
+	switch action.Target() {
+	case ActionTargetTransaction:
+		err = s.transactionRepo.Save(ctx, action.Transaction())
+	case ActionTargetBudget:
+		err = s.budgetRepo.Save(ctx, action.Budget())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.actionRepo.Save(ctx, action); err != nil {
+		return nil, err
+	}
+	return action, nil
+}
+
+// ensureTransactionMonthsOpen returns ErrMonthClosed when restoring previous would
+// change a closed month, either the one it returns to or the one it is in now
+func (s *ActionService) ensureTransactionMonthsOpen(ctx context.Context, previous *Transaction) error {
+	dates := []time.Time{previous.Date()}
+	current, err := s.transactionRepo.FindByIDAndType(ctx, previous.ID(), previous.Type())
+	if err == nil {
+		dates = append(dates, current.Date())
+	}
+	return ensureMonthsOpen(ctx, s.closedMonthRepo, previous.UserID(), dates...)
+}
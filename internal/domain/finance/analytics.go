@@ -0,0 +1,125 @@
+package finance
+
+import (
+	"sort"
+	"time"
+)
+
+// DailyTotal is what a user spent or earned in one currency on one day
+type DailyTotal struct {
+	UserID     UserID
+	Date       time.Time
+	CurrencyID CurrencyID
+	Type       TransactionType
+	Amount     float64
+	Count      int
+}
+
+// CategoryTotal is what a user spent or earned in one category and currency on one day
+type CategoryTotal struct {
+	UserID     UserID
+	Date       time.Time
+	CategoryID CategoryID
+	CurrencyID CurrencyID
+	Type       TransactionType
+	Amount     float64
+	Count      int
+}
+
+// BudgetProgress is how much of a budget's allowance has been spent
+type BudgetProgress struct {
+	BudgetID   BudgetID
+	UserID     UserID
+	CategoryID CategoryID
+	StartDate  time.Time
+	EndDate    time.Time
+	Allowance  float64
+	Spent      float64
+}
+
+// AnalyticsProjection is the read model of one user's analytics, denormalized
+// from their transactions and budgets so reports do not scan the transactions
+type AnalyticsProjection struct {
+	UserID         UserID
+	DailyTotals    []DailyTotal
+	CategoryTotals []CategoryTotal
+	Budgets        []BudgetProgress
+	ProjectedAt    time.Time
+}
+
+// ProjectAnalytics builds a user's read model from all of their transactions and
+// budgets. Building it whole, rather than applying each change, means projecting
+// the same events twice or out of order still gives the right totals.
+func ProjectAnalytics(userID UserID, transactions []*Transaction, budgets []*Budget, now time.Time) *AnalyticsProjection {
+	type dayKey struct {
+		date       time.Time
+		currencyID int
+		kind       TransactionType
+	}
+	type categoryKey struct {
+		dayKey
+		categoryID int
+	}
+
+	days := make(map[dayKey]*DailyTotal)
+	categories := make(map[categoryKey]*CategoryTotal)
+	for _, transaction := range transactions {
+		day := dayKey{startOfDay(transaction.Date().UTC()), transaction.CurrencyID().Value(), transaction.Type()}
+		daily := days[day]
+		if daily == nil {
+			daily = &DailyTotal{UserID: userID, Date: day.date, CurrencyID: transaction.CurrencyID(), Type: day.kind}
+			days[day] = daily
+		}
+		daily.Amount += transaction.Amount().Amount()
+		daily.Count++
+
+		key := categoryKey{day, transaction.CategoryID().Value()}
+		category := categories[key]
+		if category == nil {
+			category = &CategoryTotal{UserID: userID, Date: day.date, CategoryID: transaction.CategoryID(), CurrencyID: transaction.CurrencyID(), Type: day.kind}
+			categories[key] = category
+		}
+		category.Amount += transaction.Amount().Amount()
+		category.Count++
+	}
+
+	projection := &AnalyticsProjection{UserID: userID, ProjectedAt: now}
+	for _, daily := range days {
+		daily.Amount = roundCents(daily.Amount)
+		projection.DailyTotals = append(projection.DailyTotals, *daily)
+	}
+	for _, category := range categories {
+		category.Amount = roundCents(category.Amount)
+		projection.CategoryTotals = append(projection.CategoryTotals, *category)
+	}
+	sort.Slice(projection.DailyTotals, func(i, j int) bool {
+		return projection.DailyTotals[i].Date.Before(projection.DailyTotals[j].Date)
+	})
+	sort.Slice(projection.CategoryTotals, func(i, j int) bool {
+		return projection.CategoryTotals[i].Date.Before(projection.CategoryTotals[j].Date)
+	})
+
+	// Budgets count the expenses in their category dated from their start to their end date
+	for _, budget := range budgets {
+		var spent float64
+		for _, transaction := range transactions {
+			if transaction.Type() != TransactionTypeExpense || transaction.CategoryID() != budget.CategoryID() {
+				continue
+			}
+			if transaction.Date().Before(budget.StartDate()) || transaction.Date().After(budget.EndDate()) {
+				continue
+			}
+			spent += transaction.Amount().Amount()
+		}
+		projection.Budgets = append(projection.Budgets, BudgetProgress{
+			BudgetID:   budget.ID(),
+			UserID:     userID,
+			CategoryID: budget.CategoryID(),
+			StartDate:  budget.StartDate(),
+			EndDate:    budget.EndDate(),
+			Allowance:  budget.Allowance(),
+			Spent:      roundCents(spent),
+		})
+	}
+	return projection
+}
@@ -0,0 +1,245 @@
+package finance
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// AnomalyKind says what made spending unusual
+type AnomalyKind string
+
+const (
+	// AnomalyKindLargeTransaction is a single expense far above the category's usual amount
+	AnomalyKindLargeTransaction AnomalyKind = "large_transaction"
+	// AnomalyKindCategorySpike is a week of spending in a category far above its usual week
+	AnomalyKindCategorySpike AnomalyKind = "category_spike"
+)
+
+const (
+	// AnomalyWindowDays is the number of days, up to and including today, checked for anomalies
+	AnomalyWindowDays = 7
+	// anomalyBaselineWeeks is how much history before the window a user's usual spending is taken from
+	anomalyBaselineWeeks = 12
+	// anomalyMinBaseline is the fewest earlier expenses a category needs before one can stand out
+	anomalyMinBaseline = 5
+)
+
+// AnomalyThresholds are how far spending must stray from the baseline to be flagged
+type AnomalyThresholds struct {
+	// Deviations is how many standard deviations above the category's mean an expense must be
+	Deviations float64
+	// SpikeFactor is how many times the category's average week the window's spending must be
+	SpikeFactor float64
+}
+
+// anomalyThresholds maps each sensitivity but "off" to its thresholds
+var anomalyThresholds = map[string]AnomalyThresholds{
+	"low":    {Deviations: 4, SpikeFactor: 3},
+	"medium": {Deviations: 3, SpikeFactor: 2},
+	"high":   {Deviations: 2, SpikeFactor: 1.5},
+}
+
+// AnomalyThresholdsFor returns the thresholds for a sensitivity, or false when
+// anomalies are not detected at that sensitivity
+func AnomalyThresholdsFor(sensitivity string) (AnomalyThresholds, bool) {
+	thresholds, found := anomalyThresholds[sensitivity]
+	return thresholds, found
+}
+
+// AnomalyWindow returns the first day checked for anomalies at the given time
+// and the first day of the history the baseline is taken from
+func AnomalyWindow(at time.Time) (windowStart, historyStart time.Time) {
+	today := at.UTC().Truncate(24 * time.Hour)
+	windowStart = today.AddDate(0, 0, 1-AnomalyWindowDays)
+	return windowStart, windowStart.AddDate(0, 0, -7*anomalyBaselineWeeks)
+}
+
+// AnomalyID is a value object representing an anomaly identifier
+type AnomalyID struct {
+	value int
+}
+
+func NewAnomalyID(id int) AnomalyID {
+	return AnomalyID{value: id}
+}
+
+func (a AnomalyID) Value() int {
+	return a.value
+}
+
+// Anomaly is unusual spending flagged for a user. It is kept so the same
+// spending is not flagged again on the next run.
+type Anomaly struct {
+	id            AnomalyID
+	userID        UserID
+	kind          AnomalyKind
+	categoryID    CategoryID
+	transactionID *TransactionID // the expense for large transactions; nil for spikes
+	amount        float64
+	baseline      float64 // the category's mean expense, or its average week for spikes
+	detectedAt    time.Time
+}
+
+// RestoreAnomaly rebuilds a persisted anomaly
+func RestoreAnomaly(id AnomalyID, userID UserID, kind AnomalyKind, categoryID CategoryID, transactionID *TransactionID, amount, baseline float64, detectedAt time.Time) *Anomaly {
+	return &Anomaly{
+		id:            id,
+		userID:        userID,
+		kind:          kind,
+		categoryID:    categoryID,
+		transactionID: transactionID,
+		amount:        amount,
+		baseline:      baseline,
+		detectedAt:    detectedAt,
+	}
+}
+
+// Getters
+func (a *Anomaly) ID() AnomalyID {
+	return a.id
+}
+
+func (a *Anomaly) UserID() UserID {
+	return a.userID
+}
+
+func (a *Anomaly) Kind() AnomalyKind {
+	return a.kind
+}
+
+func (a *Anomaly) CategoryID() CategoryID {
+	return a.categoryID
+}
+
+func (a *Anomaly) TransactionID() *TransactionID {
+	return a.transactionID
+}
+
+func (a *Anomaly) Amount() float64 {
+	return a.amount
+}
+
+func (a *Anomaly) Baseline() float64 {
+	return a.baseline
+}
+
+func (a *Anomaly) DetectedAt() time.Time {
+	return a.detectedAt
+}
+
+// AssignID sets the ID given by the repository on save
+func (a *Anomaly) AssignID(id AnomalyID) {
+	a.id = id
+}
+
+// sameAs reports whether two anomalies flag the same spending
+func (a *Anomaly) sameAs(other *Anomaly) bool {
+	if a.kind != other.kind || a.categoryID != other.categoryID {
+		return false
+	}
+	if a.transactionID == nil || other.transactionID == nil {
+		return a.transactionID == nil && other.transactionID == nil
+	}
+	return *a.transactionID == *other.transactionID
+}
+
+// DetectAnomalies flags the user's expenses in the window ending at that are
+// unusually large for their category, and the categories whose spending in the
+// window is unusually high for a week. Transactions must cover the history from
+// AnomalyWindow; anomalies already flagged since the window started are left out.
+func DetectAnomalies(userID UserID, transactions []*Transaction, flagged []*Anomaly, at time.Time, thresholds AnomalyThresholds) []*Anomaly {
+	windowStart, historyStart := AnomalyWindow(at)
+
+	baseline := make(map[CategoryID][]float64)
+	var recent []*Transaction
+	windowTotals := make(map[CategoryID]float64)
+	for _, transaction := range transactions {
+		date := transaction.Date()
+		if transaction.Type() != TransactionTypeExpense || date.Before(historyStart) || date.After(at) {
+			continue
+		}
+		categoryID := transaction.CategoryID()
+		if date.Before(windowStart) {
+			baseline[categoryID] = append(baseline[categoryID], transaction.Amount().Amount())
+			continue
+		}
+		recent = append(recent, transaction)
+		windowTotals[categoryID] += transaction.Amount().Amount()
+	}
+
+	var anomalies []*Anomaly
+	for _, transaction := range recent {
+		amounts := baseline[transaction.CategoryID()]
+		if len(amounts) < anomalyMinBaseline {
+			continue
+		}
+		mean, deviation := meanAndDeviation(amounts)
+		amount := transaction.Amount().Amount()
+		if amount <= mean+thresholds.Deviations*deviation {
+			continue
+		}
+		transactionID := transaction.ID()
+		anomalies = append(anomalies, &Anomaly{
+			userID:        userID,
+			kind:          AnomalyKindLargeTransaction,
+			categoryID:    transaction.CategoryID(),
+			transactionID: &transactionID,
+			amount:        amount,
+			baseline:      roundCents(mean),
+			detectedAt:    at,
+		})
+	}
+
+	for categoryID, total := range windowTotals {
+		var history float64
+		for _, amount := range baseline[categoryID] {
+			history += amount
+		}
+		weekly := history / anomalyBaselineWeeks
+		if weekly == 0 || total <= thresholds.SpikeFactor*weekly {
+			continue
+		}
+		anomalies = append(anomalies, &Anomaly{
+			userID:     userID,
+			kind:       AnomalyKindCategorySpike,
+			categoryID: categoryID,
+			amount:     roundCents(total),
+			baseline:   roundCents(weekly),
+			detectedAt: at,
+		})
+	}
+
+	fresh := anomalies[:0]
+	for _, anomaly := range anomalies {
+		seen := false
+		for _, earlier := range flagged {
+			if anomaly.sameAs(earlier) {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			fresh = append(fresh, anomaly)
+		}
+	}
+	sort.SliceStable(fresh, func(i, j int) bool {
+		return fresh[i].categoryID.Value() < fresh[j].categoryID.Value()
+	})
+	return fresh
+}
+
+// meanAndDeviation returns the mean and population standard deviation of amounts
+func meanAndDeviation(amounts []float64) (float64, float64) {
+	var sum float64
+	for _, amount := range amounts {
+		sum += amount
+	}
+	mean := sum / float64(len(amounts))
+
+	var squares float64
+	for _, amount := range amounts {
+		squares += (amount - mean) * (amount - mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(amounts)))
+}
@@ -0,0 +1,113 @@
+package finance
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// BalanceAdjustmentID is a value object representing a balance adjustment identifier
+type BalanceAdjustmentID struct {
+	value int
+}
+
+func NewBalanceAdjustmentID(id int) BalanceAdjustmentID {
+	return BalanceAdjustmentID{value: id}
+}
+
+func (b BalanceAdjustmentID) Value() int {
+	return b.value
+}
+
+// BalanceAdjustment moves an account's balance without being income or spending,
+// such as to make a derived balance match a bank statement. Adjustments count
+// towards account balances but never towards analytics.
+type BalanceAdjustment struct {
+	id          BalanceAdjustmentID
+	userID      UserID
+	accountID   AccountID
+	amount      float64 // added to the balance; negative lowers it
+	date        time.Time
+	description string
+	createdAt   time.Time
+}
+
+// NewBalanceAdjustment creates a new balance adjustment
+func NewBalanceAdjustment(userID UserID, accountID AccountID, amount float64, date time.Time, description string) (*BalanceAdjustment, error) {
+	amount, err := signedAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+	if amount == 0 {
+		return nil, ErrBalanceAlreadyMatches
+	}
+
+	return &BalanceAdjustment{
+		userID:      userID,
+		accountID:   accountID,
+		amount:      amount,
+		date:        date,
+		description: strings.TrimSpace(description),
+		createdAt:   time.Now(),
+	}, nil
+}
+
+// RestoreBalanceAdjustment rebuilds a persisted balance adjustment
+func RestoreBalanceAdjustment(id BalanceAdjustmentID, userID UserID, accountID AccountID, amount float64, date time.Time, description string, createdAt time.Time) *BalanceAdjustment {
+	return &BalanceAdjustment{
+		id:          id,
+		userID:      userID,
+		accountID:   accountID,
+		amount:      amount,
+		date:        date,
+		description: description,
+		createdAt:   createdAt,
+	}
+}
+
+// Getters
+func (b *BalanceAdjustment) ID() BalanceAdjustmentID {
+	return b.id
+}
+
+func (b *BalanceAdjustment) UserID() UserID {
+	return b.userID
+}
+
+func (b *BalanceAdjustment) AccountID() AccountID {
+	return b.accountID
+}
+
+func (b *BalanceAdjustment) Amount() float64 {
+	return b.amount
+}
+
+func (b *BalanceAdjustment) Date() time.Time {
+	return b.date
+}
+
+func (b *BalanceAdjustment) Description() string {
+	return b.description
+}
+
+func (b *BalanceAdjustment) CreatedAt() time.Time {
+	return b.createdAt
+}
+
+// AssignID sets the ID given by the repository on save
+func (b *BalanceAdjustment) AssignID(id BalanceAdjustmentID) {
+	b.id = id
+}
+
+// signedAmount validates an amount that may be negative, such as a balance, and
+// rounds it to cents
+func signedAmount(amount float64) (float64, error) {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return 0, ErrInvalidAmount
+	}
+	amount = roundCents(amount)
+	if math.Abs(amount) > MaxAmount {
+		return 0, ErrAmountTooLarge
+	}
+	return amount, nil
+}
@@ -0,0 +1,106 @@
+package finance
+
+import "time"
+
+// BalanceAssertionID is a value object representing a balance assertion identifier
+type BalanceAssertionID struct {
+	value int
+}
+
+func NewBalanceAssertionID(id int) BalanceAssertionID {
+	return BalanceAssertionID{value: id}
+}
+
+func (b BalanceAssertionID) Value() int {
+	return b.value
+}
+
+// BalanceAssertion records a balance the user saw on an account at the end of a day,
+// such as the closing balance on a bank statement
+type BalanceAssertion struct {
+	id        BalanceAssertionID
+	userID    UserID
+	accountID AccountID
+	date      time.Time
+	balance   float64
+	createdAt time.Time
+}
+
+// NewBalanceAssertion creates a new balance assertion
+func NewBalanceAssertion(userID UserID, accountID AccountID, date time.Time, balance float64) *BalanceAssertion {
+	return &BalanceAssertion{
+		userID:    userID,
+		accountID: accountID,
+		date:      date,
+		balance:   roundCents(balance),
+		createdAt: time.Now(),
+	}
+}
+
+// RestoreBalanceAssertion rebuilds a persisted balance assertion
+func RestoreBalanceAssertion(id BalanceAssertionID, userID UserID, accountID AccountID, date time.Time, balance float64, createdAt time.Time) *BalanceAssertion {
+	return &BalanceAssertion{
+		id:        id,
+		userID:    userID,
+		accountID: accountID,
+		date:      date,
+		balance:   balance,
+		createdAt: createdAt,
+	}
+}
+
+// Getters
+func (b *BalanceAssertion) ID() BalanceAssertionID {
+	return b.id
+}
+
+func (b *BalanceAssertion) UserID() UserID {
+	return b.userID
+}
+
+func (b *BalanceAssertion) AccountID() AccountID {
+	return b.accountID
+}
+
+func (b *BalanceAssertion) Date() time.Time {
+	return b.date
+}
+
+func (b *BalanceAssertion) Balance() float64 {
+	return b.balance
+}
+
+func (b *BalanceAssertion) CreatedAt() time.Time {
+	return b.createdAt
+}
+
+// AssignID sets the ID given by the repository on save
+func (b *BalanceAssertion) AssignID(id BalanceAssertionID) {
+	b.id = id
+}
+
+// BalanceCheck compares an asserted balance with the balance derived from the
+// account's recorded transactions up to and including the assertion date
+type BalanceCheck struct {
+	Assertion      *BalanceAssertion
+	DerivedBalance float64
+	// PreviousDifference is the difference at the account's previous assertion, if any
+	PreviousDifference float64
+}
+
+// Difference is the derived minus the asserted balance; positive means more was
+// recorded in than the account shows, as with a duplicated income or a missed expense
+func (c *BalanceCheck) Difference() float64 {
+	return roundCents(c.DerivedBalance - c.Assertion.Balance())
+}
+
+// Holds reports whether the derived balance matches the assertion to the cent
+func (c *BalanceCheck) Holds() bool {
+	return c.Difference() == 0
+}
+
+// NewDiscrepancy is the part of the difference that arose since the previous
+// assertion, narrowing down where a missed or duplicated entry is
+func (c *BalanceCheck) NewDiscrepancy() float64 {
+	return roundCents(c.Difference() - c.PreviousDifference)
+}
@@ -0,0 +1,90 @@
+package finance
+
+import "sort"
+
+// MinBenchmarkPeers is the fewest other users a comparison is made with, so no
+// single user's spending can be worked out from it
+const MinBenchmarkPeers = 5
+
+// CategorySpend is one user's spending in one category over a period
+type CategorySpend struct {
+	UserID     UserID
+	CategoryID CategoryID
+	Amount     float64
+}
+
+// CategoryBenchmark compares a user's spending in a category with their peers'
+type CategoryBenchmark struct {
+	CategoryID CategoryID
+	Amount     float64
+	// PeerMedian is the middle of the peers' spending, counting peers who spent nothing
+	PeerMedian float64
+	// Percentile is the share of peers, 0-100, who spent less than the user
+	Percentile int
+}
+
+// BenchmarkCategories compares the user's spending in each category they spent in
+// with every peer in spending, largest spend first. Peers are the other users in
+// spending; those who spent nothing in a category count as spending zero there.
+// No comparisons are made with fewer than MinBenchmarkPeers peers.
+func BenchmarkCategories(userID UserID, spending []CategorySpend) (peers int, benchmarks []CategoryBenchmark) {
+	own := make(map[CategoryID]float64)
+	peerSpending := make(map[CategoryID]map[UserID]float64)
+	peerIDs := make(map[UserID]bool)
+	for _, spend := range spending {
+		if spend.UserID == userID {
+			own[spend.CategoryID] += spend.Amount
+			continue
+		}
+		peerIDs[spend.UserID] = true
+		if peerSpending[spend.CategoryID] == nil {
+			peerSpending[spend.CategoryID] = make(map[UserID]float64)
+		}
+		peerSpending[spend.CategoryID][spend.UserID] += spend.Amount
+	}
+
+	peers = len(peerIDs)
+	if peers < MinBenchmarkPeers {
+		return peers, []CategoryBenchmark{}
+	}
+
+	benchmarks = []CategoryBenchmark{}
+	for categoryID, amount := range own {
+		if amount <= 0 {
+			continue
+		}
+		amounts := make([]float64, 0, peers)
+		for _, peerAmount := range peerSpending[categoryID] {
+			amounts = append(amounts, peerAmount)
+		}
+		// Peers with no spending in the category
+		for len(amounts) < peers {
+			amounts = append(amounts, 0)
+		}
+		sort.Float64s(amounts)
+
+		below := sort.SearchFloat64s(amounts, amount)
+		benchmarks = append(benchmarks, CategoryBenchmark{
+			CategoryID: categoryID,
+			Amount:     roundCents(amount),
+			PeerMedian: roundCents(median(amounts)),
+			Percentile: below * 100 / peers,
+		})
+	}
+	sort.Slice(benchmarks, func(i, j int) bool {
+		if benchmarks[i].Amount != benchmarks[j].Amount {
+			return benchmarks[i].Amount > benchmarks[j].Amount
+		}
+		return benchmarks[i].CategoryID.Value() < benchmarks[j].CategoryID.Value()
+	})
+	return peers, benchmarks
+}
+
+// median returns the middle of sorted values
+func median(sorted []float64) float64 {
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[middle]
+	}
+	return (sorted[middle-1] + sorted[middle]) / 2
+}
@@ -0,0 +1,98 @@
+package finance
+
+import "time"
+
+// MinimumPaymentPercent is the share of a statement balance due as the minimum payment
+const MinimumPaymentPercent = 5
+
+// BillingCycle is when a credit card's statement closes each month and when
+// payment for it is due. Days past the end of a short month fall on its last day.
+type BillingCycle struct {
+	closingDay int
+	dueDay     int
+}
+
+// NewBillingCycle creates a billing cycle from days of the month (1-31)
+func NewBillingCycle(closingDay, dueDay int) (BillingCycle, error) {
+	if closingDay < 1 || closingDay > 31 || dueDay < 1 || dueDay > 31 {
+		return BillingCycle{}, ErrInvalidBillingCycle
+	}
+	return BillingCycle{closingDay: closingDay, dueDay: dueDay}, nil
+}
+
+func (b BillingCycle) ClosingDay() int {
+	return b.closingDay
+}
+
+func (b BillingCycle) DueDay() int {
+	return b.dueDay
+}
+
+// closingIn returns the statement closing date in the month of date
+func (b BillingCycle) closingIn(date time.Time) time.Time {
+	return dayOfMonth(date.Year(), date.Month(), b.closingDay)
+}
+
+// ClosingDateFor returns the closing date of the statement a transaction dated date falls in
+func (b BillingCycle) ClosingDateFor(date time.Time) time.Time {
+	day := date.UTC().Truncate(24 * time.Hour)
+	closing := b.closingIn(day)
+	if day.After(closing) {
+		closing = b.closingIn(MonthStart(day).AddDate(0, 1, 0))
+	}
+	return closing
+}
+
+// PreviousClosingDate returns the closing date of the statement before the one closing on closing
+func (b BillingCycle) PreviousClosingDate(closing time.Time) time.Time {
+	return b.closingIn(MonthStart(closing).AddDate(0, -1, 0))
+}
+
+// DueDate returns the payment due date of the statement closing on closing: the
+// first due day after it
+func (b BillingCycle) DueDate(closing time.Time) time.Time {
+	due := dayOfMonth(closing.Year(), closing.Month(), b.dueDay)
+	if !due.After(closing) {
+		next := MonthStart(closing).AddDate(0, 1, 0)
+		due = dayOfMonth(next.Year(), next.Month(), b.dueDay)
+	}
+	return due
+}
+
+// dayOfMonth returns the day of a month, or the month's last day when it is shorter
+func dayOfMonth(year int, month time.Month, day int) time.Time {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if day > last {
+		day = last
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// CardStatement summarizes a credit card's spending over one billing cycle.
+// Balances are what is owed on the card; a negative balance is a credit.
+type CardStatement struct {
+	PeriodStart time.Time
+	ClosingDate time.Time
+	DueDate     time.Time
+	// Open is set for the cycle that has not closed yet
+	Open             bool
+	PreviousBalance  float64
+	Charges          float64 // expenses recorded on the card
+	Payments         float64 // incomes, such as payments and refunds, recorded on the card
+	Adjustments      float64 // balance adjustments, as a change in what is owed
+	TransactionCount int
+}
+
+// Balance is what is owed when the statement closes
+func (s *CardStatement) Balance() float64 {
+	return roundCents(s.PreviousBalance + s.Charges - s.Payments + s.Adjustments)
+}
+
+// MinimumPayment is MinimumPaymentPercent of the balance, or nothing when nothing is owed
+func (s *CardStatement) MinimumPayment() float64 {
+	balance := s.Balance()
+	if balance <= 0 {
+		return 0
+	}
+	return roundCents(balance * MinimumPaymentPercent / 100)
+}
@@ -1,7 +1,7 @@
 package finance
 
 import (
-	"errors"
+	"math"
 	"time"
 )
 
@@ -24,6 +24,11 @@ type Budget struct {
 	startDate  time.Time
 	endDate    time.Time
 	createdAt  time.Time
+	version    int // goes up by one on every save; zero until first saved
+
+	// prorated budgets end with the calendar period they start in, and get the
+	// share of the amount for the days of it they cover
+	prorated bool
 }
 
 // BudgetID is a value object representing a budget identifier
@@ -49,20 +54,13 @@ func NewBudget(
 	startDate time.Time,
 ) (*Budget, error) {
 	if amount.Amount() <= 0 {
-		return nil, errors.New("budget amount must be positive")
+		return nil, ErrInvalidBudgetAmount
 	}
 
 	// Calculate end date based on period
-	var endDate time.Time
-	switch period {
-	case BudgetPeriodWeekly:
-		endDate = startDate.AddDate(0, 0, 7)
-	case BudgetPeriodMonthly:
-		endDate = startDate.AddDate(0, 1, 0)
-	case BudgetPeriodYearly:
-		endDate = startDate.AddDate(1, 0, 0)
-	default:
-		return nil, errors.New("invalid budget period")
+	endDate, err := budgetEndDate(period, startDate, false)
+	if err != nil {
+		return nil, err
 	}
 
 	return &Budget{
@@ -110,30 +108,89 @@ func (b *Budget) CreatedAt() time.Time {
 	return b.createdAt
 }
 
+// Version returns the version the budget was stored at, for optimistic locking
+func (b *Budget) Version() int {
+	return b.version
+}
+
+// AssignVersion sets the version given by the repository when the budget is read or saved
+func (b *Budget) AssignVersion(version int) {
+	b.version = version
+}
+
+// CheckVersion returns ErrVersionConflict when the budget has changed since the
+// client read it at expected; a nil expected version skips the check
+func (b *Budget) CheckVersion(expected *int) error {
+	if expected != nil && *expected != b.version {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
 // UpdateAmount updates the budget amount
 func (b *Budget) UpdateAmount(newAmount Money) error {
 	if newAmount.Amount() <= 0 {
-		return errors.New("budget amount must be positive")
+		return ErrInvalidBudgetAmount
 	}
 	if newAmount.Currency() != b.amount.Currency() {
-		return errors.New("cannot change currency of existing budget")
+		return ErrCurrencyChange
 	}
 	b.amount = newAmount
 	return nil
 }
 
+func (b *Budget) Prorated() bool {
+	return b.prorated
+}
+
+// Allowance returns the amount that can be spent between the start and end dates.
+// Prorated budgets get the share of their amount for the days of the calendar
+// period they cover, so a monthly budget of 310 starting on the 21st of a 31-day
+// month allows 110.
+func (b *Budget) Allowance() float64 {
+	if !b.prorated {
+		return b.amount.Amount()
+	}
+
+	periodStart, periodEnd := calendarPeriod(b.period, b.startDate)
+	periodDays := daysBetween(periodStart, periodEnd)
+	if periodDays <= 0 {
+		return b.amount.Amount()
+	}
+	coveredDays := min(max(daysBetween(b.startDate, b.endDate), 0), periodDays)
+	return math.Round(b.amount.Amount()*coveredDays/periodDays*100) / 100
+}
+
+// DailyAllowance spreads the allowance evenly over the days from the start date
+// up to the end date
+func (b *Budget) DailyAllowance() float64 {
+	days := daysBetween(startOfDay(b.startDate), startOfDay(b.endDate))
+	if days <= 0 {
+		return b.Allowance()
+	}
+	return b.Allowance() / days
+}
+
+// Covers checks if day falls between the start date and the end date, which is
+// the first day after the budget
+func (b *Budget) Covers(day time.Time) bool {
+	day = startOfDay(day)
+	return !day.Before(startOfDay(b.startDate)) && day.Before(startOfDay(b.endDate))
+}
+
+// UpdateProration turns proration on or off and recalculates end date
+func (b *Budget) UpdateProration(prorated bool) {
+	b.prorated = prorated
+	if endDate, err := budgetEndDate(b.period, b.startDate, prorated); err == nil {
+		b.endDate = endDate
+	}
+}
+
 // UpdatePeriod updates the budget period and recalculates end date
 func (b *Budget) UpdatePeriod(newPeriod BudgetPeriod) error {
-	var endDate time.Time
-	switch newPeriod {
-	case BudgetPeriodWeekly:
-		endDate = b.startDate.AddDate(0, 0, 7)
-	case BudgetPeriodMonthly:
-		endDate = b.startDate.AddDate(0, 1, 0)
-	case BudgetPeriodYearly:
-		endDate = b.startDate.AddDate(1, 0, 0)
-	default:
-		return errors.New("invalid budget period")
+	endDate, err := budgetEndDate(newPeriod, b.startDate, b.prorated)
+	if err != nil {
+		return err
 	}
 
 	b.period = newPeriod
@@ -146,13 +203,8 @@ func (b *Budget) UpdateStartDate(newStartDate time.Time) {
 	b.startDate = newStartDate
 
 	// Recalculate end date
-	switch b.period {
-	case BudgetPeriodWeekly:
-		b.endDate = newStartDate.AddDate(0, 0, 7)
-	case BudgetPeriodMonthly:
-		b.endDate = newStartDate.AddDate(0, 1, 0)
-	case BudgetPeriodYearly:
-		b.endDate = newStartDate.AddDate(1, 0, 0)
+	if endDate, err := budgetEndDate(b.period, newStartDate, b.prorated); err == nil {
+		b.endDate = endDate
 	}
 }
 
@@ -171,3 +223,55 @@ func (b *Budget) IsActive() bool {
 func (b *Budget) IsExpired() bool {
 	return time.Now().After(b.endDate)
 }
+
+// budgetEndDate returns when a budget starting on startDate ends: one period later,
+// or for prorated budgets at the end of the calendar period startDate falls in
+func budgetEndDate(period BudgetPeriod, startDate time.Time, prorated bool) (time.Time, error) {
+	if prorated {
+		switch period {
+		case BudgetPeriodWeekly, BudgetPeriodMonthly, BudgetPeriodYearly:
+			_, end := calendarPeriod(period, startDate)
+			return end, nil
+		default:
+			return time.Time{}, ErrInvalidBudgetPeriod
+		}
+	}
+
+	switch period {
+	case BudgetPeriodWeekly:
+		return startDate.AddDate(0, 0, 7), nil
+	case BudgetPeriodMonthly:
+		return startDate.AddDate(0, 1, 0), nil
+	case BudgetPeriodYearly:
+		return startDate.AddDate(1, 0, 0), nil
+	default:
+		return time.Time{}, ErrInvalidBudgetPeriod
+	}
+}
+
+// calendarPeriod returns the start of the calendar week (from Monday), month or year
+// containing date, and the start of the next one
+func calendarPeriod(period BudgetPeriod, date time.Time) (time.Time, time.Time) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	switch period {
+	case BudgetPeriodWeekly:
+		start := day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+		return start, start.AddDate(0, 0, 7)
+	case BudgetPeriodYearly:
+		start := time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, day.Location())
+		return start, start.AddDate(1, 0, 0)
+	default:
+		start := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+		return start, start.AddDate(0, 1, 0)
+	}
+}
+
+// startOfDay returns midnight at the start of date's day
+func startOfDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+}
+
+// daysBetween returns the number of calendar days from start to end
+func daysBetween(start, end time.Time) float64 {
+	return math.Round(end.Sub(start).Hours() / 24)
+}
@@ -0,0 +1,53 @@
+package finance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudgetAllowance(t *testing.T) {
+	tests := []struct {
+		name      string
+		amount    float64
+		period    BudgetPeriod
+		start     time.Time
+		prorated  bool
+		endDate   time.Time
+		allowance float64
+	}{
+		{"monthly", 310, BudgetPeriodMonthly, day(2024, 1, 21), false, day(2024, 2, 21), 310},
+		{"monthly prorated", 310, BudgetPeriodMonthly, day(2024, 1, 21), true, day(2024, 2, 1), 110},
+		{"monthly prorated from the first", 310, BudgetPeriodMonthly, day(2024, 1, 1), true, day(2024, 2, 1), 310},
+		{"monthly prorated in a leap February", 290, BudgetPeriodMonthly, day(2024, 2, 20), true, day(2024, 3, 1), 100},
+		{"weekly prorated from Monday", 70, BudgetPeriodWeekly, day(2024, 1, 24), true, day(2024, 1, 29), 50},
+		{"yearly prorated", 366, BudgetPeriodYearly, day(2024, 12, 1), true, day(2025, 1, 1), 31},
+		{"rounded to cents", 100, BudgetPeriodMonthly, day(2024, 1, 22), true, day(2024, 2, 1), 32.26},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, err := NewMoney(tt.amount, NewCurrencyID(1))
+			require.NoError(t, err)
+			budget, err := NewBudget(BudgetID{}, NewUserID(1), NewCategoryID(1), amount, tt.period, tt.start)
+			require.NoError(t, err)
+
+			budget.UpdateProration(tt.prorated)
+			assert.Equal(t, tt.endDate, budget.EndDate())
+			assert.Equal(t, tt.allowance, budget.Allowance())
+		})
+	}
+}
+
+func TestBudgetDailyAllowance(t *testing.T) {
+	amount, err := NewMoney(310, NewCurrencyID(1))
+	require.NoError(t, err)
+	budget, err := NewBudget(BudgetID{}, NewUserID(1), NewCategoryID(1), amount, BudgetPeriodMonthly, day(2024, 1, 21))
+	require.NoError(t, err)
+
+	budget.UpdateProration(true)
+	assert.Equal(t, 10.0, budget.DailyAllowance())
+	assert.True(t, budget.Covers(day(2024, 1, 31)))
+	assert.False(t, budget.Covers(day(2024, 2, 1)))
+}
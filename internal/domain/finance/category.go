@@ -1,6 +1,7 @@
 package finance
 
 import (
+	"context"
 	"errors"
 	"time"
 )
@@ -22,6 +23,11 @@ type Category struct {
 	isDefault    bool
 	categoryType CategoryType
 	createdAt    time.Time
+
+	// translationKey identifies a default category across languages; empty for user categories
+	translationKey string
+	// sourceID is the default category a user's copy was made from; nil for other categories
+	sourceID *CategoryID
 }
 
 // NewCategory creates a new category
@@ -34,13 +40,14 @@ func NewCategory(
 	categoryType CategoryType,
 ) (*Category, error) {
 	if name == "" {
-		return nil, errors.New("category name cannot be empty")
+		return nil, ErrEmptyCategoryName
 	}
-	
-	if color == "" {
-		color = "#3B82F6" // Default color
+
+	color, err := NormalizeColor(color)
+	if err != nil {
+		return nil, err
 	}
-	
+
 	return &Category{
 		id:           id,
 		userID:       userID,
@@ -52,6 +59,28 @@ func NewCategory(
 	}, nil
 }
 
+// RestoreCategory rebuilds a persisted category. Its color is not validated:
+// categories saved before colors were validated may have any.
+func RestoreCategory(
+	id CategoryID,
+	userID *UserID,
+	name string,
+	color string,
+	isDefault bool,
+	categoryType CategoryType,
+	createdAt time.Time,
+) *Category {
+	return &Category{
+		id:           id,
+		userID:       userID,
+		name:         name,
+		color:        color,
+		isDefault:    isDefault,
+		categoryType: categoryType,
+		createdAt:    createdAt,
+	}
+}
+
 // Getters
 func (c *Category) ID() CategoryID {
 	return c.id
@@ -81,24 +110,117 @@ func (c *Category) CreatedAt() time.Time {
 	return c.createdAt
 }
 
+func (c *Category) TranslationKey() string {
+	return c.translationKey
+}
+
+func (c *Category) SourceID() *CategoryID {
+	return c.sourceID
+}
+
+// AssignID sets the ID given by the repository on save
+func (c *Category) AssignID(id CategoryID) {
+	c.id = id
+}
+
+// AssignTranslationKey sets the key used to look up the category's name in other languages
+func (c *Category) AssignTranslationKey(key string) {
+	c.translationKey = key
+}
+
+// AssignSourceID records the default category a user's copy was made from
+func (c *Category) AssignSourceID(id CategoryID) {
+	c.sourceID = &id
+}
+
+// CopyFor makes the user's own copy of a default category, which they can
+// rename, recolor and delete like any category they created
+func (c *Category) CopyFor(userID UserID) (*Category, error) {
+	if !c.isDefault {
+		return nil, ErrCategoryAccessDenied
+	}
+	copied, err := NewCategory(CategoryID{}, &userID, c.name, c.color, false, c.categoryType)
+	if err != nil {
+		return nil, err
+	}
+	copied.AssignSourceID(c.id)
+	return copied, nil
+}
+
+// AccessibleBy reports whether the user can use the category: it is a default
+// category or one of their own
+func (c *Category) AccessibleBy(userID UserID) bool {
+	return c.isDefault || (c.userID != nil && c.userID.Value() == userID.Value())
+}
+
 // UpdateName updates the category name
 func (c *Category) UpdateName(name string) error {
 	if name == "" {
-		return errors.New("category name cannot be empty")
+		return ErrEmptyCategoryName
 	}
 	c.name = name
 	return nil
 }
 
-// UpdateColor updates the category color
-func (c *Category) UpdateColor(color string) {
-	if color == "" {
-		color = "#3B82F6"
+// UpdateColor updates the category color; an empty color is the default color
+func (c *Category) UpdateColor(color string) error {
+	color, err := NormalizeColor(color)
+	if err != nil {
+		return err
 	}
 	c.color = color
+	return nil
 }
 
 // CanBeDeleted checks if the category can be deleted
 func (c *Category) CanBeDeleted() bool {
 	return !c.isDefault
 }
+
+// categoryForUser finds a category the user can use. A default category the user
+// has their own copy of stands for that copy, so clients that still send the
+// shared IDs keep working.
+func categoryForUser(ctx context.Context, categoryRepo CategoryRepository, userID UserID, categoryID CategoryID) (*Category, error) {
+	category, err := categoryRepo.FindByID(ctx, categoryID)
+	if err != nil {
+		return nil, ErrCategoryNotFound
+	}
+	if !category.AccessibleBy(userID) {
+		return nil, ErrCategoryAccessDenied
+	}
+	if !category.IsDefault() {
+		return category, nil
+	}
+
+	copied, err := categoryRepo.FindCopy(ctx, userID, categoryID)
+	if errors.Is(err, ErrCategoryNotFound) {
+		return category, nil
+	}
+	return copied, err
+}
+
+// withoutCopiedDefaults leaves out the default categories the user has their own
+// copy of among categories
+func withoutCopiedDefaults(categories []*Category) []*Category {
+	copied := make(map[CategoryID]bool)
+	for _, category := range categories {
+		if category.SourceID() != nil {
+			copied[*category.SourceID()] = true
+		}
+	}
+
+	filtered := make([]*Category, 0, len(categories))
+	for _, category := range categories {
+		if !category.IsDefault() || !copied[category.ID()] {
+			filtered = append(filtered, category)
+		}
+	}
+	return filtered
+}
+
+// CategoryStats summarises a user's transactions in one category. Amounts are
+// summed as recorded, without converting between currencies.
+type CategoryStats struct {
+	TransactionCount int
+	MonthTotal       float64
+}
@@ -0,0 +1,80 @@
+package finance
+
+import (
+	"context"
+	"time"
+)
+
+// ClosedMonth is a calendar month a user has closed, so reports on it stay the
+// same: its transactions cannot be added, changed or deleted until it is reopened
+type ClosedMonth struct {
+	userID   UserID
+	month    time.Time // the first day of the month, in UTC
+	closedAt time.Time
+}
+
+// MonthStart returns the first day of the month date falls in
+func MonthStart(date time.Time) time.Time {
+	date = date.UTC()
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// ParseMonth parses a month written as YYYY-MM
+func ParseMonth(value string) (time.Time, error) {
+	month, err := time.Parse("2006-01", value)
+	if err != nil {
+		return time.Time{}, ErrInvalidMonth
+	}
+	return month, nil
+}
+
+// NewClosedMonth closes the month date falls in. Only months that ended before
+// now can be closed.
+func NewClosedMonth(userID UserID, date time.Time, now time.Time) (*ClosedMonth, error) {
+	month := MonthStart(date)
+	if !month.Before(MonthStart(now)) {
+		return nil, ErrInvalidCloseMonth
+	}
+
+	return &ClosedMonth{
+		userID:   userID,
+		month:    month,
+		closedAt: now,
+	}, nil
+}
+
+// RestoreClosedMonth rebuilds a persisted closed month
+func RestoreClosedMonth(userID UserID, month, closedAt time.Time) *ClosedMonth {
+	return &ClosedMonth{
+		userID:   userID,
+		month:    month,
+		closedAt: closedAt,
+	}
+}
+
+// Getters
+func (m *ClosedMonth) UserID() UserID {
+	return m.userID
+}
+
+func (m *ClosedMonth) Month() time.Time {
+	return m.month
+}
+
+func (m *ClosedMonth) ClosedAt() time.Time {
+	return m.closedAt
+}
+
+// ensureMonthsOpen returns ErrMonthClosed when the user has closed the month of any of the dates
+func ensureMonthsOpen(ctx context.Context, closedMonthRepo ClosedMonthRepository, userID UserID, dates ...time.Time) error {
+	for _, date := range dates {
+		closed, err := closedMonthRepo.IsClosed(ctx, userID, date)
+		if err != nil {
+			return err
+		}
+		if closed {
+			return ErrMonthClosed
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package finance
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultCategoryColor is the color of categories created without one
+const DefaultCategoryColor = "#3B82F6"
+
+// hexColorPattern matches #RGB and #RRGGBB colors
+var hexColorPattern = regexp.MustCompile(`^#(?:[0-9A-Fa-f]{3}|[0-9A-Fa-f]{6})$`)
+
+// PaletteColor is one of the colors recommended for categories
+type PaletteColor struct {
+	Name string
+	Hex  string
+}
+
+// palette is the recommended category colors. The default categories use the
+// first eight.
+var palette = []PaletteColor{
+	{Name: "red", Hex: "#EF4444"},
+	{Name: "blue", Hex: "#3B82F6"},
+	{Name: "violet", Hex: "#8B5CF6"},
+	{Name: "amber", Hex: "#F59E0B"},
+	{Name: "emerald", Hex: "#10B981"},
+	{Name: "pink", Hex: "#EC4899"},
+	{Name: "cyan", Hex: "#06B6D4"},
+	{Name: "gray", Hex: "#6B7280"},
+	{Name: "orange", Hex: "#F97316"},
+	{Name: "lime", Hex: "#84CC16"},
+	{Name: "teal", Hex: "#14B8A6"},
+	{Name: "indigo", Hex: "#6366F1"},
+}
+
+// Palette returns the colors recommended for categories, so clients stay
+// visually consistent. Any other hex color is accepted too.
+func Palette() []PaletteColor {
+	return append([]PaletteColor(nil), palette...)
+}
+
+// NormalizeColor validates a #RGB or #RRGGBB color and returns it as uppercase
+// #RRGGBB. An empty color is DefaultCategoryColor.
+func NormalizeColor(color string) (string, error) {
+	if color == "" {
+		return DefaultCategoryColor, nil
+	}
+	if !hexColorPattern.MatchString(color) {
+		return "", ErrInvalidCategoryColor
+	}
+
+	color = strings.ToUpper(color)
+	if len(color) == 4 {
+		color = string([]byte{'#', color[1], color[1], color[2], color[2], color[3], color[3]})
+	}
+	return color, nil
+}
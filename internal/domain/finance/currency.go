@@ -2,19 +2,30 @@ package finance
 
 import (
 	"encoding/json"
-	"errors"
+	"strings"
 	"time"
 )
 
+// defaultMinorUnits is the number of decimal places of most currencies
+const defaultMinorUnits = 2
+
+// zeroDecimalCurrencies lists the ISO 4217 currencies without a minor unit. Currencies
+// with three decimals (KWD, BHD, ...) are kept to two, the precision amounts are stored with.
+var zeroDecimalCurrencies = map[string]bool{
+	"BIF": true, "CLP": true, "DJF": true, "GNF": true, "ISK": true, "JPY": true,
+	"KMF": true, "KRW": true, "PYG": true, "RWF": true, "UGX": true, "VND": true,
+	"VUV": true, "XAF": true, "XOF": true, "XPF": true,
+}
+
 // Currency represents a currency
 type Currency struct {
-	id        CurrencyID `json:"id"`
-	userID    *UserID    `json:"user_id,omitempty"` // nil for default currencies
-	code      string     `json:"code"`
-	name      string     `json:"name"`
-	symbol    string     `json:"symbol"`
-	isDefault bool       `json:"is_default"`
-	createdAt time.Time  `json:"created_at"`
+	id        CurrencyID
+	userID    *UserID // nil for default currencies
+	code      string
+	name      string
+	symbol    string
+	isDefault bool
+	createdAt time.Time
 }
 
 // NewCurrency creates a new currency
@@ -27,15 +38,15 @@ func NewCurrency(
 	isDefault bool,
 ) (*Currency, error) {
 	if code == "" {
-		return nil, errors.New("currency code cannot be empty")
+		return nil, ErrEmptyCurrencyCode
 	}
 
 	if name == "" {
-		return nil, errors.New("currency name cannot be empty")
+		return nil, ErrEmptyCurrencyName
 	}
 
 	if symbol == "" {
-		return nil, errors.New("currency symbol cannot be empty")
+		return nil, ErrEmptyCurrencySymbol
 	}
 
 	return &Currency{
@@ -78,10 +89,18 @@ func (c *Currency) CreatedAt() time.Time {
 	return c.createdAt
 }
 
+// MinorUnits returns the number of decimal places amounts in the currency are kept to
+func (c *Currency) MinorUnits() int {
+	if zeroDecimalCurrencies[strings.ToUpper(c.code)] {
+		return 0
+	}
+	return defaultMinorUnits
+}
+
 // UpdateCode updates the currency code
 func (c *Currency) UpdateCode(code string) error {
 	if code == "" {
-		return errors.New("currency code cannot be empty")
+		return ErrEmptyCurrencyCode
 	}
 	c.code = code
 	return nil
@@ -90,7 +109,7 @@ func (c *Currency) UpdateCode(code string) error {
 // UpdateName updates the currency name
 func (c *Currency) UpdateName(name string) error {
 	if name == "" {
-		return errors.New("currency name cannot be empty")
+		return ErrEmptyCurrencyName
 	}
 	c.name = name
 	return nil
@@ -99,7 +118,7 @@ func (c *Currency) UpdateName(name string) error {
 // UpdateSymbol updates the currency symbol
 func (c *Currency) UpdateSymbol(symbol string) error {
 	if symbol == "" {
-		return errors.New("currency symbol cannot be empty")
+		return ErrEmptyCurrencySymbol
 	}
 	c.symbol = symbol
 	return nil
@@ -3,34 +3,27 @@ package finance
 import (
 	"context"
 	"errors"
+	"time"
 )
 
 // CurrencyService handles currency-related domain operations
 type CurrencyService struct {
 	currencyRepo CurrencyRepository
+	events       EventPublisher
 }
 
 // NewCurrencyService creates a new currency service
-func NewCurrencyService(currencyRepo CurrencyRepository) *CurrencyService {
+func NewCurrencyService(currencyRepo CurrencyRepository, events EventPublisher) *CurrencyService {
 	return &CurrencyService{
 		currencyRepo: currencyRepo,
+		events:       events,
 	}
 }
 
-// GetPrimaryCurrency gets the primary currency for a user
+// GetPrimaryCurrency gets the primary currency for a user, which is their
+// default currency
 func (s *CurrencyService) GetPrimaryCurrency(ctx context.Context, userID UserID) (*Currency, error) {
-	// For now, we'll return the first default currency
-	// In a real implementation, you'd get this from user preferences
-	defaultCurrencies, err := s.currencyRepo.FindDefaultCurrencies(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	if len(defaultCurrencies) == 0 {
-		return nil, errors.New("no default currency found")
-	}
-
-	return defaultCurrencies[0], nil
+	return s.GetDefaultCurrency(ctx, userID)
 }
 
 // GetCurrenciesByUser retrieves all currencies accessible to a user
@@ -67,7 +60,7 @@ func (s *CurrencyService) CreateCurrency(
 	}
 
 	if exists {
-		return nil, errors.New("currency code already exists")
+		return nil, ErrCurrencyCodeExists
 	}
 
 	// Create currency
@@ -103,16 +96,16 @@ func (s *CurrencyService) UpdateCurrency(
 	// Get currency
 	currency, err := s.currencyRepo.FindByID(ctx, currencyID)
 	if err != nil {
-		return errors.New("currency not found")
+		return ErrCurrencyNotFound
 	}
 
 	// Check if user can update this currency
 	if currency.IsDefault() {
-		return errors.New("cannot update default currency")
+		return ErrDefaultCurrencyImmutable
 	}
 
 	if currency.UserID() == nil || currency.UserID().Value() != userID.Value() {
-		return errors.New("access denied")
+		return ErrAccessDenied
 	}
 
 	// Update currency
@@ -137,19 +130,37 @@ func (s *CurrencyService) DeleteCurrency(ctx context.Context, currencyID Currenc
 	// Get currency
 	currency, err := s.currencyRepo.FindByID(ctx, currencyID)
 	if err != nil {
-		return errors.New("currency not found")
+		return ErrCurrencyNotFound
 	}
 
 	// Check if user can delete this currency
 	if !currency.CanBeDeleted() {
-		return errors.New("cannot delete default currency")
+		return ErrDefaultCurrencyNotDeletable
 	}
 
 	if currency.UserID() == nil || currency.UserID().Value() != userID.Value() {
-		return errors.New("access denied")
+		return ErrAccessDenied
 	}
 
-	return s.currencyRepo.Delete(ctx, currencyID)
+	// Refuse to delete a currency that transactions or preferences still reference
+	inUse, err := s.currencyRepo.IsInUse(ctx, currencyID)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return ErrCurrencyInUse
+	}
+
+	if err := s.currencyRepo.Delete(ctx, currencyID); err != nil {
+		return err
+	}
+
+	return s.events.Publish(ctx, CurrencyDeleted{
+		CurrencyID: currencyID.Value(),
+		UserID:     userID.Value(),
+		Code:       currency.Code(),
+		At:         time.Now(),
+	})
 }
 
 // SetDefaultCurrency sets the default currency for a user
@@ -157,25 +168,29 @@ func (s *CurrencyService) SetDefaultCurrency(ctx context.Context, userID UserID,
 	// Get currency to verify it exists and user has access
 	currency, err := s.currencyRepo.FindByID(ctx, currencyID)
 	if err != nil {
-		return errors.New("currency not found")
+		return ErrCurrencyNotFound
 	}
 
 	// Check if user has access to this currency (default or user's own)
 	if !currency.IsDefault() && (currency.UserID() == nil || currency.UserID().Value() != userID.Value()) {
-		return errors.New("access denied to currency")
+		return ErrCurrencyAccessDenied
 	}
 
 	// Set as user's default currency
 	return s.currencyRepo.SetUserDefaultCurrency(ctx, userID, currencyID)
 }
 
-// GetDefaultCurrency gets the default currency for a user
+// GetDefaultCurrency gets the default currency for a user, falling back to the
+// first default currency for users who have not chosen one
 func (s *CurrencyService) GetDefaultCurrency(ctx context.Context, userID UserID) (*Currency, error) {
 	// Try to get user's default currency
 	defaultCurrency, err := s.currencyRepo.GetUserDefaultCurrency(ctx, userID)
-	if err == nil && defaultCurrency != nil {
+	if err == nil {
 		return defaultCurrency, nil
 	}
+	if !errors.Is(err, ErrDefaultCurrencyNotSet) {
+		return nil, err
+	}
 
 	// If no user default currency is set, return the first default currency
 	defaultCurrencies, err := s.currencyRepo.FindDefaultCurrencies(ctx)
@@ -184,7 +199,7 @@ func (s *CurrencyService) GetDefaultCurrency(ctx context.Context, userID UserID)
 	}
 
 	if len(defaultCurrencies) == 0 {
-		return nil, errors.New("no default currency found")
+		return nil, ErrNoDefaultCurrency
 	}
 
 	return defaultCurrencies[0], nil
@@ -0,0 +1,66 @@
+package finance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubCurrencyRepository serves one currency and records deletes; the embedded
+// interface leaves every other method unimplemented
+type stubCurrencyRepository struct {
+	CurrencyRepository
+	currency *Currency
+	inUse    bool
+	deleted  bool
+}
+
+func (r *stubCurrencyRepository) FindByID(ctx context.Context, id CurrencyID) (*Currency, error) {
+	return r.currency, nil
+}
+
+func (r *stubCurrencyRepository) IsInUse(ctx context.Context, id CurrencyID) (bool, error) {
+	return r.inUse, nil
+}
+
+func (r *stubCurrencyRepository) Delete(ctx context.Context, id CurrencyID) error {
+	r.deleted = true
+	return nil
+}
+
+// recordingPublisher keeps the events it is given
+type recordingPublisher struct {
+	events []Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, events ...Event) error {
+	p.events = append(p.events, events...)
+	return nil
+}
+
+func TestCurrencyServiceDeleteCurrency(t *testing.T) {
+	ctx := context.Background()
+	userID := NewUserID(1)
+	currency, err := NewCurrency(NewCurrencyID(7), &userID, "IDR", "Rupiah", "Rp", false)
+	require.NoError(t, err)
+
+	t.Run("currencies in use are kept", func(t *testing.T) {
+		repo := &stubCurrencyRepository{currency: currency, inUse: true}
+		publisher := &recordingPublisher{}
+		err := NewCurrencyService(repo, publisher).DeleteCurrency(ctx, currency.ID(), userID)
+		assert.ErrorIs(t, err, ErrCurrencyInUse)
+		assert.False(t, repo.deleted)
+		assert.Empty(t, publisher.events)
+	})
+
+	t.Run("unused currencies are deleted", func(t *testing.T) {
+		repo := &stubCurrencyRepository{currency: currency}
+		publisher := &recordingPublisher{}
+		require.NoError(t, NewCurrencyService(repo, publisher).DeleteCurrency(ctx, currency.ID(), userID))
+		assert.True(t, repo.deleted)
+		require.Len(t, publisher.events, 1)
+		assert.Equal(t, EventCurrencyDeleted, publisher.events[0].EventName())
+	})
+}
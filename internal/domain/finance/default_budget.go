@@ -0,0 +1,42 @@
+package finance
+
+// DefaultBudget is one entry of the template admins define for the budgets new
+// users start with. Its category is a default expense category, since new users
+// have no categories of their own, and its amount is in the user's primary
+// currency.
+type DefaultBudget struct {
+	categoryID CategoryID
+	amount     float64
+	period     BudgetPeriod
+}
+
+// NewDefaultBudget creates a template entry
+func NewDefaultBudget(categoryID CategoryID, amount float64, period BudgetPeriod) (*DefaultBudget, error) {
+	if amount <= 0 {
+		return nil, ErrInvalidBudgetAmount
+	}
+	switch period {
+	case BudgetPeriodWeekly, BudgetPeriodMonthly, BudgetPeriodYearly:
+	default:
+		return nil, ErrInvalidBudgetPeriod
+	}
+
+	return &DefaultBudget{
+		categoryID: categoryID,
+		amount:     amount,
+		period:     period,
+	}, nil
+}
+
+// Getters
+func (d *DefaultBudget) CategoryID() CategoryID {
+	return d.categoryID
+}
+
+func (d *DefaultBudget) Amount() float64 {
+	return d.amount
+}
+
+func (d *DefaultBudget) Period() BudgetPeriod {
+	return d.period
+}
@@ -0,0 +1,103 @@
+package finance
+
+import "errors"
+
+// Domain errors returned by finance entities and services.
+// Callers should compare against these with errors.Is rather than matching messages.
+var (
+	// Not found errors
+	ErrTransactionNotFound          = errors.New("transaction not found")
+	ErrCategoryNotFound             = errors.New("category not found")
+	ErrCurrencyNotFound             = errors.New("currency not found")
+	ErrBudgetNotFound               = errors.New("budget not found")
+	ErrActionNotFound               = errors.New("action not found")
+	ErrAccountNotFound              = errors.New("account not found")
+	ErrBalanceAssertionNotFound     = errors.New("balance assertion not found")
+	ErrBalanceAdjustmentNotFound    = errors.New("balance adjustment not found")
+	ErrHoldingNotFound              = errors.New("holding not found")
+	ErrExportScheduleNotFound       = errors.New("export schedule not found")
+	ErrRecurringTransactionNotFound = errors.New("recurring transaction not found")
+	ErrScheduledTransactionNotFound = errors.New("scheduled transaction not found")
+	ErrClosedMonthNotFound          = errors.New("month is not closed")
+	ErrNoDefaultCurrency            = errors.New("no default currency found")
+	ErrDefaultCurrencyNotSet        = errors.New("no default currency set")
+
+	// Access errors
+	ErrAccessDenied         = errors.New("access denied")
+	ErrCategoryAccessDenied = errors.New("access denied to category")
+	ErrCurrencyAccessDenied = errors.New("access denied to currency")
+	ErrBenchmarkingDisabled = errors.New("turn on benchmarking in your preferences to compare your spending with other users")
+
+	// Conflict errors
+	ErrCurrencyCodeExists           = errors.New("currency code already exists")
+	ErrCurrencyInUse                = errors.New("currency is in use")
+	ErrDefaultCategoryImmutable     = errors.New("cannot update default category")
+	ErrDefaultCategoryNotDeletable  = errors.New("cannot delete default category")
+	ErrDefaultCurrencyImmutable     = errors.New("cannot update default currency")
+	ErrDefaultCurrencyNotDeletable  = errors.New("cannot delete default currency")
+	ErrActionAlreadyUndone          = errors.New("action has already been undone")
+	ErrActionExpired                = errors.New("action can no longer be undone")
+	ErrActionSuperseded             = errors.New("a later change must be undone first")
+	ErrRecurringTransactionInactive = errors.New("recurring transaction is inactive")
+	ErrScheduledTransactionClosed   = errors.New("scheduled transaction has already posted or been cancelled")
+	ErrMonthClosed                  = errors.New("month is closed; reopen it to change its transactions")
+	ErrMonthAlreadyClosed           = errors.New("month is already closed")
+	ErrNoBillingCycle               = errors.New("account has no billing cycle")
+	ErrAccountArchived              = errors.New("account is archived; unarchive it to record transactions")
+	ErrBalanceAlreadyMatches        = errors.New("balance already matches the recorded transactions")
+	ErrVersionConflict              = errors.New("record was changed since it was read; reload it and try again")
+	ErrClientIDInUse                = errors.New("client_id is already used by a transaction of the other type")
+	ErrDuplicateClientID            = errors.New("a transaction was already saved under the client_id")
+
+	// Validation errors
+	ErrTransactionTypeMismatch     = errors.New("transaction type mismatch")
+	ErrCategoryTypeMismatch        = errors.New("category type does not match transaction type")
+	ErrNegativeAmount              = errors.New("amount cannot be negative")
+	ErrInvalidAmount               = errors.New("amount must be a finite number")
+	ErrAmountTooLarge              = errors.New("amount exceeds the maximum of 99,999,999.99")
+	ErrCurrencyChange              = errors.New("cannot change currency of existing record")
+	ErrInvalidBudgetAmount         = errors.New("budget amount must be positive")
+	ErrInvalidBudgetPeriod         = errors.New("invalid budget period")
+	ErrInvalidDefaultBudget        = errors.New("default budgets must use default expense categories, each at most once")
+	ErrInvalidRecurringAmount      = errors.New("recurring transaction amount must be positive")
+	ErrInvalidFrequency            = errors.New("invalid frequency")
+	ErrEmptyCategoryName           = errors.New("category name cannot be empty")
+	ErrInvalidCategoryColor        = errors.New("category color must be a hex color like #3B82F6")
+	ErrEmptyCurrencyCode           = errors.New("currency code cannot be empty")
+	ErrEmptyCurrencyName           = errors.New("currency name cannot be empty")
+	ErrEmptyCurrencySymbol         = errors.New("currency symbol cannot be empty")
+	ErrEmptyAccountName            = errors.New("account name cannot be empty")
+	ErrInvalidAccountType          = errors.New("invalid account type")
+	ErrInvalidBillingCycle         = errors.New("closing and due days must be days of the month (1-31)")
+	ErrBillingCycleNotCreditCard   = errors.New("only credit card accounts have billing cycles")
+	ErrInvalidTicker               = errors.New("ticker must be 1-16 characters")
+	ErrInvalidQuantity             = errors.New("quantity must be a positive number")
+	ErrInvalidPrice                = errors.New("price must be a positive number")
+	ErrInvalidReconciliationStatus = errors.New("invalid reconciliation status")
+	ErrInvalidStatementPeriod      = errors.New("statement end date must not be before its start date")
+	ErrInvalidExportFormat         = errors.New("invalid export format")
+	ErrInvalidStorageProvider      = errors.New("invalid storage provider")
+	ErrEmptyExportTarget           = errors.New("export target cannot be empty")
+	ErrMissingAccessToken          = errors.New("an access token is required for this storage provider")
+	ErrIncomeNotDeductible         = errors.New("only expenses can be tax-deductible")
+	ErrInvalidTaxYear              = errors.New("invalid tax year")
+	ErrInvalidOccurrenceRange      = errors.New("until must be a date (YYYY-MM-DD) at most 5 years ahead")
+	ErrInvalidOccurrenceOverride   = errors.New("an occurrence override needs an amount or a date before the following occurrence")
+	ErrInvalidEndDate              = errors.New("end date cannot be before the next due date")
+	ErrInvalidDueDate              = errors.New("next due date must be a date (YYYY-MM-DD)")
+	ErrInvalidOccurrenceCount      = errors.New("occurrences remaining must be positive")
+	ErrInvalidMonth                = errors.New("month must be YYYY-MM")
+	ErrInvalidCloseMonth           = errors.New("only months that have ended can be closed")
+	ErrInvalidCurrencyPair         = errors.New("an exchange rate needs two different currency codes")
+	ErrInvalidExchangeRate         = errors.New("exchange rate must be a positive number")
+	ErrInvalidRateDate             = errors.New("rate date must be a date (YYYY-MM-DD)")
+	ErrInvalidDateRange            = errors.New("start and end dates must be dates (YYYY-MM-DD), the start not after the end")
+	ErrInvalidTransactionDate      = errors.New("date must be an ISO 8601 date (YYYY-MM-DD) or date and time")
+	ErrFutureTransactionDate       = errors.New("transactions cannot be dated after today")
+	ErrScheduledDateNotFuture      = errors.New("scheduled transactions must be dated after today")
+	ErrInvalidTimezone             = errors.New("timezone must be an IANA time zone name, such as Asia/Jakarta")
+	ErrInvalidLocation             = errors.New("latitude must be between -90 and 90 and longitude between -180 and 180, given together")
+	ErrInvalidBoundingBox          = errors.New("bbox must be min_lng,min_lat,max_lng,max_lat with min_lat not above max_lat")
+	ErrInvalidLineItem             = errors.New("a line item needs a name, a positive quantity and a unit price of at most 99,999,999.99")
+	ErrTooManyLineItems            = errors.New("a receipt can have at most 200 line items")
+)
@@ -0,0 +1,154 @@
+package finance
+
+import (
+	"context"
+	"time"
+)
+
+// Event names
+const (
+	EventTransactionCreated = "transaction.created"
+	EventTransactionUpdated = "transaction.updated"
+	EventTransactionDeleted = "transaction.deleted"
+	EventBudgetExceeded     = "budget.exceeded"
+	EventCurrencyDeleted    = "currency.deleted"
+)
+
+// Event is something that happened in the finance domain
+type Event interface {
+	EventName() string
+	OccurredAt() time.Time
+}
+
+// UserEvent is an event that belongs to a single user, so it can be delivered
+// to that user's integrations
+type UserEvent interface {
+	Event
+	EventUserID() int
+}
+
+// EventPublisher delivers domain events to interested subscribers
+type EventPublisher interface {
+	Publish(ctx context.Context, events ...Event) error
+}
+
+// TransactionCreated is raised when a transaction is recorded
+type TransactionCreated struct {
+	TransactionID int       `json:"transaction_id"`
+	UserID        int       `json:"user_id"`
+	CategoryID    int       `json:"category_id"`
+	CurrencyID    int       `json:"currency_id"`
+	Amount        float64   `json:"amount"`
+	Type          string    `json:"type"`
+	Date          time.Time `json:"date"`
+	At            time.Time `json:"occurred_at"`
+}
+
+// EventName returns the event name
+func (e TransactionCreated) EventName() string { return EventTransactionCreated }
+
+// OccurredAt returns when the event happened
+func (e TransactionCreated) OccurredAt() time.Time { return e.At }
+
+// EventUserID returns the user the transaction belongs to
+func (e TransactionCreated) EventUserID() int { return e.UserID }
+
+// NewTransactionCreated creates the event for a saved transaction
+func NewTransactionCreated(transaction *Transaction) TransactionCreated {
+	return TransactionCreated{
+		TransactionID: transaction.ID().Value(),
+		UserID:        transaction.UserID().Value(),
+		CategoryID:    transaction.CategoryID().Value(),
+		CurrencyID:    transaction.CurrencyID().Value(),
+		Amount:        transaction.Amount().Amount(),
+		Type:          string(transaction.Type()),
+		Date:          transaction.Date(),
+		At:            time.Now(),
+	}
+}
+
+// TransactionUpdated is raised when a transaction is edited; it carries the
+// transaction as it is now
+type TransactionUpdated struct {
+	TransactionID int       `json:"transaction_id"`
+	UserID        int       `json:"user_id"`
+	CategoryID    int       `json:"category_id"`
+	CurrencyID    int       `json:"currency_id"`
+	Amount        float64   `json:"amount"`
+	Type          string    `json:"type"`
+	Date          time.Time `json:"date"`
+	At            time.Time `json:"occurred_at"`
+}
+
+// EventName returns the event name
+func (e TransactionUpdated) EventName() string { return EventTransactionUpdated }
+
+// OccurredAt returns when the event happened
+func (e TransactionUpdated) OccurredAt() time.Time { return e.At }
+
+// EventUserID returns the user the transaction belongs to
+func (e TransactionUpdated) EventUserID() int { return e.UserID }
+
+// NewTransactionUpdated creates the event for a saved edit of a transaction
+func NewTransactionUpdated(transaction *Transaction) TransactionUpdated {
+	return TransactionUpdated(NewTransactionCreated(transaction))
+}
+
+// TransactionDeleted is raised when a transaction is deleted
+type TransactionDeleted struct {
+	TransactionID int       `json:"transaction_id"`
+	UserID        int       `json:"user_id"`
+	Type          string    `json:"type"`
+	At            time.Time `json:"occurred_at"`
+}
+
+// EventName returns the event name
+func (e TransactionDeleted) EventName() string { return EventTransactionDeleted }
+
+// OccurredAt returns when the event happened
+func (e TransactionDeleted) OccurredAt() time.Time { return e.At }
+
+// EventUserID returns the user the transaction belonged to
+func (e TransactionDeleted) EventUserID() int { return e.UserID }
+
+// BudgetExceeded is raised when a transaction pushes spending in a category over its budget
+type BudgetExceeded struct {
+	BudgetID          int       `json:"budget_id"`
+	UserID            int       `json:"user_id"`
+	CategoryID        int       `json:"category_id"`
+	TransactionID     int       `json:"transaction_id"`
+	TransactionAmount float64   `json:"transaction_amount"`
+	TransactionDate   time.Time `json:"transaction_date"`
+	Budgeted          float64   `json:"budgeted"`
+	Spent             float64   `json:"spent"`
+	Overspend         float64   `json:"overspend"`
+	PeriodStart       time.Time `json:"period_start"`
+	PeriodEnd         time.Time `json:"period_end"`
+	At                time.Time `json:"occurred_at"`
+}
+
+// EventName returns the event name
+func (e BudgetExceeded) EventName() string { return EventBudgetExceeded }
+
+// OccurredAt returns when the event happened
+func (e BudgetExceeded) OccurredAt() time.Time { return e.At }
+
+// EventUserID returns the user the budget belongs to
+func (e BudgetExceeded) EventUserID() int { return e.UserID }
+
+// CurrencyDeleted is raised when a user deletes one of their currencies
+type CurrencyDeleted struct {
+	CurrencyID int       `json:"currency_id"`
+	UserID     int       `json:"user_id"`
+	Code       string    `json:"code"`
+	At         time.Time `json:"occurred_at"`
+}
+
+// EventName returns the event name
+func (e CurrencyDeleted) EventName() string { return EventCurrencyDeleted }
+
+// OccurredAt returns when the event happened
+func (e CurrencyDeleted) OccurredAt() time.Time { return e.At }
+
+// EventUserID returns the user the currency belonged to
+func (e CurrencyDeleted) EventUserID() int { return e.UserID }
@@ -0,0 +1,185 @@
+package finance
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ExchangeRateID is a value object representing an exchange rate identifier
+type ExchangeRateID struct {
+	value int
+}
+
+func NewExchangeRateID(id int) ExchangeRateID {
+	return ExchangeRateID{value: id}
+}
+
+func (e ExchangeRateID) Value() int {
+	return e.value
+}
+
+// ExchangeRate is how many units of the quote currency one unit of the base
+// currency bought on a date. Rates are keyed by currency code, so they apply to
+// every user's currency with that code.
+type ExchangeRate struct {
+	id    ExchangeRateID
+	base  string
+	quote string
+	rate  float64
+	date  time.Time
+}
+
+// NewExchangeRate creates an exchange rate for the day date falls on
+func NewExchangeRate(base, quote string, rate float64, date time.Time) (*ExchangeRate, error) {
+	base = strings.ToUpper(strings.TrimSpace(base))
+	quote = strings.ToUpper(strings.TrimSpace(quote))
+	if base == "" || quote == "" || base == quote {
+		return nil, ErrInvalidCurrencyPair
+	}
+	if rate <= 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+		return nil, ErrInvalidExchangeRate
+	}
+
+	return &ExchangeRate{
+		base:  base,
+		quote: quote,
+		rate:  rate,
+		date:  date.UTC().Truncate(24 * time.Hour),
+	}, nil
+}
+
+// RestoreExchangeRate rebuilds a persisted exchange rate
+func RestoreExchangeRate(id ExchangeRateID, base, quote string, rate float64, date time.Time) *ExchangeRate {
+	return &ExchangeRate{
+		id:    id,
+		base:  base,
+		quote: quote,
+		rate:  rate,
+		date:  date,
+	}
+}
+
+// Getters
+func (e *ExchangeRate) ID() ExchangeRateID {
+	return e.id
+}
+
+func (e *ExchangeRate) Base() string {
+	return e.base
+}
+
+func (e *ExchangeRate) Quote() string {
+	return e.quote
+}
+
+func (e *ExchangeRate) Rate() float64 {
+	return e.rate
+}
+
+func (e *ExchangeRate) Date() time.Time {
+	return e.date
+}
+
+// AssignID sets the ID given by the repository on save
+func (e *ExchangeRate) AssignID(id ExchangeRateID) {
+	e.id = id
+}
+
+// RatePoint is the rate converting one currency into another from a date on
+type RatePoint struct {
+	Date time.Time
+	Rate float64
+}
+
+// RateHistory is the rates converting one currency into another, oldest first
+type RateHistory []RatePoint
+
+// NewRateHistory merges the rates quoted in the wanted direction with the
+// inverted rates quoted the other way. Where both exist for a day, the direct
+// rate is used.
+func NewRateHistory(direct, inverse []*ExchangeRate) RateHistory {
+	byDate := make(map[time.Time]float64)
+	for _, rate := range inverse {
+		byDate[rate.Date().UTC()] = 1 / rate.Rate()
+	}
+	for _, rate := range direct {
+		byDate[rate.Date().UTC()] = rate.Rate()
+	}
+
+	history := make(RateHistory, 0, len(byDate))
+	for date, rate := range byDate {
+		history = append(history, RatePoint{Date: date, Rate: rate})
+	}
+	sort.Slice(history, func(i, j int) bool { return history[i].Date.Before(history[j].Date) })
+	return history
+}
+
+// On returns the latest rate on or before date. Dates before the first known rate
+// use the first rate, the closest known. It returns false when there are no rates.
+func (h RateHistory) On(date time.Time) (float64, bool) {
+	if len(h) == 0 {
+		return 0, false
+	}
+
+	rate := h[0].Rate
+	for _, point := range h {
+		if point.Date.After(date) {
+			break
+		}
+		rate = point.Rate
+	}
+	return rate, true
+}
+
+// Latest returns the most recent rate, or false when there are no rates
+func (h RateHistory) Latest() (RatePoint, bool) {
+	if len(h) == 0 {
+		return RatePoint{}, false
+	}
+	return h[len(h)-1], true
+}
+
+// FXPosition is the net amount a user holds in a foreign currency and its
+// unrealized gain or loss against their default currency
+type FXPosition struct {
+	CurrencyID CurrencyID
+	// NetPosition is incomes less expenses, in the foreign currency
+	NetPosition float64
+	// CostBasis is the net position valued at the rate of each transaction's date
+	CostBasis float64
+	// CurrentRate is the latest rate, from RateDate
+	CurrentRate float64
+	RateDate    time.Time
+	// CurrentValue is the net position valued at the current rate
+	CurrentValue float64
+	// UnrealizedGain is CurrentValue less CostBasis; negative for a loss
+	UnrealizedGain float64
+}
+
+// NewFXPosition values the transactions in one foreign currency against the
+// default currency the rates convert into. It returns false when there are no rates.
+func NewFXPosition(currencyID CurrencyID, transactions []*Transaction, rates RateHistory) (*FXPosition, bool) {
+	latest, found := rates.Latest()
+	if !found {
+		return nil, false
+	}
+
+	position := &FXPosition{CurrencyID: currencyID, CurrentRate: latest.Rate, RateDate: latest.Date}
+	for _, transaction := range transactions {
+		amount := transaction.Amount().Amount()
+		if transaction.Type() == TransactionTypeExpense {
+			amount = -amount
+		}
+		rate, _ := rates.On(transaction.Date())
+		position.NetPosition += amount
+		position.CostBasis += amount * rate
+	}
+
+	position.NetPosition = roundCents(position.NetPosition)
+	position.CostBasis = roundCents(position.CostBasis)
+	position.CurrentValue = roundCents(position.NetPosition * latest.Rate)
+	position.UnrealizedGain = roundCents(position.CurrentValue - position.CostBasis)
+	return position, true
+}
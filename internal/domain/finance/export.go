@@ -0,0 +1,308 @@
+package finance
+
+import (
+	"strings"
+	"time"
+)
+
+// ExportFormat is the file format transactions are exported in
+type ExportFormat string
+
+const (
+	ExportFormatCSV  ExportFormat = "csv"
+	ExportFormatJSON ExportFormat = "json"
+)
+
+// StorageProvider is the cloud storage service exports are uploaded to
+type StorageProvider string
+
+const (
+	StorageProviderS3          StorageProvider = "s3"
+	StorageProviderGoogleDrive StorageProvider = "google_drive"
+	StorageProviderDropbox     StorageProvider = "dropbox"
+)
+
+// ExportStatus is the outcome of an export run
+type ExportStatus string
+
+const (
+	ExportStatusSucceeded ExportStatus = "succeeded"
+	ExportStatusFailed    ExportStatus = "failed"
+)
+
+// ExportScheduleID is a value object representing an export schedule identifier
+type ExportScheduleID struct {
+	value int
+}
+
+func NewExportScheduleID(id int) ExportScheduleID {
+	return ExportScheduleID{value: id}
+}
+
+func (e ExportScheduleID) Value() int {
+	return e.value
+}
+
+// ExportSchedule periodically exports a user's transactions to cloud storage.
+// The target is the bucket and key prefix for S3, the folder ID for Google Drive
+// and the folder path for Dropbox.
+type ExportSchedule struct {
+	id          ExportScheduleID
+	userID      UserID
+	format      ExportFormat
+	provider    StorageProvider
+	target      string
+	accessToken string // OAuth token for Google Drive and Dropbox; S3 uses the server's credentials
+	frequency   Frequency
+	nextRunAt   time.Time
+	createdAt   time.Time
+}
+
+// NewExportSchedule creates a new export schedule whose first run is due immediately
+func NewExportSchedule(
+	userID UserID,
+	format ExportFormat,
+	provider StorageProvider,
+	target string,
+	accessToken string,
+	frequency Frequency,
+) (*ExportSchedule, error) {
+	switch format {
+	case ExportFormatCSV, ExportFormatJSON:
+	default:
+		return nil, ErrInvalidExportFormat
+	}
+
+	switch provider {
+	case StorageProviderS3:
+	case StorageProviderGoogleDrive, StorageProviderDropbox:
+		if strings.TrimSpace(accessToken) == "" {
+			return nil, ErrMissingAccessToken
+		}
+	default:
+		return nil, ErrInvalidStorageProvider
+	}
+
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return nil, ErrEmptyExportTarget
+	}
+
+	switch frequency {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+	default:
+		return nil, ErrInvalidFrequency
+	}
+
+	now := time.Now()
+	return &ExportSchedule{
+		userID:      userID,
+		format:      format,
+		provider:    provider,
+		target:      target,
+		accessToken: accessToken,
+		frequency:   frequency,
+		nextRunAt:   now,
+		createdAt:   now,
+	}, nil
+}
+
+// RestoreExportSchedule rebuilds a persisted export schedule
+func RestoreExportSchedule(
+	id ExportScheduleID,
+	userID UserID,
+	format ExportFormat,
+	provider StorageProvider,
+	target string,
+	accessToken string,
+	frequency Frequency,
+	nextRunAt time.Time,
+	createdAt time.Time,
+) *ExportSchedule {
+	return &ExportSchedule{
+		id:          id,
+		userID:      userID,
+		format:      format,
+		provider:    provider,
+		target:      target,
+		accessToken: accessToken,
+		frequency:   frequency,
+		nextRunAt:   nextRunAt,
+		createdAt:   createdAt,
+	}
+}
+
+// Getters
+func (e *ExportSchedule) ID() ExportScheduleID {
+	return e.id
+}
+
+func (e *ExportSchedule) UserID() UserID {
+	return e.userID
+}
+
+func (e *ExportSchedule) Format() ExportFormat {
+	return e.format
+}
+
+func (e *ExportSchedule) Provider() StorageProvider {
+	return e.provider
+}
+
+func (e *ExportSchedule) Target() string {
+	return e.target
+}
+
+func (e *ExportSchedule) AccessToken() string {
+	return e.accessToken
+}
+
+func (e *ExportSchedule) Frequency() Frequency {
+	return e.frequency
+}
+
+func (e *ExportSchedule) NextRunAt() time.Time {
+	return e.nextRunAt
+}
+
+func (e *ExportSchedule) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+// AssignID sets the ID given by the repository on save
+func (e *ExportSchedule) AssignID(id ExportScheduleID) {
+	e.id = id
+}
+
+// IsDue reports whether the next run is due at the given time
+func (e *ExportSchedule) IsDue(at time.Time) bool {
+	return !e.nextRunAt.After(at)
+}
+
+// Advance moves the next run past the given time by whole periods, so a server
+// that was down does not run a schedule several times to catch up
+func (e *ExportSchedule) Advance(at time.Time) {
+	for !e.nextRunAt.After(at) {
+		switch e.frequency {
+		case FrequencyDaily:
+			e.nextRunAt = e.nextRunAt.AddDate(0, 0, 1)
+		case FrequencyWeekly:
+			e.nextRunAt = e.nextRunAt.AddDate(0, 0, 7)
+		case FrequencyMonthly:
+			e.nextRunAt = e.nextRunAt.AddDate(0, 1, 0)
+		default:
+			e.nextRunAt = e.nextRunAt.AddDate(1, 0, 0)
+		}
+	}
+}
+
+// ExportRunID is a value object representing an export run identifier
+type ExportRunID struct {
+	value int
+}
+
+func NewExportRunID(id int) ExportRunID {
+	return ExportRunID{value: id}
+}
+
+func (e ExportRunID) Value() int {
+	return e.value
+}
+
+// ExportRun records one scheduled export in the user's export history
+type ExportRun struct {
+	id               ExportRunID
+	scheduleID       ExportScheduleID
+	userID           UserID
+	provider         StorageProvider
+	fileName         string
+	transactionCount int
+	status           ExportStatus
+	errorMessage     string
+	createdAt        time.Time
+}
+
+// NewExportRun records the outcome of exporting a schedule; a nil err means it succeeded
+func NewExportRun(schedule *ExportSchedule, fileName string, transactionCount int, err error) *ExportRun {
+	run := &ExportRun{
+		scheduleID:       schedule.ID(),
+		userID:           schedule.UserID(),
+		provider:         schedule.Provider(),
+		fileName:         fileName,
+		transactionCount: transactionCount,
+		status:           ExportStatusSucceeded,
+		createdAt:        time.Now(),
+	}
+	if err != nil {
+		run.status = ExportStatusFailed
+		run.errorMessage = err.Error()
+	}
+	return run
+}
+
+// RestoreExportRun rebuilds a persisted export run
+func RestoreExportRun(
+	id ExportRunID,
+	scheduleID ExportScheduleID,
+	userID UserID,
+	provider StorageProvider,
+	fileName string,
+	transactionCount int,
+	status ExportStatus,
+	errorMessage string,
+	createdAt time.Time,
+) *ExportRun {
+	return &ExportRun{
+		id:               id,
+		scheduleID:       scheduleID,
+		userID:           userID,
+		provider:         provider,
+		fileName:         fileName,
+		transactionCount: transactionCount,
+		status:           status,
+		errorMessage:     errorMessage,
+		createdAt:        createdAt,
+	}
+}
+
+// Getters
+func (e *ExportRun) ID() ExportRunID {
+	return e.id
+}
+
+func (e *ExportRun) ScheduleID() ExportScheduleID {
+	return e.scheduleID
+}
+
+func (e *ExportRun) UserID() UserID {
+	return e.userID
+}
+
+func (e *ExportRun) Provider() StorageProvider {
+	return e.provider
+}
+
+func (e *ExportRun) FileName() string {
+	return e.fileName
+}
+
+func (e *ExportRun) TransactionCount() int {
+	return e.transactionCount
+}
+
+func (e *ExportRun) Status() ExportStatus {
+	return e.status
+}
+
+func (e *ExportRun) ErrorMessage() string {
+	return e.errorMessage
+}
+
+func (e *ExportRun) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+// AssignID sets the ID given by the repository on save
+func (e *ExportRun) AssignID(id ExportRunID) {
+	e.id = id
+}
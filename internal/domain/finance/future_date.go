@@ -0,0 +1,22 @@
+package finance
+
+import "time"
+
+// FutureDatePolicy decides what happens to transactions dated after today
+type FutureDatePolicy string
+
+const (
+	// FutureDatesAllow records future-dated transactions like any other
+	FutureDatesAllow FutureDatePolicy = "allow"
+	// FutureDatesReject refuses them with ErrFutureTransactionDate
+	FutureDatesReject FutureDatePolicy = "reject"
+	// FutureDatesSchedule schedules them to post on their date instead
+	FutureDatesSchedule FutureDatePolicy = "schedule"
+)
+
+// IsFutureDate reports whether a transaction date is after today for every user.
+// Tomorrow in UTC is already today in time zones ahead of UTC, so only the days
+// after it count.
+func IsFutureDate(date, now time.Time) bool {
+	return !date.Before(startOfDay(now.UTC()).AddDate(0, 0, 2))
+}
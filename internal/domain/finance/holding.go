@@ -0,0 +1,169 @@
+package finance
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// maxTickerLength is the longest ticker symbol a holding can have
+const maxTickerLength = 16
+
+// HoldingID is a value object representing an investment holding identifier
+type HoldingID struct {
+	value int
+}
+
+func NewHoldingID(id int) HoldingID {
+	return HoldingID{value: id}
+}
+
+func (h HoldingID) Value() int {
+	return h.value
+}
+
+// Holding is a position in a stock, fund or other security a user owns. It is
+// tracked for a full picture of the user's finances; nothing is bought or sold.
+type Holding struct {
+	id         HoldingID
+	userID     UserID
+	ticker     string
+	quantity   float64
+	costBasis  float64 // what the whole position cost, in the holding's currency
+	currencyID CurrencyID
+	createdAt  time.Time
+}
+
+// NewHolding creates a new holding
+func NewHolding(userID UserID, ticker string, quantity, costBasis float64, currencyID CurrencyID) (*Holding, error) {
+	holding := &Holding{
+		userID:     userID,
+		currencyID: currencyID,
+		createdAt:  time.Now(),
+	}
+	if err := holding.Update(ticker, quantity, costBasis); err != nil {
+		return nil, err
+	}
+	return holding, nil
+}
+
+// RestoreHolding rebuilds a persisted holding
+func RestoreHolding(id HoldingID, userID UserID, ticker string, quantity, costBasis float64, currencyID CurrencyID, createdAt time.Time) *Holding {
+	return &Holding{
+		id:         id,
+		userID:     userID,
+		ticker:     ticker,
+		quantity:   quantity,
+		costBasis:  costBasis,
+		currencyID: currencyID,
+		createdAt:  createdAt,
+	}
+}
+
+// Getters
+func (h *Holding) ID() HoldingID {
+	return h.id
+}
+
+func (h *Holding) UserID() UserID {
+	return h.userID
+}
+
+func (h *Holding) Ticker() string {
+	return h.ticker
+}
+
+func (h *Holding) Quantity() float64 {
+	return h.quantity
+}
+
+func (h *Holding) CostBasis() float64 {
+	return h.costBasis
+}
+
+func (h *Holding) CurrencyID() CurrencyID {
+	return h.currencyID
+}
+
+func (h *Holding) CreatedAt() time.Time {
+	return h.createdAt
+}
+
+// Update changes the ticker, quantity and cost basis, such as after buying more
+func (h *Holding) Update(ticker string, quantity, costBasis float64) error {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	if ticker == "" || len(ticker) > maxTickerLength {
+		return ErrInvalidTicker
+	}
+	if quantity <= 0 || math.IsNaN(quantity) || math.IsInf(quantity, 0) {
+		return ErrInvalidQuantity
+	}
+	cost, err := newMoney(costBasis, h.currencyID, defaultMinorUnits)
+	if err != nil {
+		return err
+	}
+
+	h.ticker = ticker
+	h.quantity = quantity
+	h.costBasis = cost.Amount()
+	return nil
+}
+
+// AssignID sets the ID given by the repository on save
+func (h *Holding) AssignID(id HoldingID) {
+	h.id = id
+}
+
+// BelongsTo reports whether the holding is owned by the user
+func (h *Holding) BelongsTo(userID UserID) bool {
+	return h.userID.Value() == userID.Value()
+}
+
+// HoldingSnapshot is the price of a holding's security on a day and what the
+// holding was worth at it. There is at most one snapshot per holding and day.
+type HoldingSnapshot struct {
+	holdingID HoldingID
+	date      time.Time
+	price     float64
+	value     float64
+}
+
+// NewHoldingSnapshot values the holding at a price on the day date falls on
+func NewHoldingSnapshot(holding *Holding, price float64, date time.Time) (*HoldingSnapshot, error) {
+	if price <= 0 || math.IsNaN(price) || math.IsInf(price, 0) {
+		return nil, ErrInvalidPrice
+	}
+	return &HoldingSnapshot{
+		holdingID: holding.ID(),
+		date:      date.UTC().Truncate(24 * time.Hour),
+		price:     price,
+		value:     roundCents(holding.Quantity() * price),
+	}, nil
+}
+
+// RestoreHoldingSnapshot rebuilds a persisted holding snapshot
+func RestoreHoldingSnapshot(holdingID HoldingID, date time.Time, price, value float64) *HoldingSnapshot {
+	return &HoldingSnapshot{
+		holdingID: holdingID,
+		date:      date,
+		price:     price,
+		value:     value,
+	}
+}
+
+// Getters
+func (s *HoldingSnapshot) HoldingID() HoldingID {
+	return s.holdingID
+}
+
+func (s *HoldingSnapshot) Date() time.Time {
+	return s.date
+}
+
+func (s *HoldingSnapshot) Price() float64 {
+	return s.price
+}
+
+func (s *HoldingSnapshot) Value() float64 {
+	return s.value
+}
@@ -0,0 +1,143 @@
+package finance
+
+import (
+	"context"
+	"time"
+)
+
+// InvestmentService handles investment holding domain operations
+type InvestmentService struct {
+	holdingRepo  HoldingRepository
+	snapshotRepo HoldingSnapshotRepository
+	currencyRepo CurrencyRepository
+}
+
+// NewInvestmentService creates a new investment service
+func NewInvestmentService(
+	holdingRepo HoldingRepository,
+	snapshotRepo HoldingSnapshotRepository,
+	currencyRepo CurrencyRepository,
+) *InvestmentService {
+	return &InvestmentService{
+		holdingRepo:  holdingRepo,
+		snapshotRepo: snapshotRepo,
+		currencyRepo: currencyRepo,
+	}
+}
+
+// CreateHolding records a position the user holds
+func (s *InvestmentService) CreateHolding(ctx context.Context, userID UserID, ticker string, quantity, costBasis float64, currencyID CurrencyID) (*Holding, error) {
+	// Validate currency exists and user has access
+	currency, err := s.currencyRepo.FindByID(ctx, currencyID)
+	if err != nil {
+		return nil, ErrCurrencyNotFound
+	}
+	if !currency.IsDefault() && (currency.UserID() == nil || currency.UserID().Value() != userID.Value()) {
+		return nil, ErrCurrencyAccessDenied
+	}
+
+	holding, err := NewHolding(userID, ticker, quantity, costBasis, currencyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.holdingRepo.Save(ctx, holding); err != nil {
+		return nil, err
+	}
+	return holding, nil
+}
+
+// GetHoldings retrieves all holdings for a user
+func (s *InvestmentService) GetHoldings(ctx context.Context, userID UserID) ([]*Holding, error) {
+	return s.holdingRepo.FindByUserID(ctx, userID)
+}
+
+// GetAllHoldings retrieves every user's holdings
+func (s *InvestmentService) GetAllHoldings(ctx context.Context) ([]*Holding, error) {
+	return s.holdingRepo.FindAll(ctx)
+}
+
+// GetHolding retrieves one of the user's holdings; other users' holdings are not found
+func (s *InvestmentService) GetHolding(ctx context.Context, holdingID HoldingID, userID UserID) (*Holding, error) {
+	holding, err := s.holdingRepo.FindByID(ctx, holdingID)
+	if err != nil {
+		return nil, err
+	}
+	if !holding.BelongsTo(userID) {
+		return nil, ErrHoldingNotFound
+	}
+	return holding, nil
+}
+
+// UpdateHolding changes one of the user's holdings. When the security stays the
+// same, today's value is recorded again at its latest price, so the new quantity
+// shows in net worth straight away.
+func (s *InvestmentService) UpdateHolding(ctx context.Context, holdingID HoldingID, userID UserID, ticker string, quantity, costBasis float64) (*Holding, error) {
+	holding, err := s.GetHolding(ctx, holdingID, userID)
+	if err != nil {
+		return nil, err
+	}
+	previousTicker := holding.Ticker()
+	if err := holding.Update(ticker, quantity, costBasis); err != nil {
+		return nil, err
+	}
+	if err := s.holdingRepo.Save(ctx, holding); err != nil {
+		return nil, err
+	}
+
+	if holding.Ticker() != previousTicker {
+		return holding, nil
+	}
+	latest, err := s.ValueOn(ctx, holding, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil {
+		if _, err := s.RecordPrice(ctx, holding, latest.Price(), time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	return holding, nil
+}
+
+// DeleteHolding deletes one of the user's holdings and its price history
+func (s *InvestmentService) DeleteHolding(ctx context.Context, holdingID HoldingID, userID UserID) error {
+	if _, err := s.GetHolding(ctx, holdingID, userID); err != nil {
+		return err
+	}
+	return s.holdingRepo.Delete(ctx, holdingID)
+}
+
+// RecordPrice values a holding at its security's price on a date
+func (s *InvestmentService) RecordPrice(ctx context.Context, holding *Holding, price float64, date time.Time) (*HoldingSnapshot, error) {
+	snapshot, err := NewHoldingSnapshot(holding, price, date)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.snapshotRepo.Save(ctx, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// GetSnapshots retrieves a holding's values over time, oldest first
+func (s *InvestmentService) GetSnapshots(ctx context.Context, holding *Holding) ([]*HoldingSnapshot, error) {
+	return s.snapshotRepo.FindByHoldingID(ctx, holding.ID())
+}
+
+// ValueOn returns the holding's latest snapshot on or before date, or nil when it
+// had no price by then
+func (s *InvestmentService) ValueOn(ctx context.Context, holding *Holding, date time.Time) (*HoldingSnapshot, error) {
+	snapshots, err := s.snapshotRepo.FindByHoldingID(ctx, holding.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *HoldingSnapshot
+	for _, snapshot := range snapshots {
+		if snapshot.Date().After(date) {
+			break
+		}
+		latest = snapshot
+	}
+	return latest, nil
+}
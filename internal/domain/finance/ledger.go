@@ -0,0 +1,90 @@
+package finance
+
+import (
+	"sort"
+	"time"
+)
+
+// LedgerEntry is a transaction or balance adjustment on an account with the
+// account balance after it. Exactly one of Transaction and Adjustment is set.
+type LedgerEntry struct {
+	Transaction    *Transaction
+	Adjustment     *BalanceAdjustment
+	RunningBalance float64
+}
+
+func (e LedgerEntry) date() time.Time {
+	if e.Adjustment != nil {
+		return e.Adjustment.Date()
+	}
+	return e.Transaction.Date()
+}
+
+func (e LedgerEntry) createdAt() time.Time {
+	if e.Adjustment != nil {
+		return e.Adjustment.CreatedAt()
+	}
+	return e.Transaction.CreatedAt()
+}
+
+// change is how much the entry moves the balance
+func (e LedgerEntry) change() float64 {
+	switch {
+	case e.Adjustment != nil:
+		return e.Adjustment.Amount()
+	case e.Transaction.Type() == TransactionTypeIncome:
+		return e.Transaction.Amount().Amount()
+	default:
+		return -e.Transaction.Amount().Amount()
+	}
+}
+
+// Ledger lists an account's transactions over a period the way a bank statement
+// does: oldest first, each with the balance after it
+type Ledger struct {
+	AccountID AccountID
+	// OpeningBalance is the balance before the period; the account's opening
+	// balance when the period has no start
+	OpeningBalance float64
+	Entries        []LedgerEntry
+	ClosingBalance float64
+}
+
+// NewLedger orders the transactions and adjustments oldest first and runs the
+// balance on from openingBalance. Incomes are deposits and expenses withdrawals;
+// entries on the same day keep the order they were recorded in.
+func NewLedger(accountID AccountID, openingBalance float64, transactions []*Transaction, adjustments []*BalanceAdjustment) *Ledger {
+	entries := make([]LedgerEntry, 0, len(transactions)+len(adjustments))
+	for _, transaction := range transactions {
+		entries = append(entries, LedgerEntry{Transaction: transaction})
+	}
+	for _, adjustment := range adjustments {
+		entries = append(entries, LedgerEntry{Adjustment: adjustment})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if !a.date().Equal(b.date()) {
+			return a.date().Before(b.date())
+		}
+		if !a.createdAt().Equal(b.createdAt()) {
+			return a.createdAt().Before(b.createdAt())
+		}
+		if a.Transaction != nil && b.Transaction != nil {
+			return a.Transaction.ID().Value() < b.Transaction.ID().Value()
+		}
+		return false
+	})
+
+	ledger := &Ledger{
+		AccountID:      accountID,
+		OpeningBalance: roundCents(openingBalance),
+		Entries:        entries,
+	}
+	balance := openingBalance
+	for i := range entries {
+		balance += entries[i].change()
+		entries[i].RunningBalance = roundCents(balance)
+	}
+	ledger.ClosingBalance = roundCents(balance)
+	return ledger
+}
@@ -0,0 +1,72 @@
+package finance
+
+// Location is where a transaction was made, in WGS84 degrees
+type Location struct {
+	latitude  float64
+	longitude float64
+}
+
+// NewLocation creates a location from a latitude and longitude
+func NewLocation(latitude, longitude float64) (Location, error) {
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return Location{}, ErrInvalidLocation
+	}
+	return Location{latitude: latitude, longitude: longitude}, nil
+}
+
+func (l Location) Latitude() float64 {
+	return l.latitude
+}
+
+func (l Location) Longitude() float64 {
+	return l.longitude
+}
+
+// BoundingBox is the area of a map, from its south-west to its north-east corner.
+// A box whose west edge is east of its east edge crosses the antimeridian.
+type BoundingBox struct {
+	minLongitude float64
+	minLatitude  float64
+	maxLongitude float64
+	maxLatitude  float64
+}
+
+// NewBoundingBox creates a bounding box from its corners
+func NewBoundingBox(minLongitude, minLatitude, maxLongitude, maxLatitude float64) (BoundingBox, error) {
+	if _, err := NewLocation(minLatitude, minLongitude); err != nil {
+		return BoundingBox{}, ErrInvalidBoundingBox
+	}
+	if _, err := NewLocation(maxLatitude, maxLongitude); err != nil {
+		return BoundingBox{}, ErrInvalidBoundingBox
+	}
+	if minLatitude > maxLatitude {
+		return BoundingBox{}, ErrInvalidBoundingBox
+	}
+	return BoundingBox{
+		minLongitude: minLongitude,
+		minLatitude:  minLatitude,
+		maxLongitude: maxLongitude,
+		maxLatitude:  maxLatitude,
+	}, nil
+}
+
+func (b BoundingBox) MinLongitude() float64 {
+	return b.minLongitude
+}
+
+func (b BoundingBox) MinLatitude() float64 {
+	return b.minLatitude
+}
+
+func (b BoundingBox) MaxLongitude() float64 {
+	return b.maxLongitude
+}
+
+func (b BoundingBox) MaxLatitude() float64 {
+	return b.maxLatitude
+}
+
+// CrossesAntimeridian reports whether the box wraps from 180 to -180 degrees longitude
+func (b BoundingBox) CrossesAntimeridian() bool {
+	return b.minLongitude > b.maxLongitude
+}
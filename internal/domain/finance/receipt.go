@@ -0,0 +1,74 @@
+package finance
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxLineItemsPerReceipt bounds how many line items one expense can have
+const MaxLineItemsPerReceipt = 200
+
+// ReceiptLineItem is one line of the receipt for an expense, such as an item read
+// off the receipt by OCR. A line can be given its own category so the expense can
+// later be split by what was bought.
+type ReceiptLineItem struct {
+	name       string
+	quantity   float64
+	unitPrice  float64
+	categoryID *CategoryID
+}
+
+// NewReceiptLineItem creates a new receipt line item
+func NewReceiptLineItem(name string, quantity, unitPrice float64, categoryID *CategoryID) (*ReceiptLineItem, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > 255 {
+		return nil, ErrInvalidLineItem
+	}
+	if math.IsNaN(quantity) || math.IsInf(quantity, 0) || quantity <= 0 || quantity >= 1e6 {
+		return nil, ErrInvalidLineItem
+	}
+	if math.IsNaN(unitPrice) || math.IsInf(unitPrice, 0) || unitPrice < 0 || unitPrice > MaxAmount {
+		return nil, ErrInvalidLineItem
+	}
+
+	return &ReceiptLineItem{
+		name:       name,
+		quantity:   math.Round(quantity*1000) / 1000,
+		unitPrice:  roundCents(unitPrice),
+		categoryID: categoryID,
+	}, nil
+}
+
+// RestoreReceiptLineItem rebuilds a persisted receipt line item
+func RestoreReceiptLineItem(name string, quantity, unitPrice float64, categoryID *CategoryID) *ReceiptLineItem {
+	return &ReceiptLineItem{
+		name:       name,
+		quantity:   quantity,
+		unitPrice:  unitPrice,
+		categoryID: categoryID,
+	}
+}
+
+// Getters
+func (i *ReceiptLineItem) Name() string {
+	return i.name
+}
+
+func (i *ReceiptLineItem) Quantity() float64 {
+	return i.quantity
+}
+
+func (i *ReceiptLineItem) UnitPrice() float64 {
+	return i.unitPrice
+}
+
+// CategoryID is the category the line is spent on, or nil for the expense's own
+func (i *ReceiptLineItem) CategoryID() *CategoryID {
+	return i.categoryID
+}
+
+// Total is the quantity times the unit price
+func (i *ReceiptLineItem) Total() float64 {
+	return roundCents(i.quantity * i.unitPrice)
+}
@@ -0,0 +1,87 @@
+package finance
+
+import "context"
+
+// ReceiptService handles the line items of expense receipts
+type ReceiptService struct {
+	lineItemRepo    ReceiptLineItemRepository
+	transactionRepo TransactionRepository
+	categoryRepo    CategoryRepository
+}
+
+// NewReceiptService creates a new receipt service
+func NewReceiptService(
+	lineItemRepo ReceiptLineItemRepository,
+	transactionRepo TransactionRepository,
+	categoryRepo CategoryRepository,
+) *ReceiptService {
+	return &ReceiptService{
+		lineItemRepo:    lineItemRepo,
+		transactionRepo: transactionRepo,
+		categoryRepo:    categoryRepo,
+	}
+}
+
+// GetLineItems returns one of the user's expenses with its line items
+func (s *ReceiptService) GetLineItems(ctx context.Context, expenseID TransactionID, userID UserID) (*Transaction, []*ReceiptLineItem, error) {
+	expense, err := s.findExpense(ctx, expenseID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items, err := s.lineItemRepo.FindByExpenseID(ctx, expenseID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return expense, items, nil
+}
+
+// ReplaceLineItems replaces the line items of one of the user's expenses. Every
+// category given to a line must be an expense category the user can use. The
+// lines need not add up to the expense amount, as receipts often carry taxes,
+// tips and discounts that are not itemized.
+func (s *ReceiptService) ReplaceLineItems(ctx context.Context, expenseID TransactionID, userID UserID, items []*ReceiptLineItem) (*Transaction, error) {
+	if len(items) > MaxLineItemsPerReceipt {
+		return nil, ErrTooManyLineItems
+	}
+
+	expense, err := s.findExpense(ctx, expenseID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	checked := make(map[CategoryID]bool)
+	for _, item := range items {
+		if item.CategoryID() == nil || checked[*item.CategoryID()] {
+			continue
+		}
+		category, err := s.categoryRepo.FindByID(ctx, *item.CategoryID())
+		if err != nil {
+			return nil, ErrCategoryNotFound
+		}
+		if !category.AccessibleBy(userID) {
+			return nil, ErrCategoryAccessDenied
+		}
+		if category.Type() != CategoryTypeExpense {
+			return nil, ErrCategoryTypeMismatch
+		}
+		checked[category.ID()] = true
+	}
+
+	if err := s.lineItemRepo.ReplaceForExpense(ctx, expenseID, userID, items); err != nil {
+		return nil, err
+	}
+	return expense, nil
+}
+
+// findExpense finds one of the user's expenses
+func (s *ReceiptService) findExpense(ctx context.Context, expenseID TransactionID, userID UserID) (*Transaction, error) {
+	expense, err := s.transactionRepo.FindByIDAndType(ctx, expenseID, TransactionTypeExpense)
+	if err != nil {
+		return nil, ErrTransactionNotFound
+	}
+	if expense.UserID().Value() != userID.Value() {
+		return nil, ErrAccessDenied
+	}
+	return expense, nil
+}
@@ -0,0 +1,74 @@
+package finance
+
+import (
+	"math"
+	"time"
+)
+
+// ReconciliationStatus tracks how far a transaction has been checked against the bank
+type ReconciliationStatus string
+
+const (
+	// StatusUncleared is a recorded transaction not yet seen on the account
+	StatusUncleared ReconciliationStatus = "uncleared"
+	// StatusCleared is a transaction the user has seen clear on the account
+	StatusCleared ReconciliationStatus = "cleared"
+	// StatusReconciled is a transaction matched against a bank statement
+	StatusReconciled ReconciliationStatus = "reconciled"
+)
+
+// ParseReconciliationStatus validates a reconciliation status
+func ParseReconciliationStatus(status string) (ReconciliationStatus, error) {
+	switch s := ReconciliationStatus(status); s {
+	case StatusUncleared, StatusCleared, StatusReconciled:
+		return s, nil
+	default:
+		return "", ErrInvalidReconciliationStatus
+	}
+}
+
+// AccountTotals sums the transactions recorded on an account
+type AccountTotals struct {
+	Deposits    float64
+	Withdrawals float64
+	Count       int
+}
+
+// Statement is the totals a bank statement reports for an account over a period
+type Statement struct {
+	StartDate   time.Time
+	EndDate     time.Time
+	Deposits    float64
+	Withdrawals float64
+}
+
+// Reconciliation compares the transactions recorded on an account with a bank statement
+type Reconciliation struct {
+	AccountID           AccountID
+	Statement           Statement
+	RecordedDeposits    float64
+	RecordedWithdrawals float64
+	TransactionCount    int
+	// Reconciled is set once the period's transactions have been marked reconciled
+	Reconciled bool
+}
+
+// DepositsDifference is recorded minus statement deposits
+func (r *Reconciliation) DepositsDifference() float64 {
+	return roundCents(r.RecordedDeposits - r.Statement.Deposits)
+}
+
+// WithdrawalsDifference is recorded minus statement withdrawals
+func (r *Reconciliation) WithdrawalsDifference() float64 {
+	return roundCents(r.RecordedWithdrawals - r.Statement.Withdrawals)
+}
+
+// Balanced reports whether the recorded totals match the statement to the cent
+func (r *Reconciliation) Balanced() bool {
+	return r.DepositsDifference() == 0 && r.WithdrawalsDifference() == 0
+}
+
+// roundCents rounds to two decimals, so float sums compare cleanly with statement totals
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -1,7 +1,6 @@
 package finance
 
 import (
-	"errors"
 	"time"
 )
 
@@ -27,6 +26,20 @@ type RecurringTransaction struct {
 	nextDueDate time.Time
 	isActive    bool
 	createdAt   time.Time
+
+	// The day of the month monthly and yearly occurrences fall on, or the last
+	// day of months too short for it; 0 when it is the day of the next due date
+	anchorDay int
+
+	// One-time changes to the next occurrence, leaving the schedule as it is
+	nextAmountOverride *Money
+	nextDateOverride   *time.Time
+
+	// End conditions; the recurring transaction deactivates once either is reached
+	endDate              *time.Time
+	occurrencesRemaining *int
+
+	remindedFor *time.Time // the occurrence date the user was last reminded of
 }
 
 // RecurringTransactionID is a value object representing a recurring transaction identifier
@@ -54,7 +67,7 @@ func NewRecurringTransaction(
 	nextDueDate time.Time,
 ) (*RecurringTransaction, error) {
 	if amount.Amount() <= 0 {
-		return nil, errors.New("recurring transaction amount must be positive")
+		return nil, ErrInvalidRecurringAmount
 	}
 	
 	// Validate frequency
@@ -62,7 +75,7 @@ func NewRecurringTransaction(
 	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
 		// Valid frequencies
 	default:
-		return nil, errors.New("invalid frequency")
+		return nil, ErrInvalidFrequency
 	}
 	
 	return &RecurringTransaction{
@@ -76,9 +89,37 @@ func NewRecurringTransaction(
 		nextDueDate: nextDueDate,
 		isActive:    true,
 		createdAt:   time.Now(),
+		anchorDay:   nextDueDate.Day(),
 	}, nil
 }
 
+// RestoreRecurringTransaction rebuilds a persisted recurring transaction
+func RestoreRecurringTransaction(
+	id RecurringTransactionID,
+	userID UserID,
+	categoryID CategoryID,
+	currencyID CurrencyID,
+	amount Money,
+	description string,
+	frequency Frequency,
+	nextDueDate time.Time,
+	isActive bool,
+	createdAt time.Time,
+) *RecurringTransaction {
+	return &RecurringTransaction{
+		id:          id,
+		userID:      userID,
+		categoryID:  categoryID,
+		currencyID:  currencyID,
+		amount:      amount,
+		description: description,
+		frequency:   frequency,
+		nextDueDate: nextDueDate,
+		isActive:    isActive,
+		createdAt:   createdAt,
+	}
+}
+
 // Getters
 func (r *RecurringTransaction) ID() RecurringTransactionID {
 	return r.id
@@ -120,13 +161,94 @@ func (r *RecurringTransaction) CreatedAt() time.Time {
 	return r.createdAt
 }
 
+// AssignID sets the ID given by the repository on save
+func (r *RecurringTransaction) AssignID(id RecurringTransactionID) {
+	r.id = id
+}
+
+// AssignAnchorDay sets the day of the month monthly and yearly occurrences fall on
+func (r *RecurringTransaction) AssignAnchorDay(day int) {
+	r.anchorDay = day
+}
+
+// AnchorDay returns the day of the month monthly and yearly occurrences fall on
+func (r *RecurringTransaction) AnchorDay() int {
+	if r.anchorDay == 0 {
+		return r.nextDueDate.Day()
+	}
+	return r.anchorDay
+}
+
+// AssignNextOccurrenceOverride sets the persisted one-time changes to the next occurrence
+func (r *RecurringTransaction) AssignNextOccurrenceOverride(amount *Money, date *time.Time) {
+	r.nextAmountOverride = amount
+	r.nextDateOverride = date
+}
+
+// NextAmountOverride returns the one-time amount of the next occurrence, or nil
+func (r *RecurringTransaction) NextAmountOverride() *Money {
+	return r.nextAmountOverride
+}
+
+// NextDateOverride returns the one-time date of the next occurrence, or nil
+func (r *RecurringTransaction) NextDateOverride() *time.Time {
+	return r.nextDateOverride
+}
+
+// AssignEndConditions sets the persisted end date and remaining occurrence count
+func (r *RecurringTransaction) AssignEndConditions(endDate *time.Time, occurrencesRemaining *int) {
+	r.endDate = endDate
+	r.occurrencesRemaining = occurrencesRemaining
+}
+
+// EndDate returns the last date an occurrence can fall on, or nil
+func (r *RecurringTransaction) EndDate() *time.Time {
+	return r.endDate
+}
+
+// OccurrencesRemaining returns how many more times the transaction posts, or nil when unlimited
+func (r *RecurringTransaction) OccurrencesRemaining() *int {
+	return r.occurrencesRemaining
+}
+
+// AssignRemindedFor sets the persisted date of the occurrence last reminded of
+func (r *RecurringTransaction) AssignRemindedFor(date *time.Time) {
+	r.remindedFor = date
+}
+
+// RemindedFor returns the date of the occurrence the user was last reminded of, or nil
+func (r *RecurringTransaction) RemindedFor() *time.Time {
+	return r.remindedFor
+}
+
+// NeedsReminder reports whether the next occurrence falls within daysAhead days
+// of at, counting whole days, and the user has not been reminded of it yet
+func (r *RecurringTransaction) NeedsReminder(at time.Time, daysAhead int) bool {
+	if !r.isActive || r.hasEnded() {
+		return false
+	}
+
+	date := r.NextOccurrence().Date
+	today := at.UTC().Truncate(24 * time.Hour)
+	if date.Before(today) || date.After(today.AddDate(0, 0, daysAhead)) {
+		return false
+	}
+	return r.remindedFor == nil || !r.remindedFor.Equal(date)
+}
+
+// MarkReminded records that the user was reminded of the next occurrence
+func (r *RecurringTransaction) MarkReminded() {
+	date := r.NextOccurrence().Date
+	r.remindedFor = &date
+}
+
 // UpdateAmount updates the recurring transaction amount
 func (r *RecurringTransaction) UpdateAmount(newAmount Money) error {
 	if newAmount.Amount() <= 0 {
-		return errors.New("recurring transaction amount must be positive")
+		return ErrInvalidRecurringAmount
 	}
 	if newAmount.Currency() != r.amount.Currency() {
-		return errors.New("cannot change currency of existing recurring transaction")
+		return ErrCurrencyChange
 	}
 	r.amount = newAmount
 	return nil
@@ -142,15 +264,17 @@ func (r *RecurringTransaction) UpdateFrequency(newFrequency Frequency) error {
 	switch newFrequency {
 	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
 		r.frequency = newFrequency
+		r.anchorDay = r.nextDueDate.Day()
 		return nil
 	default:
-		return errors.New("invalid frequency")
+		return ErrInvalidFrequency
 	}
 }
 
-// UpdateNextDueDate updates the next due date
+// UpdateNextDueDate updates the next due date, which later occurrences follow
 func (r *RecurringTransaction) UpdateNextDueDate(newDate time.Time) {
 	r.nextDueDate = newDate
+	r.anchorDay = newDate.Day()
 }
 
 // Activate activates the recurring transaction
@@ -163,23 +287,182 @@ func (r *RecurringTransaction) Deactivate() {
 	r.isActive = false
 }
 
+// UpdateEndConditions sets when the recurring transaction stops: on an end date,
+// after a number of further occurrences, or both. Nil values remove a condition.
+func (r *RecurringTransaction) UpdateEndConditions(endDate *time.Time, occurrencesRemaining *int) error {
+	if endDate != nil && endDate.Before(r.nextDueDate) {
+		return ErrInvalidEndDate
+	}
+	if occurrencesRemaining != nil && *occurrencesRemaining <= 0 {
+		return ErrInvalidOccurrenceCount
+	}
+
+	r.endDate = endDate
+	r.occurrencesRemaining = occurrencesRemaining
+	return nil
+}
+
+// RecordOccurrencePosted moves the schedule on after the next occurrence posted,
+// deactivating the recurring transaction once an end condition is reached
+func (r *RecurringTransaction) RecordOccurrencePosted() {
+	if r.occurrencesRemaining != nil {
+		remaining := *r.occurrencesRemaining - 1
+		r.occurrencesRemaining = &remaining
+	}
+	r.moveToNextOccurrence()
+}
+
+// SkipNextOccurrence moves the schedule past the next occurrence so it never
+// posts, dropping any one-time changes made to it. A skipped occurrence does not
+// count against the remaining occurrences.
+func (r *RecurringTransaction) SkipNextOccurrence() error {
+	if !r.isActive {
+		return ErrRecurringTransactionInactive
+	}
+	r.moveToNextOccurrence()
+	return nil
+}
+
+// moveToNextOccurrence advances the next due date by one period and deactivates
+// the recurring transaction when it has ended
+func (r *RecurringTransaction) moveToNextOccurrence() {
+	r.nextDueDate = r.CalculateNextDueDate()
+	r.ClearNextOccurrenceOverride()
+	if r.hasEnded() {
+		r.isActive = false
+	}
+}
+
+// hasEnded reports whether no occurrences are left
+func (r *RecurringTransaction) hasEnded() bool {
+	if r.occurrencesRemaining != nil && *r.occurrencesRemaining <= 0 {
+		return true
+	}
+	return r.endDate != nil && r.nextDueDate.After(*r.endDate)
+}
+
+// OverrideNextOccurrence changes the amount or date of the next occurrence only.
+// A nil amount or date keeps the scheduled one; the date must fall before the
+// occurrence after it and not after the end date.
+func (r *RecurringTransaction) OverrideNextOccurrence(amount *Money, date *time.Time) error {
+	if !r.isActive {
+		return ErrRecurringTransactionInactive
+	}
+	if amount == nil && date == nil {
+		return ErrInvalidOccurrenceOverride
+	}
+	if amount != nil {
+		if amount.Amount() <= 0 {
+			return ErrInvalidRecurringAmount
+		}
+		if amount.Currency() != r.amount.Currency() {
+			return ErrCurrencyChange
+		}
+	}
+	if date != nil && (!date.Before(r.CalculateNextDueDate()) || (r.endDate != nil && date.After(*r.endDate))) {
+		return ErrInvalidOccurrenceOverride
+	}
+
+	r.nextAmountOverride = amount
+	r.nextDateOverride = date
+	return nil
+}
+
+// ClearNextOccurrenceOverride restores the scheduled amount and date of the next occurrence
+func (r *RecurringTransaction) ClearNextOccurrenceOverride() {
+	r.nextAmountOverride = nil
+	r.nextDateOverride = nil
+}
+
+// NextOccurrence returns the next posting, with its one-time changes applied
+func (r *RecurringTransaction) NextOccurrence() Occurrence {
+	occurrence := Occurrence{Date: r.nextDueDate, Amount: r.amount}
+	if r.nextAmountOverride != nil {
+		occurrence.Amount = *r.nextAmountOverride
+	}
+	if r.nextDateOverride != nil {
+		occurrence.Date = *r.nextDateOverride
+	}
+	return occurrence
+}
+
 // CalculateNextDueDate calculates the next due date based on frequency
 func (r *RecurringTransaction) CalculateNextDueDate() time.Time {
-	switch r.frequency {
+	return advance(r.nextDueDate, r.frequency, r.AnchorDay())
+}
+
+// Occurrence is a projected posting of a recurring transaction
+type Occurrence struct {
+	Date   time.Time
+	Amount Money
+}
+
+// Occurrences projects the postings from the next occurrence up to and including
+// until, at most limit of them, stopping at the end conditions. An inactive
+// recurring transaction has none.
+func (r *RecurringTransaction) Occurrences(until time.Time, limit int) []Occurrence {
+	var occurrences []Occurrence
+	if !r.isActive || r.hasEnded() {
+		return occurrences
+	}
+	if r.occurrencesRemaining != nil && *r.occurrencesRemaining < limit {
+		limit = *r.occurrencesRemaining
+	}
+	if r.endDate != nil && r.endDate.Before(until) {
+		until = *r.endDate
+	}
+
+	if next := r.NextOccurrence(); !next.Date.After(until) && limit > 0 {
+		occurrences = append(occurrences, next)
+	}
+	for date := r.CalculateNextDueDate(); !date.After(until) && len(occurrences) < limit; date = advance(date, r.frequency, r.AnchorDay()) {
+		occurrences = append(occurrences, Occurrence{Date: date, Amount: r.amount})
+	}
+	return occurrences
+}
+
+// FirstDueDateAfter returns the first date of a schedule starting at start and
+// repeating at frequency that falls after after
+func FirstDueDateAfter(start time.Time, frequency Frequency, after time.Time) time.Time {
+	date := start
+	for !date.After(after) {
+		next := advance(date, frequency, start.Day())
+		if !next.After(date) {
+			break
+		}
+		date = next
+	}
+	return date
+}
+
+// advance returns the due date one period after date. Monthly and yearly
+// schedules fall on anchorDay, or on the last day of months too short for it.
+func advance(date time.Time, frequency Frequency, anchorDay int) time.Time {
+	switch frequency {
 	case FrequencyDaily:
-		return r.nextDueDate.AddDate(0, 0, 1)
+		return date.AddDate(0, 0, 1)
 	case FrequencyWeekly:
-		return r.nextDueDate.AddDate(0, 0, 7)
+		return date.AddDate(0, 0, 7)
 	case FrequencyMonthly:
-		return r.nextDueDate.AddDate(0, 1, 0)
+		return addMonths(date, 1, anchorDay)
 	case FrequencyYearly:
-		return r.nextDueDate.AddDate(1, 0, 0)
+		return addMonths(date, 12, anchorDay)
 	default:
-		return r.nextDueDate
+		return date
 	}
 }
 
+// addMonths returns the date the given number of months after date, on
+// anchorDay or the last day of the month, whichever comes first. Unlike
+// time.AddDate it never spills over into the month after.
+func addMonths(date time.Time, months, anchorDay int) time.Time {
+	year, month, _ := date.Date()
+	first := time.Date(year, month+time.Month(months), 1, date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), date.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(anchorDay, lastDay)-1)
+}
+
 // IsDue checks if the recurring transaction is due
 func (r *RecurringTransaction) IsDue() bool {
-	return r.isActive && time.Now().After(r.nextDueDate)
+	return r.isActive && time.Now().After(r.NextOccurrence().Date)
 }
@@ -0,0 +1,107 @@
+package finance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// day returns midnight UTC on a date
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+// newRecurring creates a recurring transaction of 10 starting on start
+func newRecurring(t *testing.T, frequency Frequency, start time.Time) *RecurringTransaction {
+	t.Helper()
+	amount, err := NewMoney(10, NewCurrencyID(1))
+	require.NoError(t, err)
+	recurring, err := NewRecurringTransaction(NewRecurringTransactionID(1), NewUserID(1), NewCategoryID(1), NewCurrencyID(1), amount, "rent", frequency, start)
+	require.NoError(t, err)
+	return recurring
+}
+
+// dates returns the dates of the occurrences
+func dates(occurrences []Occurrence) []time.Time {
+	result := make([]time.Time, len(occurrences))
+	for i, occurrence := range occurrences {
+		result[i] = occurrence.Date
+	}
+	return result
+}
+
+func TestAdvance(t *testing.T) {
+	tests := []struct {
+		name      string
+		date      time.Time
+		frequency Frequency
+		anchorDay int
+		want      time.Time
+	}{
+		{"daily", day(2024, 1, 31), FrequencyDaily, 31, day(2024, 2, 1)},
+		{"weekly", day(2024, 2, 26), FrequencyWeekly, 26, day(2024, 3, 4)},
+		{"monthly within the month", day(2024, 1, 15), FrequencyMonthly, 15, day(2024, 2, 15)},
+		{"monthly into a shorter month", day(2024, 1, 31), FrequencyMonthly, 31, day(2024, 2, 29)},
+		{"monthly into a shorter month outside a leap year", day(2023, 1, 31), FrequencyMonthly, 31, day(2023, 2, 28)},
+		{"monthly back to the anchor day", day(2024, 2, 29), FrequencyMonthly, 31, day(2024, 3, 31)},
+		{"monthly into a 30 day month", day(2024, 3, 31), FrequencyMonthly, 31, day(2024, 4, 30)},
+		{"monthly across the year", day(2024, 12, 31), FrequencyMonthly, 31, day(2025, 1, 31)},
+		{"yearly", day(2024, 3, 1), FrequencyYearly, 1, day(2025, 3, 1)},
+		{"yearly from a leap day", day(2024, 2, 29), FrequencyYearly, 29, day(2025, 2, 28)},
+		{"yearly back to a leap day", day(2027, 2, 28), FrequencyYearly, 29, day(2028, 2, 29)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, advance(tt.date, tt.frequency, tt.anchorDay))
+		})
+	}
+}
+
+func TestRecurringTransactionOccurrences(t *testing.T) {
+	t.Run("month end schedules stay on the last day", func(t *testing.T) {
+		recurring := newRecurring(t, FrequencyMonthly, day(2024, 1, 31))
+
+		assert.Equal(t, []time.Time{
+			day(2024, 1, 31), day(2024, 2, 29), day(2024, 3, 31), day(2024, 4, 30), day(2024, 5, 31),
+		}, dates(recurring.Occurrences(day(2024, 5, 31), 10)))
+	})
+
+	t.Run("posting keeps to the anchor day", func(t *testing.T) {
+		recurring := newRecurring(t, FrequencyMonthly, day(2024, 1, 31))
+		recurring.RecordOccurrencePosted()
+		recurring.RecordOccurrencePosted()
+
+		assert.Equal(t, day(2024, 3, 31), recurring.NextDueDate())
+	})
+
+	t.Run("the limit and end conditions stop the projection", func(t *testing.T) {
+		recurring := newRecurring(t, FrequencyWeekly, day(2024, 1, 1))
+		assert.Len(t, recurring.Occurrences(day(2024, 12, 31), 3), 3)
+
+		endDate := day(2024, 1, 20)
+		require.NoError(t, recurring.UpdateEndConditions(&endDate, nil))
+		assert.Equal(t, []time.Time{day(2024, 1, 1), day(2024, 1, 8), day(2024, 1, 15)}, dates(recurring.Occurrences(day(2024, 12, 31), 10)))
+
+		remaining := 2
+		require.NoError(t, recurring.UpdateEndConditions(nil, &remaining))
+		assert.Len(t, recurring.Occurrences(day(2024, 12, 31), 10), 2)
+	})
+
+	t.Run("the last occurrence deactivates the schedule", func(t *testing.T) {
+		recurring := newRecurring(t, FrequencyMonthly, day(2024, 1, 31))
+		remaining := 1
+		require.NoError(t, recurring.UpdateEndConditions(nil, &remaining))
+
+		recurring.RecordOccurrencePosted()
+		assert.False(t, recurring.IsActive())
+		assert.Empty(t, recurring.Occurrences(day(2024, 12, 31), 10))
+	})
+}
+
+func TestFirstDueDateAfter(t *testing.T) {
+	assert.Equal(t, day(2024, 4, 30), FirstDueDateAfter(day(2024, 1, 31), FrequencyMonthly, day(2024, 4, 15)))
+	assert.Equal(t, day(2024, 5, 31), FirstDueDateAfter(day(2024, 1, 31), FrequencyMonthly, day(2024, 4, 30)))
+	assert.Equal(t, day(2028, 2, 29), FirstDueDateAfter(day(2024, 2, 29), FrequencyYearly, day(2027, 3, 1)))
+}
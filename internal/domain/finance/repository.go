@@ -5,16 +5,40 @@ import (
 	"time"
 )
 
+// UnitOfWork runs a function atomically. Repository calls made with the
+// context passed to fn take part in the same database transaction, which is
+// committed when fn returns nil and rolled back otherwise.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 // TransactionRepository defines the contract for transaction persistence
 type TransactionRepository interface {
+	// Save creates or updates a transaction. Creating one under a client ID the
+	// user already saved a transaction of the same type under returns ErrDuplicateClientID.
 	Save(ctx context.Context, transaction *Transaction) error
 	FindByID(ctx context.Context, id TransactionID) (*Transaction, error)
 	FindByIDAndType(ctx context.Context, id TransactionID, transactionType TransactionType) (*Transaction, error)
+	// FindByClientID finds the user's transaction created under the client ID,
+	// archived or not, or returns ErrTransactionNotFound
+	FindByClientID(ctx context.Context, userID UserID, clientID string) (*Transaction, error)
 	FindByUserID(ctx context.Context, userID UserID) ([]*Transaction, error)
 	FindByUserIDAndDateRange(ctx context.Context, userID UserID, startDate, endDate time.Time) ([]*Transaction, error)
 	FindByUserIDAndCategory(ctx context.Context, userID UserID, categoryID CategoryID) ([]*Transaction, error)
 	FindByUserIDWithFilters(ctx context.Context, userID UserID, filters TransactionFilters) ([]*Transaction, int64, error)
+	// FindByUserIDUpdatedSince finds the user's transactions created or updated at or after since
+	FindByUserIDUpdatedSince(ctx context.Context, userID UserID, since time.Time) ([]*Transaction, error)
+	// Delete deletes a transaction and leaves a tombstone for it
 	Delete(ctx context.Context, id TransactionID) error
+	// ArchiveBefore moves transactions dated before cutoff to the archive and returns how many moved
+	ArchiveBefore(ctx context.Context, cutoff time.Time) (int, error)
+	// GetAccountTotals sums an account's transactions dated within the range
+	GetAccountTotals(ctx context.Context, accountID AccountID, startDate, endDate time.Time) (AccountTotals, error)
+	// GetCategoryStats counts the user's transactions per category and sums those
+	// dated in [monthStart, monthEnd); categories without transactions are left out
+	GetCategoryStats(ctx context.Context, userID UserID, monthStart, monthEnd time.Time) (map[CategoryID]CategoryStats, error)
+	// UpdateStatusByAccount sets the status of an account's transactions dated within the range and returns how many changed
+	UpdateStatusByAccount(ctx context.Context, accountID AccountID, startDate, endDate time.Time, status ReconciliationStatus) (int, error)
 	// Dashboard stats methods
 	GetTotalCount(ctx context.Context) (int, error)
 	GetTotalExpenses(ctx context.Context) (float64, error)
@@ -28,6 +52,11 @@ type CategoryRepository interface {
 	FindByUserID(ctx context.Context, userID UserID) ([]*Category, error)
 	FindByUserIDAndType(ctx context.Context, userID UserID, categoryType CategoryType) ([]*Category, error)
 	FindDefaultCategories(ctx context.Context) ([]*Category, error)
+	// FindCopy finds the user's copy of a default category, or returns ErrCategoryNotFound
+	FindCopy(ctx context.Context, userID UserID, sourceID CategoryID) (*Category, error)
+	// FindByUserIDUpdatedSince finds the user's and default categories created or updated at or after since
+	FindByUserIDUpdatedSince(ctx context.Context, userID UserID, since time.Time) ([]*Category, error)
+	// Delete deletes a category and leaves a tombstone for it when it belongs to a user
 	Delete(ctx context.Context, id CategoryID) error
 	ExistsByID(ctx context.Context, id CategoryID) (bool, error)
 }
@@ -41,8 +70,13 @@ type CurrencyRepository interface {
 	Delete(ctx context.Context, id CurrencyID) error
 	ExistsByID(ctx context.Context, id CurrencyID) (bool, error)
 	ExistsByCodeAndUserID(ctx context.Context, code string, userID UserID) (bool, error)
+	// SetUserDefaultCurrency stores the user's default currency, creating their
+	// preferences if they have none yet
 	SetUserDefaultCurrency(ctx context.Context, userID UserID, currencyID CurrencyID) error
+	// GetUserDefaultCurrency returns ErrDefaultCurrencyNotSet for users without
+	// preferences, and ErrCurrencyNotFound if their currency no longer exists
 	GetUserDefaultCurrency(ctx context.Context, userID UserID) (*Currency, error)
+	IsInUse(ctx context.Context, id CurrencyID) (bool, error)
 }
 
 // BudgetRepository defines the contract for budget persistence
@@ -52,6 +86,9 @@ type BudgetRepository interface {
 	FindByUserID(ctx context.Context, userID UserID) ([]*Budget, error)
 	FindByUserIDAndCategory(ctx context.Context, userID UserID, categoryID CategoryID) ([]*Budget, error)
 	FindActiveByUserID(ctx context.Context, userID UserID) ([]*Budget, error)
+	// FindByUserIDUpdatedSince finds the user's budgets created or updated at or after since
+	FindByUserIDUpdatedSince(ctx context.Context, userID UserID, since time.Time) ([]*Budget, error)
+	// Delete deletes a budget and leaves a tombstone for it
 	Delete(ctx context.Context, id BudgetID) error
 	// Dashboard stats methods
 	GetTotalCount(ctx context.Context) (int, error)
@@ -64,6 +101,181 @@ type RecurringTransactionRepository interface {
 	FindByID(ctx context.Context, id RecurringTransactionID) (*RecurringTransaction, error)
 	FindByUserID(ctx context.Context, userID UserID) ([]*RecurringTransaction, error)
 	FindActiveByUserID(ctx context.Context, userID UserID) ([]*RecurringTransaction, error)
-	FindDueTransactions(ctx context.Context) ([]*RecurringTransaction, error)
+	// FindDueTransactions returns the active recurring transactions whose next occurrence is on or before at
+	FindDueTransactions(ctx context.Context, at time.Time) ([]*RecurringTransaction, error)
+	// FindUpcomingForReminders returns the active recurring transactions whose next
+	// occurrence falls between from and to, of users who want recurring reminders
+	FindUpcomingForReminders(ctx context.Context, from, to time.Time) ([]*RecurringTransaction, error)
 	Delete(ctx context.Context, id RecurringTransactionID) error
 }
+
+// ScheduledTransactionRepository defines the contract for scheduled transaction persistence
+type ScheduledTransactionRepository interface {
+	Save(ctx context.Context, scheduled *ScheduledTransaction) error
+	// FindByID finds a scheduled transaction, or returns ErrScheduledTransactionNotFound
+	FindByID(ctx context.Context, id ScheduledTransactionID) (*ScheduledTransaction, error)
+	// FindByUserID finds the user's scheduled transactions, soonest first
+	FindByUserID(ctx context.Context, userID UserID) ([]*ScheduledTransaction, error)
+	// FindDue finds the transactions still waiting to post dated on or before date
+	FindDue(ctx context.Context, date time.Time) ([]*ScheduledTransaction, error)
+}
+
+// AnomalyRepository defines the contract for persisting flagged anomalies
+type AnomalyRepository interface {
+	Save(ctx context.Context, anomaly *Anomaly) error
+	// FindByUserIDSince returns the anomalies flagged for a user at or after since
+	FindByUserIDSince(ctx context.Context, userID UserID, since time.Time) ([]*Anomaly, error)
+}
+
+// SpendingBenchmarkRepository defines the contract for reading the spending that
+// users who opted in to benchmarks share with each other
+type SpendingBenchmarkRepository interface {
+	// SumByUserAndCategory totals the expenses dated within the range in currencies
+	// with the code, per opted-in active user and default category
+	SumByUserAndCategory(ctx context.Context, currencyCode string, startDate, endDate time.Time) ([]CategorySpend, error)
+}
+
+// AnalyticsRepository defines the contract for the analytics read model
+type AnalyticsRepository interface {
+	// Replace swaps the user's read model for a freshly projected one
+	Replace(ctx context.Context, projection *AnalyticsProjection) error
+	// FindProjectedAt returns when the user's read model was last projected, or nil
+	// when it never has been
+	FindProjectedAt(ctx context.Context, userID UserID) (*time.Time, error)
+	// FindProjectedUserIDs returns the users who have a read model
+	FindProjectedUserIDs(ctx context.Context) ([]UserID, error)
+	// FindDailyTotals returns the user's totals dated within the range, oldest first
+	FindDailyTotals(ctx context.Context, userID UserID, startDate, endDate time.Time) ([]DailyTotal, error)
+	// FindCategoryTotals returns the user's category totals dated within the range, oldest first
+	FindCategoryTotals(ctx context.Context, userID UserID, startDate, endDate time.Time) ([]CategoryTotal, error)
+	// FindBudgetProgress returns the progress of the user's budgets overlapping the range
+	FindBudgetProgress(ctx context.Context, userID UserID, startDate, endDate time.Time) ([]BudgetProgress, error)
+}
+
+// TombstoneRepository defines the contract for reading the tombstones that
+// repositories leave when they delete a user's records
+type TombstoneRepository interface {
+	// FindByUserIDSince returns the user's tombstones left at or after since, oldest first
+	FindByUserIDSince(ctx context.Context, userID UserID, since time.Time) ([]*Tombstone, error)
+}
+
+// ClosedMonthRepository defines the contract for the months users have closed
+type ClosedMonthRepository interface {
+	Save(ctx context.Context, month *ClosedMonth) error
+	// FindByUserID returns the user's closed months, latest first
+	FindByUserID(ctx context.Context, userID UserID) ([]*ClosedMonth, error)
+	// IsClosed reports whether the user has closed the month date falls in
+	IsClosed(ctx context.Context, userID UserID, date time.Time) (bool, error)
+	// Delete reopens the month date falls in, or returns ErrClosedMonthNotFound
+	Delete(ctx context.Context, userID UserID, date time.Time) error
+}
+
+// DefaultBudgetRepository defines the contract for the default budget template
+type DefaultBudgetRepository interface {
+	// FindAll returns the template, in the order it was saved
+	FindAll(ctx context.Context) ([]*DefaultBudget, error)
+	// ReplaceAll replaces the whole template
+	ReplaceAll(ctx context.Context, budgets []*DefaultBudget) error
+}
+
+// ExchangeRateRepository defines the contract for exchange rate persistence
+type ExchangeRateRepository interface {
+	// Save stores a rate, replacing any rate of the same pair on the same date
+	Save(ctx context.Context, rate *ExchangeRate) error
+	// FindByPair returns the rates converting base into quote, oldest first
+	FindByPair(ctx context.Context, base, quote string) ([]*ExchangeRate, error)
+}
+
+// ActionRepository defines the contract for the audit log of undoable changes
+type ActionRepository interface {
+	Save(ctx context.Context, action *Action) error
+	FindByID(ctx context.Context, id ActionID) (*Action, error)
+	// FindLatestByTarget returns the newest action on a record that has not been undone, or ErrActionNotFound
+	FindLatestByTarget(ctx context.Context, target ActionTarget, targetID int) (*Action, error)
+	// FindByUserIDSince returns the user's actions created after since, newest first
+	FindByUserIDSince(ctx context.Context, userID UserID, since time.Time) ([]*Action, error)
+	// DeleteBefore deletes the actions created before cutoff and returns how many were deleted
+	DeleteBefore(ctx context.Context, cutoff time.Time) (int, error)
+	// DeleteByUserIDBefore deletes the user's actions created before cutoff and returns how many were deleted
+	DeleteByUserIDBefore(ctx context.Context, userID UserID, cutoff time.Time) (int, error)
+}
+
+// AccountRepository defines the contract for account persistence
+type AccountRepository interface {
+	Save(ctx context.Context, account *Account) error
+	FindByID(ctx context.Context, id AccountID) (*Account, error)
+	FindByUserID(ctx context.Context, userID UserID) ([]*Account, error)
+	// FindWithBillingCycle returns every account with a billing cycle
+	FindWithBillingCycle(ctx context.Context) ([]*Account, error)
+}
+
+// BalanceAssertionRepository defines the contract for balance assertion persistence
+type BalanceAssertionRepository interface {
+	Save(ctx context.Context, assertion *BalanceAssertion) error
+	FindByID(ctx context.Context, id BalanceAssertionID) (*BalanceAssertion, error)
+	// FindByAccountID returns the account's assertions, oldest date first
+	FindByAccountID(ctx context.Context, accountID AccountID) ([]*BalanceAssertion, error)
+	Delete(ctx context.Context, id BalanceAssertionID) error
+}
+
+// ReceiptLineItemRepository defines the contract for receipt line item persistence
+type ReceiptLineItemRepository interface {
+	// FindByExpenseID returns the expense's line items in receipt order
+	FindByExpenseID(ctx context.Context, expenseID TransactionID) ([]*ReceiptLineItem, error)
+	// ReplaceForExpense replaces all of the expense's line items
+	ReplaceForExpense(ctx context.Context, expenseID TransactionID, userID UserID, items []*ReceiptLineItem) error
+}
+
+// HoldingRepository defines the contract for investment holding persistence
+type HoldingRepository interface {
+	Save(ctx context.Context, holding *Holding) error
+	FindByID(ctx context.Context, id HoldingID) (*Holding, error)
+	FindByUserID(ctx context.Context, userID UserID) ([]*Holding, error)
+	// FindAll returns every user's holdings, for refreshing their prices
+	FindAll(ctx context.Context) ([]*Holding, error)
+	// Delete deletes a holding with its snapshots
+	Delete(ctx context.Context, id HoldingID) error
+}
+
+// HoldingSnapshotRepository defines the contract for holding snapshot persistence
+type HoldingSnapshotRepository interface {
+	// Save stores a snapshot, replacing any snapshot of the same holding on the same date
+	Save(ctx context.Context, snapshot *HoldingSnapshot) error
+	// FindByHoldingID returns the holding's snapshots, oldest first
+	FindByHoldingID(ctx context.Context, holdingID HoldingID) ([]*HoldingSnapshot, error)
+}
+
+// BalanceAdjustmentRepository defines the contract for balance adjustment persistence
+type BalanceAdjustmentRepository interface {
+	Save(ctx context.Context, adjustment *BalanceAdjustment) error
+	FindByID(ctx context.Context, id BalanceAdjustmentID) (*BalanceAdjustment, error)
+	// FindByAccountID returns the account's adjustments, oldest date first
+	FindByAccountID(ctx context.Context, accountID AccountID) ([]*BalanceAdjustment, error)
+	// SumByAccount adds up the account's adjustments dated within the range
+	SumByAccount(ctx context.Context, accountID AccountID, startDate, endDate time.Time) (float64, error)
+	Delete(ctx context.Context, id BalanceAdjustmentID) error
+}
+
+// ExportScheduleRepository defines the contract for export schedule persistence
+type ExportScheduleRepository interface {
+	Save(ctx context.Context, schedule *ExportSchedule) error
+	FindByID(ctx context.Context, id ExportScheduleID) (*ExportSchedule, error)
+	FindByUserID(ctx context.Context, userID UserID) ([]*ExportSchedule, error)
+	// FindDue returns the schedules whose next run is at or before the given time
+	FindDue(ctx context.Context, at time.Time) ([]*ExportSchedule, error)
+	Delete(ctx context.Context, id ExportScheduleID) error
+}
+
+// ExportRunRepository defines the contract for export history persistence
+type ExportRunRepository interface {
+	Save(ctx context.Context, run *ExportRun) error
+	// FindByUserID returns the user's most recent runs, newest first
+	FindByUserID(ctx context.Context, userID UserID, limit int) ([]*ExportRun, error)
+}
+
+// TaxCategoryRepository defines the contract for persisting which categories a user
+// claims as tax-deductible; default categories are shared, so the marks are kept per user
+type TaxCategoryRepository interface {
+	SetDeductible(ctx context.Context, userID UserID, categoryID CategoryID, deductible bool) error
+	FindDeductibleCategoryIDs(ctx context.Context, userID UserID) ([]CategoryID, error)
+}
@@ -0,0 +1,172 @@
+package finance
+
+import "time"
+
+// ScheduledStatus is where a scheduled transaction is in its life
+type ScheduledStatus string
+
+const (
+	// ScheduledStatusScheduled waits for its date; it is not yet a transaction
+	ScheduledStatusScheduled ScheduledStatus = "scheduled"
+	// ScheduledStatusPosted was recorded as a transaction on its date
+	ScheduledStatusPosted ScheduledStatus = "posted"
+	// ScheduledStatusCancelled was cancelled before its date and never posts
+	ScheduledStatusCancelled ScheduledStatus = "cancelled"
+)
+
+// ScheduledTransaction is a transaction recorded ahead of its date. Until the
+// scheduler posts it on that date it is kept apart from the user's transactions,
+// so analytics and budgets do not count it.
+type ScheduledTransaction struct {
+	id              ScheduledTransactionID
+	userID          UserID
+	categoryID      CategoryID
+	currencyID      CurrencyID
+	amount          Money
+	description     string
+	date            time.Time
+	transactionType TransactionType
+	status          ScheduledStatus
+	transactionID   TransactionID // the transaction it posted as; zero until posted
+	createdAt       time.Time
+}
+
+// ScheduledTransactionID is a value object representing a scheduled transaction identifier
+type ScheduledTransactionID struct {
+	value int
+}
+
+func NewScheduledTransactionID(id int) ScheduledTransactionID {
+	return ScheduledTransactionID{value: id}
+}
+
+func (s ScheduledTransactionID) Value() int {
+	return s.value
+}
+
+// NewScheduledTransaction schedules a transaction for a date after today, in UTC
+func NewScheduledTransaction(
+	userID UserID,
+	categoryID CategoryID,
+	currencyID CurrencyID,
+	amount Money,
+	description string,
+	date time.Time,
+	transactionType TransactionType,
+	now time.Time,
+) (*ScheduledTransaction, error) {
+	if !date.After(startOfDay(now.UTC())) {
+		return nil, ErrScheduledDateNotFuture
+	}
+
+	return &ScheduledTransaction{
+		userID:          userID,
+		categoryID:      categoryID,
+		currencyID:      currencyID,
+		amount:          amount,
+		description:     description,
+		date:            date,
+		transactionType: transactionType,
+		status:          ScheduledStatusScheduled,
+		createdAt:       now,
+	}, nil
+}
+
+// RestoreScheduledTransaction rebuilds a persisted scheduled transaction
+func RestoreScheduledTransaction(
+	id ScheduledTransactionID,
+	userID UserID,
+	categoryID CategoryID,
+	currencyID CurrencyID,
+	amount Money,
+	description string,
+	date time.Time,
+	transactionType TransactionType,
+	status ScheduledStatus,
+	transactionID TransactionID,
+	createdAt time.Time,
+) *ScheduledTransaction {
+	return &ScheduledTransaction{
+		id:              id,
+		userID:          userID,
+		categoryID:      categoryID,
+		currencyID:      currencyID,
+		amount:          amount,
+		description:     description,
+		date:            date,
+		transactionType: transactionType,
+		status:          status,
+		transactionID:   transactionID,
+		createdAt:       createdAt,
+	}
+}
+
+// Getters
+func (s *ScheduledTransaction) ID() ScheduledTransactionID {
+	return s.id
+}
+
+func (s *ScheduledTransaction) UserID() UserID {
+	return s.userID
+}
+
+func (s *ScheduledTransaction) CategoryID() CategoryID {
+	return s.categoryID
+}
+
+func (s *ScheduledTransaction) CurrencyID() CurrencyID {
+	return s.currencyID
+}
+
+func (s *ScheduledTransaction) Amount() Money {
+	return s.amount
+}
+
+func (s *ScheduledTransaction) Description() string {
+	return s.description
+}
+
+func (s *ScheduledTransaction) Date() time.Time {
+	return s.date
+}
+
+func (s *ScheduledTransaction) Type() TransactionType {
+	return s.transactionType
+}
+
+func (s *ScheduledTransaction) Status() ScheduledStatus {
+	return s.status
+}
+
+// TransactionID returns the transaction it posted as, zero until it posts
+func (s *ScheduledTransaction) TransactionID() TransactionID {
+	return s.transactionID
+}
+
+func (s *ScheduledTransaction) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// AssignID sets the ID given by the repository on save
+func (s *ScheduledTransaction) AssignID(id ScheduledTransactionID) {
+	s.id = id
+}
+
+// Cancel stops the transaction from posting; only one still waiting can be cancelled
+func (s *ScheduledTransaction) Cancel() error {
+	if s.status != ScheduledStatusScheduled {
+		return ErrScheduledTransactionClosed
+	}
+	s.status = ScheduledStatusCancelled
+	return nil
+}
+
+// MarkPosted records the transaction it was posted as
+func (s *ScheduledTransaction) MarkPosted(transactionID TransactionID) error {
+	if s.status != ScheduledStatusScheduled {
+		return ErrScheduledTransactionClosed
+	}
+	s.status = ScheduledStatusPosted
+	s.transactionID = transactionID
+	return nil
+}
@@ -11,6 +11,14 @@ type TransactionService struct {
 	transactionRepo TransactionRepository
 	categoryRepo    CategoryRepository
 	currencyRepo    CurrencyRepository
+	budgetRepo      BudgetRepository
+	accountRepo     AccountRepository
+	actionRepo      ActionRepository
+	closedMonthRepo ClosedMonthRepository
+	events          EventPublisher
+	futureDates     FutureDatePolicy
+	scheduledRepo   ScheduledTransactionRepository
+	recurringRepo   RecurringTransactionRepository
 }
 
 // NewTransactionService creates a new transaction service
@@ -18,14 +26,60 @@ func NewTransactionService(
 	transactionRepo TransactionRepository,
 	categoryRepo CategoryRepository,
 	currencyRepo CurrencyRepository,
+	budgetRepo BudgetRepository,
+	accountRepo AccountRepository,
+	actionRepo ActionRepository,
+	closedMonthRepo ClosedMonthRepository,
+	events EventPublisher,
 ) *TransactionService {
 	return &TransactionService{
 		transactionRepo: transactionRepo,
 		categoryRepo:    categoryRepo,
 		currencyRepo:    currencyRepo,
+		budgetRepo:      budgetRepo,
+		accountRepo:     accountRepo,
+		actionRepo:      actionRepo,
+		closedMonthRepo: closedMonthRepo,
+		events:          events,
+		futureDates:     FutureDatesAllow,
 	}
 }
 
+// WithFutureDatePolicy sets what happens to transactions dated after today;
+// they are allowed by default
+func (s *TransactionService) WithFutureDatePolicy(policy FutureDatePolicy) *TransactionService {
+	s.futureDates = policy
+	return s
+}
+
+// WithScheduledTransactions sets the repository transactions scheduled for a
+// later date are kept in until they post
+func (s *TransactionService) WithScheduledTransactions(scheduledRepo ScheduledTransactionRepository) *TransactionService {
+	s.scheduledRepo = scheduledRepo
+	return s
+}
+
+// WithRecurringTransactions sets the repository of the recurring transactions
+// the service posts occurrences of
+func (s *TransactionService) WithRecurringTransactions(recurringRepo RecurringTransactionRepository) *TransactionService {
+	s.recurringRepo = recurringRepo
+	return s
+}
+
+// SchedulesDate reports whether the future date policy schedules a transaction
+// dated date to post on that date instead of recording it now
+func (s *TransactionService) SchedulesDate(date time.Time) bool {
+	return s.futureDates == FutureDatesSchedule && IsFutureDate(date, time.Now())
+}
+
+// checkFutureDate applies the future date policy to a transaction date
+func (s *TransactionService) checkFutureDate(date time.Time) error {
+	if s.futureDates == FutureDatesReject && IsFutureDate(date, time.Now()) {
+		return ErrFutureTransactionDate
+	}
+	return nil
+}
+
 // CreateTransaction creates a new transaction
 func (s *TransactionService) CreateTransaction(
 	ctx context.Context,
@@ -36,32 +90,28 @@ func (s *TransactionService) CreateTransaction(
 	description string,
 	date time.Time,
 	transactionType TransactionType,
+	accountID AccountID,
+	location *Location,
+	clientID string,
 ) (*Transaction, error) {
-	// Validate category exists and user has access
-	category, err := s.categoryRepo.FindByID(ctx, categoryID)
-	if err != nil {
-		return nil, errors.New("category not found")
+	// A create retried by an offline client returns the transaction it already made
+	if clientID != "" {
+		existing, err := s.findByClientID(ctx, userID, clientID, transactionType)
+		if !errors.Is(err, ErrTransactionNotFound) {
+			return existing, err
+		}
 	}
 
-	// Check if user has access to category (default or user's own)
-	if !category.IsDefault() && (category.UserID() == nil || category.UserID().Value() != userID.Value()) {
-		return nil, errors.New("access denied to category")
+	if err := s.checkFutureDate(date); err != nil {
+		return nil, err
 	}
-
-	// Validate category type matches transaction type
-	if category.Type() != CategoryType(transactionType) {
-		return nil, errors.New("category type does not match transaction type")
+	if err := ensureMonthsOpen(ctx, s.closedMonthRepo, userID, date); err != nil {
+		return nil, err
 	}
 
-	// Validate currency exists and user has access
-	currency, err := s.currencyRepo.FindByID(ctx, currencyID)
+	categoryID, amount, err := s.checkCategoryAndCurrency(ctx, userID, categoryID, currencyID, amount, transactionType)
 	if err != nil {
-		return nil, errors.New("currency not found")
-	}
-
-	// Check if user has access to currency (default or user's own)
-	if !currency.IsDefault() && (currency.UserID() == nil || currency.UserID().Value() != userID.Value()) {
-		return nil, errors.New("access denied to currency")
+		return nil, err
 	}
 
 	// Create transaction
@@ -75,20 +125,316 @@ func (s *TransactionService) CreateTransaction(
 		date,
 		transactionType,
 	)
+	transaction.UpdateLocation(location)
+	transaction.AssignClientID(clientID)
+
+	// Validate the account, when given, belongs to the user and is still open
+	if !accountID.IsZero() {
+		account, err := s.accountRepo.FindByID(ctx, accountID)
+		if err != nil {
+			return nil, err
+		}
+		if !account.BelongsTo(userID) {
+			return nil, ErrAccountNotFound
+		}
+		if account.IsArchived() {
+			return nil, ErrAccountArchived
+		}
+		transaction.AssignAccount(accountID)
+	}
 
-	// Save transaction
+	// Save transaction; a retry racing this create past the check above may have saved it first
 	if err := s.transactionRepo.Save(ctx, transaction); err != nil {
+		if errors.Is(err, ErrDuplicateClientID) {
+			return s.findByClientID(ctx, userID, clientID, transactionType)
+		}
+		return nil, err
+	}
+
+	// Announce the new transaction and any budgets it pushed over
+	events := []Event{NewTransactionCreated(transaction)}
+	exceeded, err := s.exceededBudgets(ctx, transaction)
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, exceeded...)
+	if err := s.events.Publish(ctx, events...); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// findByClientID finds the transaction the user created under the client ID,
+// which must be of the given type
+func (s *TransactionService) findByClientID(ctx context.Context, userID UserID, clientID string, transactionType TransactionType) (*Transaction, error) {
+	existing, err := s.transactionRepo.FindByClientID(ctx, userID, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Type() != transactionType {
+		return nil, ErrClientIDInUse
+	}
+	return existing, nil
+}
+
+// checkCategoryAndCurrency checks the user may record a transaction of the type
+// in the category and currency. It returns the category to record it under, the
+// user's copy of a default one, and the amount in the currency's minor units.
+func (s *TransactionService) checkCategoryAndCurrency(
+	ctx context.Context,
+	userID UserID,
+	categoryID CategoryID,
+	currencyID CurrencyID,
+	amount Money,
+	transactionType TransactionType,
+) (CategoryID, Money, error) {
+	// Validate category exists and user has access
+	category, err := categoryForUser(ctx, s.categoryRepo, userID, categoryID)
+	if err != nil {
+		return CategoryID{}, Money{}, err
+	}
+
+	// Validate category type matches transaction type
+	if category.Type() != CategoryType(transactionType) {
+		return CategoryID{}, Money{}, ErrCategoryTypeMismatch
+	}
+
+	// Validate currency exists and user has access
+	currency, err := s.currencyRepo.FindByID(ctx, currencyID)
+	if err != nil {
+		return CategoryID{}, Money{}, ErrCurrencyNotFound
+	}
+
+	// Check if user has access to currency (default or user's own)
+	if !currency.IsDefault() && (currency.UserID() == nil || currency.UserID().Value() != userID.Value()) {
+		return CategoryID{}, Money{}, ErrCurrencyAccessDenied
+	}
+
+	// Keep the amount to the currency's minor units
+	amount, err = amount.In(currency)
+	if err != nil {
+		return CategoryID{}, Money{}, err
+	}
+	return category.ID(), amount, nil
+}
+
+// ScheduleTransaction records a transaction to post on a date after today. It is
+// not a transaction, and is left out of analytics and budgets, until it posts.
+func (s *TransactionService) ScheduleTransaction(
+	ctx context.Context,
+	userID UserID,
+	categoryID CategoryID,
+	currencyID CurrencyID,
+	amount Money,
+	description string,
+	date time.Time,
+	transactionType TransactionType,
+) (*ScheduledTransaction, error) {
+	categoryID, amount, err := s.checkCategoryAndCurrency(ctx, userID, categoryID, currencyID, amount, transactionType)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled, err := NewScheduledTransaction(userID, categoryID, currencyID, amount, description, date, transactionType, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.scheduledRepo.Save(ctx, scheduled); err != nil {
+		return nil, err
+	}
+	return scheduled, nil
+}
+
+// GetScheduledTransactions returns the user's scheduled transactions, soonest first
+func (s *TransactionService) GetScheduledTransactions(ctx context.Context, userID UserID) ([]*ScheduledTransaction, error) {
+	return s.scheduledRepo.FindByUserID(ctx, userID)
+}
+
+// CancelScheduledTransaction stops one of the user's scheduled transactions from posting
+func (s *TransactionService) CancelScheduledTransaction(ctx context.Context, id ScheduledTransactionID, userID UserID) (*ScheduledTransaction, error) {
+	scheduled, err := s.scheduledRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if scheduled.UserID().Value() != userID.Value() {
+		return nil, ErrScheduledTransactionNotFound
+	}
+
+	if err := scheduled.Cancel(); err != nil {
+		return nil, err
+	}
+	if err := s.scheduledRepo.Save(ctx, scheduled); err != nil {
+		return nil, err
+	}
+	return scheduled, nil
+}
+
+// GetDueScheduledTransactions returns the scheduled transactions waiting to post whose date has come
+func (s *TransactionService) GetDueScheduledTransactions(ctx context.Context, at time.Time) ([]*ScheduledTransaction, error) {
+	return s.scheduledRepo.FindDue(ctx, startOfDay(at.UTC()))
+}
+
+// PostScheduledTransaction records a due scheduled transaction as a transaction
+// on its date, with the same checks as any new transaction
+func (s *TransactionService) PostScheduledTransaction(ctx context.Context, scheduled *ScheduledTransaction) (*Transaction, error) {
+	if scheduled.Status() != ScheduledStatusScheduled {
+		return nil, ErrScheduledTransactionClosed
+	}
+
+	transaction, err := s.CreateTransaction(
+		ctx,
+		scheduled.UserID(),
+		scheduled.CategoryID(),
+		scheduled.CurrencyID(),
+		scheduled.Amount(),
+		scheduled.Description(),
+		scheduled.Date(),
+		scheduled.Type(),
+		AccountID{},
+		nil,
+		"",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := scheduled.MarkPosted(transaction.ID()); err != nil {
+		return nil, err
+	}
+	if err := s.scheduledRepo.Save(ctx, scheduled); err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// GetDueRecurringTransactions returns the active recurring transactions whose next occurrence has come
+func (s *TransactionService) GetDueRecurringTransactions(ctx context.Context, at time.Time) ([]*RecurringTransaction, error) {
+	return s.recurringRepo.FindDueTransactions(ctx, startOfDay(at.UTC()))
+}
+
+// PostRecurringOccurrence records the next occurrence of a recurring transaction
+// as an expense or income, by its category's type, with the same checks as any
+// new transaction. The schedule then moves on to the following occurrence,
+// deactivating once an end condition is reached.
+func (s *TransactionService) PostRecurringOccurrence(ctx context.Context, recurring *RecurringTransaction) (*Transaction, error) {
+	if !recurring.IsActive() {
+		return nil, ErrRecurringTransactionInactive
+	}
+	category, err := s.categoryRepo.FindByID(ctx, recurring.CategoryID())
+	if err != nil {
+		return nil, ErrCategoryNotFound
+	}
+
+	occurrence := recurring.NextOccurrence()
+	transaction, err := s.CreateTransaction(
+		ctx,
+		recurring.UserID(),
+		recurring.CategoryID(),
+		recurring.CurrencyID(),
+		occurrence.Amount,
+		recurring.Description(),
+		occurrence.Date,
+		TransactionType(category.Type()),
+		AccountID{},
+		nil,
+		"",
+	)
+	if err != nil {
 		return nil, err
 	}
 
+	recurring.RecordOccurrencePosted()
+	if err := s.recurringRepo.Save(ctx, recurring); err != nil {
+		return nil, err
+	}
 	return transaction, nil
 }
 
+// exceededBudgets returns a BudgetExceeded event for every budget of the
+// transaction's category that this expense took over its amount
+func (s *TransactionService) exceededBudgets(ctx context.Context, transaction *Transaction) ([]Event, error) {
+	if transaction.Type() != TransactionTypeExpense {
+		return nil, nil
+	}
+
+	budgets, err := s.budgetRepo.FindByUserIDAndCategory(ctx, transaction.UserID(), transaction.CategoryID())
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	for _, budget := range budgets {
+		if transaction.Date().Before(budget.StartDate()) || transaction.Date().After(budget.EndDate()) {
+			continue
+		}
+
+		transactions, err := s.transactionRepo.FindByUserIDAndDateRange(ctx, budget.UserID(), budget.StartDate(), budget.EndDate())
+		if err != nil {
+			return nil, err
+		}
+
+		var spent float64
+		for _, t := range transactions {
+			if t.CategoryID().Value() == budget.CategoryID().Value() && t.Type() == TransactionTypeExpense {
+				spent += t.Amount().Amount()
+			}
+		}
+
+		// Only the transaction that crosses the limit raises the event
+		budgeted := budget.Allowance()
+		if spent <= budgeted || spent-transaction.Amount().Amount() > budgeted {
+			continue
+		}
+
+		events = append(events, BudgetExceeded{
+			BudgetID:          budget.ID().Value(),
+			UserID:            budget.UserID().Value(),
+			CategoryID:        budget.CategoryID().Value(),
+			TransactionID:     transaction.ID().Value(),
+			TransactionAmount: transaction.Amount().Amount(),
+			TransactionDate:   transaction.Date(),
+			Budgeted:          budgeted,
+			Spent:             spent,
+			Overspend:         spent - budgeted,
+			PeriodStart:       budget.StartDate(),
+			PeriodEnd:         budget.EndDate(),
+			At:                time.Now(),
+		})
+	}
+	return events, nil
+}
+
 // GetTransactionsByUser retrieves all transactions for a user
 func (s *TransactionService) GetTransactionsByUser(ctx context.Context, userID UserID) ([]*Transaction, error) {
 	return s.transactionRepo.FindByUserID(ctx, userID)
 }
 
+// GetTransaction retrieves one of the user's transactions. Expenses and incomes are
+// numbered separately, so an empty type looks in expenses first.
+func (s *TransactionService) GetTransaction(
+	ctx context.Context,
+	transactionID TransactionID,
+	userID UserID,
+	transactionType TransactionType,
+) (*Transaction, error) {
+	var transaction *Transaction
+	var err error
+	if transactionType == "" {
+		transaction, err = s.transactionRepo.FindByID(ctx, transactionID)
+	} else {
+		transaction, err = s.transactionRepo.FindByIDAndType(ctx, transactionID, transactionType)
+	}
+	if err != nil {
+		return nil, ErrTransactionNotFound
+	}
+
+	if transaction.UserID().Value() != userID.Value() {
+		return nil, ErrAccessDenied
+	}
+	return transaction, nil
+}
+
 // GetTransactionsByUserAndDateRange retrieves transactions for a user within a date range
 func (s *TransactionService) GetTransactionsByUserAndDateRange(
 	ctx context.Context,
@@ -107,6 +453,13 @@ func (s *TransactionService) GetTransactionsByUserWithFilters(
 	return s.transactionRepo.FindByUserIDWithFilters(ctx, userID, filters)
 }
 
+// GetCategoryStats counts the user's transactions in each category and totals
+// those dated in the month containing now
+func (s *TransactionService) GetCategoryStats(ctx context.Context, userID UserID, now time.Time) (map[CategoryID]CategoryStats, error) {
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return s.transactionRepo.GetCategoryStats(ctx, userID, monthStart, monthStart.AddDate(0, 1, 0))
+}
+
 // UpdateTransaction updates a transaction
 func (s *TransactionService) UpdateTransaction(
 	ctx context.Context,
@@ -118,44 +471,67 @@ func (s *TransactionService) UpdateTransaction(
 	description string,
 	date time.Time,
 	expectedType TransactionType,
+	expectedVersion *int,
 ) (*Transaction, error) {
 	// Get transaction to verify ownership, querying the correct table first based on expected type
 	transaction, err := s.transactionRepo.FindByIDAndType(ctx, transactionID, expectedType)
 	if err != nil {
-		return nil, errors.New("transaction not found")
+		return nil, ErrTransactionNotFound
 	}
 
 	if transaction.UserID().Value() != userID.Value() {
-		return nil, errors.New("access denied")
+		return nil, ErrAccessDenied
 	}
 
 	// Verify transaction type matches expected type (double-check for safety)
 	if transaction.Type() != expectedType {
-		return nil, errors.New("transaction type mismatch")
+		return nil, ErrTransactionTypeMismatch
 	}
 
-	// Validate category exists and user has access
-	category, err := s.categoryRepo.FindByID(ctx, categoryID)
-	if err != nil {
-		return nil, errors.New("category not found")
+	// Refuse to overwrite changes made since the client read the transaction
+	if err := transaction.CheckVersion(expectedVersion); err != nil {
+		return nil, err
 	}
 
-	// Check if user has access to category (default or user's own)
-	if !category.IsDefault() && (category.UserID() == nil || category.UserID().Value() != userID.Value()) {
-		return nil, errors.New("access denied to category")
+	// Transactions already dated in the future may keep their date
+	if !date.Equal(transaction.Date()) {
+		if err := s.checkFutureDate(date); err != nil {
+			return nil, err
+		}
 	}
 
+	// Neither the month it is in nor the one it moves to may be closed
+	if err := ensureMonthsOpen(ctx, s.closedMonthRepo, userID, transaction.Date(), date); err != nil {
+		return nil, err
+	}
+
+	// Validate category exists and user has access
+	category, err := categoryForUser(ctx, s.categoryRepo, userID, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	categoryID = category.ID()
+
 	// Validate currency exists and user has access
 	currency, err := s.currencyRepo.FindByID(ctx, currencyID)
 	if err != nil {
-		return nil, errors.New("currency not found")
+		return nil, ErrCurrencyNotFound
 	}
 
 	// Check if user has access to currency (default or user's own)
 	if !currency.IsDefault() && (currency.UserID() == nil || currency.UserID().Value() != userID.Value()) {
-		return nil, errors.New("access denied to currency")
+		return nil, ErrCurrencyAccessDenied
 	}
 
+	// Keep the amount to the currency's minor units
+	amount, err = amount.In(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	// Record the previous state so the update can be undone
+	action := NewTransactionAction(ActionKindUpdate, transaction)
+
 	// Update transaction fields
 	if err := transaction.UpdateAmount(amount); err != nil {
 		return nil, err
@@ -171,6 +547,37 @@ func (s *TransactionService) UpdateTransaction(
 	if err := s.transactionRepo.Save(ctx, transaction); err != nil {
 		return nil, err
 	}
+	if err := s.actionRepo.Save(ctx, action); err != nil {
+		return nil, err
+	}
+	if err := s.events.Publish(ctx, NewTransactionUpdated(transaction)); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// UpdateTransactionStatus sets whether a transaction has cleared or been reconciled
+func (s *TransactionService) UpdateTransactionStatus(
+	ctx context.Context,
+	transactionID TransactionID,
+	userID UserID,
+	expectedType TransactionType,
+	status ReconciliationStatus,
+) (*Transaction, error) {
+	transaction, err := s.transactionRepo.FindByIDAndType(ctx, transactionID, expectedType)
+	if err != nil {
+		return nil, ErrTransactionNotFound
+	}
+
+	if transaction.UserID().Value() != userID.Value() {
+		return nil, ErrAccessDenied
+	}
+
+	transaction.UpdateStatus(status)
+	if err := s.transactionRepo.Save(ctx, transaction); err != nil {
+		return nil, err
+	}
 
 	return transaction, nil
 }
@@ -180,14 +587,29 @@ func (s *TransactionService) DeleteTransaction(ctx context.Context, transactionI
 	// Get transaction to verify ownership
 	transaction, err := s.transactionRepo.FindByID(ctx, transactionID)
 	if err != nil {
-		return errors.New("transaction not found")
+		return ErrTransactionNotFound
 	}
 
 	if transaction.UserID().Value() != userID.Value() {
-		return errors.New("access denied")
+		return ErrAccessDenied
+	}
+
+	if err := ensureMonthsOpen(ctx, s.closedMonthRepo, userID, transaction.Date()); err != nil {
+		return err
 	}
 
-	return s.transactionRepo.Delete(ctx, transactionID)
+	if err := s.transactionRepo.Delete(ctx, transactionID); err != nil {
+		return err
+	}
+	if err := s.actionRepo.Save(ctx, NewTransactionAction(ActionKindDelete, transaction)); err != nil {
+		return err
+	}
+	return s.events.Publish(ctx, TransactionDeleted{
+		TransactionID: transactionID.Value(),
+		UserID:        userID.Value(),
+		Type:          string(transaction.Type()),
+		At:            time.Now(),
+	})
 }
 
 // CategoryService handles category-related domain operations
@@ -247,7 +669,7 @@ func (s *CategoryService) GetCategoriesByUser(ctx context.Context, userID UserID
 
 	// Combine and return
 	allCategories := append(defaultCategories, userCategories...)
-	return allCategories, nil
+	return withoutCopiedDefaults(allCategories), nil
 }
 
 // GetCategoriesByUserAndType retrieves categories by user and type
@@ -256,7 +678,41 @@ func (s *CategoryService) GetCategoriesByUserAndType(
 	userID UserID,
 	categoryType CategoryType,
 ) ([]*Category, error) {
-	return s.categoryRepo.FindByUserIDAndType(ctx, userID, categoryType)
+	categories, err := s.categoryRepo.FindByUserIDAndType(ctx, userID, categoryType)
+	if err != nil {
+		return nil, err
+	}
+	return withoutCopiedDefaults(categories), nil
+}
+
+// CopyDefaultCategories gives the user their own copy of every default category
+// they do not have one of yet, and returns the copies made
+func (s *CategoryService) CopyDefaultCategories(ctx context.Context, userID UserID) ([]*Category, error) {
+	defaults, err := s.categoryRepo.FindDefaultCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var copies []*Category
+	for _, category := range defaults {
+		_, err := s.categoryRepo.FindCopy(ctx, userID, category.ID())
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, ErrCategoryNotFound) {
+			return nil, err
+		}
+
+		copied, err := category.CopyFor(userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.categoryRepo.Save(ctx, copied); err != nil {
+			return nil, err
+		}
+		copies = append(copies, copied)
+	}
+	return copies, nil
 }
 
 // GetCategoryByID retrieves a category by ID
@@ -273,19 +729,22 @@ func (s *CategoryService) UpdateCategory(
 	color string,
 	categoryType CategoryType,
 ) error {
-	// Get category
-	category, err := s.categoryRepo.FindByID(ctx, categoryID)
+	// Get category, or the user's copy when it is a default category
+	category, err := categoryForUser(ctx, s.categoryRepo, userID, categoryID)
+	if errors.Is(err, ErrCategoryAccessDenied) {
+		return ErrAccessDenied
+	}
 	if err != nil {
-		return errors.New("category not found")
+		return err
 	}
 
 	// Check if user can update this category
 	if category.IsDefault() {
-		return errors.New("cannot update default category")
+		return ErrDefaultCategoryImmutable
 	}
 
 	if category.UserID() == nil || category.UserID().Value() != userID.Value() {
-		return errors.New("access denied")
+		return ErrAccessDenied
 	}
 
 	// Update category
@@ -293,7 +752,9 @@ func (s *CategoryService) UpdateCategory(
 		return err
 	}
 
-	category.UpdateColor(color)
+	if err := category.UpdateColor(color); err != nil {
+		return err
+	}
 
 	// Save updated category
 	return s.categoryRepo.Save(ctx, category)
@@ -301,35 +762,40 @@ func (s *CategoryService) UpdateCategory(
 
 // DeleteCategory deletes a category
 func (s *CategoryService) DeleteCategory(ctx context.Context, categoryID CategoryID, userID UserID) error {
-	// Get category
-	category, err := s.categoryRepo.FindByID(ctx, categoryID)
+	// Get category, or the user's copy when it is a default category
+	category, err := categoryForUser(ctx, s.categoryRepo, userID, categoryID)
+	if errors.Is(err, ErrCategoryAccessDenied) {
+		return ErrAccessDenied
+	}
 	if err != nil {
-		return errors.New("category not found")
+		return err
 	}
 
 	// Check if user can delete this category
 	if !category.CanBeDeleted() {
-		return errors.New("cannot delete default category")
+		return ErrDefaultCategoryNotDeletable
 	}
 
 	if category.UserID() == nil || category.UserID().Value() != userID.Value() {
-		return errors.New("access denied")
+		return ErrAccessDenied
 	}
 
-	return s.categoryRepo.Delete(ctx, categoryID)
+	return s.categoryRepo.Delete(ctx, category.ID())
 }
 
 // BudgetService handles budget-related domain operations
 type BudgetService struct {
 	budgetRepo   BudgetRepository
 	categoryRepo CategoryRepository
+	actionRepo   ActionRepository
 }
 
 // NewBudgetService creates a new budget service
-func NewBudgetService(budgetRepo BudgetRepository, categoryRepo CategoryRepository) *BudgetService {
+func NewBudgetService(budgetRepo BudgetRepository, categoryRepo CategoryRepository, actionRepo ActionRepository) *BudgetService {
 	return &BudgetService{
 		budgetRepo:   budgetRepo,
 		categoryRepo: categoryRepo,
+		actionRepo:   actionRepo,
 	}
 }
 
@@ -346,17 +812,14 @@ func (s *BudgetService) CreateBudget(
 	amount Money,
 	period BudgetPeriod,
 	startDate time.Time,
+	prorated bool,
 ) (*Budget, error) {
 	// Validate category exists and user has access
-	category, err := s.categoryRepo.FindByID(ctx, categoryID)
+	category, err := categoryForUser(ctx, s.categoryRepo, userID, categoryID)
 	if err != nil {
-		return nil, errors.New("category not found")
-	}
-
-	// Check if user has access to category (default or user's own)
-	if !category.IsDefault() && (category.UserID() == nil || category.UserID().Value() != userID.Value()) {
-		return nil, errors.New("access denied to category")
+		return nil, err
 	}
+	categoryID = category.ID()
 
 	// Create budget
 	budget, err := NewBudget(
@@ -370,6 +833,7 @@ func (s *BudgetService) CreateBudget(
 	if err != nil {
 		return nil, err
 	}
+	budget.UpdateProration(prorated)
 
 	// Save budget
 	if err := s.budgetRepo.Save(ctx, budget); err != nil {
@@ -399,29 +863,36 @@ func (s *BudgetService) UpdateBudget(
 	period BudgetPeriod,
 	startDate time.Time,
 	endDate time.Time,
+	prorated *bool,
+	expectedVersion *int,
 ) (*Budget, error) {
 	// Get budget
 	budget, err := s.budgetRepo.FindByID(ctx, budgetID)
 	if err != nil {
-		return nil, errors.New("budget not found")
+		return nil, ErrBudgetNotFound
 	}
 
 	// Check if user can update this budget
 	if budget.UserID().Value() != userID.Value() {
-		return nil, errors.New("access denied")
+		return nil, ErrAccessDenied
 	}
 
+	// Refuse to overwrite changes made since the client read the budget
+	if err := budget.CheckVersion(expectedVersion); err != nil {
+		return nil, err
+	}
+
+	// Record the previous state so the update can be undone
+	action := NewBudgetAction(ActionKindUpdate, budget)
+
 	// Validate category exists and user has access (when changing category)
 	if categoryID.Value() != 0 {
-		category, err := s.categoryRepo.FindByID(ctx, categoryID)
+		category, err := categoryForUser(ctx, s.categoryRepo, userID, categoryID)
 		if err != nil {
-			return nil, errors.New("category not found")
-		}
-		if !category.IsDefault() && (category.UserID() == nil || category.UserID().Value() != userID.Value()) {
-			return nil, errors.New("access denied to category")
+			return nil, err
 		}
 		// Update the category ID directly on the aggregate
-		budget.categoryID = categoryID
+		budget.categoryID = category.ID()
 	}
 
 	// Update budget
@@ -429,6 +900,10 @@ func (s *BudgetService) UpdateBudget(
 		return nil, err
 	}
 
+	if prorated != nil {
+		budget.UpdateProration(*prorated)
+	}
+
 	if err := budget.UpdatePeriod(period); err != nil {
 		return nil, err
 	}
@@ -440,6 +915,9 @@ func (s *BudgetService) UpdateBudget(
 	if err := s.budgetRepo.Save(ctx, budget); err != nil {
 		return nil, err
 	}
+	if err := s.actionRepo.Save(ctx, action); err != nil {
+		return nil, err
+	}
 
 	return budget, nil
 }
@@ -449,12 +927,15 @@ func (s *BudgetService) DeleteBudget(ctx context.Context, budgetID BudgetID, use
 	// Get budget to verify ownership
 	budget, err := s.budgetRepo.FindByID(ctx, budgetID)
 	if err != nil {
-		return errors.New("budget not found")
+		return ErrBudgetNotFound
 	}
 
 	if budget.UserID().Value() != userID.Value() {
-		return errors.New("access denied")
+		return ErrAccessDenied
 	}
 
-	return s.budgetRepo.Delete(ctx, budgetID)
+	if err := s.budgetRepo.Delete(ctx, budgetID); err != nil {
+		return err
+	}
+	return s.actionRepo.Save(ctx, NewBudgetAction(ActionKindDelete, budget))
 }
@@ -0,0 +1,103 @@
+package finance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubTransactionRepository finds transactions by client ID. A racing
+// transaction is saved by "another request" as soon as Save is called, so
+// Save fails with ErrDuplicateClientID.
+type stubTransactionRepository struct {
+	TransactionRepository
+	existing *Transaction
+	racing   *Transaction
+}
+
+func (r *stubTransactionRepository) FindByClientID(ctx context.Context, userID UserID, clientID string) (*Transaction, error) {
+	if r.existing == nil {
+		return nil, ErrTransactionNotFound
+	}
+	return r.existing, nil
+}
+
+func (r *stubTransactionRepository) Save(ctx context.Context, transaction *Transaction) error {
+	if r.racing != nil {
+		r.existing = r.racing
+		return ErrDuplicateClientID
+	}
+	transaction.AssignID(NewTransactionID(1))
+	return nil
+}
+
+// stubCategoryRepository serves one category
+type stubCategoryRepository struct {
+	CategoryRepository
+	category *Category
+}
+
+func (r *stubCategoryRepository) FindByID(ctx context.Context, id CategoryID) (*Category, error) {
+	return r.category, nil
+}
+
+// openMonths reports every month as open
+type openMonths struct {
+	ClosedMonthRepository
+}
+
+func (openMonths) IsClosed(ctx context.Context, userID UserID, date time.Time) (bool, error) {
+	return false, nil
+}
+
+func TestTransactionServiceCreateTransactionClientID(t *testing.T) {
+	ctx := context.Background()
+	userID := NewUserID(1)
+	currency, err := NewCurrency(NewCurrencyID(7), &userID, "IDR", "Rupiah", "Rp", false)
+	require.NoError(t, err)
+	category, err := NewCategory(NewCategoryID(3), &userID, "Food", "", false, CategoryTypeExpense)
+	require.NoError(t, err)
+	amount, err := NewMoney(12.5, currency.ID())
+	require.NoError(t, err)
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	const clientID = "5b2c3e1a-8f0d-4c6b-9a7e-2d4f6a8b0c1e"
+
+	create := func(repo *stubTransactionRepository, transactionType TransactionType) (*Transaction, error) {
+		service := NewTransactionService(
+			repo,
+			&stubCategoryRepository{category: category},
+			&stubCurrencyRepository{currency: currency},
+			nil,
+			nil,
+			nil,
+			openMonths{},
+			&recordingPublisher{},
+		)
+		return service.CreateTransaction(ctx, userID, category.ID(), currency.ID(), amount, "lunch", date, transactionType, AccountID{}, nil, clientID)
+	}
+	saved := func(transactionType TransactionType) *Transaction {
+		transaction := NewTransaction(NewTransactionID(42), userID, category.ID(), currency.ID(), amount, "lunch", date, transactionType)
+		transaction.AssignClientID(clientID)
+		return transaction
+	}
+
+	t.Run("a retry returns the transaction already made", func(t *testing.T) {
+		transaction, err := create(&stubTransactionRepository{existing: saved(TransactionTypeExpense)}, TransactionTypeExpense)
+		require.NoError(t, err)
+		assert.Equal(t, 42, transaction.ID().Value())
+	})
+
+	t.Run("a retry saved first by a concurrent request returns that transaction", func(t *testing.T) {
+		transaction, err := create(&stubTransactionRepository{racing: saved(TransactionTypeExpense)}, TransactionTypeExpense)
+		require.NoError(t, err)
+		assert.Equal(t, 42, transaction.ID().Value())
+	})
+
+	t.Run("a client ID used by the other type conflicts", func(t *testing.T) {
+		_, err := create(&stubTransactionRepository{existing: saved(TransactionTypeIncome)}, TransactionTypeExpense)
+		assert.ErrorIs(t, err, ErrClientIDInUse)
+	})
+}
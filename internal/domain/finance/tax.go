@@ -0,0 +1,39 @@
+package finance
+
+import "time"
+
+// TaxReportEntry totals the deductible expenses of one category in one currency
+type TaxReportEntry struct {
+	CategoryID   CategoryID
+	CurrencyID   CurrencyID
+	Total        float64
+	Transactions []*Transaction
+}
+
+// TaxReport summarizes a user's tax-deductible expenses for a calendar year
+type TaxReport struct {
+	Year    int
+	Entries []*TaxReportEntry
+}
+
+// TaxYearRange returns the first and last instant of a calendar year
+func TaxYearRange(year int) (time.Time, time.Time, error) {
+	if year < 1900 || year > 9999 {
+		return time.Time{}, time.Time{}, ErrInvalidTaxYear
+	}
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(1, 0, 0).Add(-time.Nanosecond), nil
+}
+
+// MissingReceipts counts the deductible expenses without a receipt reference
+func (r *TaxReport) MissingReceipts() int {
+	missing := 0
+	for _, entry := range r.Entries {
+		for _, transaction := range entry.Transactions {
+			if transaction.ReceiptReference() == "" {
+				missing++
+			}
+		}
+	}
+	return missing
+}
@@ -0,0 +1,144 @@
+package finance
+
+import (
+	"context"
+	"sort"
+)
+
+// TaxService handles tax-deductible tagging and the annual tax report
+type TaxService struct {
+	taxCategoryRepo TaxCategoryRepository
+	categoryRepo    CategoryRepository
+	transactionRepo TransactionRepository
+}
+
+// NewTaxService creates a new tax service
+func NewTaxService(
+	taxCategoryRepo TaxCategoryRepository,
+	categoryRepo CategoryRepository,
+	transactionRepo TransactionRepository,
+) *TaxService {
+	return &TaxService{
+		taxCategoryRepo: taxCategoryRepo,
+		categoryRepo:    categoryRepo,
+		transactionRepo: transactionRepo,
+	}
+}
+
+// SetCategoryDeductible marks or unmarks an expense category as tax-deductible for the user.
+// Default categories can be marked too; the mark only applies to the user.
+func (s *TaxService) SetCategoryDeductible(ctx context.Context, userID UserID, categoryID CategoryID, deductible bool) (*Category, error) {
+	category, err := categoryForUser(ctx, s.categoryRepo, userID, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	if category.Type() != CategoryTypeExpense {
+		return nil, ErrIncomeNotDeductible
+	}
+
+	if err := s.taxCategoryRepo.SetDeductible(ctx, userID, category.ID(), deductible); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// GetDeductibleCategoryIDs returns the IDs of the categories the user marked as tax-deductible
+func (s *TaxService) GetDeductibleCategoryIDs(ctx context.Context, userID UserID) (map[int]bool, error) {
+	categoryIDs, err := s.taxCategoryRepo.FindDeductibleCategoryIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	deductible := make(map[int]bool, len(categoryIDs))
+	for _, categoryID := range categoryIDs {
+		deductible[categoryID.Value()] = true
+	}
+	return deductible, nil
+}
+
+// UpdateExpenseTaxDetails marks one of the user's expenses as tax-deductible and records its receipt reference
+func (s *TaxService) UpdateExpenseTaxDetails(
+	ctx context.Context,
+	transactionID TransactionID,
+	userID UserID,
+	deductible bool,
+	receiptReference string,
+) (*Transaction, error) {
+	transaction, err := s.transactionRepo.FindByIDAndType(ctx, transactionID, TransactionTypeExpense)
+	if err != nil {
+		return nil, ErrTransactionNotFound
+	}
+
+	if transaction.UserID().Value() != userID.Value() {
+		return nil, ErrAccessDenied
+	}
+
+	if err := transaction.UpdateTaxDetails(deductible, receiptReference); err != nil {
+		return nil, err
+	}
+	if err := s.transactionRepo.Save(ctx, transaction); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// GetTaxReport totals the user's deductible expenses for the year by category and currency.
+// An expense is deductible when it is marked itself or its category is marked; archived
+// expenses are included, since tax years are usually reported after the fact.
+func (s *TaxService) GetTaxReport(ctx context.Context, userID UserID, year int) (*TaxReport, error) {
+	startDate, endDate, err := TaxYearRange(year)
+	if err != nil {
+		return nil, err
+	}
+
+	deductibleCategories, err := s.GetDeductibleCategoryIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	expenseType := TransactionTypeExpense
+	transactions, _, err := s.transactionRepo.FindByUserIDWithFilters(ctx, userID, TransactionFilters{
+		TransactionType: &expenseType,
+		StartDate:       &startDate,
+		EndDate:         &endDate,
+		IncludeArchived: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	type entryKey struct{ categoryID, currencyID int }
+	entries := make(map[entryKey]*TaxReportEntry)
+	report := &TaxReport{Year: year, Entries: []*TaxReportEntry{}}
+	for _, transaction := range transactions {
+		if !transaction.TaxDeductible() && !deductibleCategories[transaction.CategoryID().Value()] {
+			continue
+		}
+
+		key := entryKey{transaction.CategoryID().Value(), transaction.CurrencyID().Value()}
+		entry, ok := entries[key]
+		if !ok {
+			entry = &TaxReportEntry{CategoryID: transaction.CategoryID(), CurrencyID: transaction.CurrencyID()}
+			entries[key] = entry
+			report.Entries = append(report.Entries, entry)
+		}
+		entry.Total = roundCents(entry.Total + transaction.Amount().Amount())
+		entry.Transactions = append(entry.Transactions, transaction)
+	}
+
+	sort.Slice(report.Entries, func(i, j int) bool {
+		a, b := report.Entries[i], report.Entries[j]
+		if a.CategoryID.Value() != b.CategoryID.Value() {
+			return a.CategoryID.Value() < b.CategoryID.Value()
+		}
+		return a.CurrencyID.Value() < b.CurrencyID.Value()
+	})
+	for _, entry := range report.Entries {
+		sort.SliceStable(entry.Transactions, func(i, j int) bool {
+			return entry.Transactions[i].Date().Before(entry.Transactions[j].Date())
+		})
+	}
+
+	return report, nil
+}
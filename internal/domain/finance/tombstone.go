@@ -0,0 +1,51 @@
+package finance
+
+import "time"
+
+// RecordKind names the kind of record a tombstone stands for. Expenses and
+// incomes are numbered separately, so a record is only identified by its kind
+// and ID together.
+type RecordKind string
+
+const (
+	RecordKindExpense  RecordKind = "expense"
+	RecordKindIncome   RecordKind = "income"
+	RecordKindBudget   RecordKind = "budget"
+	RecordKindCategory RecordKind = "category"
+)
+
+// Tombstone records that a user's record was deleted, so clients keeping an
+// offline copy learn to remove theirs when they next sync
+type Tombstone struct {
+	userID    UserID
+	kind      RecordKind
+	recordID  int
+	deletedAt time.Time
+}
+
+// RestoreTombstone rebuilds a persisted tombstone
+func RestoreTombstone(userID UserID, kind RecordKind, recordID int, deletedAt time.Time) *Tombstone {
+	return &Tombstone{
+		userID:    userID,
+		kind:      kind,
+		recordID:  recordID,
+		deletedAt: deletedAt,
+	}
+}
+
+// Getters
+func (t *Tombstone) UserID() UserID {
+	return t.userID
+}
+
+func (t *Tombstone) Kind() RecordKind {
+	return t.kind
+}
+
+func (t *Tombstone) RecordID() int {
+	return t.recordID
+}
+
+func (t *Tombstone) DeletedAt() time.Time {
+	return t.deletedAt
+}
@@ -2,7 +2,8 @@ package finance
 
 import (
 	"encoding/json"
-	"errors"
+	"math"
+	"strings"
 	"time"
 )
 
@@ -22,6 +23,14 @@ type TransactionFilters struct {
 	EndDate         *time.Time
 	Limit           int
 	Offset          int
+	// Search matches descriptions containing the text, ignoring case
+	Search string
+	// IncludeArchived also searches transactions moved to the archive
+	IncludeArchived bool
+	// AccountID keeps only the transactions recorded against the account
+	AccountID *AccountID
+	// Bounds keeps only the transactions with a location inside the box
+	Bounds *BoundingBox
 }
 
 // Transaction represents a financial transaction
@@ -34,7 +43,15 @@ type Transaction struct {
 	description     string
 	date            time.Time
 	transactionType TransactionType
+	accountID       AccountID // zero when not recorded against an account
+	status          ReconciliationStatus
+	taxDeductible   bool
+	receiptRef      string // reference to the receipt kept for tax purposes
 	createdAt       time.Time
+	version         int    // goes up by one on every save; zero until first saved
+	clientID        string // UUID an offline client created it under; empty when not given
+
+	location *Location // where it was made; nil when the client did not say
 }
 
 // TransactionID is a value object representing a transaction identifier
@@ -99,19 +116,43 @@ func (c CurrencyID) MarshalJSON() ([]byte, error) {
 	return json.Marshal(c.value)
 }
 
+// MaxAmount is the largest amount that fits the DECIMAL(10,2) amount columns
+const MaxAmount = 99_999_999.99
+
 // Money represents a monetary amount
 type Money struct {
 	amount   float64
 	currency CurrencyID
 }
 
+// NewMoney creates an amount rounded to cents, the precision amounts are stored with.
+// It rejects NaN, infinite, negative and too large amounts.
 func NewMoney(amount float64, currency CurrencyID) (Money, error) {
+	return newMoney(amount, currency, defaultMinorUnits)
+}
+
+// newMoney validates amount and rounds it to minorUnits decimal places
+func newMoney(amount float64, currency CurrencyID, minorUnits int) (Money, error) {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return Money{}, ErrInvalidAmount
+	}
 	if amount < 0 {
-		return Money{}, errors.New("amount cannot be negative")
+		return Money{}, ErrNegativeAmount
+	}
+
+	scale := math.Pow10(minorUnits)
+	amount = math.Round(amount*scale) / scale
+	if amount > MaxAmount {
+		return Money{}, ErrAmountTooLarge
 	}
 	return Money{amount: amount, currency: currency}, nil
 }
 
+// In returns the amount in currency, rounded to the currency's minor units
+func (m Money) In(currency *Currency) (Money, error) {
+	return newMoney(m.amount, currency.ID(), currency.MinorUnits())
+}
+
 func (m Money) Amount() float64 {
 	return m.amount
 }
@@ -140,6 +181,7 @@ func NewTransaction(
 		description:     description,
 		date:            date,
 		transactionType: transactionType,
+		status:          StatusUncleared,
 		createdAt:       time.Now(),
 	}
 }
@@ -177,14 +219,70 @@ func (t *Transaction) Type() TransactionType {
 	return t.transactionType
 }
 
+func (t *Transaction) AccountID() AccountID {
+	return t.accountID
+}
+
+func (t *Transaction) Status() ReconciliationStatus {
+	return t.status
+}
+
+func (t *Transaction) TaxDeductible() bool {
+	return t.taxDeductible
+}
+
+func (t *Transaction) ReceiptReference() string {
+	return t.receiptRef
+}
+
 func (t *Transaction) CreatedAt() time.Time {
 	return t.createdAt
 }
 
+// Location returns where the transaction was made, or nil
+func (t *Transaction) Location() *Location {
+	return t.location
+}
+
+// Version returns the version the transaction was stored at, for optimistic locking
+func (t *Transaction) Version() int {
+	return t.version
+}
+
+// AssignID sets the identifier given by the repository on first save
+func (t *Transaction) AssignID(id TransactionID) {
+	t.id = id
+}
+
+// ClientID returns the UUID the client created the transaction under, or an empty string
+func (t *Transaction) ClientID() string {
+	return t.clientID
+}
+
+// AssignClientID records the UUID the client created the transaction under, so
+// a create retried by an offline client is recognised instead of duplicated
+func (t *Transaction) AssignClientID(clientID string) {
+	t.clientID = clientID
+}
+
+// AssignVersion sets the version given by the repository when the transaction is read or saved
+func (t *Transaction) AssignVersion(version int) {
+	t.version = version
+}
+
+// CheckVersion returns ErrVersionConflict when the transaction has changed since
+// the client read it at expected; a nil expected version skips the check
+func (t *Transaction) CheckVersion(expected *int) error {
+	if expected != nil && *expected != t.version {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
 // UpdateAmount updates the transaction amount
 func (t *Transaction) UpdateAmount(newAmount Money) error {
 	if newAmount.Currency() != t.currencyID {
-		return errors.New("cannot change currency of existing transaction")
+		return ErrCurrencyChange
 	}
 	t.amount = newAmount
 	return nil
@@ -199,3 +297,28 @@ func (t *Transaction) UpdateDescription(description string) {
 func (t *Transaction) UpdateDate(date time.Time) {
 	t.date = date
 }
+
+// AssignAccount records the transaction against an account
+func (t *Transaction) AssignAccount(accountID AccountID) {
+	t.accountID = accountID
+}
+
+// UpdateLocation records where the transaction was made; nil removes the location
+func (t *Transaction) UpdateLocation(location *Location) {
+	t.location = location
+}
+
+// UpdateStatus sets the reconciliation status
+func (t *Transaction) UpdateStatus(status ReconciliationStatus) {
+	t.status = status
+}
+
+// UpdateTaxDetails marks an expense as tax-deductible and records its receipt reference
+func (t *Transaction) UpdateTaxDetails(deductible bool, receiptReference string) error {
+	if t.transactionType != TransactionTypeExpense {
+		return ErrIncomeNotDeductible
+	}
+	t.taxDeductible = deductible
+	t.receiptRef = strings.TrimSpace(receiptReference)
+	return nil
+}
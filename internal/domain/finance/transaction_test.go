@@ -0,0 +1,50 @@
+package finance
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMoney(t *testing.T) {
+	currencyID := NewCurrencyID(1)
+
+	t.Run("invalid amounts are rejected", func(t *testing.T) {
+		for amount, want := range map[float64]error{
+			math.Inf(1):     ErrInvalidAmount,
+			-1:              ErrNegativeAmount,
+			MaxAmount + 1:   ErrAmountTooLarge,
+			MaxAmount + .01: ErrAmountTooLarge,
+		} {
+			_, err := NewMoney(amount, currencyID)
+			assert.ErrorIs(t, err, want, "amount %v", amount)
+		}
+		_, err := NewMoney(math.NaN(), currencyID)
+		assert.ErrorIs(t, err, ErrInvalidAmount)
+
+		money, err := NewMoney(MaxAmount, currencyID)
+		require.NoError(t, err)
+		assert.Equal(t, MaxAmount, money.Amount())
+	})
+
+	t.Run("amounts are rounded to cents", func(t *testing.T) {
+		money, err := NewMoney(12.345, currencyID)
+		require.NoError(t, err)
+		assert.Equal(t, 12.35, money.Amount())
+	})
+
+	t.Run("zero-decimal currencies are rounded to whole units", func(t *testing.T) {
+		yen, err := NewCurrency(NewCurrencyID(4), nil, "JPY", "Japanese Yen", "¥", true)
+		require.NoError(t, err)
+		assert.Equal(t, 0, yen.MinorUnits())
+
+		money, err := NewMoney(1500.6, currencyID)
+		require.NoError(t, err)
+		money, err = money.In(yen)
+		require.NoError(t, err)
+		assert.Equal(t, 1501.0, money.Amount())
+		assert.Equal(t, yen.ID(), money.Currency())
+	})
+}
@@ -0,0 +1,23 @@
+package identity
+
+import "errors"
+
+// Domain errors returned by identity entities and services.
+// Callers should compare against these with errors.Is rather than matching messages.
+var (
+	ErrUserNotFound               = errors.New("user not found")
+	ErrUserAlreadyExists          = errors.New("user already exists")
+	ErrEmailAlreadyExists         = errors.New("email already exists")
+	ErrInvalidCredentials         = errors.New("invalid credentials")
+	ErrEmptyEmail                 = errors.New("email cannot be empty")
+	ErrInvalidRole                = errors.New("invalid role")
+	ErrUserDeactivated            = errors.New("user account is deactivated")
+	ErrUserAlreadyDeactivated     = errors.New("user account is already deactivated")
+	ErrCannotDeactivateSelf       = errors.New("admins cannot deactivate their own account")
+	ErrInvalidResetToken          = errors.New("password reset link is invalid or has expired")
+	ErrInvalidAnomalySensitivity  = errors.New("anomaly sensitivity must be off, low, medium or high")
+	ErrInvalidGrowthRange         = errors.New("from and to must be dates (YYYY-MM-DD), from not after to and at most 366 days apart")
+	ErrInvalidRetentionMonths     = errors.New("months must be between 1 and 24")
+	ErrOnboardingAlreadyCompleted = errors.New("onboarding is already complete")
+	ErrInvalidTrashRetention      = errors.New("trash retention days must not be negative")
+)
@@ -0,0 +1,9 @@
+package identity
+
+// CohortActivity counts the users who signed up in one month and were active in
+// another, for retention cohorts. Months are YYYY-MM.
+type CohortActivity struct {
+	SignupMonth string
+	ActiveMonth string
+	Users       int
+}
@@ -0,0 +1,85 @@
+package identity
+
+import "time"
+
+// PasswordReset is a single-use token that lets a user choose a new password.
+// Only a hash of the token is stored; the token itself is emailed to the user.
+type PasswordReset struct {
+	id        int
+	userID    UserID
+	tokenHash string
+	expiresAt time.Time
+	usedAt    *time.Time
+	createdAt time.Time
+}
+
+// NewPasswordReset creates a password reset for a user
+func NewPasswordReset(userID UserID, tokenHash string, createdAt time.Time, ttl time.Duration) *PasswordReset {
+	return &PasswordReset{
+		userID:    userID,
+		tokenHash: tokenHash,
+		expiresAt: createdAt.Add(ttl),
+		createdAt: createdAt,
+	}
+}
+
+// RestorePasswordReset recreates a password reset from persistence
+func RestorePasswordReset(id int, userID UserID, tokenHash string, expiresAt time.Time, usedAt *time.Time, createdAt time.Time) *PasswordReset {
+	return &PasswordReset{
+		id:        id,
+		userID:    userID,
+		tokenHash: tokenHash,
+		expiresAt: expiresAt,
+		usedAt:    usedAt,
+		createdAt: createdAt,
+	}
+}
+
+// ID returns the password reset ID
+func (p *PasswordReset) ID() int {
+	return p.id
+}
+
+// UserID returns the ID of the user resetting their password
+func (p *PasswordReset) UserID() UserID {
+	return p.userID
+}
+
+// TokenHash returns the hash of the emailed token
+func (p *PasswordReset) TokenHash() string {
+	return p.tokenHash
+}
+
+// ExpiresAt returns when the token stops working
+func (p *PasswordReset) ExpiresAt() time.Time {
+	return p.expiresAt
+}
+
+// UsedAt returns when the token was used or invalidated, if it was
+func (p *PasswordReset) UsedAt() *time.Time {
+	return p.usedAt
+}
+
+// CreatedAt returns when the token was issued
+func (p *PasswordReset) CreatedAt() time.Time {
+	return p.createdAt
+}
+
+// AssignID sets the ID given by persistence
+func (p *PasswordReset) AssignID(id int) {
+	p.id = id
+}
+
+// Use marks the token as used, so it cannot reset the password again
+func (p *PasswordReset) Use(at time.Time) error {
+	if !p.IsUsable(at) {
+		return ErrInvalidResetToken
+	}
+	p.usedAt = &at
+	return nil
+}
+
+// IsUsable reports whether the token can still reset the password
+func (p *PasswordReset) IsUsable(at time.Time) bool {
+	return p.usedAt == nil && at.Before(p.expiresAt)
+}
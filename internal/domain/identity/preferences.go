@@ -0,0 +1,143 @@
+package identity
+
+import "time"
+
+// AnomalySensitivity is how readily unusual spending is flagged for a user
+type AnomalySensitivity string
+
+const (
+	AnomalySensitivityOff    AnomalySensitivity = "off"
+	AnomalySensitivityLow    AnomalySensitivity = "low"
+	AnomalySensitivityMedium AnomalySensitivity = "medium"
+	AnomalySensitivityHigh   AnomalySensitivity = "high"
+)
+
+// NewAnomalySensitivity validates an anomaly sensitivity
+func NewAnomalySensitivity(value string) (AnomalySensitivity, error) {
+	switch sensitivity := AnomalySensitivity(value); sensitivity {
+	case AnomalySensitivityOff, AnomalySensitivityLow, AnomalySensitivityMedium, AnomalySensitivityHigh:
+		return sensitivity, nil
+	}
+	return "", ErrInvalidAnomalySensitivity
+}
+
+// Preferences are the settings a user chooses for their account. Users who have
+// never saved any get DefaultPreferences.
+type Preferences struct {
+	userID             UserID
+	emailNotifications bool
+	budgetAlerts       bool
+	recurringReminders bool
+	anomalySensitivity AnomalySensitivity
+	// benchmarking is set by users who share their spending, anonymized, to compare it with others'
+	benchmarking bool
+	// onboardingCompletedAt is when the user finished setting up their account, nil until they do
+	onboardingCompletedAt *time.Time
+	// trashRetentionDays is how long the undo log keeps the user's old records, nil for the server's setting
+	trashRetentionDays *int
+}
+
+// DefaultPreferences returns the preferences of a user who has not changed any
+func DefaultPreferences(userID UserID) *Preferences {
+	return &Preferences{
+		userID:             userID,
+		emailNotifications: true,
+		budgetAlerts:       true,
+		recurringReminders: true,
+		anomalySensitivity: AnomalySensitivityMedium,
+	}
+}
+
+// RestorePreferences rebuilds persisted preferences
+func RestorePreferences(userID UserID, emailNotifications, budgetAlerts, recurringReminders bool, anomalySensitivity AnomalySensitivity, benchmarking bool, onboardingCompletedAt *time.Time, trashRetentionDays *int) *Preferences {
+	return &Preferences{
+		userID:                userID,
+		emailNotifications:    emailNotifications,
+		budgetAlerts:          budgetAlerts,
+		recurringReminders:    recurringReminders,
+		anomalySensitivity:    anomalySensitivity,
+		benchmarking:          benchmarking,
+		onboardingCompletedAt: onboardingCompletedAt,
+		trashRetentionDays:    trashRetentionDays,
+	}
+}
+
+// Getters
+func (p *Preferences) UserID() UserID {
+	return p.userID
+}
+
+func (p *Preferences) EmailNotifications() bool {
+	return p.emailNotifications
+}
+
+func (p *Preferences) BudgetAlerts() bool {
+	return p.budgetAlerts
+}
+
+func (p *Preferences) RecurringReminders() bool {
+	return p.recurringReminders
+}
+
+func (p *Preferences) AnomalySensitivity() AnomalySensitivity {
+	return p.anomalySensitivity
+}
+
+func (p *Preferences) Benchmarking() bool {
+	return p.benchmarking
+}
+
+func (p *Preferences) OnboardingCompletedAt() *time.Time {
+	return p.onboardingCompletedAt
+}
+
+func (p *Preferences) TrashRetentionDays() *int {
+	return p.trashRetentionDays
+}
+
+// SetEmailNotifications chooses whether notifications are also emailed
+func (p *Preferences) SetEmailNotifications(enabled bool) {
+	p.emailNotifications = enabled
+}
+
+// SetBudgetAlerts chooses whether the user is alerted when a budget is exceeded
+func (p *Preferences) SetBudgetAlerts(enabled bool) {
+	p.budgetAlerts = enabled
+}
+
+// SetRecurringReminders chooses whether the user is reminded of upcoming recurring transactions
+func (p *Preferences) SetRecurringReminders(enabled bool) {
+	p.recurringReminders = enabled
+}
+
+// SetAnomalySensitivity chooses how readily unusual spending is flagged
+func (p *Preferences) SetAnomalySensitivity(sensitivity AnomalySensitivity) {
+	p.anomalySensitivity = sensitivity
+}
+
+// SetBenchmarking chooses whether the user's spending is compared with other users'.
+// Only users who share their own spending see the comparison.
+func (p *Preferences) SetBenchmarking(enabled bool) {
+	p.benchmarking = enabled
+}
+
+// SetTrashRetentionDays chooses how many days the undo log keeps what the user's
+// updated and deleted records looked like; nil follows the server's setting. A
+// window longer than the server's has no effect.
+func (p *Preferences) SetTrashRetentionDays(days *int) error {
+	if days != nil && *days < 0 {
+		return ErrInvalidTrashRetention
+	}
+	p.trashRetentionDays = days
+	return nil
+}
+
+// CompleteOnboarding records that the user finished setting up their account.
+// Onboarding only happens once.
+func (p *Preferences) CompleteOnboarding(at time.Time) error {
+	if p.onboardingCompletedAt != nil {
+		return ErrOnboardingAlreadyCompleted
+	}
+	p.onboardingCompletedAt = &at
+	return nil
+}
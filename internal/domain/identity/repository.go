@@ -2,14 +2,64 @@ package identity
 
 import (
 	"context"
+	"time"
 )
 
+// UserFilters narrows a paginated user listing
+type UserFilters struct {
+	// Search matches users whose email contains it, ignoring case
+	Search string
+	Limit  int
+	Offset int
+}
+
 // UserRepository defines the contract for user persistence
 type UserRepository interface {
 	Save(ctx context.Context, user *User) error
 	FindByID(ctx context.Context, id UserID) (*User, error)
 	FindByEmail(ctx context.Context, email Email) (*User, error)
 	FindAll(ctx context.Context) ([]*User, error)
+	// FindWithFilters returns one page of users, oldest first, and the total number matching
+	FindWithFilters(ctx context.Context, filters UserFilters) ([]*User, int64, error)
 	Delete(ctx context.Context, id UserID) error
 	ExistsByEmail(ctx context.Context, email Email) (bool, error)
 }
+
+// PasswordResetRepository defines the contract for password reset token persistence
+type PasswordResetRepository interface {
+	Save(ctx context.Context, reset *PasswordReset) error
+	FindByTokenHash(ctx context.Context, tokenHash string) (*PasswordReset, error)
+	// CountSince counts the tokens issued to a user since the given time
+	CountSince(ctx context.Context, userID UserID, since time.Time) (int64, error)
+	// InvalidateByUserID marks every unused token of a user as used
+	InvalidateByUserID(ctx context.Context, userID UserID, at time.Time) error
+}
+
+// PreferencesRepository defines the contract for user preferences persistence
+type PreferencesRepository interface {
+	// FindByUserID returns the user's preferences, or the defaults when they have saved none
+	FindByUserID(ctx context.Context, userID UserID) (*Preferences, error)
+	Save(ctx context.Context, preferences *Preferences) error
+	// FindWithTrashRetention returns the preferences of every user who chose their own trash retention
+	FindWithTrashRetention(ctx context.Context) ([]*Preferences, error)
+}
+
+// GrowthMetricsRepository aggregates the back-office growth metrics. Only users
+// with the user role are counted as signups or active users. Counts are keyed
+// by day as YYYY-MM-DD or by month as YYYY-MM; days and months without any are
+// left out.
+type GrowthMetricsRepository interface {
+	// DailySignups counts the users created on each day in [from, to)
+	DailySignups(ctx context.Context, from, to time.Time) (map[string]int, error)
+	// DailyTransactions counts the expenses and incomes recorded on each day in [from, to)
+	DailyTransactions(ctx context.Context, from, to time.Time) (map[string]int, error)
+	// DailyActiveUsers counts the users who made an API request on each day from fromDay to toDay
+	DailyActiveUsers(ctx context.Context, fromDay, toDay string) (map[string]int, error)
+	// ActiveUsers counts the users who made an API request on any day from fromDay to toDay
+	ActiveUsers(ctx context.Context, fromDay, toDay string) (int, error)
+	// MonthlySignups counts the users created in each month since the given time
+	MonthlySignups(ctx context.Context, since time.Time) (map[string]int, error)
+	// CohortActivity counts, for the users created since the given time, how many
+	// of each signup month made an API request in each month
+	CohortActivity(ctx context.Context, since time.Time) ([]CohortActivity, error)
+}
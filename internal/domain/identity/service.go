@@ -3,6 +3,7 @@ package identity
 import (
 	"context"
 	"errors"
+	"time"
 )
 
 // UserService handles user-related domain operations
@@ -26,7 +27,7 @@ func (s *UserService) RegisterUser(ctx context.Context, email Email, password Pa
 	}
 
 	if exists {
-		return nil, errors.New("user already exists")
+		return nil, ErrUserAlreadyExists
 	}
 
 	// Create new user with default role
@@ -45,13 +46,13 @@ func (s *UserService) RegisterUser(ctx context.Context, email Email, password Pa
 func (s *UserService) AuthenticateUser(ctx context.Context, email Email, password PasswordHash) (*User, error) {
 	user, err := s.userRepo.FindByEmail(ctx, email)
 	if err != nil {
-		return nil, errors.New("invalid credentials")
+		return nil, ErrInvalidCredentials
 	}
 
 	// In a real implementation, you would verify the password hash here
 	// For now, we'll assume the password is already hashed and matches
 	if user.PasswordHash() != password {
-		return nil, errors.New("invalid credentials")
+		return nil, ErrInvalidCredentials
 	}
 
 	return user, nil
@@ -86,9 +87,79 @@ func (s *UserService) UpdateUserEmail(ctx context.Context, id UserID, newEmail E
 	}
 
 	if exists {
-		return errors.New("email already exists")
+		return ErrEmailAlreadyExists
 	}
 
 	user.ChangeEmail(newEmail)
 	return s.userRepo.Save(ctx, user)
 }
+
+// ChangePassword replaces a user's password hash
+func (s *UserService) ChangePassword(ctx context.Context, id UserID, password PasswordHash) error {
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := user.ChangePassword(password); err != nil {
+		return err
+	}
+	return s.userRepo.Save(ctx, user)
+}
+
+// ListUsers retrieves one page of users matching the filters
+func (s *UserService) ListUsers(ctx context.Context, filters UserFilters) ([]*User, int64, error) {
+	return s.userRepo.FindWithFilters(ctx, filters)
+}
+
+// RecordLogin stores the time of a successful sign-in
+func (s *UserService) RecordLogin(ctx context.Context, user *User) error {
+	user.RecordLogin(time.Now())
+	return s.userRepo.Save(ctx, user)
+}
+
+// DeactivateUser blocks a user from signing in. Admins cannot lock themselves out.
+func (s *UserService) DeactivateUser(ctx context.Context, actorID, id UserID) (*User, error) {
+	if actorID == id {
+		return nil, ErrCannotDeactivateSelf
+	}
+
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := user.Deactivate(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.Save(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ReactivateUser lets a deactivated user sign in again
+func (s *UserService) ReactivateUser(ctx context.Context, id UserID) (*User, error) {
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Reactivate()
+	if err := s.userRepo.Save(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// IsUserActive reports whether a user exists and may use the API
+func (s *UserService) IsUserActive(ctx context.Context, id UserID) (bool, error) {
+	user, err := s.userRepo.FindByID(ctx, id)
+	if errors.Is(err, ErrUserNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return user.IsActive(), nil
+}
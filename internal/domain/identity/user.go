@@ -1,7 +1,6 @@
 package identity
 
 import (
-	"errors"
 	"time"
 )
 
@@ -12,6 +11,9 @@ type User struct {
 	password  PasswordHash
 	role      Role
 	createdAt time.Time
+
+	lastLoginAt   *time.Time
+	deactivatedAt *time.Time
 }
 
 // UserID is a value object representing a user identifier
@@ -34,7 +36,7 @@ type Email struct {
 
 func NewEmail(email string) (Email, error) {
 	if email == "" {
-		return Email{}, errors.New("email cannot be empty")
+		return Email{}, ErrEmptyEmail
 	}
 	// Add email validation logic here
 	return Email{value: email}, nil
@@ -70,7 +72,7 @@ func NewRole(role string) (Role, error) {
 	}
 
 	if !validRoles[role] {
-		return Role{}, errors.New("invalid role")
+		return Role{}, ErrInvalidRole
 	}
 
 	return Role{value: role}, nil
@@ -99,6 +101,19 @@ func NewUser(id UserID, email Email, password PasswordHash, role Role) *User {
 	}
 }
 
+// RestoreUser rebuilds a persisted user, including its account history
+func RestoreUser(id UserID, email Email, password PasswordHash, role Role, createdAt time.Time, lastLoginAt, deactivatedAt *time.Time) *User {
+	return &User{
+		id:            id,
+		email:         email,
+		password:      password,
+		role:          role,
+		createdAt:     createdAt,
+		lastLoginAt:   lastLoginAt,
+		deactivatedAt: deactivatedAt,
+	}
+}
+
 // Getters
 func (u *User) ID() UserID {
 	return u.id
@@ -120,6 +135,21 @@ func (u *User) Role() Role {
 	return u.role
 }
 
+// LastLoginAt returns when the user last signed in, or nil if they never have
+func (u *User) LastLoginAt() *time.Time {
+	return u.lastLoginAt
+}
+
+// DeactivatedAt returns when the account was deactivated, or nil if it is active
+func (u *User) DeactivatedAt() *time.Time {
+	return u.deactivatedAt
+}
+
+// IsActive reports whether the account may sign in
+func (u *User) IsActive() bool {
+	return u.deactivatedAt == nil
+}
+
 // ChangeEmail changes the user's email
 func (u *User) ChangeEmail(newEmail Email) error {
 	u.email = newEmail
@@ -137,3 +167,27 @@ func (u *User) ChangeRole(newRole Role) error {
 	u.role = newRole
 	return nil
 }
+
+// RecordLogin records a successful sign-in
+func (u *User) RecordLogin(at time.Time) {
+	u.lastLoginAt = &at
+}
+
+// Deactivate blocks the account from signing in
+func (u *User) Deactivate(at time.Time) error {
+	if !u.IsActive() {
+		return ErrUserAlreadyDeactivated
+	}
+	u.deactivatedAt = &at
+	return nil
+}
+
+// Reactivate lets a deactivated account sign in again
+func (u *User) Reactivate() {
+	u.deactivatedAt = nil
+}
+
+// AssignID sets the ID given by the repository on save
+func (u *User) AssignID(id UserID) {
+	u.id = id
+}
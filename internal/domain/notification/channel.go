@@ -0,0 +1,164 @@
+package notification
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ChannelKind is a chat or push service notifications can be posted to
+type ChannelKind string
+
+const (
+	ChannelKindSlack   ChannelKind = "slack"
+	ChannelKindDiscord ChannelKind = "discord"
+	// ChannelKindNtfy is an ntfy.sh topic, which the ntfy apps deliver as push notifications
+	ChannelKindNtfy ChannelKind = "ntfy"
+)
+
+// webhookHosts are the hosts incoming webhooks of each service live on. Only
+// these are accepted, so a channel cannot be used to make requests elsewhere.
+var webhookHosts = map[ChannelKind][]string{
+	ChannelKindSlack:   {"hooks.slack.com"},
+	ChannelKindDiscord: {"discord.com", "discordapp.com"},
+	ChannelKindNtfy:    {"ntfy.sh"},
+}
+
+// validTypes are the notification types a channel can subscribe to
+var validTypes = map[Type]bool{
+	TypeAnnouncement:        true,
+	TypeBudgetExceeded:      true,
+	TypeRecurringReminder:   true,
+	TypeSpendingAnomaly:     true,
+	TypeCardPaymentReminder: true,
+}
+
+// ChannelID is a value object representing a notification channel identifier
+type ChannelID struct {
+	value int
+}
+
+func NewChannelID(id int) ChannelID {
+	return ChannelID{value: id}
+}
+
+func (c ChannelID) Value() int {
+	return c.value
+}
+
+// Channel is a Slack or Discord incoming webhook or ntfy topic a user's
+// notifications are also posted to, such as a personal or family channel
+type Channel struct {
+	id         ChannelID
+	userID     UserID
+	kind       ChannelKind
+	name       string
+	webhookURL string
+	types      []Type // empty receives every type
+	createdAt  time.Time
+}
+
+// NewChannel creates a new notification channel
+func NewChannel(userID UserID, kind ChannelKind, name, webhookURL string, types []Type) (*Channel, error) {
+	hosts, ok := webhookHosts[kind]
+	if !ok {
+		return nil, ErrInvalidChannelKind
+	}
+
+	parsed, err := url.Parse(webhookURL)
+	if err != nil || parsed.Scheme != "https" || parsed.User != nil || parsed.Port() != "" {
+		return nil, ErrInvalidWebhookURL
+	}
+	if !containsHost(hosts, strings.ToLower(parsed.Hostname())) {
+		return nil, ErrInvalidWebhookURL
+	}
+
+	for _, t := range types {
+		if !validTypes[t] {
+			return nil, ErrInvalidNotificationType
+		}
+	}
+
+	return &Channel{
+		userID:     userID,
+		kind:       kind,
+		name:       name,
+		webhookURL: webhookURL,
+		types:      types,
+		createdAt:  time.Now(),
+	}, nil
+}
+
+// RestoreChannel rebuilds a persisted notification channel
+func RestoreChannel(id ChannelID, userID UserID, kind ChannelKind, name, webhookURL string, types []Type, createdAt time.Time) *Channel {
+	return &Channel{
+		id:         id,
+		userID:     userID,
+		kind:       kind,
+		name:       name,
+		webhookURL: webhookURL,
+		types:      types,
+		createdAt:  createdAt,
+	}
+}
+
+func containsHost(hosts []string, host string) bool {
+	for _, h := range hosts {
+		if h == host {
+			return true
+		}
+	}
+	return false
+}
+
+// Getters
+func (c *Channel) ID() ChannelID {
+	return c.id
+}
+
+func (c *Channel) UserID() UserID {
+	return c.userID
+}
+
+func (c *Channel) Kind() ChannelKind {
+	return c.kind
+}
+
+func (c *Channel) Name() string {
+	return c.name
+}
+
+func (c *Channel) WebhookURL() string {
+	return c.webhookURL
+}
+
+func (c *Channel) Types() []Type {
+	return c.types
+}
+
+func (c *Channel) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// AssignID sets the ID given by the repository on save
+func (c *Channel) AssignID(id ChannelID) {
+	c.id = id
+}
+
+// BelongsTo reports whether the channel is owned by the user
+func (c *Channel) BelongsTo(userID UserID) bool {
+	return c.userID == userID
+}
+
+// Receives reports whether notifications of the type are posted to the channel
+func (c *Channel) Receives(t Type) bool {
+	if len(c.types) == 0 {
+		return true
+	}
+	for _, subscribed := range c.types {
+		if subscribed == t {
+			return true
+		}
+	}
+	return false
+}
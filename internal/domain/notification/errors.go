@@ -0,0 +1,18 @@
+package notification
+
+import "errors"
+
+// Domain errors returned by notification entities.
+// Callers should compare against these with errors.Is rather than matching messages.
+var (
+	ErrNotificationNotFound    = errors.New("notification not found")
+	ErrEmptyTitle              = errors.New("notification title cannot be empty")
+	ErrEmptyMessage            = errors.New("notification message cannot be empty")
+	ErrChannelNotFound         = errors.New("notification channel not found")
+	ErrInvalidChannelKind      = errors.New("channel kind must be slack, discord or ntfy")
+	ErrInvalidWebhookURL       = errors.New("webhook URL must be an https Slack or Discord incoming webhook or ntfy topic")
+	ErrInvalidNotificationType = errors.New("invalid notification type")
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrInvalidEndpointURL      = errors.New("webhook URL must be a public https URL")
+	ErrInvalidEventName        = errors.New("invalid event name")
+)
@@ -0,0 +1,128 @@
+package notification
+
+import (
+	"time"
+)
+
+// Type categorizes a notification
+type Type string
+
+const (
+	TypeAnnouncement        Type = "announcement"
+	TypeBudgetExceeded      Type = "budget_exceeded"
+	TypeRecurringReminder   Type = "recurring_reminder"
+	TypeSpendingAnomaly     Type = "spending_anomaly"
+	TypeCardPaymentReminder Type = "card_payment_reminder"
+)
+
+// NotificationID is a value object representing a notification identifier
+type NotificationID struct {
+	value int
+}
+
+func NewNotificationID(id int) NotificationID {
+	return NotificationID{value: id}
+}
+
+func (n NotificationID) Value() int {
+	return n.value
+}
+
+// UserID is a value object representing the user a notification is for
+type UserID struct {
+	value int
+}
+
+func NewUserID(id int) UserID {
+	return UserID{value: id}
+}
+
+func (u UserID) Value() int {
+	return u.value
+}
+
+// Notification is a message shown to one user in the app
+type Notification struct {
+	id               NotificationID
+	userID           UserID
+	title            string
+	message          string
+	notificationType Type
+	isRead           bool
+	createdAt        time.Time
+}
+
+// NewNotification creates a new unread notification
+func NewNotification(userID UserID, title, message string, notificationType Type) (*Notification, error) {
+	if title == "" {
+		return nil, ErrEmptyTitle
+	}
+	if message == "" {
+		return nil, ErrEmptyMessage
+	}
+
+	return &Notification{
+		userID:           userID,
+		title:            title,
+		message:          message,
+		notificationType: notificationType,
+		createdAt:        time.Now(),
+	}, nil
+}
+
+// RestoreNotification rebuilds a persisted notification
+func RestoreNotification(id NotificationID, userID UserID, title, message string, notificationType Type, isRead bool, createdAt time.Time) *Notification {
+	return &Notification{
+		id:               id,
+		userID:           userID,
+		title:            title,
+		message:          message,
+		notificationType: notificationType,
+		isRead:           isRead,
+		createdAt:        createdAt,
+	}
+}
+
+// Getters
+func (n *Notification) ID() NotificationID {
+	return n.id
+}
+
+func (n *Notification) UserID() UserID {
+	return n.userID
+}
+
+func (n *Notification) Title() string {
+	return n.title
+}
+
+func (n *Notification) Message() string {
+	return n.message
+}
+
+func (n *Notification) Type() Type {
+	return n.notificationType
+}
+
+func (n *Notification) IsRead() bool {
+	return n.isRead
+}
+
+func (n *Notification) CreatedAt() time.Time {
+	return n.createdAt
+}
+
+// BelongsTo reports whether the notification is addressed to the user
+func (n *Notification) BelongsTo(userID UserID) bool {
+	return n.userID == userID
+}
+
+// AssignID sets the ID given by the repository on save
+func (n *Notification) AssignID(id NotificationID) {
+	n.id = id
+}
+
+// MarkRead marks the notification as read
+func (n *Notification) MarkRead() {
+	n.isRead = true
+}
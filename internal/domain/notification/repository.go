@@ -0,0 +1,34 @@
+package notification
+
+import (
+	"context"
+)
+
+// Repository defines the contract for notification persistence
+type Repository interface {
+	// SaveAll creates the notifications in batches and assigns their IDs
+	SaveAll(ctx context.Context, notifications []*Notification) error
+	FindByID(ctx context.Context, id NotificationID) (*Notification, error)
+	// FindByUserID returns a user's notifications, newest first
+	FindByUserID(ctx context.Context, userID UserID, unreadOnly bool) ([]*Notification, error)
+	MarkRead(ctx context.Context, id NotificationID) error
+	MarkAllRead(ctx context.Context, userID UserID) error
+	// EmailRecipients returns the users, of those given, who accept email notifications
+	EmailRecipients(ctx context.Context, userIDs []UserID) ([]UserID, error)
+}
+
+// ChannelRepository defines the contract for notification channel persistence
+type ChannelRepository interface {
+	Save(ctx context.Context, channel *Channel) error
+	FindByID(ctx context.Context, id ChannelID) (*Channel, error)
+	FindByUserID(ctx context.Context, userID UserID) ([]*Channel, error)
+	Delete(ctx context.Context, id ChannelID) error
+}
+
+// WebhookRepository defines the contract for webhook persistence
+type WebhookRepository interface {
+	Save(ctx context.Context, webhook *Webhook) error
+	FindByID(ctx context.Context, id WebhookID) (*Webhook, error)
+	FindByUserID(ctx context.Context, userID UserID) ([]*Webhook, error)
+	Delete(ctx context.Context, id WebhookID) error
+}
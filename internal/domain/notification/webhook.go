@@ -0,0 +1,130 @@
+package notification
+
+import (
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// WebhookID is a value object representing a webhook identifier
+type WebhookID struct {
+	value int
+}
+
+func NewWebhookID(id int) WebhookID {
+	return WebhookID{value: id}
+}
+
+func (w WebhookID) Value() int {
+	return w.value
+}
+
+// Webhook is an endpoint of the user's own, such as a Zapier or Make trigger,
+// that domain events are delivered to as signed JSON
+type Webhook struct {
+	id        WebhookID
+	userID    UserID
+	url       string
+	secret    string   // signs deliveries so the receiver can verify them
+	events    []string // empty receives every event
+	createdAt time.Time
+}
+
+// NewWebhook creates a new webhook. The URL must be https and must not point at a
+// private address; events must be names the caller has checked.
+func NewWebhook(userID UserID, endpoint, secret string, events []string) (*Webhook, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil || parsed.Scheme != "https" || parsed.User != nil || parsed.Hostname() == "" {
+		return nil, ErrInvalidEndpointURL
+	}
+	if isPrivateHost(parsed.Hostname()) {
+		return nil, ErrInvalidEndpointURL
+	}
+
+	return &Webhook{
+		userID:    userID,
+		url:       endpoint,
+		secret:    secret,
+		events:    events,
+		createdAt: time.Now(),
+	}, nil
+}
+
+// RestoreWebhook rebuilds a persisted webhook
+func RestoreWebhook(id WebhookID, userID UserID, endpoint, secret string, events []string, createdAt time.Time) *Webhook {
+	return &Webhook{
+		id:        id,
+		userID:    userID,
+		url:       endpoint,
+		secret:    secret,
+		events:    events,
+		createdAt: createdAt,
+	}
+}
+
+// isPrivateHost reports whether host names this machine or an address on a private
+// network. Names are resolved at delivery time, where the address is checked again.
+func isPrivateHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || strings.HasSuffix(host, ".internal") {
+		return true
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return IsPrivateIP(ip)
+	}
+	return false
+}
+
+// IsPrivateIP reports whether ip is a loopback, private, link-local or unspecified address
+func IsPrivateIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified()
+}
+
+// Getters
+func (w *Webhook) ID() WebhookID {
+	return w.id
+}
+
+func (w *Webhook) UserID() UserID {
+	return w.userID
+}
+
+func (w *Webhook) URL() string {
+	return w.url
+}
+
+func (w *Webhook) Secret() string {
+	return w.secret
+}
+
+func (w *Webhook) Events() []string {
+	return w.events
+}
+
+func (w *Webhook) CreatedAt() time.Time {
+	return w.createdAt
+}
+
+// AssignID sets the ID given by the repository on save
+func (w *Webhook) AssignID(id WebhookID) {
+	w.id = id
+}
+
+// BelongsTo reports whether the webhook is owned by the user
+func (w *Webhook) BelongsTo(userID UserID) bool {
+	return w.userID == userID
+}
+
+// Receives reports whether the event is delivered to the webhook
+func (w *Webhook) Receives(event string) bool {
+	if len(w.events) == 0 {
+		return true
+	}
+	for _, subscribed := range w.events {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,307 @@
+// Package anonymize scrubs personal data from a copy of the database so it can
+// be used as staging data.
+package anonymize
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+
+	"panda-pocket/internal/infrastructure/database"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// batchSize is the number of rows read per query while scrubbing
+const batchSize = 500
+
+// Options configures a scrub
+type Options struct {
+	Password string // the password every user is given, so staging accounts can log in
+	Seed     int64  // seeds the amount scaling; the same seed scrubs a copy the same way
+}
+
+// Report counts the rows scrubbed and deleted per table
+type Report struct {
+	Scrubbed map[string]int64
+	Deleted  map[string]int64
+}
+
+// table describes how to scrub one table's columns
+type table struct {
+	model   interface{}
+	where   string            // limits the rows scrubbed, e.g. to user-created categories
+	amounts []string          // scaled per user, with a little noise per row
+	labels  map[string]string // replaced by the label and the row ID when not empty
+}
+
+// tables lists the user tables holding amounts or free text. Users' amounts are
+// all scaled by the same factor, so budgets, balances and spending keep their
+// proportions, and each row gets up to 5% of noise so no amount can be matched
+// to the original.
+var tables = []table{
+	{model: &database.Category{}, where: "user_id IS NOT NULL", labels: map[string]string{"name": "Category"}},
+	{model: &database.Account{}, amounts: []string{"opening_balance"}, labels: map[string]string{"name": "Account"}},
+	{model: &database.BalanceAssertion{}, amounts: []string{"balance"}},
+	{model: &database.BalanceAdjustment{}, amounts: []string{"amount"}, labels: map[string]string{"description": "Adjustment"}},
+	{model: &database.Holding{}, amounts: []string{"cost_basis"}},
+	{model: &database.Expense{}, amounts: []string{"amount"}, labels: map[string]string{"description": "Expense", "receipt_reference": "Receipt"}},
+	{model: &database.Income{}, amounts: []string{"amount"}, labels: map[string]string{"description": "Income", "receipt_reference": "Receipt"}},
+	{model: &database.ArchivedExpense{}, amounts: []string{"amount"}, labels: map[string]string{"description": "Expense", "receipt_reference": "Receipt"}},
+	{model: &database.ArchivedIncome{}, amounts: []string{"amount"}, labels: map[string]string{"description": "Income", "receipt_reference": "Receipt"}},
+	{model: &database.ReceiptLineItem{}, amounts: []string{"unit_price"}, labels: map[string]string{"name": "Item"}},
+	{model: &database.Budget{}, amounts: []string{"amount"}},
+	{model: &database.RecurringTransaction{}, amounts: []string{"amount", "next_amount_override"}, labels: map[string]string{"description": "Recurring"}},
+	{model: &database.ScheduledTransaction{}, amounts: []string{"amount"}, labels: map[string]string{"description": "Scheduled"}},
+	{model: &database.Anomaly{}, amounts: []string{"amount", "baseline"}},
+}
+
+// located lists the tables whose rows may carry a location, which is dropped
+var located = []interface{}{
+	&database.Expense{},
+	&database.Income{},
+	&database.ArchivedExpense{},
+	&database.ArchivedIncome{},
+}
+
+// disposable lists the tables that are emptied because they copy personal data
+// or hold credentials: messages, undo snapshots, event payloads, delivery targets,
+// tokens and the analytics read model, which is projected again from the scrubbed
+// amounts. Children come before their parents.
+var disposable = []interface{}{
+	&database.PasswordResetToken{},
+	&database.Notification{},
+	&database.NotificationChannel{},
+	&database.Webhook{},
+	&database.QueuedEmail{},
+	&database.OutboxEvent{},
+	&database.Action{},
+	&database.ExportRun{},
+	&database.ExportSchedule{},
+	&database.AnalyticsBudgetProgress{},
+	&database.AnalyticsCategoryTotal{},
+	&database.AnalyticsDailyTotal{},
+	&database.AnalyticsProjection{},
+}
+
+// Anonymizer scrubs personal data in place
+type Anonymizer struct {
+	db *gorm.DB
+}
+
+// NewAnonymizer creates a new anonymizer
+func NewAnonymizer(db *gorm.DB) *Anonymizer {
+	return &Anonymizer{db: db}
+}
+
+// Run scrubs the database in a single transaction. Emails become
+// user<id>@example.invalid, every password is set to opts.Password, free text is
+// replaced by labels, amounts are scaled and locations are dropped.
+func (a *Anonymizer) Run(ctx context.Context, opts Options) (*Report, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(opts.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Scrubbed: make(map[string]int64), Deleted: make(map[string]int64)}
+	s := &scrubber{
+		random:  rand.New(rand.NewSource(opts.Seed)),
+		factors: make(map[int64]float64),
+	}
+
+	err = a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, model := range disposable {
+			result := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Unscoped().Delete(model)
+			if result.Error != nil {
+				return result.Error
+			}
+			report.Deleted[tableName(tx, model)] = result.RowsAffected
+		}
+
+		result := tx.Model(&database.User{}).Where("1 = 1").Updates(map[string]interface{}{
+			"password_hash": string(hash),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		scrubbed, err := s.scrubEmails(tx)
+		if err != nil {
+			return err
+		}
+		report.Scrubbed[tableName(tx, &database.User{})] = scrubbed
+
+		for _, t := range tables {
+			scrubbed, err := s.scrubTable(tx, t)
+			if err != nil {
+				return fmt.Errorf("%s: %w", tableName(tx, t.model), err)
+			}
+			report.Scrubbed[tableName(tx, t.model)] = scrubbed
+		}
+
+		for _, model := range located {
+			err := tx.Model(model).Where("latitude IS NOT NULL OR longitude IS NOT NULL").
+				Updates(map[string]interface{}{"latitude": nil, "longitude": nil}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// scrubber keeps the scaling factor picked for each user
+type scrubber struct {
+	random  *rand.Rand
+	factors map[int64]float64
+}
+
+// scrubEmails replaces every email with one derived from the user ID
+func (s *scrubber) scrubEmails(tx *gorm.DB) (int64, error) {
+	var scrubbed int64
+	err := eachBatch(tx, &database.User{}, "", []string{"id"}, func(row map[string]interface{}) error {
+		id := toInt(row["id"])
+		scrubbed++
+		return tx.Model(&database.User{}).Where("id = ?", id).
+			Update("email", fmt.Sprintf("user%d@example.invalid", id)).Error
+	})
+	return scrubbed, err
+}
+
+// scrubTable scales the table's amounts and labels its text, row by row
+func (s *scrubber) scrubTable(tx *gorm.DB, t table) (int64, error) {
+	columns := []string{"id", "user_id"}
+	columns = append(columns, t.amounts...)
+	for column := range t.labels {
+		columns = append(columns, column)
+	}
+
+	var scrubbed int64
+	err := eachBatch(tx, t.model, t.where, columns, func(row map[string]interface{}) error {
+		id := toInt(row["id"])
+		factor := s.factor(toInt(row["user_id"]))
+
+		updates := make(map[string]interface{})
+		for _, column := range t.amounts {
+			if row[column] == nil {
+				continue
+			}
+			amount := toFloat(row[column]) * factor * (0.95 + s.random.Float64()*0.1)
+			updates[column] = roundCents(amount)
+		}
+		for column, label := range t.labels {
+			if text, _ := row[column].(string); text != "" || isBytes(row[column]) {
+				updates[column] = fmt.Sprintf("%s %d", label, id)
+			}
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+
+		scrubbed++
+		return tx.Model(t.model).Where("id = ?", id).UpdateColumns(updates).Error
+	})
+	return scrubbed, err
+}
+
+// factor returns the user's scaling factor, picking one between 0.8 and 1.25 on first use
+func (s *scrubber) factor(userID int64) float64 {
+	factor, ok := s.factors[userID]
+	if !ok {
+		factor = 0.8 + s.random.Float64()*0.45
+		s.factors[userID] = factor
+	}
+	return factor
+}
+
+// eachBatch calls fn with the columns of every matching row of model, in ID order
+func eachBatch(tx *gorm.DB, model interface{}, where string, columns []string, fn func(row map[string]interface{}) error) error {
+	var lastID int64
+	for {
+		query := tx.Model(model).Select(columns).Where("id > ?", lastID).Order("id").Limit(batchSize)
+		if where != "" {
+			query = query.Where(where)
+		}
+		var rows []map[string]interface{}
+		if err := query.Find(&rows).Error; err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			if err := fn(row); err != nil {
+				return err
+			}
+			lastID = toInt(row["id"])
+		}
+		if len(rows) < batchSize {
+			return nil
+		}
+	}
+}
+
+// tableName returns the table a model is stored in
+func tableName(tx *gorm.DB, model interface{}) string {
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(model); err != nil {
+		return fmt.Sprintf("%T", model)
+	}
+	return stmt.Schema.Table
+}
+
+// roundCents rounds an amount to cents, keeping positive amounts above zero
+func roundCents(amount float64) float64 {
+	rounded := math.Round(amount*100) / 100
+	if amount > 0 && rounded == 0 {
+		return 0.01
+	}
+	return rounded
+}
+
+// isBytes reports whether a driver returned a non-empty text column as bytes
+func isBytes(value interface{}) bool {
+	b, ok := value.([]byte)
+	return ok && len(b) > 0
+}
+
+// toInt reads an integer column as returned by any of the drivers
+func toInt(value interface{}) int64 {
+	switch v := value.(type) {
+	case int64:
+		return v
+	case int32:
+		return int64(v)
+	case int:
+		return int64(v)
+	case uint64:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case uint:
+		return int64(v)
+	default:
+		n, _ := strconv.ParseInt(fmt.Sprint(v), 10, 64)
+		return n
+	}
+}
+
+// toFloat reads a decimal column, which drivers return as floats, strings or bytes
+func toFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case []byte:
+		f, _ := strconv.ParseFloat(string(v), 64)
+		return f
+	default:
+		f, _ := strconv.ParseFloat(fmt.Sprint(v), 64)
+		return f
+	}
+}
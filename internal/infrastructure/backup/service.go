@@ -0,0 +1,413 @@
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"time"
+
+	"panda-pocket/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// fileExtension is the suffix of every backup file: gzip-compressed JSON
+const fileExtension = ".json.gz"
+
+// Backup errors
+var (
+	ErrDatabaseNotEmpty  = errors.New("database already has users; restoring a full backup replaces all data and must be forced")
+	ErrUnsupportedFormat = errors.New("unsupported backup format")
+	ErrNewerSchema       = errors.New("backup was taken from a newer schema; run the migrations first")
+)
+
+// restoreBatchSize is the number of rows inserted per statement on restore
+const restoreBatchSize = 500
+
+// Service creates and restores database backups
+type Service struct {
+	db    *gorm.DB
+	store Store
+}
+
+// NewService creates a new backup service
+func NewService(db *gorm.DB, store Store) *Service {
+	return &Service{db: db, store: store}
+}
+
+// Create dumps the whole database, or only one user's data when userID is set,
+// and returns the name of the stored backup
+func (s *Service) Create(ctx context.Context, userID *uint) (string, error) {
+	snapshot, err := s.dump(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(gz).Encode(snapshot); err != nil {
+		return "", err
+	}
+	if err := gz.Close(); err != nil {
+		return "", err
+	}
+
+	name := backupName(snapshot)
+	if err := s.store.Put(ctx, name, bytes.NewReader(buf.Bytes())); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// backupName names a backup after its scope and creation time, so names sort chronologically
+func backupName(snapshot *Snapshot) string {
+	timestamp := snapshot.CreatedAt.UTC().Format("20060102T150405Z")
+	if snapshot.UserID != nil {
+		return fmt.Sprintf("user-%d-%s%s", *snapshot.UserID, timestamp, fileExtension)
+	}
+	return "full-" + timestamp + fileExtension
+}
+
+// dump reads every backed-up table into a snapshot in one read transaction
+func (s *Service) dump(ctx context.Context, userID *uint) (*Snapshot, error) {
+	version, err := s.schemaVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{
+		Format:        snapshotFormat,
+		SchemaVersion: version,
+		CreatedAt:     time.Now(),
+		UserID:        userID,
+	}
+
+	var users []database.User
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Per-user backups hold the user's own rows; shared defaults stay in the database
+		scoped := func(column string) *gorm.DB {
+			if userID == nil {
+				return tx.Order("id")
+			}
+			return tx.Where(column+" = ?", *userID).Order("id")
+		}
+
+		for _, query := range []struct {
+			column string
+			dest   interface{}
+		}{
+			{"id", &users},
+			{"user_id", &snapshot.Currencies},
+			{"user_id", &snapshot.Accounts},
+			{"user_id", &snapshot.BalanceAssertions},
+			{"user_id", &snapshot.BalanceAdjustments},
+			{"user_id", &snapshot.Holdings},
+			{"user_id", &snapshot.HoldingSnapshots},
+			{"user_id", &snapshot.Categories},
+			{"user_id", &snapshot.TaxDeductibleCategories},
+			{"user_id", &snapshot.ClosedMonths},
+			{"user_id", &snapshot.Expenses},
+			{"user_id", &snapshot.Incomes},
+			{"user_id", &snapshot.ArchivedExpenses},
+			{"user_id", &snapshot.ArchivedIncomes},
+			{"user_id", &snapshot.ReceiptLineItems},
+			{"user_id", &snapshot.Budgets},
+			{"user_id", &snapshot.RecurringTransactions},
+			{"user_id", &snapshot.ScheduledTransactions},
+			{"user_id", &snapshot.UserPreferences},
+			{"user_id", &snapshot.Anomalies},
+			{"user_id", &snapshot.Notifications},
+			{"user_id", &snapshot.NotificationChannels},
+			{"user_id", &snapshot.Webhooks},
+			{"user_id", &snapshot.ExportSchedules},
+		} {
+			if err := scoped(query.column).Find(query.dest).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot.Users = toUserRecords(users)
+	return snapshot, nil
+}
+
+// schemaVersion returns the applied migration version
+func (s *Service) schemaVersion(ctx context.Context) (uint, error) {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return 0, err
+	}
+	migrator, err := database.NewMigrator(sqlDB, s.db.Dialector.Name())
+	if err != nil {
+		return 0, err
+	}
+	version, _, err := migrator.Version(ctx)
+	return version, err
+}
+
+// Restore loads a stored backup. A per-user backup replaces that user's data;
+// a full backup replaces all data and, unless force is set, is refused when the
+// database already has users. The restore runs in a single transaction.
+func (s *Service) Restore(ctx context.Context, name string, force bool) error {
+	snapshot, err := s.read(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if snapshot.Format != snapshotFormat {
+		return fmt.Errorf("%w: %d", ErrUnsupportedFormat, snapshot.Format)
+	}
+	version, err := s.schemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if snapshot.SchemaVersion > version {
+		return ErrNewerSchema
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if snapshot.UserID == nil && !force {
+			var count int64
+			if err := tx.Model(&database.User{}).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return ErrDatabaseNotEmpty
+			}
+		}
+
+		if err := s.clear(tx, snapshot.UserID); err != nil {
+			return err
+		}
+		if err := s.insert(tx, snapshot); err != nil {
+			return err
+		}
+		return s.resetSequences(tx)
+	})
+}
+
+// read opens and decodes a stored backup
+func (s *Service) read(ctx context.Context, name string) (*Snapshot, error) {
+	file, err := s.store.Open(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("backup %s is not a gzip file: %w", name, err)
+	}
+	defer gz.Close()
+
+	var snapshot Snapshot
+	if err := json.NewDecoder(gz).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("backup %s is corrupt: %w", name, err)
+	}
+	return &snapshot, nil
+}
+
+// clear deletes the rows a backup replaces, children before parents. Outstanding
+// password reset links are not backed up but are dropped, so none outlives a restore,
+// and so is the analytics read model, which is projected again from the restored rows.
+func (s *Service) clear(tx *gorm.DB, userID *uint) error {
+	tables := []struct {
+		model  interface{}
+		column string
+	}{
+		{&database.PasswordResetToken{}, "user_id"},
+		{&database.AnalyticsBudgetProgress{}, "user_id"},
+		{&database.AnalyticsCategoryTotal{}, "user_id"},
+		{&database.AnalyticsDailyTotal{}, "user_id"},
+		{&database.AnalyticsProjection{}, "user_id"},
+		{&database.ExportSchedule{}, "user_id"},
+		{&database.Webhook{}, "user_id"},
+		{&database.NotificationChannel{}, "user_id"},
+		{&database.Notification{}, "user_id"},
+		{&database.Anomaly{}, "user_id"},
+		{&database.UserPreferences{}, "user_id"},
+		{&database.ScheduledTransaction{}, "user_id"},
+		{&database.RecurringTransaction{}, "user_id"},
+		{&database.Budget{}, "user_id"},
+		{&database.ReceiptLineItem{}, "user_id"},
+		{&database.ArchivedIncome{}, "user_id"},
+		{&database.ArchivedExpense{}, "user_id"},
+		{&database.Income{}, "user_id"},
+		{&database.Expense{}, "user_id"},
+		{&database.ClosedMonth{}, "user_id"},
+		{&database.TaxDeductibleCategory{}, "user_id"},
+		{&database.Category{}, "user_id"},
+		{&database.HoldingSnapshot{}, "user_id"},
+		{&database.Holding{}, "user_id"},
+		{&database.BalanceAdjustment{}, "user_id"},
+		{&database.BalanceAssertion{}, "user_id"},
+		{&database.Account{}, "user_id"},
+		{&database.Currency{}, "user_id"},
+		{&database.User{}, "id"},
+	}
+
+	for _, table := range tables {
+		query := tx.Session(&gorm.Session{AllowGlobalUpdate: true})
+		if userID != nil {
+			query = query.Where(table.column+" = ?", *userID)
+		}
+		if err := query.Delete(table.model).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insert writes the snapshot rows with their original IDs, parents before children
+func (s *Service) insert(tx *gorm.DB, snapshot *Snapshot) error {
+	users := fromUserRecords(snapshot.Users)
+	for _, rows := range []interface{}{
+		&users,
+		&snapshot.Currencies,
+		&snapshot.Accounts,
+		&snapshot.BalanceAssertions,
+		&snapshot.BalanceAdjustments,
+		&snapshot.Holdings,
+		&snapshot.HoldingSnapshots,
+		&snapshot.Categories,
+		&snapshot.TaxDeductibleCategories,
+		&snapshot.ClosedMonths,
+		&snapshot.Expenses,
+		&snapshot.Incomes,
+		&snapshot.ArchivedExpenses,
+		&snapshot.ArchivedIncomes,
+		&snapshot.ReceiptLineItems,
+		&snapshot.Budgets,
+		&snapshot.RecurringTransactions,
+		&snapshot.ScheduledTransactions,
+		&snapshot.UserPreferences,
+		&snapshot.Anomalies,
+		&snapshot.Notifications,
+		&snapshot.NotificationChannels,
+		&snapshot.Webhooks,
+		&snapshot.ExportSchedules,
+	} {
+		if err := insertRows(tx, rows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertRows writes a slice of models as column maps; creating from the structs
+// would replace zero values such as false booleans with the column defaults
+func insertRows(tx *gorm.DB, rows interface{}) error {
+	slice := reflect.ValueOf(rows).Elem()
+	if slice.Len() == 0 {
+		return nil
+	}
+
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(slice.Index(0).Addr().Interface()); err != nil {
+		return err
+	}
+
+	records := make([]map[string]interface{}, 0, slice.Len())
+	for i := 0; i < slice.Len(); i++ {
+		record := make(map[string]interface{}, len(stmt.Schema.DBNames))
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			record[field.DBName], _ = field.ValueOf(tx.Statement.Context, slice.Index(i))
+		}
+		records = append(records, record)
+	}
+	return tx.Table(stmt.Schema.Table).CreateInBatches(records, restoreBatchSize).Error
+}
+
+// resetSequences moves Postgres ID sequences past the restored IDs; MySQL and
+// SQLite advance their auto-increment counters on explicit inserts
+func (s *Service) resetSequences(tx *gorm.DB) error {
+	if tx.Dialector.Name() != "postgres" {
+		return nil
+	}
+
+	for _, table := range []string{
+		"users", "currencies", "accounts", "balance_assertions", "categories",
+		"tax_deductible_categories", "expenses", "incomes", "budgets", "recurring_transactions",
+		"scheduled_transactions", "user_preferences", "notifications", "notification_channels", "webhooks",
+		"export_schedules",
+	} {
+		err := tx.Exec(fmt.Sprintf(
+			"SELECT setval(pg_get_serial_sequence('%[1]s', 'id'), COALESCE((SELECT MAX(id) FROM %[1]s), 0) + 1, false)",
+			table,
+		)).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// List returns the stored backup names, oldest first
+func (s *Service) List(ctx context.Context) ([]string, error) {
+	return s.store.List(ctx)
+}
+
+// Prune deletes the oldest full backups, keeping the newest retain of them.
+// Per-user backups are only removed by hand.
+func (s *Service) Prune(ctx context.Context, retain int) (int, error) {
+	names, err := s.store.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var full []string
+	for _, name := range names {
+		if strings.HasPrefix(name, "full-") {
+			full = append(full, name)
+		}
+	}
+
+	deleted := 0
+	for len(full)-deleted > retain {
+		if err := s.store.Delete(ctx, full[deleted]); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// Run takes a full backup every interval until ctx is cancelled, keeping the
+// newest retain backups (0 keeps all)
+func (s *Service) Run(ctx context.Context, interval time.Duration, retain int) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			name, err := s.Create(ctx, nil)
+			if err != nil {
+				slog.Error("scheduled backup failed", "error", err.Error())
+				continue
+			}
+			slog.Info("scheduled backup created", "backup", name)
+
+			if retain > 0 {
+				if _, err := s.Prune(ctx, retain); err != nil {
+					slog.Error("failed to prune old backups", "error", err.Error())
+				}
+			}
+		}
+	}
+}
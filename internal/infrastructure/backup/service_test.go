@@ -0,0 +1,132 @@
+package backup_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"panda-pocket/internal/infrastructure/backup"
+	"panda-pocket/internal/infrastructure/database"
+	"panda-pocket/internal/testsupport"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// count returns the number of rows of a model
+func count(t *testing.T, db *gorm.DB, model interface{}) int64 {
+	t.Helper()
+	var n int64
+	require.NoError(t, db.Model(model).Count(&n).Error)
+	return n
+}
+
+// putSnapshot stores a hand-written backup
+func putSnapshot(t *testing.T, store backup.Store, name string, snapshot map[string]interface{}) {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	require.NoError(t, json.NewEncoder(gz).Encode(snapshot))
+	require.NoError(t, gz.Close())
+	require.NoError(t, store.Put(context.Background(), name, &buf))
+}
+
+func TestServiceFullBackup(t *testing.T) {
+	ctx := context.Background()
+	store := backup.NewLocalStore(t.TempDir())
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	expense := fixtures.AddExpense(t, db, 12.5, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	name, err := backup.NewService(db, store).Create(ctx, nil)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(name, "full-"))
+
+	t.Run("a database with users is only replaced when forced", func(t *testing.T) {
+		service := backup.NewService(db, store)
+		assert.ErrorIs(t, service.Restore(ctx, name, false), backup.ErrDatabaseNotEmpty)
+
+		fixtures.AddExpense(t, db, 99, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, service.Restore(ctx, name, true))
+		assert.EqualValues(t, 1, count(t, db, &database.Expense{}))
+	})
+
+	t.Run("the backup restores into an empty database", func(t *testing.T) {
+		target := testsupport.NewDatabase(t)
+		require.NoError(t, backup.NewService(target, store).Restore(ctx, name, false))
+
+		var user database.User
+		require.NoError(t, target.First(&user, fixtures.User.ID).Error)
+		assert.Equal(t, fixtures.User.Email, user.Email)
+		assert.Equal(t, fixtures.User.PasswordHash, user.PasswordHash)
+
+		var restored database.Expense
+		require.NoError(t, target.First(&restored, expense.ID).Error)
+		assert.Equal(t, expense.Description, restored.Description)
+		assert.Equal(t, expense.UserID, restored.UserID)
+		assert.EqualValues(t, 2, count(t, target, &database.User{}))
+	})
+}
+
+func TestServiceUserBackup(t *testing.T) {
+	ctx := context.Background()
+	store := backup.NewLocalStore(t.TempDir())
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	service := backup.NewService(db, store)
+	fixtures.AddExpense(t, db, 12.5, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, db.Create(&database.Expense{
+		UserID:      fixtures.Admin.ID,
+		CategoryID:  fixtures.ExpenseCategory.ID,
+		CurrencyID:  fixtures.Currency.ID,
+		Amount:      5,
+		Description: "admin expense",
+		Date:        time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+	}).Error)
+
+	userID := fixtures.User.ID
+	name, err := service.Create(ctx, &userID)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(name, "user-"))
+
+	// Changes made after the backup are undone for that user only
+	fixtures.AddExpense(t, db, 99, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, db.Where("user_id = ?", fixtures.Admin.ID).Delete(&database.Expense{}).Error)
+
+	require.NoError(t, service.Restore(ctx, name, false))
+	var amounts []float64
+	require.NoError(t, db.Model(&database.Expense{}).Where("user_id = ?", userID).Pluck("amount", &amounts).Error)
+	assert.Equal(t, []float64{12.5}, amounts)
+	assert.EqualValues(t, 0, count(t, db.Where("user_id = ?", fixtures.Admin.ID), &database.Expense{}))
+	assert.EqualValues(t, 2, count(t, db, &database.User{}))
+}
+
+func TestServiceRestoreRejects(t *testing.T) {
+	ctx := context.Background()
+	store := backup.NewLocalStore(t.TempDir())
+	service := backup.NewService(testsupport.NewDatabase(t), store)
+
+	t.Run("unknown backups", func(t *testing.T) {
+		assert.ErrorIs(t, service.Restore(ctx, "full-20240301T000000Z.json.gz", false), backup.ErrBackupNotFound)
+	})
+
+	t.Run("files that are not backups", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "full-corrupt.json.gz", strings.NewReader("not gzip")))
+		assert.ErrorContains(t, service.Restore(ctx, "full-corrupt.json.gz", false), "not a gzip file")
+	})
+
+	t.Run("other formats", func(t *testing.T) {
+		putSnapshot(t, store, "full-format.json.gz", map[string]interface{}{"format": 2})
+		assert.ErrorIs(t, service.Restore(ctx, "full-format.json.gz", false), backup.ErrUnsupportedFormat)
+	})
+
+	t.Run("newer schemas", func(t *testing.T) {
+		putSnapshot(t, store, "full-schema.json.gz", map[string]interface{}{"format": 1, "schema_version": 999999})
+		assert.ErrorIs(t, service.Restore(ctx, "full-schema.json.gz", false), backup.ErrNewerSchema)
+	})
+}
@@ -0,0 +1,73 @@
+package backup
+
+import (
+	"time"
+
+	"panda-pocket/internal/infrastructure/database"
+)
+
+// snapshotFormat is bumped when the snapshot layout changes incompatibly
+const snapshotFormat = 1
+
+// Snapshot is the portable content of a backup. Rows are stored as the GORM
+// models, so a backup taken on one database type can be restored into another.
+// Operational tables (API version usage, the event outbox, the email queue, the undo
+// log, the export history, password reset tokens) are not included, nor are category
+// translations, which the migrations seed, or the analytics read model.
+type Snapshot struct {
+	Format        int       `json:"format"`
+	SchemaVersion uint      `json:"schema_version"`
+	CreatedAt     time.Time `json:"created_at"`
+	UserID        *uint     `json:"user_id,omitempty"` // set for per-user backups
+
+	Users                   []userRecord                     `json:"users"`
+	Currencies              []database.Currency              `json:"currencies"`
+	Accounts                []database.Account               `json:"accounts"`
+	BalanceAssertions       []database.BalanceAssertion      `json:"balance_assertions"`
+	BalanceAdjustments      []database.BalanceAdjustment     `json:"balance_adjustments"`
+	Holdings                []database.Holding               `json:"holdings"`
+	HoldingSnapshots        []database.HoldingSnapshot       `json:"holding_snapshots"`
+	Categories              []database.Category              `json:"categories"`
+	TaxDeductibleCategories []database.TaxDeductibleCategory `json:"tax_deductible_categories"`
+	ClosedMonths            []database.ClosedMonth           `json:"closed_months"`
+	Expenses                []database.Expense               `json:"expenses"`
+	Incomes                 []database.Income                `json:"incomes"`
+	ArchivedExpenses        []database.ArchivedExpense       `json:"archived_expenses"`
+	ArchivedIncomes         []database.ArchivedIncome        `json:"archived_incomes"`
+	ReceiptLineItems        []database.ReceiptLineItem       `json:"receipt_line_items"`
+	Budgets                 []database.Budget                `json:"budgets"`
+	RecurringTransactions   []database.RecurringTransaction  `json:"recurring_transactions"`
+	ScheduledTransactions   []database.ScheduledTransaction  `json:"scheduled_transactions"`
+	UserPreferences         []database.UserPreferences       `json:"user_preferences"`
+	Anomalies               []database.Anomaly               `json:"anomalies"`
+	Notifications           []database.Notification          `json:"notifications"`
+	NotificationChannels    []database.NotificationChannel   `json:"notification_channels"`
+	Webhooks                []database.Webhook               `json:"webhooks"`
+	ExportSchedules         []database.ExportSchedule        `json:"export_schedules"`
+}
+
+// userRecord keeps the password hash, which the User model hides from JSON
+type userRecord struct {
+	database.User
+	PasswordHash string `json:"password_hash"`
+}
+
+// toUserRecords wraps users for serialization
+func toUserRecords(users []database.User) []userRecord {
+	records := make([]userRecord, 0, len(users))
+	for _, user := range users {
+		records = append(records, userRecord{User: user, PasswordHash: user.PasswordHash})
+	}
+	return records
+}
+
+// fromUserRecords unwraps deserialized users
+func fromUserRecords(records []userRecord) []database.User {
+	users := make([]database.User, 0, len(records))
+	for _, record := range records {
+		user := record.User
+		user.PasswordHash = record.PasswordHash
+		users = append(users, user)
+	}
+	return users
+}
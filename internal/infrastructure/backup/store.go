@@ -0,0 +1,97 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ErrBackupNotFound is returned when a named backup does not exist
+var ErrBackupNotFound = errors.New("backup not found")
+
+// Store keeps backup files
+type Store interface {
+	// Put writes a backup under the given name
+	Put(ctx context.Context, name string, r io.Reader) error
+	// Open reads a backup
+	Open(ctx context.Context, name string) (io.ReadCloser, error)
+	// List returns the stored backup names in ascending order
+	List(ctx context.Context) ([]string, error)
+	// Delete removes a backup
+	Delete(ctx context.Context, name string) error
+}
+
+// LocalStore keeps backups in a directory on local disk
+type LocalStore struct {
+	dir string
+}
+
+// NewLocalStore creates a store for a directory, which is created on first write
+func NewLocalStore(dir string) *LocalStore {
+	return &LocalStore{dir: dir}
+}
+
+// Put writes a backup under the given name
+func (s *LocalStore) Put(ctx context.Context, name string, r io.Reader) error {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a failed backup never looks complete
+	tmp, err := os.CreateTemp(s.dir, ".tmp-"+name)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(s.dir, name))
+}
+
+// Open reads a backup
+func (s *LocalStore) Open(ctx context.Context, name string) (io.ReadCloser, error) {
+	file, err := os.Open(filepath.Join(s.dir, filepath.Base(name)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrBackupNotFound
+	}
+	return file, err
+}
+
+// List returns the stored backup names in ascending order
+func (s *LocalStore) List(ctx context.Context) ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), fileExtension) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Delete removes a backup
+func (s *LocalStore) Delete(ctx context.Context, name string) error {
+	err := os.Remove(filepath.Join(s.dir, filepath.Base(name)))
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrBackupNotFound
+	}
+	return err
+}
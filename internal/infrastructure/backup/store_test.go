@@ -0,0 +1,75 @@
+package backup
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "backups")
+	store := NewLocalStore(dir)
+
+	names, err := store.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, names, "a missing directory has no backups")
+
+	for _, name := range []string{"full-20240302T000000Z.json.gz", "full-20240301T000000Z.json.gz"} {
+		require.NoError(t, store.Put(ctx, name, strings.NewReader(name)))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a backup"), 0o600))
+
+	names, err = store.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"full-20240301T000000Z.json.gz", "full-20240302T000000Z.json.gz"}, names)
+
+	file, err := store.Open(ctx, "full-20240301T000000Z.json.gz")
+	require.NoError(t, err)
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	assert.Equal(t, "full-20240301T000000Z.json.gz", string(content))
+
+	require.NoError(t, store.Delete(ctx, "full-20240301T000000Z.json.gz"))
+	_, err = store.Open(ctx, "full-20240301T000000Z.json.gz")
+	assert.ErrorIs(t, err, ErrBackupNotFound)
+	assert.ErrorIs(t, store.Delete(ctx, "full-20240301T000000Z.json.gz"), ErrBackupNotFound)
+
+	// Names cannot reach outside the directory
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "..", "outside.json.gz"), nil, 0o600))
+	_, err = store.Open(ctx, "../outside.json.gz")
+	assert.ErrorIs(t, err, ErrBackupNotFound)
+}
+
+func TestPrune(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalStore(t.TempDir())
+	service := NewService(nil, store)
+	for _, name := range []string{
+		"full-20240301T000000Z.json.gz",
+		"full-20240302T000000Z.json.gz",
+		"full-20240303T000000Z.json.gz",
+		"user-1-20240301T000000Z.json.gz",
+	} {
+		require.NoError(t, store.Put(ctx, name, strings.NewReader("")))
+	}
+
+	deleted, err := service.Prune(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	names, err := store.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"full-20240302T000000Z.json.gz",
+		"full-20240303T000000Z.json.gz",
+		"user-1-20240301T000000Z.json.gz",
+	}, names, "per-user backups are kept")
+}
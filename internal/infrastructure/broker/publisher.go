@@ -0,0 +1,115 @@
+// Package broker publishes domain events to a message broker, NATS or RabbitMQ,
+// so external consumers can subscribe to them.
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"panda-pocket/internal/domain/finance"
+	"panda-pocket/internal/infrastructure/config"
+)
+
+// timeout bounds connecting to the broker and each publish, so a slow broker
+// does not hold up event delivery
+const timeout = 10 * time.Second
+
+// Message is the body published for each event
+type Message struct {
+	Event      string        `json:"event"`
+	OccurredAt time.Time     `json:"occurred_at"`
+	Data       finance.Event `json:"data"`
+}
+
+// connection is an open connection to a broker
+type connection interface {
+	// publish sends the payload to the subject and waits for the broker to accept it
+	publish(subject string, payload []byte) error
+	close() error
+}
+
+// Publisher publishes events to the configured broker. It connects on first use
+// and reconnects on the next publish after the connection fails.
+type Publisher struct {
+	dial   func(ctx context.Context) (connection, error)
+	prefix string
+
+	mu   sync.Mutex
+	conn connection
+}
+
+var _ finance.EventPublisher = (*Publisher)(nil)
+
+// NewPublisher creates a publisher for the configured broker, or returns nil when
+// no broker is configured
+func NewPublisher(cfg config.BrokerConfig) *Publisher {
+	target, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil
+	}
+
+	var dial func(ctx context.Context) (connection, error)
+	switch cfg.Type {
+	case "nats":
+		dial = func(ctx context.Context) (connection, error) { return dialNATS(ctx, target) }
+	case "rabbitmq":
+		dial = func(ctx context.Context) (connection, error) { return dialSTOMP(ctx, target) }
+	default:
+		return nil
+	}
+	return &Publisher{dial: dial, prefix: cfg.Prefix}
+}
+
+// Publish sends each event to the subject named after it, such as
+// pandapocket.budget.exceeded
+func (p *Publisher) Publish(ctx context.Context, events ...finance.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, event := range events {
+		payload, err := json.Marshal(Message{Event: event.EventName(), OccurredAt: event.OccurredAt(), Data: event})
+		if err != nil {
+			return err
+		}
+
+		if p.conn == nil {
+			if p.conn, err = p.dial(ctx); err != nil {
+				return err
+			}
+		}
+		if err := p.conn.publish(p.subject(event.EventName()), payload); err != nil {
+			p.conn.close()
+			p.conn = nil
+			return err
+		}
+	}
+	return nil
+}
+
+// HandleEvent publishes an event delivered by the event bus. Failures are
+// returned, so the outbox retries the event for the broker until it accepts it.
+func (p *Publisher) HandleEvent(ctx context.Context, event finance.Event) error {
+	return p.Publish(ctx, event)
+}
+
+// subject returns the subject, or routing key, an event is published to
+func (p *Publisher) subject(name string) string {
+	if p.prefix == "" {
+		return name
+	}
+	return p.prefix + "." + name
+}
+
+// dialTCP connects to the URL's host, on defaultPort when it names none
+func dialTCP(ctx context.Context, target *url.URL, defaultPort string) (net.Conn, error) {
+	address := target.Host
+	if target.Port() == "" {
+		address = net.JoinHostPort(target.Hostname(), defaultPort)
+	}
+	dialer := net.Dialer{Timeout: timeout}
+	return dialer.DialContext(ctx, "tcp", address)
+}
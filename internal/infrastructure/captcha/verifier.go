@@ -0,0 +1,83 @@
+// Package captcha checks CAPTCHA tokens with hCaptcha or Cloudflare Turnstile.
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"panda-pocket/internal/infrastructure/config"
+)
+
+// ErrRejected is returned when the provider does not accept a token
+var ErrRejected = errors.New("captcha verification failed")
+
+// siteverifyURLs are the token verification endpoints of the supported providers.
+// Both take the same form fields and answer in the same shape.
+var siteverifyURLs = map[string]string{
+	"hcaptcha":  "https://api.hcaptcha.com/siteverify",
+	"turnstile": "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+}
+
+// Verifier checks CAPTCHA tokens solved by clients
+type Verifier struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// NewVerifier creates a verifier for the configured provider, or returns nil when
+// no provider is configured
+func NewVerifier(cfg config.CaptchaConfig) *Verifier {
+	verifyURL, ok := siteverifyURLs[cfg.Provider]
+	if !ok {
+		return nil
+	}
+	return &Verifier{
+		url:    verifyURL,
+		secret: cfg.SecretKey,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Verify checks a token with the provider. It returns ErrRejected when the token is
+// invalid, expired or already used, and other errors when the provider can't be reached.
+func (v *Verifier) Verify(ctx context.Context, token, remoteIP string) error {
+	form := url.Values{"secret": {v.secret}, "response": {token}}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("captcha provider returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if !result.Success {
+		return ErrRejected
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Package chat posts messages to Slack and Discord incoming webhooks and ntfy topics.
+package chat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"panda-pocket/internal/domain/notification"
+)
+
+// Poster posts plain-text messages to chat webhooks
+type Poster struct {
+	client *http.Client
+}
+
+// NewPoster creates a poster with a short timeout, so a slow chat service
+// does not hold up event delivery
+func NewPoster() *Poster {
+	return &Poster{client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Post sends text to the webhook in the payload shape its service expects
+func (p *Poster) Post(ctx context.Context, kind notification.ChannelKind, webhookURL, text string) error {
+	var payload map[string]string
+	switch kind {
+	case notification.ChannelKindSlack:
+		payload = map[string]string{"text": text}
+	case notification.ChannelKindDiscord:
+		payload = map[string]string{"content": text}
+	case notification.ChannelKindNtfy:
+		// ntfy publishes the raw body to the topic as a push notification
+	default:
+		return notification.ErrInvalidChannelKind
+	}
+
+	body := []byte(text)
+	contentType := "text/plain; charset=utf-8"
+	if payload != nil {
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return err
+		}
+		contentType = "application/json"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	if kind == notification.ChannelKindNtfy {
+		req.Header.Set("Markdown", "yes")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s webhook returned status %d", kind, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,1114 @@
+package config
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultJWTSecret is the development-only secret used when JWT_SECRET is not set
+const DefaultJWTSecret = "panda-pocket-secret-key-change-in-production"
+
+// Config holds the whole application configuration
+type Config struct {
+	Server       ServerConfig       `json:"server"`
+	Database     DatabaseConfig     `json:"database"`
+	Auth         AuthConfig         `json:"auth"`
+	CORS         CORSConfig         `json:"cors"`
+	RateLimit    RateLimitConfig    `json:"rate_limit"`
+	Versions     VersionsConfig     `json:"versions"`
+	Backup       BackupConfig       `json:"backup"`
+	Archive      ArchiveConfig      `json:"archive"`
+	Reminders    RemindersConfig    `json:"reminders"`
+	Transactions TransactionsConfig `json:"transactions"`
+	Trash        TrashConfig        `json:"trash"`
+	Mail         MailConfig         `json:"mail"`
+	Encryption   EncryptionConfig   `json:"encryption"`
+	Captcha      CaptchaConfig      `json:"captcha"`
+	Prices       PricesConfig       `json:"prices"`
+	Broker       BrokerConfig       `json:"broker"`
+	Warehouse    WarehouseConfig    `json:"warehouse"`
+	Maintenance  MaintenanceConfig  `json:"maintenance"`
+}
+
+// ServerConfig holds HTTP server settings
+type ServerConfig struct {
+	Port string `json:"port"`
+	Mode string `json:"mode"` // gin mode: debug, release or test
+
+	MaxBodyBytes   int `json:"max_body_bytes"`   // largest accepted JSON request body
+	MaxUploadBytes int `json:"max_upload_bytes"` // largest accepted multipart upload
+
+	RequestTimeout time.Duration `json:"request_timeout"` // deadline for handling a request; 0 disables
+
+	// TrustedProxies are the IPs or CIDRs of the proxies whose X-Forwarded-For
+	// header gives the client IP; without any, the connection's address is used
+	TrustedProxies []string `json:"trusted_proxies"`
+}
+
+// UnmarshalJSON accepts request_timeout as a duration string such as "30s"
+func (s *ServerConfig) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Port           *string   `json:"port"`
+		Mode           *string   `json:"mode"`
+		MaxBodyBytes   *int      `json:"max_body_bytes"`
+		MaxUploadBytes *int      `json:"max_upload_bytes"`
+		RequestTimeout *string   `json:"request_timeout"`
+		TrustedProxies *[]string `json:"trusted_proxies"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if raw.Port != nil {
+		s.Port = *raw.Port
+	}
+	if raw.Mode != nil {
+		s.Mode = *raw.Mode
+	}
+	if raw.MaxBodyBytes != nil {
+		s.MaxBodyBytes = *raw.MaxBodyBytes
+	}
+	if raw.MaxUploadBytes != nil {
+		s.MaxUploadBytes = *raw.MaxUploadBytes
+	}
+	if raw.RequestTimeout != nil {
+		d, err := time.ParseDuration(*raw.RequestTimeout)
+		if err != nil {
+			return fmt.Errorf("invalid request_timeout: %w", err)
+		}
+		s.RequestTimeout = d
+	}
+	if raw.TrustedProxies != nil {
+		s.TrustedProxies = *raw.TrustedProxies
+	}
+	return nil
+}
+
+// DatabaseConfig holds database connection settings
+type DatabaseConfig struct {
+	Type string `json:"type"` // postgres, mysql or sqlite
+	Path string `json:"path"` // database file, SQLite only
+
+	Host     string `json:"host"`
+	Port     string `json:"port"`
+	User     string `json:"user"`
+	Password string `json:"password"`
+	Name     string `json:"name"`
+	SSLMode  string `json:"ssl_mode"`
+
+	// AutoMigrate applies pending schema migrations at startup
+	AutoMigrate bool `json:"auto_migrate"`
+
+	Pool PoolConfig     `json:"pool"`
+	Log  QueryLogConfig `json:"log"`
+}
+
+// PoolConfig holds database connection pool limits
+type PoolConfig struct {
+	MaxOpenConns    int           `json:"max_open_conns"`
+	MaxIdleConns    int           `json:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `json:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `json:"conn_max_idle_time"`
+}
+
+// UnmarshalJSON accepts the lifetimes as duration strings such as "30m"
+func (p *PoolConfig) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		MaxOpenConns    *int    `json:"max_open_conns"`
+		MaxIdleConns    *int    `json:"max_idle_conns"`
+		ConnMaxLifetime *string `json:"conn_max_lifetime"`
+		ConnMaxIdleTime *string `json:"conn_max_idle_time"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if raw.MaxOpenConns != nil {
+		p.MaxOpenConns = *raw.MaxOpenConns
+	}
+	if raw.MaxIdleConns != nil {
+		p.MaxIdleConns = *raw.MaxIdleConns
+	}
+	if raw.ConnMaxLifetime != nil {
+		d, err := time.ParseDuration(*raw.ConnMaxLifetime)
+		if err != nil {
+			return fmt.Errorf("invalid conn_max_lifetime: %w", err)
+		}
+		p.ConnMaxLifetime = d
+	}
+	if raw.ConnMaxIdleTime != nil {
+		d, err := time.ParseDuration(*raw.ConnMaxIdleTime)
+		if err != nil {
+			return fmt.Errorf("invalid conn_max_idle_time: %w", err)
+		}
+		p.ConnMaxIdleTime = d
+	}
+	return nil
+}
+
+// QueryLogConfig holds SQL query logging settings
+type QueryLogConfig struct {
+	Level         string        `json:"level"`          // silent, error, warn or info
+	SlowThreshold time.Duration `json:"slow_threshold"` // slower queries are logged and counted; 0 disables
+	Parameters    bool          `json:"parameters"`     // log bound values instead of placeholders
+}
+
+// UnmarshalJSON accepts slow_threshold as a duration string such as "200ms"
+func (q *QueryLogConfig) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Level         *string `json:"level"`
+		SlowThreshold *string `json:"slow_threshold"`
+		Parameters    *bool   `json:"parameters"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if raw.Level != nil {
+		q.Level = *raw.Level
+	}
+	if raw.SlowThreshold != nil {
+		d, err := time.ParseDuration(*raw.SlowThreshold)
+		if err != nil {
+			return fmt.Errorf("invalid slow_threshold: %w", err)
+		}
+		q.SlowThreshold = d
+	}
+	if raw.Parameters != nil {
+		q.Parameters = *raw.Parameters
+	}
+	return nil
+}
+
+// AuthConfig holds authentication settings
+type AuthConfig struct {
+	JWTSecret string        `json:"jwt_secret"`
+	JWTExpiry time.Duration `json:"jwt_expiry"`
+
+	// PasswordResetURL is the frontend page that takes the reset token as its token query parameter
+	PasswordResetURL   string        `json:"password_reset_url"`
+	PasswordResetTTL   time.Duration `json:"password_reset_ttl"`
+	PasswordResetLimit int           `json:"password_reset_limit"` // reset emails per address per hour
+}
+
+// UnmarshalJSON accepts jwt_expiry and password_reset_ttl as duration strings such as "24h"
+func (a *AuthConfig) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		JWTSecret          *string `json:"jwt_secret"`
+		JWTExpiry          *string `json:"jwt_expiry"`
+		PasswordResetURL   *string `json:"password_reset_url"`
+		PasswordResetTTL   *string `json:"password_reset_ttl"`
+		PasswordResetLimit *int    `json:"password_reset_limit"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if raw.JWTSecret != nil {
+		a.JWTSecret = *raw.JWTSecret
+	}
+	if raw.JWTExpiry != nil {
+		d, err := time.ParseDuration(*raw.JWTExpiry)
+		if err != nil {
+			return fmt.Errorf("invalid jwt_expiry: %w", err)
+		}
+		a.JWTExpiry = d
+	}
+	if raw.PasswordResetURL != nil {
+		a.PasswordResetURL = *raw.PasswordResetURL
+	}
+	if raw.PasswordResetTTL != nil {
+		d, err := time.ParseDuration(*raw.PasswordResetTTL)
+		if err != nil {
+			return fmt.Errorf("invalid password_reset_ttl: %w", err)
+		}
+		a.PasswordResetTTL = d
+	}
+	if raw.PasswordResetLimit != nil {
+		a.PasswordResetLimit = *raw.PasswordResetLimit
+	}
+
+	return nil
+}
+
+// CORSConfig holds cross-origin settings
+type CORSConfig struct {
+	AllowedOrigins []string `json:"allowed_origins"`
+}
+
+// RateLimitConfig holds request rate limiting settings
+type RateLimitConfig struct {
+	Enabled  bool          `json:"enabled"`
+	Backend  string        `json:"backend"` // memory or redis
+	RedisURL string        `json:"redis_url"`
+	Global   RateLimitRule `json:"global"` // per client IP, across all routes
+	Auth     RateLimitRule `json:"auth"`   // per client IP, on login and registration
+	API      RateLimitRule `json:"api"`    // per user, on authenticated routes
+
+	DailyAPICalls int `json:"daily_api_calls"` // per user and UTC day, on authenticated routes; 0 disables
+}
+
+// RateLimitRule is a request budget written as "requests/window", e.g. "100/15m"
+type RateLimitRule struct {
+	Requests int
+	Window   time.Duration
+}
+
+// UnmarshalText parses a rule in "requests/window" form
+func (r *RateLimitRule) UnmarshalText(text []byte) error {
+	requests, window, ok := strings.Cut(string(text), "/")
+	if !ok {
+		return fmt.Errorf("rate limit %q must be in requests/window form", text)
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(requests))
+	if err != nil || n < 0 {
+		return fmt.Errorf("rate limit %q has an invalid request count", text)
+	}
+
+	d, err := time.ParseDuration(strings.TrimSpace(window))
+	if err != nil || d <= 0 {
+		return fmt.Errorf("rate limit %q has an invalid window", text)
+	}
+
+	r.Requests = n
+	r.Window = d
+	return nil
+}
+
+// VersionsConfig holds the API version matrix
+type VersionsConfig struct {
+	Current    string                    `json:"current"`
+	Supported  []string                  `json:"supported"`
+	Deprecated []DeprecatedVersionConfig `json:"deprecated"`
+	StateFile  string                    `json:"state_file"` // optional; persists runtime changes across restarts
+}
+
+// DeprecatedVersionConfig describes a deprecated API version
+type DeprecatedVersionConfig struct {
+	Version    string `json:"version"`
+	SunsetDate string `json:"sunset_date"` // YYYY-MM-DD
+	Message    string `json:"message"`
+}
+
+// BackupConfig holds database backup settings
+type BackupConfig struct {
+	Storage  string        `json:"storage"`  // local or s3
+	Dir      string        `json:"dir"`      // backup directory, local storage only
+	Interval time.Duration `json:"interval"` // scheduled full backups; 0 disables them
+	Retain   int           `json:"retain"`   // scheduled backups to keep; 0 keeps all
+	S3       S3Config      `json:"s3"`
+}
+
+// S3Config holds the bucket backups or warehouse exports are written to.
+// Credentials come from the standard AWS environment variables or shared configuration.
+type S3Config struct {
+	Bucket   string `json:"bucket"`
+	Region   string `json:"region"`
+	Endpoint string `json:"endpoint"` // optional, for S3-compatible services such as MinIO
+	Prefix   string `json:"prefix"`
+}
+
+// UnmarshalJSON accepts interval as a duration string such as "24h"
+func (b *BackupConfig) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Storage  *string   `json:"storage"`
+		Dir      *string   `json:"dir"`
+		Interval *string   `json:"interval"`
+		Retain   *int      `json:"retain"`
+		S3       *S3Config `json:"s3"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if raw.Storage != nil {
+		b.Storage = *raw.Storage
+	}
+	if raw.Dir != nil {
+		b.Dir = *raw.Dir
+	}
+	if raw.Interval != nil {
+		d, err := time.ParseDuration(*raw.Interval)
+		if err != nil {
+			return fmt.Errorf("invalid interval: %w", err)
+		}
+		b.Interval = d
+	}
+	if raw.Retain != nil {
+		b.Retain = *raw.Retain
+	}
+	if raw.S3 != nil {
+		b.S3 = *raw.S3
+	}
+	return nil
+}
+
+// ArchiveConfig holds the transaction archival policy
+type ArchiveConfig struct {
+	AfterYears int `json:"after_years"` // archive transactions older than this; 0 disables archival
+}
+
+// RemindersConfig holds when users are reminded of upcoming recurring transactions and credit card payments
+type RemindersConfig struct {
+	DaysAhead int `json:"days_ahead"` // remind this many days before a recurring transaction or card payment is due; 0 disables reminders
+}
+
+// TransactionsConfig holds the rules for recording transactions
+type TransactionsConfig struct {
+	FutureDates string `json:"future_dates"` // allow, reject or schedule transactions dated after today
+}
+
+// TrashConfig holds how long the undo log keeps what updated and deleted records looked like
+type TrashConfig struct {
+	RetentionDays int `json:"retention_days"` // purge undo log entries older than this; 0 keeps them
+}
+
+// MailConfig holds the SMTP server used for outgoing email. Without a host,
+// emails are written to the log instead of being sent.
+type MailConfig struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	From     string `json:"from"`
+
+	// TemplateDir holds email templates that replace the built-in ones of the same file name
+	TemplateDir string `json:"template_dir"`
+	// MaxAttempts is how often delivery of a queued email is tried before it is dead-lettered
+	MaxAttempts int `json:"max_attempts"`
+}
+
+// EncryptionConfig holds the key for application-level encryption of sensitive fields,
+// given either directly or as a data key encrypted with AWS KMS. Without a key, the
+// fields are stored in plain text.
+type EncryptionConfig struct {
+	Key          string   `json:"key"`           // base64-encoded 32-byte AES key
+	KMSDataKey   string   `json:"kms_data_key"`  // base64 ciphertext of the key, decrypted with AWS KMS at startup
+	KMSRegion    string   `json:"kms_region"`    // optional; defaults to the AWS configuration's region
+	PreviousKeys []string `json:"previous_keys"` // retired base64 keys, kept to decrypt older values
+}
+
+// CaptchaConfig holds the CAPTCHA service that checks registrations and password
+// reset requests. Without a provider, no CAPTCHA is required.
+type CaptchaConfig struct {
+	Provider  string `json:"provider"` // hcaptcha or turnstile
+	SecretKey string `json:"secret_key"`
+}
+
+// PricesConfig holds the market data service that values investment holdings
+// daily. Without a provider, holdings are valued at the prices users record.
+type PricesConfig struct {
+	Provider string `json:"provider"` // alphavantage
+	APIKey   string `json:"api_key"`
+}
+
+// BrokerConfig holds the message broker domain events are also published to,
+// for external consumers. Without a type, events are not published.
+type BrokerConfig struct {
+	Type   string `json:"type"`   // nats or rabbitmq
+	URL    string `json:"url"`    // nats:// or tls:// for NATS; stomp:// or stomp+ssl:// for RabbitMQ's STOMP plugin
+	Prefix string `json:"prefix"` // prepended to event names to form the subject or routing key
+}
+
+// WarehouseConfig holds where transaction changes are exported nightly for BI
+// tools. Without a storage, nothing is exported.
+type WarehouseConfig struct {
+	Storage string   `json:"storage"` // local or s3
+	Dir     string   `json:"dir"`     // export directory, local storage only
+	S3      S3Config `json:"s3"`
+}
+
+// MaintenanceConfig holds maintenance mode, under which only admins are served.
+// It can also be switched on at runtime with the maintenance feature flag.
+type MaintenanceConfig struct {
+	Enabled    bool          `json:"enabled"`
+	RetryAfter time.Duration `json:"retry_after"` // sent to clients as the Retry-After header
+	Message    string        `json:"message"`     // shown to users while maintenance is on
+}
+
+// UnmarshalJSON accepts retry_after as a duration string such as "5m"
+func (m *MaintenanceConfig) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Enabled    *bool   `json:"enabled"`
+		RetryAfter *string `json:"retry_after"`
+		Message    *string `json:"message"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if raw.Enabled != nil {
+		m.Enabled = *raw.Enabled
+	}
+	if raw.RetryAfter != nil {
+		d, err := time.ParseDuration(*raw.RetryAfter)
+		if err != nil {
+			return fmt.Errorf("invalid retry_after: %w", err)
+		}
+		m.RetryAfter = d
+	}
+	if raw.Message != nil {
+		m.Message = *raw.Message
+	}
+	return nil
+}
+
+// Default returns the configuration used when nothing is overridden
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Port:           "8080",
+			Mode:           "debug",
+			MaxBodyBytes:   1 << 20,
+			MaxUploadBytes: 10 << 20,
+			RequestTimeout: 30 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Type:        "postgres",
+			Path:        "panda_pocket.db",
+			Host:        "localhost",
+			Port:        "5432",
+			User:        "postgres",
+			Name:        "panda_pocket",
+			SSLMode:     "disable",
+			AutoMigrate: true,
+			Pool: PoolConfig{
+				MaxOpenConns:    25,
+				MaxIdleConns:    5,
+				ConnMaxLifetime: 30 * time.Minute,
+				ConnMaxIdleTime: 5 * time.Minute,
+			},
+			Log: QueryLogConfig{
+				Level:         "warn",
+				SlowThreshold: 200 * time.Millisecond,
+			},
+		},
+		Auth: AuthConfig{
+			JWTSecret: DefaultJWTSecret,
+			JWTExpiry: 24 * time.Hour,
+
+			PasswordResetURL:   "http://localhost:3000/reset-password",
+			PasswordResetTTL:   time.Hour,
+			PasswordResetLimit: 3,
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: []string{
+				"http://localhost:3000",
+				"http://localhost:3001",
+				"http://localhost:3002",
+				"http://localhost:3003",
+				"http://localhost:3004",     // Back office port
+				"https://berbudget.com",     // Production frontend
+				"https://www.berbudget.com", // Production frontend with www
+			},
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: true,
+			Backend: "memory",
+			Global:  RateLimitRule{Requests: 100, Window: time.Minute},
+			Auth:    RateLimitRule{Requests: 10, Window: time.Minute},
+			API:     RateLimitRule{Requests: 60, Window: time.Minute},
+
+			DailyAPICalls: 20000,
+		},
+		Versions: VersionsConfig{
+			Current:   "v120",
+			Supported: []string{"v100", "v110", "v120"},
+		},
+		Backup: BackupConfig{
+			Storage: "local",
+			Dir:     "backups",
+			Retain:  7,
+		},
+		Reminders: RemindersConfig{
+			DaysAhead: 3,
+		},
+		Transactions: TransactionsConfig{
+			FutureDates: "allow",
+		},
+		Trash: TrashConfig{
+			RetentionDays: 30,
+		},
+		Mail: MailConfig{
+			Port:        587,
+			From:        "PandaPocket <no-reply@berbudget.com>",
+			MaxAttempts: 8,
+		},
+		Broker: BrokerConfig{
+			Prefix: "pandapocket",
+		},
+		Warehouse: WarehouseConfig{
+			Dir: "warehouse",
+		},
+		Maintenance: MaintenanceConfig{
+			RetryAfter: 5 * time.Minute,
+			Message:    "PandaPocket is down for maintenance and will be back shortly. Your data is safe.",
+		},
+	}
+}
+
+// Load builds the configuration from defaults, an optional JSON file (CONFIG_FILE)
+// and environment variables (including a .env file), in increasing order of precedence
+func Load() (*Config, error) {
+	loadEnvFile(".env")
+
+	cfg := Default()
+
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.loadEnv(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// loadFile overlays values from a JSON configuration file
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, c); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// loadEnv overlays values from environment variables
+func (c *Config) loadEnv() error {
+	setString(&c.Server.Port, "PORT")
+	setString(&c.Server.Mode, "GIN_MODE")
+	if err := setInt(&c.Server.MaxBodyBytes, "MAX_BODY_BYTES"); err != nil {
+		return err
+	}
+	if err := setInt(&c.Server.MaxUploadBytes, "MAX_UPLOAD_BYTES"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.Server.RequestTimeout, "REQUEST_TIMEOUT"); err != nil {
+		return err
+	}
+	setList(&c.Server.TrustedProxies, "TRUSTED_PROXIES")
+
+	setString(&c.Database.Type, "DB_TYPE")
+	setString(&c.Database.Path, "DB_PATH")
+	setString(&c.Database.Host, "DB_HOST")
+	setString(&c.Database.Port, "DB_PORT")
+	setString(&c.Database.User, "DB_USER")
+	setString(&c.Database.Password, "DB_PASSWORD")
+	setString(&c.Database.Name, "DB_NAME")
+	setString(&c.Database.SSLMode, "DB_SSLMODE")
+	if err := setBool(&c.Database.AutoMigrate, "DB_AUTO_MIGRATE"); err != nil {
+		return err
+	}
+	if err := setInt(&c.Database.Pool.MaxOpenConns, "DB_MAX_OPEN_CONNS"); err != nil {
+		return err
+	}
+	if err := setInt(&c.Database.Pool.MaxIdleConns, "DB_MAX_IDLE_CONNS"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.Database.Pool.ConnMaxLifetime, "DB_CONN_MAX_LIFETIME"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.Database.Pool.ConnMaxIdleTime, "DB_CONN_MAX_IDLE_TIME"); err != nil {
+		return err
+	}
+	setString(&c.Database.Log.Level, "DB_LOG_LEVEL")
+	if err := setDuration(&c.Database.Log.SlowThreshold, "DB_SLOW_QUERY_THRESHOLD"); err != nil {
+		return err
+	}
+	if err := setBool(&c.Database.Log.Parameters, "DB_LOG_PARAMETERS"); err != nil {
+		return err
+	}
+
+	setString(&c.Auth.JWTSecret, "JWT_SECRET")
+	if err := setDuration(&c.Auth.JWTExpiry, "JWT_EXPIRY"); err != nil {
+		return err
+	}
+	setString(&c.Auth.PasswordResetURL, "PASSWORD_RESET_URL")
+	if err := setDuration(&c.Auth.PasswordResetTTL, "PASSWORD_RESET_TTL"); err != nil {
+		return err
+	}
+	if err := setInt(&c.Auth.PasswordResetLimit, "PASSWORD_RESET_LIMIT"); err != nil {
+		return err
+	}
+
+	setList(&c.CORS.AllowedOrigins, "CORS_ALLOWED_ORIGINS")
+
+	if err := setBool(&c.RateLimit.Enabled, "RATE_LIMIT_ENABLED"); err != nil {
+		return err
+	}
+	setString(&c.RateLimit.Backend, "RATE_LIMIT_BACKEND")
+	setString(&c.RateLimit.RedisURL, "REDIS_URL")
+	if err := setInt(&c.RateLimit.DailyAPICalls, "RATE_LIMIT_DAILY_API_CALLS"); err != nil {
+		return err
+	}
+	for key, rule := range map[string]*RateLimitRule{
+		"RATE_LIMIT_GLOBAL": &c.RateLimit.Global,
+		"RATE_LIMIT_AUTH":   &c.RateLimit.Auth,
+		"RATE_LIMIT_API":    &c.RateLimit.API,
+	} {
+		if value := os.Getenv(key); value != "" {
+			if err := rule.UnmarshalText([]byte(value)); err != nil {
+				return fmt.Errorf("invalid %s: %w", key, err)
+			}
+		}
+	}
+
+	setString(&c.Versions.Current, "API_CURRENT_VERSION")
+	setList(&c.Versions.Supported, "API_SUPPORTED_VERSIONS")
+	setString(&c.Versions.StateFile, "API_VERSION_STATE_FILE")
+	if value := os.Getenv("API_DEPRECATED_VERSIONS"); value != "" {
+		deprecated, err := parseDeprecatedVersions(value)
+		if err != nil {
+			return fmt.Errorf("invalid API_DEPRECATED_VERSIONS: %w", err)
+		}
+		c.Versions.Deprecated = deprecated
+	}
+
+	setString(&c.Backup.Storage, "BACKUP_STORAGE")
+	setString(&c.Backup.Dir, "BACKUP_DIR")
+	if err := setDuration(&c.Backup.Interval, "BACKUP_INTERVAL"); err != nil {
+		return err
+	}
+	if err := setInt(&c.Backup.Retain, "BACKUP_RETAIN"); err != nil {
+		return err
+	}
+	setString(&c.Backup.S3.Bucket, "BACKUP_S3_BUCKET")
+	setString(&c.Backup.S3.Region, "BACKUP_S3_REGION")
+	setString(&c.Backup.S3.Endpoint, "BACKUP_S3_ENDPOINT")
+	setString(&c.Backup.S3.Prefix, "BACKUP_S3_PREFIX")
+
+	if err := setInt(&c.Archive.AfterYears, "ARCHIVE_AFTER_YEARS"); err != nil {
+		return err
+	}
+	if err := setInt(&c.Reminders.DaysAhead, "RECURRING_REMINDER_DAYS"); err != nil {
+		return err
+	}
+	setString(&c.Transactions.FutureDates, "TRANSACTION_FUTURE_DATES")
+	if err := setInt(&c.Trash.RetentionDays, "TRASH_RETENTION_DAYS"); err != nil {
+		return err
+	}
+
+	setString(&c.Mail.Host, "SMTP_HOST")
+	if err := setInt(&c.Mail.Port, "SMTP_PORT"); err != nil {
+		return err
+	}
+	setString(&c.Mail.Username, "SMTP_USERNAME")
+	setString(&c.Mail.Password, "SMTP_PASSWORD")
+	setString(&c.Mail.From, "MAIL_FROM")
+	setString(&c.Mail.TemplateDir, "MAIL_TEMPLATE_DIR")
+	if err := setInt(&c.Mail.MaxAttempts, "MAIL_MAX_ATTEMPTS"); err != nil {
+		return err
+	}
+
+	setString(&c.Encryption.Key, "ENCRYPTION_KEY")
+	setString(&c.Encryption.KMSDataKey, "ENCRYPTION_KMS_DATA_KEY")
+	setString(&c.Encryption.KMSRegion, "ENCRYPTION_KMS_REGION")
+	setList(&c.Encryption.PreviousKeys, "ENCRYPTION_PREVIOUS_KEYS")
+
+	setString(&c.Captcha.Provider, "CAPTCHA_PROVIDER")
+	setString(&c.Captcha.SecretKey, "CAPTCHA_SECRET_KEY")
+
+	setString(&c.Prices.Provider, "PRICE_PROVIDER")
+	setString(&c.Prices.APIKey, "PRICE_API_KEY")
+
+	setString(&c.Broker.Type, "EVENT_BROKER")
+	setString(&c.Broker.URL, "EVENT_BROKER_URL")
+	setString(&c.Broker.Prefix, "EVENT_BROKER_PREFIX")
+
+	setString(&c.Warehouse.Storage, "WAREHOUSE_STORAGE")
+	setString(&c.Warehouse.Dir, "WAREHOUSE_DIR")
+	setString(&c.Warehouse.S3.Bucket, "WAREHOUSE_S3_BUCKET")
+	setString(&c.Warehouse.S3.Region, "WAREHOUSE_S3_REGION")
+	setString(&c.Warehouse.S3.Endpoint, "WAREHOUSE_S3_ENDPOINT")
+	setString(&c.Warehouse.S3.Prefix, "WAREHOUSE_S3_PREFIX")
+
+	if err := setBool(&c.Maintenance.Enabled, "MAINTENANCE_MODE"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.Maintenance.RetryAfter, "MAINTENANCE_RETRY_AFTER"); err != nil {
+		return err
+	}
+	setString(&c.Maintenance.Message, "MAINTENANCE_MESSAGE")
+
+	return nil
+}
+
+// parseDeprecatedVersions parses a comma-separated list of version=sunset_date pairs
+func parseDeprecatedVersions(value string) ([]DeprecatedVersionConfig, error) {
+	var deprecated []DeprecatedVersionConfig
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		version, sunsetDate, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("%q must be in version=YYYY-MM-DD form", item)
+		}
+		deprecated = append(deprecated, DeprecatedVersionConfig{
+			Version:    strings.TrimSpace(version),
+			SunsetDate: strings.TrimSpace(sunsetDate),
+		})
+	}
+	return deprecated, nil
+}
+
+// Validate checks the configuration for values the application cannot start with
+func (c *Config) Validate() error {
+	var problems []string
+
+	if port, err := strconv.Atoi(c.Server.Port); err != nil || port <= 0 || port > 65535 {
+		problems = append(problems, "PORT must be a valid TCP port")
+	}
+
+	switch c.Server.Mode {
+	case "debug", "release", "test":
+	default:
+		problems = append(problems, "GIN_MODE must be one of debug, release, test")
+	}
+
+	if c.Server.MaxBodyBytes <= 0 {
+		problems = append(problems, "MAX_BODY_BYTES must be positive")
+	}
+	if c.Server.MaxUploadBytes <= 0 {
+		problems = append(problems, "MAX_UPLOAD_BYTES must be positive")
+	}
+	if c.Server.RequestTimeout < 0 {
+		problems = append(problems, "REQUEST_TIMEOUT must not be negative")
+	}
+	for _, proxy := range c.Server.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			problems = append(problems, "TRUSTED_PROXIES must contain IP addresses or CIDR ranges")
+			break
+		}
+	}
+
+	switch c.Database.Type {
+	case "postgres", "mysql":
+		if c.Database.Host == "" {
+			problems = append(problems, "DB_HOST is required")
+		}
+		if c.Database.Name == "" {
+			problems = append(problems, "DB_NAME is required")
+		}
+	case "sqlite":
+		if c.Database.Path == "" {
+			problems = append(problems, "DB_PATH is required for sqlite")
+		}
+	default:
+		problems = append(problems, "DB_TYPE must be one of postgres, mysql, sqlite")
+	}
+
+	pool := c.Database.Pool
+	if pool.MaxOpenConns <= 0 {
+		problems = append(problems, "DB_MAX_OPEN_CONNS must be positive")
+	}
+	if pool.MaxIdleConns < 0 || pool.MaxIdleConns > pool.MaxOpenConns {
+		problems = append(problems, "DB_MAX_IDLE_CONNS must be between 0 and DB_MAX_OPEN_CONNS")
+	}
+	if pool.ConnMaxLifetime < 0 || pool.ConnMaxIdleTime < 0 {
+		problems = append(problems, "DB_CONN_MAX_LIFETIME and DB_CONN_MAX_IDLE_TIME must not be negative")
+	}
+	switch c.Database.Log.Level {
+	case "silent", "error", "warn", "info":
+	default:
+		problems = append(problems, "DB_LOG_LEVEL must be one of silent, error, warn, info")
+	}
+	if c.Database.Log.SlowThreshold < 0 {
+		problems = append(problems, "DB_SLOW_QUERY_THRESHOLD must not be negative")
+	}
+
+	if c.Auth.JWTSecret == "" {
+		problems = append(problems, "JWT_SECRET is required")
+	} else if c.Server.Mode == "release" && c.Auth.JWTSecret == DefaultJWTSecret {
+		problems = append(problems, "JWT_SECRET must be changed from the default in release mode")
+	}
+	if c.Auth.JWTExpiry <= 0 {
+		problems = append(problems, "JWT_EXPIRY must be positive")
+	}
+	if u, err := url.Parse(c.Auth.PasswordResetURL); err != nil || u.Scheme == "" || u.Host == "" {
+		problems = append(problems, "PASSWORD_RESET_URL must be an absolute URL")
+	}
+	if c.Auth.PasswordResetTTL <= 0 {
+		problems = append(problems, "PASSWORD_RESET_TTL must be positive")
+	}
+	if c.Auth.PasswordResetLimit <= 0 {
+		problems = append(problems, "PASSWORD_RESET_LIMIT must be positive")
+	}
+
+	if len(c.CORS.AllowedOrigins) == 0 {
+		problems = append(problems, "CORS_ALLOWED_ORIGINS must contain at least one origin")
+	}
+
+	if c.RateLimit.Enabled {
+		switch c.RateLimit.Backend {
+		case "memory":
+		case "redis":
+			if u, err := url.Parse(c.RateLimit.RedisURL); err != nil || (u.Scheme != "redis" && u.Scheme != "rediss") {
+				problems = append(problems, "REDIS_URL must be a redis:// or rediss:// URL when RATE_LIMIT_BACKEND is redis")
+			}
+		default:
+			problems = append(problems, "RATE_LIMIT_BACKEND must be memory or redis")
+		}
+	}
+	if c.RateLimit.DailyAPICalls < 0 {
+		problems = append(problems, "RATE_LIMIT_DAILY_API_CALLS must not be negative")
+	}
+
+	if len(c.Versions.Supported) == 0 {
+		problems = append(problems, "API_SUPPORTED_VERSIONS must contain at least one version")
+	} else if !slices.Contains(c.Versions.Supported, c.Versions.Current) {
+		problems = append(problems, "API_CURRENT_VERSION must be one of the supported versions")
+	}
+	for _, deprecated := range c.Versions.Deprecated {
+		if deprecated.Version == c.Versions.Current {
+			problems = append(problems, "the current API version cannot be deprecated")
+		}
+		if _, err := time.Parse("2006-01-02", deprecated.SunsetDate); err != nil {
+			problems = append(problems, fmt.Sprintf("sunset date for %s must be YYYY-MM-DD", deprecated.Version))
+		}
+	}
+
+	switch c.Backup.Storage {
+	case "local":
+		if c.Backup.Dir == "" {
+			problems = append(problems, "BACKUP_DIR is required for local backups")
+		}
+	case "s3":
+		if c.Backup.S3.Bucket == "" {
+			problems = append(problems, "BACKUP_S3_BUCKET is required for s3 backups")
+		}
+	default:
+		problems = append(problems, "BACKUP_STORAGE must be local or s3")
+	}
+	if c.Backup.Interval < 0 {
+		problems = append(problems, "BACKUP_INTERVAL must not be negative")
+	}
+	if c.Backup.Retain < 0 {
+		problems = append(problems, "BACKUP_RETAIN must not be negative")
+	}
+
+	if c.Archive.AfterYears < 0 {
+		problems = append(problems, "ARCHIVE_AFTER_YEARS must not be negative")
+	}
+	if c.Reminders.DaysAhead < 0 {
+		problems = append(problems, "RECURRING_REMINDER_DAYS must not be negative")
+	}
+	switch c.Transactions.FutureDates {
+	case "allow", "reject", "schedule":
+	default:
+		problems = append(problems, "TRANSACTION_FUTURE_DATES must be allow, reject or schedule")
+	}
+	if c.Trash.RetentionDays < 0 {
+		problems = append(problems, "TRASH_RETENTION_DAYS must not be negative")
+	}
+
+	if c.Mail.Host != "" {
+		if c.Mail.Port <= 0 || c.Mail.Port > 65535 {
+			problems = append(problems, "SMTP_PORT must be between 1 and 65535")
+		}
+		if c.Mail.From == "" {
+			problems = append(problems, "MAIL_FROM is required when SMTP_HOST is set")
+		}
+	}
+	if c.Mail.MaxAttempts <= 0 {
+		problems = append(problems, "MAIL_MAX_ATTEMPTS must be positive")
+	}
+
+	if c.Encryption.Key != "" && c.Encryption.KMSDataKey != "" {
+		problems = append(problems, "set only one of ENCRYPTION_KEY and ENCRYPTION_KMS_DATA_KEY")
+	}
+	if c.Encryption.Key != "" && !isEncryptionKey(c.Encryption.Key) {
+		problems = append(problems, "ENCRYPTION_KEY must be a base64-encoded 32-byte key")
+	}
+	for _, key := range c.Encryption.PreviousKeys {
+		if !isEncryptionKey(key) {
+			problems = append(problems, "ENCRYPTION_PREVIOUS_KEYS must be base64-encoded 32-byte keys")
+			break
+		}
+	}
+
+	switch c.Captcha.Provider {
+	case "":
+	case "hcaptcha", "turnstile":
+		if c.Captcha.SecretKey == "" {
+			problems = append(problems, "CAPTCHA_SECRET_KEY is required when CAPTCHA_PROVIDER is set")
+		}
+	default:
+		problems = append(problems, "CAPTCHA_PROVIDER must be one of hcaptcha, turnstile")
+	}
+
+	switch c.Prices.Provider {
+	case "":
+	case "alphavantage":
+		if c.Prices.APIKey == "" {
+			problems = append(problems, "PRICE_API_KEY is required when PRICE_PROVIDER is set")
+		}
+	default:
+		problems = append(problems, "PRICE_PROVIDER must be alphavantage")
+	}
+
+	switch c.Broker.Type {
+	case "":
+	case "nats", "rabbitmq":
+		schemes := map[string][]string{"nats": {"nats", "tls"}, "rabbitmq": {"stomp", "stomp+ssl"}}[c.Broker.Type]
+		if target, err := url.Parse(c.Broker.URL); err != nil || target.Host == "" || !slices.Contains(schemes, target.Scheme) {
+			problems = append(problems, "EVENT_BROKER_URL must be a "+strings.Join(schemes, ":// or ")+":// URL when EVENT_BROKER is "+c.Broker.Type)
+		}
+	default:
+		problems = append(problems, "EVENT_BROKER must be nats or rabbitmq")
+	}
+
+	switch c.Warehouse.Storage {
+	case "":
+	case "local":
+		if c.Warehouse.Dir == "" {
+			problems = append(problems, "WAREHOUSE_DIR is required for local warehouse exports")
+		}
+	case "s3":
+		if c.Warehouse.S3.Bucket == "" {
+			problems = append(problems, "WAREHOUSE_S3_BUCKET is required for s3 warehouse exports")
+		}
+	default:
+		problems = append(problems, "WAREHOUSE_STORAGE must be local or s3")
+	}
+
+	if c.Maintenance.RetryAfter <= 0 {
+		problems = append(problems, "MAINTENANCE_RETRY_AFTER must be positive")
+	}
+	if c.Maintenance.Message == "" {
+		problems = append(problems, "MAINTENANCE_MESSAGE is required")
+	}
+
+	if len(problems) > 0 {
+		return errors.New("invalid configuration: " + strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// isEncryptionKey reports whether value is a base64-encoded AES-256 key
+func isEncryptionKey(value string) bool {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	return err == nil && len(key) == 32
+}
+
+// setString overrides target with the environment variable if it is set
+func setString(target *string, key string) {
+	if value := os.Getenv(key); value != "" {
+		*target = value
+	}
+}
+
+// setDuration overrides target with the environment variable parsed as a duration
+func setDuration(target *time.Duration, key string) error {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", key, err)
+	}
+	*target = d
+	return nil
+}
+
+// setInt overrides target with the environment variable parsed as an integer
+func setInt(target *int, key string) error {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", key, err)
+	}
+	*target = n
+	return nil
+}
+
+// setBool overrides target with the environment variable parsed as a boolean
+func setBool(target *bool, key string) error {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", key, err)
+	}
+	*target = b
+	return nil
+}
+
+// setList overrides target with the comma-separated environment variable
+func setList(target *[]string, key string) {
+	value := os.Getenv(key)
+	if value == "" {
+		return
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	*target = items
+}
+
+// loadEnvFile loads environment variables from a .env file if it exists.
+// Variables already present in the environment take precedence.
+func loadEnvFile(path string) {
+	file, err := os.Open(path)
+	if err != nil {
+		// .env file doesn't exist, use system environment variables
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) == 2 {
+			key := strings.TrimSpace(parts[0])
+			value := strings.TrimSpace(parts[1])
+			if os.Getenv(key) == "" {
+				os.Setenv(key, value)
+			}
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultsCacheTTL is how long the shared default categories and currencies are
+// served from memory. Changes saved through the repositories apply at once on the
+// instance that made them and within defaultsCacheTTL on every other instance.
+const defaultsCacheTTL = 5 * time.Minute
+
+// defaultsCache keeps the rows shared by every user, keyed by a variant such as
+// the request's locales. Reads inside a unit of work bypass it, so uncommitted
+// rows are never cached.
+type defaultsCache[T any] struct {
+	mu      sync.RWMutex
+	entries map[string]defaultsEntry[T]
+}
+
+// defaultsEntry is one cached variant
+type defaultsEntry[T any] struct {
+	items    []T
+	loadedAt time.Time
+}
+
+// newDefaultsCache creates an empty defaults cache
+func newDefaultsCache[T any]() *defaultsCache[T] {
+	return &defaultsCache[T]{entries: make(map[string]defaultsEntry[T])}
+}
+
+// get returns the cached items for key, calling load once they are missing or
+// older than defaultsCacheTTL
+func (c *defaultsCache[T]) get(ctx context.Context, key string, load func() ([]T, error)) ([]T, error) {
+	if ctx.Value(txKey{}) != nil {
+		return load()
+	}
+
+	c.mu.RLock()
+	entry, found := c.entries[key]
+	c.mu.RUnlock()
+	if found && time.Since(entry.loadedAt) < defaultsCacheTTL {
+		return entry.items, nil
+	}
+
+	items, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[key] = defaultsEntry[T]{items: items, loadedAt: time.Now()}
+	c.mu.Unlock()
+	return items, nil
+}
+
+// invalidate makes the next read reload every variant
+func (c *defaultsCache[T]) invalidate() {
+	c.mu.Lock()
+	c.entries = make(map[string]defaultsEntry[T])
+	c.mu.Unlock()
+}
@@ -0,0 +1,21 @@
+package database
+
+// FieldCipher encrypts sensitive column values before they are written and decrypts
+// them after they are read, so the layers above only see plain text
+type FieldCipher interface {
+	Encrypt(plaintext string) (string, error)
+	Decrypt(value string) (string, error)
+}
+
+// plainFields stores values as they are, for deployments without an encryption key
+type plainFields struct{}
+
+func (plainFields) Encrypt(plaintext string) (string, error) { return plaintext, nil }
+
+func (plainFields) Decrypt(value string) (string, error) { return value, nil }
+
+// isPlain reports whether values are stored unencrypted, so the database can match them
+func isPlain(fields FieldCipher) bool {
+	_, ok := fields.(plainFields)
+	return ok
+}
@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormAccountRepository implements the AccountRepository interface using GORM
+type GormAccountRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAccountRepository creates a new GORM account repository
+func NewGormAccountRepository(db *gorm.DB) *GormAccountRepository {
+	return &GormAccountRepository{db: db}
+}
+
+// Save saves an account and assigns its ID
+func (r *GormAccountRepository) Save(ctx context.Context, account *finance.Account) error {
+	model := &Account{
+		ID:          uint(account.ID().Value()),
+		UserID:      uint(account.UserID().Value()),
+		Name:        account.Name(),
+		AccountType: string(account.Type()),
+		CurrencyID:  uint(account.CurrencyID().Value()),
+		CreatedAt:   account.CreatedAt(),
+
+		PaymentRemindedFor: account.PaymentRemindedFor(),
+		OpeningBalance:     account.OpeningBalance(),
+		ArchivedAt:         account.ArchivedAt(),
+		EmergencyFund:      account.EmergencyFund(),
+	}
+	if cycle := account.BillingCycle(); cycle != nil {
+		closingDay, dueDay := cycle.ClosingDay(), cycle.DueDay()
+		model.StatementClosingDay = &closingDay
+		model.PaymentDueDay = &dueDay
+	}
+	if err := conn(ctx, r.db).Save(model).Error; err != nil {
+		return err
+	}
+
+	account.AssignID(finance.NewAccountID(int(model.ID)))
+	return nil
+}
+
+// FindByID finds an account by ID
+func (r *GormAccountRepository) FindByID(ctx context.Context, id finance.AccountID) (*finance.Account, error) {
+	var model Account
+	err := conn(ctx, r.db).First(&model, id.Value()).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, finance.ErrAccountNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainAccount(model), nil
+}
+
+// FindByUserID finds a user's accounts
+func (r *GormAccountRepository) FindByUserID(ctx context.Context, userID finance.UserID) ([]*finance.Account, error) {
+	var models []Account
+	if err := conn(ctx, r.db).Where("user_id = ?", userID.Value()).Order("id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	accounts := make([]*finance.Account, len(models))
+	for i, model := range models {
+		accounts[i] = toDomainAccount(model)
+	}
+	return accounts, nil
+}
+
+// FindWithBillingCycle finds every account with a billing cycle
+func (r *GormAccountRepository) FindWithBillingCycle(ctx context.Context) ([]*finance.Account, error) {
+	var models []Account
+	err := conn(ctx, r.db).Where("statement_closing_day IS NOT NULL AND payment_due_day IS NOT NULL").Order("id").Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]*finance.Account, len(models))
+	for i, model := range models {
+		accounts[i] = toDomainAccount(model)
+	}
+	return accounts, nil
+}
+
+// toDomainAccount converts a GORM account model to a domain account
+func toDomainAccount(model Account) *finance.Account {
+	var cycle *finance.BillingCycle
+	if model.StatementClosingDay != nil && model.PaymentDueDay != nil {
+		if restored, err := finance.NewBillingCycle(*model.StatementClosingDay, *model.PaymentDueDay); err == nil {
+			cycle = &restored
+		}
+	}
+	var remindedFor *time.Time
+	if model.PaymentRemindedFor != nil {
+		date := model.PaymentRemindedFor.UTC()
+		remindedFor = &date
+	}
+
+	return finance.RestoreAccount(
+		finance.NewAccountID(int(model.ID)),
+		finance.NewUserID(int(model.UserID)),
+		model.Name,
+		finance.AccountType(model.AccountType),
+		finance.NewCurrencyID(int(model.CurrencyID)),
+		model.CreatedAt,
+		cycle,
+		remindedFor,
+		model.OpeningBalance,
+		model.ArchivedAt,
+		model.EmergencyFund,
+	)
+}
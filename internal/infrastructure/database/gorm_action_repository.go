@@ -0,0 +1,258 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"panda-pocket/internal/domain/finance"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// actionSnapshot is the JSON form of a transaction or budget before a change
+type actionSnapshot struct {
+	Type             string    `json:"type,omitempty"`
+	CategoryID       int       `json:"category_id"`
+	CurrencyID       int       `json:"currency_id,omitempty"`
+	AccountID        int       `json:"account_id,omitempty"`
+	Amount           float64   `json:"amount"`
+	Description      string    `json:"description,omitempty"`
+	Date             time.Time `json:"date,omitempty"`
+	Status           string    `json:"status,omitempty"`
+	TaxDeductible    bool      `json:"tax_deductible,omitempty"`
+	ReceiptReference string    `json:"receipt_reference,omitempty"`
+	Period           string    `json:"period,omitempty"`
+	StartDate        time.Time `json:"start_date,omitempty"`
+	EndDate          time.Time `json:"end_date,omitempty"`
+	Prorated         bool      `json:"prorated,omitempty"`
+	ClientID         string    `json:"client_id,omitempty"`
+
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+}
+
+// GormActionRepository implements the finance.ActionRepository interface using GORM
+type GormActionRepository struct {
+	db     *gorm.DB
+	fields FieldCipher // encrypts the description and receipt reference in transaction snapshots
+}
+
+// NewGormActionRepository creates a new GORM action repository
+func NewGormActionRepository(db *gorm.DB) *GormActionRepository {
+	return &GormActionRepository{db: db, fields: plainFields{}}
+}
+
+// WithFieldCipher returns a copy of the repository that encrypts snapshot fields with the cipher
+func (r *GormActionRepository) WithFieldCipher(fields FieldCipher) *GormActionRepository {
+	return &GormActionRepository{db: r.db, fields: fields}
+}
+
+// Save saves an action and assigns its ID
+func (r *GormActionRepository) Save(ctx context.Context, action *finance.Action) error {
+	var snapshot actionSnapshot
+	switch action.Target() {
+	case finance.ActionTargetTransaction:
+		transaction := action.Transaction()
+		snapshot = actionSnapshot{
+			Type:        string(transaction.Type()),
+			CategoryID:  transaction.CategoryID().Value(),
+			CurrencyID:  transaction.CurrencyID().Value(),
+			AccountID:   transaction.AccountID().Value(),
+			Amount:      transaction.Amount().Amount(),
+			Description: transaction.Description(),
+			Date:        transaction.Date(),
+			Status:      string(transaction.Status()),
+
+			TaxDeductible:    transaction.TaxDeductible(),
+			ReceiptReference: transaction.ReceiptReference(),
+			ClientID:         transaction.ClientID(),
+		}
+		snapshot.Latitude, snapshot.Longitude = locationColumns(transaction.Location())
+	case finance.ActionTargetBudget:
+		budget := action.Budget()
+		snapshot = actionSnapshot{
+			CategoryID: budget.CategoryID().Value(),
+			Amount:     budget.Amount().Amount(),
+			Period:     string(budget.Period()),
+			StartDate:  budget.StartDate(),
+			EndDate:    budget.EndDate(),
+			Prorated:   budget.Prorated(),
+		}
+	}
+
+	var err error
+	if snapshot.Description, err = r.fields.Encrypt(snapshot.Description); err != nil {
+		return err
+	}
+	if snapshot.ReceiptReference, err = r.fields.Encrypt(snapshot.ReceiptReference); err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	model := &Action{
+		ID:        uint(action.ID().Value()),
+		UserID:    uint(action.UserID().Value()),
+		Kind:      string(action.Kind()),
+		Target:    string(action.Target()),
+		TargetID:  uint(action.TargetID()),
+		Snapshot:  string(payload),
+		UndoneAt:  action.UndoneAt(),
+		CreatedAt: action.CreatedAt(),
+	}
+	if err := conn(ctx, r.db).Save(model).Error; err != nil {
+		return err
+	}
+
+	action.AssignID(finance.NewActionID(int(model.ID)))
+	return nil
+}
+
+// FindByID finds an action by ID
+func (r *GormActionRepository) FindByID(ctx context.Context, id finance.ActionID) (*finance.Action, error) {
+	var model Action
+	err := conn(ctx, r.db).First(&model, id.Value()).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, finance.ErrActionNotFound
+		}
+		return nil, err
+	}
+
+	return r.toDomainAction(model)
+}
+
+// FindLatestByTarget returns the newest action on a record that has not been undone
+func (r *GormActionRepository) FindLatestByTarget(ctx context.Context, target finance.ActionTarget, targetID int) (*finance.Action, error) {
+	var model Action
+	err := conn(ctx, r.db).
+		Where("target = ? AND target_id = ? AND undone_at IS NULL", string(target), targetID).
+		Order("id DESC").
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, finance.ErrActionNotFound
+		}
+		return nil, err
+	}
+
+	return r.toDomainAction(model)
+}
+
+// FindByUserIDSince returns the user's actions created after since, newest first
+func (r *GormActionRepository) FindByUserIDSince(ctx context.Context, userID finance.UserID, since time.Time) ([]*finance.Action, error) {
+	var models []Action
+	err := conn(ctx, r.db).
+		Where("user_id = ? AND created_at > ?", userID.Value(), since).
+		Order("id DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]*finance.Action, 0, len(models))
+	for _, model := range models {
+		action, err := r.toDomainAction(model)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, action)
+	}
+	return actions, nil
+}
+
+// DeleteBefore deletes the actions created before cutoff and returns how many were deleted
+func (r *GormActionRepository) DeleteBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	result := conn(ctx, r.db).Where("created_at < ?", cutoff).Delete(&Action{})
+	return int(result.RowsAffected), result.Error
+}
+
+// DeleteByUserIDBefore deletes the user's actions created before cutoff and returns how many were deleted
+func (r *GormActionRepository) DeleteByUserIDBefore(ctx context.Context, userID finance.UserID, cutoff time.Time) (int, error) {
+	result := conn(ctx, r.db).Where("user_id = ? AND created_at < ?", userID.Value(), cutoff).Delete(&Action{})
+	return int(result.RowsAffected), result.Error
+}
+
+// toDomainAction converts a GORM action model to a domain action
+func (r *GormActionRepository) toDomainAction(model Action) (*finance.Action, error) {
+	var snapshot actionSnapshot
+	if err := json.Unmarshal([]byte(model.Snapshot), &snapshot); err != nil {
+		return nil, err
+	}
+	var err error
+	if snapshot.Description, err = r.fields.Decrypt(snapshot.Description); err != nil {
+		return nil, err
+	}
+	if snapshot.ReceiptReference, err = r.fields.Decrypt(snapshot.ReceiptReference); err != nil {
+		return nil, err
+	}
+
+	userID := finance.NewUserID(int(model.UserID))
+	categoryID := finance.NewCategoryID(snapshot.CategoryID)
+
+	var transaction *finance.Transaction
+	var budget *finance.Budget
+	switch finance.ActionTarget(model.Target) {
+	case finance.ActionTargetTransaction:
+		currencyID := finance.NewCurrencyID(snapshot.CurrencyID)
+		amount, err := finance.NewMoney(snapshot.Amount, currencyID)
+		if err != nil {
+			return nil, err
+		}
+		transaction = finance.NewTransaction(
+			finance.NewTransactionID(int(model.TargetID)),
+			userID,
+			categoryID,
+			currencyID,
+			amount,
+			snapshot.Description,
+			snapshot.Date,
+			finance.TransactionType(snapshot.Type),
+		)
+		transaction.AssignAccount(finance.NewAccountID(snapshot.AccountID))
+		if snapshot.Status != "" {
+			transaction.UpdateStatus(finance.ReconciliationStatus(snapshot.Status))
+		}
+		if snapshot.TaxDeductible || snapshot.ReceiptReference != "" {
+			if err := transaction.UpdateTaxDetails(snapshot.TaxDeductible, snapshot.ReceiptReference); err != nil {
+				return nil, err
+			}
+		}
+		transaction.UpdateLocation(restoreLocation(snapshot.Latitude, snapshot.Longitude))
+		transaction.AssignClientID(snapshot.ClientID)
+	case finance.ActionTargetBudget:
+		amount, err := finance.NewMoney(snapshot.Amount, finance.NewCurrencyID(1)) // Budgets have no currency yet
+		if err != nil {
+			return nil, err
+		}
+		budget, err = finance.NewBudget(
+			finance.NewBudgetID(int(model.TargetID)),
+			userID,
+			categoryID,
+			amount,
+			finance.BudgetPeriod(snapshot.Period),
+			snapshot.StartDate,
+		)
+		if err != nil {
+			return nil, err
+		}
+		budget.UpdateProration(snapshot.Prorated)
+		budget.UpdateEndDate(snapshot.EndDate)
+	}
+
+	return finance.RestoreAction(
+		finance.NewActionID(int(model.ID)),
+		userID,
+		finance.ActionKind(model.Kind),
+		finance.ActionTarget(model.Target),
+		int(model.TargetID),
+		transaction,
+		budget,
+		model.CreatedAt,
+		model.UndoneAt,
+	), nil
+}
@@ -0,0 +1,195 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"panda-pocket/internal/domain/finance"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormAnalyticsRepository implements the finance.AnalyticsRepository interface using GORM
+type GormAnalyticsRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAnalyticsRepository creates a new GORM analytics repository
+func NewGormAnalyticsRepository(db *gorm.DB) *GormAnalyticsRepository {
+	return &GormAnalyticsRepository{db: db}
+}
+
+// Replace deletes the user's read model and inserts the projected one in a single
+// transaction, so readers never see it half written
+func (r *GormAnalyticsRepository) Replace(ctx context.Context, projection *finance.AnalyticsProjection) error {
+	userID := uint(projection.UserID.Value())
+
+	dailyTotals := make([]AnalyticsDailyTotal, len(projection.DailyTotals))
+	for i, total := range projection.DailyTotals {
+		dailyTotals[i] = AnalyticsDailyTotal{
+			UserID:           userID,
+			Date:             total.Date,
+			CurrencyID:       uint(total.CurrencyID.Value()),
+			TransactionType:  string(total.Type),
+			Amount:           total.Amount,
+			TransactionCount: total.Count,
+		}
+	}
+	categoryTotals := make([]AnalyticsCategoryTotal, len(projection.CategoryTotals))
+	for i, total := range projection.CategoryTotals {
+		categoryTotals[i] = AnalyticsCategoryTotal{
+			UserID:           userID,
+			Date:             total.Date,
+			CategoryID:       uint(total.CategoryID.Value()),
+			CurrencyID:       uint(total.CurrencyID.Value()),
+			TransactionType:  string(total.Type),
+			Amount:           total.Amount,
+			TransactionCount: total.Count,
+		}
+	}
+	budgets := make([]AnalyticsBudgetProgress, len(projection.Budgets))
+	for i, budget := range projection.Budgets {
+		budgets[i] = AnalyticsBudgetProgress{
+			BudgetID:   uint(budget.BudgetID.Value()),
+			UserID:     userID,
+			CategoryID: uint(budget.CategoryID.Value()),
+			StartDate:  budget.StartDate,
+			EndDate:    budget.EndDate,
+			Allowance:  budget.Allowance,
+			Spent:      budget.Spent,
+		}
+	}
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&AnalyticsDailyTotal{}, &AnalyticsCategoryTotal{}, &AnalyticsBudgetProgress{}} {
+			if err := tx.Where("user_id = ?", userID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		if len(dailyTotals) > 0 {
+			if err := tx.CreateInBatches(dailyTotals, 500).Error; err != nil {
+				return err
+			}
+		}
+		if len(categoryTotals) > 0 {
+			if err := tx.CreateInBatches(categoryTotals, 500).Error; err != nil {
+				return err
+			}
+		}
+		if len(budgets) > 0 {
+			if err := tx.CreateInBatches(budgets, 500).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"projected_at"}),
+		}).Create(&AnalyticsProjection{UserID: userID, ProjectedAt: projection.ProjectedAt}).Error
+	})
+}
+
+// FindProjectedAt returns when the user's read model was last projected, or nil
+// when it never has been
+func (r *GormAnalyticsRepository) FindProjectedAt(ctx context.Context, userID finance.UserID) (*time.Time, error) {
+	var model AnalyticsProjection
+	err := conn(ctx, r.db).Where("user_id = ?", userID.Value()).First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &model.ProjectedAt, nil
+}
+
+// FindProjectedUserIDs returns the users who have a read model
+func (r *GormAnalyticsRepository) FindProjectedUserIDs(ctx context.Context) ([]finance.UserID, error) {
+	var ids []uint
+	if err := conn(ctx, r.db).Model(&AnalyticsProjection{}).Order("user_id").Pluck("user_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]finance.UserID, len(ids))
+	for i, id := range ids {
+		userIDs[i] = finance.NewUserID(int(id))
+	}
+	return userIDs, nil
+}
+
+// FindDailyTotals returns the user's totals dated within the range, oldest first
+func (r *GormAnalyticsRepository) FindDailyTotals(ctx context.Context, userID finance.UserID, startDate, endDate time.Time) ([]finance.DailyTotal, error) {
+	var models []AnalyticsDailyTotal
+	err := conn(ctx, r.db).
+		Where("user_id = ? AND date BETWEEN ? AND ?", userID.Value(), startDate, endDate).
+		Order("date").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make([]finance.DailyTotal, len(models))
+	for i, model := range models {
+		totals[i] = finance.DailyTotal{
+			UserID:     userID,
+			Date:       model.Date.UTC(),
+			CurrencyID: finance.NewCurrencyID(int(model.CurrencyID)),
+			Type:       finance.TransactionType(model.TransactionType),
+			Amount:     model.Amount,
+			Count:      model.TransactionCount,
+		}
+	}
+	return totals, nil
+}
+
+// FindCategoryTotals returns the user's category totals dated within the range, oldest first
+func (r *GormAnalyticsRepository) FindCategoryTotals(ctx context.Context, userID finance.UserID, startDate, endDate time.Time) ([]finance.CategoryTotal, error) {
+	var models []AnalyticsCategoryTotal
+	err := conn(ctx, r.db).
+		Where("user_id = ? AND date BETWEEN ? AND ?", userID.Value(), startDate, endDate).
+		Order("date").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make([]finance.CategoryTotal, len(models))
+	for i, model := range models {
+		totals[i] = finance.CategoryTotal{
+			UserID:     userID,
+			Date:       model.Date.UTC(),
+			CategoryID: finance.NewCategoryID(int(model.CategoryID)),
+			CurrencyID: finance.NewCurrencyID(int(model.CurrencyID)),
+			Type:       finance.TransactionType(model.TransactionType),
+			Amount:     model.Amount,
+			Count:      model.TransactionCount,
+		}
+	}
+	return totals, nil
+}
+
+// FindBudgetProgress returns the progress of the user's budgets overlapping the range
+func (r *GormAnalyticsRepository) FindBudgetProgress(ctx context.Context, userID finance.UserID, startDate, endDate time.Time) ([]finance.BudgetProgress, error) {
+	var models []AnalyticsBudgetProgress
+	err := conn(ctx, r.db).
+		Where("user_id = ? AND start_date <= ? AND end_date >= ?", userID.Value(), endDate, startDate).
+		Order("start_date, budget_id").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	progress := make([]finance.BudgetProgress, len(models))
+	for i, model := range models {
+		progress[i] = finance.BudgetProgress{
+			BudgetID:   finance.NewBudgetID(int(model.BudgetID)),
+			UserID:     userID,
+			CategoryID: finance.NewCategoryID(int(model.CategoryID)),
+			StartDate:  model.StartDate.UTC(),
+			EndDate:    model.EndDate.UTC(),
+			Allowance:  model.Allowance,
+			Spent:      model.Spent,
+		}
+	}
+	return progress, nil
+}
@@ -0,0 +1,71 @@
+package database
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormAnomalyRepository implements the finance.AnomalyRepository interface using GORM
+type GormAnomalyRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAnomalyRepository creates a new GORM anomaly repository
+func NewGormAnomalyRepository(db *gorm.DB) *GormAnomalyRepository {
+	return &GormAnomalyRepository{db: db}
+}
+
+// Save saves an anomaly and assigns its ID
+func (r *GormAnomalyRepository) Save(ctx context.Context, anomaly *finance.Anomaly) error {
+	model := &Anomaly{
+		ID:         uint(anomaly.ID().Value()),
+		UserID:     uint(anomaly.UserID().Value()),
+		Kind:       string(anomaly.Kind()),
+		CategoryID: uint(anomaly.CategoryID().Value()),
+		Amount:     anomaly.Amount(),
+		Baseline:   anomaly.Baseline(),
+		DetectedAt: anomaly.DetectedAt(),
+	}
+	if transactionID := anomaly.TransactionID(); transactionID != nil {
+		id := uint(transactionID.Value())
+		model.TransactionID = &id
+	}
+	if err := conn(ctx, r.db).Save(model).Error; err != nil {
+		return err
+	}
+
+	anomaly.AssignID(finance.NewAnomalyID(int(model.ID)))
+	return nil
+}
+
+// FindByUserIDSince finds the anomalies flagged for a user at or after since, oldest first
+func (r *GormAnomalyRepository) FindByUserIDSince(ctx context.Context, userID finance.UserID, since time.Time) ([]*finance.Anomaly, error) {
+	var models []Anomaly
+	err := conn(ctx, r.db).Where("user_id = ? AND detected_at >= ?", userID.Value(), since).Order("detected_at, id").Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	anomalies := make([]*finance.Anomaly, len(models))
+	for i, model := range models {
+		var transactionID *finance.TransactionID
+		if model.TransactionID != nil {
+			id := finance.NewTransactionID(int(*model.TransactionID))
+			transactionID = &id
+		}
+		anomalies[i] = finance.RestoreAnomaly(
+			finance.NewAnomalyID(int(model.ID)),
+			finance.NewUserID(int(model.UserID)),
+			finance.AnomalyKind(model.Kind),
+			finance.NewCategoryID(int(model.CategoryID)),
+			transactionID,
+			model.Amount,
+			model.Baseline,
+			model.DetectedAt,
+		)
+	}
+	return anomalies, nil
+}
@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormBalanceAdjustmentRepository implements the BalanceAdjustmentRepository interface using GORM
+type GormBalanceAdjustmentRepository struct {
+	db *gorm.DB
+}
+
+// NewGormBalanceAdjustmentRepository creates a new GORM balance adjustment repository
+func NewGormBalanceAdjustmentRepository(db *gorm.DB) *GormBalanceAdjustmentRepository {
+	return &GormBalanceAdjustmentRepository{db: db}
+}
+
+// Save saves a balance adjustment and assigns its ID
+func (r *GormBalanceAdjustmentRepository) Save(ctx context.Context, adjustment *finance.BalanceAdjustment) error {
+	model := &BalanceAdjustment{
+		ID:          uint(adjustment.ID().Value()),
+		UserID:      uint(adjustment.UserID().Value()),
+		AccountID:   uint(adjustment.AccountID().Value()),
+		Amount:      adjustment.Amount(),
+		Date:        adjustment.Date(),
+		Description: adjustment.Description(),
+		CreatedAt:   adjustment.CreatedAt(),
+	}
+	if err := conn(ctx, r.db).Save(model).Error; err != nil {
+		return err
+	}
+
+	adjustment.AssignID(finance.NewBalanceAdjustmentID(int(model.ID)))
+	return nil
+}
+
+// FindByID finds a balance adjustment by ID
+func (r *GormBalanceAdjustmentRepository) FindByID(ctx context.Context, id finance.BalanceAdjustmentID) (*finance.BalanceAdjustment, error) {
+	var model BalanceAdjustment
+	err := conn(ctx, r.db).First(&model, id.Value()).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, finance.ErrBalanceAdjustmentNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainBalanceAdjustment(model), nil
+}
+
+// FindByAccountID finds an account's balance adjustments, oldest date first
+func (r *GormBalanceAdjustmentRepository) FindByAccountID(ctx context.Context, accountID finance.AccountID) ([]*finance.BalanceAdjustment, error) {
+	var models []BalanceAdjustment
+	if err := conn(ctx, r.db).Where("account_id = ?", accountID.Value()).Order("date, id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	adjustments := make([]*finance.BalanceAdjustment, len(models))
+	for i, model := range models {
+		adjustments[i] = toDomainBalanceAdjustment(model)
+	}
+	return adjustments, nil
+}
+
+// SumByAccount sums an account's balance adjustments dated within the range
+func (r *GormBalanceAdjustmentRepository) SumByAccount(ctx context.Context, accountID finance.AccountID, startDate, endDate time.Time) (float64, error) {
+	var total float64
+	err := conn(ctx, r.db).Model(&BalanceAdjustment{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("account_id = ? AND date BETWEEN ? AND ?", accountID.Value(), startDate, endDate).
+		Scan(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// Delete deletes a balance adjustment by ID
+func (r *GormBalanceAdjustmentRepository) Delete(ctx context.Context, id finance.BalanceAdjustmentID) error {
+	return conn(ctx, r.db).Delete(&BalanceAdjustment{}, id.Value()).Error
+}
+
+// toDomainBalanceAdjustment converts a GORM balance adjustment model to a domain balance adjustment
+func toDomainBalanceAdjustment(model BalanceAdjustment) *finance.BalanceAdjustment {
+	return finance.RestoreBalanceAdjustment(
+		finance.NewBalanceAdjustmentID(int(model.ID)),
+		finance.NewUserID(int(model.UserID)),
+		finance.NewAccountID(int(model.AccountID)),
+		model.Amount,
+		model.Date,
+		model.Description,
+		model.CreatedAt,
+	)
+}
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+
+	"gorm.io/gorm"
+)
+
+// GormBalanceAssertionRepository implements the BalanceAssertionRepository interface using GORM
+type GormBalanceAssertionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormBalanceAssertionRepository creates a new GORM balance assertion repository
+func NewGormBalanceAssertionRepository(db *gorm.DB) *GormBalanceAssertionRepository {
+	return &GormBalanceAssertionRepository{db: db}
+}
+
+// Save saves a balance assertion and assigns its ID
+func (r *GormBalanceAssertionRepository) Save(ctx context.Context, assertion *finance.BalanceAssertion) error {
+	model := &BalanceAssertion{
+		ID:        uint(assertion.ID().Value()),
+		UserID:    uint(assertion.UserID().Value()),
+		AccountID: uint(assertion.AccountID().Value()),
+		Date:      assertion.Date(),
+		Balance:   assertion.Balance(),
+		CreatedAt: assertion.CreatedAt(),
+	}
+	if err := conn(ctx, r.db).Save(model).Error; err != nil {
+		return err
+	}
+
+	assertion.AssignID(finance.NewBalanceAssertionID(int(model.ID)))
+	return nil
+}
+
+// FindByID finds a balance assertion by ID
+func (r *GormBalanceAssertionRepository) FindByID(ctx context.Context, id finance.BalanceAssertionID) (*finance.BalanceAssertion, error) {
+	var model BalanceAssertion
+	err := conn(ctx, r.db).First(&model, id.Value()).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, finance.ErrBalanceAssertionNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainBalanceAssertion(model), nil
+}
+
+// FindByAccountID finds an account's balance assertions, oldest date first
+func (r *GormBalanceAssertionRepository) FindByAccountID(ctx context.Context, accountID finance.AccountID) ([]*finance.BalanceAssertion, error) {
+	var models []BalanceAssertion
+	if err := conn(ctx, r.db).Where("account_id = ?", accountID.Value()).Order("date, id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	assertions := make([]*finance.BalanceAssertion, len(models))
+	for i, model := range models {
+		assertions[i] = toDomainBalanceAssertion(model)
+	}
+	return assertions, nil
+}
+
+// Delete deletes a balance assertion by ID
+func (r *GormBalanceAssertionRepository) Delete(ctx context.Context, id finance.BalanceAssertionID) error {
+	return conn(ctx, r.db).Delete(&BalanceAssertion{}, id.Value()).Error
+}
+
+// toDomainBalanceAssertion converts a GORM balance assertion model to a domain balance assertion
+func toDomainBalanceAssertion(model BalanceAssertion) *finance.BalanceAssertion {
+	return finance.RestoreBalanceAssertion(
+		finance.NewBalanceAssertionID(int(model.ID)),
+		finance.NewUserID(int(model.UserID)),
+		finance.NewAccountID(int(model.AccountID)),
+		model.Date,
+		model.Balance,
+		model.CreatedAt,
+	)
+}
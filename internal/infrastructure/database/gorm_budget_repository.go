@@ -28,16 +28,19 @@ func (r *GormBudgetRepository) Save(ctx context.Context, budget *finance.Budget)
 		Period:     string(budget.Period()),
 		StartDate:  budget.StartDate(),
 		EndDate:    budget.EndDate(),
+		Prorated:   budget.Prorated(),
 	}
 
 	if budget.ID().Value() != 0 {
 		budgetModel.ID = uint(budget.ID().Value())
 	}
 
-	// Save using GORM
-	if err := r.db.WithContext(ctx).Save(budgetModel).Error; err != nil {
+	// Save using GORM, guarding against overwriting a newer version
+	version, err := saveVersioned(conn(ctx, r.db), budgetModel, budgetModel.ID, budget.Version(), func(v int) { budgetModel.Version = v })
+	if err != nil {
 		return err
 	}
+	budget.AssignVersion(version)
 
 	return nil
 }
@@ -46,7 +49,7 @@ func (r *GormBudgetRepository) Save(ctx context.Context, budget *finance.Budget)
 func (r *GormBudgetRepository) FindByID(ctx context.Context, id finance.BudgetID) (*finance.Budget, error) {
 	var budgetModel Budget
 
-	err := r.db.WithContext(ctx).First(&budgetModel, id.Value()).Error
+	err := conn(ctx, r.db).First(&budgetModel, id.Value()).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, err
@@ -70,7 +73,9 @@ func (r *GormBudgetRepository) FindByID(ctx context.Context, id finance.BudgetID
 		budgetModel.StartDate,
 	)
 	// Set the actual end date from database instead of calculated one
+	budget.UpdateProration(budgetModel.Prorated)
 	budget.UpdateEndDate(budgetModel.EndDate)
+	budget.AssignVersion(budgetModel.Version)
 
 	return budget, nil
 }
@@ -79,7 +84,7 @@ func (r *GormBudgetRepository) FindByID(ctx context.Context, id finance.BudgetID
 func (r *GormBudgetRepository) FindByUserID(ctx context.Context, userID finance.UserID) ([]*finance.Budget, error) {
 	var budgetModels []Budget
 
-	err := r.db.WithContext(ctx).Where("user_id = ?", userID.Value()).Find(&budgetModels).Error
+	err := conn(ctx, r.db).Where("user_id = ?", userID.Value()).Find(&budgetModels).Error
 	if err != nil {
 		return nil, err
 	}
@@ -102,7 +107,9 @@ func (r *GormBudgetRepository) FindByUserID(ctx context.Context, userID finance.
 			model.StartDate,
 		)
 		// Set the actual end date from database instead of calculated one
+		budget.UpdateProration(model.Prorated)
 		budget.UpdateEndDate(model.EndDate)
+		budget.AssignVersion(model.Version)
 		budgets = append(budgets, budget)
 	}
 
@@ -113,7 +120,7 @@ func (r *GormBudgetRepository) FindByUserID(ctx context.Context, userID finance.
 func (r *GormBudgetRepository) FindByUserIDAndCategory(ctx context.Context, userID finance.UserID, categoryID finance.CategoryID) ([]*finance.Budget, error) {
 	var budgetModels []Budget
 
-	err := r.db.WithContext(ctx).Where("user_id = ? AND category_id = ?", userID.Value(), categoryID.Value()).Find(&budgetModels).Error
+	err := conn(ctx, r.db).Where("user_id = ? AND category_id = ?", userID.Value(), categoryID.Value()).Find(&budgetModels).Error
 	if err != nil {
 		return nil, err
 	}
@@ -136,7 +143,9 @@ func (r *GormBudgetRepository) FindByUserIDAndCategory(ctx context.Context, user
 			model.StartDate,
 		)
 		// Set the actual end date from database instead of calculated one
+		budget.UpdateProration(model.Prorated)
 		budget.UpdateEndDate(model.EndDate)
+		budget.AssignVersion(model.Version)
 		budgets = append(budgets, budget)
 	}
 
@@ -148,7 +157,7 @@ func (r *GormBudgetRepository) FindActiveByUserID(ctx context.Context, userID fi
 	var budgetModels []Budget
 	now := time.Now()
 
-	err := r.db.WithContext(ctx).Where("user_id = ? AND start_date <= ? AND end_date >= ?", userID.Value(), now, now).Find(&budgetModels).Error
+	err := conn(ctx, r.db).Where("user_id = ? AND start_date <= ? AND end_date >= ?", userID.Value(), now, now).Find(&budgetModels).Error
 	if err != nil {
 		return nil, err
 	}
@@ -171,22 +180,58 @@ func (r *GormBudgetRepository) FindActiveByUserID(ctx context.Context, userID fi
 			model.StartDate,
 		)
 		// Set the actual end date from database instead of calculated one
+		budget.UpdateProration(model.Prorated)
 		budget.UpdateEndDate(model.EndDate)
+		budget.AssignVersion(model.Version)
 		budgets = append(budgets, budget)
 	}
 
 	return budgets, nil
 }
 
-// Delete deletes a budget by ID
+// FindByUserIDUpdatedSince finds the user's budgets created or updated at or after since
+func (r *GormBudgetRepository) FindByUserIDUpdatedSince(ctx context.Context, userID finance.UserID, since time.Time) ([]*finance.Budget, error) {
+	var budgetModels []Budget
+
+	err := conn(ctx, r.db).Where("user_id = ? AND updated_at >= ?", userID.Value(), since).Order("updated_at, id").Find(&budgetModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	budgets := make([]*finance.Budget, 0, len(budgetModels))
+	for _, model := range budgetModels {
+		amount, _ := finance.NewMoney(model.Amount, finance.NewCurrencyID(1)) // Default currency ID
+		budget, err := finance.NewBudget(
+			finance.NewBudgetID(int(model.ID)),
+			finance.NewUserID(int(model.UserID)),
+			finance.NewCategoryID(int(model.CategoryID)),
+			amount,
+			finance.BudgetPeriod(model.Period),
+			model.StartDate,
+		)
+		if err != nil {
+			return nil, err
+		}
+		// Set the actual end date from database instead of calculated one
+		budget.UpdateProration(model.Prorated)
+		budget.UpdateEndDate(model.EndDate)
+		budget.AssignVersion(model.Version)
+		budgets = append(budgets, budget)
+	}
+
+	return budgets, nil
+}
+
+// Delete deletes a budget by ID, leaving a tombstone for syncing clients
 func (r *GormBudgetRepository) Delete(ctx context.Context, id finance.BudgetID) error {
-	return r.db.WithContext(ctx).Delete(&Budget{}, id.Value()).Error
+	_, err := deleteWithTombstone(conn(ctx, r.db), &Budget{}, finance.RecordKindBudget, id.Value())
+	return err
 }
 
 // ExistsByID checks if a budget exists with the given ID
 func (r *GormBudgetRepository) ExistsByID(ctx context.Context, id finance.BudgetID) (bool, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&Budget{}).Where("id = ?", id.Value()).Count(&count).Error
+	err := conn(ctx, r.db).Model(&Budget{}).Where("id = ?", id.Value()).Count(&count).Error
 	if err != nil {
 		return false, err
 	}
@@ -197,7 +242,17 @@ func (r *GormBudgetRepository) ExistsByID(ctx context.Context, id finance.Budget
 // GetTotalCount gets the total count of budgets
 func (r *GormBudgetRepository) GetTotalCount(ctx context.Context) (int, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&Budget{}).Count(&count).Error
+	err := conn(ctx, r.db).Model(&Budget{}).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// GetCountByUser gets the number of budgets a user has
+func (r *GormBudgetRepository) GetCountByUser(ctx context.Context, userID int) (int, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&Budget{}).Where("user_id = ?", userID).Count(&count).Error
 	if err != nil {
 		return 0, err
 	}
@@ -207,7 +262,7 @@ func (r *GormBudgetRepository) GetTotalCount(ctx context.Context) (int, error) {
 // GetCountByDateRange gets the count of budgets created within the date range
 func (r *GormBudgetRepository) GetCountByDateRange(ctx context.Context, startDate, endDate time.Time) (int, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&Budget{}).
+	err := conn(ctx, r.db).Model(&Budget{}).
 		Where("created_at >= ? AND created_at <= ?", startDate, endDate).
 		Count(&count).Error
 	if err != nil {
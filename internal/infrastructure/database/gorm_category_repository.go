@@ -2,14 +2,19 @@ package database
 
 import (
 	"context"
+	"errors"
 	"panda-pocket/internal/domain/finance"
+	"panda-pocket/internal/infrastructure/i18n"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 // GormCategoryRepository implements the CategoryRepository interface using GORM
 type GormCategoryRepository struct {
-	db *gorm.DB
+	db       *gorm.DB
+	defaults *defaultsCache[*finance.Category] // shared categories by locale; nil when not cached
 }
 
 // NewGormCategoryRepository creates a new GORM category repository
@@ -17,6 +22,12 @@ func NewGormCategoryRepository(db *gorm.DB) *GormCategoryRepository {
 	return &GormCategoryRepository{db: db}
 }
 
+// WithDefaultsCache returns a copy of the repository that keeps the categories
+// shared by every user in memory, as they are read on nearly every request
+func (r *GormCategoryRepository) WithDefaultsCache() *GormCategoryRepository {
+	return &GormCategoryRepository{db: r.db, defaults: newDefaultsCache[*finance.Category]()}
+}
+
 // Save saves a category to the database
 func (r *GormCategoryRepository) Save(ctx context.Context, category *finance.Category) error {
 	// Convert domain category to GORM model
@@ -36,19 +47,44 @@ func (r *GormCategoryRepository) Save(ctx context.Context, category *finance.Cat
 		categoryModel.UserID = &userID
 	}
 
+	if key := category.TranslationKey(); key != "" {
+		categoryModel.TranslationKey = &key
+	}
+
+	if sourceID := category.SourceID(); sourceID != nil {
+		source := uint(sourceID.Value())
+		categoryModel.SourceCategoryID = &source
+	}
+
 	// Save using GORM
-	if err := r.db.WithContext(ctx).Save(categoryModel).Error; err != nil {
+	if err := conn(ctx, r.db).Save(categoryModel).Error; err != nil {
 		return err
 	}
+	category.AssignID(finance.NewCategoryID(int(categoryModel.ID)))
 
+	if categoryModel.UserID == nil {
+		r.invalidateDefaults()
+	}
 	return nil
 }
 
 // FindByID finds a category by ID
 func (r *GormCategoryRepository) FindByID(ctx context.Context, id finance.CategoryID) (*finance.Category, error) {
+	if r.defaults != nil {
+		shared, err := r.sharedCategories(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, category := range shared {
+			if category.ID().Value() == id.Value() {
+				return category, nil
+			}
+		}
+	}
+
 	var categoryModel Category
 
-	err := r.db.WithContext(ctx).First(&categoryModel, id.Value()).Error
+	err := conn(ctx, r.db).First(&categoryModel, id.Value()).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, err
@@ -56,112 +92,94 @@ func (r *GormCategoryRepository) FindByID(ctx context.Context, id finance.Catego
 		return nil, err
 	}
 
-	// Convert GORM model to domain category
-	categoryID := finance.NewCategoryID(int(categoryModel.ID))
-	var userID *finance.UserID
-	if categoryModel.UserID != nil {
-		userIDVal := finance.NewUserID(int(*categoryModel.UserID))
-		userID = &userIDVal
-	}
-	categoryType := finance.CategoryType(categoryModel.CategoryType)
-
-	category, err := finance.NewCategory(
-		categoryID,
-		userID,
-		categoryModel.Name,
-		categoryModel.Color,
-		categoryModel.IsDefault,
-		categoryType,
-	)
+	categories, err := r.toDomain(ctx, []Category{categoryModel})
 	if err != nil {
 		return nil, err
 	}
 
-	return category, nil
+	return categories[0], nil
 }
 
 // FindByUserID finds all categories for a user
 func (r *GormCategoryRepository) FindByUserID(ctx context.Context, userID finance.UserID) ([]*finance.Category, error) {
+	if r.defaults != nil {
+		return r.withShared(ctx, conn(ctx, r.db).Where("user_id = ?", userID.Value()), nil)
+	}
+
 	var categoryModels []Category
 
-	err := r.db.WithContext(ctx).Where("user_id = ? OR user_id IS NULL", userID.Value()).Find(&categoryModels).Error
+	err := conn(ctx, r.db).Where("user_id = ? OR user_id IS NULL", userID.Value()).Find(&categoryModels).Error
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert GORM models to domain categories
-	var categories []*finance.Category
-	for _, model := range categoryModels {
-		categoryID := finance.NewCategoryID(int(model.ID))
-		var userID *finance.UserID
-		if model.UserID != nil {
-			userIDVal := finance.NewUserID(int(*model.UserID))
-			userID = &userIDVal
-		}
-		categoryType := finance.CategoryType(model.CategoryType)
-
-		category, err := finance.NewCategory(
-			categoryID,
-			userID,
-			model.Name,
-			model.Color,
-			model.IsDefault,
-			categoryType,
-		)
-		if err != nil {
-			return nil, err
-		}
-		categories = append(categories, category)
-	}
-
-	return categories, nil
+	return r.toDomain(ctx, categoryModels)
 }
 
 // FindByUserIDAndType finds categories by user ID and type
 func (r *GormCategoryRepository) FindByUserIDAndType(ctx context.Context, userID finance.UserID, categoryType finance.CategoryType) ([]*finance.Category, error) {
+	if r.defaults != nil {
+		owned := conn(ctx, r.db).Where("user_id = ? AND category_type = ?", userID.Value(), string(categoryType))
+		return r.withShared(ctx, owned, func(category *finance.Category) bool { return category.Type() == categoryType })
+	}
+
 	var categoryModels []Category
 
-	err := r.db.WithContext(ctx).Where("(user_id = ? OR user_id IS NULL) AND category_type = ?", userID.Value(), string(categoryType)).Find(&categoryModels).Error
+	err := conn(ctx, r.db).Where("(user_id = ? OR user_id IS NULL) AND category_type = ?", userID.Value(), string(categoryType)).Find(&categoryModels).Error
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert GORM models to domain categories
-	var categories []*finance.Category
-	for _, model := range categoryModels {
-		categoryID := finance.NewCategoryID(int(model.ID))
-		var userID *finance.UserID
-		if model.UserID != nil {
-			userIDVal := finance.NewUserID(int(*model.UserID))
-			userID = &userIDVal
-		}
+	return r.toDomain(ctx, categoryModels)
+}
 
-		category, err := finance.NewCategory(
-			categoryID,
-			userID,
-			model.Name,
-			model.Color,
-			model.IsDefault,
-			categoryType,
-		)
-		if err != nil {
-			return nil, err
+// FindCopy finds the user's copy of a default category
+func (r *GormCategoryRepository) FindCopy(ctx context.Context, userID finance.UserID, sourceID finance.CategoryID) (*finance.Category, error) {
+	var categoryModel Category
+	err := conn(ctx, r.db).Where("user_id = ? AND source_category_id = ?", userID.Value(), sourceID.Value()).First(&categoryModel).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, finance.ErrCategoryNotFound
 		}
-		categories = append(categories, category)
+		return nil, err
 	}
 
-	return categories, nil
+	categories, err := r.toDomain(ctx, []Category{categoryModel})
+	if err != nil {
+		return nil, err
+	}
+	return categories[0], nil
 }
 
-// Delete deletes a category by ID
+// FindByUserIDUpdatedSince finds the user's and default categories created or updated at or after since
+func (r *GormCategoryRepository) FindByUserIDUpdatedSince(ctx context.Context, userID finance.UserID, since time.Time) ([]*finance.Category, error) {
+	var categoryModels []Category
+
+	err := conn(ctx, r.db).
+		Where("(user_id = ? OR user_id IS NULL) AND updated_at >= ?", userID.Value(), since).
+		Order("updated_at, id").
+		Find(&categoryModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDomain(ctx, categoryModels)
+}
+
+// Delete deletes a category by ID, leaving a tombstone for syncing clients
 func (r *GormCategoryRepository) Delete(ctx context.Context, id finance.CategoryID) error {
-	return r.db.WithContext(ctx).Delete(&Category{}, id.Value()).Error
+	if _, err := deleteWithTombstone(conn(ctx, r.db), &Category{}, finance.RecordKindCategory, id.Value()); err != nil {
+		return err
+	}
+
+	r.invalidateDefaults()
+	return nil
 }
 
 // ExistsByID checks if a category exists with the given ID
 func (r *GormCategoryRepository) ExistsByID(ctx context.Context, id finance.CategoryID) (bool, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&Category{}).Where("id = ?", id.Value()).Count(&count).Error
+	err := conn(ctx, r.db).Model(&Category{}).Where("id = ?", id.Value()).Count(&count).Error
 	if err != nil {
 		return false, err
 	}
@@ -169,18 +187,109 @@ func (r *GormCategoryRepository) ExistsByID(ctx context.Context, id finance.Cate
 	return count > 0, nil
 }
 
+// GetCountByUser gets the number of categories a user has created, excluding the
+// defaults and their copies of them
+func (r *GormCategoryRepository) GetCountByUser(ctx context.Context, userID int) (int, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&Category{}).Where("user_id = ? AND source_category_id IS NULL", userID).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
 // FindDefaultCategories finds all default categories
 func (r *GormCategoryRepository) FindDefaultCategories(ctx context.Context) ([]*finance.Category, error) {
+	if r.defaults != nil {
+		shared, err := r.sharedCategories(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var categories []*finance.Category
+		for _, category := range shared {
+			if category.IsDefault() {
+				categories = append(categories, category)
+			}
+		}
+		return categories, nil
+	}
+
 	var categoryModels []Category
 
-	err := r.db.WithContext(ctx).Where("is_default = ?", true).Find(&categoryModels).Error
+	err := conn(ctx, r.db).Where("is_default = ?", true).Find(&categoryModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDomain(ctx, categoryModels)
+}
+
+// sharedCategories returns copies of the categories without an owner, from the
+// cache for the request's locales
+func (r *GormCategoryRepository) sharedCategories(ctx context.Context) ([]*finance.Category, error) {
+	cached, err := r.defaults.get(ctx, strings.Join(i18n.LocalesFromContext(ctx), ","), func() ([]*finance.Category, error) {
+		var models []Category
+		if err := conn(ctx, r.db).Where("user_id IS NULL").Order("id").Find(&models).Error; err != nil {
+			return nil, err
+		}
+		return r.toDomain(ctx, models)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Callers may modify what they are given, so the cached categories are never handed out
+	categories := make([]*finance.Category, len(cached))
+	for i, category := range cached {
+		clone := *category
+		categories[i] = &clone
+	}
+	return categories, nil
+}
+
+// withShared returns the cached shared categories that match, followed by the
+// categories the owned query finds
+func (r *GormCategoryRepository) withShared(ctx context.Context, owned *gorm.DB, match func(*finance.Category) bool) ([]*finance.Category, error) {
+	shared, err := r.sharedCategories(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert GORM models to domain categories
 	var categories []*finance.Category
-	for _, model := range categoryModels {
+	for _, category := range shared {
+		if match == nil || match(category) {
+			categories = append(categories, category)
+		}
+	}
+
+	var models []Category
+	if err := owned.Order("id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	own, err := r.toDomain(ctx, models)
+	if err != nil {
+		return nil, err
+	}
+	return append(categories, own...), nil
+}
+
+// invalidateDefaults drops the cached shared categories after a change
+func (r *GormCategoryRepository) invalidateDefaults() {
+	if r.defaults != nil {
+		r.defaults.invalidate()
+	}
+}
+
+// toDomain converts GORM models to domain categories, naming default categories in
+// the most preferred language of the request that has a translation
+func (r *GormCategoryRepository) toDomain(ctx context.Context, models []Category) ([]*finance.Category, error) {
+	names, err := r.translatedNames(ctx, models)
+	if err != nil {
+		return nil, err
+	}
+
+	var categories []*finance.Category
+	for _, model := range models {
 		categoryID := finance.NewCategoryID(int(model.ID))
 		var userID *finance.UserID
 		if model.UserID != nil {
@@ -189,19 +298,69 @@ func (r *GormCategoryRepository) FindDefaultCategories(ctx context.Context) ([]*
 		}
 		categoryType := finance.CategoryType(model.CategoryType)
 
-		category, err := finance.NewCategory(
+		name := model.Name
+		if model.TranslationKey != nil && names[*model.TranslationKey] != "" {
+			name = names[*model.TranslationKey]
+		}
+
+		category := finance.RestoreCategory(
 			categoryID,
 			userID,
-			model.Name,
+			name,
 			model.Color,
 			model.IsDefault,
 			categoryType,
+			model.CreatedAt,
 		)
-		if err != nil {
-			return nil, err
+		if model.TranslationKey != nil {
+			category.AssignTranslationKey(*model.TranslationKey)
+		}
+		if model.SourceCategoryID != nil {
+			category.AssignSourceID(finance.NewCategoryID(int(*model.SourceCategoryID)))
 		}
 		categories = append(categories, category)
 	}
 
 	return categories, nil
 }
+
+// translatedNames returns the best translation for each translation key among
+// models, keyed by translation key. Without preferred locales nothing is translated.
+func (r *GormCategoryRepository) translatedNames(ctx context.Context, models []Category) (map[string]string, error) {
+	locales := i18n.LocalesFromContext(ctx)
+	if len(locales) == 0 {
+		return nil, nil
+	}
+
+	var keys []string
+	for _, model := range models {
+		if model.TranslationKey != nil {
+			keys = append(keys, *model.TranslationKey)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	var translations []CategoryTranslation
+	err := conn(ctx, r.db).Where("translation_key IN ? AND locale IN ?", keys, locales).Find(&translations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	rank := make(map[string]int, len(locales))
+	for i, locale := range locales {
+		rank[locale] = i
+	}
+
+	names := make(map[string]string)
+	best := make(map[string]int)
+	for _, translation := range translations {
+		current, found := best[translation.TranslationKey]
+		if !found || rank[translation.Locale] < current {
+			best[translation.TranslationKey] = rank[translation.Locale]
+			names[translation.TranslationKey] = translation.Name
+		}
+	}
+	return names, nil
+}
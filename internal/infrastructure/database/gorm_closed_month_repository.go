@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormClosedMonthRepository implements the finance.ClosedMonthRepository interface using GORM
+type GormClosedMonthRepository struct {
+	db *gorm.DB
+}
+
+// NewGormClosedMonthRepository creates a new GORM closed month repository
+func NewGormClosedMonthRepository(db *gorm.DB) *GormClosedMonthRepository {
+	return &GormClosedMonthRepository{db: db}
+}
+
+// Save closes a month, returning ErrMonthAlreadyClosed when it already is
+func (r *GormClosedMonthRepository) Save(ctx context.Context, month *finance.ClosedMonth) error {
+	closed, err := r.IsClosed(ctx, month.UserID(), month.Month())
+	if err != nil {
+		return err
+	}
+	if closed {
+		return finance.ErrMonthAlreadyClosed
+	}
+
+	return conn(ctx, r.db).Create(&ClosedMonth{
+		UserID:   uint(month.UserID().Value()),
+		Month:    month.Month(),
+		ClosedAt: month.ClosedAt(),
+	}).Error
+}
+
+// FindByUserID finds a user's closed months, latest first
+func (r *GormClosedMonthRepository) FindByUserID(ctx context.Context, userID finance.UserID) ([]*finance.ClosedMonth, error) {
+	var models []ClosedMonth
+	if err := conn(ctx, r.db).Where("user_id = ?", userID.Value()).Order("month DESC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	months := make([]*finance.ClosedMonth, len(models))
+	for i, model := range models {
+		months[i] = finance.RestoreClosedMonth(finance.NewUserID(int(model.UserID)), model.Month.UTC(), model.ClosedAt)
+	}
+	return months, nil
+}
+
+// IsClosed reports whether the user has closed the month date falls in
+func (r *GormClosedMonthRepository) IsClosed(ctx context.Context, userID finance.UserID, date time.Time) (bool, error) {
+	var count int64
+	err := r.inMonth(ctx, userID, date).Model(&ClosedMonth{}).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Delete reopens the month date falls in
+func (r *GormClosedMonthRepository) Delete(ctx context.Context, userID finance.UserID, date time.Time) error {
+	result := r.inMonth(ctx, userID, date).Delete(&ClosedMonth{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return finance.ErrClosedMonthNotFound
+	}
+	return nil
+}
+
+// inMonth scopes a query to the user's row for the month date falls in
+func (r *GormClosedMonthRepository) inMonth(ctx context.Context, userID finance.UserID, date time.Time) *gorm.DB {
+	start := finance.MonthStart(date)
+	return conn(ctx, r.db).Where("user_id = ? AND month >= ? AND month < ?", userID.Value(), start, start.AddDate(0, 1, 0))
+}
@@ -6,11 +6,13 @@ import (
 	"panda-pocket/internal/domain/finance"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // GormCurrencyRepository implements the CurrencyRepository interface using GORM
 type GormCurrencyRepository struct {
-	db *gorm.DB
+	db       *gorm.DB
+	defaults *defaultsCache[*finance.Currency] // shared currencies; nil when not cached
 }
 
 // NewGormCurrencyRepository creates a new GORM currency repository
@@ -18,6 +20,12 @@ func NewGormCurrencyRepository(db *gorm.DB) *GormCurrencyRepository {
 	return &GormCurrencyRepository{db: db}
 }
 
+// WithDefaultsCache returns a copy of the repository that keeps the currencies
+// shared by every user in memory, as they are read on nearly every request
+func (r *GormCurrencyRepository) WithDefaultsCache() *GormCurrencyRepository {
+	return &GormCurrencyRepository{db: r.db, defaults: newDefaultsCache[*finance.Currency]()}
+}
+
 // Save saves a currency to the database
 func (r *GormCurrencyRepository) Save(ctx context.Context, currency *finance.Currency) error {
 	// Convert domain currency to GORM model
@@ -32,24 +40,39 @@ func (r *GormCurrencyRepository) Save(ctx context.Context, currency *finance.Cur
 		currencyModel.ID = uint(currency.ID().Value())
 	}
 
-	if currency.UserID().Value() != 0 {
-		userID := uint(currency.UserID().Value())
+	if owner := currency.UserID(); owner != nil && owner.Value() != 0 {
+		userID := uint(owner.Value())
 		currencyModel.UserID = &userID
 	}
 
 	// Save using GORM
-	if err := r.db.WithContext(ctx).Save(currencyModel).Error; err != nil {
+	if err := conn(ctx, r.db).Save(currencyModel).Error; err != nil {
 		return err
 	}
 
+	if currencyModel.UserID == nil {
+		r.invalidateDefaults()
+	}
 	return nil
 }
 
 // FindByID finds a currency by ID
 func (r *GormCurrencyRepository) FindByID(ctx context.Context, id finance.CurrencyID) (*finance.Currency, error) {
+	if r.defaults != nil {
+		shared, err := r.sharedCurrencies(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, currency := range shared {
+			if currency.ID().Value() == id.Value() {
+				return currency, nil
+			}
+		}
+	}
+
 	var currencyModel Currency
 
-	err := r.db.WithContext(ctx).First(&currencyModel, id.Value()).Error
+	err := conn(ctx, r.db).First(&currencyModel, id.Value()).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, err
@@ -82,45 +105,38 @@ func (r *GormCurrencyRepository) FindByID(ctx context.Context, id finance.Curren
 
 // FindByUserID finds all currencies for a user
 func (r *GormCurrencyRepository) FindByUserID(ctx context.Context, userID finance.UserID) ([]*finance.Currency, error) {
-	var currencyModels []Currency
-
-	err := r.db.WithContext(ctx).Where("user_id = ? OR user_id IS NULL", userID.Value()).Find(&currencyModels).Error
-	if err != nil {
-		return nil, err
-	}
-
-	// Convert GORM models to domain currencies
-	var currencies []*finance.Currency
-	for _, model := range currencyModels {
-		currencyID := finance.NewCurrencyID(int(model.ID))
-		var userID *finance.UserID
-		if model.UserID != nil {
-			userIDVal := finance.NewUserID(int(*model.UserID))
-			userID = &userIDVal
+	if r.defaults != nil {
+		shared, err := r.sharedCurrencies(ctx)
+		if err != nil {
+			return nil, err
 		}
 
-		currency, err := finance.NewCurrency(
-			currencyID,
-			userID,
-			model.Code,
-			model.Name,
-			model.Symbol,
-			model.IsDefault,
-		)
+		var owned []Currency
+		if err := conn(ctx, r.db).Where("user_id = ?", userID.Value()).Order("id").Find(&owned).Error; err != nil {
+			return nil, err
+		}
+		currencies, err := toDomainCurrencies(owned)
 		if err != nil {
 			return nil, err
 		}
-		currencies = append(currencies, currency)
+		return append(shared, currencies...), nil
 	}
 
-	return currencies, nil
+	var currencyModels []Currency
+
+	err := conn(ctx, r.db).Where("user_id = ? OR user_id IS NULL", userID.Value()).Find(&currencyModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainCurrencies(currencyModels)
 }
 
 // FindByCode finds a currency by code
 func (r *GormCurrencyRepository) FindByCode(ctx context.Context, code string) (*finance.Currency, error) {
 	var currencyModel Currency
 
-	err := r.db.WithContext(ctx).Where("code = ?", code).First(&currencyModel).Error
+	err := conn(ctx, r.db).Where("code = ?", code).First(&currencyModel).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, err
@@ -153,16 +169,63 @@ func (r *GormCurrencyRepository) FindByCode(ctx context.Context, code string) (*
 
 // FindDefaultCurrencies finds all default currencies
 func (r *GormCurrencyRepository) FindDefaultCurrencies(ctx context.Context) ([]*finance.Currency, error) {
+	if r.defaults != nil {
+		shared, err := r.sharedCurrencies(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var currencies []*finance.Currency
+		for _, currency := range shared {
+			if currency.IsDefault() {
+				currencies = append(currencies, currency)
+			}
+		}
+		return currencies, nil
+	}
+
 	var currencyModels []Currency
 
-	err := r.db.WithContext(ctx).Where("is_default = ?", true).Find(&currencyModels).Error
+	err := conn(ctx, r.db).Where("is_default = ?", true).Find(&currencyModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainCurrencies(currencyModels)
+}
+
+// sharedCurrencies returns copies of the currencies without an owner, from the cache
+func (r *GormCurrencyRepository) sharedCurrencies(ctx context.Context) ([]*finance.Currency, error) {
+	cached, err := r.defaults.get(ctx, "", func() ([]*finance.Currency, error) {
+		var models []Currency
+		if err := conn(ctx, r.db).Where("user_id IS NULL").Order("id").Find(&models).Error; err != nil {
+			return nil, err
+		}
+		return toDomainCurrencies(models)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert GORM models to domain currencies
+	// Callers may modify what they are given, so the cached currencies are never handed out
+	currencies := make([]*finance.Currency, len(cached))
+	for i, currency := range cached {
+		clone := *currency
+		currencies[i] = &clone
+	}
+	return currencies, nil
+}
+
+// invalidateDefaults drops the cached shared currencies after a change
+func (r *GormCurrencyRepository) invalidateDefaults() {
+	if r.defaults != nil {
+		r.defaults.invalidate()
+	}
+}
+
+// toDomainCurrencies converts GORM currency models to domain currencies
+func toDomainCurrencies(models []Currency) ([]*finance.Currency, error) {
 	var currencies []*finance.Currency
-	for _, model := range currencyModels {
+	for _, model := range models {
 		currencyID := finance.NewCurrencyID(int(model.ID))
 		var userID *finance.UserID
 		if model.UserID != nil {
@@ -189,13 +252,18 @@ func (r *GormCurrencyRepository) FindDefaultCurrencies(ctx context.Context) ([]*
 
 // Delete deletes a currency by ID
 func (r *GormCurrencyRepository) Delete(ctx context.Context, id finance.CurrencyID) error {
-	return r.db.WithContext(ctx).Delete(&Currency{}, id.Value()).Error
+	if err := conn(ctx, r.db).Delete(&Currency{}, id.Value()).Error; err != nil {
+		return err
+	}
+
+	r.invalidateDefaults()
+	return nil
 }
 
 // ExistsByID checks if a currency exists with the given ID
 func (r *GormCurrencyRepository) ExistsByID(ctx context.Context, id finance.CurrencyID) (bool, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&Currency{}).Where("id = ?", id.Value()).Count(&count).Error
+	err := conn(ctx, r.db).Model(&Currency{}).Where("id = ?", id.Value()).Count(&count).Error
 	if err != nil {
 		return false, err
 	}
@@ -206,7 +274,7 @@ func (r *GormCurrencyRepository) ExistsByID(ctx context.Context, id finance.Curr
 // ExistsByCodeAndUserID checks if a currency exists with the given code and user ID
 func (r *GormCurrencyRepository) ExistsByCodeAndUserID(ctx context.Context, code string, userID finance.UserID) (bool, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&Currency{}).Where("code = ? AND (user_id = ? OR user_id IS NULL)", code, userID.Value()).Count(&count).Error
+	err := conn(ctx, r.db).Model(&Currency{}).Where("code = ? AND (user_id = ? OR user_id IS NULL)", code, userID.Value()).Count(&count).Error
 	if err != nil {
 		return false, err
 	}
@@ -214,31 +282,22 @@ func (r *GormCurrencyRepository) ExistsByCodeAndUserID(ctx context.Context, code
 	return count > 0, nil
 }
 
-// SetUserDefaultCurrency sets the default currency for a user
+// SetUserDefaultCurrency sets the default currency for a user in their
+// preferences. Users without preferences get them with every other setting at
+// its default; a single upsert keeps concurrent first saves from colliding on
+// the unique user_id.
 func (r *GormCurrencyRepository) SetUserDefaultCurrency(ctx context.Context, userID finance.UserID, currencyID finance.CurrencyID) error {
-	// Use the existing UserPreferences model
-	var preferences UserPreferences
-
-	// Check if user preferences already exist
-	err := r.db.WithContext(ctx).Where("user_id = ?", userID.Value()).First(&preferences).Error
-	if err != nil {
-		// If not found, create new preferences
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			preferences = UserPreferences{
-				UserID:             uint(userID.Value()),
-				PrimaryCurrencyID:  uint(currencyID.Value()),
-				EmailNotifications: true,
-				BudgetAlerts:       true,
-				RecurringReminders: true,
-			}
-			return r.db.WithContext(ctx).Create(&preferences).Error
-		}
-		return err
+	preferences := UserPreferences{
+		UserID:             uint(userID.Value()),
+		PrimaryCurrencyID:  uint(currencyID.Value()),
+		EmailNotifications: true,
+		BudgetAlerts:       true,
+		RecurringReminders: true,
 	}
-
-	// Update existing preferences
-	preferences.PrimaryCurrencyID = uint(currencyID.Value())
-	return r.db.WithContext(ctx).Save(&preferences).Error
+	return conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"primary_currency_id", "updated_at"}),
+	}).Create(&preferences).Error
 }
 
 // GetUserDefaultCurrency gets the default currency for a user
@@ -246,14 +305,39 @@ func (r *GormCurrencyRepository) GetUserDefaultCurrency(ctx context.Context, use
 	var preferences UserPreferences
 
 	// Get user preferences
-	err := r.db.WithContext(ctx).Where("user_id = ?", userID.Value()).First(&preferences).Error
+	err := conn(ctx, r.db).Where("user_id = ?", userID.Value()).First(&preferences).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("no default currency set")
+			return nil, finance.ErrDefaultCurrencyNotSet
 		}
 		return nil, err
 	}
 
 	// Get the currency by ID
-	return r.FindByID(ctx, finance.NewCurrencyID(int(preferences.PrimaryCurrencyID)))
+	currency, err := r.FindByID(ctx, finance.NewCurrencyID(int(preferences.PrimaryCurrencyID)))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, finance.ErrCurrencyNotFound
+	}
+	return currency, err
+}
+
+// IsInUse checks if any transaction, recurring or scheduled transaction or user preference references the currency
+func (r *GormCurrencyRepository) IsInUse(ctx context.Context, id finance.CurrencyID) (bool, error) {
+	for _, model := range []interface{}{&Expense{}, &Income{}, &RecurringTransaction{}, &ScheduledTransaction{}} {
+		var count int64
+		if err := conn(ctx, r.db).Model(model).Where("currency_id = ?", id.Value()).Count(&count).Error; err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+
+	var count int64
+	err := conn(ctx, r.db).Model(&UserPreferences{}).Where("primary_currency_id = ?", id.Value()).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
 }
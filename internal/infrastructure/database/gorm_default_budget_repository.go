@@ -0,0 +1,58 @@
+package database
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+
+	"gorm.io/gorm"
+)
+
+// GormDefaultBudgetRepository implements the finance.DefaultBudgetRepository interface using GORM
+type GormDefaultBudgetRepository struct {
+	db *gorm.DB
+}
+
+// NewGormDefaultBudgetRepository creates a new GORM default budget repository
+func NewGormDefaultBudgetRepository(db *gorm.DB) *GormDefaultBudgetRepository {
+	return &GormDefaultBudgetRepository{db: db}
+}
+
+// FindAll returns the template, in the order it was saved
+func (r *GormDefaultBudgetRepository) FindAll(ctx context.Context) ([]*finance.DefaultBudget, error) {
+	var models []DefaultBudget
+	if err := conn(ctx, r.db).Order("id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	budgets := make([]*finance.DefaultBudget, 0, len(models))
+	for _, m := range models {
+		budget, err := finance.NewDefaultBudget(finance.NewCategoryID(int(m.CategoryID)), m.Amount, finance.BudgetPeriod(m.Period))
+		if err != nil {
+			return nil, err
+		}
+		budgets = append(budgets, budget)
+	}
+	return budgets, nil
+}
+
+// ReplaceAll replaces the whole template in one transaction
+func (r *GormDefaultBudgetRepository) ReplaceAll(ctx context.Context, budgets []*finance.DefaultBudget) error {
+	models := make([]DefaultBudget, len(budgets))
+	for i, budget := range budgets {
+		models[i] = DefaultBudget{
+			CategoryID: uint(budget.CategoryID().Value()),
+			Amount:     budget.Amount(),
+			Period:     string(budget.Period()),
+		}
+	}
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&DefaultBudget{}).Error; err != nil {
+			return err
+		}
+		if len(models) == 0 {
+			return nil
+		}
+		return tx.Create(&models).Error
+	})
+}
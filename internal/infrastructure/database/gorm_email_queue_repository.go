@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"panda-pocket/internal/infrastructure/mail"
+
+	"gorm.io/gorm"
+)
+
+// Email queue statuses
+const (
+	emailStatusPending = "pending"
+	emailStatusSent    = "sent"
+	emailStatusDead    = "dead"
+)
+
+// GormEmailQueueRepository implements the mail.QueueStore interface using GORM
+type GormEmailQueueRepository struct {
+	db *gorm.DB
+}
+
+// NewGormEmailQueueRepository creates a new GORM email queue repository
+func NewGormEmailQueueRepository(db *gorm.DB) *GormEmailQueueRepository {
+	return &GormEmailQueueRepository{db: db}
+}
+
+// Enqueue stores a pending email and assigns its ID
+func (r *GormEmailQueueRepository) Enqueue(ctx context.Context, email *mail.QueuedEmail) error {
+	model := &QueuedEmail{
+		Recipient:     email.To,
+		Subject:       email.Subject,
+		TextBody:      email.Text,
+		HTMLBody:      email.HTML,
+		Status:        emailStatusPending,
+		NextAttemptAt: email.NextAttemptAt,
+		CreatedAt:     email.CreatedAt,
+	}
+	if err := conn(ctx, r.db).Create(model).Error; err != nil {
+		return err
+	}
+
+	email.ID = model.ID
+	return nil
+}
+
+// Due returns up to limit pending emails whose next attempt is due, oldest first
+func (r *GormEmailQueueRepository) Due(ctx context.Context, now time.Time, limit int) ([]mail.QueuedEmail, error) {
+	var models []QueuedEmail
+	err := conn(ctx, r.db).
+		Where("status = ? AND next_attempt_at <= ?", emailStatusPending, now).
+		Order("next_attempt_at ASC, id ASC").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return toQueuedEmails(models), nil
+}
+
+// MarkSent records a delivery and drops the bodies
+func (r *GormEmailQueueRepository) MarkSent(ctx context.Context, id uint, at time.Time) error {
+	return conn(ctx, r.db).Model(&QueuedEmail{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     emailStatusSent,
+		"attempts":   gorm.Expr("attempts + 1"),
+		"sent_at":    at,
+		"text_body":  "",
+		"html_body":  "",
+		"last_error": "",
+	}).Error
+}
+
+// MarkFailed records a failed attempt; without retryAt the email is dead-lettered
+func (r *GormEmailQueueRepository) MarkFailed(ctx context.Context, id uint, reason string, retryAt *time.Time) error {
+	updates := map[string]interface{}{
+		"attempts":   gorm.Expr("attempts + 1"),
+		"last_error": reason,
+	}
+	if retryAt != nil {
+		updates["next_attempt_at"] = *retryAt
+	} else {
+		updates["status"] = emailStatusDead
+	}
+	return conn(ctx, r.db).Model(&QueuedEmail{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// DeadLetters returns one page of dead-lettered emails, newest first, and their total number
+func (r *GormEmailQueueRepository) DeadLetters(ctx context.Context, limit, offset int) ([]mail.QueuedEmail, int64, error) {
+	query := conn(ctx, r.db).Model(&QueuedEmail{}).Where("status = ?", emailStatusDead)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var models []QueuedEmail
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&models).Error; err != nil {
+		return nil, 0, err
+	}
+	return toQueuedEmails(models), total, nil
+}
+
+// Requeue returns a dead-lettered email to the queue for another round of attempts
+func (r *GormEmailQueueRepository) Requeue(ctx context.Context, id uint, at time.Time) error {
+	result := conn(ctx, r.db).Model(&QueuedEmail{}).
+		Where("id = ? AND status = ?", id, emailStatusDead).
+		Updates(map[string]interface{}{
+			"status":          emailStatusPending,
+			"attempts":        0,
+			"next_attempt_at": at,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return mail.ErrEmailNotFound
+	}
+	return nil
+}
+
+// toQueuedEmails converts GORM email models to queued emails
+func toQueuedEmails(models []QueuedEmail) []mail.QueuedEmail {
+	emails := make([]mail.QueuedEmail, 0, len(models))
+	for _, model := range models {
+		emails = append(emails, mail.QueuedEmail{
+			ID:            model.ID,
+			To:            model.Recipient,
+			Subject:       model.Subject,
+			Text:          model.TextBody,
+			HTML:          model.HTMLBody,
+			Attempts:      model.Attempts,
+			LastError:     model.LastError,
+			NextAttemptAt: model.NextAttemptAt,
+			CreatedAt:     model.CreatedAt,
+		})
+	}
+	return emails
+}
@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormExchangeRateRepository implements the finance.ExchangeRateRepository interface using GORM
+type GormExchangeRateRepository struct {
+	db *gorm.DB
+}
+
+// NewGormExchangeRateRepository creates a new GORM exchange rate repository
+func NewGormExchangeRateRepository(db *gorm.DB) *GormExchangeRateRepository {
+	return &GormExchangeRateRepository{db: db}
+}
+
+// Save stores a rate, replacing any rate of the same pair on the same date
+func (r *GormExchangeRateRepository) Save(ctx context.Context, rate *finance.ExchangeRate) error {
+	var model ExchangeRate
+	err := conn(ctx, r.db).
+		Where("base_currency = ? AND quote_currency = ? AND date >= ? AND date < ?",
+			rate.Base(), rate.Quote(), rate.Date(), rate.Date().Add(24*time.Hour)).
+		First(&model).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return err
+	}
+
+	model.BaseCurrency = rate.Base()
+	model.QuoteCurrency = rate.Quote()
+	model.Rate = rate.Rate()
+	model.Date = rate.Date()
+	if err := conn(ctx, r.db).Save(&model).Error; err != nil {
+		return err
+	}
+
+	rate.AssignID(finance.NewExchangeRateID(int(model.ID)))
+	return nil
+}
+
+// FindByPair returns the rates converting base into quote, oldest first
+func (r *GormExchangeRateRepository) FindByPair(ctx context.Context, base, quote string) ([]*finance.ExchangeRate, error) {
+	var models []ExchangeRate
+	err := conn(ctx, r.db).Where("base_currency = ? AND quote_currency = ?", base, quote).Order("date").Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make([]*finance.ExchangeRate, len(models))
+	for i, model := range models {
+		rates[i] = finance.RestoreExchangeRate(
+			finance.NewExchangeRateID(int(model.ID)),
+			model.BaseCurrency,
+			model.QuoteCurrency,
+			model.Rate,
+			model.Date.UTC(),
+		)
+	}
+	return rates, nil
+}
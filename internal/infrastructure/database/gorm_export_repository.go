@@ -0,0 +1,158 @@
+package database
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormExportScheduleRepository implements the ExportScheduleRepository interface using GORM
+type GormExportScheduleRepository struct {
+	db *gorm.DB
+}
+
+// NewGormExportScheduleRepository creates a new GORM export schedule repository
+func NewGormExportScheduleRepository(db *gorm.DB) *GormExportScheduleRepository {
+	return &GormExportScheduleRepository{db: db}
+}
+
+// Save saves an export schedule and assigns its ID
+func (r *GormExportScheduleRepository) Save(ctx context.Context, schedule *finance.ExportSchedule) error {
+	model := &ExportSchedule{
+		ID:          uint(schedule.ID().Value()),
+		UserID:      uint(schedule.UserID().Value()),
+		Format:      string(schedule.Format()),
+		Provider:    string(schedule.Provider()),
+		Target:      schedule.Target(),
+		AccessToken: schedule.AccessToken(),
+		Frequency:   string(schedule.Frequency()),
+		NextRunAt:   schedule.NextRunAt(),
+		CreatedAt:   schedule.CreatedAt(),
+	}
+	if err := conn(ctx, r.db).Save(model).Error; err != nil {
+		return err
+	}
+
+	schedule.AssignID(finance.NewExportScheduleID(int(model.ID)))
+	return nil
+}
+
+// FindByID finds an export schedule by ID
+func (r *GormExportScheduleRepository) FindByID(ctx context.Context, id finance.ExportScheduleID) (*finance.ExportSchedule, error) {
+	var model ExportSchedule
+	err := conn(ctx, r.db).First(&model, id.Value()).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, finance.ErrExportScheduleNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainExportSchedule(model), nil
+}
+
+// FindByUserID finds a user's export schedules
+func (r *GormExportScheduleRepository) FindByUserID(ctx context.Context, userID finance.UserID) ([]*finance.ExportSchedule, error) {
+	return r.find(conn(ctx, r.db).Where("user_id = ?", userID.Value()))
+}
+
+// FindDue finds the schedules whose next run is at or before the given time
+func (r *GormExportScheduleRepository) FindDue(ctx context.Context, at time.Time) ([]*finance.ExportSchedule, error) {
+	return r.find(conn(ctx, r.db).Where("next_run_at <= ?", at))
+}
+
+// Delete deletes an export schedule by ID
+func (r *GormExportScheduleRepository) Delete(ctx context.Context, id finance.ExportScheduleID) error {
+	return conn(ctx, r.db).Delete(&ExportSchedule{}, id.Value()).Error
+}
+
+// find runs a schedule query, ordered by ID
+func (r *GormExportScheduleRepository) find(query *gorm.DB) ([]*finance.ExportSchedule, error) {
+	var models []ExportSchedule
+	if err := query.Order("id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	schedules := make([]*finance.ExportSchedule, len(models))
+	for i, model := range models {
+		schedules[i] = toDomainExportSchedule(model)
+	}
+	return schedules, nil
+}
+
+// toDomainExportSchedule converts a GORM export schedule model to a domain export schedule
+func toDomainExportSchedule(model ExportSchedule) *finance.ExportSchedule {
+	return finance.RestoreExportSchedule(
+		finance.NewExportScheduleID(int(model.ID)),
+		finance.NewUserID(int(model.UserID)),
+		finance.ExportFormat(model.Format),
+		finance.StorageProvider(model.Provider),
+		model.Target,
+		model.AccessToken,
+		finance.Frequency(model.Frequency),
+		model.NextRunAt,
+		model.CreatedAt,
+	)
+}
+
+// GormExportRunRepository implements the ExportRunRepository interface using GORM
+type GormExportRunRepository struct {
+	db *gorm.DB
+}
+
+// NewGormExportRunRepository creates a new GORM export run repository
+func NewGormExportRunRepository(db *gorm.DB) *GormExportRunRepository {
+	return &GormExportRunRepository{db: db}
+}
+
+// Save saves an export run and assigns its ID
+func (r *GormExportRunRepository) Save(ctx context.Context, run *finance.ExportRun) error {
+	model := &ExportRun{
+		ID:               uint(run.ID().Value()),
+		UserID:           uint(run.UserID().Value()),
+		ScheduleID:       uint(run.ScheduleID().Value()),
+		Provider:         string(run.Provider()),
+		FileName:         run.FileName(),
+		TransactionCount: run.TransactionCount(),
+		Status:           string(run.Status()),
+		Error:            run.ErrorMessage(),
+		CreatedAt:        run.CreatedAt(),
+	}
+	if err := conn(ctx, r.db).Save(model).Error; err != nil {
+		return err
+	}
+
+	run.AssignID(finance.NewExportRunID(int(model.ID)))
+	return nil
+}
+
+// FindByUserID finds a user's most recent export runs, newest first
+func (r *GormExportRunRepository) FindByUserID(ctx context.Context, userID finance.UserID, limit int) ([]*finance.ExportRun, error) {
+	var models []ExportRun
+	err := conn(ctx, r.db).
+		Where("user_id = ?", userID.Value()).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]*finance.ExportRun, len(models))
+	for i, model := range models {
+		runs[i] = finance.RestoreExportRun(
+			finance.NewExportRunID(int(model.ID)),
+			finance.NewExportScheduleID(int(model.ScheduleID)),
+			finance.NewUserID(int(model.UserID)),
+			finance.StorageProvider(model.Provider),
+			model.FileName,
+			model.TransactionCount,
+			finance.ExportStatus(model.Status),
+			model.Error,
+			model.CreatedAt,
+		)
+	}
+	return runs, nil
+}
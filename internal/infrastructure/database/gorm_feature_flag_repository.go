@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+
+	"panda-pocket/internal/infrastructure/featureflags"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormFeatureFlagRepository implements the featureflags.Store interface using GORM
+type GormFeatureFlagRepository struct {
+	db *gorm.DB
+}
+
+// NewGormFeatureFlagRepository creates a new GORM feature flag repository
+func NewGormFeatureFlagRepository(db *gorm.DB) *GormFeatureFlagRepository {
+	return &GormFeatureFlagRepository{db: db}
+}
+
+// List returns every flag
+func (r *GormFeatureFlagRepository) List(ctx context.Context) ([]featureflags.Flag, error) {
+	var models []FeatureFlag
+	if err := conn(ctx, r.db).Order("flag_key").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	flags := make([]featureflags.Flag, 0, len(models))
+	for _, model := range models {
+		userIDs := model.UserIDs
+		if userIDs == nil {
+			userIDs = []int{}
+		}
+		flags = append(flags, featureflags.Flag{
+			Key:               model.Key,
+			Description:       model.Description,
+			Enabled:           model.Enabled,
+			RolloutPercentage: model.RolloutPercentage,
+			UserIDs:           userIDs,
+			UpdatedAt:         model.UpdatedAt,
+		})
+	}
+	return flags, nil
+}
+
+// Save creates or replaces the flag with the same key
+func (r *GormFeatureFlagRepository) Save(ctx context.Context, flag featureflags.Flag) error {
+	model := FeatureFlag{
+		Key:               flag.Key,
+		Description:       flag.Description,
+		Enabled:           flag.Enabled,
+		RolloutPercentage: flag.RolloutPercentage,
+		UserIDs:           flag.UserIDs,
+		UpdatedAt:         flag.UpdatedAt,
+	}
+
+	// Select every column so a false Enabled is written rather than the column default
+	return conn(ctx, r.db).Select("*").Omit("id").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "flag_key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"description", "enabled", "rollout_percentage", "user_ids", "updated_at"}),
+	}).Create(&model).Error
+}
+
+// Delete removes a flag
+func (r *GormFeatureFlagRepository) Delete(ctx context.Context, key string) error {
+	result := conn(ctx, r.db).Where("flag_key = ?", key).Delete(&FeatureFlag{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return featureflags.ErrFlagNotFound
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"panda-pocket/internal/domain/identity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormGrowthMetricsRepository implements the identity.GrowthMetricsRepository interface using GORM
+type GormGrowthMetricsRepository struct {
+	db *gorm.DB
+}
+
+// NewGormGrowthMetricsRepository creates a new GORM growth metrics repository
+func NewGormGrowthMetricsRepository(db *gorm.DB) *GormGrowthMetricsRepository {
+	return &GormGrowthMetricsRepository{db: db}
+}
+
+// periodCount is one row of a count grouped by day or month
+type periodCount struct {
+	Period string
+	Count  int
+}
+
+// DailySignups counts the users created on each day in [from, to)
+func (r *GormGrowthMetricsRepository) DailySignups(ctx context.Context, from, to time.Time) (map[string]int, error) {
+	// SQLite compares timestamps as text, which only orders correctly in one time zone
+	from, to = from.Local(), to.Local()
+	var rows []periodCount
+	err := conn(ctx, r.db).Model(&User{}).
+		Select(r.formatDate("created_at", false)+" AS period, COUNT(*) AS count").
+		Where("role = ? AND created_at >= ? AND created_at < ?", "user", from, to).
+		Group("period").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return countsByPeriod(rows), nil
+}
+
+// DailyTransactions counts the expenses and incomes recorded on each day in [from, to)
+func (r *GormGrowthMetricsRepository) DailyTransactions(ctx context.Context, from, to time.Time) (map[string]int, error) {
+	from, to = from.Local(), to.Local()
+	counts := make(map[string]int)
+	for _, model := range []interface{}{&Expense{}, &Income{}} {
+		var rows []periodCount
+		err := conn(ctx, r.db).Model(model).
+			Select(r.formatDate("created_at", false)+" AS period, COUNT(*) AS count").
+			Where("created_at >= ? AND created_at < ?", from, to).
+			Group("period").
+			Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		for period, count := range countsByPeriod(rows) {
+			counts[period] += count
+		}
+	}
+	return counts, nil
+}
+
+// DailyActiveUsers counts the users who made an API request on each day from fromDay to toDay
+func (r *GormGrowthMetricsRepository) DailyActiveUsers(ctx context.Context, fromDay, toDay string) (map[string]int, error) {
+	var rows []periodCount
+	err := conn(ctx, r.db).Model(&APIDailyUsage{}).
+		Select("api_daily_usages.day AS period, COUNT(*) AS count").
+		Joins("JOIN users ON users.id = api_daily_usages.user_id").
+		Where("users.role = ? AND api_daily_usages.day BETWEEN ? AND ?", "user", fromDay, toDay).
+		Group("api_daily_usages.day").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return countsByPeriod(rows), nil
+}
+
+// ActiveUsers counts the users who made an API request on any day from fromDay to toDay
+func (r *GormGrowthMetricsRepository) ActiveUsers(ctx context.Context, fromDay, toDay string) (int, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&APIDailyUsage{}).
+		Joins("JOIN users ON users.id = api_daily_usages.user_id").
+		Where("users.role = ? AND api_daily_usages.day BETWEEN ? AND ?", "user", fromDay, toDay).
+		Distinct("api_daily_usages.user_id").
+		Count(&count).Error
+	return int(count), err
+}
+
+// MonthlySignups counts the users created in each month since the given time
+func (r *GormGrowthMetricsRepository) MonthlySignups(ctx context.Context, since time.Time) (map[string]int, error) {
+	since = since.Local()
+	var rows []periodCount
+	err := conn(ctx, r.db).Model(&User{}).
+		Select(r.formatDate("created_at", true)+" AS period, COUNT(*) AS count").
+		Where("role = ? AND created_at >= ?", "user", since).
+		Group("period").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return countsByPeriod(rows), nil
+}
+
+// CohortActivity counts, for the users created since the given time, how many of
+// each signup month made an API request in each month
+func (r *GormGrowthMetricsRepository) CohortActivity(ctx context.Context, since time.Time) ([]identity.CohortActivity, error) {
+	since = since.Local()
+	var rows []struct {
+		SignupMonth string
+		ActiveMonth string
+		UserCount   int
+	}
+	err := conn(ctx, r.db).Model(&User{}).
+		Select(r.formatDate("users.created_at", true)+" AS signup_month, api_monthly_usages.month AS active_month, COUNT(*) AS user_count").
+		Joins("JOIN api_monthly_usages ON api_monthly_usages.user_id = users.id").
+		Where("users.role = ? AND users.created_at >= ?", "user", since).
+		Group("signup_month, api_monthly_usages.month").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	activity := make([]identity.CohortActivity, 0, len(rows))
+	for _, row := range rows {
+		activity = append(activity, identity.CohortActivity{
+			SignupMonth: row.SignupMonth,
+			ActiveMonth: row.ActiveMonth,
+			Users:       row.UserCount,
+		})
+	}
+	return activity, nil
+}
+
+// formatDate returns the SQL formatting a timestamp column as YYYY-MM-DD, or as
+// YYYY-MM for months, in the dialect. SQLite stores timestamps as text that
+// starts with the date.
+func (r *GormGrowthMetricsRepository) formatDate(column string, month bool) string {
+	switch r.db.Dialector.Name() {
+	case "postgres":
+		if month {
+			return fmt.Sprintf("TO_CHAR(%s, 'YYYY-MM')", column)
+		}
+		return fmt.Sprintf("TO_CHAR(%s, 'YYYY-MM-DD')", column)
+	case "mysql":
+		if month {
+			return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m')", column)
+		}
+		return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m-%%d')", column)
+	default:
+		if month {
+			return fmt.Sprintf("SUBSTR(%s, 1, 7)", column)
+		}
+		return fmt.Sprintf("SUBSTR(%s, 1, 10)", column)
+	}
+}
+
+// countsByPeriod converts grouped rows to counts keyed by period
+func countsByPeriod(rows []periodCount) map[string]int {
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Period] = row.Count
+	}
+	return counts
+}
@@ -0,0 +1,154 @@
+package database
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormHoldingRepository implements the HoldingRepository interface using GORM
+type GormHoldingRepository struct {
+	db *gorm.DB
+}
+
+// NewGormHoldingRepository creates a new GORM holding repository
+func NewGormHoldingRepository(db *gorm.DB) *GormHoldingRepository {
+	return &GormHoldingRepository{db: db}
+}
+
+// Save saves a holding and assigns its ID
+func (r *GormHoldingRepository) Save(ctx context.Context, holding *finance.Holding) error {
+	model := &Holding{
+		ID:         uint(holding.ID().Value()),
+		UserID:     uint(holding.UserID().Value()),
+		Ticker:     holding.Ticker(),
+		Quantity:   holding.Quantity(),
+		CostBasis:  holding.CostBasis(),
+		CurrencyID: uint(holding.CurrencyID().Value()),
+		CreatedAt:  holding.CreatedAt(),
+	}
+	if err := conn(ctx, r.db).Save(model).Error; err != nil {
+		return err
+	}
+
+	holding.AssignID(finance.NewHoldingID(int(model.ID)))
+	return nil
+}
+
+// FindByID finds a holding by ID
+func (r *GormHoldingRepository) FindByID(ctx context.Context, id finance.HoldingID) (*finance.Holding, error) {
+	var model Holding
+	err := conn(ctx, r.db).First(&model, id.Value()).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, finance.ErrHoldingNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainHolding(model), nil
+}
+
+// FindByUserID finds a user's holdings
+func (r *GormHoldingRepository) FindByUserID(ctx context.Context, userID finance.UserID) ([]*finance.Holding, error) {
+	return r.find(conn(ctx, r.db).Where("user_id = ?", userID.Value()))
+}
+
+// FindAll finds every user's holdings
+func (r *GormHoldingRepository) FindAll(ctx context.Context) ([]*finance.Holding, error) {
+	return r.find(conn(ctx, r.db))
+}
+
+func (r *GormHoldingRepository) find(query *gorm.DB) ([]*finance.Holding, error) {
+	var models []Holding
+	if err := query.Order("id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	holdings := make([]*finance.Holding, len(models))
+	for i, model := range models {
+		holdings[i] = toDomainHolding(model)
+	}
+	return holdings, nil
+}
+
+// Delete deletes a holding and its snapshots
+func (r *GormHoldingRepository) Delete(ctx context.Context, id finance.HoldingID) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("holding_id = ?", id.Value()).Delete(&HoldingSnapshot{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&Holding{}, id.Value()).Error
+	})
+}
+
+// toDomainHolding converts a GORM holding model to a domain holding
+func toDomainHolding(model Holding) *finance.Holding {
+	return finance.RestoreHolding(
+		finance.NewHoldingID(int(model.ID)),
+		finance.NewUserID(int(model.UserID)),
+		model.Ticker,
+		model.Quantity,
+		model.CostBasis,
+		finance.NewCurrencyID(int(model.CurrencyID)),
+		model.CreatedAt,
+	)
+}
+
+// GormHoldingSnapshotRepository implements the HoldingSnapshotRepository interface using GORM
+type GormHoldingSnapshotRepository struct {
+	db *gorm.DB
+}
+
+// NewGormHoldingSnapshotRepository creates a new GORM holding snapshot repository
+func NewGormHoldingSnapshotRepository(db *gorm.DB) *GormHoldingSnapshotRepository {
+	return &GormHoldingSnapshotRepository{db: db}
+}
+
+// Save stores a snapshot, replacing any snapshot of the same holding on the same date
+func (r *GormHoldingSnapshotRepository) Save(ctx context.Context, snapshot *finance.HoldingSnapshot) error {
+	var holding Holding
+	if err := conn(ctx, r.db).Select("user_id").First(&holding, snapshot.HoldingID().Value()).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return finance.ErrHoldingNotFound
+		}
+		return err
+	}
+
+	var model HoldingSnapshot
+	err := conn(ctx, r.db).
+		Where("holding_id = ? AND date >= ? AND date < ?",
+			snapshot.HoldingID().Value(), snapshot.Date(), snapshot.Date().Add(24*time.Hour)).
+		First(&model).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return err
+	}
+
+	model.HoldingID = uint(snapshot.HoldingID().Value())
+	model.UserID = holding.UserID
+	model.Date = snapshot.Date()
+	model.Price = snapshot.Price()
+	model.Value = snapshot.Value()
+	return conn(ctx, r.db).Save(&model).Error
+}
+
+// FindByHoldingID returns a holding's snapshots, oldest first
+func (r *GormHoldingSnapshotRepository) FindByHoldingID(ctx context.Context, holdingID finance.HoldingID) ([]*finance.HoldingSnapshot, error) {
+	var models []HoldingSnapshot
+	if err := conn(ctx, r.db).Where("holding_id = ?", holdingID.Value()).Order("date").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]*finance.HoldingSnapshot, len(models))
+	for i, model := range models {
+		snapshots[i] = finance.RestoreHoldingSnapshot(
+			finance.NewHoldingID(int(model.HoldingID)),
+			model.Date.UTC(),
+			model.Price,
+			model.Value,
+		)
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"panda-pocket/internal/domain/notification"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// GormNotificationChannelRepository implements the notification.ChannelRepository interface using GORM
+type GormNotificationChannelRepository struct {
+	db *gorm.DB
+}
+
+// NewGormNotificationChannelRepository creates a new GORM notification channel repository
+func NewGormNotificationChannelRepository(db *gorm.DB) *GormNotificationChannelRepository {
+	return &GormNotificationChannelRepository{db: db}
+}
+
+// Save saves a channel and assigns its ID
+func (r *GormNotificationChannelRepository) Save(ctx context.Context, channel *notification.Channel) error {
+	types := make([]string, len(channel.Types()))
+	for i, t := range channel.Types() {
+		types[i] = string(t)
+	}
+
+	model := &NotificationChannel{
+		ID:         uint(channel.ID().Value()),
+		UserID:     uint(channel.UserID().Value()),
+		Kind:       string(channel.Kind()),
+		Name:       channel.Name(),
+		WebhookURL: channel.WebhookURL(),
+		Types:      strings.Join(types, ","),
+		CreatedAt:  channel.CreatedAt(),
+	}
+	if err := conn(ctx, r.db).Save(model).Error; err != nil {
+		return err
+	}
+
+	channel.AssignID(notification.NewChannelID(int(model.ID)))
+	return nil
+}
+
+// FindByID finds a channel by ID
+func (r *GormNotificationChannelRepository) FindByID(ctx context.Context, id notification.ChannelID) (*notification.Channel, error) {
+	var model NotificationChannel
+	err := conn(ctx, r.db).First(&model, id.Value()).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, notification.ErrChannelNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainChannel(model), nil
+}
+
+// FindByUserID finds a user's channels
+func (r *GormNotificationChannelRepository) FindByUserID(ctx context.Context, userID notification.UserID) ([]*notification.Channel, error) {
+	var models []NotificationChannel
+	if err := conn(ctx, r.db).Where("user_id = ?", userID.Value()).Order("id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	channels := make([]*notification.Channel, len(models))
+	for i, model := range models {
+		channels[i] = toDomainChannel(model)
+	}
+	return channels, nil
+}
+
+// Delete deletes a channel by ID
+func (r *GormNotificationChannelRepository) Delete(ctx context.Context, id notification.ChannelID) error {
+	return conn(ctx, r.db).Delete(&NotificationChannel{}, id.Value()).Error
+}
+
+// toDomainChannel converts a GORM notification channel model to a domain channel
+func toDomainChannel(model NotificationChannel) *notification.Channel {
+	var types []notification.Type
+	if model.Types != "" {
+		for _, t := range strings.Split(model.Types, ",") {
+			types = append(types, notification.Type(t))
+		}
+	}
+
+	return notification.RestoreChannel(
+		notification.NewChannelID(int(model.ID)),
+		notification.NewUserID(int(model.UserID)),
+		notification.ChannelKind(model.Kind),
+		model.Name,
+		model.WebhookURL,
+		types,
+		model.CreatedAt,
+	)
+}
@@ -0,0 +1,140 @@
+package database
+
+import (
+	"context"
+	"panda-pocket/internal/domain/notification"
+
+	"gorm.io/gorm"
+)
+
+// notificationBatchSize is the number of notifications inserted per statement
+const notificationBatchSize = 500
+
+// GormNotificationRepository implements the notification.Repository interface using GORM
+type GormNotificationRepository struct {
+	db *gorm.DB
+}
+
+// NewGormNotificationRepository creates a new GORM notification repository
+func NewGormNotificationRepository(db *gorm.DB) *GormNotificationRepository {
+	return &GormNotificationRepository{db: db}
+}
+
+// SaveAll creates the notifications in batches and assigns their IDs
+func (r *GormNotificationRepository) SaveAll(ctx context.Context, notifications []*notification.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	models := make([]Notification, 0, len(notifications))
+	for _, n := range notifications {
+		models = append(models, Notification{
+			UserID:    uint(n.UserID().Value()),
+			Title:     n.Title(),
+			Message:   n.Message(),
+			Type:      string(n.Type()),
+			IsRead:    n.IsRead(),
+			CreatedAt: n.CreatedAt(),
+		})
+	}
+
+	if err := conn(ctx, r.db).CreateInBatches(&models, notificationBatchSize).Error; err != nil {
+		return err
+	}
+	for i, n := range notifications {
+		n.AssignID(notification.NewNotificationID(int(models[i].ID)))
+	}
+	return nil
+}
+
+// FindByID finds a notification by ID
+func (r *GormNotificationRepository) FindByID(ctx context.Context, id notification.NotificationID) (*notification.Notification, error) {
+	var model Notification
+	err := conn(ctx, r.db).First(&model, id.Value()).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, notification.ErrNotificationNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainNotification(model), nil
+}
+
+// FindByUserID returns a user's notifications, newest first
+func (r *GormNotificationRepository) FindByUserID(ctx context.Context, userID notification.UserID, unreadOnly bool) ([]*notification.Notification, error) {
+	query := conn(ctx, r.db).Where("user_id = ?", userID.Value())
+	if unreadOnly {
+		query = query.Where("is_read = ?", false)
+	}
+
+	var models []Notification
+	if err := query.Order("created_at DESC, id DESC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	notifications := make([]*notification.Notification, len(models))
+	for i, model := range models {
+		notifications[i] = toDomainNotification(model)
+	}
+	return notifications, nil
+}
+
+// MarkRead marks a notification as read
+func (r *GormNotificationRepository) MarkRead(ctx context.Context, id notification.NotificationID) error {
+	return conn(ctx, r.db).Model(&Notification{}).Where("id = ?", id.Value()).Update("is_read", true).Error
+}
+
+// MarkAllRead marks every notification of a user as read
+func (r *GormNotificationRepository) MarkAllRead(ctx context.Context, userID notification.UserID) error {
+	return conn(ctx, r.db).Model(&Notification{}).
+		Where("user_id = ? AND is_read = ?", userID.Value(), false).
+		Update("is_read", true).Error
+}
+
+// EmailRecipients returns the users, of those given, who accept email notifications.
+// Users without saved preferences get the default, which is to accept them.
+func (r *GormNotificationRepository) EmailRecipients(ctx context.Context, userIDs []notification.UserID) ([]notification.UserID, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int, len(userIDs))
+	for i, userID := range userIDs {
+		ids[i] = userID.Value()
+	}
+
+	var optedOut []int
+	err := conn(ctx, r.db).Model(&UserPreferences{}).
+		Where("user_id IN ? AND email_notifications = ?", ids, false).
+		Pluck("user_id", &optedOut).Error
+	if err != nil {
+		return nil, err
+	}
+
+	skip := make(map[int]bool, len(optedOut))
+	for _, id := range optedOut {
+		skip[id] = true
+	}
+
+	recipients := make([]notification.UserID, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if !skip[userID.Value()] {
+			recipients = append(recipients, userID)
+		}
+	}
+	return recipients, nil
+}
+
+// toDomainNotification converts a GORM notification model to a domain notification
+func toDomainNotification(model Notification) *notification.Notification {
+	return notification.RestoreNotification(
+		notification.NewNotificationID(int(model.ID)),
+		notification.NewUserID(int(model.UserID)),
+		model.Title,
+		model.Message,
+		notification.Type(model.Type),
+		model.IsRead,
+		model.CreatedAt,
+	)
+}
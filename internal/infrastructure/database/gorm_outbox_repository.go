@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"panda-pocket/internal/infrastructure/events"
+
+	"gorm.io/gorm"
+)
+
+// GormOutboxRepository implements the events.OutboxStore interface using GORM
+type GormOutboxRepository struct {
+	db *gorm.DB
+}
+
+// NewGormOutboxRepository creates a new GORM outbox repository
+func NewGormOutboxRepository(db *gorm.DB) *GormOutboxRepository {
+	return &GormOutboxRepository{db: db}
+}
+
+// Append stores the records and assigns their IDs
+func (r *GormOutboxRepository) Append(ctx context.Context, records []events.Record) error {
+	models := make([]OutboxEvent, 0, len(records))
+	for _, record := range records {
+		nextAttemptAt := record.NextAttemptAt
+		models = append(models, OutboxEvent{
+			EventName:     record.Name,
+			Payload:       string(record.Payload),
+			OccurredAt:    record.OccurredAt,
+			NextAttemptAt: &nextAttemptAt,
+		})
+	}
+
+	if err := conn(ctx, r.db).Create(&models).Error; err != nil {
+		return err
+	}
+
+	for i := range records {
+		records[i].ID = models[i].ID
+	}
+	return nil
+}
+
+// Due returns up to limit undelivered records whose next attempt is due, oldest first
+func (r *GormOutboxRepository) Due(ctx context.Context, now time.Time, limit int) ([]events.Record, error) {
+	var models []OutboxEvent
+	err := conn(ctx, r.db).
+		Where("published_at IS NULL AND next_attempt_at <= ?", now).
+		Order("next_attempt_at ASC, id ASC").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]events.Record, 0, len(models))
+	for _, model := range models {
+		records = append(records, events.Record{
+			ID:            model.ID,
+			Name:          model.EventName,
+			Payload:       []byte(model.Payload),
+			OccurredAt:    model.OccurredAt,
+			NextAttemptAt: *model.NextAttemptAt,
+			Attempts:      model.Attempts,
+			DeliveredTo:   model.DeliveredTo,
+		})
+	}
+	return records, nil
+}
+
+// MarkPublished records that every handler accepted the event
+func (r *GormOutboxRepository) MarkPublished(ctx context.Context, id uint) error {
+	return conn(ctx, r.db).Model(&OutboxEvent{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"published_at":    time.Now(),
+		"next_attempt_at": nil,
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_error":      "",
+	}).Error
+}
+
+// MarkFailed records a failed delivery attempt and the subscribers that accepted
+// the event so far; without retryAt the event is given up on
+func (r *GormOutboxRepository) MarkFailed(ctx context.Context, id uint, reason string, retryAt *time.Time, deliveredTo []string) error {
+	delivered, err := json.Marshal(deliveredTo)
+	if err != nil {
+		return err
+	}
+	return conn(ctx, r.db).Model(&OutboxEvent{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"next_attempt_at": retryAt,
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_error":      reason,
+		"delivered_to":    string(delivered),
+	}).Error
+}
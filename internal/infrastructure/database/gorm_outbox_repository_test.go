@@ -0,0 +1,47 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"panda-pocket/internal/infrastructure/database"
+	"panda-pocket/internal/infrastructure/events"
+	"panda-pocket/internal/testsupport"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGormOutboxRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := database.NewGormOutboxRepository(testsupport.NewDatabase(t))
+	now := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	records := []events.Record{
+		{Name: "currency.deleted", Payload: []byte(`{"code":"IDR"}`), OccurredAt: now, NextAttemptAt: now},
+		{Name: "currency.deleted", Payload: []byte(`{"code":"USD"}`), OccurredAt: now, NextAttemptAt: now.Add(time.Hour)},
+	}
+	require.NoError(t, repo.Append(ctx, records))
+	require.NotZero(t, records[0].ID)
+
+	due, err := repo.Due(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1, "records are not due before their next attempt")
+	assert.Equal(t, records[0].ID, due[0].ID)
+	assert.Equal(t, `{"code":"IDR"}`, string(due[0].Payload))
+
+	retryAt := now.Add(30 * time.Second)
+	require.NoError(t, repo.MarkFailed(ctx, records[0].ID, "webhook is down", &retryAt, []string{"email"}))
+	due, err = repo.Due(ctx, retryAt, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, 1, due[0].Attempts)
+	assert.Equal(t, []string{"email"}, []string(due[0].DeliveredTo))
+
+	require.NoError(t, repo.MarkPublished(ctx, records[0].ID))
+	require.NoError(t, repo.MarkFailed(ctx, records[1].ID, "unknown event", nil, nil))
+	due, err = repo.Due(ctx, now.Add(24*time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, due, "published and given up records are not due")
+}
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"panda-pocket/internal/domain/identity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormPasswordResetRepository implements the identity.PasswordResetRepository interface using GORM
+type GormPasswordResetRepository struct {
+	db *gorm.DB
+}
+
+// NewGormPasswordResetRepository creates a new GORM password reset repository
+func NewGormPasswordResetRepository(db *gorm.DB) *GormPasswordResetRepository {
+	return &GormPasswordResetRepository{db: db}
+}
+
+// Save saves a password reset and assigns its ID
+func (r *GormPasswordResetRepository) Save(ctx context.Context, reset *identity.PasswordReset) error {
+	model := &PasswordResetToken{
+		ID:        uint(reset.ID()),
+		UserID:    uint(reset.UserID().Value()),
+		TokenHash: reset.TokenHash(),
+		ExpiresAt: reset.ExpiresAt(),
+		UsedAt:    reset.UsedAt(),
+		CreatedAt: reset.CreatedAt(),
+	}
+	if err := conn(ctx, r.db).Save(model).Error; err != nil {
+		return err
+	}
+
+	reset.AssignID(int(model.ID))
+	return nil
+}
+
+// FindByTokenHash finds a password reset by the hash of its token
+func (r *GormPasswordResetRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*identity.PasswordReset, error) {
+	var model PasswordResetToken
+	err := conn(ctx, r.db).Where("token_hash = ?", tokenHash).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, identity.ErrInvalidResetToken
+		}
+		return nil, err
+	}
+
+	return identity.RestorePasswordReset(
+		int(model.ID),
+		identity.NewUserID(int(model.UserID)),
+		model.TokenHash,
+		model.ExpiresAt,
+		model.UsedAt,
+		model.CreatedAt,
+	), nil
+}
+
+// CountSince counts the tokens issued to a user since the given time
+func (r *GormPasswordResetRepository) CountSince(ctx context.Context, userID identity.UserID, since time.Time) (int64, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&PasswordResetToken{}).
+		Where("user_id = ? AND created_at > ?", userID.Value(), since).
+		Count(&count).Error
+	return count, err
+}
+
+// InvalidateByUserID marks every unused token of a user as used
+func (r *GormPasswordResetRepository) InvalidateByUserID(ctx context.Context, userID identity.UserID, at time.Time) error {
+	return conn(ctx, r.db).Model(&PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID.Value()).
+		Update("used_at", at).Error
+}
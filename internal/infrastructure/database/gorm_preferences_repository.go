@@ -0,0 +1,102 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"panda-pocket/internal/domain/finance"
+	"panda-pocket/internal/domain/identity"
+
+	"gorm.io/gorm"
+)
+
+// GormPreferencesRepository implements the identity.PreferencesRepository interface using GORM
+type GormPreferencesRepository struct {
+	db *gorm.DB
+}
+
+// NewGormPreferencesRepository creates a new GORM preferences repository
+func NewGormPreferencesRepository(db *gorm.DB) *GormPreferencesRepository {
+	return &GormPreferencesRepository{db: db}
+}
+
+// FindByUserID finds a user's preferences, or returns the defaults when they have none saved
+func (r *GormPreferencesRepository) FindByUserID(ctx context.Context, userID identity.UserID) (*identity.Preferences, error) {
+	var model UserPreferences
+	err := conn(ctx, r.db).Where("user_id = ?", userID.Value()).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return identity.DefaultPreferences(userID), nil
+		}
+		return nil, err
+	}
+
+	return toDomainPreferences(model), nil
+}
+
+// FindWithTrashRetention finds the preferences of every user who chose their own trash retention
+func (r *GormPreferencesRepository) FindWithTrashRetention(ctx context.Context) ([]*identity.Preferences, error) {
+	var models []UserPreferences
+	err := conn(ctx, r.db).Where("trash_retention_days IS NOT NULL").Order("user_id").Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := make([]*identity.Preferences, 0, len(models))
+	for _, model := range models {
+		preferences = append(preferences, toDomainPreferences(model))
+	}
+	return preferences, nil
+}
+
+// Save saves a user's preferences. A user saving preferences for the first time
+// gets the first default currency as their primary currency.
+func (r *GormPreferencesRepository) Save(ctx context.Context, preferences *identity.Preferences) error {
+	var model UserPreferences
+	err := conn(ctx, r.db).Where("user_id = ?", preferences.UserID().Value()).First(&model).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		var currencyIDs []uint
+		err := conn(ctx, r.db).Model(&Currency{}).Where("is_default = ?", true).Order("id").Limit(1).Pluck("id", &currencyIDs).Error
+		if err != nil {
+			return err
+		}
+		if len(currencyIDs) == 0 {
+			return finance.ErrNoDefaultCurrency
+		}
+		model = UserPreferences{
+			UserID:            uint(preferences.UserID().Value()),
+			PrimaryCurrencyID: currencyIDs[0],
+		}
+		// Create skips zero values in favour of the column defaults, so the
+		// preferences themselves are written by the update below
+		if err := conn(ctx, r.db).Create(&model).Error; err != nil {
+			return err
+		}
+	}
+
+	model.EmailNotifications = preferences.EmailNotifications()
+	model.BudgetAlerts = preferences.BudgetAlerts()
+	model.RecurringReminders = preferences.RecurringReminders()
+	model.AnomalySensitivity = string(preferences.AnomalySensitivity())
+	model.Benchmarking = preferences.Benchmarking()
+	model.OnboardingCompletedAt = preferences.OnboardingCompletedAt()
+	model.TrashRetentionDays = preferences.TrashRetentionDays()
+	return conn(ctx, r.db).Save(&model).Error
+}
+
+// toDomainPreferences converts a GORM preferences model to domain preferences
+func toDomainPreferences(model UserPreferences) *identity.Preferences {
+	return identity.RestorePreferences(
+		identity.NewUserID(int(model.UserID)),
+		model.EmailNotifications,
+		model.BudgetAlerts,
+		model.RecurringReminders,
+		identity.AnomalySensitivity(model.AnomalySensitivity),
+		model.Benchmarking,
+		model.OnboardingCompletedAt,
+		model.TrashRetentionDays,
+	)
+}
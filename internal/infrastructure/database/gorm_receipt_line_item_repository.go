@@ -0,0 +1,83 @@
+package database
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"panda-pocket/internal/infrastructure/logging"
+
+	"gorm.io/gorm"
+)
+
+// GormReceiptLineItemRepository implements the ReceiptLineItemRepository interface using GORM
+type GormReceiptLineItemRepository struct {
+	db     *gorm.DB
+	fields FieldCipher // encrypts line item names
+}
+
+// NewGormReceiptLineItemRepository creates a new GORM receipt line item repository
+func NewGormReceiptLineItemRepository(db *gorm.DB) *GormReceiptLineItemRepository {
+	return &GormReceiptLineItemRepository{db: db, fields: plainFields{}}
+}
+
+// WithFieldCipher returns a copy of the repository that stores line item names
+// encrypted with the cipher
+func (r *GormReceiptLineItemRepository) WithFieldCipher(fields FieldCipher) *GormReceiptLineItemRepository {
+	return &GormReceiptLineItemRepository{db: r.db, fields: fields}
+}
+
+// FindByExpenseID finds an expense's line items in receipt order
+func (r *GormReceiptLineItemRepository) FindByExpenseID(ctx context.Context, expenseID finance.TransactionID) ([]*finance.ReceiptLineItem, error) {
+	var models []ReceiptLineItem
+	if err := conn(ctx, r.db).Where("expense_id = ?", expenseID.Value()).Order("position").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]*finance.ReceiptLineItem, len(models))
+	for i, model := range models {
+		name, err := r.fields.Decrypt(model.Name)
+		if err != nil {
+			logging.FromContext(ctx).Warn("failed to decrypt line item name", "line_item_id", model.ID, "error", err)
+			name = ""
+		}
+		var categoryID *finance.CategoryID
+		if model.CategoryID != nil {
+			id := finance.NewCategoryID(int(*model.CategoryID))
+			categoryID = &id
+		}
+		items[i] = finance.RestoreReceiptLineItem(name, model.Quantity, model.UnitPrice, categoryID)
+	}
+	return items, nil
+}
+
+// ReplaceForExpense deletes the expense's line items and saves items in their place
+func (r *GormReceiptLineItemRepository) ReplaceForExpense(ctx context.Context, expenseID finance.TransactionID, userID finance.UserID, items []*finance.ReceiptLineItem) error {
+	models := make([]ReceiptLineItem, len(items))
+	for i, item := range items {
+		name, err := r.fields.Encrypt(item.Name())
+		if err != nil {
+			return err
+		}
+		models[i] = ReceiptLineItem{
+			ExpenseID: uint(expenseID.Value()),
+			UserID:    uint(userID.Value()),
+			Position:  i,
+			Name:      name,
+			Quantity:  item.Quantity(),
+			UnitPrice: item.UnitPrice(),
+		}
+		if item.CategoryID() != nil {
+			categoryID := uint(item.CategoryID().Value())
+			models[i].CategoryID = &categoryID
+		}
+	}
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expense_id = ?", expenseID.Value()).Delete(&ReceiptLineItem{}).Error; err != nil {
+			return err
+		}
+		if len(models) == 0 {
+			return nil
+		}
+		return tx.Create(&models).Error
+	})
+}
@@ -0,0 +1,145 @@
+package database
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormRecurringTransactionRepository implements the RecurringTransactionRepository interface using GORM
+type GormRecurringTransactionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormRecurringTransactionRepository creates a new GORM recurring transaction repository
+func NewGormRecurringTransactionRepository(db *gorm.DB) *GormRecurringTransactionRepository {
+	return &GormRecurringTransactionRepository{db: db}
+}
+
+// Save saves a recurring transaction and assigns its ID
+func (r *GormRecurringTransactionRepository) Save(ctx context.Context, recurring *finance.RecurringTransaction) error {
+	model := &RecurringTransaction{
+		ID:          uint(recurring.ID().Value()),
+		UserID:      uint(recurring.UserID().Value()),
+		CategoryID:  uint(recurring.CategoryID().Value()),
+		CurrencyID:  uint(recurring.CurrencyID().Value()),
+		Amount:      recurring.Amount().Amount(),
+		Description: recurring.Description(),
+		Frequency:   string(recurring.Frequency()),
+		NextDueDate: recurring.NextDueDate(),
+		IsActive:    recurring.IsActive(),
+		CreatedAt:   recurring.CreatedAt(),
+	}
+	if amount := recurring.NextAmountOverride(); amount != nil {
+		value := amount.Amount()
+		model.NextAmountOverride = &value
+	}
+	model.NextDateOverride = recurring.NextDateOverride()
+	model.EndDate = recurring.EndDate()
+	model.OccurrencesRemaining = recurring.OccurrencesRemaining()
+	model.RemindedFor = recurring.RemindedFor()
+	anchorDay := recurring.AnchorDay()
+	model.AnchorDay = &anchorDay
+	// Select every column so deactivation is written even though false is the zero value
+	if err := conn(ctx, r.db).Select("*").Save(model).Error; err != nil {
+		return err
+	}
+
+	recurring.AssignID(finance.NewRecurringTransactionID(int(model.ID)))
+	return nil
+}
+
+// FindByID finds a recurring transaction by ID
+func (r *GormRecurringTransactionRepository) FindByID(ctx context.Context, id finance.RecurringTransactionID) (*finance.RecurringTransaction, error) {
+	var model RecurringTransaction
+	err := conn(ctx, r.db).First(&model, id.Value()).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, finance.ErrRecurringTransactionNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainRecurringTransaction(model), nil
+}
+
+// FindByUserID finds a user's recurring transactions
+func (r *GormRecurringTransactionRepository) FindByUserID(ctx context.Context, userID finance.UserID) ([]*finance.RecurringTransaction, error) {
+	return r.find(conn(ctx, r.db).Where("user_id = ?", userID.Value()))
+}
+
+// FindActiveByUserID finds a user's active recurring transactions
+func (r *GormRecurringTransactionRepository) FindActiveByUserID(ctx context.Context, userID finance.UserID) ([]*finance.RecurringTransaction, error) {
+	return r.find(conn(ctx, r.db).Where("user_id = ? AND is_active = ?", userID.Value(), true))
+}
+
+// FindDueTransactions finds the active recurring transactions whose next occurrence is on or before at
+func (r *GormRecurringTransactionRepository) FindDueTransactions(ctx context.Context, at time.Time) ([]*finance.RecurringTransaction, error) {
+	return r.find(conn(ctx, r.db).Where("is_active = ? AND COALESCE(next_date_override, next_due_date) <= ?", true, at))
+}
+
+// FindUpcomingForReminders finds the active recurring transactions whose next
+// occurrence is between from and to, of users who have not turned recurring
+// reminders off. Users without preferences get reminders.
+func (r *GormRecurringTransactionRepository) FindUpcomingForReminders(ctx context.Context, from, to time.Time) ([]*finance.RecurringTransaction, error) {
+	query := conn(ctx, r.db).
+		Select("recurring_transactions.*").
+		Joins("LEFT JOIN user_preferences ON user_preferences.user_id = recurring_transactions.user_id").
+		Where("recurring_transactions.is_active = ?", true).
+		Where("COALESCE(recurring_transactions.next_date_override, recurring_transactions.next_due_date) BETWEEN ? AND ?", from, to).
+		Where("user_preferences.id IS NULL OR user_preferences.recurring_reminders = ?", true)
+	return r.find(query)
+}
+
+// Delete deletes a recurring transaction by ID
+func (r *GormRecurringTransactionRepository) Delete(ctx context.Context, id finance.RecurringTransactionID) error {
+	return conn(ctx, r.db).Delete(&RecurringTransaction{}, id.Value()).Error
+}
+
+// find runs a recurring transaction query, ordered by next due date
+func (r *GormRecurringTransactionRepository) find(query *gorm.DB) ([]*finance.RecurringTransaction, error) {
+	var models []RecurringTransaction
+	if err := query.Order("recurring_transactions.next_due_date, recurring_transactions.id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	recurring := make([]*finance.RecurringTransaction, len(models))
+	for i, model := range models {
+		recurring[i] = toDomainRecurringTransaction(model)
+	}
+	return recurring, nil
+}
+
+// toDomainRecurringTransaction converts a GORM recurring transaction model to a domain recurring transaction
+func toDomainRecurringTransaction(model RecurringTransaction) *finance.RecurringTransaction {
+	currencyID := finance.NewCurrencyID(int(model.CurrencyID))
+	amount, _ := finance.NewMoney(model.Amount, currencyID)
+
+	recurring := finance.RestoreRecurringTransaction(
+		finance.NewRecurringTransactionID(int(model.ID)),
+		finance.NewUserID(int(model.UserID)),
+		finance.NewCategoryID(int(model.CategoryID)),
+		currencyID,
+		amount,
+		model.Description,
+		finance.Frequency(model.Frequency),
+		model.NextDueDate,
+		model.IsActive,
+		model.CreatedAt,
+	)
+
+	var amountOverride *finance.Money
+	if model.NextAmountOverride != nil {
+		override, _ := finance.NewMoney(*model.NextAmountOverride, currencyID)
+		amountOverride = &override
+	}
+	recurring.AssignNextOccurrenceOverride(amountOverride, model.NextDateOverride)
+	recurring.AssignEndConditions(model.EndDate, model.OccurrencesRemaining)
+	recurring.AssignRemindedFor(model.RemindedFor)
+	if model.AnchorDay != nil {
+		recurring.AssignAnchorDay(*model.AnchorDay)
+	}
+	return recurring
+}
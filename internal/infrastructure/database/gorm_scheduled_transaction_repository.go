@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"panda-pocket/internal/domain/finance"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormScheduledTransactionRepository implements the ScheduledTransactionRepository interface using GORM
+type GormScheduledTransactionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormScheduledTransactionRepository creates a new GORM scheduled transaction repository
+func NewGormScheduledTransactionRepository(db *gorm.DB) *GormScheduledTransactionRepository {
+	return &GormScheduledTransactionRepository{db: db}
+}
+
+// Save saves a scheduled transaction and assigns its ID
+func (r *GormScheduledTransactionRepository) Save(ctx context.Context, scheduled *finance.ScheduledTransaction) error {
+	model := &ScheduledTransaction{
+		ID:              uint(scheduled.ID().Value()),
+		UserID:          uint(scheduled.UserID().Value()),
+		CategoryID:      uint(scheduled.CategoryID().Value()),
+		CurrencyID:      uint(scheduled.CurrencyID().Value()),
+		Amount:          scheduled.Amount().Amount(),
+		Description:     scheduled.Description(),
+		Date:            scheduled.Date(),
+		TransactionType: string(scheduled.Type()),
+		Status:          string(scheduled.Status()),
+		CreatedAt:       scheduled.CreatedAt(),
+	}
+	if transactionID := scheduled.TransactionID().Value(); transactionID != 0 {
+		id := uint(transactionID)
+		model.TransactionID = &id
+	}
+	if err := conn(ctx, r.db).Save(model).Error; err != nil {
+		return err
+	}
+
+	scheduled.AssignID(finance.NewScheduledTransactionID(int(model.ID)))
+	return nil
+}
+
+// FindByID finds a scheduled transaction by ID
+func (r *GormScheduledTransactionRepository) FindByID(ctx context.Context, id finance.ScheduledTransactionID) (*finance.ScheduledTransaction, error) {
+	var model ScheduledTransaction
+	if err := conn(ctx, r.db).First(&model, id.Value()).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, finance.ErrScheduledTransactionNotFound
+		}
+		return nil, err
+	}
+	return toDomainScheduledTransaction(model), nil
+}
+
+// FindByUserID finds a user's scheduled transactions, soonest first
+func (r *GormScheduledTransactionRepository) FindByUserID(ctx context.Context, userID finance.UserID) ([]*finance.ScheduledTransaction, error) {
+	return r.find(conn(ctx, r.db).Where("user_id = ?", userID.Value()))
+}
+
+// FindDue finds the scheduled transactions still waiting to post dated on or before date
+func (r *GormScheduledTransactionRepository) FindDue(ctx context.Context, date time.Time) ([]*finance.ScheduledTransaction, error) {
+	return r.find(conn(ctx, r.db).Where("status = ? AND date <= ?", string(finance.ScheduledStatusScheduled), date))
+}
+
+// find runs a scheduled transaction query, ordered by date
+func (r *GormScheduledTransactionRepository) find(query *gorm.DB) ([]*finance.ScheduledTransaction, error) {
+	var models []ScheduledTransaction
+	if err := query.Order("date, id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	scheduled := make([]*finance.ScheduledTransaction, len(models))
+	for i, model := range models {
+		scheduled[i] = toDomainScheduledTransaction(model)
+	}
+	return scheduled, nil
+}
+
+// toDomainScheduledTransaction converts a GORM scheduled transaction model to a domain scheduled transaction
+func toDomainScheduledTransaction(model ScheduledTransaction) *finance.ScheduledTransaction {
+	currencyID := finance.NewCurrencyID(int(model.CurrencyID))
+	amount, _ := finance.NewMoney(model.Amount, currencyID)
+
+	var transactionID finance.TransactionID
+	if model.TransactionID != nil {
+		transactionID = finance.NewTransactionID(int(*model.TransactionID))
+	}
+
+	return finance.RestoreScheduledTransaction(
+		finance.NewScheduledTransactionID(int(model.ID)),
+		finance.NewUserID(int(model.UserID)),
+		finance.NewCategoryID(int(model.CategoryID)),
+		currencyID,
+		amount,
+		model.Description,
+		model.Date,
+		finance.TransactionType(model.TransactionType),
+		finance.ScheduledStatus(model.Status),
+		transactionID,
+		model.CreatedAt,
+	)
+}
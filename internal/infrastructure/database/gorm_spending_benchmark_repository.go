@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormSpendingBenchmarkRepository implements the SpendingBenchmarkRepository interface using GORM
+type GormSpendingBenchmarkRepository struct {
+	db *gorm.DB
+}
+
+// NewGormSpendingBenchmarkRepository creates a new GORM spending benchmark repository
+func NewGormSpendingBenchmarkRepository(db *gorm.DB) *GormSpendingBenchmarkRepository {
+	return &GormSpendingBenchmarkRepository{db: db}
+}
+
+// SumByUserAndCategory totals the expenses of users who opted in to benchmarks and
+// have not been deactivated, per user and default category. Users' copies of a
+// default category count towards it. Other user categories are left out: they
+// mean something different to every user.
+func (r *GormSpendingBenchmarkRepository) SumByUserAndCategory(ctx context.Context, currencyCode string, startDate, endDate time.Time) ([]finance.CategorySpend, error) {
+	var rows []struct {
+		UserID     uint
+		CategoryID uint
+		Total      float64
+	}
+	err := conn(ctx, r.db).Model(&Expense{}).
+		Select("expenses.user_id, COALESCE(categories.source_category_id, expenses.category_id) AS category_id, SUM(expenses.amount) AS total").
+		Joins("JOIN currencies ON currencies.id = expenses.currency_id").
+		Joins("JOIN categories ON categories.id = expenses.category_id").
+		Joins("JOIN user_preferences ON user_preferences.user_id = expenses.user_id").
+		Joins("JOIN users ON users.id = expenses.user_id").
+		Where("currencies.code = ?", currencyCode).
+		Where("categories.user_id IS NULL OR categories.source_category_id IS NOT NULL").
+		Where("user_preferences.benchmarking = ?", true).
+		Where("users.deactivated_at IS NULL").
+		Where("expenses.date BETWEEN ? AND ?", startDate, endDate).
+		Group("expenses.user_id, COALESCE(categories.source_category_id, expenses.category_id)").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	spending := make([]finance.CategorySpend, len(rows))
+	for i, row := range rows {
+		spending[i] = finance.CategorySpend{
+			UserID:     finance.NewUserID(int(row.UserID)),
+			CategoryID: finance.NewCategoryID(int(row.CategoryID)),
+			Amount:     row.Total,
+		}
+	}
+	return spending, nil
+}
@@ -0,0 +1,51 @@
+package database
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+
+	"gorm.io/gorm"
+)
+
+// GormTaxCategoryRepository implements the TaxCategoryRepository interface using GORM
+type GormTaxCategoryRepository struct {
+	db *gorm.DB
+}
+
+// NewGormTaxCategoryRepository creates a new GORM tax category repository
+func NewGormTaxCategoryRepository(db *gorm.DB) *GormTaxCategoryRepository {
+	return &GormTaxCategoryRepository{db: db}
+}
+
+// SetDeductible marks or unmarks a category as tax-deductible for the user; marking twice is a no-op
+func (r *GormTaxCategoryRepository) SetDeductible(ctx context.Context, userID finance.UserID, categoryID finance.CategoryID, deductible bool) error {
+	model := TaxDeductibleCategory{
+		UserID:     uint(userID.Value()),
+		CategoryID: uint(categoryID.Value()),
+	}
+	if !deductible {
+		return conn(ctx, r.db).
+			Where("user_id = ? AND category_id = ?", model.UserID, model.CategoryID).
+			Delete(&TaxDeductibleCategory{}).Error
+	}
+	return conn(ctx, r.db).
+		Where("user_id = ? AND category_id = ?", model.UserID, model.CategoryID).
+		FirstOrCreate(&model).Error
+}
+
+// FindDeductibleCategoryIDs finds the categories the user marked as tax-deductible
+func (r *GormTaxCategoryRepository) FindDeductibleCategoryIDs(ctx context.Context, userID finance.UserID) ([]finance.CategoryID, error) {
+	var ids []uint
+	if err := conn(ctx, r.db).Model(&TaxDeductibleCategory{}).
+		Where("user_id = ?", userID.Value()).
+		Order("category_id").
+		Pluck("category_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	categoryIDs := make([]finance.CategoryID, len(ids))
+	for i, id := range ids {
+		categoryIDs[i] = finance.NewCategoryID(int(id))
+	}
+	return categoryIDs, nil
+}
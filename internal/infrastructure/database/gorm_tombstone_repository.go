@@ -0,0 +1,72 @@
+package database
+
+import (
+	"context"
+	"panda-pocket/internal/domain/finance"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormTombstoneRepository implements the finance.TombstoneRepository interface using GORM
+type GormTombstoneRepository struct {
+	db *gorm.DB
+}
+
+// NewGormTombstoneRepository creates a new GORM tombstone repository
+func NewGormTombstoneRepository(db *gorm.DB) *GormTombstoneRepository {
+	return &GormTombstoneRepository{db: db}
+}
+
+// FindByUserIDSince finds the user's tombstones left at or after since, oldest first
+func (r *GormTombstoneRepository) FindByUserIDSince(ctx context.Context, userID finance.UserID, since time.Time) ([]*finance.Tombstone, error) {
+	var models []Tombstone
+	err := conn(ctx, r.db).
+		Where("user_id = ? AND deleted_at >= ?", userID.Value(), since).
+		Order("deleted_at, id").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	tombstones := make([]*finance.Tombstone, len(models))
+	for i, model := range models {
+		tombstones[i] = finance.RestoreTombstone(
+			finance.NewUserID(int(model.UserID)),
+			finance.RecordKind(model.Kind),
+			int(model.RecordID),
+			model.DeletedAt,
+		)
+	}
+	return tombstones, nil
+}
+
+// deleteWithTombstone deletes the row of model with the ID and leaves a tombstone
+// for it in the same database transaction. Rows without an owner, such as default
+// categories, get no tombstone. It reports whether a row was deleted.
+func deleteWithTombstone(db *gorm.DB, model interface{}, kind finance.RecordKind, id int) (bool, error) {
+	deleted := false
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var owners []*uint
+		if err := tx.Model(model).Where("id = ?", id).Pluck("user_id", &owners).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(model, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		deleted = result.RowsAffected > 0
+		if !deleted || len(owners) == 0 || owners[0] == nil {
+			return nil
+		}
+
+		return tx.Create(&Tombstone{
+			UserID:    *owners[0],
+			Kind:      string(kind),
+			RecordID:  uint(id),
+			DeletedAt: time.Now(),
+		}).Error
+	})
+	return deleted, err
+}
@@ -2,9 +2,11 @@ package database
 
 import (
 	"context"
-	"log"
+	"errors"
 	"panda-pocket/internal/domain/finance"
+	"panda-pocket/internal/infrastructure/logging"
 	"sort"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -12,16 +14,32 @@ import (
 
 // GormTransactionRepository implements the TransactionRepository interface using GORM
 type GormTransactionRepository struct {
-	db *gorm.DB
+	db     *gorm.DB
+	fields FieldCipher // encrypts descriptions and receipt references
 }
 
 // NewGormTransactionRepository creates a new GORM transaction repository
 func NewGormTransactionRepository(db *gorm.DB) *GormTransactionRepository {
-	return &GormTransactionRepository{db: db}
+	return &GormTransactionRepository{db: db, fields: plainFields{}}
+}
+
+// WithFieldCipher returns a copy of the repository that stores descriptions and
+// receipt references encrypted with the cipher
+func (r *GormTransactionRepository) WithFieldCipher(fields FieldCipher) *GormTransactionRepository {
+	return &GormTransactionRepository{db: r.db, fields: fields}
 }
 
 // Save saves a transaction to the database
 func (r *GormTransactionRepository) Save(ctx context.Context, transaction *finance.Transaction) error {
+	description, err := r.fields.Encrypt(transaction.Description())
+	if err != nil {
+		return err
+	}
+	receiptReference, err := r.fields.Encrypt(transaction.ReceiptReference())
+	if err != nil {
+		return err
+	}
+
 	// Convert domain transaction to GORM model
 	var transactionModel interface{}
 
@@ -31,9 +49,16 @@ func (r *GormTransactionRepository) Save(ctx context.Context, transaction *finan
 			CategoryID:  uint(transaction.CategoryID().Value()),
 			CurrencyID:  uint(transaction.CurrencyID().Value()),
 			Amount:      transaction.Amount().Amount(),
-			Description: transaction.Description(),
+			Description: description,
 			Date:        transaction.Date(),
+			AccountID:   accountColumn(transaction.AccountID()),
+			Status:      string(transaction.Status()),
+
+			TaxDeductible:    transaction.TaxDeductible(),
+			ReceiptReference: receiptReference,
+			ClientID:         clientIDColumn(transaction.ClientID()),
 		}
+		expenseModel.Latitude, expenseModel.Longitude = locationColumns(transaction.Location())
 
 		if transaction.ID().Value() != 0 {
 			expenseModel.ID = uint(transaction.ID().Value())
@@ -46,9 +71,13 @@ func (r *GormTransactionRepository) Save(ctx context.Context, transaction *finan
 			CategoryID:  uint(transaction.CategoryID().Value()),
 			CurrencyID:  uint(transaction.CurrencyID().Value()),
 			Amount:      transaction.Amount().Amount(),
-			Description: transaction.Description(),
+			Description: description,
 			Date:        transaction.Date(),
+			AccountID:   accountColumn(transaction.AccountID()),
+			Status:      string(transaction.Status()),
+			ClientID:    clientIDColumn(transaction.ClientID()),
 		}
+		incomeModel.Latitude, incomeModel.Longitude = locationColumns(transaction.Location())
 
 		if transaction.ID().Value() != 0 {
 			incomeModel.ID = uint(transaction.ID().Value())
@@ -57,9 +86,22 @@ func (r *GormTransactionRepository) Save(ctx context.Context, transaction *finan
 		transactionModel = incomeModel
 	}
 
-	// Save using GORM
-	if err := r.db.WithContext(ctx).Save(transactionModel).Error; err != nil {
-		return err
+	// Save using GORM, guarding against overwriting a newer version
+	switch model := transactionModel.(type) {
+	case *Expense:
+		version, err := saveVersioned(conn(ctx, r.db), model, model.ID, transaction.Version(), func(v int) { model.Version = v })
+		if err != nil {
+			return r.clientIDError(model.ClientID, err)
+		}
+		transaction.AssignID(finance.NewTransactionID(int(model.ID)))
+		transaction.AssignVersion(version)
+	case *Income:
+		version, err := saveVersioned(conn(ctx, r.db), model, model.ID, transaction.Version(), func(v int) { model.Version = v })
+		if err != nil {
+			return r.clientIDError(model.ClientID, err)
+		}
+		transaction.AssignID(finance.NewTransactionID(int(model.ID)))
+		transaction.AssignVersion(version)
 	}
 
 	return nil
@@ -69,14 +111,14 @@ func (r *GormTransactionRepository) Save(ctx context.Context, transaction *finan
 func (r *GormTransactionRepository) FindByID(ctx context.Context, id finance.TransactionID) (*finance.Transaction, error) {
 	// Try to find in expenses first
 	var expenseModel Expense
-	err := r.db.WithContext(ctx).First(&expenseModel, id.Value()).Error
+	err := conn(ctx, r.db).First(&expenseModel, id.Value()).Error
 	if err == nil {
-		return r.expenseToTransaction(&expenseModel), nil
+		return r.expenseToTransaction(ctx, &expenseModel), nil
 	}
 
 	// If not found in expenses, try incomes
 	var incomeModel Income
-	err = r.db.WithContext(ctx).First(&incomeModel, id.Value()).Error
+	err = conn(ctx, r.db).First(&incomeModel, id.Value()).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, err
@@ -84,7 +126,7 @@ func (r *GormTransactionRepository) FindByID(ctx context.Context, id finance.Tra
 		return nil, err
 	}
 
-	return r.incomeToTransaction(&incomeModel), nil
+	return r.incomeToTransaction(ctx, &incomeModel), nil
 }
 
 // FindByIDAndType finds a transaction by ID, checking the correct table first based on transaction type
@@ -92,20 +134,48 @@ func (r *GormTransactionRepository) FindByIDAndType(ctx context.Context, id fina
 	if transactionType == finance.TransactionTypeExpense {
 		// Check expenses table first
 		var expenseModel Expense
-		err := r.db.WithContext(ctx).First(&expenseModel, id.Value()).Error
+		err := conn(ctx, r.db).First(&expenseModel, id.Value()).Error
 		if err != nil {
 			return nil, err
 		}
-		return r.expenseToTransaction(&expenseModel), nil
+		return r.expenseToTransaction(ctx, &expenseModel), nil
 	} else {
 		// Check incomes table first
 		var incomeModel Income
-		err := r.db.WithContext(ctx).First(&incomeModel, id.Value()).Error
+		err := conn(ctx, r.db).First(&incomeModel, id.Value()).Error
+		if err != nil {
+			return nil, err
+		}
+		return r.incomeToTransaction(ctx, &incomeModel), nil
+	}
+}
+
+// FindByClientID finds the user's transaction created under the client ID,
+// checking expenses first, then incomes, each with its archive
+func (r *GormTransactionRepository) FindByClientID(ctx context.Context, userID finance.UserID, clientID string) (*finance.Transaction, error) {
+	for _, table := range []string{"expenses", "archived_expenses"} {
+		var expenseModels []Expense
+		err := conn(ctx, r.db).Table(table).Where("user_id = ? AND client_id = ?", userID.Value(), clientID).Limit(1).Find(&expenseModels).Error
+		if err != nil {
+			return nil, err
+		}
+		if len(expenseModels) > 0 {
+			return r.expenseToTransaction(ctx, &expenseModels[0]), nil
+		}
+	}
+
+	for _, table := range []string{"incomes", "archived_incomes"} {
+		var incomeModels []Income
+		err := conn(ctx, r.db).Table(table).Where("user_id = ? AND client_id = ?", userID.Value(), clientID).Limit(1).Find(&incomeModels).Error
 		if err != nil {
 			return nil, err
 		}
-		return r.incomeToTransaction(&incomeModel), nil
+		if len(incomeModels) > 0 {
+			return r.incomeToTransaction(ctx, &incomeModels[0]), nil
+		}
 	}
+
+	return nil, finance.ErrTransactionNotFound
 }
 
 // FindByUserID finds all transactions for a user
@@ -114,24 +184,24 @@ func (r *GormTransactionRepository) FindByUserID(ctx context.Context, userID fin
 
 	// Get expenses
 	var expenseModels []Expense
-	err := r.db.WithContext(ctx).Where("user_id = ?", userID.Value()).Find(&expenseModels).Error
+	err := conn(ctx, r.db).Where("user_id = ?", userID.Value()).Find(&expenseModels).Error
 	if err != nil {
 		return nil, err
 	}
 
 	for _, model := range expenseModels {
-		transactions = append(transactions, r.expenseToTransaction(&model))
+		transactions = append(transactions, r.expenseToTransaction(ctx, &model))
 	}
 
 	// Get incomes
 	var incomeModels []Income
-	err = r.db.WithContext(ctx).Where("user_id = ?", userID.Value()).Find(&incomeModels).Error
+	err = conn(ctx, r.db).Where("user_id = ?", userID.Value()).Find(&incomeModels).Error
 	if err != nil {
 		return nil, err
 	}
 
 	for _, model := range incomeModels {
-		transactions = append(transactions, r.incomeToTransaction(&model))
+		transactions = append(transactions, r.incomeToTransaction(ctx, &model))
 	}
 
 	return transactions, nil
@@ -143,43 +213,65 @@ func (r *GormTransactionRepository) FindByUserIDAndDateRange(ctx context.Context
 
 	// Get expenses
 	var expenseModels []Expense
-	err := r.db.WithContext(ctx).Where("user_id = ? AND date BETWEEN ? AND ?", userID.Value(), startDate, endDate).Find(&expenseModels).Error
+	err := conn(ctx, r.db).Where("user_id = ? AND date BETWEEN ? AND ?", userID.Value(), startDate, endDate).Find(&expenseModels).Error
 	if err != nil {
 		return nil, err
 	}
 
 	for _, model := range expenseModels {
-		transactions = append(transactions, r.expenseToTransaction(&model))
+		transactions = append(transactions, r.expenseToTransaction(ctx, &model))
 	}
 
 	// Get incomes
 	var incomeModels []Income
-	err = r.db.WithContext(ctx).Where("user_id = ? AND date BETWEEN ? AND ?", userID.Value(), startDate, endDate).Find(&incomeModels).Error
+	err = conn(ctx, r.db).Where("user_id = ? AND date BETWEEN ? AND ?", userID.Value(), startDate, endDate).Find(&incomeModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, model := range incomeModels {
+		transactions = append(transactions, r.incomeToTransaction(ctx, &model))
+	}
+
+	return transactions, nil
+}
+
+// FindByUserIDUpdatedSince finds the user's transactions created or updated at or after since
+func (r *GormTransactionRepository) FindByUserIDUpdatedSince(ctx context.Context, userID finance.UserID, since time.Time) ([]*finance.Transaction, error) {
+	var transactions []*finance.Transaction
+
+	var expenseModels []Expense
+	err := conn(ctx, r.db).Where("user_id = ? AND updated_at >= ?", userID.Value(), since).Order("updated_at, id").Find(&expenseModels).Error
 	if err != nil {
 		return nil, err
 	}
+	for _, model := range expenseModels {
+		transactions = append(transactions, r.expenseToTransaction(ctx, &model))
+	}
 
+	var incomeModels []Income
+	err = conn(ctx, r.db).Where("user_id = ? AND updated_at >= ?", userID.Value(), since).Order("updated_at, id").Find(&incomeModels).Error
+	if err != nil {
+		return nil, err
+	}
 	for _, model := range incomeModels {
-		transactions = append(transactions, r.incomeToTransaction(&model))
+		transactions = append(transactions, r.incomeToTransaction(ctx, &model))
 	}
 
 	return transactions, nil
 }
 
-// Delete deletes a transaction by ID
+// Delete deletes a transaction by ID, leaving a tombstone for syncing clients
 func (r *GormTransactionRepository) Delete(ctx context.Context, id finance.TransactionID) error {
 	// Try to delete from expenses first
-	result := r.db.WithContext(ctx).Delete(&Expense{}, id.Value())
-	if result.Error != nil {
-		return result.Error
-	}
-	if result.RowsAffected > 0 {
-		return nil
+	deleted, err := deleteWithTombstone(conn(ctx, r.db), &Expense{}, finance.RecordKindExpense, id.Value())
+	if err != nil || deleted {
+		return err
 	}
 
 	// If not found in expenses, try incomes
-	result = r.db.WithContext(ctx).Delete(&Income{}, id.Value())
-	return result.Error
+	_, err = deleteWithTombstone(conn(ctx, r.db), &Income{}, finance.RecordKindIncome, id.Value())
+	return err
 }
 
 // ExistsByID checks if a transaction exists with the given ID
@@ -187,7 +279,7 @@ func (r *GormTransactionRepository) ExistsByID(ctx context.Context, id finance.T
 	var count int64
 
 	// Check expenses
-	err := r.db.WithContext(ctx).Model(&Expense{}).Where("id = ?", id.Value()).Count(&count).Error
+	err := conn(ctx, r.db).Model(&Expense{}).Where("id = ?", id.Value()).Count(&count).Error
 	if err != nil {
 		return false, err
 	}
@@ -196,7 +288,7 @@ func (r *GormTransactionRepository) ExistsByID(ctx context.Context, id finance.T
 	}
 
 	// Check incomes
-	err = r.db.WithContext(ctx).Model(&Income{}).Where("id = ?", id.Value()).Count(&count).Error
+	err = conn(ctx, r.db).Model(&Income{}).Where("id = ?", id.Value()).Count(&count).Error
 	if err != nil {
 		return false, err
 	}
@@ -210,24 +302,24 @@ func (r *GormTransactionRepository) FindByUserIDAndCategory(ctx context.Context,
 
 	// Get expenses
 	var expenseModels []Expense
-	err := r.db.WithContext(ctx).Where("user_id = ? AND category_id = ?", userID.Value(), categoryID.Value()).Find(&expenseModels).Error
+	err := conn(ctx, r.db).Where("user_id = ? AND category_id = ?", userID.Value(), categoryID.Value()).Find(&expenseModels).Error
 	if err != nil {
 		return nil, err
 	}
 
 	for _, model := range expenseModels {
-		transactions = append(transactions, r.expenseToTransaction(&model))
+		transactions = append(transactions, r.expenseToTransaction(ctx, &model))
 	}
 
 	// Get incomes
 	var incomeModels []Income
-	err = r.db.WithContext(ctx).Where("user_id = ? AND category_id = ?", userID.Value(), categoryID.Value()).Find(&incomeModels).Error
+	err = conn(ctx, r.db).Where("user_id = ? AND category_id = ?", userID.Value(), categoryID.Value()).Find(&incomeModels).Error
 	if err != nil {
 		return nil, err
 	}
 
 	for _, model := range incomeModels {
-		transactions = append(transactions, r.incomeToTransaction(&model))
+		transactions = append(transactions, r.incomeToTransaction(ctx, &model))
 	}
 
 	return transactions, nil
@@ -264,41 +356,60 @@ func (r *GormTransactionRepository) FindByUserIDWithFilters(ctx context.Context,
 		args = append(args, categoryIDs)
 	}
 
-	// Count total records first
-	if filters.TransactionType != nil && *filters.TransactionType == finance.TransactionTypeExpense {
-		// Count expenses
-		err := r.db.WithContext(ctx).Model(&Expense{}).Where(baseConditions, args...).Count(&totalCount).Error
-		if err != nil {
-			return nil, 0, err
+	// Apply account filter
+	if filters.AccountID != nil {
+		baseConditions += " AND account_id = ?"
+		args = append(args, filters.AccountID.Value())
+	}
+
+	// Apply map bounds; a box across the antimeridian wraps from its west edge to its east edge
+	if filters.Bounds != nil {
+		longitudeCondition := "longitude BETWEEN ? AND ?"
+		if filters.Bounds.CrossesAntimeridian() {
+			longitudeCondition = "(longitude >= ? OR longitude <= ?)"
 		}
-	} else if filters.TransactionType != nil && *filters.TransactionType == finance.TransactionTypeIncome {
-		// Count incomes
-		err := r.db.WithContext(ctx).Model(&Income{}).Where(baseConditions, args...).Count(&totalCount).Error
-		if err != nil {
-			return nil, 0, err
+		baseConditions += " AND latitude BETWEEN ? AND ? AND " + longitudeCondition
+		args = append(args,
+			filters.Bounds.MinLatitude(), filters.Bounds.MaxLatitude(),
+			filters.Bounds.MinLongitude(), filters.Bounds.MaxLongitude(),
+		)
+	}
+
+	// Apply description search; encrypted descriptions can only be matched once decrypted
+	searchDecrypted := filters.Search != "" && !isPlain(r.fields)
+	if filters.Search != "" && !searchDecrypted {
+		baseConditions += " AND LOWER(description) LIKE ?"
+		args = append(args, "%"+strings.ToLower(filters.Search)+"%")
+	}
+
+	// Pick the tables to search; archived rows have the same columns as the hot tables
+	var expenseTables, incomeTables []string
+	if filters.TransactionType == nil || *filters.TransactionType == finance.TransactionTypeExpense {
+		expenseTables = append(expenseTables, "expenses")
+		if filters.IncludeArchived {
+			expenseTables = append(expenseTables, "archived_expenses")
 		}
-	} else {
-		// Count both expenses and incomes
-		var expenseCount, incomeCount int64
-
-		err := r.db.WithContext(ctx).Model(&Expense{}).Where(baseConditions, args...).Count(&expenseCount).Error
-		if err != nil {
-			return nil, 0, err
+	}
+	if filters.TransactionType == nil || *filters.TransactionType == finance.TransactionTypeIncome {
+		incomeTables = append(incomeTables, "incomes")
+		if filters.IncludeArchived {
+			incomeTables = append(incomeTables, "archived_incomes")
 		}
+	}
 
-		err = r.db.WithContext(ctx).Model(&Income{}).Where(baseConditions, args...).Count(&incomeCount).Error
+	// Count total records first
+	for _, table := range append(append([]string{}, expenseTables...), incomeTables...) {
+		var count int64
+		err := conn(ctx, r.db).Table(table).Where(baseConditions, args...).Count(&count).Error
 		if err != nil {
 			return nil, 0, err
 		}
-
-		totalCount = expenseCount + incomeCount
+		totalCount += count
 	}
 
-	// Apply pagination and fetch data
-	if filters.TransactionType != nil && *filters.TransactionType == finance.TransactionTypeExpense {
-		// Query expenses only
-		var expenseModels []Expense
-		query := r.db.WithContext(ctx).Where(baseConditions, args...).Order("date DESC, created_at DESC")
+	// A single table is paginated by the database
+	if len(expenseTables)+len(incomeTables) == 1 && !searchDecrypted {
+		query := conn(ctx, r.db).Where(baseConditions, args...).Order("date DESC, created_at DESC")
 
 		if filters.Limit > 0 {
 			query = query.Limit(filters.Limit)
@@ -307,89 +418,121 @@ func (r *GormTransactionRepository) FindByUserIDWithFilters(ctx context.Context,
 			query = query.Offset(filters.Offset)
 		}
 
-		err := query.Find(&expenseModels).Error
+		if len(expenseTables) == 1 {
+			// Query expenses only
+			var expenseModels []Expense
+			if err := query.Table(expenseTables[0]).Find(&expenseModels).Error; err != nil {
+				return nil, 0, err
+			}
+			for _, model := range expenseModels {
+				allTransactions = append(allTransactions, r.expenseToTransaction(ctx, &model))
+			}
+		} else {
+			// Query incomes only
+			var incomeModels []Income
+			if err := query.Table(incomeTables[0]).Find(&incomeModels).Error; err != nil {
+				return nil, 0, err
+			}
+			for _, model := range incomeModels {
+				allTransactions = append(allTransactions, r.incomeToTransaction(ctx, &model))
+			}
+		}
+
+		return allTransactions, totalCount, nil
+	}
+
+	// Query several tables and combine results
+	// For pagination across tables, we need a more complex approach
+	// We'll fetch from every table and merge, then apply pagination in memory
+	// This is not ideal for very large datasets, but works for most use cases
+	for _, table := range expenseTables {
+		var expenseModels []Expense
+		err := conn(ctx, r.db).Table(table).Where(baseConditions, args...).Order("date DESC, created_at DESC").Find(&expenseModels).Error
 		if err != nil {
 			return nil, 0, err
 		}
-
 		for _, model := range expenseModels {
-			allTransactions = append(allTransactions, r.expenseToTransaction(&model))
+			allTransactions = append(allTransactions, r.expenseToTransaction(ctx, &model))
 		}
-	} else if filters.TransactionType != nil && *filters.TransactionType == finance.TransactionTypeIncome {
-		// Query incomes only
+	}
+	for _, table := range incomeTables {
 		var incomeModels []Income
-		query := r.db.WithContext(ctx).Where(baseConditions, args...).Order("date DESC, created_at DESC")
-
-		if filters.Limit > 0 {
-			query = query.Limit(filters.Limit)
-		}
-		if filters.Offset > 0 {
-			query = query.Offset(filters.Offset)
-		}
-
-		err := query.Find(&incomeModels).Error
+		err := conn(ctx, r.db).Table(table).Where(baseConditions, args...).Order("date DESC, created_at DESC").Find(&incomeModels).Error
 		if err != nil {
 			return nil, 0, err
 		}
-
 		for _, model := range incomeModels {
-			allTransactions = append(allTransactions, r.incomeToTransaction(&model))
+			allTransactions = append(allTransactions, r.incomeToTransaction(ctx, &model))
 		}
-	} else {
-		// Query both tables and combine results
-		// For pagination across two tables, we need a more complex approach
-		// We'll fetch from both tables and merge, then apply pagination in memory
-		// This is not ideal for very large datasets, but works for most use cases
-
-		var expenseModels []Expense
-		var incomeModels []Income
+	}
 
-		// Fetch all matching records from both tables
-		err := r.db.WithContext(ctx).Where(baseConditions, args...).Order("date DESC, created_at DESC").Find(&expenseModels).Error
-		if err != nil {
-			return nil, 0, err
+	if searchDecrypted {
+		search := strings.ToLower(filters.Search)
+		matched := allTransactions[:0]
+		for _, transaction := range allTransactions {
+			if strings.Contains(strings.ToLower(transaction.Description()), search) {
+				matched = append(matched, transaction)
+			}
 		}
+		allTransactions = matched
+		totalCount = int64(len(matched))
+	}
 
-		err = r.db.WithContext(ctx).Where(baseConditions, args...).Order("date DESC, created_at DESC").Find(&incomeModels).Error
-		if err != nil {
-			return nil, 0, err
+	// Sort combined results by date DESC, then by created_at DESC
+	sort.Slice(allTransactions, func(i, j int) bool {
+		if allTransactions[i].Date().Equal(allTransactions[j].Date()) {
+			return allTransactions[i].CreatedAt().After(allTransactions[j].CreatedAt())
 		}
+		return allTransactions[i].Date().After(allTransactions[j].Date())
+	})
 
-		// Convert to domain objects
-		for _, model := range expenseModels {
-			allTransactions = append(allTransactions, r.expenseToTransaction(&model))
-		}
-		for _, model := range incomeModels {
-			allTransactions = append(allTransactions, r.incomeToTransaction(&model))
-		}
+	// Apply pagination in memory; as for a single table, a zero limit returns every row
+	start := filters.Offset
+	end := len(allTransactions)
+	if filters.Limit > 0 && start+filters.Limit < end {
+		end = start + filters.Limit
+	}
 
-		// Sort combined results by date DESC, then by created_at DESC
-		sort.Slice(allTransactions, func(i, j int) bool {
-			if allTransactions[i].Date().Equal(allTransactions[j].Date()) {
-				return allTransactions[i].CreatedAt().After(allTransactions[j].CreatedAt())
-			}
-			return allTransactions[i].Date().After(allTransactions[j].Date())
-		})
+	if start >= len(allTransactions) {
+		allTransactions = []*finance.Transaction{}
+	} else {
+		allTransactions = allTransactions[start:end]
+	}
 
-		// Apply pagination in memory
-		start := filters.Offset
-		end := start + filters.Limit
+	return allTransactions, totalCount, nil
+}
 
-		if start >= len(allTransactions) {
-			allTransactions = []*finance.Transaction{}
-		} else {
-			if end > len(allTransactions) {
-				end = len(allTransactions)
+// ArchiveBefore moves expenses and incomes dated before cutoff to the archive
+// tables, keeping their IDs, and returns how many were moved
+func (r *GormTransactionRepository) ArchiveBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	const columns = "id, user_id, category_id, currency_id, account_id, amount, description, date, status, tax_deductible, receipt_reference, version, client_id, latitude, longitude, created_at, updated_at"
+
+	var moved int64
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		for _, tables := range [][2]string{{"expenses", "archived_expenses"}, {"incomes", "archived_incomes"}} {
+			insert := tx.Exec(
+				"INSERT INTO "+tables[1]+" ("+columns+", archived_at) SELECT "+columns+", ? FROM "+tables[0]+" WHERE date < ?",
+				now, cutoff,
+			)
+			if insert.Error != nil {
+				return insert.Error
+			}
+			if err := tx.Exec("DELETE FROM "+tables[0]+" WHERE date < ?", cutoff).Error; err != nil {
+				return err
 			}
-			allTransactions = allTransactions[start:end]
+			moved += insert.RowsAffected
 		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
 	}
-
-	return allTransactions, totalCount, nil
+	return int(moved), nil
 }
 
 // Helper methods to convert GORM models to domain transactions
-func (r *GormTransactionRepository) expenseToTransaction(expense *Expense) *finance.Transaction {
+func (r *GormTransactionRepository) expenseToTransaction(ctx context.Context, expense *Expense) *finance.Transaction {
 	transactionID := finance.NewTransactionID(int(expense.ID))
 	userID := finance.NewUserID(int(expense.UserID))
 	categoryID := finance.NewCategoryID(int(expense.CategoryID))
@@ -397,7 +540,7 @@ func (r *GormTransactionRepository) expenseToTransaction(expense *Expense) *fina
 	amount, err := finance.NewMoney(expense.Amount, currencyID)
 	if err != nil {
 		// Log error but continue - this shouldn't happen with valid data
-		log.Printf("Warning: failed to create money from expense amount: %v", err)
+		logging.FromContext(ctx).Warn("failed to create money from expense amount", "expense_id", expense.ID, "error", err)
 		amount, _ = finance.NewMoney(0, currencyID) // Fallback to 0 amount
 	}
 
@@ -407,14 +550,20 @@ func (r *GormTransactionRepository) expenseToTransaction(expense *Expense) *fina
 		categoryID,
 		currencyID,
 		amount,
-		expense.Description,
+		r.decrypt(ctx, expense.Description, "expense_id", expense.ID),
 		expense.Date,
 		finance.TransactionTypeExpense,
 	)
+	restoreReconciliation(transaction, expense.AccountID, expense.Status)
+	transaction.UpdateLocation(restoreLocation(expense.Latitude, expense.Longitude))
+	// Cannot fail, the transaction is an expense
+	_ = transaction.UpdateTaxDetails(expense.TaxDeductible, r.decrypt(ctx, expense.ReceiptReference, "expense_id", expense.ID))
+	transaction.AssignVersion(expense.Version)
+	transaction.AssignClientID(restoreClientID(expense.ClientID))
 	return transaction
 }
 
-func (r *GormTransactionRepository) incomeToTransaction(income *Income) *finance.Transaction {
+func (r *GormTransactionRepository) incomeToTransaction(ctx context.Context, income *Income) *finance.Transaction {
 	transactionID := finance.NewTransactionID(int(income.ID))
 	userID := finance.NewUserID(int(income.UserID))
 	categoryID := finance.NewCategoryID(int(income.CategoryID))
@@ -422,7 +571,7 @@ func (r *GormTransactionRepository) incomeToTransaction(income *Income) *finance
 	amount, err := finance.NewMoney(income.Amount, currencyID)
 	if err != nil {
 		// Log error but continue - this shouldn't happen with valid data
-		log.Printf("Warning: failed to create money from income amount: %v", err)
+		logging.FromContext(ctx).Warn("failed to create money from income amount", "income_id", income.ID, "error", err)
 		amount, _ = finance.NewMoney(0, currencyID) // Fallback to 0 amount
 	}
 
@@ -432,25 +581,234 @@ func (r *GormTransactionRepository) incomeToTransaction(income *Income) *finance
 		categoryID,
 		currencyID,
 		amount,
-		income.Description,
+		r.decrypt(ctx, income.Description, "income_id", income.ID),
 		income.Date,
 		finance.TransactionTypeIncome,
 	)
+	restoreReconciliation(transaction, income.AccountID, income.Status)
+	transaction.UpdateLocation(restoreLocation(income.Latitude, income.Longitude))
+	transaction.AssignVersion(income.Version)
+	transaction.AssignClientID(restoreClientID(income.ClientID))
 	return transaction
 }
 
+// decrypt reads an encrypted column value; a value that cannot be decrypted is
+// logged and read as empty rather than failing the whole query
+func (r *GormTransactionRepository) decrypt(ctx context.Context, value, idKey string, id uint) string {
+	plaintext, err := r.fields.Decrypt(value)
+	if err != nil {
+		logging.FromContext(ctx).Warn("failed to decrypt transaction field", idKey, id, "error", err)
+		return ""
+	}
+	return plaintext
+}
+
+// saveVersioned saves a model with a version column and returns the version it
+// was stored at. New rows start at version 1. A row read at version is only
+// updated while it is still at that version, so of two clients editing the same
+// record the later one gets finance.ErrVersionConflict rather than overwriting
+// the other. Rows saved without a version, like undo restores, overwrite whatever
+// is stored and may recreate a deleted row.
+func saveVersioned(db *gorm.DB, model interface{}, id uint, version int, setVersion func(int)) (int, error) {
+	if id == 0 {
+		setVersion(1)
+		return 1, db.Create(model).Error
+	}
+
+	if version > 0 {
+		setVersion(version + 1)
+		result := db.Model(model).Select("*").Omit("created_at").Where("version = ?", version).Updates(model)
+		if result.Error != nil {
+			return 0, result.Error
+		}
+		if result.RowsAffected == 0 {
+			return 0, finance.ErrVersionConflict
+		}
+		return version + 1, nil
+	}
+
+	var stored []int
+	if err := db.Model(model).Where("id = ?", id).Pluck("version", &stored).Error; err != nil {
+		return 0, err
+	}
+	version = 1
+	if len(stored) > 0 {
+		version = stored[0] + 1
+	}
+	setVersion(version)
+	return version, db.Save(model).Error
+}
+
+// clientIDColumn maps an unset client ID to NULL, which the unique index on
+// user and client ID allows any number of
+func clientIDColumn(clientID string) *string {
+	if clientID == "" {
+		return nil
+	}
+	return &clientID
+}
+
+// clientIDError reports a save that broke the unique index on user and client
+// ID as finance.ErrDuplicateClientID, and returns any other error as it is
+func (r *GormTransactionRepository) clientIDError(clientID *string, err error) error {
+	if clientID == nil {
+		return err
+	}
+	if translator, ok := r.db.Dialector.(gorm.ErrorTranslator); ok && errors.Is(translator.Translate(err), gorm.ErrDuplicatedKey) {
+		return finance.ErrDuplicateClientID
+	}
+	return err
+}
+
+// restoreClientID reads a stored client ID; NULL is read as unset
+func restoreClientID(clientID *string) string {
+	if clientID == nil {
+		return ""
+	}
+	return *clientID
+}
+
+// accountColumn maps an unset account ID to NULL
+func accountColumn(accountID finance.AccountID) *uint {
+	if accountID.IsZero() {
+		return nil
+	}
+	id := uint(accountID.Value())
+	return &id
+}
+
+// restoreReconciliation copies the stored account and reconciliation status onto a transaction
+func restoreReconciliation(transaction *finance.Transaction, accountID *uint, status string) {
+	if accountID != nil {
+		transaction.AssignAccount(finance.NewAccountID(int(*accountID)))
+	}
+	if status != "" {
+		transaction.UpdateStatus(finance.ReconciliationStatus(status))
+	}
+}
+
+// locationColumns maps an unknown location to NULL coordinates
+func locationColumns(location *finance.Location) (*float64, *float64) {
+	if location == nil {
+		return nil, nil
+	}
+	latitude, longitude := location.Latitude(), location.Longitude()
+	return &latitude, &longitude
+}
+
+// restoreLocation rebuilds a stored location; it is unknown unless both coordinates are set
+func restoreLocation(latitude, longitude *float64) *finance.Location {
+	if latitude == nil || longitude == nil {
+		return nil
+	}
+	location, err := finance.NewLocation(*latitude, *longitude)
+	if err != nil {
+		return nil
+	}
+	return &location
+}
+
+// GetAccountTotals sums an account's expenses as withdrawals and incomes as deposits
+// within the date range
+func (r *GormTransactionRepository) GetAccountTotals(ctx context.Context, accountID finance.AccountID, startDate, endDate time.Time) (finance.AccountTotals, error) {
+	var totals finance.AccountTotals
+	for _, table := range []struct {
+		model interface{}
+		sum   *float64
+	}{
+		{&Expense{}, &totals.Withdrawals},
+		{&Income{}, &totals.Deposits},
+	} {
+		var row struct {
+			Total float64
+			Count int
+		}
+		err := conn(ctx, r.db).Model(table.model).
+			Select("COALESCE(SUM(amount), 0) AS total, COUNT(*) AS count").
+			Where("account_id = ? AND date BETWEEN ? AND ?", accountID.Value(), startDate, endDate).
+			Scan(&row).Error
+		if err != nil {
+			return finance.AccountTotals{}, err
+		}
+		*table.sum = row.Total
+		totals.Count += row.Count
+	}
+	return totals, nil
+}
+
+// GetCategoryStats counts a user's expenses and incomes per category and sums
+// those dated within the month
+func (r *GormTransactionRepository) GetCategoryStats(ctx context.Context, userID finance.UserID, monthStart, monthEnd time.Time) (map[finance.CategoryID]finance.CategoryStats, error) {
+	stats := make(map[finance.CategoryID]finance.CategoryStats)
+	for _, model := range []interface{}{&Expense{}, &Income{}} {
+		var rows []struct {
+			CategoryID uint
+			Count      int
+			MonthTotal float64
+		}
+		err := conn(ctx, r.db).Model(model).
+			Select("category_id, COUNT(*) AS count, COALESCE(SUM(CASE WHEN date >= ? AND date < ? THEN amount ELSE 0 END), 0) AS month_total", monthStart, monthEnd).
+			Where("user_id = ?", userID.Value()).
+			Group("category_id").
+			Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			id := finance.NewCategoryID(int(row.CategoryID))
+			entry := stats[id]
+			entry.TransactionCount += row.Count
+			entry.MonthTotal += row.MonthTotal
+			stats[id] = entry
+		}
+	}
+	return stats, nil
+}
+
+// UpdateStatusByAccount sets the status of an account's expenses and incomes within the date range
+func (r *GormTransactionRepository) UpdateStatusByAccount(ctx context.Context, accountID finance.AccountID, startDate, endDate time.Time, status finance.ReconciliationStatus) (int, error) {
+	var updated int64
+	for _, model := range []interface{}{&Expense{}, &Income{}} {
+		result := conn(ctx, r.db).Model(model).
+			Where("account_id = ? AND date BETWEEN ? AND ?", accountID.Value(), startDate, endDate).
+			Update("status", string(status))
+		if result.Error != nil {
+			return 0, result.Error
+		}
+		updated += result.RowsAffected
+	}
+	return int(updated), nil
+}
+
 // GetTotalCount gets the total count of transactions
 func (r *GormTransactionRepository) GetTotalCount(ctx context.Context) (int, error) {
 	var expenseCount, incomeCount int64
 
 	// Count expenses
-	err := r.db.WithContext(ctx).Model(&Expense{}).Count(&expenseCount).Error
+	err := conn(ctx, r.db).Model(&Expense{}).Count(&expenseCount).Error
 	if err != nil {
 		return 0, err
 	}
 
 	// Count incomes
-	err = r.db.WithContext(ctx).Model(&Income{}).Count(&incomeCount).Error
+	err = conn(ctx, r.db).Model(&Income{}).Count(&incomeCount).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return int(expenseCount + incomeCount), nil
+}
+
+// GetCountByUser gets the number of a user's expenses and incomes, excluding archived ones
+func (r *GormTransactionRepository) GetCountByUser(ctx context.Context, userID int) (int, error) {
+	var expenseCount, incomeCount int64
+
+	err := conn(ctx, r.db).Model(&Expense{}).Where("user_id = ?", userID).Count(&expenseCount).Error
+	if err != nil {
+		return 0, err
+	}
+
+	err = conn(ctx, r.db).Model(&Income{}).Where("user_id = ?", userID).Count(&incomeCount).Error
 	if err != nil {
 		return 0, err
 	}
@@ -461,7 +819,7 @@ func (r *GormTransactionRepository) GetTotalCount(ctx context.Context) (int, err
 // GetTotalExpenses gets the total amount of expenses
 func (r *GormTransactionRepository) GetTotalExpenses(ctx context.Context) (float64, error) {
 	var total float64
-	err := r.db.WithContext(ctx).Model(&Expense{}).
+	err := conn(ctx, r.db).Model(&Expense{}).
 		Select("COALESCE(SUM(amount), 0)").
 		Scan(&total).Error
 	if err != nil {
@@ -473,7 +831,7 @@ func (r *GormTransactionRepository) GetTotalExpenses(ctx context.Context) (float
 // GetTotalIncome gets the total amount of income
 func (r *GormTransactionRepository) GetTotalIncome(ctx context.Context) (float64, error) {
 	var total float64
-	err := r.db.WithContext(ctx).Model(&Income{}).
+	err := conn(ctx, r.db).Model(&Income{}).
 		Select("COALESCE(SUM(amount), 0)").
 		Scan(&total).Error
 	if err != nil {
@@ -0,0 +1,111 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"panda-pocket/internal/domain/finance"
+	"panda-pocket/internal/infrastructure/database"
+	"panda-pocket/internal/testsupport"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGormTransactionRepositoryClientID(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	repo := database.NewGormTransactionRepository(db)
+	ctx := context.Background()
+	userID := finance.NewUserID(int(fixtures.User.ID))
+	const clientID = "5b2c3e1a-8f0d-4c6b-9a7e-2d4f6a8b0c1e"
+
+	newExpense := func(t *testing.T, clientID string) *finance.Transaction {
+		currencyID := finance.NewCurrencyID(int(fixtures.Currency.ID))
+		amount, err := finance.NewMoney(12.5, currencyID)
+		require.NoError(t, err)
+		transaction := finance.NewTransaction(
+			finance.TransactionID{},
+			userID,
+			finance.NewCategoryID(int(fixtures.ExpenseCategory.ID)),
+			currencyID,
+			amount,
+			"made offline",
+			time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			finance.TransactionTypeExpense,
+		)
+		transaction.AssignClientID(clientID)
+		return transaction
+	}
+
+	first := newExpense(t, clientID)
+	require.NoError(t, repo.Save(ctx, first))
+
+	t.Run("a second create under the client ID is reported as a duplicate", func(t *testing.T) {
+		err := repo.Save(ctx, newExpense(t, clientID))
+		assert.ErrorIs(t, err, finance.ErrDuplicateClientID)
+	})
+
+	t.Run("creates without a client ID are not duplicates", func(t *testing.T) {
+		require.NoError(t, repo.Save(ctx, newExpense(t, "")))
+		require.NoError(t, repo.Save(ctx, newExpense(t, "")))
+	})
+
+	t.Run("archived transactions are found by client ID", func(t *testing.T) {
+		_, err := repo.ArchiveBefore(ctx, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+
+		found, err := repo.FindByClientID(ctx, userID, clientID)
+		require.NoError(t, err)
+		assert.Equal(t, first.ID(), found.ID())
+		assert.Equal(t, finance.TransactionTypeExpense, found.Type())
+
+		_, err = repo.FindByClientID(ctx, userID, "0d6c7f5e-4a39-4c3e-9f4b-2f1f7d3b8a10")
+		assert.ErrorIs(t, err, finance.ErrTransactionNotFound)
+	})
+}
+
+func TestGormTransactionRepositoryArchiveBefore(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	repo := database.NewGormTransactionRepository(db)
+	ctx := context.Background()
+	userID := finance.NewUserID(int(fixtures.User.ID))
+	cutoff := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	oldExpense := fixtures.AddExpense(t, db, 10, cutoff.AddDate(0, 0, -1))
+	oldIncome := fixtures.AddIncome(t, db, 20, cutoff.AddDate(-1, 0, 0))
+	fixtures.AddExpense(t, db, 30, cutoff)
+	fixtures.AddIncome(t, db, 40, cutoff.AddDate(0, 1, 0))
+
+	moved, err := repo.ArchiveBefore(ctx, cutoff)
+	require.NoError(t, err)
+	assert.Equal(t, 2, moved, "transactions on the cutoff stay")
+
+	var archived database.ArchivedExpense
+	require.NoError(t, db.First(&archived, oldExpense.ID).Error)
+	assert.EqualValues(t, 10, archived.Amount)
+	assert.False(t, archived.ArchivedAt.IsZero())
+	_, err = repo.FindByID(ctx, finance.NewTransactionID(int(oldExpense.ID)))
+	assert.Error(t, err, "archived transactions leave the hot tables")
+
+	t.Run("listings leave archived transactions out unless asked", func(t *testing.T) {
+		transactions, total, err := repo.FindByUserIDWithFilters(ctx, userID, finance.TransactionFilters{})
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, total)
+		assert.Len(t, transactions, 2)
+
+		transactions, total, err = repo.FindByUserIDWithFilters(ctx, userID, finance.TransactionFilters{IncludeArchived: true})
+		require.NoError(t, err)
+		assert.EqualValues(t, 4, total)
+		require.Len(t, transactions, 4)
+		assert.Equal(t, finance.NewTransactionID(int(oldIncome.ID)), transactions[3].ID(), "archived transactions keep their IDs")
+	})
+
+	t.Run("archiving again moves nothing", func(t *testing.T) {
+		moved, err := repo.ArchiveBefore(ctx, cutoff)
+		require.NoError(t, err)
+		assert.Zero(t, moved)
+	})
+}
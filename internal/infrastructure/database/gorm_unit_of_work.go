@@ -0,0 +1,36 @@
+package database
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// txKey is the context key holding the active GORM transaction
+type txKey struct{}
+
+// GormUnitOfWork implements the UnitOfWork interface using GORM transactions
+type GormUnitOfWork struct {
+	db *gorm.DB
+}
+
+// NewGormUnitOfWork creates a new GORM unit of work
+func NewGormUnitOfWork(db *gorm.DB) *GormUnitOfWork {
+	return &GormUnitOfWork{db: db}
+}
+
+// Do runs fn inside a transaction. Nested calls join the outer transaction
+// through a savepoint, so use cases can be composed.
+func (u *GormUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return conn(ctx, u.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// conn returns the transaction bound to ctx by GormUnitOfWork, or db otherwise
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
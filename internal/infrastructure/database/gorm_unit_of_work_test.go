@@ -0,0 +1,78 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"panda-pocket/internal/domain/finance"
+	"panda-pocket/internal/infrastructure/database"
+	"panda-pocket/internal/testsupport"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGormUnitOfWork(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	fixtures := testsupport.Seed(t, db)
+	unitOfWork := database.NewGormUnitOfWork(db)
+	repo := database.NewGormTransactionRepository(db)
+	errFailed := errors.New("failed")
+
+	save := func(ctx context.Context, description string) error {
+		currencyID := finance.NewCurrencyID(int(fixtures.Currency.ID))
+		amount, err := finance.NewMoney(10, currencyID)
+		require.NoError(t, err)
+		return repo.Save(ctx, finance.NewTransaction(
+			finance.TransactionID{},
+			finance.NewUserID(int(fixtures.User.ID)),
+			finance.NewCategoryID(int(fixtures.ExpenseCategory.ID)),
+			currencyID,
+			amount,
+			description,
+			time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			finance.TransactionTypeExpense,
+		))
+	}
+	saved := func(t *testing.T, description string) bool {
+		var count int64
+		require.NoError(t, db.Model(&database.Expense{}).Where("description = ?", description).Count(&count).Error)
+		return count > 0
+	}
+
+	t.Run("changes are committed when fn succeeds", func(t *testing.T) {
+		err := unitOfWork.Do(context.Background(), func(ctx context.Context) error {
+			return save(ctx, "committed")
+		})
+		require.NoError(t, err)
+		assert.True(t, saved(t, "committed"))
+	})
+
+	t.Run("every change is rolled back when fn fails", func(t *testing.T) {
+		err := unitOfWork.Do(context.Background(), func(ctx context.Context) error {
+			require.NoError(t, save(ctx, "first"))
+			require.NoError(t, save(ctx, "second"))
+			return errFailed
+		})
+		assert.ErrorIs(t, err, errFailed)
+		assert.False(t, saved(t, "first"))
+		assert.False(t, saved(t, "second"))
+	})
+
+	t.Run("a failed nested unit rolls back to its savepoint", func(t *testing.T) {
+		err := unitOfWork.Do(context.Background(), func(ctx context.Context) error {
+			require.NoError(t, save(ctx, "outer"))
+			nested := unitOfWork.Do(ctx, func(ctx context.Context) error {
+				require.NoError(t, save(ctx, "inner"))
+				return errFailed
+			})
+			assert.ErrorIs(t, nested, errFailed)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.True(t, saved(t, "outer"))
+		assert.False(t, saved(t, "inner"))
+	})
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"panda-pocket/internal/domain/identity"
+	"strings"
 
 	"gorm.io/gorm"
 )
@@ -21,9 +22,12 @@ func NewGormUserRepository(db *gorm.DB) *GormUserRepository {
 func (r *GormUserRepository) Save(ctx context.Context, user *identity.User) error {
 	// Convert domain user to GORM model
 	userModel := &User{
-		Email:        user.Email().Value(),
-		PasswordHash: user.PasswordHash().Value(),
-		Role:         user.Role().Value(),
+		Email:         user.Email().Value(),
+		PasswordHash:  user.PasswordHash().Value(),
+		Role:          user.Role().Value(),
+		LastLoginAt:   user.LastLoginAt(),
+		DeactivatedAt: user.DeactivatedAt(),
+		CreatedAt:     user.CreatedAt(),
 	}
 
 	if user.ID().Value() != 0 {
@@ -31,13 +35,10 @@ func (r *GormUserRepository) Save(ctx context.Context, user *identity.User) erro
 	}
 
 	// Save using GORM
-	if err := r.db.WithContext(ctx).Save(userModel).Error; err != nil {
+	if err := conn(ctx, r.db).Save(userModel).Error; err != nil {
 		return err
 	}
-
-	// Note: In a real implementation, you'd want to update the domain user's ID
-	// This would require modifying the domain user to be mutable or using a different approach
-	// For now, we rely on the database to handle ID generation
+	user.AssignID(identity.NewUserID(int(userModel.ID)))
 
 	return nil
 }
@@ -46,99 +47,120 @@ func (r *GormUserRepository) Save(ctx context.Context, user *identity.User) erro
 func (r *GormUserRepository) FindByID(ctx context.Context, id identity.UserID) (*identity.User, error) {
 	var userModel User
 
-	err := r.db.WithContext(ctx).First(&userModel, id.Value()).Error
+	err := conn(ctx, r.db).First(&userModel, id.Value()).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, err
+			return nil, identity.ErrUserNotFound
 		}
 		return nil, err
 	}
 
-	// Convert GORM model to domain user
-	emailVO, err := identity.NewEmail(userModel.Email)
-	if err != nil {
-		return nil, err
-	}
-
-	passwordHashVO := identity.NewPasswordHash(userModel.PasswordHash)
-	roleVO, err := identity.NewRole(userModel.Role)
-	if err != nil {
-		return nil, err
-	}
-	userID := identity.NewUserID(int(userModel.ID))
-
-	user := identity.NewUser(userID, emailVO, passwordHashVO, roleVO)
-
-	return user, nil
+	return toDomainUser(userModel)
 }
 
 // FindByEmail finds a user by email
 func (r *GormUserRepository) FindByEmail(ctx context.Context, email identity.Email) (*identity.User, error) {
 	var userModel User
 
-	err := r.db.WithContext(ctx).Where("email = ?", email.Value()).First(&userModel).Error
+	err := conn(ctx, r.db).Where("email = ?", email.Value()).First(&userModel).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, err
+			return nil, identity.ErrUserNotFound
 		}
 		return nil, err
 	}
 
-	// Convert GORM model to domain user
-	passwordHashVO := identity.NewPasswordHash(userModel.PasswordHash)
-	roleVO, err := identity.NewRole(userModel.Role)
-	if err != nil {
-		return nil, err
-	}
-	userID := identity.NewUserID(int(userModel.ID))
-
-	user := identity.NewUser(userID, email, passwordHashVO, roleVO)
-
-	return user, nil
+	return toDomainUser(userModel)
 }
 
 // Delete deletes a user by ID
 func (r *GormUserRepository) Delete(ctx context.Context, id identity.UserID) error {
-	return r.db.WithContext(ctx).Delete(&User{}, id.Value()).Error
+	return conn(ctx, r.db).Delete(&User{}, id.Value()).Error
 }
 
 // FindAll finds all users
 func (r *GormUserRepository) FindAll(ctx context.Context) ([]*identity.User, error) {
 	var userModels []User
 
-	err := r.db.WithContext(ctx).Find(&userModels).Error
+	err := conn(ctx, r.db).Find(&userModels).Error
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert GORM models to domain users
-	users := make([]*identity.User, len(userModels))
-	for i, userModel := range userModels {
-		emailVO, err := identity.NewEmail(userModel.Email)
-		if err != nil {
-			return nil, err
-		}
+	return toDomainUsers(userModels)
+}
 
-		passwordHashVO := identity.NewPasswordHash(userModel.PasswordHash)
-		roleVO, err := identity.NewRole(userModel.Role)
-		if err != nil {
-			return nil, err
-		}
-		userID := identity.NewUserID(int(userModel.ID))
+// FindWithFilters returns one page of users matching the filters and the total number matching
+func (r *GormUserRepository) FindWithFilters(ctx context.Context, filters identity.UserFilters) ([]*identity.User, int64, error) {
+	query := conn(ctx, r.db).Model(&User{})
+	if filters.Search != "" {
+		query = query.Where("LOWER(email) LIKE ?", "%"+strings.ToLower(filters.Search)+"%")
+	}
 
-		users[i] = identity.NewUser(userID, emailVO, passwordHashVO, roleVO)
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
 	}
 
-	return users, nil
+	var userModels []User
+	query = query.Order("id").Offset(filters.Offset)
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+	if err := query.Find(&userModels).Error; err != nil {
+		return nil, 0, err
+	}
+
+	users, err := toDomainUsers(userModels)
+	if err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
 }
 
 // ExistsByEmail checks if a user exists with the given email
 func (r *GormUserRepository) ExistsByEmail(ctx context.Context, email identity.Email) (bool, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&User{}).Where("email = ?", email.Value()).Count(&count).Error
+	err := conn(ctx, r.db).Model(&User{}).Where("email = ?", email.Value()).Count(&count).Error
 	if err != nil {
 		return false, err
 	}
 
 	return count > 0, nil
 }
+
+// toDomainUser converts a GORM user model to a domain user
+func toDomainUser(userModel User) (*identity.User, error) {
+	emailVO, err := identity.NewEmail(userModel.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	roleVO, err := identity.NewRole(userModel.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	return identity.RestoreUser(
+		identity.NewUserID(int(userModel.ID)),
+		emailVO,
+		identity.NewPasswordHash(userModel.PasswordHash),
+		roleVO,
+		userModel.CreatedAt,
+		userModel.LastLoginAt,
+		userModel.DeactivatedAt,
+	), nil
+}
+
+// toDomainUsers converts GORM user models to domain users
+func toDomainUsers(userModels []User) ([]*identity.User, error) {
+	users := make([]*identity.User, len(userModels))
+	for i, userModel := range userModels {
+		user, err := toDomainUser(userModel)
+		if err != nil {
+			return nil, err
+		}
+		users[i] = user
+	}
+	return users, nil
+}
@@ -0,0 +1,174 @@
+package database
+
+import (
+	"context"
+	"panda-pocket/internal/infrastructure/metrics"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormVersionUsageRepository implements the metrics.VersionUsageStore interface using GORM
+type GormVersionUsageRepository struct {
+	db *gorm.DB
+}
+
+// NewGormVersionUsageRepository creates a new GORM version usage repository
+func NewGormVersionUsageRepository(db *gorm.DB) *GormVersionUsageRepository {
+	return &GormVersionUsageRepository{db: db}
+}
+
+// AddUsage adds request counts to the stored totals
+func (r *GormVersionUsageRepository) AddUsage(ctx context.Context, usage []metrics.VersionUsage) error {
+	models := make([]APIVersionUsage, 0, len(usage))
+	for _, u := range usage {
+		models = append(models, APIVersionUsage{
+			Version:      u.Version,
+			Method:       u.Method,
+			Route:        u.Route,
+			RequestCount: u.RequestCount,
+			FirstSeenAt:  u.FirstSeenAt,
+			LastSeenAt:   u.LastSeenAt,
+		})
+	}
+
+	// Increment existing counters, keeping the original first_seen_at
+	return conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "version"}, {Name: "method"}, {Name: "route"}},
+		DoUpdates: r.incrementAssignments("api_version_usages"),
+	}).Create(&models).Error
+}
+
+// AddClientUsage adds per-user request counts to the stored totals
+func (r *GormVersionUsageRepository) AddClientUsage(ctx context.Context, usage []metrics.ClientUsage) error {
+	models := make([]APIVersionClientUsage, 0, len(usage))
+	for _, u := range usage {
+		models = append(models, APIVersionClientUsage{
+			UserID:       uint(u.UserID),
+			Version:      u.Version,
+			RequestCount: u.RequestCount,
+			FirstSeenAt:  u.FirstSeenAt,
+			LastSeenAt:   u.LastSeenAt,
+		})
+	}
+
+	return conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "version"}},
+		DoUpdates: r.incrementAssignments("api_version_client_usages"),
+	}).Create(&models).Error
+}
+
+// AddMonthlyUsage adds per-user monthly request counts to the stored totals
+func (r *GormVersionUsageRepository) AddMonthlyUsage(ctx context.Context, usage []metrics.MonthlyUsage) error {
+	models := make([]APIMonthlyUsage, 0, len(usage))
+	for _, u := range usage {
+		models = append(models, APIMonthlyUsage{
+			UserID:       uint(u.UserID),
+			Month:        u.Month,
+			RequestCount: u.RequestCount,
+			LastSeenAt:   u.LastSeenAt,
+		})
+	}
+
+	return conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "month"}},
+		DoUpdates: r.incrementAssignments("api_monthly_usages"),
+	}).Create(&models).Error
+}
+
+// AddDailyUsage adds per-user daily request counts to the stored totals
+func (r *GormVersionUsageRepository) AddDailyUsage(ctx context.Context, usage []metrics.DailyUsage) error {
+	models := make([]APIDailyUsage, 0, len(usage))
+	for _, u := range usage {
+		models = append(models, APIDailyUsage{
+			UserID:       uint(u.UserID),
+			Day:          u.Day,
+			RequestCount: u.RequestCount,
+			LastSeenAt:   u.LastSeenAt,
+		})
+	}
+
+	return conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "day"}},
+		DoUpdates: r.incrementAssignments("api_daily_usages"),
+	}).Create(&models).Error
+}
+
+// incrementAssignments returns the upsert assignments for table in the dialect's syntax
+func (r *GormVersionUsageRepository) incrementAssignments(table string) clause.Set {
+	if r.db.Dialector.Name() == "mysql" {
+		// MySQL's ON DUPLICATE KEY UPDATE refers to the new row with VALUES()
+		return clause.Assignments(map[string]interface{}{
+			"request_count": gorm.Expr("request_count + VALUES(request_count)"),
+			"last_seen_at":  gorm.Expr("VALUES(last_seen_at)"),
+		})
+	}
+	return clause.Assignments(map[string]interface{}{
+		"request_count": gorm.Expr(table + ".request_count + excluded.request_count"),
+		"last_seen_at":  gorm.Expr("excluded.last_seen_at"),
+	})
+}
+
+// ListUsage returns the stored totals ordered by version and route
+func (r *GormVersionUsageRepository) ListUsage(ctx context.Context) ([]metrics.VersionUsage, error) {
+	var models []APIVersionUsage
+	if err := conn(ctx, r.db).Order("version, route, method").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	usage := make([]metrics.VersionUsage, 0, len(models))
+	for _, m := range models {
+		usage = append(usage, metrics.VersionUsage{
+			Version:      m.Version,
+			Method:       m.Method,
+			Route:        m.Route,
+			RequestCount: m.RequestCount,
+			FirstSeenAt:  m.FirstSeenAt,
+			LastSeenAt:   m.LastSeenAt,
+		})
+	}
+	return usage, nil
+}
+
+// ListClientUsage returns the per-user totals for the versions with each user's
+// email, most recently seen first
+func (r *GormVersionUsageRepository) ListClientUsage(ctx context.Context, versions []string) ([]metrics.ClientUsage, error) {
+	var rows []struct {
+		APIVersionClientUsage
+		Email string
+	}
+	err := conn(ctx, r.db).Model(&APIVersionClientUsage{}).
+		Select("api_version_client_usages.*, users.email").
+		Joins("LEFT JOIN users ON users.id = api_version_client_usages.user_id").
+		Where("api_version_client_usages.version IN ?", versions).
+		Order("api_version_client_usages.version, api_version_client_usages.last_seen_at DESC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make([]metrics.ClientUsage, 0, len(rows))
+	for _, row := range rows {
+		usage = append(usage, metrics.ClientUsage{
+			UserID:       int(row.UserID),
+			Email:        row.Email,
+			Version:      row.Version,
+			RequestCount: row.RequestCount,
+			FirstSeenAt:  row.FirstSeenAt,
+			LastSeenAt:   row.LastSeenAt,
+		})
+	}
+	return usage, nil
+}
+
+// GetMonthlyUsage returns the user's request count for the month, 0 when there is none
+func (r *GormVersionUsageRepository) GetMonthlyUsage(ctx context.Context, userID int, month string) (int64, error) {
+	var counts []int64
+	err := conn(ctx, r.db).Model(&APIMonthlyUsage{}).
+		Where("user_id = ? AND month = ?", userID, month).
+		Pluck("request_count", &counts).Error
+	if err != nil || len(counts) == 0 {
+		return 0, err
+	}
+	return counts[0], nil
+}
@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"panda-pocket/internal/domain/notification"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// GormWebhookRepository implements the notification.WebhookRepository interface using GORM
+type GormWebhookRepository struct {
+	db *gorm.DB
+}
+
+// NewGormWebhookRepository creates a new GORM webhook repository
+func NewGormWebhookRepository(db *gorm.DB) *GormWebhookRepository {
+	return &GormWebhookRepository{db: db}
+}
+
+// Save saves a webhook and assigns its ID
+func (r *GormWebhookRepository) Save(ctx context.Context, webhook *notification.Webhook) error {
+	model := &Webhook{
+		ID:        uint(webhook.ID().Value()),
+		UserID:    uint(webhook.UserID().Value()),
+		URL:       webhook.URL(),
+		Secret:    webhook.Secret(),
+		Events:    strings.Join(webhook.Events(), ","),
+		CreatedAt: webhook.CreatedAt(),
+	}
+	if err := conn(ctx, r.db).Save(model).Error; err != nil {
+		return err
+	}
+
+	webhook.AssignID(notification.NewWebhookID(int(model.ID)))
+	return nil
+}
+
+// FindByID finds a webhook by ID
+func (r *GormWebhookRepository) FindByID(ctx context.Context, id notification.WebhookID) (*notification.Webhook, error) {
+	var model Webhook
+	err := conn(ctx, r.db).First(&model, id.Value()).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, notification.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainWebhook(model), nil
+}
+
+// FindByUserID finds a user's webhooks
+func (r *GormWebhookRepository) FindByUserID(ctx context.Context, userID notification.UserID) ([]*notification.Webhook, error) {
+	var models []Webhook
+	if err := conn(ctx, r.db).Where("user_id = ?", userID.Value()).Order("id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]*notification.Webhook, len(models))
+	for i, model := range models {
+		webhooks[i] = toDomainWebhook(model)
+	}
+	return webhooks, nil
+}
+
+// Delete deletes a webhook by ID
+func (r *GormWebhookRepository) Delete(ctx context.Context, id notification.WebhookID) error {
+	return conn(ctx, r.db).Delete(&Webhook{}, id.Value()).Error
+}
+
+// toDomainWebhook converts a GORM webhook model to a domain webhook
+func toDomainWebhook(model Webhook) *notification.Webhook {
+	var events []string
+	if model.Events != "" {
+		events = strings.Split(model.Events, ",")
+	}
+
+	return notification.RestoreWebhook(
+		notification.NewWebhookID(int(model.ID)),
+		notification.NewUserID(int(model.UserID)),
+		model.URL,
+		model.Secret,
+		events,
+		model.CreatedAt,
+	)
+}
@@ -0,0 +1,31 @@
+package database
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// HealthCheck verifies database connectivity for readiness probes
+type HealthCheck struct {
+	db *gorm.DB
+}
+
+// NewHealthCheck creates a new database health check
+func NewHealthCheck(db *gorm.DB) *HealthCheck {
+	return &HealthCheck{db: db}
+}
+
+// Name returns the component name reported by the readiness probe
+func (h *HealthCheck) Name() string {
+	return "database"
+}
+
+// Check pings the database using the underlying connection pool
+func (h *HealthCheck) Check(ctx context.Context) error {
+	sqlDB, err := h.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
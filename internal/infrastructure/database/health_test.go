@@ -0,0 +1,24 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"panda-pocket/internal/infrastructure/database"
+	"panda-pocket/internal/testsupport"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthCheck(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	check := database.NewHealthCheck(db)
+	assert.Equal(t, "database", check.Name())
+	assert.NoError(t, check.Check(context.Background()))
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+	assert.Error(t, check.Check(context.Background()))
+}
@@ -1,56 +1,35 @@
 package database
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"log"
-	"os"
-	"strings"
+	"panda-pocket/internal/infrastructure/config"
+	"panda-pocket/internal/infrastructure/metrics"
 
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
-// loadEnvFile loads environment variables from .env file if it exists
-func loadEnvFile() {
-	file, err := os.Open(".env")
+// InitDB initializes the database connection using GORM, migrates the schema and seeds default data
+func InitDB(cfg config.DatabaseConfig) (*gorm.DB, error) {
+	db, err := Connect(cfg)
 	if err != nil {
-		// .env file doesn't exist, use system environment variables
-		return
+		return nil, err
 	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
 
-		parts := strings.SplitN(line, "=", 2)
-		if len(parts) == 2 {
-			key := strings.TrimSpace(parts[0])
-			value := strings.TrimSpace(parts[1])
-			if os.Getenv(key) == "" {
-				os.Setenv(key, value)
-			}
+	// Apply pending schema migrations
+	if cfg.AutoMigrate {
+		err = migrateUp(db)
+		if err != nil {
+			return nil, err
 		}
 	}
-}
 
-// InitDB initializes the PostgreSQL database connection using GORM
-func InitDB() (*gorm.DB, error) {
-	// Load environment variables from .env file
-	loadEnvFile()
-
-	db, err := initGormDB()
-	if err != nil {
-		return nil, err
-	}
-
-	// Auto-migrate the schema
-	err = autoMigrate(db)
+	// Fail fast when the schema has drifted from the models
+	err = VerifySchema(db)
 	if err != nil {
 		return nil, err
 	}
@@ -61,34 +40,25 @@ func InitDB() (*gorm.DB, error) {
 		return nil, err
 	}
 
-	log.Printf("Database initialized successfully with GORM and PostgreSQL")
+	log.Printf("Database initialized successfully with GORM and %s", db.Dialector.Name())
 	return db, nil
 }
 
-func initGormDB() (*gorm.DB, error) {
-	host := getEnv("DB_HOST", "localhost")
-	port := getEnv("DB_PORT", "5432")
-	user := getEnv("DB_USER", "herlangga.wicaksono")
-	password := getEnv("DB_PASSWORD", "")
-	dbname := getEnv("DB_NAME", "panda_pocket")
-
-	var dsn string
-	if password != "" {
-		dsn = fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-			host, port, user, password, dbname)
-	} else {
-		dsn = fmt.Sprintf("host=%s port=%s user=%s dbname=%s sslmode=disable",
-			host, port, user, dbname)
+// Connect opens the configured database connection without touching the schema
+func Connect(cfg config.DatabaseConfig) (*gorm.DB, error) {
+	dialector, err := openDialector(cfg)
+	if err != nil {
+		return nil, err
 	}
 
 	// Configure GORM
-	config := &gorm.Config{
-		Logger:                                   logger.Default.LogMode(logger.Info),
+	gormConfig := &gorm.Config{
+		Logger:                                   NewQueryLogger(cfg.Log, metrics.NewSlowQueries()),
 		DisableForeignKeyConstraintWhenMigrating: true,
 		SkipDefaultTransaction:                   true,
 	}
 
-	db, err := gorm.Open(postgres.Open(dsn), config)
+	db, err := gorm.Open(dialector, gormConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -99,32 +69,62 @@ func initGormDB() (*gorm.DB, error) {
 		return nil, err
 	}
 
-	sqlDB.SetMaxOpenConns(25)
-	sqlDB.SetMaxIdleConns(5)
+	sqlDB.SetMaxOpenConns(cfg.Pool.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.Pool.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.Pool.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.Pool.ConnMaxIdleTime)
+	if cfg.Type == "sqlite" {
+		// SQLite allows a single writer; one connection avoids "database is locked"
+		sqlDB.SetMaxOpenConns(1)
+	}
 
 	return db, nil
 }
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// openDialector returns the GORM dialector for the configured database type
+func openDialector(cfg config.DatabaseConfig) (gorm.Dialector, error) {
+	switch cfg.Type {
+	case "postgres", "":
+		var dsn string
+		if cfg.Password != "" {
+			dsn = fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+				cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Name, cfg.SSLMode)
+		} else {
+			dsn = fmt.Sprintf("host=%s port=%s user=%s dbname=%s sslmode=%s",
+				cfg.Host, cfg.Port, cfg.User, cfg.Name, cfg.SSLMode)
+		}
+		return postgres.Open(dsn), nil
+	case "mysql":
+		// multiStatements lets a migration file hold several statements
+		dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=UTC&multiStatements=true",
+			cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Name)
+		return mysql.Open(dsn), nil
+	case "sqlite":
+		// Foreign keys are off by default in SQLite; the busy timeout smooths over brief locks
+		return sqlite.Open(cfg.Path + "?_foreign_keys=on&_busy_timeout=5000"), nil
+	default:
+		return nil, fmt.Errorf("unsupported database type %q", cfg.Type)
 	}
-	return defaultValue
 }
 
-// autoMigrate runs GORM auto-migration for all models
-func autoMigrate(db *gorm.DB) error {
-	return db.AutoMigrate(
-		&User{},
-		&Currency{},
-		&Category{},
-		&Expense{},
-		&Income{},
-		&Budget{},
-		&RecurringTransaction{},
-		&UserPreferences{},
-		&Notification{},
-	)
+// migrateUp applies all pending schema migrations
+func migrateUp(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	migrator, err := NewMigrator(sqlDB, db.Dialector.Name())
+	if err != nil {
+		return err
+	}
+
+	applied, err := migrator.Up(context.Background())
+	if err != nil {
+		return err
+	}
+	log.Printf("Applied %d schema migrations", applied)
+	return nil
 }
 
 // createDefaultData creates default categories and currencies using GORM
@@ -159,24 +159,24 @@ func createDefaultCategoriesGorm(db *gorm.DB) error {
 		return nil
 	}
 
-	// Default expense categories
+	// Default expense categories; translation keys must match the category_translations seed
 	defaultExpenseCategories := []Category{
-		{Name: "Food", Color: "#EF4444", IsDefault: true, CategoryType: "expense"},
-		{Name: "Transport", Color: "#3B82F6", IsDefault: true, CategoryType: "expense"},
-		{Name: "Entertainment", Color: "#8B5CF6", IsDefault: true, CategoryType: "expense"},
-		{Name: "Shopping", Color: "#F59E0B", IsDefault: true, CategoryType: "expense"},
-		{Name: "Bills", Color: "#10B981", IsDefault: true, CategoryType: "expense"},
-		{Name: "Healthcare", Color: "#EC4899", IsDefault: true, CategoryType: "expense"},
-		{Name: "Education", Color: "#06B6D4", IsDefault: true, CategoryType: "expense"},
-		{Name: "Other", Color: "#6B7280", IsDefault: true, CategoryType: "expense"},
+		{Name: "Food", Color: "#EF4444", IsDefault: true, CategoryType: "expense", TranslationKey: translationKey("expense.food")},
+		{Name: "Transport", Color: "#3B82F6", IsDefault: true, CategoryType: "expense", TranslationKey: translationKey("expense.transport")},
+		{Name: "Entertainment", Color: "#8B5CF6", IsDefault: true, CategoryType: "expense", TranslationKey: translationKey("expense.entertainment")},
+		{Name: "Shopping", Color: "#F59E0B", IsDefault: true, CategoryType: "expense", TranslationKey: translationKey("expense.shopping")},
+		{Name: "Bills", Color: "#10B981", IsDefault: true, CategoryType: "expense", TranslationKey: translationKey("expense.bills")},
+		{Name: "Healthcare", Color: "#EC4899", IsDefault: true, CategoryType: "expense", TranslationKey: translationKey("expense.healthcare")},
+		{Name: "Education", Color: "#06B6D4", IsDefault: true, CategoryType: "expense", TranslationKey: translationKey("expense.education")},
+		{Name: "Other", Color: "#6B7280", IsDefault: true, CategoryType: "expense", TranslationKey: translationKey("expense.other")},
 	}
 
 	// Default income categories
 	defaultIncomeCategories := []Category{
-		{Name: "Salary", Color: "#10B981", IsDefault: true, CategoryType: "income"},
-		{Name: "Bonus", Color: "#F59E0B", IsDefault: true, CategoryType: "income"},
-		{Name: "Freelance", Color: "#8B5CF6", IsDefault: true, CategoryType: "income"},
-		{Name: "Other", Color: "#6B7280", IsDefault: true, CategoryType: "income"},
+		{Name: "Salary", Color: "#10B981", IsDefault: true, CategoryType: "income", TranslationKey: translationKey("income.salary")},
+		{Name: "Bonus", Color: "#F59E0B", IsDefault: true, CategoryType: "income", TranslationKey: translationKey("income.bonus")},
+		{Name: "Freelance", Color: "#8B5CF6", IsDefault: true, CategoryType: "income", TranslationKey: translationKey("income.freelance")},
+		{Name: "Other", Color: "#6B7280", IsDefault: true, CategoryType: "income", TranslationKey: translationKey("income.other")},
 	}
 
 	// Create expense categories
@@ -194,6 +194,11 @@ func createDefaultCategoriesGorm(db *gorm.DB) error {
 	return nil
 }
 
+// translationKey returns a pointer to key for seeding default categories
+func translationKey(key string) *string {
+	return &key
+}
+
 // createDefaultCurrenciesGorm creates default currencies using GORM
 func createDefaultCurrenciesGorm(db *gorm.DB) error {
 	// Check if default currencies already exist
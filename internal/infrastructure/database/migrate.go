@@ -0,0 +1,266 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// migrationFiles holds the numbered up/down SQL migrations, one directory per dialect.
+// Files follow the golang-migrate naming scheme ({version}_{name}.up.sql /
+// {version}_{name}.down.sql) and the same schema_migrations table layout,
+// so the migrate CLI from golang-migrate can be pointed at them as well.
+//
+//go:embed migrations
+var migrationFiles embed.FS
+
+// ErrDirtyMigration is returned when a previous migration failed halfway
+var ErrDirtyMigration = errors.New("database is in a dirty migration state, fix it manually and force the version")
+
+// Migration represents one numbered schema change
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus describes whether a migration has been applied
+type MigrationStatus struct {
+	Version uint
+	Name    string
+	Applied bool
+}
+
+// Migrator applies and rolls back schema migrations
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// NewMigrator creates a migrator for the embedded migrations of a dialect,
+// e.g. "postgres", "mysql" or "sqlite" as reported by the GORM dialector
+func NewMigrator(db *sql.DB, dialect string) (*Migrator, error) {
+	migrations, err := loadMigrations(migrationFiles, path.Join("migrations", dialect))
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// loadMigrations reads and pairs up/down files from a directory
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for dialect: %w", err)
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, entry := range entries {
+		name := entry.Name()
+		base, direction, ok := strings.Cut(strings.TrimSuffix(name, ".sql"), ".")
+		if !ok || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("invalid migration file name %q", name)
+		}
+		versionText, label, _ := strings.Cut(base, "_")
+		version, err := strconv.ParseUint(versionText, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %q: %w", name, err)
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, exists := byVersion[uint(version)]
+		if !exists {
+			migration = &Migration{Version: uint(version), Name: label}
+			byVersion[uint(version)] = migration
+		}
+		if direction == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d has no up file", migration.Version)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// ensureVersionTable creates the schema_migrations table if needed
+func (m *Migrator) ensureVersionTable(ctx context.Context) error {
+	_, err := m.db.ExecContext(ctx,
+		`CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`)
+	return err
+}
+
+// Version returns the current schema version; 0 means nothing is applied
+func (m *Migrator) Version(ctx context.Context) (uint, bool, error) {
+	if err := m.ensureVersionTable(ctx); err != nil {
+		return 0, false, err
+	}
+
+	var version int64
+	var dirty bool
+	err := m.db.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return uint(version), dirty, nil
+}
+
+// setVersion records the schema version; version 0 clears it.
+// Values are formatted inline because placeholder syntax differs per dialect.
+func (m *Migrator) setVersion(ctx context.Context, tx *sql.Tx, version uint, dirty bool) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations`); err != nil {
+		return err
+	}
+	if version == 0 {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(`INSERT INTO schema_migrations (version, dirty) VALUES (%d, %t)`, version, dirty))
+	return err
+}
+
+// Up applies all pending migrations and returns how many were applied
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	current, dirty, err := m.Version(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, ErrDirtyMigration
+	}
+
+	applied := 0
+	for _, migration := range m.migrations {
+		if migration.Version <= current {
+			continue
+		}
+		if err := m.apply(ctx, migration.Up, migration.Version); err != nil {
+			return applied, fmt.Errorf("migration %d_%s up: %w", migration.Version, migration.Name, err)
+		}
+		log.Printf("Applied migration %d_%s", migration.Version, migration.Name)
+		applied++
+	}
+	return applied, nil
+}
+
+// Down rolls back the given number of applied migrations
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	current, dirty, err := m.Version(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, ErrDirtyMigration
+	}
+
+	rolledBack := 0
+	for i := len(m.migrations) - 1; i >= 0 && rolledBack < steps; i-- {
+		migration := m.migrations[i]
+		if migration.Version > current {
+			continue
+		}
+
+		var previous uint
+		if i > 0 {
+			previous = m.migrations[i-1].Version
+		}
+		if err := m.apply(ctx, migration.Down, previous); err != nil {
+			return rolledBack, fmt.Errorf("migration %d_%s down: %w", migration.Version, migration.Name, err)
+		}
+		log.Printf("Rolled back migration %d_%s", migration.Version, migration.Name)
+		rolledBack++
+	}
+	return rolledBack, nil
+}
+
+// apply runs a migration script and records the resulting version in one transaction
+func (m *Migrator) apply(ctx context.Context, script string, version uint) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if hasStatements(script) {
+		if _, err := tx.ExecContext(ctx, script); err != nil {
+			return err
+		}
+	}
+	if err := m.setVersion(ctx, tx, version, false); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// hasStatements reports whether a script contains anything besides blank lines
+// and comments; MySQL rejects empty queries
+func hasStatements(script string) bool {
+	for _, line := range strings.Split(script, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "--") {
+			return true
+		}
+	}
+	return false
+}
+
+// Force sets the schema version without running migrations, clearing the dirty flag
+func (m *Migrator) Force(ctx context.Context, version uint) error {
+	if err := m.ensureVersionTable(ctx); err != nil {
+		return err
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := m.setVersion(ctx, tx, version, false); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Status lists every known migration and whether it has been applied
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	current, _, err := m.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		statuses = append(statuses, MigrationStatus{
+			Version: migration.Version,
+			Name:    migration.Name,
+			Applied: migration.Version <= current,
+		})
+	}
+	return statuses, nil
+}
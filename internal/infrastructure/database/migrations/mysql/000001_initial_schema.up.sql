@@ -0,0 +1,137 @@
+-- Initial schema, MySQL/MariaDB flavour of postgres/000001_initial_schema.up.sql.
+-- Indexed or defaulted strings use VARCHAR because MySQL cannot index or default TEXT.
+
+CREATE TABLE IF NOT EXISTS users (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    email VARCHAR(191) NOT NULL,
+    password_hash TEXT NOT NULL,
+    role VARCHAR(20) DEFAULT 'user',
+    last_login_at DATETIME(3),
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    UNIQUE INDEX idx_users_email (email),
+    CONSTRAINT chk_users_role CHECK (role IN ('user', 'admin', 'super_admin'))
+);
+
+CREATE TABLE IF NOT EXISTS currencies (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED,
+    code VARCHAR(16) NOT NULL,
+    name VARCHAR(191) NOT NULL,
+    symbol VARCHAR(16) NOT NULL,
+    is_default BOOLEAN DEFAULT false,
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    INDEX idx_currencies_user_id (user_id)
+);
+
+CREATE TABLE IF NOT EXISTS categories (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED,
+    name VARCHAR(191) NOT NULL,
+    color VARCHAR(16) DEFAULT '#3B82F6',
+    is_default BOOLEAN DEFAULT false,
+    category_type VARCHAR(16) DEFAULT 'expense',
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    INDEX idx_categories_user_id (user_id)
+);
+
+CREATE TABLE IF NOT EXISTS expenses (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    category_id BIGINT UNSIGNED NOT NULL,
+    currency_id BIGINT UNSIGNED NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    description TEXT,
+    date DATE NOT NULL,
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    INDEX idx_expenses_user_id (user_id),
+    INDEX idx_expenses_category_id (category_id),
+    INDEX idx_expenses_currency_id (currency_id)
+);
+
+CREATE TABLE IF NOT EXISTS incomes (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    category_id BIGINT UNSIGNED NOT NULL,
+    currency_id BIGINT UNSIGNED NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    description TEXT,
+    date DATE NOT NULL,
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    INDEX idx_incomes_user_id (user_id),
+    INDEX idx_incomes_category_id (category_id),
+    INDEX idx_incomes_currency_id (currency_id)
+);
+
+CREATE TABLE IF NOT EXISTS budgets (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    category_id BIGINT UNSIGNED NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    period VARCHAR(16) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    INDEX idx_budgets_user_id (user_id),
+    INDEX idx_budgets_category_id (category_id),
+    CONSTRAINT chk_budgets_period CHECK (period IN ('weekly', 'monthly', 'yearly'))
+);
+
+CREATE TABLE IF NOT EXISTS recurring_transactions (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    category_id BIGINT UNSIGNED NOT NULL,
+    currency_id BIGINT UNSIGNED NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    description TEXT,
+    frequency VARCHAR(16) NOT NULL,
+    next_due_date DATE NOT NULL,
+    is_active BOOLEAN DEFAULT true,
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    INDEX idx_recurring_transactions_user_id (user_id),
+    INDEX idx_recurring_transactions_category_id (category_id),
+    INDEX idx_recurring_transactions_currency_id (currency_id),
+    CONSTRAINT chk_recurring_transactions_frequency CHECK (frequency IN ('daily', 'weekly', 'monthly', 'yearly'))
+);
+
+CREATE TABLE IF NOT EXISTS user_preferences (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    primary_currency_id BIGINT UNSIGNED NOT NULL,
+    email_notifications BOOLEAN DEFAULT true,
+    budget_alerts BOOLEAN DEFAULT true,
+    recurring_reminders BOOLEAN DEFAULT true,
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    UNIQUE INDEX idx_user_preferences_user_id (user_id)
+);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    is_read BOOLEAN DEFAULT false,
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    INDEX idx_notifications_user_id (user_id)
+);
+
+CREATE TABLE IF NOT EXISTS api_version_usages (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    version VARCHAR(16) NOT NULL,
+    method VARCHAR(16) NOT NULL,
+    route VARCHAR(191) NOT NULL,
+    request_count BIGINT NOT NULL DEFAULT 0,
+    first_seen_at DATETIME(3) NOT NULL,
+    last_seen_at DATETIME(3) NOT NULL,
+    UNIQUE INDEX idx_api_version_usage_endpoint (version, method, route),
+    INDEX idx_api_version_usages_last_seen_at (last_seen_at)
+);
@@ -0,0 +1 @@
+-- Nothing to undo: 000002 only fills in columns and constraints that 000001 already defines.
@@ -0,0 +1,5 @@
+DROP INDEX idx_budgets_user_period ON budgets;
+DROP INDEX idx_incomes_user_category_date ON incomes;
+DROP INDEX idx_incomes_user_date ON incomes;
+DROP INDEX idx_expenses_user_category_date ON expenses;
+DROP INDEX idx_expenses_user_date ON expenses;
@@ -0,0 +1,6 @@
+-- Composite indexes matching the transaction list, filter and budget lookups
+CREATE INDEX idx_expenses_user_date ON expenses (user_id, date);
+CREATE INDEX idx_expenses_user_category_date ON expenses (user_id, category_id, date);
+CREATE INDEX idx_incomes_user_date ON incomes (user_id, date);
+CREATE INDEX idx_incomes_user_category_date ON incomes (user_id, category_id, date);
+CREATE INDEX idx_budgets_user_period ON budgets (user_id, start_date, end_date);
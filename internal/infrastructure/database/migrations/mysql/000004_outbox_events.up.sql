@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    event_name VARCHAR(64) NOT NULL,
+    payload TEXT NOT NULL,
+    occurred_at DATETIME(3) NOT NULL,
+    published_at DATETIME(3),
+    attempts BIGINT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at DATETIME(3),
+    INDEX idx_outbox_events_event_name (event_name),
+    INDEX idx_outbox_events_published_at (published_at)
+);
@@ -0,0 +1,28 @@
+-- Transactions older than the retention period are moved here by the archival job
+CREATE TABLE IF NOT EXISTS archived_expenses (
+    id BIGINT UNSIGNED PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    category_id BIGINT UNSIGNED NOT NULL,
+    currency_id BIGINT UNSIGNED NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    description TEXT,
+    date DATE NOT NULL,
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    archived_at DATETIME(3) NOT NULL,
+    INDEX idx_archived_expenses_user_date (user_id, date)
+);
+
+CREATE TABLE IF NOT EXISTS archived_incomes (
+    id BIGINT UNSIGNED PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    category_id BIGINT UNSIGNED NOT NULL,
+    currency_id BIGINT UNSIGNED NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    description TEXT,
+    date DATE NOT NULL,
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    archived_at DATETIME(3) NOT NULL,
+    INDEX idx_archived_incomes_user_date (user_id, date)
+);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS feature_flags (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    flag_key VARCHAR(64) NOT NULL,
+    description TEXT,
+    enabled BOOLEAN NOT NULL DEFAULT false,
+    rollout_percentage BIGINT NOT NULL DEFAULT 0,
+    user_ids TEXT,
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    UNIQUE INDEX idx_feature_flags_flag_key (flag_key)
+);
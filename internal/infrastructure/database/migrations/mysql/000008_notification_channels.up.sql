@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS notification_channels (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    webhook_url TEXT NOT NULL,
+    types TEXT,
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    INDEX idx_notification_channels_user_id (user_id)
+);
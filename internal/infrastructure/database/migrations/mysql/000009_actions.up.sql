@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS actions (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    target VARCHAR(16) NOT NULL,
+    target_id BIGINT UNSIGNED NOT NULL,
+    snapshot TEXT NOT NULL,
+    undone_at DATETIME(3),
+    created_at DATETIME(3),
+    INDEX idx_actions_user_id (user_id),
+    INDEX idx_actions_target (target, target_id)
+);
@@ -0,0 +1,17 @@
+DROP INDEX idx_expenses_account_id ON expenses;
+ALTER TABLE expenses DROP COLUMN status;
+ALTER TABLE expenses DROP COLUMN account_id;
+
+DROP INDEX idx_incomes_account_id ON incomes;
+ALTER TABLE incomes DROP COLUMN status;
+ALTER TABLE incomes DROP COLUMN account_id;
+
+DROP INDEX idx_archived_expenses_account_id ON archived_expenses;
+ALTER TABLE archived_expenses DROP COLUMN status;
+ALTER TABLE archived_expenses DROP COLUMN account_id;
+
+DROP INDEX idx_archived_incomes_account_id ON archived_incomes;
+ALTER TABLE archived_incomes DROP COLUMN status;
+ALTER TABLE archived_incomes DROP COLUMN account_id;
+
+DROP TABLE IF EXISTS accounts;
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS accounts (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    account_type VARCHAR(16) NOT NULL,
+    currency_id BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    INDEX idx_accounts_user_id (user_id)
+);
+
+ALTER TABLE expenses ADD COLUMN account_id BIGINT UNSIGNED NULL;
+ALTER TABLE expenses ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'uncleared';
+CREATE INDEX idx_expenses_account_id ON expenses (account_id);
+
+ALTER TABLE incomes ADD COLUMN account_id BIGINT UNSIGNED NULL;
+ALTER TABLE incomes ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'uncleared';
+CREATE INDEX idx_incomes_account_id ON incomes (account_id);
+
+ALTER TABLE archived_expenses ADD COLUMN account_id BIGINT UNSIGNED NULL;
+ALTER TABLE archived_expenses ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'uncleared';
+CREATE INDEX idx_archived_expenses_account_id ON archived_expenses (account_id);
+
+ALTER TABLE archived_incomes ADD COLUMN account_id BIGINT UNSIGNED NULL;
+ALTER TABLE archived_incomes ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'uncleared';
+CREATE INDEX idx_archived_incomes_account_id ON archived_incomes (account_id);
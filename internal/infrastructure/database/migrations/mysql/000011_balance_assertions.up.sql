@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS balance_assertions (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    account_id BIGINT UNSIGNED NOT NULL,
+    date DATE NOT NULL,
+    balance DECIMAL(10,2) NOT NULL,
+    created_at DATETIME(3),
+    INDEX idx_balance_assertions_user_id (user_id),
+    INDEX idx_balance_assertions_account_id (account_id)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS export_runs;
+DROP TABLE IF EXISTS export_schedules;
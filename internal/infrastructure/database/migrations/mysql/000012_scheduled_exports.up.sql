@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS export_schedules (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    format VARCHAR(16) NOT NULL,
+    provider VARCHAR(16) NOT NULL,
+    target VARCHAR(255) NOT NULL,
+    access_token TEXT,
+    frequency VARCHAR(16) NOT NULL,
+    next_run_at DATETIME(3) NOT NULL,
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    INDEX idx_export_schedules_user_id (user_id),
+    INDEX idx_export_schedules_next_run_at (next_run_at)
+);
+
+CREATE TABLE IF NOT EXISTS export_runs (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    schedule_id BIGINT UNSIGNED NOT NULL,
+    provider VARCHAR(16) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    transaction_count INT NOT NULL DEFAULT 0,
+    status VARCHAR(16) NOT NULL,
+    error TEXT,
+    created_at DATETIME(3),
+    INDEX idx_export_runs_user_id (user_id)
+);
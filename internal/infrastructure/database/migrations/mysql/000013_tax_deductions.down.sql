@@ -0,0 +1,13 @@
+ALTER TABLE expenses DROP COLUMN receipt_reference;
+ALTER TABLE expenses DROP COLUMN tax_deductible;
+
+ALTER TABLE incomes DROP COLUMN receipt_reference;
+ALTER TABLE incomes DROP COLUMN tax_deductible;
+
+ALTER TABLE archived_expenses DROP COLUMN receipt_reference;
+ALTER TABLE archived_expenses DROP COLUMN tax_deductible;
+
+ALTER TABLE archived_incomes DROP COLUMN receipt_reference;
+ALTER TABLE archived_incomes DROP COLUMN tax_deductible;
+
+DROP TABLE IF EXISTS tax_deductible_categories;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS tax_deductible_categories (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    category_id BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME(3),
+    UNIQUE INDEX idx_tax_deductible_categories_user_category (user_id, category_id)
+);
+
+ALTER TABLE expenses ADD COLUMN tax_deductible BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE expenses ADD COLUMN receipt_reference VARCHAR(255) NULL;
+
+ALTER TABLE incomes ADD COLUMN tax_deductible BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE incomes ADD COLUMN receipt_reference VARCHAR(255) NULL;
+
+ALTER TABLE archived_expenses ADD COLUMN tax_deductible BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE archived_expenses ADD COLUMN receipt_reference VARCHAR(255) NULL;
+
+ALTER TABLE archived_incomes ADD COLUMN tax_deductible BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE archived_incomes ADD COLUMN receipt_reference VARCHAR(255) NULL;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    expires_at DATETIME(3) NOT NULL,
+    used_at DATETIME(3) NULL,
+    created_at DATETIME(3),
+    UNIQUE INDEX idx_password_reset_tokens_token_hash (token_hash),
+    INDEX idx_password_reset_tokens_user_created (user_id, created_at)
+);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS email_queue (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    recipient VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    text_body TEXT,
+    html_body TEXT,
+    status VARCHAR(16) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at DATETIME(3) NOT NULL,
+    sent_at DATETIME(3) NULL,
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    INDEX idx_email_queue_status_next_attempt (status, next_attempt_at)
+);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS category_translations;
+
+DROP INDEX idx_categories_translation_key ON categories;
+ALTER TABLE categories DROP COLUMN translation_key;
@@ -0,0 +1 @@
+ALTER TABLE budgets ADD COLUMN prorated BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS webhooks (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    url TEXT NOT NULL,
+    secret VARCHAR(64) NOT NULL,
+    events TEXT,
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    INDEX idx_webhooks_user_id (user_id)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS api_version_client_usages;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS api_version_client_usages (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    version VARCHAR(16) NOT NULL,
+    request_count BIGINT NOT NULL DEFAULT 0,
+    first_seen_at DATETIME(3) NOT NULL,
+    last_seen_at DATETIME(3) NOT NULL,
+    UNIQUE INDEX idx_api_version_client_usage_user (user_id, version)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE recurring_transactions DROP COLUMN next_date_override;
+ALTER TABLE recurring_transactions DROP COLUMN next_amount_override;
@@ -0,0 +1,2 @@
+ALTER TABLE recurring_transactions DROP COLUMN occurrences_remaining;
+ALTER TABLE recurring_transactions DROP COLUMN end_date;
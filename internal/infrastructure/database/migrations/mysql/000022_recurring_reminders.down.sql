@@ -0,0 +1 @@
+ALTER TABLE recurring_transactions DROP COLUMN reminded_for;
@@ -0,0 +1 @@
+ALTER TABLE recurring_transactions ADD COLUMN reminded_for DATE;
@@ -0,0 +1,14 @@
+ALTER TABLE user_preferences ADD COLUMN anomaly_sensitivity VARCHAR(10) NOT NULL DEFAULT 'medium';
+
+CREATE TABLE IF NOT EXISTS anomalies (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    category_id BIGINT UNSIGNED NOT NULL,
+    transaction_id BIGINT UNSIGNED,
+    amount DECIMAL(10,2) NOT NULL,
+    baseline DECIMAL(10,2) NOT NULL,
+    detected_at DATETIME(3) NOT NULL,
+    created_at DATETIME(3),
+    INDEX idx_anomalies_user_detected (user_id, detected_at)
+);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS closed_months (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    month DATE NOT NULL,
+    closed_at DATETIME(3) NOT NULL,
+    created_at DATETIME(3),
+    UNIQUE INDEX idx_closed_months_user_month (user_id, month)
+);
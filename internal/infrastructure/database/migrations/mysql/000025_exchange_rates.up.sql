@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    base_currency VARCHAR(10) NOT NULL,
+    quote_currency VARCHAR(10) NOT NULL,
+    rate DECIMAL(18,8) NOT NULL,
+    date DATE NOT NULL,
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    UNIQUE INDEX idx_exchange_rates_pair_date (base_currency, quote_currency, date)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE accounts ADD COLUMN statement_closing_day INTEGER;
+ALTER TABLE accounts ADD COLUMN payment_due_day INTEGER;
+ALTER TABLE accounts ADD COLUMN payment_reminded_for DATE;
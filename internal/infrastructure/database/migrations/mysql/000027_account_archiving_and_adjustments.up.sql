@@ -0,0 +1,13 @@
+ALTER TABLE accounts ADD COLUMN opening_balance DECIMAL(10,2) NOT NULL DEFAULT 0;
+ALTER TABLE accounts ADD COLUMN archived_at DATETIME(3);
+CREATE TABLE IF NOT EXISTS balance_adjustments (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    account_id BIGINT UNSIGNED NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    date DATE NOT NULL,
+    description TEXT,
+    created_at DATETIME(3),
+    INDEX idx_balance_adjustments_user_id (user_id),
+    INDEX idx_balance_adjustments_account_id (account_id)
+);
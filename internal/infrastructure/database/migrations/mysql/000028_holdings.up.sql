@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS holdings (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    ticker VARCHAR(16) NOT NULL,
+    quantity DECIMAL(20,8) NOT NULL,
+    cost_basis DECIMAL(10,2) NOT NULL,
+    currency_id BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    INDEX idx_holdings_user_id (user_id)
+);
+CREATE TABLE IF NOT EXISTS holding_snapshots (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    holding_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    date DATE NOT NULL,
+    price DECIMAL(18,8) NOT NULL,
+    value DECIMAL(12,2) NOT NULL,
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    UNIQUE INDEX idx_holding_snapshots_holding_date (holding_id, date),
+    INDEX idx_holding_snapshots_user_id (user_id)
+);
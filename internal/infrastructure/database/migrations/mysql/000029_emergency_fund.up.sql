@@ -0,0 +1 @@
+ALTER TABLE accounts ADD COLUMN emergency_fund BOOLEAN NOT NULL DEFAULT false;
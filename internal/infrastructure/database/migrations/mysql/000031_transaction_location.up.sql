@@ -0,0 +1,11 @@
+ALTER TABLE expenses ADD COLUMN latitude DOUBLE NULL;
+ALTER TABLE expenses ADD COLUMN longitude DOUBLE NULL;
+
+ALTER TABLE incomes ADD COLUMN latitude DOUBLE NULL;
+ALTER TABLE incomes ADD COLUMN longitude DOUBLE NULL;
+
+ALTER TABLE archived_expenses ADD COLUMN latitude DOUBLE NULL;
+ALTER TABLE archived_expenses ADD COLUMN longitude DOUBLE NULL;
+
+ALTER TABLE archived_incomes ADD COLUMN latitude DOUBLE NULL;
+ALTER TABLE archived_incomes ADD COLUMN longitude DOUBLE NULL;
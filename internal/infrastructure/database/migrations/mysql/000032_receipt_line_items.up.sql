@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS receipt_line_items (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    expense_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    position INT NOT NULL,
+    name TEXT NOT NULL,
+    quantity DECIMAL(12,3) NOT NULL,
+    unit_price DECIMAL(10,2) NOT NULL,
+    category_id BIGINT UNSIGNED NULL,
+    created_at DATETIME(3),
+    INDEX idx_receipt_line_items_expense_id (expense_id),
+    INDEX idx_receipt_line_items_user_id (user_id)
+);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS api_monthly_usages (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    month VARCHAR(7) NOT NULL,
+    request_count BIGINT NOT NULL DEFAULT 0,
+    last_seen_at DATETIME(3) NOT NULL,
+    UNIQUE INDEX idx_api_monthly_usage_user (user_id, month)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE expenses ADD COLUMN version INT NOT NULL DEFAULT 1;
+ALTER TABLE incomes ADD COLUMN version INT NOT NULL DEFAULT 1;
+ALTER TABLE budgets ADD COLUMN version INT NOT NULL DEFAULT 1;
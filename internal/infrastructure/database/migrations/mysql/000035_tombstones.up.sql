@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS tombstones (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    record_id BIGINT UNSIGNED NOT NULL,
+    deleted_at DATETIME(3) NOT NULL,
+    INDEX idx_tombstones_user_deleted (user_id, deleted_at)
+);
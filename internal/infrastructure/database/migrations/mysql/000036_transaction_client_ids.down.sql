@@ -0,0 +1,5 @@
+DROP INDEX idx_incomes_user_client ON incomes;
+ALTER TABLE incomes DROP COLUMN client_id;
+
+DROP INDEX idx_expenses_user_client ON expenses;
+ALTER TABLE expenses DROP COLUMN client_id;
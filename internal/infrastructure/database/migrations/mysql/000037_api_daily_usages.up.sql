@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS api_daily_usages (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    day VARCHAR(10) NOT NULL,
+    request_count BIGINT NOT NULL DEFAULT 0,
+    last_seen_at DATETIME(3) NOT NULL,
+    UNIQUE INDEX idx_api_daily_usage_user (user_id, day),
+    INDEX idx_api_daily_usages_day (day)
+);
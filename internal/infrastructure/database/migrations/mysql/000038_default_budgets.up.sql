@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS default_budgets (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    category_id BIGINT UNSIGNED NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    period VARCHAR(16) NOT NULL,
+    created_at DATETIME(3) NULL,
+    UNIQUE INDEX idx_default_budgets_category_id (category_id),
+    CONSTRAINT chk_default_budgets_period CHECK (period IN ('weekly', 'monthly', 'yearly'))
+);
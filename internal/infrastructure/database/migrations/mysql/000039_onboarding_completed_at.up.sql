@@ -0,0 +1 @@
+ALTER TABLE user_preferences ADD COLUMN onboarding_completed_at DATETIME(3) NULL;
@@ -0,0 +1,52 @@
+-- Copies are merged back into the defaults they were made from, losing any
+-- renames or recolors
+UPDATE expenses SET category_id = (
+    SELECT copies.source_category_id FROM categories AS copies WHERE copies.id = expenses.category_id AND copies.source_category_id IS NOT NULL
+) WHERE EXISTS (
+    SELECT 1 FROM categories AS copies WHERE copies.id = expenses.category_id AND copies.source_category_id IS NOT NULL
+);
+UPDATE incomes SET category_id = (
+    SELECT copies.source_category_id FROM categories AS copies WHERE copies.id = incomes.category_id AND copies.source_category_id IS NOT NULL
+) WHERE EXISTS (
+    SELECT 1 FROM categories AS copies WHERE copies.id = incomes.category_id AND copies.source_category_id IS NOT NULL
+);
+UPDATE archived_expenses SET category_id = (
+    SELECT copies.source_category_id FROM categories AS copies WHERE copies.id = archived_expenses.category_id AND copies.source_category_id IS NOT NULL
+) WHERE EXISTS (
+    SELECT 1 FROM categories AS copies WHERE copies.id = archived_expenses.category_id AND copies.source_category_id IS NOT NULL
+);
+UPDATE archived_incomes SET category_id = (
+    SELECT copies.source_category_id FROM categories AS copies WHERE copies.id = archived_incomes.category_id AND copies.source_category_id IS NOT NULL
+) WHERE EXISTS (
+    SELECT 1 FROM categories AS copies WHERE copies.id = archived_incomes.category_id AND copies.source_category_id IS NOT NULL
+);
+UPDATE budgets SET category_id = (
+    SELECT copies.source_category_id FROM categories AS copies WHERE copies.id = budgets.category_id AND copies.source_category_id IS NOT NULL
+) WHERE EXISTS (
+    SELECT 1 FROM categories AS copies WHERE copies.id = budgets.category_id AND copies.source_category_id IS NOT NULL
+);
+UPDATE recurring_transactions SET category_id = (
+    SELECT copies.source_category_id FROM categories AS copies WHERE copies.id = recurring_transactions.category_id AND copies.source_category_id IS NOT NULL
+) WHERE EXISTS (
+    SELECT 1 FROM categories AS copies WHERE copies.id = recurring_transactions.category_id AND copies.source_category_id IS NOT NULL
+);
+UPDATE receipt_line_items SET category_id = (
+    SELECT copies.source_category_id FROM categories AS copies WHERE copies.id = receipt_line_items.category_id AND copies.source_category_id IS NOT NULL
+) WHERE EXISTS (
+    SELECT 1 FROM categories AS copies WHERE copies.id = receipt_line_items.category_id AND copies.source_category_id IS NOT NULL
+);
+UPDATE tax_deductible_categories SET category_id = (
+    SELECT copies.source_category_id FROM categories AS copies WHERE copies.id = tax_deductible_categories.category_id AND copies.source_category_id IS NOT NULL
+) WHERE EXISTS (
+    SELECT 1 FROM categories AS copies WHERE copies.id = tax_deductible_categories.category_id AND copies.source_category_id IS NOT NULL
+);
+UPDATE anomalies SET category_id = (
+    SELECT copies.source_category_id FROM categories AS copies WHERE copies.id = anomalies.category_id AND copies.source_category_id IS NOT NULL
+) WHERE EXISTS (
+    SELECT 1 FROM categories AS copies WHERE copies.id = anomalies.category_id AND copies.source_category_id IS NOT NULL
+);
+
+DELETE FROM categories WHERE source_category_id IS NOT NULL;
+
+DROP INDEX idx_categories_source_category_id ON categories;
+ALTER TABLE categories DROP COLUMN source_category_id;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS scheduled_transactions (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    category_id BIGINT UNSIGNED NOT NULL,
+    currency_id BIGINT UNSIGNED NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    description TEXT,
+    date DATE NOT NULL,
+    transaction_type VARCHAR(16) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'scheduled',
+    transaction_id BIGINT UNSIGNED NULL,
+    created_at DATETIME(3) NULL,
+    updated_at DATETIME(3) NULL,
+    INDEX idx_scheduled_transactions_user_id (user_id),
+    INDEX idx_scheduled_transactions_category_id (category_id),
+    INDEX idx_scheduled_transactions_currency_id (currency_id),
+    INDEX idx_scheduled_transactions_status_date (status, date),
+    CONSTRAINT chk_scheduled_transactions_transaction_type CHECK (transaction_type IN ('expense', 'income'))
+);
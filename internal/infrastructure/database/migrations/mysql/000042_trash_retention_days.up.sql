@@ -0,0 +1 @@
+ALTER TABLE user_preferences ADD COLUMN trash_retention_days INT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX idx_outbox_events_next_attempt_at ON outbox_events;
+ALTER TABLE outbox_events DROP COLUMN next_attempt_at;
@@ -0,0 +1,2 @@
+ALTER TABLE outbox_events ADD COLUMN next_attempt_at DATETIME(3) NULL;
+CREATE INDEX idx_outbox_events_next_attempt_at ON outbox_events (next_attempt_at);
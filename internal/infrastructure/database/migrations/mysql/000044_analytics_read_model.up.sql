@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS analytics_projections (
+    user_id BIGINT UNSIGNED PRIMARY KEY,
+    projected_at DATETIME(3) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS analytics_daily_totals (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    date DATE NOT NULL,
+    currency_id BIGINT UNSIGNED NOT NULL,
+    transaction_type VARCHAR(16) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    transaction_count INT NOT NULL,
+    UNIQUE INDEX idx_analytics_daily_totals_key (user_id, date, currency_id, transaction_type)
+);
+
+CREATE TABLE IF NOT EXISTS analytics_category_totals (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    date DATE NOT NULL,
+    category_id BIGINT UNSIGNED NOT NULL,
+    currency_id BIGINT UNSIGNED NOT NULL,
+    transaction_type VARCHAR(16) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    transaction_count INT NOT NULL,
+    INDEX idx_analytics_category_totals_user_date (user_id, date)
+);
+
+CREATE TABLE IF NOT EXISTS analytics_budget_progress (
+    budget_id BIGINT UNSIGNED PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    category_id BIGINT UNSIGNED NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    allowance DECIMAL(15,2) NOT NULL,
+    spent DECIMAL(15,2) NOT NULL,
+    INDEX idx_analytics_budget_progress_user_id (user_id)
+);
@@ -0,0 +1 @@
+ALTER TABLE outbox_events ADD COLUMN delivered_to TEXT NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE archived_incomes DROP COLUMN client_id;
+ALTER TABLE archived_incomes DROP COLUMN version;
+
+ALTER TABLE archived_expenses DROP COLUMN client_id;
+ALTER TABLE archived_expenses DROP COLUMN version;
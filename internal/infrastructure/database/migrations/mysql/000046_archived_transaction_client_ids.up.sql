@@ -0,0 +1,5 @@
+ALTER TABLE archived_expenses ADD COLUMN version INT NOT NULL DEFAULT 1;
+ALTER TABLE archived_expenses ADD COLUMN client_id VARCHAR(36) NULL;
+
+ALTER TABLE archived_incomes ADD COLUMN version INT NOT NULL DEFAULT 1;
+ALTER TABLE archived_incomes ADD COLUMN client_id VARCHAR(36) NULL;
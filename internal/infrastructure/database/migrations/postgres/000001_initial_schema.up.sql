@@ -0,0 +1,137 @@
+-- Initial schema, matching the tables previously created by GORM AutoMigrate.
+-- IF NOT EXISTS lets databases created by AutoMigrate adopt migrations as-is.
+
+CREATE TABLE IF NOT EXISTS users (
+    id BIGSERIAL PRIMARY KEY,
+    email TEXT NOT NULL,
+    password_hash TEXT NOT NULL,
+    role TEXT DEFAULT 'user',
+    last_login_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT chk_users_role CHECK (role IN ('user', 'admin', 'super_admin'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+
+CREATE TABLE IF NOT EXISTS currencies (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT,
+    code TEXT NOT NULL,
+    name TEXT NOT NULL,
+    symbol TEXT NOT NULL,
+    is_default BOOLEAN DEFAULT false,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_currencies_user_id ON currencies (user_id);
+
+CREATE TABLE IF NOT EXISTS categories (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT,
+    name TEXT NOT NULL,
+    color TEXT DEFAULT '#3B82F6',
+    is_default BOOLEAN DEFAULT false,
+    category_type TEXT DEFAULT 'expense',
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_categories_user_id ON categories (user_id);
+
+CREATE TABLE IF NOT EXISTS expenses (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    category_id BIGINT NOT NULL,
+    currency_id BIGINT NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    description TEXT,
+    date DATE NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_expenses_user_id ON expenses (user_id);
+CREATE INDEX IF NOT EXISTS idx_expenses_category_id ON expenses (category_id);
+CREATE INDEX IF NOT EXISTS idx_expenses_currency_id ON expenses (currency_id);
+
+CREATE TABLE IF NOT EXISTS incomes (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    category_id BIGINT NOT NULL,
+    currency_id BIGINT NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    description TEXT,
+    date DATE NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_incomes_user_id ON incomes (user_id);
+CREATE INDEX IF NOT EXISTS idx_incomes_category_id ON incomes (category_id);
+CREATE INDEX IF NOT EXISTS idx_incomes_currency_id ON incomes (currency_id);
+
+CREATE TABLE IF NOT EXISTS budgets (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    category_id BIGINT NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    period TEXT NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT chk_budgets_period CHECK (period IN ('weekly', 'monthly', 'yearly'))
+);
+CREATE INDEX IF NOT EXISTS idx_budgets_user_id ON budgets (user_id);
+CREATE INDEX IF NOT EXISTS idx_budgets_category_id ON budgets (category_id);
+
+CREATE TABLE IF NOT EXISTS recurring_transactions (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    category_id BIGINT NOT NULL,
+    currency_id BIGINT NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    description TEXT,
+    frequency TEXT NOT NULL,
+    next_due_date DATE NOT NULL,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT chk_recurring_transactions_frequency CHECK (frequency IN ('daily', 'weekly', 'monthly', 'yearly'))
+);
+CREATE INDEX IF NOT EXISTS idx_recurring_transactions_user_id ON recurring_transactions (user_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_transactions_category_id ON recurring_transactions (category_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_transactions_currency_id ON recurring_transactions (currency_id);
+
+CREATE TABLE IF NOT EXISTS user_preferences (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    primary_currency_id BIGINT NOT NULL,
+    email_notifications BOOLEAN DEFAULT true,
+    budget_alerts BOOLEAN DEFAULT true,
+    recurring_reminders BOOLEAN DEFAULT true,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_preferences_user_id ON user_preferences (user_id);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    title TEXT NOT NULL,
+    message TEXT NOT NULL,
+    type TEXT NOT NULL,
+    is_read BOOLEAN DEFAULT false,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications (user_id);
+
+CREATE TABLE IF NOT EXISTS api_version_usages (
+    id BIGSERIAL PRIMARY KEY,
+    version TEXT NOT NULL,
+    method TEXT NOT NULL,
+    route TEXT NOT NULL,
+    request_count BIGINT NOT NULL DEFAULT 0,
+    first_seen_at TIMESTAMPTZ NOT NULL,
+    last_seen_at TIMESTAMPTZ NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_version_usage_endpoint ON api_version_usages (version, method, route);
+CREATE INDEX IF NOT EXISTS idx_api_version_usages_last_seen_at ON api_version_usages (last_seen_at);
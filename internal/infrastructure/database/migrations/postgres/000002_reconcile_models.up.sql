@@ -0,0 +1,49 @@
+-- Bring databases created by the old ad-hoc scripts in line with the GORM models.
+-- Every statement is a no-op on a schema created by 000001.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role TEXT DEFAULT 'user';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS last_login_at TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ;
+
+-- scripts/add_user_role.sql named the role check differently
+ALTER TABLE users DROP CONSTRAINT IF EXISTS check_user_role;
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'chk_users_role') THEN
+        ALTER TABLE users ADD CONSTRAINT chk_users_role CHECK (role IN ('user', 'admin', 'super_admin'));
+    END IF;
+END $$;
+
+ALTER TABLE currencies ADD COLUMN IF NOT EXISTS user_id BIGINT;
+ALTER TABLE currencies ADD COLUMN IF NOT EXISTS is_default BOOLEAN DEFAULT false;
+ALTER TABLE currencies ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ;
+ALTER TABLE currencies ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ;
+
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS user_id BIGINT;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS color TEXT DEFAULT '#3B82F6';
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS is_default BOOLEAN DEFAULT false;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS category_type TEXT DEFAULT 'expense';
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ;
+
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS currency_id BIGINT;
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ;
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ;
+
+ALTER TABLE incomes ADD COLUMN IF NOT EXISTS currency_id BIGINT;
+ALTER TABLE incomes ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ;
+ALTER TABLE incomes ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ;
+
+ALTER TABLE budgets ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ;
+ALTER TABLE budgets ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ;
+
+ALTER TABLE recurring_transactions ADD COLUMN IF NOT EXISTS is_active BOOLEAN DEFAULT true;
+ALTER TABLE recurring_transactions ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ;
+ALTER TABLE recurring_transactions ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ;
+
+ALTER TABLE user_preferences ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ;
+ALTER TABLE user_preferences ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ;
+
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS archived_incomes;
+DROP TABLE IF EXISTS archived_expenses;
@@ -0,0 +1,28 @@
+-- Transactions older than the retention period are moved here by the archival job
+CREATE TABLE IF NOT EXISTS archived_expenses (
+    id BIGINT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    category_id BIGINT NOT NULL,
+    currency_id BIGINT NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    description TEXT,
+    date DATE NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    archived_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_archived_expenses_user_date ON archived_expenses (user_id, date);
+
+CREATE TABLE IF NOT EXISTS archived_incomes (
+    id BIGINT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    category_id BIGINT NOT NULL,
+    currency_id BIGINT NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    description TEXT,
+    date DATE NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    archived_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_archived_incomes_user_date ON archived_incomes (user_id, date);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS feature_flags (
+    id BIGSERIAL PRIMARY KEY,
+    flag_key VARCHAR(64) NOT NULL,
+    description TEXT,
+    enabled BOOLEAN NOT NULL DEFAULT false,
+    rollout_percentage BIGINT NOT NULL DEFAULT 0,
+    user_ids TEXT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_feature_flags_flag_key ON feature_flags (flag_key);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS notification_channels (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    kind TEXT NOT NULL,
+    name TEXT NOT NULL,
+    webhook_url TEXT NOT NULL,
+    types TEXT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_notification_channels_user_id ON notification_channels (user_id);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS actions (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    kind TEXT NOT NULL,
+    target TEXT NOT NULL,
+    target_id BIGINT NOT NULL,
+    snapshot TEXT NOT NULL,
+    undone_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_actions_user_id ON actions (user_id);
+CREATE INDEX IF NOT EXISTS idx_actions_target ON actions (target, target_id);
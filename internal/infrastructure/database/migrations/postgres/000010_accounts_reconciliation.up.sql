@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS accounts (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    name TEXT NOT NULL,
+    account_type TEXT NOT NULL,
+    currency_id BIGINT NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_accounts_user_id ON accounts (user_id);
+
+ALTER TABLE expenses ADD COLUMN account_id BIGINT;
+ALTER TABLE expenses ADD COLUMN status TEXT NOT NULL DEFAULT 'uncleared';
+CREATE INDEX IF NOT EXISTS idx_expenses_account_id ON expenses (account_id);
+
+ALTER TABLE incomes ADD COLUMN account_id BIGINT;
+ALTER TABLE incomes ADD COLUMN status TEXT NOT NULL DEFAULT 'uncleared';
+CREATE INDEX IF NOT EXISTS idx_incomes_account_id ON incomes (account_id);
+
+ALTER TABLE archived_expenses ADD COLUMN account_id BIGINT;
+ALTER TABLE archived_expenses ADD COLUMN status TEXT NOT NULL DEFAULT 'uncleared';
+CREATE INDEX IF NOT EXISTS idx_archived_expenses_account_id ON archived_expenses (account_id);
+
+ALTER TABLE archived_incomes ADD COLUMN account_id BIGINT;
+ALTER TABLE archived_incomes ADD COLUMN status TEXT NOT NULL DEFAULT 'uncleared';
+CREATE INDEX IF NOT EXISTS idx_archived_incomes_account_id ON archived_incomes (account_id);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS balance_assertions (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    account_id BIGINT NOT NULL,
+    date DATE NOT NULL,
+    balance DECIMAL(10,2) NOT NULL,
+    created_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_balance_assertions_user_id ON balance_assertions (user_id);
+CREATE INDEX IF NOT EXISTS idx_balance_assertions_account_id ON balance_assertions (account_id);
@@ -0,0 +1,13 @@
+ALTER TABLE expenses DROP COLUMN IF EXISTS receipt_reference;
+ALTER TABLE expenses DROP COLUMN IF EXISTS tax_deductible;
+
+ALTER TABLE incomes DROP COLUMN IF EXISTS receipt_reference;
+ALTER TABLE incomes DROP COLUMN IF EXISTS tax_deductible;
+
+ALTER TABLE archived_expenses DROP COLUMN IF EXISTS receipt_reference;
+ALTER TABLE archived_expenses DROP COLUMN IF EXISTS tax_deductible;
+
+ALTER TABLE archived_incomes DROP COLUMN IF EXISTS receipt_reference;
+ALTER TABLE archived_incomes DROP COLUMN IF EXISTS tax_deductible;
+
+DROP TABLE IF EXISTS tax_deductible_categories;
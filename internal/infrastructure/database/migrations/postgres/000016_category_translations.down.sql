@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS category_translations;
+
+DROP INDEX IF EXISTS idx_categories_translation_key;
+ALTER TABLE categories DROP COLUMN IF EXISTS translation_key;
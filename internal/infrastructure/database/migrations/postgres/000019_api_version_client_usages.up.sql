@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS api_version_client_usages (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    version TEXT NOT NULL,
+    request_count BIGINT NOT NULL DEFAULT 0,
+    first_seen_at TIMESTAMPTZ NOT NULL,
+    last_seen_at TIMESTAMPTZ NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_version_client_usage_user ON api_version_client_usages (user_id, version);
@@ -0,0 +1,2 @@
+ALTER TABLE recurring_transactions DROP COLUMN IF EXISTS next_date_override;
+ALTER TABLE recurring_transactions DROP COLUMN IF EXISTS next_amount_override;
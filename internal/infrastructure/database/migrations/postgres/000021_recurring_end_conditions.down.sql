@@ -0,0 +1,2 @@
+ALTER TABLE recurring_transactions DROP COLUMN IF EXISTS occurrences_remaining;
+ALTER TABLE recurring_transactions DROP COLUMN IF EXISTS end_date;
@@ -0,0 +1,2 @@
+ALTER TABLE recurring_transactions ADD COLUMN end_date DATE;
+ALTER TABLE recurring_transactions ADD COLUMN occurrences_remaining INTEGER;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS anomalies;
+ALTER TABLE user_preferences DROP COLUMN IF EXISTS anomaly_sensitivity;
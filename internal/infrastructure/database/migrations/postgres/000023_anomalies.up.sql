@@ -0,0 +1,14 @@
+ALTER TABLE user_preferences ADD COLUMN anomaly_sensitivity VARCHAR(10) NOT NULL DEFAULT 'medium';
+
+CREATE TABLE IF NOT EXISTS anomalies (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    category_id BIGINT NOT NULL,
+    transaction_id BIGINT,
+    amount DECIMAL(10,2) NOT NULL,
+    baseline DECIMAL(10,2) NOT NULL,
+    detected_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_anomalies_user_detected ON anomalies (user_id, detected_at);
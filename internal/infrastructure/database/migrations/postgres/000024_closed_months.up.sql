@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS closed_months (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    month DATE NOT NULL,
+    closed_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_closed_months_user_month ON closed_months (user_id, month);
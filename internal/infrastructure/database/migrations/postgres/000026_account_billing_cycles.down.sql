@@ -0,0 +1,3 @@
+ALTER TABLE accounts DROP COLUMN IF EXISTS payment_reminded_for;
+ALTER TABLE accounts DROP COLUMN IF EXISTS payment_due_day;
+ALTER TABLE accounts DROP COLUMN IF EXISTS statement_closing_day;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS balance_adjustments;
+ALTER TABLE accounts DROP COLUMN IF EXISTS archived_at;
+ALTER TABLE accounts DROP COLUMN IF EXISTS opening_balance;
@@ -0,0 +1,13 @@
+ALTER TABLE accounts ADD COLUMN opening_balance DECIMAL(10,2) NOT NULL DEFAULT 0;
+ALTER TABLE accounts ADD COLUMN archived_at TIMESTAMPTZ;
+CREATE TABLE IF NOT EXISTS balance_adjustments (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    account_id BIGINT NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    date DATE NOT NULL,
+    description TEXT,
+    created_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_balance_adjustments_user_id ON balance_adjustments (user_id);
+CREATE INDEX IF NOT EXISTS idx_balance_adjustments_account_id ON balance_adjustments (account_id);
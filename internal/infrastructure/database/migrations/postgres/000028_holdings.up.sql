@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS holdings (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    ticker VARCHAR(16) NOT NULL,
+    quantity DECIMAL(20,8) NOT NULL,
+    cost_basis DECIMAL(10,2) NOT NULL,
+    currency_id BIGINT NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_holdings_user_id ON holdings (user_id);
+CREATE TABLE IF NOT EXISTS holding_snapshots (
+    id BIGSERIAL PRIMARY KEY,
+    holding_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    date DATE NOT NULL,
+    price DECIMAL(18,8) NOT NULL,
+    value DECIMAL(12,2) NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_holding_snapshots_holding_date ON holding_snapshots (holding_id, date);
+CREATE INDEX IF NOT EXISTS idx_holding_snapshots_user_id ON holding_snapshots (user_id);
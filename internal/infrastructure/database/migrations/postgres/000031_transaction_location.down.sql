@@ -0,0 +1,11 @@
+ALTER TABLE expenses DROP COLUMN IF EXISTS longitude;
+ALTER TABLE expenses DROP COLUMN IF EXISTS latitude;
+
+ALTER TABLE incomes DROP COLUMN IF EXISTS longitude;
+ALTER TABLE incomes DROP COLUMN IF EXISTS latitude;
+
+ALTER TABLE archived_expenses DROP COLUMN IF EXISTS longitude;
+ALTER TABLE archived_expenses DROP COLUMN IF EXISTS latitude;
+
+ALTER TABLE archived_incomes DROP COLUMN IF EXISTS longitude;
+ALTER TABLE archived_incomes DROP COLUMN IF EXISTS latitude;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS receipt_line_items (
+    id BIGSERIAL PRIMARY KEY,
+    expense_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    position INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    quantity DECIMAL(12,3) NOT NULL,
+    unit_price DECIMAL(10,2) NOT NULL,
+    category_id BIGINT,
+    created_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_receipt_line_items_expense_id ON receipt_line_items (expense_id);
+CREATE INDEX IF NOT EXISTS idx_receipt_line_items_user_id ON receipt_line_items (user_id);
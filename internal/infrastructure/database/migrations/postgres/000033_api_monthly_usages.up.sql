@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS api_monthly_usages (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    month VARCHAR(7) NOT NULL,
+    request_count BIGINT NOT NULL DEFAULT 0,
+    last_seen_at TIMESTAMPTZ NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_monthly_usage_user ON api_monthly_usages (user_id, month);
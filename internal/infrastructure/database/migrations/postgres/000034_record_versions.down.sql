@@ -0,0 +1,3 @@
+ALTER TABLE budgets DROP COLUMN IF EXISTS version;
+ALTER TABLE incomes DROP COLUMN IF EXISTS version;
+ALTER TABLE expenses DROP COLUMN IF EXISTS version;
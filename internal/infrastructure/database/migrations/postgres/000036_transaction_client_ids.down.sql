@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_incomes_user_client;
+ALTER TABLE incomes DROP COLUMN IF EXISTS client_id;
+
+DROP INDEX IF EXISTS idx_expenses_user_client;
+ALTER TABLE expenses DROP COLUMN IF EXISTS client_id;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS api_daily_usages (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    day VARCHAR(10) NOT NULL,
+    request_count BIGINT NOT NULL DEFAULT 0,
+    last_seen_at TIMESTAMPTZ NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_daily_usage_user ON api_daily_usages (user_id, day);
+CREATE INDEX IF NOT EXISTS idx_api_daily_usages_day ON api_daily_usages (day);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS scheduled_transactions (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    category_id BIGINT NOT NULL,
+    currency_id BIGINT NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    description TEXT,
+    date DATE NOT NULL,
+    transaction_type VARCHAR(16) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'scheduled',
+    transaction_id BIGINT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT chk_scheduled_transactions_transaction_type CHECK (transaction_type IN ('expense', 'income'))
+);
+CREATE INDEX IF NOT EXISTS idx_scheduled_transactions_user_id ON scheduled_transactions (user_id);
+CREATE INDEX IF NOT EXISTS idx_scheduled_transactions_category_id ON scheduled_transactions (category_id);
+CREATE INDEX IF NOT EXISTS idx_scheduled_transactions_currency_id ON scheduled_transactions (currency_id);
+CREATE INDEX IF NOT EXISTS idx_scheduled_transactions_status_date ON scheduled_transactions (status, date);
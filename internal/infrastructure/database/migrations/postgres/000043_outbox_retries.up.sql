@@ -0,0 +1,2 @@
+ALTER TABLE outbox_events ADD COLUMN next_attempt_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_next_attempt_at ON outbox_events (next_attempt_at);
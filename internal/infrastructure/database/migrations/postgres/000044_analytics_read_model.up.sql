@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS analytics_projections (
+    user_id BIGINT PRIMARY KEY,
+    projected_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS analytics_daily_totals (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    date DATE NOT NULL,
+    currency_id BIGINT NOT NULL,
+    transaction_type VARCHAR(16) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    transaction_count INTEGER NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_analytics_daily_totals_key ON analytics_daily_totals (user_id, date, currency_id, transaction_type);
+
+CREATE TABLE IF NOT EXISTS analytics_category_totals (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    date DATE NOT NULL,
+    category_id BIGINT NOT NULL,
+    currency_id BIGINT NOT NULL,
+    transaction_type VARCHAR(16) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    transaction_count INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_analytics_category_totals_user_date ON analytics_category_totals (user_id, date);
+
+CREATE TABLE IF NOT EXISTS analytics_budget_progress (
+    budget_id BIGINT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    category_id BIGINT NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    allowance DECIMAL(15,2) NOT NULL,
+    spent DECIMAL(15,2) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_analytics_budget_progress_user_id ON analytics_budget_progress (user_id);
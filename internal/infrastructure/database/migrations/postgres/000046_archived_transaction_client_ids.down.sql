@@ -0,0 +1,5 @@
+ALTER TABLE archived_incomes DROP COLUMN IF EXISTS client_id;
+ALTER TABLE archived_incomes DROP COLUMN IF EXISTS version;
+
+ALTER TABLE archived_expenses DROP COLUMN IF EXISTS client_id;
+ALTER TABLE archived_expenses DROP COLUMN IF EXISTS version;
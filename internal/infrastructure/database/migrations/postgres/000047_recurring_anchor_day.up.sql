@@ -0,0 +1 @@
+ALTER TABLE recurring_transactions ADD COLUMN anchor_day INTEGER;
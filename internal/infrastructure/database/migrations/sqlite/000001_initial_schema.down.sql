@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS api_version_usages;
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS user_preferences;
+DROP TABLE IF EXISTS recurring_transactions;
+DROP TABLE IF EXISTS budgets;
+DROP TABLE IF EXISTS incomes;
+DROP TABLE IF EXISTS expenses;
+DROP TABLE IF EXISTS categories;
+DROP TABLE IF EXISTS currencies;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,136 @@
+-- Initial schema, SQLite flavour of postgres/000001_initial_schema.up.sql.
+
+CREATE TABLE IF NOT EXISTS users (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    email TEXT NOT NULL,
+    password_hash TEXT NOT NULL,
+    role TEXT DEFAULT 'user',
+    last_login_at DATETIME,
+    created_at DATETIME,
+    updated_at DATETIME,
+    CONSTRAINT chk_users_role CHECK (role IN ('user', 'admin', 'super_admin'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+
+CREATE TABLE IF NOT EXISTS currencies (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER,
+    code TEXT NOT NULL,
+    name TEXT NOT NULL,
+    symbol TEXT NOT NULL,
+    is_default NUMERIC DEFAULT false,
+    created_at DATETIME,
+    updated_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_currencies_user_id ON currencies (user_id);
+
+CREATE TABLE IF NOT EXISTS categories (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER,
+    name TEXT NOT NULL,
+    color TEXT DEFAULT '#3B82F6',
+    is_default NUMERIC DEFAULT false,
+    category_type TEXT DEFAULT 'expense',
+    created_at DATETIME,
+    updated_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_categories_user_id ON categories (user_id);
+
+CREATE TABLE IF NOT EXISTS expenses (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    category_id INTEGER NOT NULL,
+    currency_id INTEGER NOT NULL,
+    amount NUMERIC(10,2) NOT NULL,
+    description TEXT,
+    date DATE NOT NULL,
+    created_at DATETIME,
+    updated_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_expenses_user_id ON expenses (user_id);
+CREATE INDEX IF NOT EXISTS idx_expenses_category_id ON expenses (category_id);
+CREATE INDEX IF NOT EXISTS idx_expenses_currency_id ON expenses (currency_id);
+
+CREATE TABLE IF NOT EXISTS incomes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    category_id INTEGER NOT NULL,
+    currency_id INTEGER NOT NULL,
+    amount NUMERIC(10,2) NOT NULL,
+    description TEXT,
+    date DATE NOT NULL,
+    created_at DATETIME,
+    updated_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_incomes_user_id ON incomes (user_id);
+CREATE INDEX IF NOT EXISTS idx_incomes_category_id ON incomes (category_id);
+CREATE INDEX IF NOT EXISTS idx_incomes_currency_id ON incomes (currency_id);
+
+CREATE TABLE IF NOT EXISTS budgets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    category_id INTEGER NOT NULL,
+    amount NUMERIC(10,2) NOT NULL,
+    period TEXT NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    created_at DATETIME,
+    updated_at DATETIME,
+    CONSTRAINT chk_budgets_period CHECK (period IN ('weekly', 'monthly', 'yearly'))
+);
+CREATE INDEX IF NOT EXISTS idx_budgets_user_id ON budgets (user_id);
+CREATE INDEX IF NOT EXISTS idx_budgets_category_id ON budgets (category_id);
+
+CREATE TABLE IF NOT EXISTS recurring_transactions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    category_id INTEGER NOT NULL,
+    currency_id INTEGER NOT NULL,
+    amount NUMERIC(10,2) NOT NULL,
+    description TEXT,
+    frequency TEXT NOT NULL,
+    next_due_date DATE NOT NULL,
+    is_active NUMERIC DEFAULT true,
+    created_at DATETIME,
+    updated_at DATETIME,
+    CONSTRAINT chk_recurring_transactions_frequency CHECK (frequency IN ('daily', 'weekly', 'monthly', 'yearly'))
+);
+CREATE INDEX IF NOT EXISTS idx_recurring_transactions_user_id ON recurring_transactions (user_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_transactions_category_id ON recurring_transactions (category_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_transactions_currency_id ON recurring_transactions (currency_id);
+
+CREATE TABLE IF NOT EXISTS user_preferences (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    primary_currency_id INTEGER NOT NULL,
+    email_notifications NUMERIC DEFAULT true,
+    budget_alerts NUMERIC DEFAULT true,
+    recurring_reminders NUMERIC DEFAULT true,
+    created_at DATETIME,
+    updated_at DATETIME
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_preferences_user_id ON user_preferences (user_id);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    message TEXT NOT NULL,
+    type TEXT NOT NULL,
+    is_read NUMERIC DEFAULT false,
+    created_at DATETIME,
+    updated_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications (user_id);
+
+CREATE TABLE IF NOT EXISTS api_version_usages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    version TEXT NOT NULL,
+    method TEXT NOT NULL,
+    route TEXT NOT NULL,
+    request_count INTEGER NOT NULL DEFAULT 0,
+    first_seen_at DATETIME NOT NULL,
+    last_seen_at DATETIME NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_version_usage_endpoint ON api_version_usages (version, method, route);
+CREATE INDEX IF NOT EXISTS idx_api_version_usages_last_seen_at ON api_version_usages (last_seen_at);
@@ -0,0 +1,2 @@
+-- Intentionally empty: the reconciliation only applies to PostgreSQL databases
+-- created before versioned migrations. Kept so versions line up across dialects.
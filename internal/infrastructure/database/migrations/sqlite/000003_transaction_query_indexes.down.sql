@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_budgets_user_period;
+DROP INDEX IF EXISTS idx_incomes_user_category_date;
+DROP INDEX IF EXISTS idx_incomes_user_date;
+DROP INDEX IF EXISTS idx_expenses_user_category_date;
+DROP INDEX IF EXISTS idx_expenses_user_date;
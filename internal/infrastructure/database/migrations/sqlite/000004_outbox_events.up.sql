@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_name TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    occurred_at DATETIME NOT NULL,
+    published_at DATETIME,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_events_event_name ON outbox_events (event_name);
+CREATE INDEX IF NOT EXISTS idx_outbox_events_published_at ON outbox_events (published_at);
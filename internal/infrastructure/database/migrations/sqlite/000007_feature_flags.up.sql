@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS feature_flags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    flag_key TEXT NOT NULL,
+    description TEXT,
+    enabled NUMERIC NOT NULL DEFAULT false,
+    rollout_percentage INTEGER NOT NULL DEFAULT 0,
+    user_ids TEXT,
+    created_at DATETIME,
+    updated_at DATETIME
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_feature_flags_flag_key ON feature_flags (flag_key);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS actions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    kind TEXT NOT NULL,
+    target TEXT NOT NULL,
+    target_id INTEGER NOT NULL,
+    snapshot TEXT NOT NULL,
+    undone_at DATETIME,
+    created_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_actions_user_id ON actions (user_id);
+CREATE INDEX IF NOT EXISTS idx_actions_target ON actions (target, target_id);
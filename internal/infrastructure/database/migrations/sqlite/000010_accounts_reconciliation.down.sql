@@ -0,0 +1,17 @@
+DROP INDEX IF EXISTS idx_expenses_account_id;
+ALTER TABLE expenses DROP COLUMN status;
+ALTER TABLE expenses DROP COLUMN account_id;
+
+DROP INDEX IF EXISTS idx_incomes_account_id;
+ALTER TABLE incomes DROP COLUMN status;
+ALTER TABLE incomes DROP COLUMN account_id;
+
+DROP INDEX IF EXISTS idx_archived_expenses_account_id;
+ALTER TABLE archived_expenses DROP COLUMN status;
+ALTER TABLE archived_expenses DROP COLUMN account_id;
+
+DROP INDEX IF EXISTS idx_archived_incomes_account_id;
+ALTER TABLE archived_incomes DROP COLUMN status;
+ALTER TABLE archived_incomes DROP COLUMN account_id;
+
+DROP TABLE IF EXISTS accounts;
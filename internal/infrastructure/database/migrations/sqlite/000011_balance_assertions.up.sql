@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS balance_assertions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    account_id INTEGER NOT NULL,
+    date DATE NOT NULL,
+    balance NUMERIC(10,2) NOT NULL,
+    created_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_balance_assertions_user_id ON balance_assertions (user_id);
+CREATE INDEX IF NOT EXISTS idx_balance_assertions_account_id ON balance_assertions (account_id);
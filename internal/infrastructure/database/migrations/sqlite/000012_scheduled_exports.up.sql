@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS export_schedules (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    format TEXT NOT NULL,
+    provider TEXT NOT NULL,
+    target TEXT NOT NULL,
+    access_token TEXT,
+    frequency TEXT NOT NULL,
+    next_run_at DATETIME NOT NULL,
+    created_at DATETIME,
+    updated_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_export_schedules_user_id ON export_schedules (user_id);
+CREATE INDEX IF NOT EXISTS idx_export_schedules_next_run_at ON export_schedules (next_run_at);
+
+CREATE TABLE IF NOT EXISTS export_runs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    schedule_id INTEGER NOT NULL,
+    provider TEXT NOT NULL,
+    file_name TEXT NOT NULL,
+    transaction_count INTEGER NOT NULL DEFAULT 0,
+    status TEXT NOT NULL,
+    error TEXT,
+    created_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_export_runs_user_id ON export_runs (user_id);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS tax_deductible_categories (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    category_id INTEGER NOT NULL,
+    created_at DATETIME
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tax_deductible_categories_user_category ON tax_deductible_categories (user_id, category_id);
+
+ALTER TABLE expenses ADD COLUMN tax_deductible BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE expenses ADD COLUMN receipt_reference TEXT;
+
+ALTER TABLE incomes ADD COLUMN tax_deductible BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE incomes ADD COLUMN receipt_reference TEXT;
+
+ALTER TABLE archived_expenses ADD COLUMN tax_deductible BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE archived_expenses ADD COLUMN receipt_reference TEXT;
+
+ALTER TABLE archived_incomes ADD COLUMN tax_deductible BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE archived_incomes ADD COLUMN receipt_reference TEXT;
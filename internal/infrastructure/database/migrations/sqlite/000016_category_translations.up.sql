@@ -0,0 +1,38 @@
+ALTER TABLE categories ADD COLUMN translation_key TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_translation_key ON categories (translation_key);
+
+-- Default categories seeded before translation keys existed
+UPDATE categories SET translation_key = 'expense.food' WHERE is_default = true AND user_id IS NULL AND category_type = 'expense' AND name = 'Food';
+UPDATE categories SET translation_key = 'expense.transport' WHERE is_default = true AND user_id IS NULL AND category_type = 'expense' AND name = 'Transport';
+UPDATE categories SET translation_key = 'expense.entertainment' WHERE is_default = true AND user_id IS NULL AND category_type = 'expense' AND name = 'Entertainment';
+UPDATE categories SET translation_key = 'expense.shopping' WHERE is_default = true AND user_id IS NULL AND category_type = 'expense' AND name = 'Shopping';
+UPDATE categories SET translation_key = 'expense.bills' WHERE is_default = true AND user_id IS NULL AND category_type = 'expense' AND name = 'Bills';
+UPDATE categories SET translation_key = 'expense.healthcare' WHERE is_default = true AND user_id IS NULL AND category_type = 'expense' AND name = 'Healthcare';
+UPDATE categories SET translation_key = 'expense.education' WHERE is_default = true AND user_id IS NULL AND category_type = 'expense' AND name = 'Education';
+UPDATE categories SET translation_key = 'expense.other' WHERE is_default = true AND user_id IS NULL AND category_type = 'expense' AND name = 'Other';
+UPDATE categories SET translation_key = 'income.salary' WHERE is_default = true AND user_id IS NULL AND category_type = 'income' AND name = 'Salary';
+UPDATE categories SET translation_key = 'income.bonus' WHERE is_default = true AND user_id IS NULL AND category_type = 'income' AND name = 'Bonus';
+UPDATE categories SET translation_key = 'income.freelance' WHERE is_default = true AND user_id IS NULL AND category_type = 'income' AND name = 'Freelance';
+UPDATE categories SET translation_key = 'income.other' WHERE is_default = true AND user_id IS NULL AND category_type = 'income' AND name = 'Other';
+
+CREATE TABLE IF NOT EXISTS category_translations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    translation_key TEXT NOT NULL,
+    locale TEXT NOT NULL,
+    name TEXT NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_category_translations_key_locale ON category_translations (translation_key, locale);
+
+INSERT INTO category_translations (translation_key, locale, name) VALUES
+    ('expense.food', 'id', 'Makanan'),
+    ('expense.transport', 'id', 'Transportasi'),
+    ('expense.entertainment', 'id', 'Hiburan'),
+    ('expense.shopping', 'id', 'Belanja'),
+    ('expense.bills', 'id', 'Tagihan'),
+    ('expense.healthcare', 'id', 'Kesehatan'),
+    ('expense.education', 'id', 'Pendidikan'),
+    ('expense.other', 'id', 'Lainnya'),
+    ('income.salary', 'id', 'Gaji'),
+    ('income.bonus', 'id', 'Bonus'),
+    ('income.freelance', 'id', 'Pekerjaan Lepas'),
+    ('income.other', 'id', 'Lainnya');
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS api_version_client_usages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    version TEXT NOT NULL,
+    request_count INTEGER NOT NULL DEFAULT 0,
+    first_seen_at DATETIME NOT NULL,
+    last_seen_at DATETIME NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_version_client_usage_user ON api_version_client_usages (user_id, version);
@@ -0,0 +1,2 @@
+ALTER TABLE recurring_transactions ADD COLUMN next_amount_override NUMERIC(10,2);
+ALTER TABLE recurring_transactions ADD COLUMN next_date_override DATE;
@@ -0,0 +1,14 @@
+ALTER TABLE user_preferences ADD COLUMN anomaly_sensitivity TEXT NOT NULL DEFAULT 'medium';
+
+CREATE TABLE IF NOT EXISTS anomalies (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    kind TEXT NOT NULL,
+    category_id INTEGER NOT NULL,
+    transaction_id INTEGER,
+    amount NUMERIC(10,2) NOT NULL,
+    baseline NUMERIC(10,2) NOT NULL,
+    detected_at DATETIME NOT NULL,
+    created_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_anomalies_user_detected ON anomalies (user_id, detected_at);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS closed_months (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    month DATE NOT NULL,
+    closed_at DATETIME NOT NULL,
+    created_at DATETIME
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_closed_months_user_month ON closed_months (user_id, month);
@@ -0,0 +1,3 @@
+ALTER TABLE accounts DROP COLUMN payment_reminded_for;
+ALTER TABLE accounts DROP COLUMN payment_due_day;
+ALTER TABLE accounts DROP COLUMN statement_closing_day;
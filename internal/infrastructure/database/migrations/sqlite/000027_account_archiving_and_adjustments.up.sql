@@ -0,0 +1,13 @@
+ALTER TABLE accounts ADD COLUMN opening_balance NUMERIC(10,2) NOT NULL DEFAULT 0;
+ALTER TABLE accounts ADD COLUMN archived_at DATETIME;
+CREATE TABLE IF NOT EXISTS balance_adjustments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    account_id INTEGER NOT NULL,
+    amount NUMERIC(10,2) NOT NULL,
+    date DATE NOT NULL,
+    description TEXT,
+    created_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_balance_adjustments_user_id ON balance_adjustments (user_id);
+CREATE INDEX IF NOT EXISTS idx_balance_adjustments_account_id ON balance_adjustments (account_id);
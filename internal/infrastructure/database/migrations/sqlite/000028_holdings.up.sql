@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS holdings (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    ticker VARCHAR(16) NOT NULL,
+    quantity NUMERIC(20,8) NOT NULL,
+    cost_basis NUMERIC(10,2) NOT NULL,
+    currency_id INTEGER NOT NULL,
+    created_at DATETIME,
+    updated_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_holdings_user_id ON holdings (user_id);
+CREATE TABLE IF NOT EXISTS holding_snapshots (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    holding_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    date DATE NOT NULL,
+    price NUMERIC(18,8) NOT NULL,
+    value NUMERIC(12,2) NOT NULL,
+    created_at DATETIME,
+    updated_at DATETIME
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_holding_snapshots_holding_date ON holding_snapshots (holding_id, date);
+CREATE INDEX IF NOT EXISTS idx_holding_snapshots_user_id ON holding_snapshots (user_id);
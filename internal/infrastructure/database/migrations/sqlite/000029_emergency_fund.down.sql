@@ -0,0 +1 @@
+ALTER TABLE accounts DROP COLUMN emergency_fund;
@@ -0,0 +1 @@
+ALTER TABLE user_preferences ADD COLUMN benchmarking BOOLEAN NOT NULL DEFAULT false;
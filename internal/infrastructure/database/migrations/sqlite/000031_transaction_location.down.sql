@@ -0,0 +1,11 @@
+ALTER TABLE expenses DROP COLUMN longitude;
+ALTER TABLE expenses DROP COLUMN latitude;
+
+ALTER TABLE incomes DROP COLUMN longitude;
+ALTER TABLE incomes DROP COLUMN latitude;
+
+ALTER TABLE archived_expenses DROP COLUMN longitude;
+ALTER TABLE archived_expenses DROP COLUMN latitude;
+
+ALTER TABLE archived_incomes DROP COLUMN longitude;
+ALTER TABLE archived_incomes DROP COLUMN latitude;
@@ -0,0 +1,11 @@
+ALTER TABLE expenses ADD COLUMN latitude REAL;
+ALTER TABLE expenses ADD COLUMN longitude REAL;
+
+ALTER TABLE incomes ADD COLUMN latitude REAL;
+ALTER TABLE incomes ADD COLUMN longitude REAL;
+
+ALTER TABLE archived_expenses ADD COLUMN latitude REAL;
+ALTER TABLE archived_expenses ADD COLUMN longitude REAL;
+
+ALTER TABLE archived_incomes ADD COLUMN latitude REAL;
+ALTER TABLE archived_incomes ADD COLUMN longitude REAL;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS receipt_line_items (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    expense_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    quantity NUMERIC(12,3) NOT NULL,
+    unit_price NUMERIC(10,2) NOT NULL,
+    category_id INTEGER,
+    created_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_receipt_line_items_expense_id ON receipt_line_items (expense_id);
+CREATE INDEX IF NOT EXISTS idx_receipt_line_items_user_id ON receipt_line_items (user_id);
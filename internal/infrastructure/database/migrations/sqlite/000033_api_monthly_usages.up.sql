@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS api_monthly_usages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    month TEXT NOT NULL,
+    request_count INTEGER NOT NULL DEFAULT 0,
+    last_seen_at DATETIME NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_monthly_usage_user ON api_monthly_usages (user_id, month);
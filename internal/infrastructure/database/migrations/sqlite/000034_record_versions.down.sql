@@ -0,0 +1,3 @@
+ALTER TABLE budgets DROP COLUMN version;
+ALTER TABLE incomes DROP COLUMN version;
+ALTER TABLE expenses DROP COLUMN version;
@@ -0,0 +1,3 @@
+ALTER TABLE expenses ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE incomes ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE budgets ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_incomes_user_client;
+ALTER TABLE incomes DROP COLUMN client_id;
+
+DROP INDEX IF EXISTS idx_expenses_user_client;
+ALTER TABLE expenses DROP COLUMN client_id;
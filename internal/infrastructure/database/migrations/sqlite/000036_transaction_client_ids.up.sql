@@ -0,0 +1,5 @@
+ALTER TABLE expenses ADD COLUMN client_id TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_expenses_user_client ON expenses (user_id, client_id);
+
+ALTER TABLE incomes ADD COLUMN client_id TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_incomes_user_client ON incomes (user_id, client_id);
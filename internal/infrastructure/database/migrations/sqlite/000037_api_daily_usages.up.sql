@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS api_daily_usages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    day TEXT NOT NULL,
+    request_count INTEGER NOT NULL DEFAULT 0,
+    last_seen_at DATETIME NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_daily_usage_user ON api_daily_usages (user_id, day);
+CREATE INDEX IF NOT EXISTS idx_api_daily_usages_day ON api_daily_usages (day);
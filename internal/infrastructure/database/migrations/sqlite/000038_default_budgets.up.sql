@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS default_budgets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    category_id INTEGER NOT NULL,
+    amount NUMERIC(10,2) NOT NULL,
+    period TEXT NOT NULL,
+    created_at DATETIME,
+    CONSTRAINT chk_default_budgets_period CHECK (period IN ('weekly', 'monthly', 'yearly'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_default_budgets_category_id ON default_budgets (category_id);
@@ -0,0 +1 @@
+ALTER TABLE user_preferences DROP COLUMN onboarding_completed_at;
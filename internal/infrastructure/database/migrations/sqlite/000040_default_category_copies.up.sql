@@ -0,0 +1,55 @@
+-- Every user gets their own copy of each default category, and what they
+-- recorded against a default category moves to their copy of it
+ALTER TABLE categories ADD COLUMN source_category_id INTEGER;
+CREATE INDEX IF NOT EXISTS idx_categories_source_category_id ON categories (source_category_id);
+
+INSERT INTO categories (user_id, name, color, is_default, category_type, source_category_id, created_at, updated_at)
+SELECT users.id, defaults.name, defaults.color, false, defaults.category_type, defaults.id, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM users CROSS JOIN categories AS defaults
+WHERE defaults.user_id IS NULL AND defaults.is_default = true;
+
+UPDATE expenses SET category_id = (
+    SELECT copies.id FROM categories AS copies WHERE copies.user_id = expenses.user_id AND copies.source_category_id = expenses.category_id
+) WHERE EXISTS (
+    SELECT 1 FROM categories AS copies WHERE copies.user_id = expenses.user_id AND copies.source_category_id = expenses.category_id
+);
+UPDATE incomes SET category_id = (
+    SELECT copies.id FROM categories AS copies WHERE copies.user_id = incomes.user_id AND copies.source_category_id = incomes.category_id
+) WHERE EXISTS (
+    SELECT 1 FROM categories AS copies WHERE copies.user_id = incomes.user_id AND copies.source_category_id = incomes.category_id
+);
+UPDATE archived_expenses SET category_id = (
+    SELECT copies.id FROM categories AS copies WHERE copies.user_id = archived_expenses.user_id AND copies.source_category_id = archived_expenses.category_id
+) WHERE EXISTS (
+    SELECT 1 FROM categories AS copies WHERE copies.user_id = archived_expenses.user_id AND copies.source_category_id = archived_expenses.category_id
+);
+UPDATE archived_incomes SET category_id = (
+    SELECT copies.id FROM categories AS copies WHERE copies.user_id = archived_incomes.user_id AND copies.source_category_id = archived_incomes.category_id
+) WHERE EXISTS (
+    SELECT 1 FROM categories AS copies WHERE copies.user_id = archived_incomes.user_id AND copies.source_category_id = archived_incomes.category_id
+);
+UPDATE budgets SET category_id = (
+    SELECT copies.id FROM categories AS copies WHERE copies.user_id = budgets.user_id AND copies.source_category_id = budgets.category_id
+) WHERE EXISTS (
+    SELECT 1 FROM categories AS copies WHERE copies.user_id = budgets.user_id AND copies.source_category_id = budgets.category_id
+);
+UPDATE recurring_transactions SET category_id = (
+    SELECT copies.id FROM categories AS copies WHERE copies.user_id = recurring_transactions.user_id AND copies.source_category_id = recurring_transactions.category_id
+) WHERE EXISTS (
+    SELECT 1 FROM categories AS copies WHERE copies.user_id = recurring_transactions.user_id AND copies.source_category_id = recurring_transactions.category_id
+);
+UPDATE receipt_line_items SET category_id = (
+    SELECT copies.id FROM categories AS copies WHERE copies.user_id = receipt_line_items.user_id AND copies.source_category_id = receipt_line_items.category_id
+) WHERE EXISTS (
+    SELECT 1 FROM categories AS copies WHERE copies.user_id = receipt_line_items.user_id AND copies.source_category_id = receipt_line_items.category_id
+);
+UPDATE tax_deductible_categories SET category_id = (
+    SELECT copies.id FROM categories AS copies WHERE copies.user_id = tax_deductible_categories.user_id AND copies.source_category_id = tax_deductible_categories.category_id
+) WHERE EXISTS (
+    SELECT 1 FROM categories AS copies WHERE copies.user_id = tax_deductible_categories.user_id AND copies.source_category_id = tax_deductible_categories.category_id
+);
+UPDATE anomalies SET category_id = (
+    SELECT copies.id FROM categories AS copies WHERE copies.user_id = anomalies.user_id AND copies.source_category_id = anomalies.category_id
+) WHERE EXISTS (
+    SELECT 1 FROM categories AS copies WHERE copies.user_id = anomalies.user_id AND copies.source_category_id = anomalies.category_id
+);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS scheduled_transactions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    category_id INTEGER NOT NULL,
+    currency_id INTEGER NOT NULL,
+    amount NUMERIC(10,2) NOT NULL,
+    description TEXT,
+    date DATE NOT NULL,
+    transaction_type TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'scheduled',
+    transaction_id INTEGER,
+    created_at DATETIME,
+    updated_at DATETIME,
+    CONSTRAINT chk_scheduled_transactions_transaction_type CHECK (transaction_type IN ('expense', 'income'))
+);
+CREATE INDEX IF NOT EXISTS idx_scheduled_transactions_user_id ON scheduled_transactions (user_id);
+CREATE INDEX IF NOT EXISTS idx_scheduled_transactions_category_id ON scheduled_transactions (category_id);
+CREATE INDEX IF NOT EXISTS idx_scheduled_transactions_currency_id ON scheduled_transactions (currency_id);
+CREATE INDEX IF NOT EXISTS idx_scheduled_transactions_status_date ON scheduled_transactions (status, date);
@@ -0,0 +1 @@
+ALTER TABLE user_preferences DROP COLUMN trash_retention_days;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS analytics_budget_progress;
+DROP TABLE IF EXISTS analytics_category_totals;
+DROP TABLE IF EXISTS analytics_daily_totals;
+DROP TABLE IF EXISTS analytics_projections;
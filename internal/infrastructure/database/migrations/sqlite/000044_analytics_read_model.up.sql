@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS analytics_projections (
+    user_id INTEGER PRIMARY KEY,
+    projected_at DATETIME NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS analytics_daily_totals (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    date DATE NOT NULL,
+    currency_id INTEGER NOT NULL,
+    transaction_type VARCHAR(16) NOT NULL,
+    amount NUMERIC(15,2) NOT NULL,
+    transaction_count INTEGER NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_analytics_daily_totals_key ON analytics_daily_totals (user_id, date, currency_id, transaction_type);
+
+CREATE TABLE IF NOT EXISTS analytics_category_totals (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    date DATE NOT NULL,
+    category_id INTEGER NOT NULL,
+    currency_id INTEGER NOT NULL,
+    transaction_type VARCHAR(16) NOT NULL,
+    amount NUMERIC(15,2) NOT NULL,
+    transaction_count INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_analytics_category_totals_user_date ON analytics_category_totals (user_id, date);
+
+CREATE TABLE IF NOT EXISTS analytics_budget_progress (
+    budget_id INTEGER PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    category_id INTEGER NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    allowance NUMERIC(15,2) NOT NULL,
+    spent NUMERIC(15,2) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_analytics_budget_progress_user_id ON analytics_budget_progress (user_id);
@@ -8,11 +8,13 @@ import (
 type User struct {
 	ID           uint       `gorm:"primaryKey" json:"id"`
 	Email        string     `gorm:"uniqueIndex;not null" json:"email"`
-	PasswordHash string     `gorm:"not null" json:"-"`
+	PasswordHash string     `gorm:"not null" json:"secret"`
 	Role         string     `gorm:"default:'user';check:role IN ('user', 'admin', 'super_admin')" json:"role"`
 	LastLoginAt  *time.Time `json:"last_login_at,omitempty"`
-	CreatedAt    time.Time  `json:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at"`
+	// DeactivatedAt is set when an admin deactivates the account; deactivated users cannot sign in
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
 
 	// Relationships
 	Currencies            []Currency             `gorm:"foreignKey:UserID" json:"currencies,omitempty"`
@@ -25,6 +27,16 @@ type User struct {
 	Notifications         []Notification         `gorm:"foreignKey:UserID" json:"notifications,omitempty"`
 }
 
+// PasswordResetToken represents an emailed password reset link. Only a hash of the token is stored.
+type PasswordResetToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index:idx_password_reset_tokens_user_created,priority:1" json:"user_id"`
+	TokenHash string     `gorm:"not null;size:64;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `gorm:"index:idx_password_reset_tokens_user_created,priority:2" json:"created_at"`
+}
+
 // Currency represents a currency in the database
 type Currency struct {
 	ID        uint      `gorm:"primaryKey" json:"id"`
@@ -44,16 +56,31 @@ type Currency struct {
 	UserPreferences       []UserPreferences      `gorm:"foreignKey:PrimaryCurrencyID" json:"user_preferences,omitempty"`
 }
 
+// ExchangeRate represents a dated rate between two currency codes in the database
+type ExchangeRate struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	BaseCurrency  string    `gorm:"type:varchar(10);not null;uniqueIndex:idx_exchange_rates_pair_date,priority:1" json:"base_currency"`
+	QuoteCurrency string    `gorm:"type:varchar(10);not null;uniqueIndex:idx_exchange_rates_pair_date,priority:2" json:"quote_currency"`
+	Rate          float64   `gorm:"type:decimal(18,8);not null" json:"rate"`
+	Date          time.Time `gorm:"type:date;not null;uniqueIndex:idx_exchange_rates_pair_date,priority:3" json:"date"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
 // Category represents a category in the database
 type Category struct {
-	ID           uint      `gorm:"primaryKey" json:"id"`
-	UserID       *uint     `gorm:"index" json:"user_id,omitempty"`
-	Name         string    `gorm:"not null" json:"name"`
-	Color        string    `gorm:"default:'#3B82F6'" json:"color"`
-	IsDefault    bool      `gorm:"default:false" json:"is_default"`
-	CategoryType string    `gorm:"default:'expense'" json:"category_type"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID           uint   `gorm:"primaryKey" json:"id"`
+	UserID       *uint  `gorm:"index" json:"user_id,omitempty"`
+	Name         string `gorm:"not null" json:"name"`
+	Color        string `gorm:"default:'#3B82F6'" json:"color"`
+	IsDefault    bool   `gorm:"default:false" json:"is_default"`
+	CategoryType string `gorm:"default:'expense'" json:"category_type"`
+	// TranslationKey identifies a default category across languages; user categories have none
+	TranslationKey *string `gorm:"size:100;uniqueIndex" json:"translation_key,omitempty"`
+	// SourceCategoryID is the default category a user's copy was made from
+	SourceCategoryID *uint     `gorm:"index" json:"source_category_id,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
 
 	// Relationships
 	User                  *User                  `gorm:"foreignKey:UserID" json:"user,omitempty"`
@@ -63,17 +90,132 @@ type Category struct {
 	RecurringTransactions []RecurringTransaction `gorm:"foreignKey:CategoryID" json:"recurring_transactions,omitempty"`
 }
 
-// Expense represents an expense transaction in the database
-type Expense struct {
+// Account represents a bank account, cash wallet or credit card in the database
+type Account struct {
 	ID          uint      `gorm:"primaryKey" json:"id"`
 	UserID      uint      `gorm:"not null;index" json:"user_id"`
-	CategoryID  uint      `gorm:"not null;index" json:"category_id"`
-	CurrencyID  uint      `gorm:"not null;index" json:"currency_id"`
+	Name        string    `gorm:"not null" json:"name"`
+	AccountType string    `gorm:"not null;size:16" json:"account_type"`
+	CurrencyID  uint      `gorm:"not null" json:"currency_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Statement cycle of credit cards; both days are set or neither is
+	StatementClosingDay *int       `json:"statement_closing_day,omitempty"`
+	PaymentDueDay       *int       `json:"payment_due_day,omitempty"`
+	PaymentRemindedFor  *time.Time `gorm:"type:date" json:"payment_reminded_for,omitempty"`
+
+	OpeningBalance float64    `gorm:"type:decimal(10,2);not null;default:0" json:"opening_balance"`
+	ArchivedAt     *time.Time `json:"archived_at,omitempty"`
+
+	EmergencyFund bool `gorm:"not null;default:false" json:"emergency_fund"`
+}
+
+// BalanceAssertion represents a balance the user saw on an account at the end of a day
+type BalanceAssertion struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	AccountID uint      `gorm:"not null;index" json:"account_id"`
+	Date      time.Time `gorm:"type:date;not null" json:"date"`
+	Balance   float64   `gorm:"type:decimal(10,2);not null" json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ReceiptLineItem represents one line of an expense's receipt
+type ReceiptLineItem struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	ExpenseID  uint      `gorm:"not null;index" json:"expense_id"`
+	UserID     uint      `gorm:"not null;index" json:"user_id"`
+	Position   int       `gorm:"not null" json:"position"`
+	Name       string    `gorm:"type:text;not null" json:"name"`
+	Quantity   float64   `gorm:"type:decimal(12,3);not null" json:"quantity"`
+	UnitPrice  float64   `gorm:"type:decimal(10,2);not null" json:"unit_price"`
+	CategoryID *uint     `json:"category_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// BalanceAdjustment represents a change to an account's balance that is neither income nor spending
+type BalanceAdjustment struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	UserID      uint      `gorm:"not null;index" json:"user_id"`
+	AccountID   uint      `gorm:"not null;index" json:"account_id"`
 	Amount      float64   `gorm:"type:decimal(10,2);not null" json:"amount"`
-	Description string    `gorm:"type:text" json:"description"`
 	Date        time.Time `gorm:"type:date;not null" json:"date"`
+	Description string    `json:"description"`
 	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Holding represents an investment holding in the database
+type Holding struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	UserID     uint      `gorm:"not null;index" json:"user_id"`
+	Ticker     string    `gorm:"type:varchar(16);not null" json:"ticker"`
+	Quantity   float64   `gorm:"type:decimal(20,8);not null" json:"quantity"`
+	CostBasis  float64   `gorm:"type:decimal(10,2);not null" json:"cost_basis"`
+	CurrencyID uint      `gorm:"not null" json:"currency_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// HoldingSnapshot represents the price and value of a holding on a day in the database
+type HoldingSnapshot struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	HoldingID uint      `gorm:"not null;uniqueIndex:idx_holding_snapshots_holding_date,priority:1" json:"holding_id"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	Date      time.Time `gorm:"type:date;not null;uniqueIndex:idx_holding_snapshots_holding_date,priority:2" json:"date"`
+	Price     float64   `gorm:"type:decimal(18,8);not null" json:"price"`
+	Value     float64   `gorm:"type:decimal(12,2);not null" json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CategoryTranslation is the name of a default category in one language. The
+// English name stays on the category itself and is used when no translation matches.
+type CategoryTranslation struct {
+	ID             uint   `gorm:"primaryKey" json:"id"`
+	TranslationKey string `gorm:"size:100;not null;uniqueIndex:idx_category_translations_key_locale" json:"translation_key"`
+	Locale         string `gorm:"size:16;not null;uniqueIndex:idx_category_translations_key_locale" json:"locale"`
+	Name           string `gorm:"not null" json:"name"`
+}
+
+// TaxDeductibleCategory records that a user claims an expense category as tax-deductible
+type TaxDeductibleCategory struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	UserID     uint      `gorm:"not null;uniqueIndex:idx_tax_deductible_categories_user_category,priority:1" json:"user_id"`
+	CategoryID uint      `gorm:"not null;uniqueIndex:idx_tax_deductible_categories_user_category,priority:2" json:"category_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ClosedMonth represents a month a user has closed in the database
+type ClosedMonth struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_closed_months_user_month,priority:1" json:"user_id"`
+	Month     time.Time `gorm:"type:date;not null;uniqueIndex:idx_closed_months_user_month,priority:2" json:"month"`
+	ClosedAt  time.Time `gorm:"not null" json:"closed_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Expense represents an expense transaction in the database
+type Expense struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	UserID           uint      `gorm:"not null;index;uniqueIndex:idx_expenses_user_client,priority:1" json:"user_id"`
+	CategoryID       uint      `gorm:"not null;index" json:"category_id"`
+	CurrencyID       uint      `gorm:"not null;index" json:"currency_id"`
+	AccountID        *uint     `gorm:"index" json:"account_id,omitempty"`
+	Amount           float64   `gorm:"type:decimal(10,2);not null" json:"amount"`
+	Description      string    `gorm:"type:text" json:"description"`
+	Date             time.Time `gorm:"type:date;not null" json:"date"`
+	Status           string    `gorm:"size:16;not null;default:uncleared" json:"status"`
+	TaxDeductible    bool      `gorm:"not null;default:false" json:"tax_deductible"`
+	ReceiptReference string    `gorm:"size:255" json:"receipt_reference,omitempty"`
+	Version          int       `gorm:"not null;default:1" json:"version"`
+	ClientID         *string   `gorm:"size:36;uniqueIndex:idx_expenses_user_client,priority:2" json:"client_id,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+
+	// Where the transaction was made; both are NULL when the client did not say
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
 
 	// Relationships
 	User     *User     `gorm:"foreignKey:UserID" json:"user,omitempty"`
@@ -83,15 +225,25 @@ type Expense struct {
 
 // Income represents an income transaction in the database
 type Income struct {
-	ID          uint      `gorm:"primaryKey" json:"id"`
-	UserID      uint      `gorm:"not null;index" json:"user_id"`
-	CategoryID  uint      `gorm:"not null;index" json:"category_id"`
-	CurrencyID  uint      `gorm:"not null;index" json:"currency_id"`
-	Amount      float64   `gorm:"type:decimal(10,2);not null" json:"amount"`
-	Description string    `gorm:"type:text" json:"description"`
-	Date        time.Time `gorm:"type:date;not null" json:"date"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	UserID           uint      `gorm:"not null;index;uniqueIndex:idx_incomes_user_client,priority:1" json:"user_id"`
+	CategoryID       uint      `gorm:"not null;index" json:"category_id"`
+	CurrencyID       uint      `gorm:"not null;index" json:"currency_id"`
+	AccountID        *uint     `gorm:"index" json:"account_id,omitempty"`
+	Amount           float64   `gorm:"type:decimal(10,2);not null" json:"amount"`
+	Description      string    `gorm:"type:text" json:"description"`
+	Date             time.Time `gorm:"type:date;not null" json:"date"`
+	Status           string    `gorm:"size:16;not null;default:uncleared" json:"status"`
+	TaxDeductible    bool      `gorm:"not null;default:false" json:"tax_deductible"`
+	ReceiptReference string    `gorm:"size:255" json:"receipt_reference,omitempty"`
+	Version          int       `gorm:"not null;default:1" json:"version"`
+	ClientID         *string   `gorm:"size:36;uniqueIndex:idx_incomes_user_client,priority:2" json:"client_id,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+
+	// Where the transaction was made; both are NULL when the client did not say
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
 
 	// Relationships
 	User     *User     `gorm:"foreignKey:UserID" json:"user,omitempty"`
@@ -99,6 +251,54 @@ type Income struct {
 	Currency *Currency `gorm:"foreignKey:CurrencyID" json:"currency,omitempty"`
 }
 
+// ArchivedExpense represents an expense moved out of the expenses table by the archival policy
+type ArchivedExpense struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	UserID           uint      `gorm:"not null;index:idx_archived_expenses_user_date,priority:1" json:"user_id"`
+	CategoryID       uint      `gorm:"not null" json:"category_id"`
+	CurrencyID       uint      `gorm:"not null" json:"currency_id"`
+	AccountID        *uint     `gorm:"index" json:"account_id,omitempty"`
+	Amount           float64   `gorm:"type:decimal(10,2);not null" json:"amount"`
+	Description      string    `gorm:"type:text" json:"description"`
+	Date             time.Time `gorm:"type:date;not null;index:idx_archived_expenses_user_date,priority:2" json:"date"`
+	Status           string    `gorm:"size:16;not null;default:uncleared" json:"status"`
+	TaxDeductible    bool      `gorm:"not null;default:false" json:"tax_deductible"`
+	ReceiptReference string    `gorm:"size:255" json:"receipt_reference,omitempty"`
+	Version          int       `gorm:"not null;default:1" json:"version"`
+	ClientID         *string   `gorm:"size:36" json:"client_id,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+	ArchivedAt       time.Time `gorm:"not null" json:"archived_at"`
+
+	// Where the transaction was made; both are NULL when the client did not say
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+}
+
+// ArchivedIncome represents an income moved out of the incomes table by the archival policy
+type ArchivedIncome struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	UserID           uint      `gorm:"not null;index:idx_archived_incomes_user_date,priority:1" json:"user_id"`
+	CategoryID       uint      `gorm:"not null" json:"category_id"`
+	CurrencyID       uint      `gorm:"not null" json:"currency_id"`
+	AccountID        *uint     `gorm:"index" json:"account_id,omitempty"`
+	Amount           float64   `gorm:"type:decimal(10,2);not null" json:"amount"`
+	Description      string    `gorm:"type:text" json:"description"`
+	Date             time.Time `gorm:"type:date;not null;index:idx_archived_incomes_user_date,priority:2" json:"date"`
+	Status           string    `gorm:"size:16;not null;default:uncleared" json:"status"`
+	TaxDeductible    bool      `gorm:"not null;default:false" json:"tax_deductible"`
+	ReceiptReference string    `gorm:"size:255" json:"receipt_reference,omitempty"`
+	Version          int       `gorm:"not null;default:1" json:"version"`
+	ClientID         *string   `gorm:"size:36" json:"client_id,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+	ArchivedAt       time.Time `gorm:"not null" json:"archived_at"`
+
+	// Where the transaction was made; both are NULL when the client did not say
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+}
+
 // Budget represents a budget in the database
 type Budget struct {
 	ID         uint      `gorm:"primaryKey" json:"id"`
@@ -108,6 +308,8 @@ type Budget struct {
 	Period     string    `gorm:"not null;check:period IN ('weekly', 'monthly', 'yearly')" json:"period"`
 	StartDate  time.Time `gorm:"type:date;not null" json:"start_date"`
 	EndDate    time.Time `gorm:"type:date;not null" json:"end_date"`
+	Prorated   bool      `gorm:"not null;default:false" json:"prorated"`
+	Version    int       `gorm:"not null;default:1" json:"version"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
 
@@ -116,6 +318,15 @@ type Budget struct {
 	Category *Category `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
 }
 
+// DefaultBudget represents an entry of the default budget template in the database
+type DefaultBudget struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	CategoryID uint      `gorm:"not null;uniqueIndex" json:"category_id"`
+	Amount     float64   `gorm:"type:decimal(10,2);not null" json:"amount"`
+	Period     string    `gorm:"not null;check:period IN ('weekly', 'monthly', 'yearly')" json:"period"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
 // RecurringTransaction represents a recurring transaction in the database
 type RecurringTransaction struct {
 	ID          uint      `gorm:"primaryKey" json:"id"`
@@ -130,20 +341,58 @@ type RecurringTransaction struct {
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 
+	// AnchorDay is the day of the month monthly and yearly occurrences fall on;
+	// nil for rows saved before it was kept, which follow next_due_date
+	AnchorDay *int `json:"anchor_day,omitempty"`
+
+	// One-time changes to the next occurrence; cleared once it posts or is skipped
+	NextAmountOverride *float64   `gorm:"type:decimal(10,2)" json:"next_amount_override,omitempty"`
+	NextDateOverride   *time.Time `gorm:"type:date" json:"next_date_override,omitempty"`
+
+	// End conditions; nil when the transaction recurs indefinitely
+	EndDate              *time.Time `gorm:"type:date" json:"end_date,omitempty"`
+	OccurrencesRemaining *int       `json:"occurrences_remaining,omitempty"`
+
+	// RemindedFor is the occurrence date the user was last reminded of
+	RemindedFor *time.Time `gorm:"type:date" json:"reminded_for,omitempty"`
+
 	// Relationships
 	User     *User     `gorm:"foreignKey:UserID" json:"user,omitempty"`
 	Category *Category `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
 	Currency *Currency `gorm:"foreignKey:CurrencyID" json:"currency,omitempty"`
 }
 
+// ScheduledTransaction represents a transaction waiting to post on a later date in the database
+type ScheduledTransaction struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	UserID          uint      `gorm:"not null;index" json:"user_id"`
+	CategoryID      uint      `gorm:"not null;index" json:"category_id"`
+	CurrencyID      uint      `gorm:"not null;index" json:"currency_id"`
+	Amount          float64   `gorm:"type:decimal(10,2);not null" json:"amount"`
+	Description     string    `gorm:"type:text" json:"description"`
+	Date            time.Time `gorm:"type:date;not null;index:idx_scheduled_transactions_status_date,priority:2" json:"date"`
+	TransactionType string    `gorm:"size:16;not null;check:transaction_type IN ('expense', 'income')" json:"transaction_type"`
+	Status          string    `gorm:"size:16;not null;default:scheduled;index:idx_scheduled_transactions_status_date,priority:1" json:"status"`
+	// TransactionID is the expense or income it posted as; NULL until it posts
+	TransactionID *uint     `json:"transaction_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
 // UserPreferences represents user preferences in the database
 type UserPreferences struct {
-	ID                 uint      `gorm:"primaryKey" json:"id"`
-	UserID             uint      `gorm:"uniqueIndex;not null" json:"user_id"`
-	PrimaryCurrencyID  uint      `gorm:"not null" json:"primary_currency_id"`
-	EmailNotifications bool      `gorm:"default:true" json:"email_notifications"`
-	BudgetAlerts       bool      `gorm:"default:true" json:"budget_alerts"`
-	RecurringReminders bool      `gorm:"default:true" json:"recurring_reminders"`
+	ID                 uint   `gorm:"primaryKey" json:"id"`
+	UserID             uint   `gorm:"uniqueIndex;not null" json:"user_id"`
+	PrimaryCurrencyID  uint   `gorm:"not null" json:"primary_currency_id"`
+	EmailNotifications bool   `gorm:"default:true" json:"email_notifications"`
+	BudgetAlerts       bool   `gorm:"default:true" json:"budget_alerts"`
+	RecurringReminders bool   `gorm:"default:true" json:"recurring_reminders"`
+	AnomalySensitivity string `gorm:"size:10;not null;default:medium" json:"anomaly_sensitivity"`
+	Benchmarking       bool   `gorm:"not null;default:false" json:"benchmarking"`
+	// OnboardingCompletedAt is nil until the user completes onboarding
+	OnboardingCompletedAt *time.Time `json:"onboarding_completed_at"`
+	// TrashRetentionDays is nil for users who follow the server's trash retention
+	TrashRetentionDays *int      `json:"trash_retention_days"`
 	CreatedAt          time.Time `json:"created_at"`
 	UpdatedAt          time.Time `json:"updated_at"`
 
@@ -152,6 +401,19 @@ type UserPreferences struct {
 	PrimaryCurrency *Currency `gorm:"foreignKey:PrimaryCurrencyID" json:"primary_currency,omitempty"`
 }
 
+// Anomaly represents unusual spending flagged for a user in the database
+type Anomaly struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	UserID        uint      `gorm:"not null;index:idx_anomalies_user_detected,priority:1" json:"user_id"`
+	Kind          string    `gorm:"size:32;not null" json:"kind"`
+	CategoryID    uint      `gorm:"not null" json:"category_id"`
+	TransactionID *uint     `json:"transaction_id"` // the expense for large transactions; null for spikes
+	Amount        float64   `gorm:"type:decimal(10,2);not null" json:"amount"`
+	Baseline      float64   `gorm:"type:decimal(10,2);not null" json:"baseline"`
+	DetectedAt    time.Time `gorm:"not null;index:idx_anomalies_user_detected,priority:2" json:"detected_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
 // Notification represents a notification in the database
 type Notification struct {
 	ID        uint      `gorm:"primaryKey" json:"id"`
@@ -167,6 +429,209 @@ type Notification struct {
 	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
 }
 
+// NotificationChannel represents a Slack or Discord webhook a user's notifications are posted to
+type NotificationChannel struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	UserID     uint      `gorm:"not null;index" json:"user_id"`
+	Kind       string    `gorm:"not null" json:"kind"`
+	Name       string    `gorm:"not null" json:"name"`
+	WebhookURL string    `gorm:"type:text;not null" json:"webhook_url"`
+	Types      string    `gorm:"type:text" json:"types"` // comma-separated notification types; empty receives all
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+
+	// Relationships
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// Webhook represents a user's endpoint that domain events are delivered to
+type Webhook struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	URL       string    `gorm:"type:text;not null" json:"url"`
+	Secret    string    `gorm:"not null" json:"-"`
+	Events    string    `gorm:"type:text" json:"events"` // comma-separated event names; empty receives all
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relationships
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// QueuedEmail represents an outgoing email in the delivery queue. The bodies are
+// cleared once the email is sent.
+type QueuedEmail struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	Recipient     string     `gorm:"not null" json:"recipient"`
+	Subject       string     `gorm:"not null" json:"subject"`
+	TextBody      string     `gorm:"type:text" json:"-"`
+	HTMLBody      string     `gorm:"type:text" json:"-"`
+	Status        string     `gorm:"not null;size:16;index:idx_email_queue_status_next_attempt,priority:1" json:"status"`
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	LastError     string     `gorm:"type:text" json:"last_error"`
+	NextAttemptAt time.Time  `gorm:"not null;index:idx_email_queue_status_next_attempt,priority:2" json:"next_attempt_at"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// APIVersionUsage represents request counts per API version and endpoint in the database
+type APIVersionUsage struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	Version      string    `gorm:"not null;uniqueIndex:idx_api_version_usage_endpoint" json:"version"`
+	Method       string    `gorm:"not null;uniqueIndex:idx_api_version_usage_endpoint" json:"method"`
+	Route        string    `gorm:"not null;uniqueIndex:idx_api_version_usage_endpoint" json:"route"`
+	RequestCount int64     `gorm:"not null;default:0" json:"request_count"`
+	FirstSeenAt  time.Time `gorm:"not null" json:"first_seen_at"`
+	LastSeenAt   time.Time `gorm:"not null;index" json:"last_seen_at"`
+}
+
+// APIVersionClientUsage represents request counts per API version and user in the database
+type APIVersionClientUsage struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	UserID       uint      `gorm:"not null;uniqueIndex:idx_api_version_client_usage_user" json:"user_id"`
+	Version      string    `gorm:"not null;uniqueIndex:idx_api_version_client_usage_user" json:"version"`
+	RequestCount int64     `gorm:"not null;default:0" json:"request_count"`
+	FirstSeenAt  time.Time `gorm:"not null" json:"first_seen_at"`
+	LastSeenAt   time.Time `gorm:"not null" json:"last_seen_at"`
+}
+
+// APIMonthlyUsage represents request counts per user and calendar month in the database
+type APIMonthlyUsage struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	UserID       uint      `gorm:"not null;uniqueIndex:idx_api_monthly_usage_user" json:"user_id"`
+	Month        string    `gorm:"size:7;not null;uniqueIndex:idx_api_monthly_usage_user" json:"month"`
+	RequestCount int64     `gorm:"not null;default:0" json:"request_count"`
+	LastSeenAt   time.Time `gorm:"not null" json:"last_seen_at"`
+}
+
+// APIDailyUsage represents request counts per user and day in the database
+type APIDailyUsage struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	UserID       uint      `gorm:"not null;uniqueIndex:idx_api_daily_usage_user,priority:1" json:"user_id"`
+	Day          string    `gorm:"size:10;not null;uniqueIndex:idx_api_daily_usage_user,priority:2;index" json:"day"`
+	RequestCount int64     `gorm:"not null;default:0" json:"request_count"`
+	LastSeenAt   time.Time `gorm:"not null" json:"last_seen_at"`
+}
+
+// OutboxEvent represents a published domain event awaiting delivery in the database.
+// NextAttemptAt is cleared once the event is delivered or given up on.
+type OutboxEvent struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	EventName     string     `gorm:"not null;index" json:"event_name"`
+	Payload       string     `gorm:"type:text;not null" json:"payload"`
+	OccurredAt    time.Time  `gorm:"not null" json:"occurred_at"`
+	PublishedAt   *time.Time `gorm:"index" json:"published_at,omitempty"`
+	NextAttemptAt *time.Time `gorm:"index" json:"next_attempt_at,omitempty"`
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	LastError     string     `gorm:"type:text" json:"last_error"`
+	DeliveredTo   []string   `gorm:"type:text;serializer:json" json:"delivered_to"` // subscribers that accepted the event
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// Action represents an undoable update or delete in the audit log. Snapshot
+// holds the changed record's previous state as JSON.
+type Action struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	Kind      string     `gorm:"not null;size:16" json:"kind"`
+	Target    string     `gorm:"not null;size:16;index:idx_actions_target" json:"target"`
+	TargetID  uint       `gorm:"not null;index:idx_actions_target" json:"target_id"`
+	Snapshot  string     `gorm:"type:text;not null" json:"snapshot"`
+	UndoneAt  *time.Time `json:"undone_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// AnalyticsProjection records when a user's analytics read model was last projected
+type AnalyticsProjection struct {
+	UserID      uint      `gorm:"primaryKey;autoIncrement:false" json:"user_id"`
+	ProjectedAt time.Time `gorm:"not null" json:"projected_at"`
+}
+
+// AnalyticsDailyTotal is a user's expenses or incomes in one currency on one day
+type AnalyticsDailyTotal struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	UserID           uint      `gorm:"not null;uniqueIndex:idx_analytics_daily_totals_key,priority:1" json:"user_id"`
+	Date             time.Time `gorm:"type:date;not null;uniqueIndex:idx_analytics_daily_totals_key,priority:2" json:"date"`
+	CurrencyID       uint      `gorm:"not null;uniqueIndex:idx_analytics_daily_totals_key,priority:3" json:"currency_id"`
+	TransactionType  string    `gorm:"size:16;not null;uniqueIndex:idx_analytics_daily_totals_key,priority:4" json:"transaction_type"`
+	Amount           float64   `gorm:"type:decimal(15,2);not null" json:"amount"`
+	TransactionCount int       `gorm:"not null" json:"transaction_count"`
+}
+
+// AnalyticsCategoryTotal is a user's expenses or incomes in one category and
+// currency on one day
+type AnalyticsCategoryTotal struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	UserID           uint      `gorm:"not null;index:idx_analytics_category_totals_user_date,priority:1" json:"user_id"`
+	Date             time.Time `gorm:"type:date;not null;index:idx_analytics_category_totals_user_date,priority:2" json:"date"`
+	CategoryID       uint      `gorm:"not null" json:"category_id"`
+	CurrencyID       uint      `gorm:"not null" json:"currency_id"`
+	TransactionType  string    `gorm:"size:16;not null" json:"transaction_type"`
+	Amount           float64   `gorm:"type:decimal(15,2);not null" json:"amount"`
+	TransactionCount int       `gorm:"not null" json:"transaction_count"`
+}
+
+// AnalyticsBudgetProgress is how much of a budget's allowance has been spent
+type AnalyticsBudgetProgress struct {
+	BudgetID   uint      `gorm:"primaryKey;autoIncrement:false" json:"budget_id"`
+	UserID     uint      `gorm:"not null;index" json:"user_id"`
+	CategoryID uint      `gorm:"not null" json:"category_id"`
+	StartDate  time.Time `gorm:"type:date;not null" json:"start_date"`
+	EndDate    time.Time `gorm:"type:date;not null" json:"end_date"`
+	Allowance  float64   `gorm:"type:decimal(15,2);not null" json:"allowance"`
+	Spent      float64   `gorm:"type:decimal(15,2);not null" json:"spent"`
+}
+
+// Tombstone records a deleted expense, income, budget or category, so clients
+// syncing changes can delete their copy
+type Tombstone struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;index:idx_tombstones_user_deleted,priority:1" json:"user_id"`
+	Kind      string    `gorm:"not null;size:16" json:"kind"`
+	RecordID  uint      `gorm:"not null" json:"record_id"`
+	DeletedAt time.Time `gorm:"not null;index:idx_tombstones_user_deleted,priority:2" json:"deleted_at"`
+}
+
+// ExportSchedule represents a user's scheduled transaction export in the database
+type ExportSchedule struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	UserID      uint      `gorm:"not null;index" json:"user_id"`
+	Format      string    `gorm:"not null;size:16" json:"format"`
+	Provider    string    `gorm:"not null;size:16" json:"provider"`
+	Target      string    `gorm:"not null" json:"target"`
+	AccessToken string    `gorm:"type:text" json:"access_token"`
+	Frequency   string    `gorm:"not null;size:16" json:"frequency"`
+	NextRunAt   time.Time `gorm:"not null;index" json:"next_run_at"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ExportRun represents one scheduled export in the export history
+type ExportRun struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	UserID           uint      `gorm:"not null;index" json:"user_id"`
+	ScheduleID       uint      `gorm:"not null" json:"schedule_id"`
+	Provider         string    `gorm:"not null;size:16" json:"provider"`
+	FileName         string    `gorm:"not null" json:"file_name"`
+	TransactionCount int       `gorm:"not null;default:0" json:"transaction_count"`
+	Status           string    `gorm:"not null;size:16" json:"status"`
+	Error            string    `gorm:"type:text" json:"error"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// FeatureFlag represents a feature switch in the database
+type FeatureFlag struct {
+	ID                uint      `gorm:"primaryKey" json:"id"`
+	Key               string    `gorm:"column:flag_key;uniqueIndex;not null;size:64" json:"key"`
+	Description       string    `gorm:"type:text" json:"description"`
+	Enabled           bool      `gorm:"not null;default:false" json:"enabled"`
+	RolloutPercentage int       `gorm:"not null;default:0" json:"rollout_percentage"`
+	UserIDs           []int     `gorm:"type:text;serializer:json" json:"user_ids"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
 // TableName methods for custom table names (optional)
 func (User) TableName() string {
 	return "users"
@@ -176,6 +641,10 @@ func (Currency) TableName() string {
 	return "currencies"
 }
 
+func (ExchangeRate) TableName() string {
+	return "exchange_rates"
+}
+
 func (Category) TableName() string {
 	return "categories"
 }
@@ -196,6 +665,10 @@ func (RecurringTransaction) TableName() string {
 	return "recurring_transactions"
 }
 
+func (ScheduledTransaction) TableName() string {
+	return "scheduled_transactions"
+}
+
 func (UserPreferences) TableName() string {
 	return "user_preferences"
 }
@@ -203,3 +676,127 @@ func (UserPreferences) TableName() string {
 func (Notification) TableName() string {
 	return "notifications"
 }
+
+func (APIVersionUsage) TableName() string {
+	return "api_version_usages"
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+func (ArchivedExpense) TableName() string {
+	return "archived_expenses"
+}
+
+func (ArchivedIncome) TableName() string {
+	return "archived_incomes"
+}
+
+func (FeatureFlag) TableName() string {
+	return "feature_flags"
+}
+
+func (NotificationChannel) TableName() string {
+	return "notification_channels"
+}
+
+func (Action) TableName() string {
+	return "actions"
+}
+
+func (Tombstone) TableName() string {
+	return "tombstones"
+}
+
+func (AnalyticsProjection) TableName() string {
+	return "analytics_projections"
+}
+
+func (AnalyticsDailyTotal) TableName() string {
+	return "analytics_daily_totals"
+}
+
+func (AnalyticsCategoryTotal) TableName() string {
+	return "analytics_category_totals"
+}
+
+func (AnalyticsBudgetProgress) TableName() string {
+	return "analytics_budget_progress"
+}
+
+func (Account) TableName() string {
+	return "accounts"
+}
+
+func (BalanceAssertion) TableName() string {
+	return "balance_assertions"
+}
+
+func (ReceiptLineItem) TableName() string {
+	return "receipt_line_items"
+}
+
+func (BalanceAdjustment) TableName() string {
+	return "balance_adjustments"
+}
+
+func (Holding) TableName() string {
+	return "holdings"
+}
+
+func (HoldingSnapshot) TableName() string {
+	return "holding_snapshots"
+}
+
+func (ExportSchedule) TableName() string {
+	return "export_schedules"
+}
+
+func (ExportRun) TableName() string {
+	return "export_runs"
+}
+
+func (TaxDeductibleCategory) TableName() string {
+	return "tax_deductible_categories"
+}
+
+func (PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
+
+func (QueuedEmail) TableName() string {
+	return "email_queue"
+}
+
+func (CategoryTranslation) TableName() string {
+	return "category_translations"
+}
+
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+func (Anomaly) TableName() string {
+	return "anomalies"
+}
+
+func (DefaultBudget) TableName() string {
+	return "default_budgets"
+}
+
+func (ClosedMonth) TableName() string {
+	return "closed_months"
+}
+
+func (APIVersionClientUsage) TableName() string {
+	return "api_version_client_usages"
+}
+
+func (APIMonthlyUsage) TableName() string {
+	return "api_monthly_usages"
+}
+
+func (APIDailyUsage) TableName() string {
+	return "api_daily_usages"
+}
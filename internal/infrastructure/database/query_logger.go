@@ -0,0 +1,135 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"panda-pocket/internal/infrastructure/config"
+	"panda-pocket/internal/infrastructure/logging"
+	"panda-pocket/internal/infrastructure/metrics"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/utils"
+)
+
+// QueryLogger writes GORM's logs through the structured logger of the request
+// running the query. Queries slower than the threshold are logged as warnings
+// and counted per statement. Bound values are left out of the logged SQL unless
+// parameter logging is enabled, so logs carry no user data by default.
+type QueryLogger struct {
+	level         logger.LogLevel
+	slowThreshold time.Duration
+	parameters    bool
+	slowQueries   *metrics.SlowQueries
+}
+
+// NewQueryLogger creates a query logger counting slow queries in slowQueries
+func NewQueryLogger(cfg config.QueryLogConfig, slowQueries *metrics.SlowQueries) *QueryLogger {
+	return &QueryLogger{
+		level:         parseLogLevel(cfg.Level),
+		slowThreshold: cfg.SlowThreshold,
+		parameters:    cfg.Parameters,
+		slowQueries:   slowQueries,
+	}
+}
+
+// SlowQueries returns the slow query counts of a database logging through a
+// QueryLogger, or nil when it does not
+func SlowQueries(db *gorm.DB) *metrics.SlowQueries {
+	if queryLogger, ok := db.Logger.(*QueryLogger); ok {
+		return queryLogger.slowQueries
+	}
+	return nil
+}
+
+// LogMode returns a copy of the logger at another level
+func (l *QueryLogger) LogMode(level logger.LogLevel) logger.Interface {
+	copied := *l
+	copied.level = level
+	return &copied
+}
+
+// Info logs a message from GORM at info level
+func (l *QueryLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Info {
+		logging.FromContext(ctx).Info(fmt.Sprintf(msg, args...), "source", utils.FileWithLineNum())
+	}
+}
+
+// Warn logs a message from GORM at warn level
+func (l *QueryLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Warn {
+		logging.FromContext(ctx).Warn(fmt.Sprintf(msg, args...), "source", utils.FileWithLineNum())
+	}
+}
+
+// Error logs a message from GORM at error level
+func (l *QueryLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Error {
+		logging.FromContext(ctx).Error(fmt.Sprintf(msg, args...), "source", utils.FileWithLineNum())
+	}
+}
+
+// Trace logs a finished query: failures at error level, queries slower than the
+// threshold at warn level and every other query at info level
+func (l *QueryLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	elapsed := time.Since(begin)
+	slow := l.slowThreshold > 0 && elapsed > l.slowThreshold
+	failed := err != nil && !errors.Is(err, gorm.ErrRecordNotFound)
+
+	if slow && l.slowQueries != nil {
+		sql, _ := fc()
+		l.slowQueries.Record(sql, elapsed, time.Now())
+	}
+
+	var level slog.Level
+	var msg string
+	switch {
+	case failed && l.level >= logger.Error:
+		level, msg = slog.LevelError, "query failed"
+	case slow && l.level >= logger.Warn:
+		level, msg = slog.LevelWarn, "slow query"
+	case l.level >= logger.Info:
+		level, msg = slog.LevelInfo, "query"
+	default:
+		return
+	}
+
+	sql, rows := fc()
+	attrs := []any{
+		"sql", sql,
+		"rows", rows,
+		"duration_ms", float64(elapsed.Microseconds()) / 1000,
+		"source", utils.FileWithLineNum(),
+	}
+	if failed {
+		attrs = append(attrs, "error", err.Error())
+	}
+	logging.FromContext(ctx).Log(ctx, level, msg, attrs...)
+}
+
+// ParamsFilter leaves the bound values out of the SQL GORM hands to Trace unless
+// parameter logging is enabled
+func (l *QueryLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if l.parameters {
+		return sql, params
+	}
+	return sql, nil
+}
+
+// parseLogLevel maps a configured log level to GORM's, defaulting to warn
+func parseLogLevel(level string) logger.LogLevel {
+	switch level {
+	case "silent":
+		return logger.Silent
+	case "error":
+		return logger.Error
+	case "info":
+		return logger.Info
+	default:
+		return logger.Warn
+	}
+}
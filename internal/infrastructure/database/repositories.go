@@ -0,0 +1,51 @@
+package database
+
+import (
+	"panda-pocket/internal/domain/finance"
+	"panda-pocket/internal/domain/identity"
+	"panda-pocket/internal/domain/notification"
+	"panda-pocket/internal/infrastructure/events"
+	"panda-pocket/internal/infrastructure/featureflags"
+	"panda-pocket/internal/infrastructure/mail"
+	"panda-pocket/internal/infrastructure/metrics"
+)
+
+// Each aggregate has exactly one persistence implementation, the GORM repository.
+// These assertions keep the implementations in step with the domain interfaces.
+var (
+	_ identity.UserRepository                = (*GormUserRepository)(nil)
+	_ identity.PasswordResetRepository       = (*GormPasswordResetRepository)(nil)
+	_ identity.PreferencesRepository         = (*GormPreferencesRepository)(nil)
+	_ identity.GrowthMetricsRepository       = (*GormGrowthMetricsRepository)(nil)
+	_ notification.Repository                = (*GormNotificationRepository)(nil)
+	_ notification.ChannelRepository         = (*GormNotificationChannelRepository)(nil)
+	_ notification.WebhookRepository         = (*GormWebhookRepository)(nil)
+	_ finance.TransactionRepository          = (*GormTransactionRepository)(nil)
+	_ finance.CategoryRepository             = (*GormCategoryRepository)(nil)
+	_ finance.CurrencyRepository             = (*GormCurrencyRepository)(nil)
+	_ finance.BudgetRepository               = (*GormBudgetRepository)(nil)
+	_ finance.DefaultBudgetRepository        = (*GormDefaultBudgetRepository)(nil)
+	_ finance.ActionRepository               = (*GormActionRepository)(nil)
+	_ finance.AccountRepository              = (*GormAccountRepository)(nil)
+	_ finance.BalanceAssertionRepository     = (*GormBalanceAssertionRepository)(nil)
+	_ finance.BalanceAdjustmentRepository    = (*GormBalanceAdjustmentRepository)(nil)
+	_ finance.ReceiptLineItemRepository      = (*GormReceiptLineItemRepository)(nil)
+	_ finance.HoldingRepository              = (*GormHoldingRepository)(nil)
+	_ finance.HoldingSnapshotRepository      = (*GormHoldingSnapshotRepository)(nil)
+	_ finance.ExportScheduleRepository       = (*GormExportScheduleRepository)(nil)
+	_ finance.ExportRunRepository            = (*GormExportRunRepository)(nil)
+	_ finance.TaxCategoryRepository          = (*GormTaxCategoryRepository)(nil)
+	_ finance.RecurringTransactionRepository = (*GormRecurringTransactionRepository)(nil)
+	_ finance.ScheduledTransactionRepository = (*GormScheduledTransactionRepository)(nil)
+	_ finance.AnomalyRepository              = (*GormAnomalyRepository)(nil)
+	_ finance.SpendingBenchmarkRepository    = (*GormSpendingBenchmarkRepository)(nil)
+	_ finance.ClosedMonthRepository          = (*GormClosedMonthRepository)(nil)
+	_ finance.ExchangeRateRepository         = (*GormExchangeRateRepository)(nil)
+	_ finance.TombstoneRepository            = (*GormTombstoneRepository)(nil)
+	_ finance.AnalyticsRepository            = (*GormAnalyticsRepository)(nil)
+	_ finance.UnitOfWork                     = (*GormUnitOfWork)(nil)
+	_ metrics.VersionUsageStore              = (*GormVersionUsageRepository)(nil)
+	_ events.OutboxStore                     = (*GormOutboxRepository)(nil)
+	_ mail.QueueStore                        = (*GormEmailQueueRepository)(nil)
+	_ featureflags.Store                     = (*GormFeatureFlagRepository)(nil)
+)
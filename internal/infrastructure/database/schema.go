@@ -0,0 +1,90 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Models returns every GORM model backed by a migrated table.
+// New models must be added here and get a migration creating their table.
+func Models() []interface{} {
+	return []interface{}{
+		&User{},
+		&PasswordResetToken{},
+		&Currency{},
+		&ExchangeRate{},
+		&Category{},
+		&CategoryTranslation{},
+		&TaxDeductibleCategory{},
+		&ClosedMonth{},
+		&Account{},
+		&BalanceAssertion{},
+		&BalanceAdjustment{},
+		&Holding{},
+		&HoldingSnapshot{},
+		&Expense{},
+		&Income{},
+		&ArchivedExpense{},
+		&ArchivedIncome{},
+		&ReceiptLineItem{},
+		&Budget{},
+		&DefaultBudget{},
+		&RecurringTransaction{},
+		&ScheduledTransaction{},
+		&UserPreferences{},
+		&Anomaly{},
+		&Notification{},
+		&NotificationChannel{},
+		&Webhook{},
+		&APIVersionUsage{},
+		&APIVersionClientUsage{},
+		&APIMonthlyUsage{},
+		&APIDailyUsage{},
+		&OutboxEvent{},
+		&QueuedEmail{},
+		&FeatureFlag{},
+		&Action{},
+		&Tombstone{},
+		&AnalyticsProjection{},
+		&AnalyticsDailyTotal{},
+		&AnalyticsCategoryTotal{},
+		&AnalyticsBudgetProgress{},
+		&ExportSchedule{},
+		&ExportRun{},
+	}
+}
+
+// VerifySchema checks that every model table and column exists in the database,
+// so drift between the migrations and the models fails fast instead of at query time
+func VerifySchema(db *gorm.DB) error {
+	migrator := db.Migrator()
+
+	var problems []string
+	for _, model := range Models() {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+		table := stmt.Schema.Table
+
+		if !migrator.HasTable(model) {
+			problems = append(problems, fmt.Sprintf("table %s is missing", table))
+			continue
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			if !migrator.HasColumn(model, field.DBName) {
+				problems = append(problems, fmt.Sprintf("column %s.%s is missing", table, field.DBName))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("database schema does not match the models (run the migrations): %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// prefix marks encrypted values; values without it were written before encryption
+// was switched on and are returned as they are
+const prefix = "enc:v1:"
+
+// KeySize is the length of an AES-256 key in bytes
+const KeySize = 32
+
+// ErrUnknownKey is returned when a value was encrypted with a key that is not configured
+var ErrUnknownKey = errors.New("value was encrypted with an unknown key")
+
+// FieldCipher encrypts column values with AES-GCM. Each value carries the ID of its key,
+// so retired keys can still decrypt older values while new values use the current key.
+type FieldCipher struct {
+	currentID string
+	keys      map[string]cipher.AEAD
+}
+
+// NewFieldCipher creates a cipher that encrypts with key and also decrypts with previousKeys
+func NewFieldCipher(key []byte, previousKeys ...[]byte) (*FieldCipher, error) {
+	c := &FieldCipher{keys: make(map[string]cipher.AEAD)}
+	for i, k := range append([][]byte{key}, previousKeys...) {
+		if len(k) != KeySize {
+			return nil, fmt.Errorf("encryption key must be %d bytes, got %d", KeySize, len(k))
+		}
+		block, err := aes.NewCipher(k)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+
+		id := keyID(k)
+		if i == 0 {
+			c.currentID = id
+		}
+		c.keys[id] = aead
+	}
+	return c, nil
+}
+
+// keyID identifies a key without revealing it
+func keyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:4])
+}
+
+// Encrypt encrypts a value with the current key. Empty values are left empty.
+func (c *FieldCipher) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	aead := c.keys[c.currentID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(c.currentID))
+	return prefix + c.currentID + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts a value written by Encrypt; values without the encryption prefix are returned unchanged
+func (c *FieldCipher) Decrypt(value string) (string, error) {
+	if !strings.HasPrefix(value, prefix) {
+		return value, nil
+	}
+
+	id, encoded, ok := strings.Cut(strings.TrimPrefix(value, prefix), ":")
+	if !ok {
+		return "", errors.New("malformed encrypted value")
+	}
+	aead, ok := c.keys[id]
+	if !ok {
+		return "", ErrUnknownKey
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("malformed encrypted value: %w", err)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("malformed encrypted value")
+	}
+
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(id))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// DecodeKey decodes a base64-encoded key
+func DecodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("encryption key must be base64: %w", err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes, got %d", KeySize, len(key))
+	}
+	return key, nil
+}
@@ -0,0 +1,36 @@
+package encryption
+
+import (
+	"context"
+
+	"panda-pocket/internal/infrastructure/config"
+)
+
+// NewFromConfig creates the field cipher for the configured key, or returns nil
+// when encryption is not configured
+func NewFromConfig(ctx context.Context, cfg config.EncryptionConfig) (*FieldCipher, error) {
+	var key []byte
+	var err error
+	switch {
+	case cfg.KMSDataKey != "":
+		key, err = DecryptDataKey(ctx, cfg.KMSRegion, cfg.KMSDataKey)
+	case cfg.Key != "":
+		key, err = DecodeKey(cfg.Key)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	previousKeys := make([][]byte, 0, len(cfg.PreviousKeys))
+	for _, encoded := range cfg.PreviousKeys {
+		previous, err := DecodeKey(encoded)
+		if err != nil {
+			return nil, err
+		}
+		previousKeys = append(previousKeys, previous)
+	}
+
+	return NewFieldCipher(key, previousKeys...)
+}
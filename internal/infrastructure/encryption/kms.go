@@ -0,0 +1,84 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+)
+
+// DecryptDataKey asks AWS KMS to decrypt a data key encrypted under a KMS key (envelope
+// encryption), so the plain key is never stored in configuration. Credentials come from
+// the standard AWS environment variables or shared configuration.
+func DecryptDataKey(ctx context.Context, region, encodedCiphertext string) ([]byte, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encodedCiphertext))
+	if err != nil {
+		return nil, fmt.Errorf("KMS data key must be base64: %w", err)
+	}
+
+	var opts []func(*awsconfig.LoadOptions) error
+	if region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if awsCfg.Region == "" {
+		return nil, errors.New("an AWS region is required to reach KMS")
+	}
+	credentials, err := awsCfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(map[string][]byte{"CiphertextBlob": ciphertext})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://kms."+awsCfg.Region+".amazonaws.com/", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService.Decrypt")
+
+	payloadHash := sha256.Sum256(body)
+	if err := v4.NewSigner().SignHTTP(ctx, credentials, req, hex.EncodeToString(payloadHash[:]), "kms", awsCfg.Region, time.Now()); err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("KMS decrypt returned status %d: %s", resp.StatusCode, message)
+	}
+
+	// JSON decodes base64 strings into byte slices
+	var result struct {
+		Plaintext []byte `json:"Plaintext"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if len(result.Plaintext) != KeySize {
+		return nil, fmt.Errorf("KMS data key must be %d bytes, got %d", KeySize, len(result.Plaintext))
+	}
+	return result.Plaintext, nil
+}
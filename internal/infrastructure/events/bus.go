@@ -0,0 +1,212 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"panda-pocket/internal/domain/finance"
+)
+
+// AllEvents subscribes a handler to every event
+const AllEvents = "*"
+
+// Handler reacts to a published domain event
+type Handler func(ctx context.Context, event finance.Event) error
+
+// MaxAttempts is how often delivery of an event is tried before it is given up on
+const MaxAttempts = 10
+
+// Record is a domain event stored in the outbox
+type Record struct {
+	ID            uint
+	Name          string
+	Payload       []byte
+	OccurredAt    time.Time
+	NextAttemptAt time.Time
+	Attempts      int
+	DeliveredTo   []string // subscribers that accepted the event in earlier attempts
+}
+
+// OutboxStore persists published events until they have been delivered
+type OutboxStore interface {
+	// Append stores the records and assigns their IDs
+	Append(ctx context.Context, records []Record) error
+	// Due returns up to limit undelivered records whose next attempt is due, oldest first
+	Due(ctx context.Context, now time.Time, limit int) ([]Record, error)
+	// MarkPublished records that every handler accepted the event
+	MarkPublished(ctx context.Context, id uint) error
+	// MarkFailed records a failed delivery attempt and the subscribers that accepted
+	// the event so far; without retryAt the event is given up on
+	MarkFailed(ctx context.Context, id uint, reason string, retryAt *time.Time, deliveredTo []string) error
+}
+
+// Bus is an asynchronous event bus backed by an outbox table. Publish writes
+// events to the outbox using the caller's context, so inside a unit of work they
+// are stored in the same transaction as the aggregate change, and Run delivers
+// them to subscribers from there. Events are only delivered once committed, and
+// survive a crash or restart until every subscriber accepted them. After a
+// failed attempt only the subscribers that have not accepted the event yet are
+// called again. Delivery is still at least once, as a crash can come between a
+// subscriber accepting an event and the outbox recording it, so subscribers must
+// tolerate seeing an event twice.
+type Bus struct {
+	outbox   OutboxStore
+	mu       sync.RWMutex
+	handlers map[string][]subscription
+	wake     chan struct{}
+}
+
+// subscription is a handler registered under its subscriber's name
+type subscription struct {
+	subscriber string
+	handler    Handler
+}
+
+// NewBus creates a new event bus
+func NewBus(outbox OutboxStore) *Bus {
+	return &Bus{
+		outbox:   outbox,
+		handlers: make(map[string][]subscription),
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Subscribe registers a subscriber's handler for an event name, or AllEvents.
+// The outbox tracks which subscribers accepted an event by name, so the name
+// must be unique and stay the same across restarts.
+func (b *Bus) Subscribe(subscriber, name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], subscription{subscriber: subscriber, handler: handler})
+}
+
+// Publish stores the events in the outbox for delivery
+func (b *Bus) Publish(ctx context.Context, events ...finance.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	records := make([]Record, 0, len(events))
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		records = append(records, Record{
+			Name:          event.EventName(),
+			Payload:       payload,
+			OccurredAt:    event.OccurredAt(),
+			NextAttemptAt: now,
+		})
+	}
+
+	if err := b.outbox.Append(ctx, records); err != nil {
+		return err
+	}
+
+	// Deliver right away rather than at the next tick. Inside a unit of work the
+	// events are not visible until it commits; the next tick picks them up then.
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Run delivers due events every interval, and as soon as new ones are published, until ctx is cancelled
+func (b *Bus) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-b.wake:
+		}
+
+		if err := b.Deliver(ctx); err != nil {
+			slog.Error("failed to deliver outbox events", "error", err.Error())
+		}
+	}
+}
+
+// Deliver attempts every due event once
+func (b *Bus) Deliver(ctx context.Context) error {
+	for {
+		records, err := b.outbox.Due(ctx, time.Now(), 50)
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return nil
+		}
+
+		for _, record := range records {
+			if err := b.deliver(ctx, record); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// deliver calls every subscriber of one event and records the outcome in the outbox
+func (b *Bus) deliver(ctx context.Context, record Record) error {
+	event, err := Decode(record.Name, record.Payload)
+	if err != nil {
+		slog.Error("outbox event cannot be decoded, giving up", "event", record.Name, "outbox_id", record.ID, "error", err.Error())
+		return b.outbox.MarkFailed(ctx, record.ID, err.Error(), nil, record.DeliveredTo)
+	}
+
+	b.mu.RLock()
+	subscriptions := append(append([]subscription(nil), b.handlers[record.Name]...), b.handlers[AllEvents]...)
+	b.mu.RUnlock()
+
+	var failure error
+	delivered := slices.Clone(record.DeliveredTo)
+	for _, subscription := range subscriptions {
+		if slices.Contains(record.DeliveredTo, subscription.subscriber) {
+			continue
+		}
+		if err := subscription.handler(ctx, event); err != nil {
+			slog.Error("event handler failed", "event", record.Name, "subscriber", subscription.subscriber, "outbox_id", record.ID, "error", err.Error())
+			failure = err
+			continue
+		}
+		delivered = append(delivered, subscription.subscriber)
+	}
+	if failure == nil {
+		return b.outbox.MarkPublished(ctx, record.ID)
+	}
+
+	attempts := record.Attempts + 1
+	if attempts >= MaxAttempts {
+		slog.Error("event delivery given up", "event", record.Name, "outbox_id", record.ID, "attempts", attempts)
+		return b.outbox.MarkFailed(ctx, record.ID, failure.Error(), nil, delivered)
+	}
+	retryAt := time.Now().Add(retryDelay(attempts))
+	return b.outbox.MarkFailed(ctx, record.ID, failure.Error(), &retryAt, delivered)
+}
+
+// retryDelay doubles from 30 seconds after each failed attempt, up to an hour
+func retryDelay(attempts int) time.Duration {
+	delay := 30 * time.Second
+	for i := 1; i < attempts && delay < time.Hour; i++ {
+		delay *= 2
+	}
+	return min(delay, time.Hour)
+}
+
+// LogHandler returns a handler that logs every delivered event
+func LogHandler(logger *slog.Logger) Handler {
+	return func(ctx context.Context, event finance.Event) error {
+		logger.Info("domain event", "event", event.EventName(), "occurred_at", event.OccurredAt())
+		return nil
+	}
+}
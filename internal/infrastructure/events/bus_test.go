@@ -0,0 +1,183 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"panda-pocket/internal/domain/finance"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryOutbox keeps outbox records in memory
+type memoryOutbox struct {
+	records   []Record
+	published map[uint]bool
+	given     map[uint]string // records given up on, with the reason
+}
+
+func newMemoryOutbox() *memoryOutbox {
+	return &memoryOutbox{published: map[uint]bool{}, given: map[uint]string{}}
+}
+
+func (o *memoryOutbox) Append(ctx context.Context, records []Record) error {
+	for _, record := range records {
+		record.ID = uint(len(o.records) + 1)
+		o.records = append(o.records, record)
+	}
+	return nil
+}
+
+func (o *memoryOutbox) Due(ctx context.Context, now time.Time, limit int) ([]Record, error) {
+	var due []Record
+	for _, record := range o.records {
+		_, givenUp := o.given[record.ID]
+		if !o.published[record.ID] && !givenUp && !record.NextAttemptAt.After(now) && len(due) < limit {
+			due = append(due, record)
+		}
+	}
+	return due, nil
+}
+
+func (o *memoryOutbox) MarkPublished(ctx context.Context, id uint) error {
+	o.published[id] = true
+	return nil
+}
+
+func (o *memoryOutbox) MarkFailed(ctx context.Context, id uint, reason string, retryAt *time.Time, deliveredTo []string) error {
+	record := &o.records[id-1]
+	record.Attempts++
+	record.DeliveredTo = deliveredTo
+	if retryAt == nil {
+		o.given[id] = reason
+		return nil
+	}
+	record.NextAttemptAt = *retryAt
+	return nil
+}
+
+// retryNow makes every record due again
+func (o *memoryOutbox) retryNow() {
+	for i := range o.records {
+		o.records[i].NextAttemptAt = time.Time{}
+	}
+}
+
+// currencyDeleted returns an event for a test
+func currencyDeleted() finance.CurrencyDeleted {
+	return finance.CurrencyDeleted{CurrencyID: 7, UserID: 1, Code: "IDR", At: time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)}
+}
+
+func TestBusDeliver(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("events go to their subscribers and to those of every event", func(t *testing.T) {
+		outbox := newMemoryOutbox()
+		bus := NewBus(outbox)
+		var got []string
+		bus.Subscribe("currencies", finance.EventCurrencyDeleted, func(ctx context.Context, event finance.Event) error {
+			got = append(got, "currencies:"+event.(finance.CurrencyDeleted).Code)
+			return nil
+		})
+		bus.Subscribe("audit", AllEvents, func(ctx context.Context, event finance.Event) error {
+			got = append(got, "audit:"+event.EventName())
+			return nil
+		})
+		bus.Subscribe("budgets", finance.EventBudgetExceeded, func(ctx context.Context, event finance.Event) error {
+			got = append(got, "budgets")
+			return nil
+		})
+
+		require.NoError(t, bus.Publish(ctx, currencyDeleted()))
+		assert.Empty(t, got, "events are delivered from the outbox, not on publish")
+
+		require.NoError(t, bus.Deliver(ctx))
+		assert.Equal(t, []string{"currencies:IDR", "audit:currency.deleted"}, got)
+		assert.True(t, outbox.published[1])
+
+		require.NoError(t, bus.Deliver(ctx))
+		assert.Len(t, got, 2)
+	})
+
+	t.Run("only the subscribers that failed are called again", func(t *testing.T) {
+		outbox := newMemoryOutbox()
+		bus := NewBus(outbox)
+		calls := map[string]int{}
+		failing := true
+		bus.Subscribe("email", AllEvents, func(ctx context.Context, event finance.Event) error {
+			calls["email"]++
+			return nil
+		})
+		bus.Subscribe("webhook", AllEvents, func(ctx context.Context, event finance.Event) error {
+			calls["webhook"]++
+			if failing {
+				return errors.New("webhook is down")
+			}
+			return nil
+		})
+
+		require.NoError(t, bus.Publish(ctx, currencyDeleted()))
+		require.NoError(t, bus.Deliver(ctx))
+		record := outbox.records[0]
+		assert.False(t, outbox.published[1])
+		assert.Equal(t, []string{"email"}, record.DeliveredTo)
+		assert.True(t, record.NextAttemptAt.After(time.Now()))
+
+		// Not due again until the retry delay has passed
+		require.NoError(t, bus.Deliver(ctx))
+		assert.Equal(t, 1, calls["webhook"])
+
+		failing = false
+		outbox.retryNow()
+		require.NoError(t, bus.Deliver(ctx))
+		assert.Equal(t, map[string]int{"email": 1, "webhook": 2}, calls)
+		assert.True(t, outbox.published[1])
+	})
+
+	t.Run("delivery is given up after MaxAttempts", func(t *testing.T) {
+		outbox := newMemoryOutbox()
+		bus := NewBus(outbox)
+		bus.Subscribe("webhook", AllEvents, func(ctx context.Context, event finance.Event) error {
+			return errors.New("webhook is down")
+		})
+
+		require.NoError(t, bus.Publish(ctx, currencyDeleted()))
+		for i := 0; i < MaxAttempts; i++ {
+			outbox.retryNow()
+			require.NoError(t, bus.Deliver(ctx))
+		}
+		assert.Equal(t, MaxAttempts, outbox.records[0].Attempts)
+		assert.Equal(t, "webhook is down", outbox.given[1])
+	})
+
+	t.Run("events that cannot be decoded are given up at once", func(t *testing.T) {
+		outbox := newMemoryOutbox()
+		bus := NewBus(outbox)
+		require.NoError(t, outbox.Append(ctx, []Record{{Name: "salary.paid", Payload: []byte(`{}`)}}))
+
+		require.NoError(t, bus.Deliver(ctx))
+		assert.Contains(t, outbox.given[1], "unknown event")
+	})
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 30*time.Second, retryDelay(1))
+	assert.Equal(t, time.Minute, retryDelay(2))
+	assert.Equal(t, 4*time.Minute, retryDelay(4))
+	assert.Equal(t, time.Hour, retryDelay(MaxAttempts))
+}
+
+func TestDecode(t *testing.T) {
+	for _, sample := range Samples() {
+		payload, err := json.Marshal(sample)
+		require.NoError(t, err)
+
+		decoded, err := Decode(sample.EventName(), payload)
+		require.NoError(t, err, sample.EventName())
+		assert.Equal(t, sample, decoded)
+	}
+}
@@ -0,0 +1,128 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"panda-pocket/internal/domain/finance"
+)
+
+// EventType describes an event that integrations can subscribe to
+type EventType struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Sample      finance.Event `json:"sample_payload"`
+}
+
+// Names returns the name of every published event
+func Names() []string {
+	catalog := Catalog()
+	names := make([]string, len(catalog))
+	for i, eventType := range catalog {
+		names[i] = eventType.Name
+	}
+	return names
+}
+
+// Samples returns the sample payload of every published event
+func Samples() []finance.Event {
+	catalog := Catalog()
+	samples := make([]finance.Event, len(catalog))
+	for i, eventType := range catalog {
+		samples[i] = eventType.Sample
+	}
+	return samples
+}
+
+// Decode rebuilds an event stored in the outbox from its name and payload
+func Decode(name string, payload []byte) (finance.Event, error) {
+	for _, eventType := range Catalog() {
+		if eventType.Name != name {
+			continue
+		}
+		event := reflect.New(reflect.TypeOf(eventType.Sample))
+		if err := json.Unmarshal(payload, event.Interface()); err != nil {
+			return nil, err
+		}
+		return event.Elem().Interface().(finance.Event), nil
+	}
+	return nil, fmt.Errorf("unknown event %q", name)
+}
+
+// Catalog lists every published event with an example payload. Payloads are the
+// JSON stored in the outbox, so a new event only needs adding here to be listed.
+func Catalog() []EventType {
+	at := time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	return []EventType{
+		{
+			Name:        finance.EventTransactionCreated,
+			Description: "An expense or income was recorded",
+			Sample: finance.TransactionCreated{
+				TransactionID: 42,
+				UserID:        7,
+				CategoryID:    3,
+				CurrencyID:    1,
+				Amount:        12.5,
+				Type:          "expense",
+				Date:          date,
+				At:            at,
+			},
+		},
+		{
+			Name:        finance.EventTransactionUpdated,
+			Description: "An expense or income was edited",
+			Sample: finance.TransactionUpdated{
+				TransactionID: 42,
+				UserID:        7,
+				CategoryID:    3,
+				CurrencyID:    1,
+				Amount:        15,
+				Type:          "expense",
+				Date:          date,
+				At:            at,
+			},
+		},
+		{
+			Name:        finance.EventTransactionDeleted,
+			Description: "An expense or income was deleted",
+			Sample: finance.TransactionDeleted{
+				TransactionID: 42,
+				UserID:        7,
+				Type:          "expense",
+				At:            at,
+			},
+		},
+		{
+			Name:        finance.EventBudgetExceeded,
+			Description: "A transaction pushed spending in a category over its budget",
+			Sample: finance.BudgetExceeded{
+				BudgetID:          5,
+				UserID:            7,
+				CategoryID:        3,
+				TransactionID:     42,
+				TransactionAmount: 12.5,
+				TransactionDate:   date,
+				Budgeted:          300,
+				Spent:             312.5,
+				Overspend:         12.5,
+				PeriodStart:       time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+				PeriodEnd:         time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+				At:                at,
+			},
+		},
+		{
+			Name:        finance.EventCurrencyDeleted,
+			Description: "A user deleted one of their currencies",
+			Sample: finance.CurrencyDeleted{
+				CurrencyID: 9,
+				UserID:     7,
+				Code:       "JPY",
+				At:         at,
+			},
+		},
+	}
+}
@@ -0,0 +1,57 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+
+	"panda-pocket/internal/domain/finance"
+)
+
+// dropboxUploadURL is the Dropbox content upload endpoint
+const dropboxUploadURL = "https://content.dropboxapi.com/2/files/upload"
+
+// DropboxProvider uploads exports to a folder in the user's Dropbox
+type DropboxProvider struct {
+	client    *http.Client
+	uploadURL string
+}
+
+// NewDropboxProvider creates a Dropbox provider
+func NewDropboxProvider(client *http.Client) *DropboxProvider {
+	return &DropboxProvider{client: client, uploadURL: dropboxUploadURL}
+}
+
+// Upload writes the file to the folder path given as the schedule target, such as
+// "/PandaPocket", authorized with the schedule's access token
+func (p *DropboxProvider) Upload(ctx context.Context, schedule *finance.ExportSchedule, name, contentType string, data []byte) error {
+	arg, err := json.Marshal(map[string]interface{}{
+		"path": path.Join("/", schedule.Target(), name),
+		"mode": "overwrite",
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.uploadURL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+schedule.AccessToken())
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Dropbox-API-Arg", string(arg))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("dropbox upload returned status %d", resp.StatusCode)
+	}
+	return nil
+}
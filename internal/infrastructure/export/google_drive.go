@@ -0,0 +1,79 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+
+	"panda-pocket/internal/domain/finance"
+)
+
+// googleDriveUploadURL is the Google Drive multipart upload endpoint
+const googleDriveUploadURL = "https://www.googleapis.com/upload/drive/v3/files?uploadType=multipart"
+
+// GoogleDriveProvider uploads exports to a folder in the user's Google Drive
+type GoogleDriveProvider struct {
+	client    *http.Client
+	uploadURL string
+}
+
+// NewGoogleDriveProvider creates a Google Drive provider
+func NewGoogleDriveProvider(client *http.Client) *GoogleDriveProvider {
+	return &GoogleDriveProvider{client: client, uploadURL: googleDriveUploadURL}
+}
+
+// Upload creates the file in the folder whose ID is the schedule target,
+// authorized with the schedule's access token
+func (p *GoogleDriveProvider) Upload(ctx context.Context, schedule *finance.ExportSchedule, name, contentType string, data []byte) error {
+	metadata, err := json.Marshal(map[string]interface{}{
+		"name":    name,
+		"parents": []string{schedule.Target()},
+	})
+	if err != nil {
+		return err
+	}
+
+	// A multipart/related body carries the file metadata and content in one request
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType string
+		content     []byte
+	}{
+		{"application/json; charset=UTF-8", metadata},
+		{contentType, data},
+	} {
+		w, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType}})
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(part.content); err != nil {
+			return err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.uploadURL, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+schedule.AccessToken())
+	req.Header.Set("Content-Type", "multipart/related; boundary="+writer.Boundary())
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("google drive upload returned status %d", resp.StatusCode)
+	}
+	return nil
+}
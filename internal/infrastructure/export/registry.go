@@ -0,0 +1,45 @@
+// Package export uploads transaction exports to cloud storage providers.
+package export
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"panda-pocket/internal/domain/finance"
+)
+
+// Provider uploads an export file to one storage service
+type Provider interface {
+	Upload(ctx context.Context, schedule *finance.ExportSchedule, name, contentType string, data []byte) error
+}
+
+// Registry routes uploads to the provider a schedule names. Providers can be
+// replaced or added with Register, for example to use a fake in tests.
+type Registry struct {
+	providers map[finance.StorageProvider]Provider
+}
+
+// NewRegistry creates a registry with the S3, Google Drive and Dropbox providers
+func NewRegistry() *Registry {
+	client := &http.Client{Timeout: time.Minute}
+	return &Registry{providers: map[finance.StorageProvider]Provider{
+		finance.StorageProviderS3:          NewS3Provider(),
+		finance.StorageProviderGoogleDrive: NewGoogleDriveProvider(client),
+		finance.StorageProviderDropbox:     NewDropboxProvider(client),
+	}}
+}
+
+// Register sets the provider used for a storage service
+func (r *Registry) Register(kind finance.StorageProvider, provider Provider) {
+	r.providers[kind] = provider
+}
+
+// Upload sends the file to the schedule's storage provider
+func (r *Registry) Upload(ctx context.Context, schedule *finance.ExportSchedule, name, contentType string, data []byte) error {
+	provider, ok := r.providers[schedule.Provider()]
+	if !ok {
+		return finance.ErrInvalidStorageProvider
+	}
+	return provider.Upload(ctx, schedule, name, contentType, data)
+}
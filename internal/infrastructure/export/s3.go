@@ -0,0 +1,42 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"strings"
+
+	"panda-pocket/internal/domain/finance"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3Provider uploads exports to an S3 bucket with the server's AWS credentials,
+// taken from the standard AWS environment variables or shared configuration
+type S3Provider struct{}
+
+// NewS3Provider creates an S3 provider
+func NewS3Provider() *S3Provider {
+	return &S3Provider{}
+}
+
+// Upload writes the file to the bucket and key prefix given as the schedule target,
+// such as "my-bucket/pandapocket/exports"
+func (p *S3Provider) Upload(ctx context.Context, schedule *finance.ExportSchedule, name, contentType string, data []byte) error {
+	bucket, prefix, _ := strings.Cut(strings.Trim(schedule.Target(), "/"), "/")
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = s3.NewFromConfig(awsCfg).PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(path.Join(prefix, name)),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+	})
+	return err
+}
@@ -0,0 +1,197 @@
+// Package featureflags switches features on for everyone, for listed users or
+// for a percentage of users, without a redeploy.
+package featureflags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Feature flag errors
+var (
+	ErrFlagNotFound   = errors.New("feature flag not found")
+	ErrInvalidFlagKey = errors.New("feature flag key must be 1-64 lowercase letters, digits, '_', '-' or '.'")
+	ErrInvalidRollout = errors.New("rollout percentage must be between 0 and 100")
+)
+
+// cacheTTL is how long flags are served from memory. Changes made through the
+// admin API apply at once on the instance that handled them and within cacheTTL
+// on every other instance.
+const cacheTTL = 30 * time.Second
+
+var keyPattern = regexp.MustCompile(`^[a-z0-9_.-]{1,64}$`)
+
+// Flag is a feature switch. A flag is on for a user when it is enabled, when the
+// user is listed, or when the user falls inside the rollout percentage.
+type Flag struct {
+	Key               string    `json:"key"`
+	Description       string    `json:"description"`
+	Enabled           bool      `json:"enabled"`            // on for every user
+	RolloutPercentage int       `json:"rollout_percentage"` // share of users the flag is on for
+	UserIDs           []int     `json:"user_ids"`           // users the flag is always on for
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// IsOnFor reports whether the flag is on for a user
+func (f Flag) IsOnFor(userID int) bool {
+	if f.Enabled {
+		return true
+	}
+	for _, id := range f.UserIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return f.RolloutPercentage > 0 && bucket(f.Key, userID) < f.RolloutPercentage
+}
+
+// bucket places a user in one of 100 buckets per flag, so raising a flag's
+// percentage only ever adds users and different flags reach different users
+func bucket(key string, userID int) int {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%s:%d", key, userID)
+	return int(h.Sum32() % 100)
+}
+
+// Store persists feature flags
+type Store interface {
+	// List returns every flag
+	List(ctx context.Context) ([]Flag, error)
+	// Save creates or replaces the flag with the same key
+	Save(ctx context.Context, flag Flag) error
+	// Delete removes a flag, returning ErrFlagNotFound if it does not exist
+	Delete(ctx context.Context, key string) error
+}
+
+// FlagService answers flag checks from an in-memory copy of the stored flags
+type FlagService struct {
+	store Store
+
+	mu       sync.RWMutex
+	flags    map[string]Flag
+	loadedAt time.Time
+	failedAt time.Time // when reloading last failed; reloads wait cacheTTL after it
+	loadErr  error
+}
+
+// NewFlagService creates a new flag service
+func NewFlagService(store Store) *FlagService {
+	return &FlagService{store: store}
+}
+
+// IsEnabled reports whether a flag is on for a user. Unknown flags are off, and
+// so are all flags if they have never been loaded.
+func (s *FlagService) IsEnabled(ctx context.Context, key string, userID int) bool {
+	flags, _ := s.load(ctx)
+
+	flag, ok := flags[key]
+	return ok && flag.IsOnFor(userID)
+}
+
+// EnabledFor returns the keys of the flags that are on for a user, sorted
+func (s *FlagService) EnabledFor(ctx context.Context, userID int) ([]string, error) {
+	flags, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	for key, flag := range flags {
+		if flag.IsOnFor(userID) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// List returns every flag, sorted by key
+func (s *FlagService) List(ctx context.Context) ([]Flag, error) {
+	flags, err := s.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Key < flags[j].Key })
+	return flags, nil
+}
+
+// Set creates or replaces a flag
+func (s *FlagService) Set(ctx context.Context, flag Flag) (Flag, error) {
+	if !keyPattern.MatchString(flag.Key) {
+		return Flag{}, ErrInvalidFlagKey
+	}
+	if flag.RolloutPercentage < 0 || flag.RolloutPercentage > 100 {
+		return Flag{}, ErrInvalidRollout
+	}
+	if flag.UserIDs == nil {
+		flag.UserIDs = []int{}
+	}
+	flag.UpdatedAt = time.Now()
+
+	if err := s.store.Save(ctx, flag); err != nil {
+		return Flag{}, err
+	}
+	s.invalidate()
+	return flag, nil
+}
+
+// Delete removes a flag, which turns it off for everyone
+func (s *FlagService) Delete(ctx context.Context, key string) error {
+	if err := s.store.Delete(ctx, key); err != nil {
+		return err
+	}
+	s.invalidate()
+	return nil
+}
+
+// load returns the cached flags, reloading them once they are older than cacheTTL.
+// When reloading fails the previous copy is returned with the error, and the
+// store is left alone for cacheTTL, so flag checks on every request do not
+// query a database that is down.
+func (s *FlagService) load(ctx context.Context) (map[string]Flag, error) {
+	s.mu.RLock()
+	flags, fresh := s.flags, time.Since(s.loadedAt) < cacheTTL
+	failing, loadErr := time.Since(s.failedAt) < cacheTTL, s.loadErr
+	s.mu.RUnlock()
+	if flags != nil && fresh {
+		return flags, nil
+	}
+	if failing {
+		return flags, loadErr
+	}
+
+	list, err := s.store.List(ctx)
+	if err != nil {
+		slog.Error("failed to load feature flags", "error", err.Error(), "retry_in", cacheTTL.String())
+		s.mu.Lock()
+		s.failedAt, s.loadErr = time.Now(), err
+		s.mu.Unlock()
+		return flags, err
+	}
+
+	flags = make(map[string]Flag, len(list))
+	for _, flag := range list {
+		flags[flag.Key] = flag
+	}
+
+	s.mu.Lock()
+	s.flags, s.loadedAt = flags, time.Now()
+	s.failedAt, s.loadErr = time.Time{}, nil
+	s.mu.Unlock()
+	return flags, nil
+}
+
+// invalidate makes the next check reload the flags
+func (s *FlagService) invalidate() {
+	s.mu.Lock()
+	s.flags = nil
+	s.failedAt, s.loadErr = time.Time{}, nil
+	s.mu.Unlock()
+}
@@ -0,0 +1,100 @@
+package featureflags
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubStore serves a fixed list of flags, or fails, and counts the loads
+type stubStore struct {
+	flags []Flag
+	err   error
+	lists int
+}
+
+func (s *stubStore) List(ctx context.Context) ([]Flag, error) {
+	s.lists++
+	return s.flags, s.err
+}
+
+func (s *stubStore) Save(ctx context.Context, flag Flag) error {
+	s.flags = append(s.flags, flag)
+	return nil
+}
+
+func (s *stubStore) Delete(ctx context.Context, key string) error {
+	return ErrFlagNotFound
+}
+
+func TestFlagServiceLoad(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("flags are served from memory", func(t *testing.T) {
+		store := &stubStore{flags: []Flag{{Key: "maintenance", Enabled: true}}}
+		service := NewFlagService(store)
+
+		for i := 0; i < 10; i++ {
+			assert.True(t, service.IsEnabled(ctx, "maintenance", 1))
+		}
+		assert.Equal(t, 1, store.lists)
+	})
+
+	t.Run("failed loads are not retried on every check", func(t *testing.T) {
+		store := &stubStore{err: errors.New("database is down")}
+		service := NewFlagService(store)
+
+		for i := 0; i < 10; i++ {
+			assert.False(t, service.IsEnabled(ctx, "maintenance", 1))
+		}
+		assert.Equal(t, 1, store.lists)
+
+		_, err := service.EnabledFor(ctx, 1)
+		assert.EqualError(t, err, "database is down")
+		assert.Equal(t, 1, store.lists)
+	})
+
+	t.Run("the last loaded flags are kept while loads fail", func(t *testing.T) {
+		store := &stubStore{flags: []Flag{{Key: "maintenance", Enabled: true}}}
+		service := NewFlagService(store)
+		require.True(t, service.IsEnabled(ctx, "maintenance", 1))
+
+		// Expire the cache as if cacheTTL had passed
+		service.loadedAt = service.loadedAt.Add(-cacheTTL)
+		store.err = errors.New("database is down")
+
+		for i := 0; i < 10; i++ {
+			assert.True(t, service.IsEnabled(ctx, "maintenance", 1))
+		}
+		assert.Equal(t, 2, store.lists)
+	})
+
+	t.Run("changing a flag reloads at once", func(t *testing.T) {
+		store := &stubStore{err: errors.New("database is down")}
+		service := NewFlagService(store)
+		require.False(t, service.IsEnabled(ctx, "maintenance", 1))
+
+		store.err = nil
+		_, err := service.Set(ctx, Flag{Key: "maintenance", Enabled: true})
+		require.NoError(t, err)
+		assert.True(t, service.IsEnabled(ctx, "maintenance", 1))
+	})
+}
+
+func TestFlagIsOnFor(t *testing.T) {
+	assert.True(t, Flag{Key: "a", Enabled: true}.IsOnFor(1))
+	assert.True(t, Flag{Key: "a", UserIDs: []int{7}}.IsOnFor(7))
+	assert.False(t, Flag{Key: "a", UserIDs: []int{7}}.IsOnFor(8))
+	assert.False(t, Flag{Key: "a"}.IsOnFor(1))
+	assert.True(t, Flag{Key: "a", RolloutPercentage: 100}.IsOnFor(1))
+
+	// Raising the percentage only ever adds users
+	for userID := 1; userID <= 200; userID++ {
+		if (Flag{Key: "a", RolloutPercentage: 20}).IsOnFor(userID) {
+			assert.True(t, Flag{Key: "a", RolloutPercentage: 50}.IsOnFor(userID))
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Package i18n carries the client's preferred languages through a request.
+package i18n
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// contextKey is the type used for values stored in a context by this package
+type contextKey string
+
+const localesKey contextKey = "locales"
+
+// maxLocales caps how many languages of an Accept-Language header are kept
+const maxLocales = 10
+
+// WithLocales returns a copy of ctx carrying the preferred locales, most preferred first
+func WithLocales(ctx context.Context, locales []string) context.Context {
+	return context.WithValue(ctx, localesKey, locales)
+}
+
+// LocalesFromContext returns the preferred locales stored in ctx, or nil
+func LocalesFromContext(ctx context.Context) []string {
+	if locales, ok := ctx.Value(localesKey).([]string); ok {
+		return locales
+	}
+	return nil
+}
+
+// ParseAcceptLanguage returns the languages of an Accept-Language header ordered by
+// quality, lowercased. Regional tags are followed by their base language, so
+// "id-ID" also matches translations stored for "id".
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag     string
+		quality float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+		tags = append(tags, weighted{tag: tag, quality: quality})
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].quality > tags[j].quality })
+
+	seen := make(map[string]bool)
+	var locales []string
+	add := func(locale string) {
+		if !seen[locale] && len(locales) < maxLocales {
+			seen[locale] = true
+			locales = append(locales, locale)
+		}
+	}
+	for _, t := range tags {
+		add(t.tag)
+		if base, _, regional := strings.Cut(t.tag, "-"); regional {
+			add(base)
+		}
+	}
+	return locales
+}
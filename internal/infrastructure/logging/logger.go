@@ -0,0 +1,44 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"os"
+)
+
+// contextKey is the type used for values stored in a context by this package
+type contextKey string
+
+const (
+	requestIDKey contextKey = "request_id"
+	loggerKey    contextKey = "logger"
+)
+
+// NewLogger creates a JSON structured logger writing to stdout
+func NewLogger() *slog.Logger {
+	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+	}))
+}
+
+// WithRequestID returns a copy of ctx carrying the request ID and a logger annotated with it
+func WithRequestID(ctx context.Context, requestID string, logger *slog.Logger) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey, requestID)
+	return context.WithValue(ctx, loggerKey, logger.With("request_id", requestID))
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, or an empty string
+func RequestIDFromContext(ctx context.Context) string {
+	if requestID, ok := ctx.Value(requestIDKey).(string); ok {
+		return requestID
+	}
+	return ""
+}
+
+// FromContext returns the request-scoped logger stored in ctx, falling back to the default logger
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
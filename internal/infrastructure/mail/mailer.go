@@ -0,0 +1,129 @@
+// Package mail sends email through SMTP and renders email templates.
+package mail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	netmail "net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"time"
+
+	"panda-pocket/internal/infrastructure/config"
+)
+
+// Mailer sends plain-text email, or email with both a plain-text and an HTML body
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+	SendHTML(ctx context.Context, to, subject, text, html string) error
+}
+
+// SMTPMailer sends email through an SMTP server, upgrading to TLS when the server supports it
+type SMTPMailer struct {
+	addr string
+	auth smtp.Auth
+	from *netmail.Address
+}
+
+// NewSMTPMailer creates a mailer for the configured server
+func NewSMTPMailer(cfg config.MailConfig) (*SMTPMailer, error) {
+	from, err := netmail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAIL_FROM: %w", err)
+	}
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	return &SMTPMailer{
+		addr: cfg.Host + ":" + strconv.Itoa(cfg.Port),
+		auth: auth,
+		from: from,
+	}, nil
+}
+
+// Send sends a plain-text email to one recipient
+func (m *SMTPMailer) Send(ctx context.Context, to, subject, body string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	msg := m.header(to, subject)
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(body)
+
+	return smtp.SendMail(m.addr, m.auth, m.from.Address, []string{to}, msg.Bytes())
+}
+
+// SendHTML sends an email with plain-text and HTML alternatives to one recipient
+func (m *SMTPMailer) SendHTML(ctx context.Context, to, subject, text, html string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if html == "" {
+		return m.Send(ctx, to, subject, text)
+	}
+
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	for _, alternative := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", text},
+		{"text/html; charset=utf-8", html},
+	} {
+		part, err := parts.CreatePart(textproto.MIMEHeader{"Content-Type": {alternative.contentType}})
+		if err != nil {
+			return err
+		}
+		if _, err := part.Write([]byte(alternative.content)); err != nil {
+			return err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return err
+	}
+
+	msg := m.header(to, subject)
+	fmt.Fprintf(msg, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", parts.Boundary())
+	msg.Write(body.Bytes())
+
+	return smtp.SendMail(m.addr, m.auth, m.from.Address, []string{to}, msg.Bytes())
+}
+
+// header writes the headers shared by every message, up to the content type
+func (m *SMTPMailer) header(to, subject string) *bytes.Buffer {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", m.from.String())
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	return &msg
+}
+
+// LogMailer writes emails to the log instead of sending them, for development
+// and for deployments without an SMTP server
+type LogMailer struct {
+	logger *slog.Logger
+}
+
+// NewLogMailer creates a mailer that logs emails
+func NewLogMailer(logger *slog.Logger) *LogMailer {
+	return &LogMailer{logger: logger}
+}
+
+// Send logs the email
+func (m *LogMailer) Send(ctx context.Context, to, subject, body string) error {
+	m.logger.InfoContext(ctx, "email not sent, no SMTP server configured", "to", to, "subject", subject)
+	return nil
+}
+
+// SendHTML logs the email
+func (m *LogMailer) SendHTML(ctx context.Context, to, subject, text, html string) error {
+	return m.Send(ctx, to, subject, text)
+}
@@ -0,0 +1,171 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// ErrEmailNotFound is returned when a dead-lettered email does not exist
+var ErrEmailNotFound = errors.New("email not found")
+
+// QueuedEmail is an email waiting in the delivery queue, or given up on after too many failed attempts
+type QueuedEmail struct {
+	ID            uint      `json:"id"`
+	To            string    `json:"to"`
+	Subject       string    `json:"subject"`
+	Text          string    `json:"-"`
+	HTML          string    `json:"-"`
+	Attempts      int       `json:"attempts"`
+	LastError     string    `json:"last_error,omitempty"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// QueueStore persists queued emails until they are delivered
+type QueueStore interface {
+	// Enqueue stores a pending email and assigns its ID
+	Enqueue(ctx context.Context, email *QueuedEmail) error
+	// Due returns up to limit pending emails whose next attempt is due, oldest first
+	Due(ctx context.Context, now time.Time, limit int) ([]QueuedEmail, error)
+	// MarkSent records a delivery and drops the bodies, which may hold secrets such as reset links
+	MarkSent(ctx context.Context, id uint, at time.Time) error
+	// MarkFailed records a failed attempt; without retryAt the email is dead-lettered
+	MarkFailed(ctx context.Context, id uint, reason string, retryAt *time.Time) error
+	// DeadLetters returns one page of dead-lettered emails, newest first, and their total number
+	DeadLetters(ctx context.Context, limit, offset int) ([]QueuedEmail, int64, error)
+	// Requeue returns a dead-lettered email to the queue for another round of attempts
+	Requeue(ctx context.Context, id uint, at time.Time) error
+}
+
+// Queue sends email in the background. Emails are stored before Send returns, so
+// slow or failing mail servers neither hold up requests nor lose mail; delivery
+// is retried with growing delays before the email is dead-lettered for admins.
+type Queue struct {
+	store       QueueStore
+	mailer      Mailer
+	maxAttempts int
+	wake        chan struct{}
+}
+
+// NewQueue creates a queue delivering through mailer, giving up on an email after maxAttempts
+func NewQueue(store QueueStore, mailer Mailer, maxAttempts int) *Queue {
+	return &Queue{
+		store:       store,
+		mailer:      mailer,
+		maxAttempts: maxAttempts,
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// Send queues a plain-text email
+func (q *Queue) Send(ctx context.Context, to, subject, body string) error {
+	return q.SendHTML(ctx, to, subject, body, "")
+}
+
+// SendHTML queues an email with plain-text and HTML bodies
+func (q *Queue) SendHTML(ctx context.Context, to, subject, text, html string) error {
+	now := time.Now()
+	err := q.store.Enqueue(ctx, &QueuedEmail{
+		To:            to,
+		Subject:       subject,
+		Text:          text,
+		HTML:          html,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Deliver right away rather than at the next tick
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// DeadLetters returns one page of emails that could not be delivered, newest first
+func (q *Queue) DeadLetters(ctx context.Context, limit, offset int) ([]QueuedEmail, int64, error) {
+	return q.store.DeadLetters(ctx, limit, offset)
+}
+
+// Retry returns a dead-lettered email to the queue
+func (q *Queue) Retry(ctx context.Context, id uint) error {
+	if err := q.store.Requeue(ctx, id, time.Now()); err != nil {
+		return err
+	}
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Run delivers due emails every interval, and as soon as new ones are queued, until ctx is cancelled
+func (q *Queue) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-q.wake:
+		}
+
+		if err := q.Deliver(ctx); err != nil {
+			slog.Error("failed to deliver queued emails", "error", err.Error())
+		}
+	}
+}
+
+// Deliver attempts every due email once
+func (q *Queue) Deliver(ctx context.Context) error {
+	for {
+		emails, err := q.store.Due(ctx, time.Now(), 50)
+		if err != nil {
+			return err
+		}
+		if len(emails) == 0 {
+			return nil
+		}
+
+		for _, email := range emails {
+			if err := q.deliver(ctx, email); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// deliver sends one email and records the outcome
+func (q *Queue) deliver(ctx context.Context, email QueuedEmail) error {
+	sendErr := q.mailer.SendHTML(ctx, email.To, email.Subject, email.Text, email.HTML)
+	if sendErr == nil {
+		return q.store.MarkSent(ctx, email.ID, time.Now())
+	}
+
+	attempts := email.Attempts + 1
+	if attempts >= q.maxAttempts {
+		slog.Error("email dead-lettered", "email_id", email.ID, "attempts", attempts, "error", sendErr.Error())
+		return q.store.MarkFailed(ctx, email.ID, sendErr.Error(), nil)
+	}
+
+	retryAt := time.Now().Add(retryDelay(attempts))
+	slog.Warn("email delivery failed, will retry", "email_id", email.ID, "attempts", attempts, "retry_at", retryAt, "error", sendErr.Error())
+	return q.store.MarkFailed(ctx, email.ID, sendErr.Error(), &retryAt)
+}
+
+// retryDelay doubles from 30 seconds after each failed attempt, up to an hour
+func retryDelay(attempts int) time.Duration {
+	delay := 30 * time.Second
+	for i := 1; i < attempts && delay < time.Hour; i++ {
+		delay *= 2
+	}
+	return min(delay, time.Hour)
+}
@@ -0,0 +1,115 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+)
+
+//go:embed templates/*
+var embedded embed.FS
+
+// TemplateRenderer renders emails from a plain-text template, which also defines the
+// subject, and an optional HTML template. The templates are built into the binary;
+// a deployment can replace any of them with a file of the same name in its own directory.
+type TemplateRenderer struct {
+	text map[string]*texttemplate.Template
+	html map[string]*htmltemplate.Template
+}
+
+// NewTemplateRenderer parses the built-in templates, preferring files in overrideDir
+// when it is set
+func NewTemplateRenderer(overrideDir string) (*TemplateRenderer, error) {
+	entries, err := fs.ReadDir(embedded, "templates")
+	if err != nil {
+		return nil, err
+	}
+
+	r := &TemplateRenderer{
+		text: make(map[string]*texttemplate.Template),
+		html: make(map[string]*htmltemplate.Template),
+	}
+	for _, entry := range entries {
+		file := entry.Name()
+		source, err := readTemplate(overrideDir, file)
+		if err != nil {
+			return nil, err
+		}
+
+		name := strings.TrimSuffix(file, filepath.Ext(file))
+		switch filepath.Ext(file) {
+		case ".txt":
+			tmpl, err := texttemplate.New(file).Parse(source)
+			if err != nil {
+				return nil, fmt.Errorf("invalid email template %s: %w", file, err)
+			}
+			if tmpl.Lookup("subject") == nil {
+				return nil, fmt.Errorf("email template %s must define a subject", file)
+			}
+			r.text[name] = tmpl
+		case ".html":
+			tmpl, err := htmltemplate.New(file).Parse(source)
+			if err != nil {
+				return nil, fmt.Errorf("invalid email template %s: %w", file, err)
+			}
+			r.html[name] = tmpl
+		}
+	}
+	return r, nil
+}
+
+// readTemplate reads a template from the override directory, falling back to the built-in one
+func readTemplate(overrideDir, file string) (string, error) {
+	if overrideDir != "" {
+		source, err := os.ReadFile(filepath.Join(overrideDir, file))
+		if err == nil {
+			return string(source), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+	}
+
+	source, err := embedded.ReadFile("templates/" + file)
+	if err != nil {
+		return "", err
+	}
+	return string(source), nil
+}
+
+// Render renders the subject, plain-text body and HTML body of an email. The HTML body
+// is empty when the email has no HTML template.
+func (r *TemplateRenderer) Render(name string, data interface{}) (subject, text, html string, err error) {
+	textTmpl, ok := r.text[name]
+	if !ok {
+		return "", "", "", fmt.Errorf("unknown email template %q", name)
+	}
+
+	var buf bytes.Buffer
+	if err := textTmpl.ExecuteTemplate(&buf, "subject", data); err != nil {
+		return "", "", "", err
+	}
+	subject = strings.TrimSpace(buf.String())
+
+	buf.Reset()
+	if err := textTmpl.Execute(&buf, data); err != nil {
+		return "", "", "", err
+	}
+	text = buf.String()
+
+	if htmlTmpl, ok := r.html[name]; ok {
+		buf.Reset()
+		if err := htmlTmpl.Execute(&buf, data); err != nil {
+			return "", "", "", err
+		}
+		html = buf.String()
+	}
+	return subject, text, html, nil
+}
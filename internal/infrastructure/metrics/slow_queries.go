@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxSlowQueries bounds how many distinct statements are counted, so a flood of
+// unusual queries cannot grow memory without limit
+const maxSlowQueries = 200
+
+// literals matches quoted strings and numbers, which normalizeSQL replaces
+var literals = regexp.MustCompile(`'(?:[^']|'')*'|\b\d+(?:\.\d+)?\b`)
+
+// SlowQuery is how often one statement ran slower than the threshold since startup
+type SlowQuery struct {
+	SQL           string
+	Count         int64
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+	LastSeenAt    time.Time
+}
+
+// SlowQueries counts the queries that ran slower than the slow query threshold,
+// per statement. Statements are counted with their literal values replaced by ?,
+// so the counts never hold user data and repeats of one query add up.
+type SlowQueries struct {
+	mu      sync.Mutex
+	queries map[string]*SlowQuery
+	dropped int64
+}
+
+// NewSlowQueries creates empty slow query counters
+func NewSlowQueries() *SlowQueries {
+	return &SlowQueries{queries: make(map[string]*SlowQuery)}
+}
+
+// Record counts one slow run of a statement
+func (s *SlowQueries) Record(sql string, duration time.Duration, at time.Time) {
+	sql = normalizeSQL(sql)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	query := s.queries[sql]
+	if query == nil {
+		if len(s.queries) >= maxSlowQueries {
+			s.dropped++
+			return
+		}
+		query = &SlowQuery{SQL: sql}
+		s.queries[sql] = query
+	}
+	query.Count++
+	query.TotalDuration += duration
+	query.MaxDuration = max(query.MaxDuration, duration)
+	query.LastSeenAt = at
+}
+
+// List returns the counted statements, the most total time spent first, and how
+// many slow runs were not counted because the limit of statements was reached
+func (s *SlowQueries) List() ([]SlowQuery, int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queries := make([]SlowQuery, 0, len(s.queries))
+	for _, query := range s.queries {
+		queries = append(queries, *query)
+	}
+	sort.Slice(queries, func(i, j int) bool {
+		if queries[i].TotalDuration != queries[j].TotalDuration {
+			return queries[i].TotalDuration > queries[j].TotalDuration
+		}
+		return queries[i].SQL < queries[j].SQL
+	})
+	return queries, s.dropped
+}
+
+// normalizeSQL replaces the literal values in a statement with ? and collapses
+// its whitespace
+func normalizeSQL(sql string) string {
+	return strings.Join(strings.Fields(literals.ReplaceAllString(sql, "?")), " ")
+}
@@ -0,0 +1,323 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// VersionUsage is the request count for one API version and endpoint
+type VersionUsage struct {
+	Version      string
+	Method       string
+	Route        string
+	RequestCount int64
+	FirstSeenAt  time.Time
+	LastSeenAt   time.Time
+}
+
+// ClientUsage is the request count for one API version by one user
+type ClientUsage struct {
+	UserID       int
+	Email        string // filled in by the store when listing
+	Version      string
+	RequestCount int64
+	FirstSeenAt  time.Time
+	LastSeenAt   time.Time
+}
+
+// MonthlyUsage is the request count for one user in one calendar month (UTC),
+// across all API versions
+type MonthlyUsage struct {
+	UserID       int
+	Month        string // YYYY-MM
+	RequestCount int64
+	LastSeenAt   time.Time
+}
+
+// DailyUsage is the request count for one user on one day (UTC), across all API
+// versions. A row for a day means the user was active that day.
+type DailyUsage struct {
+	UserID       int
+	Day          string // YYYY-MM-DD
+	RequestCount int64
+	LastSeenAt   time.Time
+}
+
+// VersionUsageStore persists version usage counters
+type VersionUsageStore interface {
+	// AddUsage adds the given counts to the stored totals
+	AddUsage(ctx context.Context, usage []VersionUsage) error
+	// ListUsage returns the stored totals
+	ListUsage(ctx context.Context) ([]VersionUsage, error)
+	// AddClientUsage adds the given per-user counts to the stored totals
+	AddClientUsage(ctx context.Context, usage []ClientUsage) error
+	// ListClientUsage returns the stored per-user totals for the versions
+	ListClientUsage(ctx context.Context, versions []string) ([]ClientUsage, error)
+	// AddMonthlyUsage adds the given per-user monthly counts to the stored totals
+	AddMonthlyUsage(ctx context.Context, usage []MonthlyUsage) error
+	// GetMonthlyUsage returns the stored count for the user in the month, 0 when there is none
+	GetMonthlyUsage(ctx context.Context, userID int, month string) (int64, error)
+	// AddDailyUsage adds the given per-user daily counts to the stored totals
+	AddDailyUsage(ctx context.Context, usage []DailyUsage) error
+}
+
+// usageKey identifies a counter
+type usageKey struct {
+	version string
+	method  string
+	route   string
+}
+
+// clientKey identifies a per-user counter
+type clientKey struct {
+	userID  int
+	version string
+}
+
+// monthlyKey identifies a per-user monthly counter
+type monthlyKey struct {
+	userID int
+	month  string
+}
+
+// dailyKey identifies a per-user daily counter
+type dailyKey struct {
+	userID int
+	day    string
+}
+
+// monthLayout formats the month of monthly counters
+const monthLayout = "2006-01"
+
+// dayLayout formats the day of daily counters
+const dayLayout = "2006-01-02"
+
+// VersionUsageTracker counts requests per API version and endpoint, per version
+// and user, and per user and month or day, in memory and periodically flushes
+// the counts to a store
+type VersionUsageTracker struct {
+	store   VersionUsageStore
+	mu      sync.Mutex
+	pending map[usageKey]*VersionUsage
+	clients map[clientKey]*ClientUsage
+	monthly map[monthlyKey]*MonthlyUsage
+	daily   map[dailyKey]*DailyUsage
+}
+
+// NewVersionUsageTracker creates a new version usage tracker
+func NewVersionUsageTracker(store VersionUsageStore) *VersionUsageTracker {
+	return &VersionUsageTracker{
+		store:   store,
+		pending: make(map[usageKey]*VersionUsage),
+		clients: make(map[clientKey]*ClientUsage),
+		monthly: make(map[monthlyKey]*MonthlyUsage),
+		daily:   make(map[dailyKey]*DailyUsage),
+	}
+}
+
+// Record counts one request. userID is 0 for unauthenticated requests, which
+// are only counted per endpoint.
+func (t *VersionUsageTracker) Record(version, method, route string, userID int) {
+	now := time.Now()
+	key := usageKey{version: version, method: method, route: route}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	usage, ok := t.pending[key]
+	if !ok {
+		usage = &VersionUsage{
+			Version:     version,
+			Method:      method,
+			Route:       route,
+			FirstSeenAt: now,
+		}
+		t.pending[key] = usage
+	}
+	usage.RequestCount++
+	usage.LastSeenAt = now
+
+	if userID == 0 {
+		return
+	}
+	client, ok := t.clients[clientKey{userID: userID, version: version}]
+	if !ok {
+		client = &ClientUsage{
+			UserID:      userID,
+			Version:     version,
+			FirstSeenAt: now,
+		}
+		t.clients[clientKey{userID: userID, version: version}] = client
+	}
+	client.RequestCount++
+	client.LastSeenAt = now
+
+	month := monthlyKey{userID: userID, month: now.UTC().Format(monthLayout)}
+	monthly, ok := t.monthly[month]
+	if !ok {
+		monthly = &MonthlyUsage{UserID: userID, Month: month.month}
+		t.monthly[month] = monthly
+	}
+	monthly.RequestCount++
+	monthly.LastSeenAt = now
+
+	day := dailyKey{userID: userID, day: now.UTC().Format(dayLayout)}
+	daily, ok := t.daily[day]
+	if !ok {
+		daily = &DailyUsage{UserID: userID, Day: day.day}
+		t.daily[day] = daily
+	}
+	daily.RequestCount++
+	daily.LastSeenAt = now
+}
+
+// Flush writes pending counts to the store.
+// Counts are kept for the next flush if the store fails.
+func (t *VersionUsageTracker) Flush(ctx context.Context) error {
+	t.mu.Lock()
+	pending, clients, monthly, daily := t.pending, t.clients, t.monthly, t.daily
+	t.pending = make(map[usageKey]*VersionUsage)
+	t.clients = make(map[clientKey]*ClientUsage)
+	t.monthly = make(map[monthlyKey]*MonthlyUsage)
+	t.daily = make(map[dailyKey]*DailyUsage)
+	t.mu.Unlock()
+
+	if len(pending) > 0 {
+		batch := make([]VersionUsage, 0, len(pending))
+		for _, usage := range pending {
+			batch = append(batch, *usage)
+		}
+		if err := t.store.AddUsage(ctx, batch); err != nil {
+			t.restore(pending, clients, monthly, daily)
+			return err
+		}
+	}
+
+	if len(clients) > 0 {
+		batch := make([]ClientUsage, 0, len(clients))
+		for _, usage := range clients {
+			batch = append(batch, *usage)
+		}
+		if err := t.store.AddClientUsage(ctx, batch); err != nil {
+			t.restore(nil, clients, monthly, daily)
+			return err
+		}
+	}
+
+	if len(monthly) > 0 {
+		batch := make([]MonthlyUsage, 0, len(monthly))
+		for _, usage := range monthly {
+			batch = append(batch, *usage)
+		}
+		if err := t.store.AddMonthlyUsage(ctx, batch); err != nil {
+			t.restore(nil, nil, monthly, daily)
+			return err
+		}
+	}
+
+	if len(daily) > 0 {
+		batch := make([]DailyUsage, 0, len(daily))
+		for _, usage := range daily {
+			batch = append(batch, *usage)
+		}
+		if err := t.store.AddDailyUsage(ctx, batch); err != nil {
+			t.restore(nil, nil, nil, daily)
+			return err
+		}
+	}
+	return nil
+}
+
+// restore merges unflushed counts back into the pending sets
+func (t *VersionUsageTracker) restore(unflushed map[usageKey]*VersionUsage, clients map[clientKey]*ClientUsage, monthly map[monthlyKey]*MonthlyUsage, daily map[dailyKey]*DailyUsage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key, usage := range unflushed {
+		current, ok := t.pending[key]
+		if !ok {
+			t.pending[key] = usage
+			continue
+		}
+		current.RequestCount += usage.RequestCount
+		current.FirstSeenAt = usage.FirstSeenAt
+	}
+	for key, usage := range clients {
+		current, ok := t.clients[key]
+		if !ok {
+			t.clients[key] = usage
+			continue
+		}
+		current.RequestCount += usage.RequestCount
+		current.FirstSeenAt = usage.FirstSeenAt
+	}
+	for key, usage := range monthly {
+		current, ok := t.monthly[key]
+		if !ok {
+			t.monthly[key] = usage
+			continue
+		}
+		current.RequestCount += usage.RequestCount
+	}
+	for key, usage := range daily {
+		current, ok := t.daily[key]
+		if !ok {
+			t.daily[key] = usage
+			continue
+		}
+		current.RequestCount += usage.RequestCount
+	}
+}
+
+// Usage flushes pending counts and returns the stored totals
+func (t *VersionUsageTracker) Usage(ctx context.Context) ([]VersionUsage, error) {
+	if err := t.Flush(ctx); err != nil {
+		return nil, err
+	}
+	return t.store.ListUsage(ctx)
+}
+
+// ClientUsage flushes pending counts and returns the stored per-user totals for the versions
+func (t *VersionUsageTracker) ClientUsage(ctx context.Context, versions []string) ([]ClientUsage, error) {
+	if err := t.Flush(ctx); err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, nil
+	}
+	return t.store.ListClientUsage(ctx, versions)
+}
+
+// MonthlyRequests flushes pending counts and returns the number of requests the
+// user has made in the current calendar month (UTC)
+func (t *VersionUsageTracker) MonthlyRequests(ctx context.Context, userID int) (int64, error) {
+	if err := t.Flush(ctx); err != nil {
+		return 0, err
+	}
+	return t.store.GetMonthlyUsage(ctx, userID, time.Now().UTC().Format(monthLayout))
+}
+
+// Run flushes pending counts every interval until ctx is cancelled
+func (t *VersionUsageTracker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Final flush with a fresh context so shutdown doesn't drop counts
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if err := t.Flush(flushCtx); err != nil {
+				slog.Error("failed to flush version usage", "error", err.Error())
+			}
+			cancel()
+			return
+		case <-ticker.C:
+			if err := t.Flush(ctx); err != nil {
+				slog.Error("failed to flush version usage", "error", err.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Package prices looks up security prices with Alpha Vantage.
+package prices
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"panda-pocket/internal/infrastructure/config"
+)
+
+// ErrUnknownTicker is returned when the provider has no quote for a ticker
+var ErrUnknownTicker = errors.New("no price found for ticker")
+
+const alphaVantageURL = "https://www.alphavantage.co/query"
+
+// Provider looks up the latest closing prices of securities
+type Provider struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+// NewProvider creates a provider for the configured service, or returns nil when
+// no service is configured
+func NewProvider(cfg config.PricesConfig) *Provider {
+	if cfg.Provider != "alphavantage" {
+		return nil
+	}
+	return &Provider{
+		url:    alphaVantageURL,
+		apiKey: cfg.APIKey,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Price returns the ticker's latest price and the trading day it is from. It
+// returns ErrUnknownTicker when the provider does not know the ticker, and other
+// errors when the provider can't be reached or is rate limiting requests.
+func (p *Provider) Price(ctx context.Context, ticker string) (float64, time.Time, error) {
+	query := url.Values{"function": {"GLOBAL_QUOTE"}, "symbol": {ticker}, "apikey": {p.apiKey}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url+"?"+query.Encode(), nil)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, time.Time{}, fmt.Errorf("price provider returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Quote struct {
+			Price      string `json:"05. price"`
+			TradingDay string `json:"07. latest trading day"`
+		} `json:"Global Quote"`
+		// Set instead of a quote when the API key is over its request limit
+		Note        string `json:"Note"`
+		Information string `json:"Information"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, time.Time{}, err
+	}
+	if result.Note != "" || result.Information != "" {
+		return 0, time.Time{}, fmt.Errorf("price provider refused the request: %s%s", result.Note, result.Information)
+	}
+	if result.Quote.Price == "" {
+		return 0, time.Time{}, ErrUnknownTicker
+	}
+
+	price, err := strconv.ParseFloat(result.Quote.Price, 64)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("price provider returned an invalid price %q", result.Quote.Price)
+	}
+	day, err := time.Parse("2006-01-02", result.Quote.TradingDay)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("price provider returned an invalid trading day %q", result.Quote.TradingDay)
+	}
+	return price, day, nil
+}
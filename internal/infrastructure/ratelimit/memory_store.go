@@ -0,0 +1,123 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// bucket holds the token count for one key
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+	fullAt   time.Time // when the bucket is full again if left alone
+}
+
+// counter holds the request count for one key until it expires
+type counter struct {
+	count    int64
+	expireAt time.Time
+}
+
+// MemoryStore is a process-local token bucket store.
+// Limits are not shared between instances; use RedisStore when running several replicas.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	counters  map[string]*counter
+	lastSweep time.Time
+	lastPurge time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore creates a new in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets:  make(map[string]*bucket),
+		counters: make(map[string]*counter),
+		now:      time.Now,
+	}
+}
+
+// Take takes a token from the bucket for key
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	capacity := float64(limit.Requests)
+	perToken := limit.refillInterval()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, lastSeen: now}
+		s.buckets[key] = b
+	}
+
+	// Refill tokens for the time elapsed since the last request
+	elapsed := now.Sub(b.lastSeen)
+	b.tokens = min(capacity, b.tokens+float64(elapsed)/float64(perToken))
+	b.lastSeen = now
+
+	result := Result{Limit: limit.Requests}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration((1 - b.tokens) * float64(perToken))
+	}
+
+	result.Remaining = int(b.tokens)
+	result.ResetAfter = time.Duration((capacity - b.tokens) * float64(perToken))
+	b.fullAt = now.Add(result.ResetAfter)
+	return result, nil
+}
+
+// Increment adds one to the counter for key
+func (s *MemoryStore) Increment(ctx context.Context, key string, expireAt time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.purge(now)
+
+	c, ok := s.counters[key]
+	if !ok || !now.Before(c.expireAt) {
+		c = &counter{expireAt: expireAt}
+		s.counters[key] = c
+	}
+	c.count++
+	return c.count, nil
+}
+
+// sweep drops buckets that have been idle long enough to be full again.
+// Each bucket is judged by its own refill time, as buckets of different
+// limits share the store.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if !now.Before(b.fullAt) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// purge drops expired counters
+func (s *MemoryStore) purge(now time.Time) {
+	if now.Sub(s.lastPurge) < time.Minute {
+		return
+	}
+	s.lastPurge = now
+
+	for key, c := range s.counters {
+		if !now.Before(c.expireAt) {
+			delete(s.counters, key)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a settable time source for the store
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+// newTestStore creates a memory store driven by a fake clock
+func newTestStore() (*MemoryStore, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	store := NewMemoryStore()
+	store.now = clock.Now
+	return store, clock
+}
+
+// take takes n tokens and returns the last result
+func take(t *testing.T, store *MemoryStore, key string, limit Limit, n int) Result {
+	t.Helper()
+	var result Result
+	for i := 0; i < n; i++ {
+		var err error
+		result, err = store.Take(context.Background(), key, limit)
+		require.NoError(t, err)
+	}
+	return result
+}
+
+func TestMemoryStoreTake(t *testing.T) {
+	limit := Limit{Requests: 10, Window: time.Minute}
+
+	t.Run("a full bucket allows a burst of its capacity", func(t *testing.T) {
+		store, _ := newTestStore()
+
+		result := take(t, store, "user:1", limit, 10)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 0, result.Remaining)
+		assert.Equal(t, time.Minute, result.ResetAfter)
+
+		result = take(t, store, "user:1", limit, 1)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 6*time.Second, result.RetryAfter)
+	})
+
+	t.Run("tokens are refilled evenly over the window", func(t *testing.T) {
+		store, clock := newTestStore()
+		take(t, store, "user:1", limit, 10)
+
+		clock.Advance(12 * time.Second)
+		assert.True(t, take(t, store, "user:1", limit, 2).Allowed)
+		assert.False(t, take(t, store, "user:1", limit, 1).Allowed)
+
+		clock.Advance(time.Hour)
+		result := take(t, store, "user:1", limit, 1)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 9, result.Remaining)
+	})
+
+	t.Run("keys have their own buckets", func(t *testing.T) {
+		store, _ := newTestStore()
+		take(t, store, "user:1", limit, 11)
+
+		assert.True(t, take(t, store, "user:2", limit, 1).Allowed)
+	})
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	short := Limit{Requests: 10, Window: time.Minute}
+	long := Limit{Requests: 10, Window: time.Hour}
+
+	t.Run("full buckets are dropped", func(t *testing.T) {
+		store, clock := newTestStore()
+		take(t, store, "ip:1", short, 5)
+
+		clock.Advance(2 * time.Minute)
+		take(t, store, "ip:2", short, 1)
+		assert.NotContains(t, store.buckets, "ip:1")
+	})
+
+	t.Run("buckets of longer windows are kept until they are full", func(t *testing.T) {
+		store, clock := newTestStore()
+		take(t, store, "user:1", long, 10)
+
+		// A request under the short limit sweeps while the long bucket is still refilling
+		clock.Advance(2 * time.Minute)
+		take(t, store, "ip:1", short, 1)
+		require.Contains(t, store.buckets, "user:1")
+
+		assert.False(t, take(t, store, "user:1", long, 1).Allowed)
+	})
+}
+
+func TestMemoryStoreIncrement(t *testing.T) {
+	store, clock := newTestStore()
+	ctx := context.Background()
+	expireAt := clock.now.Add(time.Hour)
+
+	for want := int64(1); want <= 3; want++ {
+		count, err := store.Increment(ctx, "daily:1", expireAt)
+		require.NoError(t, err)
+		assert.Equal(t, want, count)
+	}
+
+	clock.Advance(time.Hour)
+	count, err := store.Increment(ctx, "daily:1", clock.now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
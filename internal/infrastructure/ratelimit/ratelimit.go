@@ -0,0 +1,40 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limit describes a token bucket: Requests tokens refilled evenly over Window
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// Enabled reports whether the limit should be enforced
+func (l Limit) Enabled() bool {
+	return l.Requests > 0 && l.Window > 0
+}
+
+// refillInterval returns how long it takes to regain a single token
+func (l Limit) refillInterval() time.Duration {
+	return l.Window / time.Duration(l.Requests)
+}
+
+// Result is the outcome of taking a token from a bucket
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration // time until a token is available, zero when allowed
+	ResetAfter time.Duration // time until the bucket is full again
+}
+
+// Store takes tokens from buckets identified by key, and counts requests in
+// fixed windows for quotas
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+	// Increment adds one to the counter for key and returns the new count.
+	// The counter starts over from zero once expireAt has passed.
+	Increment(ctx context.Context, key string, expireAt time.Time) (int64, error)
+}
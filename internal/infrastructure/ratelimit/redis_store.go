@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// takeScript refills and takes a token atomically.
+// KEYS[1] bucket key; ARGV: capacity, milliseconds per token, now in milliseconds.
+// Returns {allowed, tokens * 1000}.
+var takeScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local per_token = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil then
+  tokens = capacity
+  ts = now
+end
+
+tokens = math.min(capacity, tokens + (now - ts) / per_token)
+
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+
+redis.call("HSET", KEYS[1], "tokens", tokens, "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(capacity * per_token))
+
+return {allowed, math.floor(tokens * 1000)}
+`)
+
+// incrementScript counts a request and sets the counter's expiry on the first one.
+// KEYS[1] counter key; ARGV: expiry in Unix milliseconds. Returns the new count.
+var incrementScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+  redis.call("PEXPIREAT", KEYS[1], ARGV[1])
+end
+return count
+`)
+
+// RedisStore is a token bucket store shared between instances through Redis
+type RedisStore struct {
+	client redis.Scripter
+	prefix string
+}
+
+// NewRedisStore creates a new Redis-backed store
+func NewRedisStore(client redis.Scripter) *RedisStore {
+	return &RedisStore{
+		client: client,
+		prefix: "ratelimit:",
+	}
+}
+
+// Take takes a token from the bucket for key
+func (s *RedisStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	perToken := limit.refillInterval()
+	perTokenMs := max(perToken.Milliseconds(), 1)
+
+	values, err := takeScript.Run(ctx, s.client, []string{s.prefix + key},
+		limit.Requests, perTokenMs, time.Now().UnixMilli()).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+
+	tokens := float64(values[1]) / 1000
+	result := Result{
+		Allowed:    values[0] == 1,
+		Limit:      limit.Requests,
+		Remaining:  int(tokens),
+		ResetAfter: time.Duration((float64(limit.Requests) - tokens) * float64(perToken)),
+	}
+	if !result.Allowed {
+		result.RetryAfter = time.Duration((1 - tokens) * float64(perToken))
+	}
+	return result, nil
+}
+
+// Increment adds one to the counter for key
+func (s *RedisStore) Increment(ctx context.Context, key string, expireAt time.Time) (int64, error) {
+	return incrementScript.Run(ctx, s.client, []string{s.prefix + key}, expireAt.UnixMilli()).Int64()
+}
@@ -0,0 +1,210 @@
+// Package realtime pushes domain events and notifications to the dashboards a
+// user has open over a live connection.
+package realtime
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"panda-pocket/internal/domain/finance"
+)
+
+// Topics a connection can subscribe to
+const (
+	TopicTransactions  = "transactions"
+	TopicBudgets       = "budgets"
+	TopicNotifications = "notifications"
+)
+
+// ErrUnknownTopic is returned when subscribing to a topic that does not exist
+var ErrUnknownTopic = errors.New("unknown topic")
+
+// queueSize is the number of pushes held for a connection that is slow to read them
+const queueSize = 64
+
+// eventTopics lists the topic each pushed domain event is sent on; other events are not pushed
+var eventTopics = map[string]string{
+	finance.EventTransactionCreated: TopicTransactions,
+	finance.EventTransactionUpdated: TopicTransactions,
+	finance.EventTransactionDeleted: TopicTransactions,
+	finance.EventBudgetExceeded:     TopicBudgets,
+}
+
+// Topics returns every topic, in the order they are documented
+func Topics() []string {
+	return []string{TopicTransactions, TopicBudgets, TopicNotifications}
+}
+
+// Push is a message sent to the connections subscribed to its topic
+type Push struct {
+	Type       string      `json:"type"` // always "event", to tell pushes from replies
+	Topic      string      `json:"topic"`
+	Event      string      `json:"event"`
+	Data       interface{} `json:"data"`
+	OccurredAt time.Time   `json:"occurred_at"`
+}
+
+// Subscriber is one open connection: its topics and the pushes awaiting delivery
+type Subscriber struct {
+	userID int
+	mu     sync.Mutex
+	topics map[string]bool
+	pushes chan Push
+}
+
+// Subscribe adds topics to the connection's subscriptions. Nothing is added
+// when any of them is unknown.
+func (s *Subscriber) Subscribe(topics ...string) error {
+	for _, topic := range topics {
+		if !isTopic(topic) {
+			return ErrUnknownTopic
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, topic := range topics {
+		s.topics[topic] = true
+	}
+	return nil
+}
+
+// Unsubscribe removes topics from the connection's subscriptions
+func (s *Subscriber) Unsubscribe(topics ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, topic := range topics {
+		delete(s.topics, topic)
+	}
+}
+
+// Topics returns the topics the connection is subscribed to, sorted by name
+func (s *Subscriber) Topics() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	topics := make([]string, 0, len(s.topics))
+	for topic := range s.topics {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// Pushes returns the queue of pushes to send on the connection
+func (s *Subscriber) Pushes() <-chan Push {
+	return s.pushes
+}
+
+// subscribed reports whether the connection receives pushes on topic
+func (s *Subscriber) subscribed(topic string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.topics[topic]
+}
+
+// Hub tracks the open connections of every user and fans pushes out to them.
+// Pushes are best effort: a connection that falls too far behind misses them,
+// and clients catch up through the regular endpoints when they reconnect.
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[int]map[*Subscriber]struct{}
+}
+
+// NewHub creates a new hub
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[int]map[*Subscriber]struct{}),
+	}
+}
+
+// Connect registers a new connection for the user, subscribed to nothing
+func (h *Hub) Connect(userID int) *Subscriber {
+	subscriber := &Subscriber{
+		userID: userID,
+		topics: make(map[string]bool),
+		pushes: make(chan Push, queueSize),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[*Subscriber]struct{})
+	}
+	h.subscribers[userID][subscriber] = struct{}{}
+	return subscriber
+}
+
+// Disconnect removes a connection so it receives no further pushes
+func (h *Hub) Disconnect(subscriber *Subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subscribers[subscriber.userID], subscriber)
+	if len(h.subscribers[subscriber.userID]) == 0 {
+		delete(h.subscribers, subscriber.userID)
+	}
+}
+
+// Publish pushes data to the user's connections subscribed to topic
+func (h *Hub) Publish(userID int, topic, event string, data interface{}) {
+	h.publish(userID, Push{
+		Type:       "event",
+		Topic:      topic,
+		Event:      event,
+		Data:       data,
+		OccurredAt: time.Now(),
+	})
+}
+
+// HandleEvent pushes a delivered domain event to its user's connections. It is
+// subscribed to the event bus, and never fails so the outbox is not held back.
+func (h *Hub) HandleEvent(ctx context.Context, event finance.Event) error {
+	topic, ok := eventTopics[event.EventName()]
+	if !ok {
+		return nil
+	}
+	userEvent, ok := event.(finance.UserEvent)
+	if !ok {
+		return nil
+	}
+
+	h.publish(userEvent.EventUserID(), Push{
+		Type:       "event",
+		Topic:      topic,
+		Event:      event.EventName(),
+		Data:       event,
+		OccurredAt: event.OccurredAt(),
+	})
+	return nil
+}
+
+// publish queues the push on every subscribed connection of the user without blocking
+func (h *Hub) publish(userID int, push Push) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for subscriber := range h.subscribers[userID] {
+		if !subscriber.subscribed(push.Topic) {
+			continue
+		}
+		select {
+		case subscriber.pushes <- push:
+		default:
+			slog.Warn("live connection queue full, push dropped", "user_id", userID, "topic", push.Topic, "event", push.Event)
+		}
+	}
+}
+
+// isTopic reports whether topic is one connections can subscribe to
+func isTopic(topic string) bool {
+	for _, known := range Topics() {
+		if topic == known {
+			return true
+		}
+	}
+	return false
+}
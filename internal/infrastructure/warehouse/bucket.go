@@ -0,0 +1,137 @@
+package warehouse
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"panda-pocket/internal/infrastructure/config"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// ErrNotFound is returned when an object does not exist
+var ErrNotFound = errors.New("object not found")
+
+// Bucket keeps export files under slash-separated keys
+type Bucket interface {
+	// Put writes an object, replacing any with the same key
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	// Get reads an object, or returns ErrNotFound
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// NewBucket creates the bucket for the configured storage backend
+func NewBucket(ctx context.Context, cfg config.WarehouseConfig) (Bucket, error) {
+	if cfg.Storage == "s3" {
+		return NewS3Bucket(ctx, cfg.S3)
+	}
+	return NewLocalBucket(cfg.Dir), nil
+}
+
+// LocalBucket keeps exports in a directory on local disk, for tools that read
+// a mounted volume
+type LocalBucket struct {
+	dir string
+}
+
+// NewLocalBucket creates a bucket for a directory, which is created on first write
+func NewLocalBucket(dir string) *LocalBucket {
+	return &LocalBucket{dir: dir}
+}
+
+// Put writes an object, replacing any with the same key
+func (b *LocalBucket) Put(ctx context.Context, key, contentType string, data []byte) error {
+	name := filepath.Join(b.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(name), 0o700); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a failed export never looks complete
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".tmp-"+filepath.Base(name))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// Get reads an object, or returns ErrNotFound
+func (b *LocalBucket) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(b.dir, filepath.FromSlash(key)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// S3Bucket keeps exports in an S3 (or S3-compatible) bucket
+type S3Bucket struct {
+	client *s3.Client
+	bucket string
+	prefix string
+}
+
+// NewS3Bucket creates a bucket for the configured S3 bucket and key prefix
+func NewS3Bucket(ctx context.Context, cfg config.S3Config) (*S3Bucket, error) {
+	var opts []func(*awsconfig.LoadOptions) error
+	if cfg.Region != "" {
+		opts = append(opts, awsconfig.WithRegion(cfg.Region))
+	}
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+			o.UsePathStyle = true
+		}
+	})
+	return &S3Bucket{client: client, bucket: cfg.Bucket, prefix: strings.Trim(cfg.Prefix, "/")}, nil
+}
+
+// Put writes an object, replacing any with the same key
+func (b *S3Bucket) Put(ctx context.Context, key, contentType string, data []byte) error {
+	_, err := b.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(b.bucket),
+		Key:         aws.String(path.Join(b.prefix, key)),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+	})
+	return err
+}
+
+// Get reads an object, or returns ErrNotFound
+func (b *S3Bucket) Get(ctx context.Context, key string) ([]byte, error) {
+	out, err := b.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(path.Join(b.prefix, key)),
+	})
+	var noSuchKey *types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer out.Body.Close()
+	return io.ReadAll(out.Body)
+}
@@ -0,0 +1,246 @@
+// Package warehouse exports transaction changes as gzip-compressed CSV files for
+// BI tools such as Metabase or Looker.
+package warehouse
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"panda-pocket/internal/domain/finance"
+	"panda-pocket/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// watermarkKey holds the end of the last export, where the next one starts
+const watermarkKey = "_watermark"
+
+// transactionColumns and deletionColumns head the exported files. Expenses and
+// incomes are numbered separately, so a transaction is identified by its type
+// and ID together.
+var (
+	transactionColumns = []string{"type", "id", "user_id", "category_id", "category", "currency", "amount", "date", "status", "tax_deductible", "account_id", "created_at", "updated_at"}
+	deletionColumns    = []string{"type", "id", "user_id", "deleted_at"}
+)
+
+// transactionRow is an expense or income with the names BI tools group by
+type transactionRow struct {
+	ID            uint
+	UserID        uint
+	CategoryID    uint
+	Category      string
+	Currency      string
+	Amount        float64
+	Date          time.Time
+	Status        string
+	TaxDeductible bool
+	AccountID     *uint
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Exporter writes the expenses and incomes created, changed or deleted since its
+// last export to a bucket, one partition per export:
+//
+//	transactions/exported=2024-03-02/transactions.csv.gz
+//	transactions/exported=2024-03-02/deletions.csv.gz
+//
+// Descriptions, receipts and locations are left out. A transaction changed again
+// appears in a later partition too, so readers keep its row with the latest
+// updated_at.
+type Exporter struct {
+	db     *gorm.DB
+	bucket Bucket
+}
+
+// NewExporter creates an exporter writing to bucket
+func NewExporter(db *gorm.DB, bucket Bucket) *Exporter {
+	return &Exporter{db: db, bucket: bucket}
+}
+
+// Export writes the changes made before until and since the last export, and
+// returns how many rows were written. The first export holds every transaction.
+func (e *Exporter) Export(ctx context.Context, until time.Time) (int, error) {
+	since, err := e.watermark(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if !until.After(since) {
+		return 0, nil
+	}
+
+	transactions, transactionCount, err := e.transactions(ctx, since, until)
+	if err != nil {
+		return 0, err
+	}
+	deletions, deletionCount, err := e.deletions(ctx, since, until)
+	if err != nil {
+		return 0, err
+	}
+
+	// The watermark only moves once both files are written, so a failed export
+	// is repeated in full
+	partition := "transactions/exported=" + until.UTC().Format("2006-01-02") + "/"
+	if err := e.bucket.Put(ctx, partition+"transactions.csv.gz", "application/gzip", transactions); err != nil {
+		return 0, err
+	}
+	if err := e.bucket.Put(ctx, partition+"deletions.csv.gz", "application/gzip", deletions); err != nil {
+		return 0, err
+	}
+	if err := e.bucket.Put(ctx, watermarkKey, "text/plain", []byte(until.UTC().Format(time.RFC3339))); err != nil {
+		return 0, err
+	}
+	return transactionCount + deletionCount, nil
+}
+
+// watermark returns where the next export starts, the zero time before the first
+func (e *Exporter) watermark(ctx context.Context) (time.Time, error) {
+	data, err := e.bucket.Get(ctx, watermarkKey)
+	if errors.Is(err, ErrNotFound) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339, string(bytes.TrimSpace(data)))
+}
+
+// transactions writes the expenses and incomes updated in [since, until)
+func (e *Exporter) transactions(ctx context.Context, since, until time.Time) ([]byte, int, error) {
+	return writeCSV(transactionColumns, func(w *csv.Writer) (int, error) {
+		count := 0
+		sources := []struct {
+			transactionType finance.TransactionType
+			table           string
+		}{
+			{finance.TransactionTypeExpense, database.Expense{}.TableName()},
+			{finance.TransactionTypeIncome, database.Income{}.TableName()},
+		}
+		for _, source := range sources {
+			rows, err := e.db.WithContext(ctx).
+				Table(source.table+" AS t").
+				Select("t.id, t.user_id, t.category_id, categories.name AS category, currencies.code AS currency, t.amount, t.date, t.status, t.tax_deductible, t.account_id, t.created_at, t.updated_at").
+				Joins("LEFT JOIN categories ON categories.id = t.category_id").
+				Joins("LEFT JOIN currencies ON currencies.id = t.currency_id").
+				Where("t.updated_at >= ? AND t.updated_at < ?", since, until).
+				Order("t.id").
+				Rows()
+			if err != nil {
+				return count, err
+			}
+
+			for rows.Next() {
+				var row transactionRow
+				if err := e.db.ScanRows(rows, &row); err != nil {
+					rows.Close()
+					return count, err
+				}
+				accountID := ""
+				if row.AccountID != nil {
+					accountID = strconv.FormatUint(uint64(*row.AccountID), 10)
+				}
+				err := w.Write([]string{
+					string(source.transactionType),
+					strconv.FormatUint(uint64(row.ID), 10),
+					strconv.FormatUint(uint64(row.UserID), 10),
+					strconv.FormatUint(uint64(row.CategoryID), 10),
+					row.Category,
+					row.Currency,
+					strconv.FormatFloat(row.Amount, 'f', 2, 64),
+					row.Date.Format("2006-01-02"),
+					row.Status,
+					strconv.FormatBool(row.TaxDeductible),
+					accountID,
+					row.CreatedAt.UTC().Format(time.RFC3339),
+					row.UpdatedAt.UTC().Format(time.RFC3339),
+				})
+				if err != nil {
+					rows.Close()
+					return count, err
+				}
+				count++
+			}
+			if err := rows.Close(); err != nil {
+				return count, err
+			}
+		}
+		return count, nil
+	})
+}
+
+// deletions writes the expenses and incomes deleted in [since, until)
+func (e *Exporter) deletions(ctx context.Context, since, until time.Time) ([]byte, int, error) {
+	var tombstones []database.Tombstone
+	err := e.db.WithContext(ctx).
+		Where("kind IN ? AND deleted_at >= ? AND deleted_at < ?", []string{string(finance.RecordKindExpense), string(finance.RecordKindIncome)}, since, until).
+		Order("id").
+		Find(&tombstones).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return writeCSV(deletionColumns, func(w *csv.Writer) (int, error) {
+		for _, tombstone := range tombstones {
+			err := w.Write([]string{
+				tombstone.Kind,
+				strconv.FormatUint(uint64(tombstone.RecordID), 10),
+				strconv.FormatUint(uint64(tombstone.UserID), 10),
+				tombstone.DeletedAt.UTC().Format(time.RFC3339),
+			})
+			if err != nil {
+				return 0, err
+			}
+		}
+		return len(tombstones), nil
+	})
+}
+
+// writeCSV returns a gzip-compressed CSV file with the header and the rows
+// written by write, and how many rows there are
+func writeCSV(header []string, write func(w *csv.Writer) (int, error)) ([]byte, int, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	w := csv.NewWriter(gz)
+	if err := w.Write(header); err != nil {
+		return nil, 0, err
+	}
+	count, err := write(w)
+	if err != nil {
+		return nil, 0, err
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, 0, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, 0, err
+	}
+	return buf.Bytes(), count, nil
+}
+
+// Run exports the changes made before the start of each day, in UTC, every
+// interval until ctx is cancelled
+func (e *Exporter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			rows, err := e.Export(ctx, time.Now().UTC().Truncate(24*time.Hour))
+			if err != nil {
+				slog.Error("warehouse export failed", "error", err.Error())
+				continue
+			}
+			slog.Info("warehouse export written", "rows", rows)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Package webhook delivers signed event payloads to user registered endpoints.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"panda-pocket/internal/domain/notification"
+)
+
+// Headers sent with every delivery
+const (
+	EventHeader     = "X-PandaPocket-Event"
+	SignatureHeader = "X-PandaPocket-Signature"
+	TimestampHeader = "X-PandaPocket-Timestamp"
+)
+
+// errPrivateAddress is returned when an endpoint resolves to a private address
+var errPrivateAddress = errors.New("webhook endpoint resolves to a private address")
+
+// Sender posts signed JSON payloads to webhooks
+type Sender struct {
+	client *http.Client
+}
+
+// NewSender creates a sender with a short timeout, so a slow endpoint does not
+// hold up event delivery. Connections to private addresses are refused after DNS
+// resolution, so a public name pointing inside the network cannot be used either.
+func NewSender() *Sender {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || notification.IsPrivateIP(ip) {
+				return errPrivateAddress
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &Sender{client: &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: transport,
+		// Redirects are not followed; the endpoint registered is the one delivered to
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}}
+}
+
+// NewUnrestrictedSender creates a sender that delivers to any address, for
+// development and tests against local endpoints
+func NewUnrestrictedSender(client *http.Client) *Sender {
+	return &Sender{client: client}
+}
+
+// Send posts the payload with a signature over the timestamp and body. Receivers
+// verify it by computing hex(HMAC-SHA256(secret, timestamp + "." + body)).
+func (s *Sender) Send(ctx context.Context, url, secret, event string, payload []byte) error {
+	_, err := s.Deliver(ctx, url, secret, event, payload)
+	return err
+}
+
+// Deliver sends like Send and also returns the status code the endpoint answered
+// with, or 0 when no response was received
+func (s *Sender) Deliver(ctx context.Context, url, secret, event string, payload []byte) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "PandaPocket-Webhooks/1.0")
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, "sha256="+Sign(secret, timestamp, payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the hex encoded signature of a delivery
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
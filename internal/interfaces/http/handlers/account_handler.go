@@ -0,0 +1,429 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"panda-pocket/internal/application/finance"
+	domainFinance "panda-pocket/internal/domain/finance"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccountHandler handles account and reconciliation requests
+type AccountHandler struct {
+	manageAccountsUseCase          *finance.ManageAccountsUseCase
+	reconcileAccountUseCase        *finance.ReconcileAccountUseCase
+	updateTransactionStatusUseCase *finance.UpdateTransactionStatusUseCase
+	balanceAssertionsUseCase       *finance.BalanceAssertionsUseCase
+	getAccountTransactionsUseCase  *finance.GetAccountTransactionsUseCase
+	cardStatementsUseCase          *finance.CardStatementsUseCase
+	balanceAdjustmentsUseCase      *finance.BalanceAdjustmentsUseCase
+}
+
+// NewAccountHandler creates a new account handler instance
+func NewAccountHandler(
+	manageAccountsUseCase *finance.ManageAccountsUseCase,
+	reconcileAccountUseCase *finance.ReconcileAccountUseCase,
+	updateTransactionStatusUseCase *finance.UpdateTransactionStatusUseCase,
+	balanceAssertionsUseCase *finance.BalanceAssertionsUseCase,
+	getAccountTransactionsUseCase *finance.GetAccountTransactionsUseCase,
+	cardStatementsUseCase *finance.CardStatementsUseCase,
+	balanceAdjustmentsUseCase *finance.BalanceAdjustmentsUseCase,
+) *AccountHandler {
+	return &AccountHandler{
+		manageAccountsUseCase:          manageAccountsUseCase,
+		reconcileAccountUseCase:        reconcileAccountUseCase,
+		updateTransactionStatusUseCase: updateTransactionStatusUseCase,
+		balanceAssertionsUseCase:       balanceAssertionsUseCase,
+		getAccountTransactionsUseCase:  getAccountTransactionsUseCase,
+		cardStatementsUseCase:          cardStatementsUseCase,
+		balanceAdjustmentsUseCase:      balanceAdjustmentsUseCase,
+	}
+}
+
+// GetAccounts handles listing the current user's accounts; archived accounts
+// are only listed when asked for
+func (h *AccountHandler) GetAccounts(c *gin.Context) {
+	var req finance.ListAccountsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	accounts, err := h.manageAccountsUseCase.List(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_ACCOUNTS_ERROR", "Failed to fetch accounts")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"accounts": accounts})
+}
+
+// CreateAccount handles creating an account
+func (h *AccountHandler) CreateAccount(c *gin.Context) {
+	var req finance.CreateAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	account, err := h.manageAccountsUseCase.Create(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		HandleError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, gin.H{"account": account})
+}
+
+// ArchiveAccount handles archiving an account so it takes no new transactions
+func (h *AccountHandler) ArchiveAccount(c *gin.Context) {
+	accountID, ok := parseAccountID(c)
+	if !ok {
+		return
+	}
+
+	account, err := h.manageAccountsUseCase.Archive(c.Request.Context(), c.GetInt("user_id"), accountID)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"account": account})
+}
+
+// UnarchiveAccount handles reopening an archived account
+func (h *AccountHandler) UnarchiveAccount(c *gin.Context) {
+	accountID, ok := parseAccountID(c)
+	if !ok {
+		return
+	}
+
+	account, err := h.manageAccountsUseCase.Unarchive(c.Request.Context(), c.GetInt("user_id"), accountID)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"account": account})
+}
+
+// GetAccountTransactions handles listing an account's transactions, oldest
+// first, with the running balance after each
+func (h *AccountHandler) GetAccountTransactions(c *gin.Context) {
+	accountID, ok := parseAccountID(c)
+	if !ok {
+		return
+	}
+
+	var req finance.GetAccountTransactionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	response, err := h.getAccountTransactionsUseCase.Execute(c.Request.Context(), c.GetInt("user_id"), accountID, req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
+
+// SetEmergencyFund handles adding an account to or removing it from the user's emergency fund
+func (h *AccountHandler) SetEmergencyFund(c *gin.Context) {
+	accountID, ok := parseAccountID(c)
+	if !ok {
+		return
+	}
+
+	var req finance.SetEmergencyFundRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	account, err := h.manageAccountsUseCase.SetEmergencyFund(c.Request.Context(), c.GetInt("user_id"), accountID, req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"account": account})
+}
+
+// SetBillingCycle handles setting when a credit card's statements close and are due
+func (h *AccountHandler) SetBillingCycle(c *gin.Context) {
+	accountID, ok := parseAccountID(c)
+	if !ok {
+		return
+	}
+
+	var req finance.SetBillingCycleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	account, err := h.cardStatementsUseCase.SetBillingCycle(c.Request.Context(), c.GetInt("user_id"), accountID, req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"account": account})
+}
+
+// RemoveBillingCycle handles removing a credit card's billing cycle
+func (h *AccountHandler) RemoveBillingCycle(c *gin.Context) {
+	accountID, ok := parseAccountID(c)
+	if !ok {
+		return
+	}
+
+	account, err := h.cardStatementsUseCase.RemoveBillingCycle(c.Request.Context(), c.GetInt("user_id"), accountID)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"account": account})
+}
+
+// GetStatements handles summarizing a credit card's spending per billing cycle
+func (h *AccountHandler) GetStatements(c *gin.Context) {
+	accountID, ok := parseAccountID(c)
+	if !ok {
+		return
+	}
+
+	var req finance.GetCardStatementsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	response, err := h.cardStatementsUseCase.List(c.Request.Context(), c.GetInt("user_id"), accountID, req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
+
+// GetReconciliation handles comparing an account with bank statement totals
+// given as query parameters, without changing any transaction
+func (h *AccountHandler) GetReconciliation(c *gin.Context) {
+	accountID, ok := parseAccountID(c)
+	if !ok {
+		return
+	}
+
+	var req finance.ReconcileAccountRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	response, err := h.reconcileAccountUseCase.Report(c.Request.Context(), c.GetInt("user_id"), accountID, req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
+
+// Reconcile handles marking an account's transactions for a statement period
+// reconciled; nothing is marked unless the totals match the statement
+func (h *AccountHandler) Reconcile(c *gin.Context) {
+	accountID, ok := parseAccountID(c)
+	if !ok {
+		return
+	}
+
+	var req finance.ReconcileAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	response, err := h.reconcileAccountUseCase.Execute(c.Request.Context(), c.GetInt("user_id"), accountID, req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
+
+// GetBalanceAssertions handles listing an account's balance assertions, each
+// checked against the balance derived from the recorded transactions
+func (h *AccountHandler) GetBalanceAssertions(c *gin.Context) {
+	accountID, ok := parseAccountID(c)
+	if !ok {
+		return
+	}
+
+	assertions, err := h.balanceAssertionsUseCase.List(c.Request.Context(), c.GetInt("user_id"), accountID)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"balance_assertions": assertions})
+}
+
+// CreateBalanceAssertion handles asserting an account's balance at the end of a day
+func (h *AccountHandler) CreateBalanceAssertion(c *gin.Context) {
+	accountID, ok := parseAccountID(c)
+	if !ok {
+		return
+	}
+
+	var req finance.CreateBalanceAssertionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	assertion, err := h.balanceAssertionsUseCase.Create(c.Request.Context(), c.GetInt("user_id"), accountID, req)
+	if err != nil {
+		HandleError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, gin.H{"balance_assertion": assertion})
+}
+
+// DeleteBalanceAssertion handles removing a balance assertion
+func (h *AccountHandler) DeleteBalanceAssertion(c *gin.Context) {
+	accountID, ok := parseAccountID(c)
+	if !ok {
+		return
+	}
+
+	var assertionID int
+	if _, err := fmt.Sscanf(c.Param("assertion_id"), "%d", &assertionID); err != nil {
+		BadRequestResponse(c, "INVALID_BALANCE_ASSERTION_ID", "Invalid balance assertion ID")
+		return
+	}
+
+	if err := h.balanceAssertionsUseCase.Delete(c.Request.Context(), c.GetInt("user_id"), accountID, assertionID); err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"message": "Balance assertion deleted successfully"})
+}
+
+// GetBalanceAdjustments handles listing an account's balance adjustments
+func (h *AccountHandler) GetBalanceAdjustments(c *gin.Context) {
+	accountID, ok := parseAccountID(c)
+	if !ok {
+		return
+	}
+
+	adjustments, err := h.balanceAdjustmentsUseCase.List(c.Request.Context(), c.GetInt("user_id"), accountID)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"balance_adjustments": adjustments})
+}
+
+// CreateBalanceAdjustment handles adjusting an account's derived balance to the
+// balance it should have at the end of a day
+func (h *AccountHandler) CreateBalanceAdjustment(c *gin.Context) {
+	accountID, ok := parseAccountID(c)
+	if !ok {
+		return
+	}
+
+	var req finance.CreateBalanceAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	adjustment, err := h.balanceAdjustmentsUseCase.Create(c.Request.Context(), c.GetInt("user_id"), accountID, req)
+	if err != nil {
+		HandleError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, gin.H{"balance_adjustment": adjustment})
+}
+
+// DeleteBalanceAdjustment handles removing a balance adjustment
+func (h *AccountHandler) DeleteBalanceAdjustment(c *gin.Context) {
+	accountID, ok := parseAccountID(c)
+	if !ok {
+		return
+	}
+
+	var adjustmentID int
+	if _, err := fmt.Sscanf(c.Param("adjustment_id"), "%d", &adjustmentID); err != nil {
+		BadRequestResponse(c, "INVALID_BALANCE_ADJUSTMENT_ID", "Invalid balance adjustment ID")
+		return
+	}
+
+	if err := h.balanceAdjustmentsUseCase.Delete(c.Request.Context(), c.GetInt("user_id"), accountID, adjustmentID); err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"message": "Balance adjustment deleted successfully"})
+}
+
+// UpdateExpenseStatus handles marking an expense uncleared, cleared or reconciled
+func (h *AccountHandler) UpdateExpenseStatus(c *gin.Context) {
+	h.updateTransactionStatus(c, domainFinance.TransactionTypeExpense, "expense")
+}
+
+// UpdateIncomeStatus handles marking an income uncleared, cleared or reconciled
+func (h *AccountHandler) UpdateIncomeStatus(c *gin.Context) {
+	h.updateTransactionStatus(c, domainFinance.TransactionTypeIncome, "income")
+}
+
+// updateTransactionStatus sets the status of a transaction of the expected type
+func (h *AccountHandler) updateTransactionStatus(c *gin.Context, expectedType domainFinance.TransactionType, key string) {
+	var transactionID int
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &transactionID); err != nil {
+		BadRequestResponse(c, "INVALID_TRANSACTION_ID", "Invalid transaction ID")
+		return
+	}
+
+	var req finance.UpdateTransactionStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	transaction, err := h.updateTransactionStatusUseCase.Execute(c.Request.Context(), transactionID, c.GetInt("user_id"), expectedType, req)
+	if err != nil {
+		HandleError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{
+		key: gin.H{
+			"id":         transaction.ID().Value(),
+			"account_id": transaction.AccountID().Value(),
+			"status":     string(transaction.Status()),
+		},
+	})
+}
+
+// parseAccountID reads the account ID path parameter, responding with an error when it is invalid
+func parseAccountID(c *gin.Context) (int, bool) {
+	var accountID int
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &accountID); err != nil {
+		BadRequestResponse(c, "INVALID_ACCOUNT_ID", "Invalid account ID")
+		return 0, false
+	}
+	return accountID, true
+}
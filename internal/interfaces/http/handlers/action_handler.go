@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"panda-pocket/internal/application/finance"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ActionHandler handles listing and undoing recent changes
+type ActionHandler struct {
+	getActionsUseCase *finance.GetActionsUseCase
+	undoActionUseCase *finance.UndoActionUseCase
+}
+
+// NewActionHandler creates a new action handler instance
+func NewActionHandler(getActionsUseCase *finance.GetActionsUseCase, undoActionUseCase *finance.UndoActionUseCase) *ActionHandler {
+	return &ActionHandler{
+		getActionsUseCase: getActionsUseCase,
+		undoActionUseCase: undoActionUseCase,
+	}
+}
+
+// GetActions handles listing the current user's changes that can still be undone
+func (h *ActionHandler) GetActions(c *gin.Context) {
+	actions, err := h.getActionsUseCase.Execute(c.Request.Context(), c.GetInt("user_id"))
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_ACTIONS_ERROR", "Failed to fetch actions")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"actions": actions})
+}
+
+// UndoAction handles reversing a recent update or delete
+func (h *ActionHandler) UndoAction(c *gin.Context) {
+	var actionID int
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &actionID); err != nil {
+		BadRequestResponse(c, "INVALID_ACTION_ID", "Invalid action ID")
+		return
+	}
+
+	response, err := h.undoActionUseCase.Execute(c.Request.Context(), c.GetInt("user_id"), actionID)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
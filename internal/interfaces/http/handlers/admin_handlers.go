@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"panda-pocket/internal/application/identity"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminHandlers handles the back-office user management requests
+type AdminHandlers struct {
+	listUsersUseCase      *identity.ListUsersUseCase
+	getUserUsageUseCase   *identity.GetUserUsageUseCase
+	deactivateUserUseCase *identity.DeactivateUserUseCase
+}
+
+// NewAdminHandlers creates a new admin handlers instance
+func NewAdminHandlers(
+	listUsersUseCase *identity.ListUsersUseCase,
+	getUserUsageUseCase *identity.GetUserUsageUseCase,
+	deactivateUserUseCase *identity.DeactivateUserUseCase,
+) *AdminHandlers {
+	return &AdminHandlers{
+		listUsersUseCase:      listUsersUseCase,
+		getUserUsageUseCase:   getUserUsageUseCase,
+		deactivateUserUseCase: deactivateUserUseCase,
+	}
+}
+
+// ListUsers handles the paginated user listing, optionally searching by email
+func (h *AdminHandlers) ListUsers(c *gin.Context) {
+	req := identity.ListUsersRequest{Search: c.Query("search")}
+	if pageParam := c.Query("page"); pageParam != "" {
+		if page, err := strconv.Atoi(pageParam); err == nil {
+			req.Page = page
+		}
+	}
+	if limitParam := c.Query("limit"); limitParam != "" {
+		if limit, err := strconv.Atoi(limitParam); err == nil {
+			req.Limit = limit
+		}
+	}
+
+	response, err := h.listUsersUseCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_USERS_ERROR", "Failed to fetch users")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{
+		"users": response.Users,
+		"pagination": gin.H{
+			"page":        response.Page,
+			"limit":       response.Limit,
+			"total":       response.Total,
+			"total_pages": response.TotalPages,
+		},
+	})
+}
+
+// GetUserUsage handles getting one user's usage metrics
+func (h *AdminHandlers) GetUserUsage(c *gin.Context) {
+	userID, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.getUserUsageUseCase.Execute(c.Request.Context(), userID)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
+
+// DeactivateUser handles deactivating a user account
+func (h *AdminHandlers) DeactivateUser(c *gin.Context) {
+	userID, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.deactivateUserUseCase.Execute(c.Request.Context(), c.GetInt("user_id"), userID)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
+
+// ReactivateUser handles reactivating a deactivated user account
+func (h *AdminHandlers) ReactivateUser(c *gin.Context) {
+	userID, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.deactivateUserUseCase.Reactivate(c.Request.Context(), userID)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
+
+// parseUserID reads the user ID path parameter, responding with an error when it is invalid
+func parseUserID(c *gin.Context) (int, bool) {
+	var userID int
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &userID); err != nil {
+		BadRequestResponse(c, "INVALID_USER_ID", "Invalid user ID")
+		return 0, false
+	}
+	return userID, true
+}
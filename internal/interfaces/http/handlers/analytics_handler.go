@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"net/http"
+	"panda-pocket/internal/application/finance"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyticsHandler handles spending analytics beyond the period totals
+type AnalyticsHandler struct {
+	spendingPatternsUseCase   *finance.SpendingPatternsUseCase
+	spendingBenchmarksUseCase *finance.SpendingBenchmarksUseCase
+	spendingByPeriodUseCase   *finance.SpendingByPeriodUseCase
+}
+
+// NewAnalyticsHandler creates a new analytics handler instance
+func NewAnalyticsHandler(
+	spendingPatternsUseCase *finance.SpendingPatternsUseCase,
+	spendingBenchmarksUseCase *finance.SpendingBenchmarksUseCase,
+	spendingByPeriodUseCase *finance.SpendingByPeriodUseCase,
+) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		spendingPatternsUseCase:   spendingPatternsUseCase,
+		spendingBenchmarksUseCase: spendingBenchmarksUseCase,
+		spendingByPeriodUseCase:   spendingByPeriodUseCase,
+	}
+}
+
+// GetSpendingPatterns handles the current user's spending grouped by weekday and hour
+func (h *AnalyticsHandler) GetSpendingPatterns(c *gin.Context) {
+	var req finance.SpendingPatternsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	response, err := h.spendingPatternsUseCase.Execute(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
+
+// GetSpendingBenchmarks handles comparing the current user's category spending with other users'
+func (h *AnalyticsHandler) GetSpendingBenchmarks(c *gin.Context) {
+	var req finance.SpendingBenchmarksRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	response, err := h.spendingBenchmarksUseCase.Execute(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
+
+// GetSpendingByPeriod handles the current user's spending per week or month with rolling averages and a trend line
+func (h *AnalyticsHandler) GetSpendingByPeriod(c *gin.Context) {
+	var req finance.SpendingByPeriodRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	response, err := h.spendingByPeriodUseCase.Execute(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+
+	"panda-pocket/internal/infrastructure/backup"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BackupHandler lets admins trigger and list database backups.
+// Restores are deliberately only available through the backup command.
+type BackupHandler struct {
+	service *backup.Service
+}
+
+// NewBackupHandler creates a new backup handler instance
+func NewBackupHandler(service *backup.Service) *BackupHandler {
+	return &BackupHandler{service: service}
+}
+
+// CreateBackupRequest selects what to back up; without a user ID the whole database is dumped
+type CreateBackupRequest struct {
+	UserID *uint `json:"user_id"`
+}
+
+// CreateBackup dumps the database, or one user's data, to backup storage
+func (h *BackupHandler) CreateBackup(c *gin.Context) {
+	var req CreateBackupRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			BadRequestResponse(c, "INVALID_REQUEST", "Invalid backup request")
+			return
+		}
+	}
+
+	name, err := h.service.Create(c.Request.Context(), req.UserID)
+	if err != nil {
+		InternalServerErrorResponse(c, "BACKUP_ERROR", "Failed to create backup")
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, gin.H{"name": name})
+}
+
+// ListBackups returns the stored backups, oldest first
+func (h *BackupHandler) ListBackups(c *gin.Context) {
+	names, err := h.service.List(c.Request.Context())
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_BACKUPS_ERROR", "Failed to list backups")
+		return
+	}
+	if names == nil {
+		names = []string{}
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"backups": names})
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+	"panda-pocket/internal/application/finance"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BudgetCalendarHandler handles budget calendar requests
+type BudgetCalendarHandler struct {
+	budgetCalendarUseCase *finance.BudgetCalendarUseCase
+}
+
+// NewBudgetCalendarHandler creates a new budget calendar handler instance
+func NewBudgetCalendarHandler(budgetCalendarUseCase *finance.BudgetCalendarUseCase) *BudgetCalendarHandler {
+	return &BudgetCalendarHandler{
+		budgetCalendarUseCase: budgetCalendarUseCase,
+	}
+}
+
+// GetCalendar handles the current user's planned spend against actual spend for each day of a month
+func (h *BudgetCalendarHandler) GetCalendar(c *gin.Context) {
+	var req finance.BudgetCalendarRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	response, err := h.budgetCalendarUseCase.Execute(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+	"panda-pocket/internal/application/finance"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BudgetSuggestionHandler handles budget suggestion requests
+type BudgetSuggestionHandler struct {
+	budgetSuggestionsUseCase *finance.BudgetSuggestionsUseCase
+}
+
+// NewBudgetSuggestionHandler creates a new budget suggestion handler instance
+func NewBudgetSuggestionHandler(budgetSuggestionsUseCase *finance.BudgetSuggestionsUseCase) *BudgetSuggestionHandler {
+	return &BudgetSuggestionHandler{
+		budgetSuggestionsUseCase: budgetSuggestionsUseCase,
+	}
+}
+
+// GetSuggestions handles proposing budgets from the current user's spending history
+func (h *BudgetSuggestionHandler) GetSuggestions(c *gin.Context) {
+	var req finance.BudgetSuggestionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	response, err := h.budgetSuggestionsUseCase.Suggest(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
+
+// AcceptSuggestions handles creating budgets from accepted suggestions in one call,
+// or with dry_run=true listing the budgets that would be created
+func (h *BudgetSuggestionHandler) AcceptSuggestions(c *gin.Context) {
+	var req finance.AcceptBudgetSuggestionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	dryRun := c.Query("dry_run") == "true"
+	budgets, err := h.budgetSuggestionsUseCase.Accept(c.Request.Context(), c.GetInt("user_id"), req, dryRun)
+	if err != nil {
+		HandleError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if dryRun {
+		SuccessResponse(c, http.StatusOK, gin.H{"budgets": budgets, "dry_run": true})
+		return
+	}
+	SuccessResponse(c, http.StatusCreated, gin.H{"budgets": budgets})
+}
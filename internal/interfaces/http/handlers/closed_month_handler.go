@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+	"panda-pocket/internal/application/finance"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClosedMonthHandler handles closing and reopening months
+type ClosedMonthHandler struct {
+	manageClosedMonthsUseCase *finance.ManageClosedMonthsUseCase
+}
+
+// NewClosedMonthHandler creates a new closed month handler instance
+func NewClosedMonthHandler(manageClosedMonthsUseCase *finance.ManageClosedMonthsUseCase) *ClosedMonthHandler {
+	return &ClosedMonthHandler{
+		manageClosedMonthsUseCase: manageClosedMonthsUseCase,
+	}
+}
+
+// GetClosedMonths handles listing the current user's closed months
+func (h *ClosedMonthHandler) GetClosedMonths(c *gin.Context) {
+	months, err := h.manageClosedMonthsUseCase.List(c.Request.Context(), c.GetInt("user_id"))
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_CLOSED_MONTHS_ERROR", "Failed to fetch closed months")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"closed_months": months})
+}
+
+// CloseMonth handles closing a month for the current user
+func (h *ClosedMonthHandler) CloseMonth(c *gin.Context) {
+	var req finance.CloseMonthRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	month, err := h.manageClosedMonthsUseCase.Close(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, month)
+}
+
+// ReopenMonth handles reopening one of the current user's closed months
+func (h *ClosedMonthHandler) ReopenMonth(c *gin.Context) {
+	if err := h.manageClosedMonthsUseCase.Reopen(c.Request.Context(), c.GetInt("user_id"), c.Param("month")); err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"message": "Month reopened"})
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+	"panda-pocket/internal/application/finance"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultBudgetHandler handles the back-office default budget template
+type DefaultBudgetHandler struct {
+	manageDefaultBudgetsUseCase *finance.ManageDefaultBudgetsUseCase
+}
+
+// NewDefaultBudgetHandler creates a new default budget handler instance
+func NewDefaultBudgetHandler(manageDefaultBudgetsUseCase *finance.ManageDefaultBudgetsUseCase) *DefaultBudgetHandler {
+	return &DefaultBudgetHandler{
+		manageDefaultBudgetsUseCase: manageDefaultBudgetsUseCase,
+	}
+}
+
+// GetDefaultBudgets handles listing the budgets new users start with
+func (h *DefaultBudgetHandler) GetDefaultBudgets(c *gin.Context) {
+	budgets, err := h.manageDefaultBudgetsUseCase.List(c.Request.Context())
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_DEFAULT_BUDGETS_ERROR", "Failed to fetch default budgets")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"default_budgets": budgets})
+}
+
+// ReplaceDefaultBudgets handles replacing the budgets new users start with
+func (h *DefaultBudgetHandler) ReplaceDefaultBudgets(c *gin.Context) {
+	var req finance.ReplaceDefaultBudgetsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	budgets, err := h.manageDefaultBudgetsUseCase.Replace(c.Request.Context(), req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"default_budgets": budgets})
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"panda-pocket/internal/infrastructure/mail"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EmailQueueHandler lets admins inspect emails that could not be delivered and send them again
+type EmailQueueHandler struct {
+	queue *mail.Queue
+}
+
+// NewEmailQueueHandler creates a new email queue handler instance
+func NewEmailQueueHandler(queue *mail.Queue) *EmailQueueHandler {
+	return &EmailQueueHandler{queue: queue}
+}
+
+// ListDeadLetters returns one page of dead-lettered emails, newest first
+func (h *EmailQueueHandler) ListDeadLetters(c *gin.Context) {
+	page, limit := 1, 20
+	if value, err := strconv.Atoi(c.Query("page")); err == nil && value > 0 {
+		page = value
+	}
+	if value, err := strconv.Atoi(c.Query("limit")); err == nil && value > 0 && value <= 100 {
+		limit = value
+	}
+
+	emails, total, err := h.queue.DeadLetters(c.Request.Context(), limit, (page-1)*limit)
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_EMAILS_ERROR", "Failed to fetch dead-lettered emails")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{
+		"emails": emails,
+		"pagination": gin.H{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": (total + int64(limit) - 1) / int64(limit),
+		},
+	})
+}
+
+// RetryEmail puts a dead-lettered email back in the queue
+func (h *EmailQueueHandler) RetryEmail(c *gin.Context) {
+	var id uint
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &id); err != nil {
+		BadRequestResponse(c, "INVALID_EMAIL_ID", "Invalid email ID")
+		return
+	}
+
+	err := h.queue.Retry(c.Request.Context(), id)
+	switch {
+	case errors.Is(err, mail.ErrEmailNotFound):
+		NotFoundResponse(c, "EMAIL_NOT_FOUND", "Dead-lettered email not found")
+		return
+	case err != nil:
+		InternalServerErrorResponse(c, "RETRY_EMAIL_ERROR", "Failed to retry email")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"message": "Email queued for delivery"})
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+	"panda-pocket/internal/application/finance"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EmergencyFundHandler handles the emergency fund dashboard metric
+type EmergencyFundHandler struct {
+	emergencyFundUseCase *finance.EmergencyFundUseCase
+}
+
+// NewEmergencyFundHandler creates a new emergency fund handler instance
+func NewEmergencyFundHandler(emergencyFundUseCase *finance.EmergencyFundUseCase) *EmergencyFundHandler {
+	return &EmergencyFundHandler{
+		emergencyFundUseCase: emergencyFundUseCase,
+	}
+}
+
+// GetEmergencyFund handles how many months of spending the current user's emergency fund covers
+func (h *EmergencyFundHandler) GetEmergencyFund(c *gin.Context) {
+	var req finance.EmergencyFundRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	response, err := h.emergencyFundUseCase.Execute(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cachedList serves data with CachedSuccessResponse, sending ifNoneMatch when set
+func cachedList(data interface{}, ifNoneMatch string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/items", func(c *gin.Context) { CachedSuccessResponse(c, data) })
+
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCachedSuccessResponse(t *testing.T) {
+	items := []string{"coffee", "rent"}
+	first := cachedList(items, "")
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+
+	t.Run("responses carry a weak ETag of the body", func(t *testing.T) {
+		assert.Regexp(t, `^W/"[0-9a-f]{32}"$`, etag)
+		assert.Equal(t, "private, no-cache", first.Header().Get("Cache-Control"))
+		assert.JSONEq(t, `{"status":"success","data":["coffee","rent"]}`, first.Body.String())
+	})
+
+	t.Run("the same data gets the same ETag and other data another", func(t *testing.T) {
+		assert.Equal(t, etag, cachedList([]string{"coffee", "rent"}, "").Header().Get("ETag"))
+		assert.NotEqual(t, etag, cachedList([]string{"coffee"}, "").Header().Get("ETag"))
+	})
+
+	t.Run("a matching If-None-Match gets 304 without a body", func(t *testing.T) {
+		w := cachedList(items, etag)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.String())
+		assert.Equal(t, etag, w.Header().Get("ETag"))
+	})
+
+	t.Run("a changed list is sent again", func(t *testing.T) {
+		w := cachedList([]string{"coffee"}, etag)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestETagMatches(t *testing.T) {
+	const etag = `W/"abc"`
+	assert.False(t, etagMatches("", etag))
+	assert.True(t, etagMatches(`W/"abc"`, etag))
+	assert.True(t, etagMatches(`"abc"`, etag))
+	assert.True(t, etagMatches(`"xyz", W/"abc"`, etag))
+	assert.True(t, etagMatches("*", etag))
+	assert.False(t, etagMatches(`W/"xyz"`, etag))
+}
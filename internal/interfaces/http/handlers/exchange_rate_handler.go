@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+	"panda-pocket/internal/application/finance"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExchangeRateHandler handles exchange rates and the FX gain/loss report
+type ExchangeRateHandler struct {
+	manageExchangeRatesUseCase *finance.ManageExchangeRatesUseCase
+	fxGainLossUseCase          *finance.FXGainLossUseCase
+}
+
+// NewExchangeRateHandler creates a new exchange rate handler instance
+func NewExchangeRateHandler(
+	manageExchangeRatesUseCase *finance.ManageExchangeRatesUseCase,
+	fxGainLossUseCase *finance.FXGainLossUseCase,
+) *ExchangeRateHandler {
+	return &ExchangeRateHandler{
+		manageExchangeRatesUseCase: manageExchangeRatesUseCase,
+		fxGainLossUseCase:          fxGainLossUseCase,
+	}
+}
+
+// GetExchangeRates handles listing the rates recorded for the base and quote query parameters
+func (h *ExchangeRateHandler) GetExchangeRates(c *gin.Context) {
+	rates, err := h.manageExchangeRatesUseCase.List(c.Request.Context(), c.Query("base"), c.Query("quote"))
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"exchange_rates": rates})
+}
+
+// SetExchangeRate handles recording the rate of a currency pair on a date (admin only)
+func (h *ExchangeRateHandler) SetExchangeRate(c *gin.Context) {
+	var req finance.SetExchangeRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	rate, err := h.manageExchangeRatesUseCase.Set(c.Request.Context(), req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, rate)
+}
+
+// GetFXGainLossReport handles the current user's unrealized exchange gain/loss report
+func (h *ExchangeRateHandler) GetFXGainLossReport(c *gin.Context) {
+	report, err := h.fxGainLossUseCase.Execute(c.Request.Context(), c.GetInt("user_id"))
+	if err != nil {
+		InternalServerErrorResponse(c, "FX_REPORT_ERROR", "Failed to build exchange gain/loss report")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, report)
+}
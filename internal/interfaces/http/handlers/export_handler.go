@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"panda-pocket/internal/application/finance"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportHandler handles scheduled transaction exports and the export history
+type ExportHandler struct {
+	manageExportsUseCase *finance.ManageExportsUseCase
+}
+
+// NewExportHandler creates a new export handler instance
+func NewExportHandler(manageExportsUseCase *finance.ManageExportsUseCase) *ExportHandler {
+	return &ExportHandler{
+		manageExportsUseCase: manageExportsUseCase,
+	}
+}
+
+// GetSchedules handles listing the current user's export schedules
+func (h *ExportHandler) GetSchedules(c *gin.Context) {
+	schedules, err := h.manageExportsUseCase.ListSchedules(c.Request.Context(), c.GetInt("user_id"))
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_EXPORT_SCHEDULES_ERROR", "Failed to fetch export schedules")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"schedules": schedules})
+}
+
+// CreateSchedule handles scheduling a transaction export to cloud storage
+func (h *ExportHandler) CreateSchedule(c *gin.Context) {
+	var req finance.CreateExportScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	schedule, err := h.manageExportsUseCase.CreateSchedule(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		HandleError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, gin.H{"schedule": schedule})
+}
+
+// DeleteSchedule handles removing an export schedule
+func (h *ExportHandler) DeleteSchedule(c *gin.Context) {
+	var scheduleID int
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &scheduleID); err != nil {
+		BadRequestResponse(c, "INVALID_EXPORT_SCHEDULE_ID", "Invalid export schedule ID")
+		return
+	}
+
+	if err := h.manageExportsUseCase.DeleteSchedule(c.Request.Context(), c.GetInt("user_id"), scheduleID); err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"message": "Export schedule deleted successfully"})
+}
+
+// GetExports handles listing the current user's recent exports
+func (h *ExportHandler) GetExports(c *gin.Context) {
+	runs, err := h.manageExportsUseCase.ListRuns(c.Request.Context(), c.GetInt("user_id"))
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_EXPORTS_ERROR", "Failed to fetch exports")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"exports": runs})
+}
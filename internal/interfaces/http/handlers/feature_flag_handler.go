@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"panda-pocket/internal/infrastructure/featureflags"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeatureFlagHandler lets admins toggle feature flags at runtime and lets
+// clients ask which features are on for the signed-in user
+type FeatureFlagHandler struct {
+	flags *featureflags.FlagService
+}
+
+// NewFeatureFlagHandler creates a new feature flag handler instance
+func NewFeatureFlagHandler(flags *featureflags.FlagService) *FeatureFlagHandler {
+	return &FeatureFlagHandler{flags: flags}
+}
+
+// SetFeatureFlagRequest represents the request to create or replace a feature flag
+type SetFeatureFlagRequest struct {
+	Description       string `json:"description"`
+	Enabled           bool   `json:"enabled"`
+	RolloutPercentage int    `json:"rollout_percentage"`
+	UserIDs           []int  `json:"user_ids"`
+}
+
+// GetFeatures returns the keys of the features that are on for the current user
+func (h *FeatureFlagHandler) GetFeatures(c *gin.Context) {
+	keys, err := h.flags.EnabledFor(c.Request.Context(), c.GetInt("user_id"))
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_FEATURES_ERROR", "Failed to fetch features")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"features": keys})
+}
+
+// ListFeatureFlags returns every feature flag
+func (h *FeatureFlagHandler) ListFeatureFlags(c *gin.Context) {
+	flags, err := h.flags.List(c.Request.Context())
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_FEATURE_FLAGS_ERROR", "Failed to fetch feature flags")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"feature_flags": flags})
+}
+
+// SetFeatureFlag creates or replaces a feature flag
+func (h *FeatureFlagHandler) SetFeatureFlag(c *gin.Context) {
+	var req SetFeatureFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	flag, err := h.flags.Set(c.Request.Context(), featureflags.Flag{
+		Key:               c.Param("key"),
+		Description:       req.Description,
+		Enabled:           req.Enabled,
+		RolloutPercentage: req.RolloutPercentage,
+		UserIDs:           req.UserIDs,
+	})
+	switch {
+	case errors.Is(err, featureflags.ErrInvalidFlagKey), errors.Is(err, featureflags.ErrInvalidRollout):
+		ValidationErrorResponse(c, err.Error())
+		return
+	case err != nil:
+		InternalServerErrorResponse(c, "SAVE_FEATURE_FLAG_ERROR", "Failed to save feature flag")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, flag)
+}
+
+// DeleteFeatureFlag removes a feature flag, turning it off for everyone
+func (h *FeatureFlagHandler) DeleteFeatureFlag(c *gin.Context) {
+	err := h.flags.Delete(c.Request.Context(), c.Param("key"))
+	switch {
+	case errors.Is(err, featureflags.ErrFlagNotFound):
+		NotFoundResponse(c, "FEATURE_FLAG_NOT_FOUND", "Feature flag not found")
+		return
+	case err != nil:
+		InternalServerErrorResponse(c, "DELETE_FEATURE_FLAG_ERROR", "Failed to delete feature flag")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"message": "Feature flag deleted"})
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fieldTree holds the requested fields by name; a nil subtree keeps the whole value
+type fieldTree map[string]fieldTree
+
+// selectFields reduces each item of a list to the fields named in the fields
+// query parameter, such as fields=id,amount,category.name, so clients on slow
+// connections can ask for less. Nested fields are named with dots. Unknown
+// names are ignored, since optional fields are left out of items without them.
+// The list is returned unchanged when no fields are requested.
+func selectFields(c *gin.Context, items interface{}) interface{} {
+	tree := parseFields(c.Query("fields"))
+	if tree == nil {
+		return items
+	}
+
+	body, err := json.Marshal(items)
+	if err != nil {
+		return items
+	}
+	var values []interface{}
+	if err := json.Unmarshal(body, &values); err != nil {
+		return items
+	}
+
+	selected := make([]interface{}, len(values))
+	for i, value := range values {
+		selected[i] = tree.apply(value)
+	}
+	return selected
+}
+
+// parseFields builds the tree of a comma-separated field list, or nil when it is empty
+func parseFields(fields string) fieldTree {
+	var tree fieldTree
+	for _, path := range strings.Split(fields, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		if tree == nil {
+			tree = fieldTree{}
+		}
+
+		node := tree
+		names := strings.Split(path, ".")
+		for i, name := range names {
+			child, found := node[name]
+			if found && child == nil {
+				break // the whole value is already kept
+			}
+			if i == len(names)-1 {
+				node[name] = nil
+				break
+			}
+			if child == nil {
+				child = fieldTree{}
+				node[name] = child
+			}
+			node = child
+		}
+	}
+	return tree
+}
+
+// apply keeps the fields of the tree in value, descending into objects and lists of objects
+func (t fieldTree) apply(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		kept := make(map[string]interface{}, len(t))
+		for name, subtree := range t {
+			field, found := v[name]
+			if !found {
+				continue
+			}
+			if subtree == nil {
+				kept[name] = field
+			} else {
+				kept[name] = subtree.apply(field)
+			}
+		}
+		return kept
+	case []interface{}:
+		kept := make([]interface{}, len(v))
+		for i, item := range v {
+			kept[i] = t.apply(item)
+		}
+		return kept
+	default:
+		return value
+	}
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"panda-pocket/internal/application/finance"
@@ -147,7 +148,7 @@ func (h *FinanceHandlers) GetExpenses(c *gin.Context) {
 		}
 	}
 
-	SuccessResponse(c, http.StatusOK, expenses)
+	SuccessResponse(c, http.StatusOK, selectFields(c, expenses))
 }
 
 // GetIncomes handles getting incomes
@@ -168,44 +169,37 @@ func (h *FinanceHandlers) GetIncomes(c *gin.Context) {
 		}
 	}
 
-	SuccessResponse(c, http.StatusOK, incomes)
+	SuccessResponse(c, http.StatusOK, selectFields(c, incomes))
 }
 
 // GetAllTransactions handles getting all transactions with filters
 func (h *FinanceHandlers) GetAllTransactions(c *gin.Context) {
 	userID := c.GetInt("user_id")
 
-	// Parse query parameters
-	req := finance.GetAllTransactionsRequest{
-		Type:      c.Query("type"),
-		StartDate: c.Query("start_date"),
-		EndDate:   c.Query("end_date"),
-	}
-
-	// Parse category IDs from query parameter
-	if categoryIDsParam := c.Query("category_ids"); categoryIDsParam != "" {
-		req.CategoryIDs = []string{categoryIDsParam}
-	}
-
-	// Parse pagination parameters
-	if pageParam := c.Query("page"); pageParam != "" {
-		if page, err := strconv.Atoi(pageParam); err == nil {
-			req.Page = page
-		}
-	}
-	if limitParam := c.Query("limit"); limitParam != "" {
-		if limit, err := strconv.Atoi(limitParam); err == nil {
-			req.Limit = limit
-		}
+	req, ok := parseTransactionFilters(c)
+	if !ok {
+		return
 	}
-
 	response, err := h.getAllTransactionsUseCase.Execute(c.Request.Context(), userID, req)
+	if errors.Is(err, domainFinance.ErrInvalidBoundingBox) || errors.Is(err, domainFinance.ErrInvalidDateRange) {
+		HandleError(c, err, http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		InternalServerErrorResponse(c, "FETCH_TRANSACTIONS_ERROR", "Failed to fetch transactions")
 		return
 	}
 
-	SuccessResponse(c, http.StatusOK, response)
+	// The top-level pagination fields are the v100 shape, kept alongside meta for existing clients
+	CachedSuccessResponse(c, struct {
+		*finance.GetAllTransactionsResponse
+		Transactions interface{}    `json:"transactions"`
+		Meta         PaginationMeta `json:"meta"`
+	}{
+		response,
+		selectFields(c, response.Transactions),
+		paginate(c, response.Total, response.Page, response.Limit, response.TotalPages),
+	})
 }
 
 // CreateCategory handles category creation
@@ -233,14 +227,15 @@ func (h *FinanceHandlers) CreateCategory(c *gin.Context) {
 func (h *FinanceHandlers) GetCategories(c *gin.Context) {
 	userID := c.GetInt("user_id")
 	categoryType := c.Query("type") // Optional filter by type
+	includeStats := c.Query("include") == "stats"
 
-	response, err := h.getCategoriesUseCase.Execute(c.Request.Context(), userID, categoryType)
+	response, err := h.getCategoriesUseCase.Execute(c.Request.Context(), userID, categoryType, includeStats)
 	if err != nil {
 		InternalServerErrorResponse(c, "FETCH_CATEGORIES_ERROR", "Failed to fetch categories")
 		return
 	}
 
-	SuccessResponse(c, http.StatusOK, response.Categories)
+	CachedSuccessResponse(c, response.Categories)
 }
 
 // UpdateCategory handles category updates
@@ -339,6 +334,7 @@ func (h *FinanceHandlers) UpdateExpense(c *gin.Context) {
 		Amount      float64 `json:"amount" binding:"required"`
 		Description string  `json:"description" binding:"required"`
 		Date        string  `json:"date" binding:"required"`
+		Version     *int    `json:"version"` // the version read; unchecked when omitted
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -357,6 +353,7 @@ func (h *FinanceHandlers) UpdateExpense(c *gin.Context) {
 		req.Description,
 		req.Date,
 		domainFinance.TransactionTypeExpense,
+		req.Version,
 	)
 	if err != nil {
 		HandleError(c, err, http.StatusBadRequest)
@@ -373,6 +370,7 @@ func (h *FinanceHandlers) UpdateExpense(c *gin.Context) {
 			"description": transaction.Description(),
 			"date":        transaction.Date().Format("2006-01-02"),
 			"type":        "expense",
+			"version":     transaction.Version(),
 		},
 	})
 }
@@ -387,6 +385,7 @@ func (h *FinanceHandlers) UpdateIncome(c *gin.Context) {
 		Amount      float64 `json:"amount" binding:"required"`
 		Description string  `json:"description" binding:"required"`
 		Date        string  `json:"date" binding:"required"`
+		Version     *int    `json:"version"` // the version read; unchecked when omitted
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -405,6 +404,7 @@ func (h *FinanceHandlers) UpdateIncome(c *gin.Context) {
 		req.Description,
 		req.Date,
 		domainFinance.TransactionTypeIncome,
+		req.Version,
 	)
 	if err != nil {
 		HandleError(c, err, http.StatusBadRequest)
@@ -421,6 +421,7 @@ func (h *FinanceHandlers) UpdateIncome(c *gin.Context) {
 			"description": transaction.Description(),
 			"date":        transaction.Date().Format("2006-01-02"),
 			"type":        "income",
+			"version":     transaction.Version(),
 		},
 	})
 }
@@ -472,7 +473,7 @@ func (h *FinanceHandlers) GetBudgets(c *gin.Context) {
 		return
 	}
 
-	SuccessResponse(c, http.StatusOK, response.Budgets)
+	SuccessResponse(c, http.StatusOK, selectFields(c, response.Budgets))
 }
 
 // UpdateBudget handles budget updates
@@ -486,6 +487,8 @@ func (h *FinanceHandlers) UpdateBudget(c *gin.Context) {
 		Period     string  `json:"period" binding:"required"`
 		StartDate  string  `json:"start_date" binding:"required"`
 		EndDate    string  `json:"end_date" binding:"required"`
+		Prorate    *bool   `json:"prorate"` // unchanged when omitted
+		Version    *int    `json:"version"` // the version read; unchecked when omitted
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -503,6 +506,8 @@ func (h *FinanceHandlers) UpdateBudget(c *gin.Context) {
 		req.Period,
 		req.StartDate,
 		req.EndDate,
+		req.Prorate,
+		req.Version,
 	)
 	if err != nil {
 		HandleError(c, err, http.StatusBadRequest)
@@ -539,7 +544,7 @@ func (h *FinanceHandlers) GetCurrencies(c *gin.Context) {
 		return
 	}
 
-	SuccessResponse(c, http.StatusOK, response.Currencies)
+	CachedSuccessResponse(c, response.Currencies)
 }
 
 // CreateCurrency handles currency creation
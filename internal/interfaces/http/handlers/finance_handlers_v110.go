@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"panda-pocket/internal/application/finance"
+	domainFinance "panda-pocket/internal/domain/finance"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VersionMetadata describes the API version that produced a response
+type VersionMetadata struct {
+	Version  string   `json:"version"`
+	Features []string `json:"features"`
+}
+
+// FinanceHandlersV110 adds the v110 transaction endpoints on top of the v100 handlers
+type FinanceHandlersV110 struct {
+	*FinanceHandlers
+}
+
+// NewFinanceHandlersV110 creates a new v110 finance handlers instance
+func NewFinanceHandlersV110(base *FinanceHandlers) *FinanceHandlersV110 {
+	return &FinanceHandlersV110{
+		FinanceHandlers: base,
+	}
+}
+
+// GetAllTransactions handles getting transactions with pagination and version metadata
+func (h *FinanceHandlersV110) GetAllTransactions(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	req, ok := parseTransactionFilters(c)
+	if !ok {
+		return
+	}
+	response, err := h.getAllTransactionsUseCase.Execute(c.Request.Context(), userID, req)
+	if errors.Is(err, domainFinance.ErrInvalidBoundingBox) || errors.Is(err, domainFinance.ErrInvalidDateRange) {
+		HandleError(c, err, http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_TRANSACTIONS_ERROR", "Failed to fetch transactions")
+		return
+	}
+
+	// pagination is the v110 shape, kept alongside meta for existing clients
+	CachedSuccessResponse(c, gin.H{
+		"transactions": selectFields(c, response.Transactions),
+		"meta":         paginate(c, response.Total, response.Page, response.Limit, response.TotalPages),
+		"pagination": gin.H{
+			"page":        response.Page,
+			"limit":       response.Limit,
+			"total":       response.Total,
+			"total_pages": response.TotalPages,
+		},
+		"analytics": VersionMetadata{
+			Version:  "v110",
+			Features: []string{"analytics", "advanced_filtering", "pagination"},
+		},
+	})
+}
+
+// CreateTransaction handles creating an expense or income from a single endpoint
+func (h *FinanceHandlersV110) CreateTransaction(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	var req finance.CreateTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	if req.Type != "expense" && req.Type != "income" {
+		BadRequestResponse(c, "INVALID_TRANSACTION_TYPE", "Transaction type must be expense or income")
+		return
+	}
+
+	response, err := h.createTransactionUseCase.Execute(c.Request.Context(), userID, req)
+	if err != nil {
+		HandleError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, gin.H{
+		"transaction": response,
+		"analytics": VersionMetadata{
+			Version:  "v110",
+			Features: []string{"enhanced_validation", "analytics"},
+		},
+	})
+}
+
+// GetTransactionAnalytics handles getting transaction analytics for a period
+func (h *FinanceHandlersV110) GetTransactionAnalytics(c *gin.Context) {
+	userID := c.GetInt("user_id")
+	period := c.DefaultQuery("period", "monthly")
+
+	response, err := h.getAnalyticsUseCase.Execute(c.Request.Context(), userID, finance.GetAnalyticsRequest{
+		Period: period,
+	})
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_ANALYTICS_ERROR", "Failed to fetch analytics")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{
+		"analytics": gin.H{
+			"period":   period,
+			"data":     response,
+			"version":  "v110",
+			"features": []string{"detailed_analytics", "period_analysis", "trend_analysis"},
+		},
+	})
+}
+
+// parseTransactionFilters reads the transaction list query parameters. It reports
+// false, having already responded, when the pagination cursor is invalid.
+func parseTransactionFilters(c *gin.Context) (finance.GetAllTransactionsRequest, bool) {
+	req := finance.GetAllTransactionsRequest{
+		Type:      c.Query("type"),
+		StartDate: c.Query("start_date"),
+		EndDate:   c.Query("end_date"),
+		BBox:      c.Query("bbox"),
+	}
+
+	// Parse category IDs from query parameter
+	if categoryIDsParam := c.Query("category_ids"); categoryIDsParam != "" {
+		req.CategoryIDs = []string{categoryIDsParam}
+	}
+
+	// Archived transactions are only searched on request
+	if includeArchived, err := strconv.ParseBool(c.Query("include_archived")); err == nil {
+		req.IncludeArchived = includeArchived
+	}
+
+	// Parse pagination parameters
+	page, ok := parsePage(c)
+	if !ok {
+		return req, false
+	}
+	req.Page = page
+	if limitParam := c.Query("limit"); limitParam != "" {
+		if limit, err := strconv.Atoi(limitParam); err == nil {
+			req.Limit = limit
+		}
+	}
+
+	return req, true
+}
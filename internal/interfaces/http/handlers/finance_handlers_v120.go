@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"panda-pocket/internal/application/finance"
+	domainFinance "panda-pocket/internal/domain/finance"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FinanceHandlersV120 adds version metadata to list and analytics responses on top of v110
+type FinanceHandlersV120 struct {
+	*FinanceHandlersV110
+}
+
+// NewFinanceHandlersV120 creates a new v120 finance handlers instance
+func NewFinanceHandlersV120(base *FinanceHandlersV110) *FinanceHandlersV120 {
+	return &FinanceHandlersV120{
+		FinanceHandlersV110: base,
+	}
+}
+
+// GetCategories handles getting categories with version metadata
+func (h *FinanceHandlersV120) GetCategories(c *gin.Context) {
+	userID := c.GetInt("user_id")
+	categoryType := c.Query("type") // Optional filter by type
+	includeStats := c.Query("include") == "stats"
+
+	response, err := h.getCategoriesUseCase.Execute(c.Request.Context(), userID, categoryType, includeStats)
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_CATEGORIES_ERROR", "Failed to fetch categories")
+		return
+	}
+
+	CachedSuccessResponse(c, gin.H{
+		"categories": response.Categories,
+		"analytics": VersionMetadata{
+			Version:  "v120",
+			Features: []string{"analytics", "category_insights"},
+		},
+	})
+}
+
+// CreateCategory handles category creation with version metadata
+func (h *FinanceHandlersV120) CreateCategory(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	var req finance.CreateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	response, err := h.createCategoryUseCase.Execute(c.Request.Context(), userID, req)
+	if err != nil {
+		HandleError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, gin.H{
+		"category": response,
+		"analytics": VersionMetadata{
+			Version:  "v120",
+			Features: []string{"enhanced_validation", "analytics"},
+		},
+	})
+}
+
+// GetAllTransactions handles getting transactions with the meta pagination block only
+func (h *FinanceHandlersV120) GetAllTransactions(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	req, ok := parseTransactionFilters(c)
+	if !ok {
+		return
+	}
+	response, err := h.getAllTransactionsUseCase.Execute(c.Request.Context(), userID, req)
+	if errors.Is(err, domainFinance.ErrInvalidBoundingBox) || errors.Is(err, domainFinance.ErrInvalidDateRange) {
+		HandleError(c, err, http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_TRANSACTIONS_ERROR", "Failed to fetch transactions")
+		return
+	}
+
+	CachedSuccessResponse(c, gin.H{
+		"transactions": selectFields(c, response.Transactions),
+		"meta":         paginate(c, response.Total, response.Page, response.Limit, response.TotalPages),
+		"analytics": VersionMetadata{
+			Version:  "v120",
+			Features: []string{"analytics", "advanced_filtering", "pagination"},
+		},
+	})
+}
+
+// GetBudgets handles getting budgets with version metadata
+func (h *FinanceHandlersV120) GetBudgets(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	response, err := h.getBudgetsUseCase.Execute(c.Request.Context(), userID)
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_BUDGETS_ERROR", "Failed to fetch budgets")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{
+		"budgets": selectFields(c, response.Budgets),
+		"analytics": VersionMetadata{
+			Version:  "v120",
+			Features: []string{"analytics", "budget_insights"},
+		},
+	})
+}
+
+// GetCurrencies handles getting currencies with version metadata
+func (h *FinanceHandlersV120) GetCurrencies(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	response, err := h.getCurrenciesUseCase.Execute(c.Request.Context(), domainFinance.NewUserID(userID))
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_CURRENCIES_ERROR", "Failed to fetch currencies")
+		return
+	}
+
+	CachedSuccessResponse(c, gin.H{
+		"currencies": response.Currencies,
+		"analytics": VersionMetadata{
+			Version:  "v120",
+			Features: []string{"analytics", "currency_insights"},
+		},
+	})
+}
+
+// GetAnalytics handles getting analytics with version metadata
+func (h *FinanceHandlersV120) GetAnalytics(c *gin.Context) {
+	userID := c.GetInt("user_id")
+	period := c.DefaultQuery("period", "monthly")
+
+	response, err := h.getAnalyticsUseCase.Execute(c.Request.Context(), userID, finance.GetAnalyticsRequest{
+		Period: period,
+	})
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_ANALYTICS_ERROR", "Failed to fetch analytics")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{
+		"analytics": response,
+		"version":   "v120",
+		"features":  []string{"detailed_analytics", "period_analysis", "trend_analysis", "export_functionality"},
+	})
+}
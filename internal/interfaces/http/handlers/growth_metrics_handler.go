@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"panda-pocket/internal/application/identity"
+	domainIdentity "panda-pocket/internal/domain/identity"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GrowthMetricsHandler handles the back-office growth reports
+type GrowthMetricsHandler struct {
+	getGrowthMetricsUseCase *identity.GetGrowthMetricsUseCase
+}
+
+// NewGrowthMetricsHandler creates a new growth metrics handler instance
+func NewGrowthMetricsHandler(getGrowthMetricsUseCase *identity.GetGrowthMetricsUseCase) *GrowthMetricsHandler {
+	return &GrowthMetricsHandler{
+		getGrowthMetricsUseCase: getGrowthMetricsUseCase,
+	}
+}
+
+// GetGrowth handles getting the daily signups, active users and transactions
+// between the from and to query parameters
+func (h *GrowthMetricsHandler) GetGrowth(c *gin.Context) {
+	response, err := h.getGrowthMetricsUseCase.Growth(c.Request.Context(), identity.GrowthMetricsRequest{
+		From: c.Query("from"),
+		To:   c.Query("to"),
+	})
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
+
+// GetRetention handles getting the retention of the signup cohorts of the last
+// months, as many as the months query parameter asks for
+func (h *GrowthMetricsHandler) GetRetention(c *gin.Context) {
+	months := 0
+	if param := c.Query("months"); param != "" {
+		parsed, err := strconv.Atoi(param)
+		if err != nil {
+			HandleError(c, domainIdentity.ErrInvalidRetentionMonths, http.StatusBadRequest)
+			return
+		}
+		months = parsed
+	}
+
+	response, err := h.getGrowthMetricsUseCase.Retention(c.Request.Context(), months)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthCheck reports the health of a single dependency (database, cache, rate provider, ...)
+type HealthCheck interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+// ComponentStatus represents the health of a single component in a readiness response
+type ComponentStatus struct {
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// HealthHandlers handles liveness and readiness probes
+type HealthHandlers struct {
+	checks  []HealthCheck
+	timeout time.Duration
+}
+
+// NewHealthHandlers creates a new health handlers instance
+func NewHealthHandlers(checks ...HealthCheck) *HealthHandlers {
+	return &HealthHandlers{
+		checks:  checks,
+		timeout: 2 * time.Second,
+	}
+}
+
+// Live reports that the process is up and able to serve requests
+func (h *HealthHandlers) Live(c *gin.Context) {
+	SuccessResponse(c, http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Ready verifies every registered dependency and reports component-level status
+func (h *HealthHandlers) Ready(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	components := make(map[string]ComponentStatus, len(h.checks))
+	ready := true
+	for _, check := range h.checks {
+		start := time.Now()
+		err := check.Check(ctx)
+		status := ComponentStatus{
+			Status:    "ok",
+			LatencyMs: time.Since(start).Milliseconds(),
+		}
+		if err != nil {
+			ready = false
+			status.Status = "unavailable"
+			status.Error = err.Error()
+		}
+		components[check.Name()] = status
+	}
+
+	if !ready {
+		c.JSON(http.StatusServiceUnavailable, APIResponse{
+			Status: "error",
+			Data: gin.H{
+				"status":     "unavailable",
+				"components": components,
+			},
+			Error: &ErrorResponse{
+				ErrorCode:    "SERVICE_UNAVAILABLE",
+				ErrorMessage: "One or more dependencies are unavailable",
+			},
+		})
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{
+		"status":     "ok",
+		"components": components,
+	})
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubCheck is a health check returning a fixed error, or waiting for the
+// probe's deadline when block is set
+type stubCheck struct {
+	name  string
+	err   error
+	block bool
+}
+
+func (c stubCheck) Name() string { return c.name }
+
+func (c stubCheck) Check(ctx context.Context) error {
+	if c.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return c.err
+}
+
+// probe serves one request to the health handlers
+func probe(h *HealthHandlers, handler func(*HealthHandlers, *gin.Context)) (*httptest.ResponseRecorder, map[string]interface{}) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/probe", func(c *gin.Context) { handler(h, c) })
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/probe", nil))
+	var body map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &body)
+	return w, body
+}
+
+func TestHealthHandlers(t *testing.T) {
+	t.Run("liveness does not run the checks", func(t *testing.T) {
+		h := NewHealthHandlers(stubCheck{name: "database", err: errors.New("connection refused")})
+		w, _ := probe(h, (*HealthHandlers).Live)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("ready when every check passes", func(t *testing.T) {
+		h := NewHealthHandlers(stubCheck{name: "database"}, stubCheck{name: "cache"})
+		w, body := probe(h, (*HealthHandlers).Ready)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		data := body["data"].(map[string]interface{})
+		assert.Equal(t, "ok", data["status"])
+		components := data["components"].(map[string]interface{})
+		assert.Equal(t, "ok", components["database"].(map[string]interface{})["status"])
+		assert.Equal(t, "ok", components["cache"].(map[string]interface{})["status"])
+	})
+
+	t.Run("not ready when a check fails", func(t *testing.T) {
+		h := NewHealthHandlers(stubCheck{name: "database", err: errors.New("connection refused")}, stubCheck{name: "cache"})
+		w, body := probe(h, (*HealthHandlers).Ready)
+
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, "SERVICE_UNAVAILABLE", body["error"].(map[string]interface{})["error_code"])
+		components := body["data"].(map[string]interface{})["components"].(map[string]interface{})
+		database := components["database"].(map[string]interface{})
+		assert.Equal(t, "unavailable", database["status"])
+		assert.Equal(t, "connection refused", database["error"])
+		assert.Equal(t, "ok", components["cache"].(map[string]interface{})["status"])
+	})
+
+	t.Run("checks that hang time out", func(t *testing.T) {
+		h := NewHealthHandlers(stubCheck{name: "database", block: true})
+		h.timeout = 10 * time.Millisecond
+		w, _ := probe(h, (*HealthHandlers).Ready)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
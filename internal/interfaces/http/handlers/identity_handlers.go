@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"panda-pocket/internal/application/identity"
 	"strings"
@@ -11,9 +12,11 @@ import (
 
 // IdentityHandlers handles identity-related HTTP requests
 type IdentityHandlers struct {
-	registerUserUseCase *identity.RegisterUserUseCase
-	loginUserUseCase    *identity.LoginUserUseCase
-	getUsersUseCase     *identity.GetUsersUseCase
+	registerUserUseCase   *identity.RegisterUserUseCase
+	loginUserUseCase      *identity.LoginUserUseCase
+	getUsersUseCase       *identity.GetUsersUseCase
+	forgotPasswordUseCase *identity.ForgotPasswordUseCase
+	resetPasswordUseCase  *identity.ResetPasswordUseCase
 }
 
 // NewIdentityHandlers creates a new identity handlers instance
@@ -21,16 +24,24 @@ func NewIdentityHandlers(
 	registerUserUseCase *identity.RegisterUserUseCase,
 	loginUserUseCase *identity.LoginUserUseCase,
 	getUsersUseCase *identity.GetUsersUseCase,
+	forgotPasswordUseCase *identity.ForgotPasswordUseCase,
+	resetPasswordUseCase *identity.ResetPasswordUseCase,
 ) *IdentityHandlers {
 	return &IdentityHandlers{
-		registerUserUseCase: registerUserUseCase,
-		loginUserUseCase:    loginUserUseCase,
-		getUsersUseCase:     getUsersUseCase,
+		registerUserUseCase:   registerUserUseCase,
+		loginUserUseCase:      loginUserUseCase,
+		getUsersUseCase:       getUsersUseCase,
+		forgotPasswordUseCase: forgotPasswordUseCase,
+		resetPasswordUseCase:  resetPasswordUseCase,
 	}
 }
 
 // formatValidationError converts technical validation errors to user-friendly messages
 func formatValidationError(err error) string {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return "Request body is too large"
+	}
 	if validationErrs, ok := err.(validator.ValidationErrors); ok {
 		var messages []string
 		for _, e := range validationErrs {
@@ -101,6 +112,43 @@ func (h *IdentityHandlers) Login(c *gin.Context) {
 	})
 }
 
+// ForgotPassword handles requests for a password reset email. The response is the
+// same whether or not the email belongs to an account.
+func (h *IdentityHandlers) ForgotPassword(c *gin.Context) {
+	var req identity.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	if err := h.forgotPasswordUseCase.Execute(c.Request.Context(), req); err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{
+		"message": "If an account exists for this email, a password reset link has been sent",
+	})
+}
+
+// ResetPassword handles choosing a new password with a reset token
+func (h *IdentityHandlers) ResetPassword(c *gin.Context) {
+	var req identity.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	if err := h.resetPasswordUseCase.Execute(c.Request.Context(), req); err != nil {
+		HandleError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{
+		"message": "Your password has been reset",
+	})
+}
+
 // GetUsers handles getting all users
 func (h *IdentityHandlers) GetUsers(c *gin.Context) {
 	response, err := h.getUsersUseCase.Execute(c.Request.Context())
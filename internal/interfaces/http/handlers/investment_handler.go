@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"panda-pocket/internal/application/finance"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InvestmentHandler handles investment holdings and the net worth report
+type InvestmentHandler struct {
+	manageHoldingsUseCase *finance.ManageHoldingsUseCase
+	netWorthUseCase       *finance.NetWorthUseCase
+}
+
+// NewInvestmentHandler creates a new investment handler instance
+func NewInvestmentHandler(
+	manageHoldingsUseCase *finance.ManageHoldingsUseCase,
+	netWorthUseCase *finance.NetWorthUseCase,
+) *InvestmentHandler {
+	return &InvestmentHandler{
+		manageHoldingsUseCase: manageHoldingsUseCase,
+		netWorthUseCase:       netWorthUseCase,
+	}
+}
+
+// GetHoldings handles listing the current user's holdings with their latest values
+func (h *InvestmentHandler) GetHoldings(c *gin.Context) {
+	holdings, err := h.manageHoldingsUseCase.List(c.Request.Context(), c.GetInt("user_id"))
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_HOLDINGS_ERROR", "Failed to fetch holdings")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"holdings": holdings})
+}
+
+// CreateHolding handles recording a holding
+func (h *InvestmentHandler) CreateHolding(c *gin.Context) {
+	var req finance.CreateHoldingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	holding, err := h.manageHoldingsUseCase.Create(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		HandleError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, gin.H{"holding": holding})
+}
+
+// UpdateHolding handles changing a holding's ticker, quantity or cost basis
+func (h *InvestmentHandler) UpdateHolding(c *gin.Context) {
+	holdingID, ok := parseHoldingID(c)
+	if !ok {
+		return
+	}
+
+	var req finance.UpdateHoldingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	holding, err := h.manageHoldingsUseCase.Update(c.Request.Context(), c.GetInt("user_id"), holdingID, req)
+	if err != nil {
+		HandleError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"holding": holding})
+}
+
+// DeleteHolding handles removing a holding and its price history
+func (h *InvestmentHandler) DeleteHolding(c *gin.Context) {
+	holdingID, ok := parseHoldingID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.manageHoldingsUseCase.Delete(c.Request.Context(), c.GetInt("user_id"), holdingID); err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"message": "Holding deleted successfully"})
+}
+
+// GetHoldingPrices handles listing a holding's values over time
+func (h *InvestmentHandler) GetHoldingPrices(c *gin.Context) {
+	holdingID, ok := parseHoldingID(c)
+	if !ok {
+		return
+	}
+
+	prices, err := h.manageHoldingsUseCase.ListPrices(c.Request.Context(), c.GetInt("user_id"), holdingID)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"prices": prices})
+}
+
+// RecordHoldingPrice handles recording the price of a holding's security on a day
+func (h *InvestmentHandler) RecordHoldingPrice(c *gin.Context) {
+	holdingID, ok := parseHoldingID(c)
+	if !ok {
+		return
+	}
+
+	var req finance.RecordHoldingPriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	price, err := h.manageHoldingsUseCase.RecordPrice(c.Request.Context(), c.GetInt("user_id"), holdingID, req)
+	if err != nil {
+		HandleError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, gin.H{"price": price})
+}
+
+// GetNetWorthReport handles the current user's net worth on a day
+func (h *InvestmentHandler) GetNetWorthReport(c *gin.Context) {
+	var req finance.NetWorthRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	report, err := h.netWorthUseCase.Execute(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		HandleError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, report)
+}
+
+// parseHoldingID reads the holding ID path parameter, responding with an error when it is invalid
+func parseHoldingID(c *gin.Context) (int, bool) {
+	var holdingID int
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &holdingID); err != nil {
+		BadRequestResponse(c, "INVALID_HOLDING_ID", "Invalid holding ID")
+		return 0, false
+	}
+	return holdingID, true
+}
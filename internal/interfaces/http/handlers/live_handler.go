@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"panda-pocket/internal/infrastructure/realtime"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+// maxLiveMessageBytes is the largest message a client may send on a live connection
+const maxLiveMessageBytes = 4096
+
+// liveRequest is a message a client sends on a live connection
+type liveRequest struct {
+	Type   string   `json:"type"` // subscribe, unsubscribe or ping
+	Topics []string `json:"topics"`
+}
+
+// LiveHandler handles the WebSocket connections dashboards receive pushes on
+type LiveHandler struct {
+	hub *realtime.Hub
+}
+
+// NewLiveHandler creates a new live handler instance
+func NewLiveHandler(hub *realtime.Hub) *LiveHandler {
+	return &LiveHandler{
+		hub: hub,
+	}
+}
+
+// Connect upgrades the request to a WebSocket connection for the signed-in user.
+// Nothing is pushed until the client subscribes to a topic.
+func (h *LiveHandler) Connect(c *gin.Context) {
+	userID := c.GetInt("user_id")
+	server := websocket.Server{Handler: func(ws *websocket.Conn) {
+		ws.MaxPayloadBytes = maxLiveMessageBytes
+		h.serve(ws, userID)
+	}}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// serve answers the client's messages and sends its pushes until either side
+// closes the connection
+func (h *LiveHandler) serve(ws *websocket.Conn, userID int) {
+	subscriber := h.hub.Connect(userID)
+	defer h.hub.Disconnect(subscriber)
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			var message []byte
+			err := websocket.Message.Receive(ws, &message)
+			if err != nil && !errors.Is(err, websocket.ErrFrameTooLarge) {
+				return
+			}
+
+			var request liveRequest
+			reply := liveError("INVALID_MESSAGE", "Messages must be JSON objects of at most 4 KB")
+			if err == nil && json.Unmarshal(message, &request) == nil {
+				reply = h.answer(subscriber, request)
+			}
+			if err := websocket.JSON.Send(ws, reply); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-closed:
+			ws.Close()
+			return
+		case push := <-subscriber.Pushes():
+			if err := websocket.JSON.Send(ws, push); err != nil {
+				ws.Close()
+				<-closed
+				return
+			}
+		}
+	}
+}
+
+// answer applies a client message to the connection's subscriptions. Changes
+// are answered with every topic the connection is now subscribed to.
+func (h *LiveHandler) answer(subscriber *realtime.Subscriber, request liveRequest) gin.H {
+	switch request.Type {
+	case "subscribe":
+		if err := subscriber.Subscribe(request.Topics...); err != nil {
+			return liveError("UNKNOWN_TOPIC", "Topics must be transactions, budgets or notifications")
+		}
+		return gin.H{"type": "subscribed", "topics": subscriber.Topics()}
+	case "unsubscribe":
+		subscriber.Unsubscribe(request.Topics...)
+		return gin.H{"type": "subscribed", "topics": subscriber.Topics()}
+	case "ping":
+		return gin.H{"type": "pong"}
+	default:
+		return liveError("UNKNOWN_MESSAGE_TYPE", "Message type must be subscribe, unsubscribe or ping")
+	}
+}
+
+// liveError is the reply to a message the connection could not act on
+func liveError(code, message string) gin.H {
+	return gin.H{"type": "error", "code": code, "message": message}
+}
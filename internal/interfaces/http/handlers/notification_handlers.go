@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"panda-pocket/internal/application/notification"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationHandlers handles notification-related HTTP requests
+type NotificationHandlers struct {
+	getNotificationsUseCase      *notification.GetNotificationsUseCase
+	markNotificationReadUseCase  *notification.MarkNotificationReadUseCase
+	broadcastAnnouncementUseCase *notification.BroadcastAnnouncementUseCase
+	manageChannelsUseCase        *notification.ManageChannelsUseCase
+}
+
+// NewNotificationHandlers creates a new notification handlers instance
+func NewNotificationHandlers(
+	getNotificationsUseCase *notification.GetNotificationsUseCase,
+	markNotificationReadUseCase *notification.MarkNotificationReadUseCase,
+	broadcastAnnouncementUseCase *notification.BroadcastAnnouncementUseCase,
+	manageChannelsUseCase *notification.ManageChannelsUseCase,
+) *NotificationHandlers {
+	return &NotificationHandlers{
+		getNotificationsUseCase:      getNotificationsUseCase,
+		markNotificationReadUseCase:  markNotificationReadUseCase,
+		broadcastAnnouncementUseCase: broadcastAnnouncementUseCase,
+		manageChannelsUseCase:        manageChannelsUseCase,
+	}
+}
+
+// GetNotifications handles getting the current user's notifications
+func (h *NotificationHandlers) GetNotifications(c *gin.Context) {
+	userID := c.GetInt("user_id")
+	unreadOnly := c.Query("unread") == "true"
+
+	response, err := h.getNotificationsUseCase.Execute(c.Request.Context(), userID, unreadOnly)
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_NOTIFICATIONS_ERROR", "Failed to fetch notifications")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
+
+// MarkNotificationRead handles marking one notification as read
+func (h *NotificationHandlers) MarkNotificationRead(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	var notificationID int
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &notificationID); err != nil {
+		BadRequestResponse(c, "INVALID_NOTIFICATION_ID", "Invalid notification ID")
+		return
+	}
+
+	if err := h.markNotificationReadUseCase.Execute(c.Request.Context(), userID, notificationID); err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"message": "Notification marked as read"})
+}
+
+// MarkAllNotificationsRead handles marking all of the current user's notifications as read
+func (h *NotificationHandlers) MarkAllNotificationsRead(c *gin.Context) {
+	if err := h.markNotificationReadUseCase.MarkAll(c.Request.Context(), c.GetInt("user_id")); err != nil {
+		InternalServerErrorResponse(c, "UPDATE_NOTIFICATIONS_ERROR", "Failed to mark notifications as read")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"message": "All notifications marked as read"})
+}
+
+// BroadcastAnnouncement handles admins sending an announcement to all users or a segment of them
+func (h *NotificationHandlers) BroadcastAnnouncement(c *gin.Context) {
+	var req notification.BroadcastAnnouncementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	response, err := h.broadcastAnnouncementUseCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, response)
+}
+
+// GetChannels handles listing the current user's Slack and Discord channels
+func (h *NotificationHandlers) GetChannels(c *gin.Context) {
+	channels, err := h.manageChannelsUseCase.List(c.Request.Context(), c.GetInt("user_id"))
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_CHANNELS_ERROR", "Failed to fetch notification channels")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"channels": channels})
+}
+
+// CreateChannel handles adding a Slack or Discord channel for the current user
+func (h *NotificationHandlers) CreateChannel(c *gin.Context) {
+	var req notification.CreateChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	response, err := h.manageChannelsUseCase.Create(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, response)
+}
+
+// DeleteChannel handles removing one of the current user's channels
+func (h *NotificationHandlers) DeleteChannel(c *gin.Context) {
+	var channelID int
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &channelID); err != nil {
+		BadRequestResponse(c, "INVALID_CHANNEL_ID", "Invalid channel ID")
+		return
+	}
+
+	if err := h.manageChannelsUseCase.Delete(c.Request.Context(), c.GetInt("user_id"), channelID); err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"message": "Notification channel deleted"})
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+	"panda-pocket/internal/application/finance"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OnboardingHandler handles setting up a new user's account
+type OnboardingHandler struct {
+	onboardingUseCase *finance.OnboardingUseCase
+}
+
+// NewOnboardingHandler creates a new onboarding handler instance
+func NewOnboardingHandler(onboardingUseCase *finance.OnboardingUseCase) *OnboardingHandler {
+	return &OnboardingHandler{
+		onboardingUseCase: onboardingUseCase,
+	}
+}
+
+// CompleteOnboarding handles applying the current user's onboarding choices in one request
+func (h *OnboardingHandler) CompleteOnboarding(c *gin.Context) {
+	var req finance.OnboardingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	response, err := h.onboardingUseCase.Complete(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PaginationMeta is the pagination block of list responses. NextCursor is an
+// opaque token for the following page, passed back as the cursor query
+// parameter; it is null on the last page.
+type PaginationMeta struct {
+	Total      int64   `json:"total"`
+	Page       int     `json:"page"`
+	Limit      int     `json:"limit"`
+	TotalPages int     `json:"total_pages"`
+	NextCursor *string `json:"next_cursor"`
+}
+
+// cursorPrefix marks a cursor's payload so arbitrary base64 is not taken for one
+const cursorPrefix = "page:"
+
+// parsePage reads the page requested by the cursor query parameter, falling back
+// to the page parameter. It returns 0 when neither is given and false, after
+// responding with INVALID_CURSOR, when the cursor cannot be decoded.
+func parsePage(c *gin.Context) (int, bool) {
+	if cursor := c.Query("cursor"); cursor != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(cursor)
+		page, ok := 0, err == nil && strings.HasPrefix(string(raw), cursorPrefix)
+		if ok {
+			page, err = strconv.Atoi(strings.TrimPrefix(string(raw), cursorPrefix))
+			ok = err == nil && page > 0
+		}
+		if !ok {
+			BadRequestResponse(c, "INVALID_CURSOR", "Invalid pagination cursor")
+			return 0, false
+		}
+		return page, true
+	}
+
+	page, _ := strconv.Atoi(c.Query("page"))
+	return page, true
+}
+
+// paginate returns the pagination block for a page of a list and sets RFC 5988
+// Link headers to the first, previous, next and last pages. The links repeat the
+// request's query with only the page changed.
+func paginate(c *gin.Context, total int64, page, limit, totalPages int) PaginationMeta {
+	meta := PaginationMeta{
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: totalPages,
+	}
+
+	links := []string{pageLink(c.Request, 1, "first")}
+	if page > 1 {
+		links = append(links, pageLink(c.Request, min(page-1, totalPages), "prev"))
+	}
+	if page < totalPages {
+		cursor := base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(page+1)))
+		meta.NextCursor = &cursor
+		links = append(links, pageLink(c.Request, page+1, "next"))
+	}
+	links = append(links, pageLink(c.Request, totalPages, "last"))
+	c.Header("Link", strings.Join(links, ", "))
+
+	return meta
+}
+
+// pageLink formats a Link header entry for a page of the requested list
+func pageLink(r *http.Request, page int, rel string) string {
+	query := r.URL.Query()
+	query.Del("cursor")
+	query.Set("page", strconv.Itoa(page))
+	return fmt.Sprintf(`<%s?%s>; rel="%s"`, r.URL.Path, query.Encode(), rel)
+}
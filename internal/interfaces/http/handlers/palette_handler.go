@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"panda-pocket/internal/application/finance"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PaletteHandler handles the colors recommended for categories
+type PaletteHandler struct {
+	getPaletteUseCase *finance.GetPaletteUseCase
+}
+
+// NewPaletteHandler creates a new palette handler instance
+func NewPaletteHandler(getPaletteUseCase *finance.GetPaletteUseCase) *PaletteHandler {
+	return &PaletteHandler{
+		getPaletteUseCase: getPaletteUseCase,
+	}
+}
+
+// GetPalette handles getting the recommended category colors
+func (h *PaletteHandler) GetPalette(c *gin.Context) {
+	CachedSuccessResponse(c, h.getPaletteUseCase.Execute())
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+	"panda-pocket/internal/application/identity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PreferencesHandler handles the current user's preferences
+type PreferencesHandler struct {
+	managePreferencesUseCase *identity.ManagePreferencesUseCase
+}
+
+// NewPreferencesHandler creates a new preferences handler instance
+func NewPreferencesHandler(managePreferencesUseCase *identity.ManagePreferencesUseCase) *PreferencesHandler {
+	return &PreferencesHandler{
+		managePreferencesUseCase: managePreferencesUseCase,
+	}
+}
+
+// GetPreferences handles getting the current user's preferences
+func (h *PreferencesHandler) GetPreferences(c *gin.Context) {
+	preferences, err := h.managePreferencesUseCase.Get(c.Request.Context(), c.GetInt("user_id"))
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_PREFERENCES_ERROR", "Failed to fetch preferences")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, preferences)
+}
+
+// UpdatePreferences handles changing some of the current user's preferences
+func (h *PreferencesHandler) UpdatePreferences(c *gin.Context) {
+	var req identity.UpdatePreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	preferences, err := h.managePreferencesUseCase.Update(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, preferences)
+}
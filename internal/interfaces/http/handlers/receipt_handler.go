@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"panda-pocket/internal/application/finance"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReceiptHandler handles the line items of expense receipts
+type ReceiptHandler struct {
+	lineItemsUseCase *finance.ReceiptLineItemsUseCase
+}
+
+// NewReceiptHandler creates a new receipt handler instance
+func NewReceiptHandler(lineItemsUseCase *finance.ReceiptLineItemsUseCase) *ReceiptHandler {
+	return &ReceiptHandler{
+		lineItemsUseCase: lineItemsUseCase,
+	}
+}
+
+// GetLineItems handles listing an expense's receipt line items
+func (h *ReceiptHandler) GetLineItems(c *gin.Context) {
+	var expenseID int
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &expenseID); err != nil {
+		BadRequestResponse(c, "INVALID_TRANSACTION_ID", "Invalid transaction ID")
+		return
+	}
+
+	response, err := h.lineItemsUseCase.Get(c.Request.Context(), c.GetInt("user_id"), expenseID)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
+
+// ReplaceLineItems handles replacing an expense's receipt line items
+func (h *ReceiptHandler) ReplaceLineItems(c *gin.Context) {
+	var expenseID int
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &expenseID); err != nil {
+		BadRequestResponse(c, "INVALID_TRANSACTION_ID", "Invalid transaction ID")
+		return
+	}
+
+	var req finance.ReplaceLineItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	response, err := h.lineItemsUseCase.Replace(c.Request.Context(), c.GetInt("user_id"), expenseID, req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
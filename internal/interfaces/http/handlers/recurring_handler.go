@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"panda-pocket/internal/application/finance"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RecurringHandler handles recurring transaction requests
+type RecurringHandler struct {
+	manageRecurringUseCase *finance.ManageRecurringTransactionsUseCase
+}
+
+// NewRecurringHandler creates a new recurring handler instance
+func NewRecurringHandler(manageRecurringUseCase *finance.ManageRecurringTransactionsUseCase) *RecurringHandler {
+	return &RecurringHandler{
+		manageRecurringUseCase: manageRecurringUseCase,
+	}
+}
+
+// MakeRecurring handles creating a recurring transaction from an existing transaction
+func (h *RecurringHandler) MakeRecurring(c *gin.Context) {
+	var transactionID int
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &transactionID); err != nil {
+		BadRequestResponse(c, "INVALID_TRANSACTION_ID", "Invalid transaction ID")
+		return
+	}
+
+	var req finance.MakeRecurringRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	response, err := h.manageRecurringUseCase.MakeRecurring(c.Request.Context(), c.GetInt("user_id"), transactionID, req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, response)
+}
+
+// GetOccurrences handles previewing the upcoming postings of a recurring transaction
+func (h *RecurringHandler) GetOccurrences(c *gin.Context) {
+	recurringID, ok := parseRecurringID(c)
+	if !ok {
+		return
+	}
+
+	var req finance.RecurringOccurrencesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	response, err := h.manageRecurringUseCase.Occurrences(c.Request.Context(), c.GetInt("user_id"), recurringID, req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
+
+// SkipNextOccurrence handles skipping the next posting of a recurring transaction
+func (h *RecurringHandler) SkipNextOccurrence(c *gin.Context) {
+	recurringID, ok := parseRecurringID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.manageRecurringUseCase.SkipNextOccurrence(c.Request.Context(), c.GetInt("user_id"), recurringID)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
+
+// OverrideNextOccurrence handles changing the amount or date of the next posting one time
+func (h *RecurringHandler) OverrideNextOccurrence(c *gin.Context) {
+	recurringID, ok := parseRecurringID(c)
+	if !ok {
+		return
+	}
+
+	var req finance.OverrideOccurrenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	response, err := h.manageRecurringUseCase.OverrideNextOccurrence(c.Request.Context(), c.GetInt("user_id"), recurringID, req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
+
+// ClearNextOccurrenceOverride handles restoring the scheduled amount and date of the next posting
+func (h *RecurringHandler) ClearNextOccurrenceOverride(c *gin.Context) {
+	recurringID, ok := parseRecurringID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.manageRecurringUseCase.ClearNextOccurrenceOverride(c.Request.Context(), c.GetInt("user_id"), recurringID)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
+
+// UpdateEndConditions handles setting when a recurring transaction stops posting
+func (h *RecurringHandler) UpdateEndConditions(c *gin.Context) {
+	recurringID, ok := parseRecurringID(c)
+	if !ok {
+		return
+	}
+
+	var req finance.UpdateEndConditionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	response, err := h.manageRecurringUseCase.UpdateEndConditions(c.Request.Context(), c.GetInt("user_id"), recurringID, req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
+
+// parseRecurringID reads the recurring transaction ID from the path, responding with an error when it is invalid
+func parseRecurringID(c *gin.Context) (int, bool) {
+	var recurringID int
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &recurringID); err != nil {
+		BadRequestResponse(c, "INVALID_RECURRING_TRANSACTION_ID", "Invalid recurring transaction ID")
+		return 0, false
+	}
+	return recurringID, true
+}
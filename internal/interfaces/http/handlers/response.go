@@ -1,7 +1,12 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	domainFinance "panda-pocket/internal/domain/finance"
+	domainIdentity "panda-pocket/internal/domain/identity"
+	domainNotification "panda-pocket/internal/domain/notification"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -29,8 +34,14 @@ func SuccessResponse(c *gin.Context, statusCode int, data interface{}) {
 	})
 }
 
-// SendErrorResponse sends an error API response
+// SendErrorResponse sends an error API response. Server errors of requests whose
+// deadline has passed are sent as 504 REQUEST_TIMEOUT, as they are almost always
+// caused by the cancelled queries.
 func SendErrorResponse(c *gin.Context, statusCode int, errorCode string, errorMessage string) {
+	if statusCode >= http.StatusInternalServerError && statusCode != http.StatusGatewayTimeout && deadlineExceeded(c) {
+		RequestTimeoutResponse(c)
+		return
+	}
 	c.JSON(statusCode, APIResponse{
 		Status: "error",
 		Data:   nil,
@@ -66,12 +77,156 @@ func InternalServerErrorResponse(c *gin.Context, errorCode string, errorMessage
 	SendErrorResponse(c, http.StatusInternalServerError, errorCode, errorMessage)
 }
 
+// RequestTimeoutResponse sends a 504 Gateway Timeout for requests that ran past their deadline
+func RequestTimeoutResponse(c *gin.Context) {
+	SendErrorResponse(c, http.StatusGatewayTimeout, "REQUEST_TIMEOUT", "The request took too long to complete")
+}
+
+// deadlineExceeded reports whether the request ran past its deadline
+func deadlineExceeded(c *gin.Context) bool {
+	return errors.Is(c.Request.Context().Err(), context.DeadlineExceeded)
+}
+
 // ValidationErrorResponse sends a 400 Bad Request for validation errors
 func ValidationErrorResponse(c *gin.Context, errorMessage string) {
 	BadRequestResponse(c, "VALIDATION_ERROR", errorMessage)
 }
 
-// getErrorCodeFromMessage maps error messages to standardized error codes
+// domainErrorMapping maps a typed domain error to an error code and HTTP status code
+type domainErrorMapping struct {
+	err        error
+	errorCode  string
+	statusCode int
+}
+
+// domainErrorMappings lists the typed domain errors understood by HandleError
+var domainErrorMappings = []domainErrorMapping{
+	// Finance - not found
+	{domainFinance.ErrTransactionNotFound, "TRANSACTION_NOT_FOUND", http.StatusNotFound},
+	{domainFinance.ErrCategoryNotFound, "CATEGORY_NOT_FOUND", http.StatusNotFound},
+	{domainFinance.ErrCurrencyNotFound, "CURRENCY_NOT_FOUND", http.StatusNotFound},
+	{domainFinance.ErrBudgetNotFound, "BUDGET_NOT_FOUND", http.StatusNotFound},
+	{domainFinance.ErrNoDefaultCurrency, "CURRENCY_NOT_FOUND", http.StatusNotFound},
+	{domainFinance.ErrActionNotFound, "ACTION_NOT_FOUND", http.StatusNotFound},
+	{domainFinance.ErrAccountNotFound, "ACCOUNT_NOT_FOUND", http.StatusNotFound},
+	{domainFinance.ErrBalanceAssertionNotFound, "BALANCE_ASSERTION_NOT_FOUND", http.StatusNotFound},
+	{domainFinance.ErrBalanceAdjustmentNotFound, "BALANCE_ADJUSTMENT_NOT_FOUND", http.StatusNotFound},
+	{domainFinance.ErrExportScheduleNotFound, "EXPORT_SCHEDULE_NOT_FOUND", http.StatusNotFound},
+	{domainFinance.ErrRecurringTransactionNotFound, "RECURRING_TRANSACTION_NOT_FOUND", http.StatusNotFound},
+	{domainFinance.ErrScheduledTransactionNotFound, "SCHEDULED_TRANSACTION_NOT_FOUND", http.StatusNotFound},
+	{domainFinance.ErrClosedMonthNotFound, "CLOSED_MONTH_NOT_FOUND", http.StatusNotFound},
+	{domainFinance.ErrHoldingNotFound, "HOLDING_NOT_FOUND", http.StatusNotFound},
+
+	// Finance - access
+	{domainFinance.ErrAccessDenied, "ACCESS_DENIED", http.StatusForbidden},
+	{domainFinance.ErrCategoryAccessDenied, "CATEGORY_ACCESS_DENIED", http.StatusForbidden},
+	{domainFinance.ErrCurrencyAccessDenied, "CURRENCY_ACCESS_DENIED", http.StatusForbidden},
+	{domainFinance.ErrDefaultCategoryImmutable, "DEFAULT_CATEGORY_IMMUTABLE", http.StatusForbidden},
+	{domainFinance.ErrDefaultCategoryNotDeletable, "DEFAULT_CATEGORY_IMMUTABLE", http.StatusForbidden},
+	{domainFinance.ErrDefaultCurrencyImmutable, "DEFAULT_CURRENCY_IMMUTABLE", http.StatusForbidden},
+	{domainFinance.ErrDefaultCurrencyNotDeletable, "DEFAULT_CURRENCY_IMMUTABLE", http.StatusForbidden},
+	{domainFinance.ErrBenchmarkingDisabled, "BENCHMARKING_DISABLED", http.StatusForbidden},
+
+	// Finance - conflicts
+	{domainFinance.ErrCurrencyCodeExists, "CURRENCY_CODE_EXISTS", http.StatusConflict},
+	{domainFinance.ErrCurrencyInUse, "CURRENCY_IN_USE", http.StatusConflict},
+	{domainFinance.ErrActionAlreadyUndone, "ACTION_ALREADY_UNDONE", http.StatusConflict},
+	{domainFinance.ErrActionExpired, "ACTION_EXPIRED", http.StatusConflict},
+	{domainFinance.ErrActionSuperseded, "ACTION_SUPERSEDED", http.StatusConflict},
+	{domainFinance.ErrRecurringTransactionInactive, "RECURRING_TRANSACTION_INACTIVE", http.StatusConflict},
+	{domainFinance.ErrScheduledTransactionClosed, "SCHEDULED_TRANSACTION_CLOSED", http.StatusConflict},
+	{domainFinance.ErrMonthClosed, "MONTH_CLOSED", http.StatusConflict},
+	{domainFinance.ErrMonthAlreadyClosed, "MONTH_ALREADY_CLOSED", http.StatusConflict},
+	{domainFinance.ErrNoBillingCycle, "NO_BILLING_CYCLE", http.StatusConflict},
+	{domainFinance.ErrAccountArchived, "ACCOUNT_ARCHIVED", http.StatusConflict},
+	{domainFinance.ErrBalanceAlreadyMatches, "BALANCE_ALREADY_MATCHES", http.StatusConflict},
+	{domainFinance.ErrVersionConflict, "VERSION_CONFLICT", http.StatusConflict},
+	{domainFinance.ErrClientIDInUse, "CLIENT_ID_IN_USE", http.StatusConflict},
+
+	// Finance - validation
+	{domainFinance.ErrTransactionTypeMismatch, "TRANSACTION_TYPE_MISMATCH", http.StatusBadRequest},
+	{domainFinance.ErrCategoryTypeMismatch, "CATEGORY_TYPE_MISMATCH", http.StatusBadRequest},
+	{domainFinance.ErrNegativeAmount, "INVALID_AMOUNT", http.StatusBadRequest},
+	{domainFinance.ErrInvalidAmount, "INVALID_AMOUNT", http.StatusBadRequest},
+	{domainFinance.ErrAmountTooLarge, "INVALID_AMOUNT", http.StatusBadRequest},
+	{domainFinance.ErrCurrencyChange, "VALIDATION_ERROR", http.StatusBadRequest},
+	{domainFinance.ErrInvalidBudgetAmount, "INVALID_AMOUNT", http.StatusBadRequest},
+	{domainFinance.ErrInvalidBudgetPeriod, "VALIDATION_ERROR", http.StatusBadRequest},
+	{domainFinance.ErrInvalidDefaultBudget, "INVALID_DEFAULT_BUDGET", http.StatusBadRequest},
+	{domainFinance.ErrInvalidRecurringAmount, "INVALID_AMOUNT", http.StatusBadRequest},
+	{domainFinance.ErrInvalidFrequency, "VALIDATION_ERROR", http.StatusBadRequest},
+	{domainFinance.ErrEmptyCategoryName, "VALIDATION_ERROR", http.StatusBadRequest},
+	{domainFinance.ErrInvalidCategoryColor, "INVALID_COLOR", http.StatusBadRequest},
+	{domainFinance.ErrEmptyCurrencyCode, "VALIDATION_ERROR", http.StatusBadRequest},
+	{domainFinance.ErrEmptyCurrencyName, "VALIDATION_ERROR", http.StatusBadRequest},
+	{domainFinance.ErrEmptyCurrencySymbol, "VALIDATION_ERROR", http.StatusBadRequest},
+	{domainFinance.ErrEmptyAccountName, "VALIDATION_ERROR", http.StatusBadRequest},
+	{domainFinance.ErrInvalidAccountType, "INVALID_ACCOUNT_TYPE", http.StatusBadRequest},
+	{domainFinance.ErrInvalidBillingCycle, "INVALID_BILLING_CYCLE", http.StatusBadRequest},
+	{domainFinance.ErrBillingCycleNotCreditCard, "INVALID_BILLING_CYCLE", http.StatusBadRequest},
+	{domainFinance.ErrInvalidReconciliationStatus, "INVALID_STATUS", http.StatusBadRequest},
+	{domainFinance.ErrInvalidStatementPeriod, "INVALID_STATEMENT_PERIOD", http.StatusBadRequest},
+	{domainFinance.ErrInvalidExportFormat, "INVALID_EXPORT_FORMAT", http.StatusBadRequest},
+	{domainFinance.ErrInvalidStorageProvider, "INVALID_STORAGE_PROVIDER", http.StatusBadRequest},
+	{domainFinance.ErrEmptyExportTarget, "VALIDATION_ERROR", http.StatusBadRequest},
+	{domainFinance.ErrMissingAccessToken, "VALIDATION_ERROR", http.StatusBadRequest},
+	{domainFinance.ErrIncomeNotDeductible, "INCOME_NOT_DEDUCTIBLE", http.StatusBadRequest},
+	{domainFinance.ErrInvalidTaxYear, "INVALID_YEAR", http.StatusBadRequest},
+	{domainFinance.ErrInvalidOccurrenceRange, "INVALID_OCCURRENCE_RANGE", http.StatusBadRequest},
+	{domainFinance.ErrInvalidOccurrenceOverride, "INVALID_OCCURRENCE_OVERRIDE", http.StatusBadRequest},
+	{domainFinance.ErrInvalidEndDate, "INVALID_END_DATE", http.StatusBadRequest},
+	{domainFinance.ErrInvalidDueDate, "INVALID_DATE", http.StatusBadRequest},
+	{domainFinance.ErrInvalidOccurrenceCount, "VALIDATION_ERROR", http.StatusBadRequest},
+	{domainFinance.ErrInvalidMonth, "INVALID_MONTH", http.StatusBadRequest},
+	{domainFinance.ErrInvalidCloseMonth, "INVALID_MONTH", http.StatusBadRequest},
+	{domainFinance.ErrInvalidCurrencyPair, "INVALID_EXCHANGE_RATE", http.StatusBadRequest},
+	{domainFinance.ErrInvalidExchangeRate, "INVALID_EXCHANGE_RATE", http.StatusBadRequest},
+	{domainFinance.ErrInvalidRateDate, "INVALID_EXCHANGE_RATE", http.StatusBadRequest},
+	{domainFinance.ErrInvalidDateRange, "INVALID_DATE_RANGE", http.StatusBadRequest},
+	{domainFinance.ErrInvalidTransactionDate, "VALIDATION_ERROR", http.StatusBadRequest},
+	{domainFinance.ErrFutureTransactionDate, "FUTURE_DATE_NOT_ALLOWED", http.StatusBadRequest},
+	{domainFinance.ErrScheduledDateNotFuture, "INVALID_SCHEDULED_DATE", http.StatusBadRequest},
+	{domainFinance.ErrInvalidTimezone, "INVALID_TIMEZONE", http.StatusBadRequest},
+	{domainFinance.ErrInvalidLocation, "INVALID_LOCATION", http.StatusBadRequest},
+	{domainFinance.ErrInvalidBoundingBox, "INVALID_BBOX", http.StatusBadRequest},
+	{domainFinance.ErrInvalidLineItem, "INVALID_LINE_ITEM", http.StatusBadRequest},
+	{domainFinance.ErrTooManyLineItems, "TOO_MANY_LINE_ITEMS", http.StatusBadRequest},
+	{domainFinance.ErrInvalidTicker, "INVALID_HOLDING", http.StatusBadRequest},
+	{domainFinance.ErrInvalidQuantity, "INVALID_HOLDING", http.StatusBadRequest},
+	{domainFinance.ErrInvalidPrice, "INVALID_PRICE", http.StatusBadRequest},
+
+	// Identity
+	{domainIdentity.ErrUserNotFound, "USER_NOT_FOUND", http.StatusNotFound},
+	{domainIdentity.ErrUserAlreadyExists, "USER_ALREADY_EXISTS", http.StatusConflict},
+	{domainIdentity.ErrEmailAlreadyExists, "EMAIL_ALREADY_EXISTS", http.StatusConflict},
+	{domainIdentity.ErrInvalidCredentials, "INVALID_CREDENTIALS", http.StatusUnauthorized},
+	{domainIdentity.ErrEmptyEmail, "INVALID_EMAIL", http.StatusBadRequest},
+	{domainIdentity.ErrInvalidRole, "VALIDATION_ERROR", http.StatusBadRequest},
+	{domainIdentity.ErrUserDeactivated, "ACCOUNT_DEACTIVATED", http.StatusForbidden},
+	{domainIdentity.ErrUserAlreadyDeactivated, "ACCOUNT_ALREADY_DEACTIVATED", http.StatusConflict},
+	{domainIdentity.ErrCannotDeactivateSelf, "CANNOT_DEACTIVATE_SELF", http.StatusBadRequest},
+	{domainIdentity.ErrInvalidResetToken, "INVALID_RESET_TOKEN", http.StatusBadRequest},
+	{domainIdentity.ErrInvalidAnomalySensitivity, "VALIDATION_ERROR", http.StatusBadRequest},
+	{domainIdentity.ErrInvalidTrashRetention, "VALIDATION_ERROR", http.StatusBadRequest},
+	{domainIdentity.ErrInvalidGrowthRange, "INVALID_DATE_RANGE", http.StatusBadRequest},
+	{domainIdentity.ErrInvalidRetentionMonths, "VALIDATION_ERROR", http.StatusBadRequest},
+	{domainIdentity.ErrOnboardingAlreadyCompleted, "ONBOARDING_ALREADY_COMPLETED", http.StatusConflict},
+
+	// Notifications
+	{domainNotification.ErrNotificationNotFound, "NOTIFICATION_NOT_FOUND", http.StatusNotFound},
+	{domainNotification.ErrEmptyTitle, "VALIDATION_ERROR", http.StatusBadRequest},
+	{domainNotification.ErrEmptyMessage, "VALIDATION_ERROR", http.StatusBadRequest},
+	{domainNotification.ErrChannelNotFound, "CHANNEL_NOT_FOUND", http.StatusNotFound},
+	{domainNotification.ErrInvalidChannelKind, "INVALID_CHANNEL_KIND", http.StatusBadRequest},
+	{domainNotification.ErrInvalidWebhookURL, "INVALID_WEBHOOK_URL", http.StatusBadRequest},
+	{domainNotification.ErrInvalidNotificationType, "INVALID_NOTIFICATION_TYPE", http.StatusBadRequest},
+	{domainNotification.ErrWebhookNotFound, "WEBHOOK_NOT_FOUND", http.StatusNotFound},
+	{domainNotification.ErrInvalidEndpointURL, "INVALID_WEBHOOK_URL", http.StatusBadRequest},
+	{domainNotification.ErrInvalidEventName, "INVALID_EVENT_NAME", http.StatusBadRequest},
+}
+
+// getErrorCodeFromMessage maps error messages to standardized error codes.
+// It is only used as a fallback for errors that are not typed domain errors.
 func getErrorCodeFromMessage(errorMessage string) string {
 	errorMessageLower := strings.ToLower(errorMessage)
 
@@ -116,6 +271,21 @@ func getErrorCodeFromMessage(errorMessage string) string {
 // HandleError handles errors and sends appropriate error response
 func HandleError(c *gin.Context, err error, defaultStatusCode int) {
 	errorMessage := err.Error()
+
+	// Typed domain errors carry their own code and status
+	for _, mapping := range domainErrorMappings {
+		if errors.Is(err, mapping.err) {
+			SendErrorResponse(c, mapping.statusCode, mapping.errorCode, errorMessage)
+			return
+		}
+	}
+
+	// Anything else failing past the deadline most likely failed because of it
+	if errors.Is(err, context.DeadlineExceeded) || deadlineExceeded(c) {
+		RequestTimeoutResponse(c)
+		return
+	}
+
 	errorCode := getErrorCodeFromMessage(errorMessage)
 
 	// Determine status code based on error code
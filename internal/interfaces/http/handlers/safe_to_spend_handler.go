@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+	"panda-pocket/internal/application/finance"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SafeToSpendHandler handles safe to spend requests
+type SafeToSpendHandler struct {
+	safeToSpendUseCase *finance.SafeToSpendUseCase
+}
+
+// NewSafeToSpendHandler creates a new safe to spend handler instance
+func NewSafeToSpendHandler(safeToSpendUseCase *finance.SafeToSpendUseCase) *SafeToSpendHandler {
+	return &SafeToSpendHandler{
+		safeToSpendUseCase: safeToSpendUseCase,
+	}
+}
+
+// GetSafeToSpend handles what the current user can spend this month once bills and budgets are provided for
+func (h *SafeToSpendHandler) GetSafeToSpend(c *gin.Context) {
+	var req finance.SafeToSpendRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	response, err := h.safeToSpendUseCase.Execute(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
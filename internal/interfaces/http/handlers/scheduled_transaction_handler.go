@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"panda-pocket/internal/application/finance"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ScheduledTransactionHandler handles scheduled transaction requests
+type ScheduledTransactionHandler struct {
+	manageScheduledUseCase *finance.ManageScheduledTransactionsUseCase
+}
+
+// NewScheduledTransactionHandler creates a new scheduled transaction handler instance
+func NewScheduledTransactionHandler(manageScheduledUseCase *finance.ManageScheduledTransactionsUseCase) *ScheduledTransactionHandler {
+	return &ScheduledTransactionHandler{
+		manageScheduledUseCase: manageScheduledUseCase,
+	}
+}
+
+// Schedule handles scheduling a transaction to post on a later date
+func (h *ScheduledTransactionHandler) Schedule(c *gin.Context) {
+	var req finance.ScheduleTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	response, err := h.manageScheduledUseCase.Schedule(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, response)
+}
+
+// List handles listing the user's scheduled transactions
+func (h *ScheduledTransactionHandler) List(c *gin.Context) {
+	response, err := h.manageScheduledUseCase.List(c.Request.Context(), c.GetInt("user_id"))
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
+
+// Cancel handles cancelling a scheduled transaction before it posts
+func (h *ScheduledTransactionHandler) Cancel(c *gin.Context) {
+	var scheduledID int
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &scheduledID); err != nil {
+		BadRequestResponse(c, "INVALID_SCHEDULED_TRANSACTION_ID", "Invalid scheduled transaction ID")
+		return
+	}
+
+	response, err := h.manageScheduledUseCase.Cancel(c.Request.Context(), c.GetInt("user_id"), scheduledID)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"panda-pocket/internal/application/finance"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchHandler handles the global search requests
+type SearchHandler struct {
+	searchUseCase *finance.SearchUseCase
+}
+
+// NewSearchHandler creates a new search handler instance
+func NewSearchHandler(searchUseCase *finance.SearchUseCase) *SearchHandler {
+	return &SearchHandler{
+		searchUseCase: searchUseCase,
+	}
+}
+
+// Search handles searching the current user's transactions, categories,
+// budgets and merchants in one call
+func (h *SearchHandler) Search(c *gin.Context) {
+	req := finance.SearchRequest{Query: c.Query("q")}
+	if limitParam := c.Query("limit"); limitParam != "" {
+		if limit, err := strconv.Atoi(limitParam); err == nil {
+			req.Limit = limit
+		}
+	}
+
+	response, err := h.searchUseCase.Execute(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		if errors.Is(err, finance.ErrSearchQueryTooShort) {
+			BadRequestResponse(c, "INVALID_SEARCH_QUERY", err.Error())
+			return
+		}
+		InternalServerErrorResponse(c, "SEARCH_ERROR", "Failed to search")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
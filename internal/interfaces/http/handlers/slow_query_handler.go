@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"panda-pocket/internal/infrastructure/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SlowQueryHandler reports the queries that ran slower than the slow query threshold
+type SlowQueryHandler struct {
+	slowQueries *metrics.SlowQueries
+	threshold   time.Duration
+}
+
+// NewSlowQueryHandler creates a new slow query handler instance. slowQueries may
+// be nil when the database does not count slow queries.
+func NewSlowQueryHandler(slowQueries *metrics.SlowQueries, threshold time.Duration) *SlowQueryHandler {
+	return &SlowQueryHandler{
+		slowQueries: slowQueries,
+		threshold:   threshold,
+	}
+}
+
+// SlowQueryResponse is how often one statement ran slower than the threshold
+type SlowQueryResponse struct {
+	SQL             string    `json:"sql"`
+	Count           int64     `json:"count"`
+	TotalDurationMs float64   `json:"total_duration_ms"`
+	MaxDurationMs   float64   `json:"max_duration_ms"`
+	LastSeenAt      time.Time `json:"last_seen_at"`
+}
+
+// GetSlowQueries returns the slow queries counted since startup, the most total
+// time spent first
+func (h *SlowQueryHandler) GetSlowQueries(c *gin.Context) {
+	queries := []SlowQueryResponse{}
+	var dropped int64
+	if h.slowQueries != nil {
+		var counted []metrics.SlowQuery
+		counted, dropped = h.slowQueries.List()
+		for _, query := range counted {
+			queries = append(queries, SlowQueryResponse{
+				SQL:             query.SQL,
+				Count:           query.Count,
+				TotalDurationMs: milliseconds(query.TotalDuration),
+				MaxDurationMs:   milliseconds(query.MaxDuration),
+				LastSeenAt:      query.LastSeenAt,
+			})
+		}
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{
+		"threshold_ms": milliseconds(h.threshold),
+		"queries":      queries,
+		"uncounted":    dropped,
+	})
+}
+
+// milliseconds converts a duration to fractional milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
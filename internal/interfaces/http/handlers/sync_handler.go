@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"net/http"
+	"panda-pocket/internal/application/finance"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// syncCursorPrefix marks a sync cursor's payload so a pagination cursor is not taken for one
+const syncCursorPrefix = "sync:"
+
+// syncOverlap is how far before a sync its cursor reaches, so changes committed
+// while that sync was reading are sent again rather than missed. Clients store
+// records by ID, so receiving a change twice does no harm.
+const syncOverlap = time.Minute
+
+// SyncHandler handles the change feed offline-first clients sync from
+type SyncHandler struct {
+	syncUseCase *finance.SyncUseCase
+}
+
+// NewSyncHandler creates a new sync handler instance
+func NewSyncHandler(syncUseCase *finance.SyncUseCase) *SyncHandler {
+	return &SyncHandler{
+		syncUseCase: syncUseCase,
+	}
+}
+
+// Sync handles getting the records changed since the cursor in the since query
+// parameter, or every record when it is omitted
+func (h *SyncHandler) Sync(c *gin.Context) {
+	since, ok := parseSyncCursor(c.Query("since"))
+	if !ok {
+		BadRequestResponse(c, "INVALID_CURSOR", "Invalid sync cursor")
+		return
+	}
+
+	response, err := h.syncUseCase.Execute(c.Request.Context(), c.GetInt("user_id"), since)
+	if err != nil {
+		InternalServerErrorResponse(c, "SYNC_ERROR", "Failed to fetch changes")
+		return
+	}
+	next := response.SyncedAt.Add(-syncOverlap).Format(time.RFC3339Nano)
+	response.NextCursor = base64.RawURLEncoding.EncodeToString([]byte(syncCursorPrefix + next))
+
+	SuccessResponse(c, http.StatusOK, response)
+}
+
+// parseSyncCursor decodes a sync cursor into the time it syncs from; an empty
+// cursor syncs from the beginning
+func parseSyncCursor(cursor string) (time.Time, bool) {
+	if cursor == "" {
+		return time.Time{}, true
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(raw), syncCursorPrefix) {
+		return time.Time{}, false
+	}
+	since, err := time.Parse(time.RFC3339Nano, strings.TrimPrefix(string(raw), syncCursorPrefix))
+	if err != nil {
+		return time.Time{}, false
+	}
+	// Stored timestamps are written in the server's time zone, as time.Now() is
+	return since.Local(), true
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"panda-pocket/internal/application/finance"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TaxHandler handles tax-deductible tagging and the annual tax report
+type TaxHandler struct {
+	taxDeductionsUseCase *finance.TaxDeductionsUseCase
+}
+
+// NewTaxHandler creates a new tax handler instance
+func NewTaxHandler(taxDeductionsUseCase *finance.TaxDeductionsUseCase) *TaxHandler {
+	return &TaxHandler{
+		taxDeductionsUseCase: taxDeductionsUseCase,
+	}
+}
+
+// SetCategoryTaxDeductible handles marking or unmarking an expense category as tax-deductible
+func (h *TaxHandler) SetCategoryTaxDeductible(c *gin.Context) {
+	var categoryID int
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &categoryID); err != nil {
+		BadRequestResponse(c, "INVALID_CATEGORY_ID", "Invalid category ID")
+		return
+	}
+
+	var req finance.SetCategoryTaxDeductibleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	category, err := h.taxDeductionsUseCase.SetCategoryDeductible(c.Request.Context(), c.GetInt("user_id"), categoryID, req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"category": category})
+}
+
+// UpdateExpenseTaxDetails handles marking an expense as tax-deductible and attaching its receipt reference
+func (h *TaxHandler) UpdateExpenseTaxDetails(c *gin.Context) {
+	var transactionID int
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &transactionID); err != nil {
+		BadRequestResponse(c, "INVALID_TRANSACTION_ID", "Invalid transaction ID")
+		return
+	}
+
+	var req finance.UpdateExpenseTaxDetailsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	transaction, err := h.taxDeductionsUseCase.UpdateExpenseTaxDetails(c.Request.Context(), c.GetInt("user_id"), transactionID, req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{
+		"expense": gin.H{
+			"id":                transaction.ID().Value(),
+			"tax_deductible":    transaction.TaxDeductible(),
+			"receipt_reference": transaction.ReceiptReference(),
+		},
+	})
+}
+
+// GetTaxReport handles summarizing the current user's deductible expenses for a year
+func (h *TaxHandler) GetTaxReport(c *gin.Context) {
+	var year int
+	if _, err := fmt.Sscanf(c.Param("year"), "%d", &year); err != nil {
+		BadRequestResponse(c, "INVALID_YEAR", "Invalid year")
+		return
+	}
+
+	report, err := h.taxDeductionsUseCase.Report(c.Request.Context(), c.GetInt("user_id"), year)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, report)
+}
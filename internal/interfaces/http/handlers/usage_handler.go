@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+	"panda-pocket/internal/application/identity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UsageHandler handles the current user's usage metrics
+type UsageHandler struct {
+	getAccountUsageUseCase *identity.GetAccountUsageUseCase
+}
+
+// NewUsageHandler creates a new usage handler instance
+func NewUsageHandler(getAccountUsageUseCase *identity.GetAccountUsageUseCase) *UsageHandler {
+	return &UsageHandler{
+		getAccountUsageUseCase: getAccountUsageUseCase,
+	}
+}
+
+// GetAccountUsage handles getting the current user's usage metrics
+func (h *UsageHandler) GetAccountUsage(c *gin.Context) {
+	usage, err := h.getAccountUsageUseCase.Execute(c.Request.Context(), c.GetInt("user_id"))
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_USAGE_ERROR", "Failed to fetch account usage")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, usage)
+}
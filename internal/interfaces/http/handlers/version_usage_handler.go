@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"panda-pocket/internal/infrastructure/metrics"
+	"panda-pocket/internal/interfaces/http/versioning"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VersionUsageHandler reports API version adoption
+type VersionUsageHandler struct {
+	tracker        *metrics.VersionUsageTracker
+	versionManager *versioning.VersionManager
+}
+
+// NewVersionUsageHandler creates a new version usage handler instance
+func NewVersionUsageHandler(tracker *metrics.VersionUsageTracker, vm *versioning.VersionManager) *VersionUsageHandler {
+	return &VersionUsageHandler{
+		tracker:        tracker,
+		versionManager: vm,
+	}
+}
+
+// EndpointUsage is the request count for one endpoint of a version
+type EndpointUsage struct {
+	Method       string    `json:"method"`
+	Route        string    `json:"route"`
+	RequestCount int64     `json:"request_count"`
+	FirstSeenAt  time.Time `json:"first_seen_at"`
+	LastSeenAt   time.Time `json:"last_seen_at"`
+}
+
+// VersionUsageResponse is the adoption summary for one version
+type VersionUsageResponse struct {
+	Version      string          `json:"version"`
+	Status       string          `json:"status"`
+	SunsetDate   string          `json:"sunset_date,omitempty"`
+	RequestCount int64           `json:"request_count"`
+	LastSeenAt   *time.Time      `json:"last_seen_at,omitempty"`
+	Endpoints    []EndpointUsage `json:"endpoints"`
+}
+
+// GetVersionUsage returns request counts per version and endpoint
+func (h *VersionUsageHandler) GetVersionUsage(c *gin.Context) {
+	usage, err := h.tracker.Usage(c.Request.Context())
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_VERSION_USAGE_ERROR", "Failed to fetch version usage")
+		return
+	}
+
+	// Start with every known version so unused ones show up with zero requests
+	byVersion := make(map[string]*VersionUsageResponse)
+	var order []string
+	addVersion := func(version string) *VersionUsageResponse {
+		if summary, ok := byVersion[version]; ok {
+			return summary
+		}
+		summary := &VersionUsageResponse{
+			Version:   version,
+			Status:    h.versionManager.GetVersionStatus(version),
+			Endpoints: []EndpointUsage{},
+		}
+		if info, ok := h.versionManager.GetDeprecationInfo(version); ok {
+			summary.SunsetDate = info.SunsetDate.Format("2006-01-02")
+		}
+		byVersion[version] = summary
+		order = append(order, version)
+		return summary
+	}
+
+	for _, version := range h.versionManager.GetSupportedVersions() {
+		addVersion(version)
+	}
+	for _, version := range h.versionManager.GetDeprecatedVersions() {
+		addVersion(version)
+	}
+
+	for _, u := range usage {
+		summary := addVersion(u.Version)
+		summary.RequestCount += u.RequestCount
+		if summary.LastSeenAt == nil || u.LastSeenAt.After(*summary.LastSeenAt) {
+			lastSeen := u.LastSeenAt
+			summary.LastSeenAt = &lastSeen
+		}
+		summary.Endpoints = append(summary.Endpoints, EndpointUsage{
+			Method:       u.Method,
+			Route:        u.Route,
+			RequestCount: u.RequestCount,
+			FirstSeenAt:  u.FirstSeenAt,
+			LastSeenAt:   u.LastSeenAt,
+		})
+	}
+
+	versions := make([]VersionUsageResponse, 0, len(order))
+	for _, version := range order {
+		versions = append(versions, *byVersion[version])
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{
+		"current_version": h.versionManager.GetCurrentVersion(),
+		"versions":        versions,
+	})
+}
+
+// ClientVersionUsage is one user's requests to a deprecated version
+type ClientVersionUsage struct {
+	UserID       int       `json:"user_id"`
+	Email        string    `json:"email"`
+	RequestCount int64     `json:"request_count"`
+	FirstSeenAt  time.Time `json:"first_seen_at"`
+	LastSeenAt   time.Time `json:"last_seen_at"`
+}
+
+// DeprecatedVersionClients lists the users still calling a deprecated version
+type DeprecatedVersionClients struct {
+	Version    string               `json:"version"`
+	SunsetDate string               `json:"sunset_date,omitempty"`
+	Clients    []ClientVersionUsage `json:"clients"`
+}
+
+// GetDeprecatedVersionClients returns, per deprecated version, the users that
+// still call it, so they can be contacted before the version is sunset
+func (h *VersionUsageHandler) GetDeprecatedVersionClients(c *gin.Context) {
+	deprecated := h.versionManager.GetDeprecatedVersions()
+
+	usage, err := h.tracker.ClientUsage(c.Request.Context(), deprecated)
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_VERSION_USAGE_ERROR", "Failed to fetch version usage")
+		return
+	}
+
+	byVersion := make(map[string]*DeprecatedVersionClients, len(deprecated))
+	versions := make([]*DeprecatedVersionClients, 0, len(deprecated))
+	for _, version := range deprecated {
+		summary := &DeprecatedVersionClients{Version: version, Clients: []ClientVersionUsage{}}
+		if info, ok := h.versionManager.GetDeprecationInfo(version); ok {
+			summary.SunsetDate = info.SunsetDate.Format("2006-01-02")
+		}
+		byVersion[version] = summary
+		versions = append(versions, summary)
+	}
+
+	for _, u := range usage {
+		summary, ok := byVersion[u.Version]
+		if !ok {
+			continue
+		}
+		summary.Clients = append(summary.Clients, ClientVersionUsage{
+			UserID:       u.UserID,
+			Email:        u.Email,
+			RequestCount: u.RequestCount,
+			FirstSeenAt:  u.FirstSeenAt,
+			LastSeenAt:   u.LastSeenAt,
+		})
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"versions": versions})
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"panda-pocket/internal/application/notification"
+	"panda-pocket/internal/infrastructure/events"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandler handles webhook integration requests
+type WebhookHandler struct {
+	manageWebhooksUseCase *notification.ManageWebhooksUseCase
+	testWebhookUseCase    *notification.TestWebhookUseCase
+}
+
+// NewWebhookHandler creates a new webhook handler instance
+func NewWebhookHandler(manageWebhooksUseCase *notification.ManageWebhooksUseCase, testWebhookUseCase *notification.TestWebhookUseCase) *WebhookHandler {
+	return &WebhookHandler{
+		manageWebhooksUseCase: manageWebhooksUseCase,
+		testWebhookUseCase:    testWebhookUseCase,
+	}
+}
+
+// ListEventTypes returns the available event types with sample payloads, so
+// integration platforms such as Zapier or Make can configure triggers
+func (h *WebhookHandler) ListEventTypes(c *gin.Context) {
+	CachedSuccessResponse(c, gin.H{"events": events.Catalog()})
+}
+
+// GetWebhooks handles listing the current user's webhooks
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	webhooks, err := h.manageWebhooksUseCase.List(c.Request.Context(), c.GetInt("user_id"))
+	if err != nil {
+		InternalServerErrorResponse(c, "FETCH_WEBHOOKS_ERROR", "Failed to fetch webhooks")
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"webhooks": webhooks})
+}
+
+// CreateWebhook handles registering a webhook for the current user
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req notification.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationErrorResponse(c, formatValidationError(err))
+		return
+	}
+
+	response, err := h.manageWebhooksUseCase.Create(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, response)
+}
+
+// DeleteWebhook handles removing one of the current user's webhooks
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	var webhookID int
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &webhookID); err != nil {
+		BadRequestResponse(c, "INVALID_WEBHOOK_ID", "Invalid webhook ID")
+		return
+	}
+
+	if err := h.manageWebhooksUseCase.Delete(c.Request.Context(), c.GetInt("user_id"), webhookID); err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, gin.H{"message": "Webhook deleted"})
+}
+
+// TestWebhook handles sending a signed sample event to one of the current user's
+// webhooks and reporting how the endpoint answered
+func (h *WebhookHandler) TestWebhook(c *gin.Context) {
+	var webhookID int
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &webhookID); err != nil {
+		BadRequestResponse(c, "INVALID_WEBHOOK_ID", "Invalid webhook ID")
+		return
+	}
+
+	var req notification.TestWebhookRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			ValidationErrorResponse(c, formatValidationError(err))
+			return
+		}
+	}
+
+	response, err := h.testWebhookUseCase.Execute(c.Request.Context(), c.GetInt("user_id"), webhookID, req)
+	if err != nil {
+		HandleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, response)
+}
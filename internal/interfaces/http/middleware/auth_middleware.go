@@ -1,39 +1,43 @@
 package middleware
 
 import (
+	"context"
 	"panda-pocket/internal/application/identity"
 	"panda-pocket/internal/interfaces/http/handlers"
 
 	"github.com/gin-gonic/gin"
 )
 
+// AccountStatus reports whether a signed-in user's account is still active
+type AccountStatus interface {
+	IsActive(ctx context.Context, userID int) (bool, error)
+}
+
 // AuthMiddleware handles JWT authentication
 type AuthMiddleware struct {
 	tokenService identity.TokenService
+	accounts     AccountStatus
 }
 
-// NewAuthMiddleware creates a new auth middleware
-func NewAuthMiddleware(tokenService identity.TokenService) *AuthMiddleware {
+// NewAuthMiddleware creates a new auth middleware. Tokens of deactivated
+// accounts are rejected even before they expire.
+func NewAuthMiddleware(tokenService identity.TokenService, accounts AccountStatus) *AuthMiddleware {
 	return &AuthMiddleware{
 		tokenService: tokenService,
+		accounts:     accounts,
 	}
 }
 
 // RequireAuth is the middleware function that validates JWT tokens
 func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		tokenString := c.GetHeader("Authorization")
+		tokenString := bearerToken(c)
 		if tokenString == "" {
 			handlers.UnauthorizedResponse(c, "AUTHORIZATION_HEADER_REQUIRED", "Authorization header required")
 			c.Abort()
 			return
 		}
 
-		// Remove "Bearer " prefix if present
-		if len(tokenString) > 7 && tokenString[:7] == "Bearer " {
-			tokenString = tokenString[7:]
-		}
-
 		claims, err := m.tokenService.ValidateToken(tokenString)
 		if err != nil {
 			handlers.UnauthorizedResponse(c, "INVALID_TOKEN", "Invalid token")
@@ -41,6 +45,18 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 			return
 		}
 
+		active, err := m.accounts.IsActive(c.Request.Context(), claims.UserID)
+		if err != nil {
+			handlers.InternalServerErrorResponse(c, "ACCOUNT_STATUS_ERROR", "Failed to check account status")
+			c.Abort()
+			return
+		}
+		if !active {
+			handlers.UnauthorizedResponse(c, "ACCOUNT_DEACTIVATED", "Account is deactivated")
+			c.Abort()
+			return
+		}
+
 		// Set user info in context
 		c.Set("user_id", claims.UserID)
 		c.Set("email", claims.Email)
@@ -49,6 +65,16 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 	}
 }
 
+// bearerToken returns the token in the Authorization header, with or without the
+// "Bearer " prefix
+func bearerToken(c *gin.Context) string {
+	tokenString := c.GetHeader("Authorization")
+	if len(tokenString) > 7 && tokenString[:7] == "Bearer " {
+		tokenString = tokenString[7:]
+	}
+	return tokenString
+}
+
 // RequireRole is a middleware that checks if the user has the required role
 func (m *AuthMiddleware) RequireRole(requiredRole string) gin.HandlerFunc {
 	return func(c *gin.Context) {
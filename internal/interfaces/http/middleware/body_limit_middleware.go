@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+
+	"panda-pocket/internal/interfaces/http/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BodyLimitMiddleware caps request body sizes and rejects unexpected content types
+type BodyLimitMiddleware struct {
+	maxBodyBytes   int64
+	maxUploadBytes int64
+}
+
+// NewBodyLimitMiddleware creates a new body limit middleware
+func NewBodyLimitMiddleware(maxBodyBytes, maxUploadBytes int) *BodyLimitMiddleware {
+	return &BodyLimitMiddleware{
+		maxBodyBytes:   int64(maxBodyBytes),
+		maxUploadBytes: int64(maxUploadBytes),
+	}
+}
+
+// LimitBody accepts JSON bodies up to the body limit and multipart uploads up to the
+// upload limit. Bodies of any other type are rejected, as are bodies whose declared
+// length is over the limit; bodies without a declared length are cut off at the limit.
+func (m *BodyLimitMiddleware) LimitBody() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Body == nil || c.Request.Body == http.NoBody || c.Request.ContentLength == 0 {
+			c.Next()
+			return
+		}
+
+		var limit int64
+		switch mediaType(c.Request.Header.Get("Content-Type")) {
+		case "application/json":
+			limit = m.maxBodyBytes
+		case "multipart/form-data":
+			limit = m.maxUploadBytes
+		default:
+			handlers.SendErrorResponse(c, http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE", "Request body must be application/json")
+			c.Abort()
+			return
+		}
+
+		if c.Request.ContentLength > limit {
+			handlers.SendErrorResponse(c, http.StatusRequestEntityTooLarge, "REQUEST_TOO_LARGE", "Request body is too large")
+			c.Abort()
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
+
+// mediaType returns the media type of a Content-Type header without parameters
+func mediaType(header string) string {
+	parsed, _, err := mime.ParseMediaType(header)
+	if err != nil {
+		return ""
+	}
+	return parsed
+}
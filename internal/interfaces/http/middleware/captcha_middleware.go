@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"panda-pocket/internal/infrastructure/captcha"
+	"panda-pocket/internal/interfaces/http/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CaptchaTokenHeader carries the token the client received for solving the CAPTCHA
+const CaptchaTokenHeader = "X-Captcha-Token"
+
+// CaptchaVerifier checks CAPTCHA tokens with the provider
+type CaptchaVerifier interface {
+	Verify(ctx context.Context, token, remoteIP string) error
+}
+
+// CaptchaMiddleware requires a solved CAPTCHA on public endpoints that bots abuse
+type CaptchaMiddleware struct {
+	verifier CaptchaVerifier
+	logger   *slog.Logger
+}
+
+// NewCaptchaMiddleware creates a new CAPTCHA middleware. With a nil verifier,
+// no CAPTCHA is required.
+func NewCaptchaMiddleware(verifier CaptchaVerifier, logger *slog.Logger) *CaptchaMiddleware {
+	return &CaptchaMiddleware{
+		verifier: verifier,
+		logger:   logger,
+	}
+}
+
+// Require rejects requests without a valid CAPTCHA token
+func (m *CaptchaMiddleware) Require() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if m.verifier == nil {
+			c.Next()
+			return
+		}
+
+		token := c.GetHeader(CaptchaTokenHeader)
+		if token == "" {
+			handlers.BadRequestResponse(c, "CAPTCHA_REQUIRED", "Please complete the CAPTCHA")
+			c.Abort()
+			return
+		}
+
+		if err := m.verifier.Verify(c.Request.Context(), token, c.ClientIP()); err != nil {
+			if errors.Is(err, captcha.ErrRejected) {
+				handlers.BadRequestResponse(c, "CAPTCHA_FAILED", "CAPTCHA verification failed, please try again")
+				c.Abort()
+				return
+			}
+
+			// Fail closed: letting requests through would defeat the CAPTCHA while the provider is down
+			m.logger.ErrorContext(c.Request.Context(), "captcha provider unavailable", "error", err.Error())
+			handlers.SendErrorResponse(c, http.StatusServiceUnavailable, "CAPTCHA_UNAVAILABLE", "CAPTCHA verification is unavailable, please try again later")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"panda-pocket/internal/infrastructure/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocaleMiddleware reads the client's preferred languages so responses can be localized
+type LocaleMiddleware struct{}
+
+// NewLocaleMiddleware creates a new locale middleware
+func NewLocaleMiddleware() *LocaleMiddleware {
+	return &LocaleMiddleware{}
+}
+
+// DetectLocale stores the languages from the Accept-Language header in the request context
+func (m *LocaleMiddleware) DetectLocale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Responses differ by language, so caches must key on it
+		c.Writer.Header().Add("Vary", "Accept-Language")
+
+		if locales := i18n.ParseAcceptLanguage(c.GetHeader("Accept-Language")); len(locales) > 0 {
+			c.Request = c.Request.WithContext(i18n.WithLocales(c.Request.Context(), locales))
+		}
+
+		c.Next()
+	}
+}
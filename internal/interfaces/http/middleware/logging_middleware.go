@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"panda-pocket/internal/infrastructure/logging"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader is the header used to receive and return request IDs
+const RequestIDHeader = "X-Request-ID"
+
+// LoggingMiddleware assigns request IDs and writes structured access logs
+type LoggingMiddleware struct {
+	logger *slog.Logger
+}
+
+// NewLoggingMiddleware creates a new logging middleware
+func NewLoggingMiddleware(logger *slog.Logger) *LoggingMiddleware {
+	return &LoggingMiddleware{
+		logger: logger,
+	}
+}
+
+// RequestID assigns a request ID (reusing the client's X-Request-ID when present),
+// stores it in the request context and echoes it in the response headers
+func (m *LoggingMiddleware) RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > 128 {
+			requestID = generateRequestID()
+		}
+
+		c.Set("request_id", requestID)
+		c.Header(RequestIDHeader, requestID)
+		c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), requestID, m.logger))
+
+		c.Next()
+	}
+}
+
+// LogRequests logs method, path, status, latency and user ID of every request as JSON
+func (m *LoggingMiddleware) LogRequests() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		path := c.Request.URL.Path
+
+		c.Next()
+
+		attrs := []any{
+			"request_id", c.GetString("request_id"),
+			"method", c.Request.Method,
+			"path", path,
+			"status", c.Writer.Status(),
+			"latency_ms", time.Since(start).Milliseconds(),
+			"client_ip", c.ClientIP(),
+		}
+		if userID := c.GetInt("user_id"); userID != 0 {
+			attrs = append(attrs, "user_id", userID)
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, "errors", c.Errors.String())
+		}
+
+		switch {
+		case c.Writer.Status() >= 500:
+			m.logger.Error("request completed", attrs...)
+		case c.Writer.Status() >= 400:
+			m.logger.Warn("request completed", attrs...)
+		default:
+			m.logger.Info("request completed", attrs...)
+		}
+	}
+}
+
+// generateRequestID creates a random 16 byte hex encoded request ID
+func generateRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return hex.EncodeToString([]byte(time.Now().Format(time.RFC3339Nano)))
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"panda-pocket/internal/infrastructure/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loggedRouter routes requests through the logging middleware, writing the
+// access log as JSON lines to the returned buffer
+func loggedRouter() (*gin.Engine, *bytes.Buffer) {
+	gin.SetMode(gin.TestMode)
+	var logs bytes.Buffer
+	loggingMiddleware := NewLoggingMiddleware(slog.New(slog.NewJSONHandler(&logs, nil)))
+
+	router := gin.New()
+	router.Use(loggingMiddleware.RequestID())
+	router.Use(loggingMiddleware.LogRequests())
+	return router, &logs
+}
+
+// logLines decodes the JSON log lines written to logs
+func logLines(t *testing.T, logs *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		lines = append(lines, entry)
+	}
+	return lines
+}
+
+func TestRequestID(t *testing.T) {
+	router, _ := loggedRouter()
+	var seen string
+	router.GET("/ping", func(c *gin.Context) {
+		seen = logging.RequestIDFromContext(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	})
+
+	t.Run("a request ID is generated when none is sent", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+
+		requestID := w.Header().Get(RequestIDHeader)
+		assert.Len(t, requestID, 32)
+		assert.Equal(t, requestID, seen)
+	})
+
+	t.Run("the client's request ID is kept", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.Header.Set(RequestIDHeader, "trace-123")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, "trace-123", w.Header().Get(RequestIDHeader))
+		assert.Equal(t, "trace-123", seen)
+	})
+
+	t.Run("overlong request IDs are replaced", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.Header.Set(RequestIDHeader, strings.Repeat("a", 129))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Len(t, w.Header().Get(RequestIDHeader), 32)
+	})
+}
+
+func TestLogRequests(t *testing.T) {
+	router, logs := loggedRouter()
+	router.GET("/items", func(c *gin.Context) {
+		c.Set("user_id", 7)
+		logging.FromContext(c.Request.Context()).Info("listing items")
+		c.Status(http.StatusOK)
+	})
+	router.GET("/missing", func(c *gin.Context) { c.Status(http.StatusNotFound) })
+	router.GET("/broken", func(c *gin.Context) { c.Status(http.StatusInternalServerError) })
+
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	req.Header.Set(RequestIDHeader, "trace-123")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/missing", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/broken", nil))
+
+	lines := logLines(t, logs)
+	require.Len(t, lines, 4)
+
+	// Logs written while handling the request carry its ID
+	assert.Equal(t, "listing items", lines[0]["msg"])
+	assert.Equal(t, "trace-123", lines[0]["request_id"])
+
+	access := lines[1]
+	assert.Equal(t, "request completed", access["msg"])
+	assert.Equal(t, "INFO", access["level"])
+	assert.Equal(t, "trace-123", access["request_id"])
+	assert.Equal(t, "GET", access["method"])
+	assert.Equal(t, "/items", access["path"])
+	assert.EqualValues(t, 200, access["status"])
+	assert.EqualValues(t, 7, access["user_id"])
+	assert.Contains(t, access, "latency_ms")
+
+	assert.Equal(t, "WARN", lines[2]["level"])
+	assert.NotContains(t, lines[2], "user_id")
+	assert.Equal(t, "ERROR", lines[3]["level"])
+}
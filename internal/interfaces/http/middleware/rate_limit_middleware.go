@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"panda-pocket/internal/infrastructure/ratelimit"
+	"panda-pocket/internal/interfaces/http/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitMiddleware enforces token bucket limits per user or client IP
+type RateLimitMiddleware struct {
+	store  ratelimit.Store
+	logger *slog.Logger
+}
+
+// NewRateLimitMiddleware creates a new rate limit middleware
+func NewRateLimitMiddleware(store ratelimit.Store, logger *slog.Logger) *RateLimitMiddleware {
+	return &RateLimitMiddleware{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// Limit returns a handler enforcing limit for the named route group.
+// Requests are keyed by user ID once authenticated, otherwise by client IP.
+func (m *RateLimitMiddleware) Limit(name string, limit ratelimit.Limit) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !limit.Enabled() {
+			c.Next()
+			return
+		}
+
+		result, err := m.store.Take(c.Request.Context(), name+":"+clientKey(c), limit)
+		if err != nil {
+			// Fail open so a store outage doesn't take the API down with it
+			m.logger.ErrorContext(c.Request.Context(), "rate limit store unavailable",
+				"limiter", name,
+				"error", err.Error(),
+			)
+			c.Next()
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			handlers.SendErrorResponse(c, http.StatusTooManyRequests, "RATE_LIMIT_EXCEEDED", "Too many requests, please try again later")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// DailyQuota returns a handler capping each user's requests per calendar day
+// (UTC) at limit, so a runaway script using a personal API key cannot hog a
+// shared deployment. Unauthenticated requests are not counted.
+func (m *RateLimitMiddleware) DailyQuota(limit int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetInt("user_id")
+		if limit <= 0 || userID == 0 {
+			c.Next()
+			return
+		}
+
+		now := time.Now().UTC()
+		resetAt := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+		key := "daily:user:" + strconv.Itoa(userID) + ":" + now.Format("2006-01-02")
+
+		count, err := m.store.Increment(c.Request.Context(), key, resetAt)
+		if err != nil {
+			// Fail open, as for the rate limits
+			m.logger.ErrorContext(c.Request.Context(), "rate limit store unavailable",
+				"limiter", "daily",
+				"error", err.Error(),
+			)
+			c.Next()
+			return
+		}
+
+		c.Header("X-Daily-Quota-Limit", strconv.Itoa(limit))
+		c.Header("X-Daily-Quota-Remaining", strconv.FormatInt(max(int64(limit)-count, 0), 10))
+
+		if count > int64(limit) {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(resetAt.Sub(now))))
+			handlers.SendErrorResponse(c, http.StatusTooManyRequests, "DAILY_QUOTA_EXCEEDED", "Daily API call limit reached, please try again tomorrow")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// clientKey identifies the caller for rate limiting
+func clientKey(c *gin.Context) string {
+	if userID := c.GetInt("user_id"); userID != 0 {
+		return "user:" + strconv.Itoa(userID)
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// ceilSeconds rounds a duration up to whole seconds
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
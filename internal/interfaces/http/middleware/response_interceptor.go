@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bufferedResponseWriter holds JSON response bodies in memory so middleware can
+// rewrite them after the handler has run. Whether to buffer is decided when the
+// handler starts writing the body: any other content type, such as CSV exports
+// and backup downloads, is passed straight through, as are flushed and hijacked
+// responses.
+type bufferedResponseWriter struct {
+	gin.ResponseWriter
+	body        bytes.Buffer
+	status      int
+	decided     bool
+	passthrough bool
+}
+
+// newBufferedResponseWriter wraps w, buffering JSON bodies written to it
+func newBufferedResponseWriter(w gin.ResponseWriter) *bufferedResponseWriter {
+	return &bufferedResponseWriter{
+		ResponseWriter: w,
+		status:         http.StatusOK,
+	}
+}
+
+// decide picks between buffering and passing the response through, once the
+// handler has set its Content-Type
+func (w *bufferedResponseWriter) decide() {
+	if w.decided {
+		return
+	}
+	w.decided = true
+	if !strings.Contains(w.Header().Get("Content-Type"), "application/json") {
+		w.pass()
+	}
+}
+
+// pass sends the recorded status and writes everything else straight through
+func (w *bufferedResponseWriter) pass() {
+	w.decided, w.passthrough = true, true
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+// WriteHeader records the status code without sending it
+func (w *bufferedResponseWriter) WriteHeader(code int) {
+	if w.passthrough {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if code > 0 {
+		w.status = code
+	}
+}
+
+// WriteHeaderNow is a no-op while buffering; headers are sent when the buffer is flushed
+func (w *bufferedResponseWriter) WriteHeaderNow() {
+	if w.passthrough {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+// Write buffers JSON bodies and passes any other body through
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	w.decide()
+	if w.passthrough {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.body.Write(data)
+}
+
+// WriteString buffers JSON bodies and passes any other body through
+func (w *bufferedResponseWriter) WriteString(s string) (int, error) {
+	w.decide()
+	if w.passthrough {
+		return w.ResponseWriter.WriteString(s)
+	}
+	return w.body.WriteString(s)
+}
+
+// Flush marks the response as streamed, so it is passed through from here on
+func (w *bufferedResponseWriter) Flush() {
+	if !w.passthrough {
+		w.pass()
+		w.ResponseWriter.Write(w.body.Bytes())
+		w.body.Reset()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Hijack hands the connection to the handler, bypassing the buffer
+func (w *bufferedResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.decided, w.passthrough = true, true
+	return w.ResponseWriter.Hijack()
+}
+
+// Status returns the recorded status code
+func (w *bufferedResponseWriter) Status() int {
+	if w.passthrough {
+		return w.ResponseWriter.Status()
+	}
+	return w.status
+}
+
+// Size returns the number of body bytes written
+func (w *bufferedResponseWriter) Size() int {
+	if w.passthrough {
+		return w.ResponseWriter.Size()
+	}
+	return w.body.Len()
+}
+
+// Written reports whether anything has been written
+func (w *bufferedResponseWriter) Written() bool {
+	if w.passthrough {
+		return w.ResponseWriter.Written()
+	}
+	return w.body.Len() > 0
+}
+
+// flush sends the recorded status and the given body to the underlying writer,
+// unless the response has been passed through already
+func (w *bufferedResponseWriter) flush(body []byte) {
+	if w.passthrough {
+		return
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	if len(body) > 0 {
+		w.ResponseWriter.Write(body)
+	} else {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
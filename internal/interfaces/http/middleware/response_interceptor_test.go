@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"panda-pocket/internal/interfaces/http/versioning"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// deprecatedRouter routes requests through the deprecation warning with v100 deprecated
+func deprecatedRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	versionManager := versioning.NewVersionManager()
+	versionManager.AddDeprecatedVersion("v100", time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC), "API version v100 is deprecated")
+	versionMiddleware := NewVersionMiddleware(versionManager)
+
+	router := gin.New()
+	router.Use(versionMiddleware.ExtractVersion())
+	router.Use(versionMiddleware.AddDeprecationWarning())
+	return router
+}
+
+func TestAddDeprecationWarningBuffering(t *testing.T) {
+	t.Run("JSON bodies get the notice", func(t *testing.T) {
+		router := deprecatedRouter()
+		router.GET("/api/v100/items", func(c *gin.Context) {
+			c.JSON(http.StatusCreated, gin.H{"status": "success"})
+		})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v100/items", nil))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Contains(t, body, "deprecation")
+	})
+
+	t.Run("other bodies are passed through as they are written", func(t *testing.T) {
+		router := deprecatedRouter()
+		w := httptest.NewRecorder()
+		router.GET("/api/v100/export", func(c *gin.Context) {
+			c.Header("Content-Type", "text/csv")
+			c.Status(http.StatusOK)
+			c.Writer.WriteString("date,amount\n")
+			c.Writer.Flush()
+
+			// The first rows reach the client before the handler finishes
+			assert.Equal(t, "date,amount\n", w.Body.String())
+			assert.True(t, w.Flushed)
+			c.Writer.WriteString("2024-01-01,10\n")
+		})
+
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v100/export", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "date,amount\n2024-01-01,10\n", w.Body.String())
+		assert.Equal(t, "true", w.Header().Get("X-API-Deprecated"))
+	})
+
+	t.Run("upgrade requests keep the original writer", func(t *testing.T) {
+		router := deprecatedRouter()
+		router.GET("/api/v100/ws", func(c *gin.Context) {
+			_, buffered := c.Writer.(*bufferedResponseWriter)
+			assert.False(t, buffered)
+			c.Status(http.StatusSwitchingProtocols)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v100/ws", nil)
+		req.Header.Set("Connection", "Upgrade")
+		req.Header.Set("Upgrade", "websocket")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, "true", w.Header().Get("X-API-Deprecated"))
+	})
+
+	t.Run("hijacked connections bypass the buffer", func(t *testing.T) {
+		router := deprecatedRouter()
+		router.GET("/api/v100/raw", func(c *gin.Context) {
+			conn, rw, err := c.Writer.Hijack()
+			require.NoError(t, err)
+			defer conn.Close()
+			rw.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 2\r\nConnection: close\r\n\r\nok")
+			rw.Flush()
+		})
+		server := httptest.NewServer(router)
+		defer server.Close()
+
+		resp, err := http.Get(server.URL + "/api/v100/raw")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "ok", string(body))
+	})
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"panda-pocket/internal/interfaces/http/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// untimedContextKey is the gin context key holding the request context as it was
+// before the deadline was applied
+const untimedContextKey = "untimed_context"
+
+// TimeoutMiddleware gives every request a deadline. Repositories run their queries
+// with the request context, so a stuck query is cancelled at the deadline instead
+// of hanging the handler, and the request fails with 504 REQUEST_TIMEOUT.
+type TimeoutMiddleware struct {
+	timeout time.Duration
+}
+
+// NewTimeoutMiddleware creates a new timeout middleware; a zero timeout disables it
+func NewTimeoutMiddleware(timeout time.Duration) *TimeoutMiddleware {
+	return &TimeoutMiddleware{
+		timeout: timeout,
+	}
+}
+
+// Deadline cancels the request context once the timeout has passed. Handlers that
+// fail because of it respond with 504 through the handlers' error responses; when
+// a handler wrote nothing at all, the 504 is sent here.
+func (m *TimeoutMiddleware) Deadline() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if m.timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), m.timeout)
+		defer cancel()
+		c.Set(untimedContextKey, c.Request.Context())
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			handlers.RequestTimeoutResponse(c)
+		}
+	}
+}
+
+// NoDeadline lifts the deadline for routes that are expected to outlive it, such
+// as long-lived connections and backups. The request is still cancelled when the
+// client goes away.
+func (m *TimeoutMiddleware) NoDeadline() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ctx, ok := c.Get(untimedContextKey); ok {
+			c.Request = c.Request.WithContext(ctx.(context.Context))
+		}
+		c.Next()
+	}
+}
@@ -1,11 +1,16 @@
 package middleware
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
+	"panda-pocket/internal/infrastructure/metrics"
+	"panda-pocket/internal/interfaces/http/versioning"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -18,23 +23,16 @@ type VersionInfo struct {
 	UpgradeURL   string
 }
 
-// VersionMiddleware handles API version extraction and validation
+// VersionMiddleware handles API version extraction and validation.
+// Version support and deprecation data come from the VersionManager.
 type VersionMiddleware struct {
-	supportedVersions map[string]VersionInfo
+	versionManager *versioning.VersionManager
 }
 
 // NewVersionMiddleware creates a new version middleware instance
-func NewVersionMiddleware() *VersionMiddleware {
+func NewVersionMiddleware(versionManager *versioning.VersionManager) *VersionMiddleware {
 	return &VersionMiddleware{
-		supportedVersions: map[string]VersionInfo{
-			"v100": {
-				Version:      "v100",
-				IsSupported:  true,
-				IsDeprecated: false,
-				SunsetDate:   "",
-				UpgradeURL:   "",
-			},
-		},
+		versionManager: versionManager,
 	}
 }
 
@@ -48,7 +46,7 @@ func (vm *VersionMiddleware) ExtractVersion() gin.HandlerFunc {
 		if len(parts) >= 3 && strings.HasPrefix(parts[2], "v") {
 			version := parts[2]
 
-			if versionInfo, exists := vm.supportedVersions[version]; exists {
+			if versionInfo, exists := vm.GetVersionInfo(version); exists {
 				c.Set("api_version", version)
 				c.Set("version_info", versionInfo)
 
@@ -66,8 +64,9 @@ func (vm *VersionMiddleware) ExtractVersion() gin.HandlerFunc {
 		}
 
 		// No version specified - redirect to latest
-		c.Header("X-API-Version", "v100")
-		c.Header("X-API-Latest", "v100")
+		latest := vm.GetCurrentVersion()
+		c.Header("X-API-Version", latest)
+		c.Header("X-API-Latest", latest)
 		c.Next()
 	}
 }
@@ -79,28 +78,28 @@ func (vm *VersionMiddleware) ValidateVersion() gin.HandlerFunc {
 
 		if version == "" {
 			// No version specified, use latest
-			c.Set("api_version", "v100")
+			c.Set("api_version", vm.GetCurrentVersion())
 			c.Next()
 			return
 		}
 
-		versionInfo, exists := vm.supportedVersions[version]
+		versionInfo, exists := vm.GetVersionInfo(version)
 		if !exists {
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error":              "Unsupported API version",
-				"supported_versions": []string{"v100"},
-				"latest_version":     "v100",
+				"supported_versions": vm.GetSupportedVersions(),
+				"latest_version":     vm.GetCurrentVersion(),
 			})
 			c.Abort()
 			return
 		}
 
-		if !versionInfo.IsSupported {
+		if !versionInfo.IsSupported || vm.CheckSunsetDate(version) {
 			c.JSON(http.StatusGone, gin.H{
 				"error":          "API version no longer supported",
 				"version":        version,
-				"latest_version": "v100",
-				"upgrade_url":    "https://docs.pandapocket.com/upgrade",
+				"latest_version": vm.GetCurrentVersion(),
+				"upgrade_url":    versioning.DefaultUpgradeURL,
 			})
 			c.Abort()
 			return
@@ -110,121 +109,256 @@ func (vm *VersionMiddleware) ValidateVersion() gin.HandlerFunc {
 	}
 }
 
-// AddDeprecationWarning adds deprecation warnings to responses
-func (vm *VersionMiddleware) AddDeprecationWarning() gin.HandlerFunc {
+// RecordUsage counts requests per API version and route, and per version and
+// authenticated user, for adoption reporting. Unmatched routes are not counted.
+func (vm *VersionMiddleware) RecordUsage(tracker *metrics.VersionUsageTracker) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		c.Next()
+
 		version := c.GetString("api_version")
+		route := c.FullPath()
+		if version == "" || route == "" {
+			return
+		}
+		// Runs after the handlers, so the auth middleware has set the user
+		tracker.Record(version, c.Request.Method, route, c.GetInt("user_id"))
+	}
+}
+
+// DeprecationNotice is added to JSON response bodies of deprecated versions
+type DeprecationNotice struct {
+	Version       string `json:"version"`
+	SunsetDate    string `json:"sunset_date"`
+	LatestVersion string `json:"latest_version"`
+	UpgradeURL    string `json:"upgrade_url"`
+	Message       string `json:"message"`
+}
 
-		if versionInfo, exists := vm.supportedVersions[version]; exists && versionInfo.IsDeprecated {
-			// Add deprecation warning to response headers
-			c.Header("X-API-Deprecated", "true")
-			c.Header("X-API-Sunset-Date", versionInfo.SunsetDate)
-			c.Header("X-API-Upgrade-URL", versionInfo.UpgradeURL)
+// AddDeprecationWarning adds deprecation warnings to responses.
+// Successful JSON object bodies also get a top-level "deprecation" object; other
+// bodies and upgraded connections only get the headers.
+func (vm *VersionMiddleware) AddDeprecationWarning() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version := c.GetString("api_version")
 
-			// Add deprecation warning to response body
+		versionInfo, exists := vm.GetVersionInfo(version)
+		if !exists || !versionInfo.IsDeprecated {
 			c.Next()
+			return
+		}
 
-			// Check if response is successful and add deprecation warning
-			if c.Writer.Status() < 400 {
-				// This will be handled by a response interceptor
-				vm.addDeprecationWarningToResponse(c)
-			}
-		} else {
+		notice := vm.deprecationNotice(versionInfo)
+
+		// Add deprecation warning to response headers
+		c.Header("X-API-Deprecated", "true")
+		c.Header("X-API-Sunset-Date", versionInfo.SunsetDate)
+		c.Header("X-API-Upgrade-URL", versionInfo.UpgradeURL)
+		c.Header("X-API-Deprecation-Warning", notice.Message)
+
+		// Upgraded connections such as /ws take over the connection itself
+		if c.GetHeader("Upgrade") != "" {
 			c.Next()
+			return
 		}
+
+		// Buffer JSON responses so the body can be rewritten
+		original := c.Writer
+		buffered := newBufferedResponseWriter(original)
+		c.Writer = buffered
+
+		c.Next()
+
+		c.Writer = original
+		buffered.flush(addDeprecationToBody(buffered, notice))
+	}
+}
+
+// deprecationNotice builds the deprecation notice for a version
+func (vm *VersionMiddleware) deprecationNotice(versionInfo VersionInfo) DeprecationNotice {
+	message := fmt.Sprintf("API version %s is deprecated and will be removed on %s. Please upgrade to %s.",
+		versionInfo.Version, versionInfo.SunsetDate, vm.GetCurrentVersion())
+	if info, ok := vm.versionManager.GetDeprecationInfo(versionInfo.Version); ok && info.WarningMessage != "" {
+		message = info.WarningMessage
+	}
+
+	return DeprecationNotice{
+		Version:       versionInfo.Version,
+		SunsetDate:    versionInfo.SunsetDate,
+		LatestVersion: vm.GetCurrentVersion(),
+		UpgradeURL:    versionInfo.UpgradeURL,
+		Message:       message,
 	}
 }
 
-// addDeprecationWarningToResponse adds deprecation warning to response body
-func (vm *VersionMiddleware) addDeprecationWarningToResponse(c *gin.Context) {
-	version := c.GetString("api_version")
-	versionInfo := vm.supportedVersions[version]
+// addDeprecationToBody returns the buffered body with the notice added when it is
+// a successful JSON object; any other body is returned unchanged
+func addDeprecationToBody(w *bufferedResponseWriter, notice DeprecationNotice) []byte {
+	body := w.body.Bytes()
+	if w.Status() >= http.StatusBadRequest || !strings.Contains(w.Header().Get("Content-Type"), "application/json") {
+		return body
+	}
 
-	// Get the current response
-	response := c.Writer.Header().Get("Content-Type")
-	if strings.Contains(response, "application/json") {
-		// Add deprecation warning to JSON response
-		c.Header("X-API-Deprecation-Warning", fmt.Sprintf("API version %s is deprecated and will be removed on %s. Please upgrade to v100.", version, versionInfo.SunsetDate))
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(body, &object); err != nil {
+		return body
 	}
+
+	encoded, err := json.Marshal(notice)
+	if err != nil {
+		return body
+	}
+	object["deprecation"] = encoded
+
+	rewritten, err := json.Marshal(object)
+	if err != nil {
+		return body
+	}
+	return rewritten
 }
 
 // GetSupportedVersions returns list of supported versions
 func (vm *VersionMiddleware) GetSupportedVersions() []string {
-	var versions []string
-	for version, info := range vm.supportedVersions {
-		if info.IsSupported {
-			versions = append(versions, version)
-		}
-	}
+	versions := append([]string(nil), vm.versionManager.GetSupportedVersions()...)
+	sort.Strings(versions)
 	return versions
 }
 
 // IsVersionDeprecated checks if a version is deprecated
 func (vm *VersionMiddleware) IsVersionDeprecated(version string) bool {
-	if info, exists := vm.supportedVersions[version]; exists {
-		return info.IsDeprecated
-	}
-	return false
+	return vm.versionManager.IsVersionDeprecated(version)
 }
 
-// GetVersionInfo returns version information
+// GetVersionInfo returns version information.
+// Versions that are neither supported nor deprecated are unknown.
 func (vm *VersionMiddleware) GetVersionInfo(version string) (VersionInfo, bool) {
-	info, exists := vm.supportedVersions[version]
-	return info, exists
+	info := VersionInfo{
+		Version:     version,
+		IsSupported: vm.versionManager.IsVersionSupported(version),
+	}
+
+	deprecation, deprecated := vm.versionManager.GetDeprecationInfo(version)
+	if deprecated {
+		info.IsDeprecated = true
+		info.SunsetDate = deprecation.SunsetDate.Format("2006-01-02")
+		info.UpgradeURL = deprecation.UpgradeURL
+	}
+
+	if !info.IsSupported && !deprecated {
+		return VersionInfo{}, false
+	}
+	return info, true
 }
 
 // UpdateVersionStatus updates the status of a version
 func (vm *VersionMiddleware) UpdateVersionStatus(version string, isDeprecated bool, sunsetDate string) {
-	if info, exists := vm.supportedVersions[version]; exists {
-		info.IsDeprecated = isDeprecated
-		info.SunsetDate = sunsetDate
-		vm.supportedVersions[version] = info
+	if !isDeprecated {
+		vm.versionManager.RemoveDeprecatedVersion(version)
+		return
 	}
+
+	sunset, _ := time.Parse("2006-01-02", sunsetDate)
+	vm.versionManager.AddDeprecatedVersion(version, sunset, fmt.Sprintf("API version %s is deprecated", version))
 }
 
 // AddVersion adds a new version to the supported versions
 func (vm *VersionMiddleware) AddVersion(version string, isDeprecated bool, sunsetDate string) {
-	vm.supportedVersions[version] = VersionInfo{
-		Version:      version,
-		IsSupported:  true,
-		IsDeprecated: isDeprecated,
-		SunsetDate:   sunsetDate,
-		UpgradeURL:   "https://docs.pandapocket.com/upgrade",
-	}
+	vm.versionManager.AddSupportedVersion(version)
+	vm.UpdateVersionStatus(version, isDeprecated, sunsetDate)
 }
 
 // RemoveVersion removes a version from supported versions
 func (vm *VersionMiddleware) RemoveVersion(version string) {
-	if info, exists := vm.supportedVersions[version]; exists {
-		info.IsSupported = false
-		vm.supportedVersions[version] = info
-	}
+	vm.versionManager.RemoveSupportedVersion(version)
 }
 
 // GetCurrentVersion returns the current/latest version
 func (vm *VersionMiddleware) GetCurrentVersion() string {
-	return "v100"
+	return vm.versionManager.GetCurrentVersion()
 }
 
 // GetDeprecatedVersions returns list of deprecated versions
 func (vm *VersionMiddleware) GetDeprecatedVersions() []string {
-	var versions []string
-	for version, info := range vm.supportedVersions {
-		if info.IsDeprecated {
-			versions = append(versions, version)
-		}
-	}
+	versions := vm.versionManager.GetDeprecatedVersions()
+	sort.Strings(versions)
 	return versions
 }
 
 // CheckSunsetDate checks if a version has reached its sunset date
 func (vm *VersionMiddleware) CheckSunsetDate(version string) bool {
-	if info, exists := vm.supportedVersions[version]; exists && info.SunsetDate != "" {
-		sunsetDate, err := time.Parse("2006-01-02", info.SunsetDate)
-		if err != nil {
+	return vm.versionManager.CheckSunsetDate(version)
+}
+
+// vendorMediaTypePrefix is the Accept media type prefix used to request a version,
+// e.g. application/vnd.pandapocket.v120+json
+const vendorMediaTypePrefix = "application/vnd.pandapocket."
+
+// Negotiate rewrites unversioned /api requests to a versioned route group.
+// The version is taken from the X-API-Version header, then the Accept header,
+// and falls back to the current version. Versioned URLs are left untouched.
+// It wraps the router because Gin matches routes before running middleware.
+func (vm *VersionMiddleware) Negotiate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rest, ok := strings.CutPrefix(r.URL.Path, "/api/")
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Already versioned, or a version management or back office route
+		segment, _, _ := strings.Cut(rest, "/")
+		if segment == "version" || segment == "admin" || vm.isVersionSegment(segment) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		version, source := vm.negotiateVersion(r)
+		if _, exists := vm.GetVersionInfo(version); !exists {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(gin.H{
+				"error":              "Unsupported API version",
+				"supported_versions": vm.GetSupportedVersions(),
+				"latest_version":     vm.GetCurrentVersion(),
+			})
+			return
+		}
+
+		w.Header().Add("Vary", "Accept, X-API-Version")
+		w.Header().Set("X-API-Version-Source", source)
+
+		r.URL.Path = "/api/" + version + "/" + rest
+		r.URL.RawPath = ""
+		next.ServeHTTP(w, r)
+	})
+}
+
+// negotiateVersion returns the requested version and where it came from
+func (vm *VersionMiddleware) negotiateVersion(r *http.Request) (string, string) {
+	if version := strings.TrimSpace(r.Header.Get("X-API-Version")); version != "" {
+		return strings.ToLower(version), "header"
+	}
+
+	for _, mediaType := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, _ = strings.Cut(strings.TrimSpace(mediaType), ";")
+		if rest, ok := strings.CutPrefix(strings.ToLower(mediaType), vendorMediaTypePrefix); ok {
+			if version, _, _ := strings.Cut(rest, "+"); version != "" {
+				return version, "accept"
+			}
+		}
+	}
+
+	return vm.GetCurrentVersion(), "default"
+}
+
+// isVersionSegment reports whether a path segment looks like a version, e.g. v120
+func (vm *VersionMiddleware) isVersionSegment(segment string) bool {
+	if len(segment) < 2 || segment[0] != 'v' {
+		return false
+	}
+	for _, r := range segment[1:] {
+		if r < '0' || r > '9' {
 			return false
 		}
-		return time.Now().After(sunsetDate)
 	}
-	return false
+	return true
 }
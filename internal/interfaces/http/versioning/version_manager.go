@@ -2,118 +2,266 @@ package versioning
 
 import (
 	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
-// VersionManager manages API versions and their lifecycle
+// VersionManager manages API versions and their lifecycle.
+// Reads use an immutable snapshot; writes copy the snapshot, swap it in and
+// persist it when a store is configured, so it is safe for concurrent use.
 type VersionManager struct {
-	currentVersion     string
-	supportedVersions  []string
-	deprecatedVersions map[string]DeprecationInfo
+	state      atomic.Pointer[VersionMatrix]
+	mu         sync.Mutex // serializes writers
+	store      VersionStore
+	configured VersionMatrix // the configured matrix, saved with the state
 }
 
 // DeprecationInfo contains information about deprecated versions
 type DeprecationInfo struct {
-	Version        string
-	SunsetDate     time.Time
-	WarningMessage string
-	UpgradeURL     string
+	Version        string    `json:"version"`
+	SunsetDate     time.Time `json:"sunset_date"`
+	WarningMessage string    `json:"warning_message"`
+	UpgradeURL     string    `json:"upgrade_url"`
 }
 
-// NewVersionManager creates a new version manager instance
+// DefaultUpgradeURL is the upgrade guide linked from deprecation notices
+const DefaultUpgradeURL = "https://docs.pandapocket.com/upgrade"
+
+// VersionMatrix describes which versions are served and which are deprecated
+type VersionMatrix struct {
+	Current    string            `json:"current"`
+	Supported  []string          `json:"supported"`
+	Deprecated []DeprecationInfo `json:"deprecated"`
+}
+
+// DefaultVersionMatrix returns the version matrix used when none is configured
+func DefaultVersionMatrix() VersionMatrix {
+	return VersionMatrix{
+		Current:   "v120",
+		Supported: []string{"v100", "v110", "v120"},
+	}
+}
+
+// clone returns a deep copy of the matrix
+func (m VersionMatrix) clone() VersionMatrix {
+	return VersionMatrix{
+		Current:    m.Current,
+		Supported:  slices.Clone(m.Supported),
+		Deprecated: slices.Clone(m.Deprecated),
+	}
+}
+
+// equal reports whether two deprecations are the same
+func (d DeprecationInfo) equal(other DeprecationInfo) bool {
+	return d.Version == other.Version && d.SunsetDate.Equal(other.SunsetDate) &&
+		d.WarningMessage == other.WarningMessage && d.UpgradeURL == other.UpgradeURL
+}
+
+// deprecation returns the deprecation info for a version
+func (m VersionMatrix) deprecation(version string) (DeprecationInfo, bool) {
+	for _, info := range m.Deprecated {
+		if info.Version == version {
+			return info, true
+		}
+	}
+	return DeprecationInfo{}, false
+}
+
+// NewVersionManager creates a new version manager instance with the default version matrix
 func NewVersionManager() *VersionManager {
-	return &VersionManager{
-		currentVersion:     "v100",
-		supportedVersions:  []string{"v100"},
-		deprecatedVersions: map[string]DeprecationInfo{},
+	return NewVersionManagerFromMatrix(DefaultVersionMatrix())
+}
+
+// NewVersionManagerFromMatrix creates a new version manager instance from a version matrix
+func NewVersionManagerFromMatrix(matrix VersionMatrix) *VersionManager {
+	vm := &VersionManager{}
+	vm.state.Store(normalizeMatrix(matrix))
+	return vm
+}
+
+// NewPersistentVersionManager creates a version manager backed by a store.
+// Changes made at runtime survive restarts, and so do changes to the given
+// configured matrix: those made since the state was saved are applied over it.
+func NewPersistentVersionManager(matrix VersionMatrix, store VersionStore) (*VersionManager, error) {
+	configured := *normalizeMatrix(matrix)
+	state, found, err := store.Load()
+	if err != nil {
+		return nil, err
 	}
+	if found {
+		matrix = mergeMatrix(*normalizeMatrix(state.Configured), configured, *normalizeMatrix(state.Matrix))
+	}
+
+	vm := NewVersionManagerFromMatrix(matrix)
+	vm.store, vm.configured = store, configured
+	if found {
+		// Save the new configured matrix, so its changes are not applied again
+		// over runtime changes made after this start
+		vm.update(func(m *VersionMatrix) {})
+	}
+	return vm, nil
 }
 
-// IsVersionSupported checks if a version is supported
-func (vm *VersionManager) IsVersionSupported(version string) bool {
-	for _, v := range vm.supportedVersions {
-		if v == version {
-			return true
+// mergeMatrix applies the configuration changes from base to configured over
+// the saved matrix, keeping the runtime changes the saved matrix holds
+func mergeMatrix(base, configured, saved VersionMatrix) VersionMatrix {
+	merged := saved.clone()
+	if configured.Current != base.Current {
+		merged.Current = configured.Current
+	}
+
+	for _, version := range configured.Supported {
+		if !slices.Contains(base.Supported, version) && !slices.Contains(merged.Supported, version) {
+			merged.Supported = append(merged.Supported, version)
 		}
 	}
-	return false
+	for _, version := range base.Supported {
+		if !slices.Contains(configured.Supported, version) {
+			merged.Supported = slices.DeleteFunc(merged.Supported, func(v string) bool { return v == version })
+		}
+	}
+
+	for _, info := range configured.Deprecated {
+		if before, ok := base.deprecation(info.Version); ok && before.equal(info) {
+			continue
+		}
+		merged.Deprecated = slices.DeleteFunc(merged.Deprecated, func(d DeprecationInfo) bool { return d.Version == info.Version })
+		merged.Deprecated = append(merged.Deprecated, info)
+	}
+	for _, info := range base.Deprecated {
+		if _, ok := configured.deprecation(info.Version); !ok {
+			merged.Deprecated = slices.DeleteFunc(merged.Deprecated, func(d DeprecationInfo) bool { return d.Version == info.Version })
+		}
+	}
+	return merged
+}
+
+// normalizeMatrix copies the matrix and fills in default upgrade URLs
+func normalizeMatrix(matrix VersionMatrix) *VersionMatrix {
+	normalized := matrix.clone()
+	for i := range normalized.Deprecated {
+		if normalized.Deprecated[i].UpgradeURL == "" {
+			normalized.Deprecated[i].UpgradeURL = DefaultUpgradeURL
+		}
+	}
+	return &normalized
+}
+
+// snapshot returns the current immutable state
+func (vm *VersionManager) snapshot() *VersionMatrix {
+	return vm.state.Load()
+}
+
+// update applies fn to a copy of the state, swaps it in and persists it
+func (vm *VersionManager) update(fn func(m *VersionMatrix)) {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
+	next := vm.snapshot().clone()
+	fn(&next)
+	vm.state.Store(&next)
+
+	if vm.store != nil {
+		if err := vm.store.Save(VersionState{Configured: vm.configured, Matrix: next}); err != nil {
+			slog.Error("failed to persist version matrix", "error", err.Error())
+		}
+	}
+}
+
+// Matrix returns a copy of the current version matrix
+func (vm *VersionManager) Matrix() VersionMatrix {
+	return vm.snapshot().clone()
+}
+
+// IsVersionSupported checks if a version is supported
+func (vm *VersionManager) IsVersionSupported(version string) bool {
+	return slices.Contains(vm.snapshot().Supported, version)
 }
 
 // IsVersionDeprecated checks if a version is deprecated
 func (vm *VersionManager) IsVersionDeprecated(version string) bool {
-	_, exists := vm.deprecatedVersions[version]
+	_, exists := vm.snapshot().deprecation(version)
 	return exists
 }
 
 // GetDeprecationInfo returns deprecation information for a version
 func (vm *VersionManager) GetDeprecationInfo(version string) (DeprecationInfo, bool) {
-	info, exists := vm.deprecatedVersions[version]
-	return info, exists
+	return vm.snapshot().deprecation(version)
 }
 
 // GetCurrentVersion returns the current/latest version
 func (vm *VersionManager) GetCurrentVersion() string {
-	return vm.currentVersion
+	return vm.snapshot().Current
 }
 
 // GetSupportedVersions returns list of supported versions
 func (vm *VersionManager) GetSupportedVersions() []string {
-	return vm.supportedVersions
+	return slices.Clone(vm.snapshot().Supported)
 }
 
 // GetDeprecatedVersions returns list of deprecated versions
 func (vm *VersionManager) GetDeprecatedVersions() []string {
 	var versions []string
-	for version := range vm.deprecatedVersions {
-		versions = append(versions, version)
+	for _, info := range vm.snapshot().Deprecated {
+		versions = append(versions, info.Version)
 	}
 	return versions
 }
 
 // AddDeprecatedVersion adds a version to the deprecated list
 func (vm *VersionManager) AddDeprecatedVersion(version string, sunsetDate time.Time, warningMessage string) {
-	vm.deprecatedVersions[version] = DeprecationInfo{
+	info := DeprecationInfo{
 		Version:        version,
 		SunsetDate:     sunsetDate,
 		WarningMessage: warningMessage,
-		UpgradeURL:     "https://docs.pandapocket.com/upgrade",
+		UpgradeURL:     DefaultUpgradeURL,
 	}
+
+	vm.update(func(m *VersionMatrix) {
+		m.Deprecated = slices.DeleteFunc(m.Deprecated, func(d DeprecationInfo) bool { return d.Version == version })
+		m.Deprecated = append(m.Deprecated, info)
+	})
 }
 
 // RemoveDeprecatedVersion removes a version from the deprecated list
 func (vm *VersionManager) RemoveDeprecatedVersion(version string) {
-	delete(vm.deprecatedVersions, version)
+	vm.update(func(m *VersionMatrix) {
+		m.Deprecated = slices.DeleteFunc(m.Deprecated, func(d DeprecationInfo) bool { return d.Version == version })
+	})
 }
 
 // UpdateCurrentVersion updates the current version
 func (vm *VersionManager) UpdateCurrentVersion(version string) {
-	vm.currentVersion = version
+	vm.update(func(m *VersionMatrix) {
+		m.Current = version
+	})
 }
 
 // AddSupportedVersion adds a version to the supported list
 func (vm *VersionManager) AddSupportedVersion(version string) {
-	// Check if version already exists
-	for _, v := range vm.supportedVersions {
-		if v == version {
-			return
-		}
+	if vm.IsVersionSupported(version) {
+		return
 	}
-	vm.supportedVersions = append(vm.supportedVersions, version)
+	vm.update(func(m *VersionMatrix) {
+		if !slices.Contains(m.Supported, version) {
+			m.Supported = append(m.Supported, version)
+		}
+	})
 }
 
 // RemoveSupportedVersion removes a version from the supported list
 func (vm *VersionManager) RemoveSupportedVersion(version string) {
-	for i, v := range vm.supportedVersions {
-		if v == version {
-			vm.supportedVersions = append(vm.supportedVersions[:i], vm.supportedVersions[i+1:]...)
-			break
-		}
-	}
+	vm.update(func(m *VersionMatrix) {
+		m.Supported = slices.DeleteFunc(m.Supported, func(v string) bool { return v == version })
+	})
 }
 
 // CheckSunsetDate checks if a version has reached its sunset date
 func (vm *VersionManager) CheckSunsetDate(version string) bool {
-	if info, exists := vm.deprecatedVersions[version]; exists {
+	if info, exists := vm.GetDeprecationInfo(version); exists {
 		return time.Now().After(info.SunsetDate)
 	}
 	return false
@@ -137,7 +285,7 @@ func (vm *VersionManager) GetVersionLifecycle(version string) map[string]interfa
 	lifecycle := map[string]interface{}{
 		"version": version,
 		"status":  status,
-		"current": version == vm.currentVersion,
+		"current": version == vm.GetCurrentVersion(),
 	}
 
 	if vm.IsVersionDeprecated(version) {
@@ -154,20 +302,21 @@ func (vm *VersionManager) GetVersionLifecycle(version string) map[string]interfa
 
 // GetVersionMatrix returns a matrix of all versions and their status
 func (vm *VersionManager) GetVersionMatrix() map[string]interface{} {
+	state := vm.snapshot()
 	matrix := map[string]interface{}{
-		"current_version":     vm.currentVersion,
-		"supported_versions":  vm.supportedVersions,
+		"current_version":     state.Current,
+		"supported_versions":  slices.Clone(state.Supported),
 		"deprecated_versions": vm.GetDeprecatedVersions(),
 		"versions":            make(map[string]interface{}),
 	}
 
 	// Add all versions to the matrix
 	allVersions := make(map[string]bool)
-	for _, v := range vm.supportedVersions {
+	for _, v := range state.Supported {
 		allVersions[v] = true
 	}
-	for v := range vm.deprecatedVersions {
-		allVersions[v] = true
+	for _, info := range state.Deprecated {
+		allVersions[info.Version] = true
 	}
 
 	for version := range allVersions {
@@ -198,12 +347,13 @@ func (vm *VersionManager) ValidateVersionTransition(fromVersion, toVersion strin
 
 // GetMigrationPath returns the recommended migration path for a version
 func (vm *VersionManager) GetMigrationPath(fromVersion string) []string {
-	if fromVersion == vm.currentVersion {
-		return []string{vm.currentVersion}
+	currentVersion := vm.GetCurrentVersion()
+	if fromVersion == currentVersion {
+		return []string{currentVersion}
 	}
 
 	// Simple migration path: go directly to current version
-	return []string{vm.currentVersion}
+	return []string{currentVersion}
 }
 
 // GetVersionFeatures returns the features available in a version
@@ -217,13 +367,18 @@ func (vm *VersionManager) GetVersionFeatures(version string) map[string]interfac
 
 	// Add version-specific features
 	switch version {
-	case "v100":
+	case "v100", "v110", "v120":
 		features["analytics"] = true
 		features["advanced_filtering"] = true
 		features["bulk_operations"] = true
 		features["export_functionality"] = true
 	}
 
+	// Features added in later versions
+	features["unified_transactions"] = version == "v110" || version == "v120"
+	features["transaction_analytics"] = version == "v110" || version == "v120"
+	features["version_metadata"] = version == "v120"
+
 	return features
 }
 
@@ -0,0 +1,140 @@
+package versioning
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sunset = time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// restart creates a persistent version manager over the state file in dir
+func restart(t *testing.T, dir string, configured VersionMatrix) *VersionManager {
+	t.Helper()
+	vm, err := NewPersistentVersionManager(configured, NewFileVersionStore(filepath.Join(dir, "versions.json")))
+	require.NoError(t, err)
+	return vm
+}
+
+func TestPersistentVersionManager(t *testing.T) {
+	t.Run("runtime changes survive a restart", func(t *testing.T) {
+		dir := t.TempDir()
+		vm := restart(t, dir, DefaultVersionMatrix())
+		vm.AddDeprecatedVersion("v100", sunset, "API version v100 is deprecated")
+		vm.RemoveSupportedVersion("v110")
+
+		vm = restart(t, dir, DefaultVersionMatrix())
+		assert.True(t, vm.IsVersionDeprecated("v100"))
+		assert.False(t, vm.IsVersionSupported("v110"))
+	})
+
+	t.Run("configuration changes are applied over runtime changes", func(t *testing.T) {
+		dir := t.TempDir()
+		configured := DefaultVersionMatrix()
+		configured.Deprecated = []DeprecationInfo{{Version: "v110", SunsetDate: sunset}}
+		vm := restart(t, dir, configured)
+		vm.AddDeprecatedVersion("v100", sunset, "API version v100 is deprecated")
+
+		later := sunset.AddDate(1, 0, 0)
+		configured = VersionMatrix{
+			Current:    "v130",
+			Supported:  []string{"v100", "v110", "v120", "v130"},
+			Deprecated: []DeprecationInfo{{Version: "v110", SunsetDate: later}},
+		}
+		vm = restart(t, dir, configured)
+
+		assert.Equal(t, "v130", vm.GetCurrentVersion())
+		assert.True(t, vm.IsVersionSupported("v130"))
+		assert.True(t, vm.IsVersionDeprecated("v100"))
+		info, ok := vm.GetDeprecationInfo("v110")
+		require.True(t, ok)
+		assert.True(t, info.SunsetDate.Equal(later))
+	})
+
+	t.Run("versions dropped from the configuration are dropped", func(t *testing.T) {
+		dir := t.TempDir()
+		configured := DefaultVersionMatrix()
+		configured.Deprecated = []DeprecationInfo{{Version: "v100", SunsetDate: sunset}}
+		restart(t, dir, configured)
+
+		vm := restart(t, dir, VersionMatrix{Current: "v120", Supported: []string{"v110", "v120"}})
+		assert.False(t, vm.IsVersionSupported("v100"))
+		assert.False(t, vm.IsVersionDeprecated("v100"))
+	})
+
+	t.Run("configuration changes are applied once", func(t *testing.T) {
+		dir := t.TempDir()
+		restart(t, dir, DefaultVersionMatrix())
+
+		configured := DefaultVersionMatrix()
+		configured.Supported = append(configured.Supported, "v130")
+		vm := restart(t, dir, configured)
+		vm.RemoveSupportedVersion("v130")
+
+		vm = restart(t, dir, configured)
+		assert.False(t, vm.IsVersionSupported("v130"))
+	})
+
+	t.Run("a saved bare matrix is read with the configuration applied over it", func(t *testing.T) {
+		dir := t.TempDir()
+		state := `{"current": "v110", "supported": ["v100", "v110"], "deprecated": []}`
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "versions.json"), []byte(state), 0o600))
+
+		vm := restart(t, dir, DefaultVersionMatrix())
+		assert.Equal(t, "v120", vm.GetCurrentVersion())
+		assert.Equal(t, []string{"v100", "v110", "v120"}, vm.GetSupportedVersions())
+	})
+}
+
+func TestVersionManagerConcurrentAccess(t *testing.T) {
+	dir := t.TempDir()
+	vm := restart(t, dir, DefaultVersionMatrix())
+
+	const writers = 8
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				vm.IsVersionSupported("v120")
+				vm.GetVersionStatus("v100")
+				matrix := vm.Matrix()
+				// A snapshot is never seen half written
+				assert.GreaterOrEqual(t, len(matrix.Supported), 3)
+			}
+		}()
+	}
+
+	var writersDone sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		writersDone.Add(1)
+		go func(i int) {
+			defer writersDone.Done()
+			version := fmt.Sprintf("v2%02d", i)
+			vm.AddSupportedVersion(version)
+			vm.AddDeprecatedVersion(version, sunset, "")
+		}(i)
+	}
+	writersDone.Wait()
+	close(stop)
+	wg.Wait()
+
+	// No update was lost, in memory or in the state file
+	for _, current := range []*VersionManager{vm, restart(t, dir, DefaultVersionMatrix())} {
+		assert.Len(t, current.GetSupportedVersions(), 3+writers)
+		assert.Len(t, current.GetDeprecatedVersions(), writers)
+	}
+}
@@ -0,0 +1,82 @@
+package versioning
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// VersionState is what a VersionStore keeps: the version matrix in effect and the
+// configured matrix it started from, so later configuration changes can be told
+// apart from changes made at runtime
+type VersionState struct {
+	Configured VersionMatrix `json:"configured"`
+	Matrix     VersionMatrix `json:"matrix"`
+}
+
+// VersionStore persists the version matrix across restarts
+type VersionStore interface {
+	// Load returns the saved state, or found=false when nothing has been saved
+	Load() (state VersionState, found bool, err error)
+	Save(state VersionState) error
+}
+
+// FileVersionStore keeps the version matrix in a JSON file
+type FileVersionStore struct {
+	path string
+}
+
+// NewFileVersionStore creates a new file-backed version store
+func NewFileVersionStore(path string) *FileVersionStore {
+	return &FileVersionStore{path: path}
+}
+
+// Load reads the version state from the file. Files holding only a matrix, as
+// written before the configured matrix was saved with it, are read as a matrix
+// with no configured matrix, so the configuration is applied over it in full.
+func (s *FileVersionStore) Load() (VersionState, bool, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return VersionState{}, false, nil
+	}
+	if err != nil {
+		return VersionState{}, false, fmt.Errorf("failed to read version state %s: %w", s.path, err)
+	}
+
+	var state VersionState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return VersionState{}, false, fmt.Errorf("failed to parse version state %s: %w", s.path, err)
+	}
+	if state.Matrix.Current == "" {
+		if err := json.Unmarshal(data, &state.Matrix); err != nil {
+			return VersionState{}, false, fmt.Errorf("failed to parse version state %s: %w", s.path, err)
+		}
+	}
+	return state, true, nil
+}
+
+// Save writes the version state to the file atomically
+func (s *FileVersionStore) Save(state VersionState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file and rename so readers never see a partial file
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
@@ -0,0 +1,99 @@
+// Package testsupport provides databases, fixtures and HTTP helpers for
+// integration tests, so they run without a preconfigured database.
+package testsupport
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"panda-pocket/internal/infrastructure/config"
+	"panda-pocket/internal/infrastructure/database"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// postgresImage is the Postgres version integration tests run against
+const postgresImage = "postgres:16-alpine"
+
+// NewDatabase returns a migrated database holding only the default data, private
+// to one test. It is an in-memory SQLite database unless TEST_DB=postgres, in which
+// case a throwaway Postgres container is started (Docker required) and removed when
+// the test ends.
+func NewDatabase(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	cfg := config.Default().Database
+	cfg.AutoMigrate = true
+
+	switch engine := os.Getenv("TEST_DB"); engine {
+	case "", "sqlite":
+		// One connection keeps every query on the same in-memory database
+		cfg.Type = "sqlite"
+		cfg.Path = ":memory:"
+	case "postgres":
+		startPostgres(t, &cfg)
+	default:
+		t.Fatalf("unsupported TEST_DB %q, use sqlite or postgres", engine)
+	}
+
+	db, err := database.InitDB(cfg)
+	if err != nil {
+		t.Fatalf("failed to initialize test database: %v", err)
+	}
+	db.Logger = logger.Discard
+
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// startPostgres runs a Postgres container for the test and points cfg at it
+func startPostgres(t testing.TB, cfg *config.DatabaseConfig) {
+	t.Helper()
+	ctx := context.Background()
+
+	container, err := postgres.Run(ctx, postgresImage,
+		postgres.WithDatabase("panda_pocket_test"),
+		postgres.WithUsername("panda"),
+		postgres.WithPassword("panda"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(time.Minute),
+		),
+	)
+	if err != nil {
+		t.Fatalf("failed to start postgres container: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(context.Background()); err != nil {
+			t.Logf("failed to remove postgres container: %v", err)
+		}
+	})
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		t.Fatalf("failed to get postgres host: %v", err)
+	}
+	port, err := container.MappedPort(ctx, "5432/tcp")
+	if err != nil {
+		t.Fatalf("failed to get postgres port: %v", err)
+	}
+
+	cfg.Type = "postgres"
+	cfg.Host = host
+	cfg.Port = port.Port()
+	cfg.User = "panda"
+	cfg.Password = "panda"
+	cfg.Name = "panda_pocket_test"
+	cfg.SSLMode = "disable"
+}
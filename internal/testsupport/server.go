@@ -0,0 +1,100 @@
+package testsupport
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"panda-pocket/internal/application"
+	appIdentity "panda-pocket/internal/application/identity"
+	"panda-pocket/internal/infrastructure/config"
+	"panda-pocket/internal/infrastructure/database"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Server runs the full HTTP stack against a test database without a network listener
+type Server struct {
+	App     *application.App
+	handler http.Handler
+}
+
+// NewServer wires the application on db with the default configuration,
+// with rate limiting disabled so tests can send any number of requests
+func NewServer(t testing.TB, db *gorm.DB) *Server {
+	t.Helper()
+	return NewServerWithConfig(t, db, func(*config.Config) {})
+}
+
+// NewServerWithConfig is NewServer with the configuration changed by configure
+// before the application is wired
+func NewServerWithConfig(t testing.TB, db *gorm.DB, configure func(*config.Config)) *Server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	cfg := config.Default()
+	cfg.Server.Mode = "test"
+	cfg.RateLimit.Enabled = false
+	configure(cfg)
+
+	app, err := application.NewApp(db, cfg)
+	if err != nil {
+		t.Fatalf("failed to create application: %v", err)
+	}
+	return &Server{App: app, handler: app.Handler()}
+}
+
+// Token issues a JWT for a user, as returned by login
+func (s *Server) Token(t testing.TB, user database.User) string {
+	t.Helper()
+
+	auth := s.App.Config.Auth
+	token, err := appIdentity.NewTokenService(auth.JWTSecret, auth.JWTExpiry).GenerateToken(int(user.ID), user.Email, user.Role)
+	if err != nil {
+		t.Fatalf("failed to issue token: %v", err)
+	}
+	return token
+}
+
+// Do sends a request, JSON-encoding body when it is not nil, with the token as a
+// bearer credential when it is not empty
+func (s *Server) Do(t testing.TB, method, path, token string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			t.Fatalf("failed to encode request body: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(method, path, &payload)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	w := httptest.NewRecorder()
+	s.handler.ServeHTTP(w, req)
+	return w
+}
+
+// DecodeData decodes the data field of a success response into v
+func DecodeData(t testing.TB, w *httptest.ResponseRecorder, v interface{}) {
+	t.Helper()
+
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("failed to decode response %s: %v", w.Body.String(), err)
+	}
+	if err := json.Unmarshal(envelope.Data, v); err != nil {
+		t.Fatalf("failed to decode response data %s: %v", envelope.Data, err)
+	}
+}
@@ -1,14 +1,32 @@
 package main
 
 import (
+	"context"
 	"log"
+	"log/slog"
+	"net/http"
 	"panda-pocket/internal/application"
+	"panda-pocket/internal/infrastructure/config"
 	"panda-pocket/internal/infrastructure/database"
+	"panda-pocket/internal/infrastructure/logging"
+	"time"
+
+	"github.com/gin-gonic/gin"
 )
 
 func main() {
+	// Use structured JSON logging everywhere
+	slog.SetDefault(logging.NewLogger())
+
+	// Load configuration from defaults, optional config file and environment
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Failed to load configuration:", err)
+	}
+	gin.SetMode(cfg.Server.Mode)
+
 	// Initialize database with GORM
-	db, err := database.InitDB()
+	db, err := database.InitDB(cfg.Database)
 	if err != nil {
 		log.Fatal("Failed to initialize database:", err)
 	}
@@ -21,11 +39,67 @@ func main() {
 	defer sqlDB.Close()
 
 	// Create application with all dependencies
-	app := application.NewApp(db)
+	app, err := application.NewApp(db, cfg)
+	if err != nil {
+		log.Fatal("Failed to create application:", err)
+	}
+
+	// Persist API version usage counters in the background
+	go app.VersionUsageTracker.Run(context.Background(), time.Minute)
+
+	// Deliver domain events from the outbox to subscribers, retrying failed deliveries
+	go app.EventBus.Run(context.Background(), time.Second)
+
+	// Catch the analytics read model up with changes that raised no events
+	go app.AnalyticsProjector.Run(context.Background(), time.Hour)
+
+	// Send queued emails, retrying failed deliveries
+	go app.EmailQueue.Run(context.Background(), 30*time.Second)
+
+	// Move old transactions to the archive once a day
+	if cfg.Archive.AfterYears > 0 {
+		go app.ArchiveTransactions.Run(context.Background(), 24*time.Hour)
+	}
 
-	// Setup routes
-	router := app.SetupRoutes()
+	// Run due transaction exports to cloud storage
+	go app.ScheduledExports.Run(context.Background(), 15*time.Minute)
 
-	log.Println("Server starting on :8080")
-	router.Run(":8080")
+	// Post scheduled transactions once their date comes
+	go app.PostScheduled.Run(context.Background(), time.Hour)
+
+	// Post the occurrences of recurring transactions once their date comes
+	go app.PostRecurring.Run(context.Background(), time.Hour)
+
+	// Export transaction changes to the data warehouse once a day
+	if app.WarehouseExport != nil {
+		go app.WarehouseExport.Run(context.Background(), 24*time.Hour)
+	}
+
+	// Purge undo log entries past their retention once a day
+	go app.PurgeTrash.Run(context.Background(), 24*time.Hour)
+
+	// Remind users of upcoming recurring transactions and credit card payments
+	if cfg.Reminders.DaysAhead > 0 {
+		go app.RecurringReminders.Run(context.Background(), time.Hour)
+		go app.CardPaymentReminders.Run(context.Background(), time.Hour)
+	}
+
+	// Value investment holdings at the latest market prices once a day
+	if cfg.Prices.Provider != "" {
+		go app.HoldingPrices.Run(context.Background(), 24*time.Hour)
+	}
+
+	// Flag unusual spending at the sensitivity each user chose
+	go app.AnomalyDetection.Run(context.Background(), time.Hour)
+
+	// Scheduled full database backups
+	if cfg.Backup.Interval > 0 {
+		go app.BackupService.Run(context.Background(), cfg.Backup.Interval, cfg.Backup.Retain)
+	}
+
+	addr := ":" + cfg.Server.Port
+	log.Println("Server starting on " + addr)
+	if err := http.ListenAndServe(addr, app.Handler()); err != nil {
+		log.Fatal("Server stopped:", err)
+	}
 }
@@ -6,29 +6,20 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
-	"panda-pocket/internal/application"
-	"panda-pocket/internal/infrastructure/database"
 	"panda-pocket/internal/interfaces/http/handlers"
 	"panda-pocket/internal/interfaces/http/middleware"
 	"panda-pocket/internal/interfaces/http/versioning"
+	"panda-pocket/internal/testsupport"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestAPIVersioning(t *testing.T) {
-	// Setup test database
-	db, err := database.InitDB()
-	if err != nil {
-		t.Fatalf("Failed to initialize database: %v", err)
-	}
-
-	// Create application
-	app := application.NewApp(db)
-
-	// Setup routes
-	router := app.SetupRoutes()
+	// Create application on a throwaway test database
+	server := testsupport.NewServer(t, testsupport.NewDatabase(t))
 
 	// Test cases
 	tests := []struct {
@@ -53,8 +44,8 @@ func TestAPIVersioning(t *testing.T) {
 			path:           "/api/transactions",
 			expectedStatus: http.StatusUnauthorized, // No auth token
 			expectedHeaders: map[string]string{
-				"X-API-Version": "v100",
-				"X-API-Latest":  "v100",
+				"X-API-Version":        "v120",
+				"X-API-Version-Source": "default",
 			},
 		},
 		{
@@ -73,15 +64,8 @@ func TestAPIVersioning(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Create request
-			req, err := http.NewRequest(tt.method, tt.path, nil)
-			assert.NoError(t, err)
-
-			// Create response recorder
-			w := httptest.NewRecorder()
-
 			// Serve request
-			router.ServeHTTP(w, req)
+			w := server.Do(t, tt.method, tt.path, "", nil)
 
 			// Check status code
 			assert.Equal(t, tt.expectedStatus, w.Code, "Status code mismatch")
@@ -106,7 +90,7 @@ func TestVersionMiddleware(t *testing.T) {
 	router := gin.New()
 
 	// Add version middleware
-	versionMiddleware := middleware.NewVersionMiddleware()
+	versionMiddleware := middleware.NewVersionMiddleware(versioning.NewVersionManager())
 	router.Use(versionMiddleware.ExtractVersion())
 
 	// Add test route
@@ -135,12 +119,15 @@ func TestDeprecationHandler(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 
+	// Deprecate v100 for this test
+	versionManager := versioning.NewVersionManager()
+	versionManager.AddDeprecatedVersion("v100", time.Date(2099, 6, 1, 0, 0, 0, 0, time.UTC), "API version v100 is deprecated")
+
 	// Add version middleware
-	versionMiddleware := middleware.NewVersionMiddleware()
+	versionMiddleware := middleware.NewVersionMiddleware(versionManager)
 	router.Use(versionMiddleware.ExtractVersion())
 
 	// Add deprecation handler
-	versionManager := versioning.NewVersionManager()
 	deprecationHandler := handlers.NewDeprecationHandler(versionManager)
 	router.Use(deprecationHandler.HandleDeprecatedVersion)
 
@@ -157,24 +144,81 @@ func TestDeprecationHandler(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Equal(t, "true", w.Header().Get("X-API-Deprecated"))
-	assert.Equal(t, "2024-06-01", w.Header().Get("X-API-Sunset-Date"))
+	assert.Equal(t, "2099-06-01", w.Header().Get("X-API-Sunset-Date"))
 	assert.Equal(t, "https://docs.pandapocket.com/upgrade", w.Header().Get("X-API-Upgrade-URL"))
 }
 
+func TestDeprecationBodyWarning(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	// Deprecate v100 for this test
+	versionManager := versioning.NewVersionManager()
+	versionManager.AddDeprecatedVersion("v100", time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC), "API version v100 is deprecated")
+
+	versionMiddleware := middleware.NewVersionMiddleware(versionManager)
+	router.Use(versionMiddleware.ExtractVersion())
+	router.Use(versionMiddleware.AddDeprecationWarning())
+
+	router.GET("/api/v100/test", func(c *gin.Context) {
+		handlers.SuccessResponse(c, http.StatusOK, gin.H{"message": "test"})
+	})
+	router.GET("/api/v100/fail", func(c *gin.Context) {
+		handlers.BadRequestResponse(c, "TEST_ERROR", "test error")
+	})
+	router.GET("/api/v120/test", func(c *gin.Context) {
+		handlers.SuccessResponse(c, http.StatusOK, gin.H{"message": "test"})
+	})
+
+	// Deprecated version gets a deprecation object in the body
+	req, _ := http.NewRequest("GET", "/api/v100/test", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "success", response["status"])
+	deprecation, ok := response["deprecation"].(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, "v100", deprecation["version"])
+	assert.Equal(t, "2099-01-01", deprecation["sunset_date"])
+	assert.Equal(t, "v120", deprecation["latest_version"])
+
+	// Error responses are left untouched
+	req, _ = http.NewRequest("GET", "/api/v100/fail", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	response = map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.NotContains(t, response, "deprecation")
+
+	// Current version is left untouched
+	req, _ = http.NewRequest("GET", "/api/v120/test", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	response = map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.NotContains(t, response, "deprecation")
+}
+
 func TestVersionManager(t *testing.T) {
 	vm := versioning.NewVersionManager()
 
 	// Test supported versions
 	assert.True(t, vm.IsVersionSupported("v100"))
-	assert.False(t, vm.IsVersionSupported("v120"))
-	assert.False(t, vm.IsVersionSupported("v110"))
+	assert.True(t, vm.IsVersionSupported("v120"))
+	assert.True(t, vm.IsVersionSupported("v110"))
 	assert.False(t, vm.IsVersionSupported("v090"))
 
 	// Test deprecated versions
 	assert.False(t, vm.IsVersionDeprecated("v100"))
 
 	// Test current version
-	assert.Equal(t, "v100", vm.GetCurrentVersion())
+	assert.Equal(t, "v120", vm.GetCurrentVersion())
 
 	// Test supported versions list
 	supportedVersions := vm.GetSupportedVersions()
@@ -199,13 +243,16 @@ func TestMigrationPath(t *testing.T) {
 	// Test migration path
 	migrationPath := vm.GetMigrationPath("v100")
 	assert.NotNil(t, migrationPath)
-	assert.Contains(t, migrationPath, "v100")
+	assert.Contains(t, migrationPath, "v120")
 
 	// Test version transition validation
 	err := vm.ValidateVersionTransition("v100", "v100")
 	assert.NoError(t, err)
 
 	err = vm.ValidateVersionTransition("v100", "v120")
+	assert.NoError(t, err)
+
+	err = vm.ValidateVersionTransition("v100", "v090")
 	assert.Error(t, err)
 }
 
@@ -214,7 +261,7 @@ func BenchmarkVersionMiddleware(b *testing.B) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 
-	versionMiddleware := middleware.NewVersionMiddleware()
+	versionMiddleware := middleware.NewVersionMiddleware(versioning.NewVersionManager())
 	router.Use(versionMiddleware.ExtractVersion())
 
 	router.GET("/api/v100/test", func(c *gin.Context) {